@@ -0,0 +1,311 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/alexcollie/kaizen/internal"
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/alexcollie/kaizen/pkg/analyzer"
+	"github.com/alexcollie/kaizen/pkg/churn"
+	"github.com/alexcollie/kaizen/pkg/languages"
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/alexcollie/kaizen/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	backfillPath      string
+	backfillEvery     int
+	backfillLimit     int
+	backfillChurnDays int
+	backfillSkipChurn bool
+)
+
+var backfillCmd = &cobra.Command{
+	Use:   "backfill",
+	Short: "Analyze past commits to seed the snapshot history",
+	Long: `Walks back through the current branch's first-parent history, checks every
+--every'th commit out into a temporary git worktree, analyzes it, and saves a
+snapshot stamped with that commit's date and hash. Up to --limit commits are
+analyzed, oldest first, so trend charts have history right away instead of
+after weeks of CI runs.
+
+Every commit is analyzed with the .kaizen.yaml of the current checkout, so the
+snapshots are comparable with each other and with new ones. Churn covers the
+--churn-days before each commit. Commits that already have a snapshot are
+skipped, so backfill can be re-run to extend the history. A commit that cannot
+be checked out or analyzed is reported and skipped.`,
+	Run: runBackfill,
+}
+
+// backfillCommit is a commit from the branch history considered for backfilling
+type backfillCommit struct {
+	Hash        string
+	CommittedAt time.Time
+	Stored      bool // A snapshot for this commit is already saved
+}
+
+func init() {
+	backfillCmd.Flags().StringVarP(&backfillPath, "path", "p", ".", "Path to analyze (a repository or a directory inside one)")
+	backfillCmd.Flags().IntVar(&backfillEvery, "every", 10, "Analyze every Nth commit, starting with HEAD")
+	backfillCmd.Flags().IntVar(&backfillLimit, "limit", 50, "Maximum number of commits to analyze")
+	backfillCmd.Flags().IntVar(&backfillChurnDays, "churn-days", 90, "Days of history before each commit counted as churn")
+	backfillCmd.Flags().BoolVar(&backfillSkipChurn, "skip-churn", false, "Skip churn analysis (much faster on long histories)")
+}
+
+func runBackfill(cmd *cobra.Command, args []string) {
+	if backfillEvery < 1 || backfillLimit < 1 {
+		fmt.Fprintf(os.Stderr, "Error: --every and --limit must be at least 1\n")
+		os.Exit(1)
+	}
+
+	absolutePath, err := filepath.Abs(backfillPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not resolve %s: %v\n", backfillPath, err)
+		os.Exit(1)
+	}
+
+	if _, err := gitOutput(absolutePath, "rev-parse", "--git-dir"); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s is not inside a git repository\n", backfillPath)
+		os.Exit(1)
+	}
+	repositoryRoot := snapshotRepository(absolutePath)
+	relativePath, err := filepath.Rel(repositoryRoot, absolutePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not resolve %s within %s: %v\n", backfillPath, repositoryRoot, err)
+		os.Exit(1)
+	}
+
+	logOutput, err := gitOutput(repositoryRoot, "log", "--first-parent", "--format=%H %cI",
+		fmt.Sprintf("--max-count=%d", backfillEvery*backfillLimit))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not read the commit history: %v\n", err)
+		os.Exit(1)
+	}
+	history, err := parseBackfillLog(logOutput)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	cfg, err := config.LoadConfig(absolutePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not load config: %v\n", err)
+		cfg = config.DefaultConfig()
+	}
+
+	dbPath, err := locateDatabase(absolutePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not locate database: %v\n", err)
+		os.Exit(1)
+	}
+	backend, err := storage.NewBackend(storage.BackendConfig{
+		Type:       "sqlite",
+		Path:       dbPath,
+		Repository: repositoryRoot,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not open database: %v\n", err)
+		os.Exit(1)
+	}
+	defer func() { _ = backend.Close() }()
+
+	commits := selectBackfillCommits(history, backfillEvery, backfillLimit)
+	markStoredCommits(backend, commits)
+
+	fmt.Printf("⏪ Kaizen Backfill: %s\n\n", absolutePath)
+	fmt.Printf("Analyzing %d of the last %d commit(s), every %d\n\n", len(commits), len(history), backfillEvery)
+
+	saved, skipped, failed := 0, 0, 0
+	for index, commit := range commits {
+		fmt.Printf("[%d/%d] %s %s ", index+1, len(commits), shortHash(commit.Hash), commit.CommittedAt.Format("2006-01-02"))
+
+		if commit.Stored {
+			fmt.Printf("– already stored\n")
+			skipped++
+			continue
+		}
+
+		snapshotID, err := backfillCommitSnapshot(backend, cfg, repositoryRoot, relativePath, commit)
+		if err != nil {
+			fmt.Printf("✗\n")
+			fmt.Fprintf(os.Stderr, "  Warning: %v\n", err)
+			failed++
+			continue
+		}
+		fmt.Printf("✓ snapshot #%d\n", snapshotID)
+		saved++
+	}
+
+	fmt.Printf("\n💾 Saved %d snapshot(s)", saved)
+	if skipped > 0 {
+		fmt.Printf(", %d already stored", skipped)
+	}
+	if failed > 0 {
+		fmt.Printf(", %d failed", failed)
+	}
+	fmt.Printf("\n")
+
+	if saved > 0 {
+		fmt.Printf("\nNext steps:\n")
+		fmt.Printf("  kaizen trend overall_score\n")
+	}
+}
+
+// parseBackfillLog parses git log --format="%H %cI" output, newest commit first
+func parseBackfillLog(output string) ([]backfillCommit, error) {
+	var commits []backfillCommit
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("unexpected git log line %q", line)
+		}
+		committedAt, err := time.Parse(time.RFC3339, fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("could not parse the commit date of %s: %w", fields[0], err)
+		}
+
+		commits = append(commits, backfillCommit{Hash: fields[0], CommittedAt: committedAt})
+	}
+	return commits, nil
+}
+
+// selectBackfillCommits picks every Nth commit of history (newest first), starting with the
+// newest, up to limit commits, and returns them oldest first so snapshots are saved in the
+// order they were committed
+func selectBackfillCommits(history []backfillCommit, every, limit int) []backfillCommit {
+	var selected []backfillCommit
+	for index := 0; index < len(history) && len(selected) < limit; index += every {
+		selected = append(selected, history[index])
+	}
+
+	for left, right := 0, len(selected)-1; left < right; left, right = left+1, right-1 {
+		selected[left], selected[right] = selected[right], selected[left]
+	}
+	return selected
+}
+
+// markStoredCommits flags the commits that already have a snapshot in backend
+func markStoredCommits(backend storage.StorageBackend, commits []backfillCommit) {
+	snapshots, err := backend.ListSnapshots(0)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not list stored snapshots: %v\n", err)
+		return
+	}
+
+	stored := make(map[string]bool, len(snapshots))
+	for _, snapshot := range snapshots {
+		if snapshot.GitCommitHash != "" {
+			stored[snapshot.GitCommitHash] = true
+		}
+	}
+	for index := range commits {
+		commits[index].Stored = stored[commits[index].Hash]
+	}
+}
+
+// backfillCommitSnapshot checks commit out into a temporary worktree, analyzes relativePath
+// within it, and saves the result as a snapshot dated at the commit. The worktree is removed
+// afterwards whether or not the analysis succeeds.
+func backfillCommitSnapshot(backend storage.StorageBackend, cfg *config.Config, repositoryRoot, relativePath string, commit backfillCommit) (int64, error) {
+	worktreeParent, err := os.MkdirTemp("", "kaizen-backfill-")
+	if err != nil {
+		return 0, fmt.Errorf("could not create a temporary directory: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(worktreeParent) }()
+
+	worktree := filepath.Join(worktreeParent, "worktree")
+	if _, err := gitOutput(repositoryRoot, "worktree", "add", "--detach", worktree, commit.Hash); err != nil {
+		return 0, fmt.Errorf("could not check out %s: %w", shortHash(commit.Hash), err)
+	}
+	defer func() {
+		_, _ = gitOutput(repositoryRoot, "worktree", "remove", "--force", worktree)
+	}()
+
+	analyzedPath := filepath.Join(worktree, relativePath)
+	if _, err := os.Stat(analyzedPath); err != nil {
+		return 0, fmt.Errorf("%s does not exist at %s", relativePath, shortHash(commit.Hash))
+	}
+
+	options := analyzer.AnalysisOptions{
+		RootPath:                   analyzedPath,
+		Since:                      commit.CommittedAt.AddDate(0, 0, -backfillChurnDays),
+		IncludeLanguages:           cfg.Analysis.Languages,
+		ExcludePatterns:            cfg.GetExcludePatterns(),
+		ExcludeDirs:                cfg.Analysis.ExcludeDirs,
+		IncludeChurn:               !(backfillSkipChurn || cfg.Analysis.SkipChurn),
+		MaxWorkers:                 cfg.Analysis.MaxWorkers,
+		MaxFileSize:                cfg.Analysis.MaxFileSize,
+		SkipGenerated:              cfg.Analysis.SkipGenerated,
+		MIVariant:                  cfg.Analysis.MIVariant,
+		CountStdlibCalls:           cfg.Analysis.CountStdlibCalls,
+		CountAnonymousFunctions:    cfg.Analysis.CountAnonymousFunctions,
+		ChurnMetric:                cfg.Analysis.ChurnMetric,
+		PathStyle:                  config.PathStyleRelative, // Absolute paths would point into the temporary worktree
+		MinFunctionLines:           cfg.Analysis.MinFunctionLines,
+		ExcludeTrivialFromAverages: cfg.Analysis.ExcludeTrivialFromAverages,
+		AverageMethod:              cfg.Analysis.AverageMethod,
+		TimeoutPerFile:             cfg.Analysis.TimeoutPerFile,
+		Thresholds:                 cfg.Thresholds,
+		Scoring:                    cfg.Scoring,
+		Reports:                    cfg.Reports,
+	}
+
+	// The worktree's HEAD is the commit, so churn only sees history up to it
+	pipeline := analyzer.NewPipeline(languages.NewRegistry(), churn.NewGitChurnAnalyzer(analyzedPath), analyzer.NewAggregator())
+	result, err := pipeline.Analyze(options)
+	if err != nil {
+		return 0, fmt.Errorf("could not analyze %s: %w", shortHash(commit.Hash), err)
+	}
+	stampBackfillResult(result, repositoryRoot, commit)
+
+	snapshotID, err := backend.Save(result, storage.SnapshotMetadata{
+		GitCommitHash: commit.Hash,
+		KaizenVersion: internal.Version,
+		Note:          "backfill",
+		Repository:    repositoryRoot,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("could not save the snapshot for %s: %w", shortHash(commit.Hash), err)
+	}
+	return snapshotID, nil
+}
+
+// stampBackfillResult dates result at commit instead of now and points its relative file paths
+// at the repository rather than the temporary worktree it was analyzed in
+func stampBackfillResult(result *models.AnalysisResult, repositoryRoot string, commit backfillCommit) {
+	result.AnalyzedAt = commit.CommittedAt
+	result.TimeRange.Until = commit.CommittedAt
+	result.Repository = repositoryRoot
+}
+
+// gitOutput runs git in dir and returns its trimmed output; errors include git's stderr
+func gitOutput(dir string, args ...string) (string, error) {
+	command := exec.Command("git", args...)
+	command.Dir = dir
+	output, err := command.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// shortHash abbreviates a commit hash for progress output
+func shortHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
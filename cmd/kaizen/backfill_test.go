@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseBackfillLog(t *testing.T) {
+	output := "aaaa1111 2026-03-02T10:00:00+01:00\nbbbb2222 2026-03-01T09:30:00Z\n"
+
+	commits, err := parseBackfillLog(output)
+	if err != nil {
+		t.Fatalf("parseBackfillLog returned error: %v", err)
+	}
+	if len(commits) != 2 || commits[0].Hash != "aaaa1111" || commits[1].Hash != "bbbb2222" {
+		t.Fatalf("Expected both commits newest first, got %+v", commits)
+	}
+	if expected := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC); !commits[0].CommittedAt.Equal(expected) {
+		t.Errorf("Expected the first commit at %s, got %s", expected, commits[0].CommittedAt)
+	}
+
+	if _, err := parseBackfillLog("aaaa1111 yesterday\n"); err == nil {
+		t.Error("parseBackfillLog should reject an unparseable date")
+	}
+}
+
+func TestSelectBackfillCommits(t *testing.T) {
+	var history []backfillCommit
+	for _, hash := range []string{"h0", "h1", "h2", "h3", "h4", "h5", "h6"} {
+		history = append(history, backfillCommit{Hash: hash})
+	}
+
+	selected := selectBackfillCommits(history, 3, 10)
+	var hashes []string
+	for _, commit := range selected {
+		hashes = append(hashes, commit.Hash)
+	}
+	if len(hashes) != 3 || hashes[0] != "h6" || hashes[1] != "h3" || hashes[2] != "h0" {
+		t.Errorf("Expected every third commit oldest first [h6 h3 h0], got %v", hashes)
+	}
+
+	limited := selectBackfillCommits(history, 1, 2)
+	if len(limited) != 2 || limited[0].Hash != "h1" || limited[1].Hash != "h0" {
+		t.Errorf("Expected the two newest commits oldest first, got %+v", limited)
+	}
+}
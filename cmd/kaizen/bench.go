@@ -0,0 +1,241 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/alexcollie/kaizen/pkg/analyzer"
+	"github.com/alexcollie/kaizen/pkg/churn"
+	"github.com/alexcollie/kaizen/pkg/languages"
+	"github.com/spf13/cobra"
+)
+
+var (
+	benchPath      string
+	benchSince     string
+	benchSkipChurn bool
+	benchTop       int
+)
+
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Time each phase of an analysis run",
+	Long: `Runs a full analysis with the same settings as kaizen analyze and reports how
+long each phase took (file walk, parsing per language, churn, fan-in,
+aggregation, scoring), a histogram of per-file times, and the slowest files.
+Nothing is saved. Use it to see whether git churn or parsing dominates before
+reaching for --skip-churn or exclusions.`,
+	Run: runBench,
+}
+
+// histogramBucket counts files whose analysis took less than upperBound
+type histogramBucket struct {
+	label      string
+	upperBound time.Duration
+	count      int
+}
+
+// timingHistogramBounds are the upper bounds of the per-file histogram buckets; slower files
+// go into a final open-ended bucket
+var timingHistogramBounds = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+}
+
+func runBench(cmd *cobra.Command, args []string) {
+	cfg, err := config.LoadConfig(benchPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not load config: %v\n", err)
+		cfg = config.DefaultConfig()
+	}
+
+	sinceValue := benchSince
+	if sinceValue == "90d" && cfg.Analysis.Since != "" {
+		sinceValue = cfg.Analysis.Since
+	}
+	since, err := parseSinceTime(sinceValue)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing --since: %v\n", err)
+		os.Exit(1)
+	}
+
+	timings := analyzer.NewPhaseTimings()
+	options := analyzer.AnalysisOptions{
+		RootPath:                   benchPath,
+		Since:                      since,
+		IncludeLanguages:           cfg.Analysis.Languages,
+		ExcludePatterns:            cfg.GetExcludePatterns(),
+		ExcludeDirs:                cfg.Analysis.ExcludeDirs,
+		IncludeChurn:               !(benchSkipChurn || cfg.Analysis.SkipChurn),
+		MaxWorkers:                 cfg.Analysis.MaxWorkers,
+		MaxFileSize:                cfg.Analysis.MaxFileSize,
+		SkipGenerated:              cfg.Analysis.SkipGenerated,
+		MIVariant:                  cfg.Analysis.MIVariant,
+		CountStdlibCalls:           cfg.Analysis.CountStdlibCalls,
+		CountAnonymousFunctions:    cfg.Analysis.CountAnonymousFunctions,
+		ChurnMetric:                cfg.Analysis.ChurnMetric,
+		PathStyle:                  cfg.Analysis.PathStyle,
+		MinFunctionLines:           cfg.Analysis.MinFunctionLines,
+		ExcludeTrivialFromAverages: cfg.Analysis.ExcludeTrivialFromAverages,
+		AverageMethod:              cfg.Analysis.AverageMethod,
+		TimeoutPerFile:             cfg.Analysis.TimeoutPerFile,
+		Thresholds:                 cfg.Thresholds,
+		Scoring:                    cfg.Scoring,
+		Reports:                    cfg.Reports,
+		Timings:                    timings,
+	}
+
+	fmt.Printf("⏱️  Kaizen Benchmark: %s\n\n", benchPath)
+
+	pipeline := analyzer.NewPipeline(languages.NewRegistry(), churn.NewGitChurnAnalyzer(benchPath), analyzer.NewAggregator())
+	start := time.Now()
+	if _, err := pipeline.Analyze(options); err != nil {
+		fmt.Fprintf(os.Stderr, "Error during analysis: %v\n", err)
+		os.Exit(1)
+	}
+	total := time.Since(start)
+
+	printPhaseBreakdown(timings, total, options.IncludeChurn)
+	printTimingHistogram(buildTimingHistogram(timings.Files))
+	printSlowestFiles(slowestFiles(timings.Files, benchTop))
+}
+
+// printPhaseBreakdown prints each phase's time and share of the total run
+func printPhaseBreakdown(timings *analyzer.PhaseTimings, total time.Duration, includeChurn bool) {
+	fmt.Printf("Phases (total %s, %d files):\n", formatBenchDuration(total), len(timings.Files))
+
+	for _, phase := range analyzer.AnalysisPhases {
+		elapsed := timings.Phases[phase]
+		note := ""
+		if phase == analyzer.PhaseChurn && !includeChurn {
+			note = " (skipped)"
+		}
+		fmt.Printf("  %-12s %10s  %5.1f%%%s\n", phase, formatBenchDuration(elapsed), durationShare(elapsed, total), note)
+
+		if phase == analyzer.PhaseParsing {
+			languageNames := make([]string, 0, len(timings.Languages))
+			for language := range timings.Languages {
+				languageNames = append(languageNames, language)
+			}
+			sort.Slice(languageNames, func(first, second int) bool {
+				return timings.Languages[languageNames[first]] > timings.Languages[languageNames[second]]
+			})
+			for _, language := range languageNames {
+				elapsed := timings.Languages[language]
+				fmt.Printf("    %-10s %10s  %5.1f%%\n", language, formatBenchDuration(elapsed), durationShare(elapsed, total))
+			}
+		}
+	}
+	fmt.Println()
+}
+
+// buildTimingHistogram counts files by their total analysis time
+func buildTimingHistogram(files []analyzer.FileTiming) []histogramBucket {
+	buckets := make([]histogramBucket, 0, len(timingHistogramBounds)+1)
+	lowerLabel := "0"
+	for _, bound := range timingHistogramBounds {
+		buckets = append(buckets, histogramBucket{
+			label:      fmt.Sprintf("%s–%s", lowerLabel, formatBenchDuration(bound)),
+			upperBound: bound,
+		})
+		lowerLabel = formatBenchDuration(bound)
+	}
+	buckets = append(buckets, histogramBucket{label: "≥ " + lowerLabel})
+
+	for _, file := range files {
+		bucketIndex := len(buckets) - 1
+		for index, bucket := range buckets[:len(buckets)-1] {
+			if file.Total() < bucket.upperBound {
+				bucketIndex = index
+				break
+			}
+		}
+		buckets[bucketIndex].count++
+	}
+
+	return buckets
+}
+
+// printTimingHistogram prints the per-file histogram with bars scaled to the largest bucket
+func printTimingHistogram(buckets []histogramBucket) {
+	fmt.Println("Per-file time:")
+
+	largest := 0
+	for _, bucket := range buckets {
+		if bucket.count > largest {
+			largest = bucket.count
+		}
+	}
+
+	const barWidth = 30
+	for _, bucket := range buckets {
+		bar := ""
+		if largest > 0 {
+			bar = strings.Repeat("█", bucket.count*barWidth/largest)
+		}
+		fmt.Printf("  %-14s %6d  %s\n", bucket.label, bucket.count, bar)
+	}
+	fmt.Println()
+}
+
+// slowestFiles returns up to limit files, slowest first
+func slowestFiles(files []analyzer.FileTiming, limit int) []analyzer.FileTiming {
+	sorted := append([]analyzer.FileTiming{}, files...)
+	sort.SliceStable(sorted, func(first, second int) bool {
+		return sorted[first].Total() > sorted[second].Total()
+	})
+	if limit >= 0 && len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+	return sorted
+}
+
+// printSlowestFiles lists the slowest files with their parsing and churn split
+func printSlowestFiles(files []analyzer.FileTiming) {
+	if len(files) == 0 {
+		return
+	}
+
+	fmt.Println("Slowest files:")
+	for _, file := range files {
+		fmt.Printf("  %10s  %s (parse %s, churn %s)\n",
+			formatBenchDuration(file.Total()), file.Path,
+			formatBenchDuration(file.Parsing), formatBenchDuration(file.Churn))
+	}
+}
+
+// durationShare returns elapsed as a percentage of total
+func durationShare(elapsed, total time.Duration) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return float64(elapsed) / float64(total) * 100
+}
+
+// formatBenchDuration rounds a duration to a readable precision for its size
+func formatBenchDuration(duration time.Duration) string {
+	switch {
+	case duration >= time.Second:
+		return duration.Round(10 * time.Millisecond).String()
+	case duration >= time.Millisecond:
+		return duration.Round(10 * time.Microsecond).String()
+	default:
+		return duration.Round(time.Microsecond).String()
+	}
+}
+
+func init() {
+	benchCmd.Flags().StringVarP(&benchPath, "path", "p", ".", "Path to analyze")
+	benchCmd.Flags().StringVarP(&benchSince, "since", "s", "90d", "Analyze churn since (e.g., 30d, 2024-01-01)")
+	benchCmd.Flags().BoolVar(&benchSkipChurn, "skip-churn", false, "Skip git churn analysis")
+	benchCmd.Flags().IntVar(&benchTop, "top", 10, "Number of slowest files to list")
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexcollie/kaizen/pkg/analyzer"
+)
+
+func TestBuildTimingHistogram(t *testing.T) {
+	files := []analyzer.FileTiming{
+		{Path: "fast.go", Parsing: 200 * time.Microsecond},
+		{Path: "medium.go", Parsing: 2 * time.Millisecond, Churn: time.Millisecond},
+		{Path: "edge.go", Parsing: 5 * time.Millisecond},
+		{Path: "slow.go", Parsing: time.Second, Churn: time.Second},
+	}
+
+	buckets := buildTimingHistogram(files)
+
+	if len(buckets) != len(timingHistogramBounds)+1 {
+		t.Fatalf("Expected %d buckets, got %d", len(timingHistogramBounds)+1, len(buckets))
+	}
+
+	expectedCounts := map[string]int{"0–1ms": 1, "1ms–5ms": 1, "5ms–10ms": 1, "≥ 1s": 1}
+	for _, bucket := range buckets {
+		if bucket.count != expectedCounts[bucket.label] {
+			t.Errorf("Bucket %s: expected %d files, got %d", bucket.label, expectedCounts[bucket.label], bucket.count)
+		}
+	}
+}
+
+func TestSlowestFiles(t *testing.T) {
+	files := []analyzer.FileTiming{
+		{Path: "a.go", Parsing: time.Millisecond},
+		{Path: "b.go", Parsing: time.Millisecond, Churn: 5 * time.Millisecond},
+		{Path: "c.go", Parsing: 3 * time.Millisecond},
+	}
+
+	slowest := slowestFiles(files, 2)
+
+	if len(slowest) != 2 {
+		t.Fatalf("Expected 2 files, got %d", len(slowest))
+	}
+	if slowest[0].Path != "b.go" || slowest[1].Path != "c.go" {
+		t.Errorf("Expected b.go then c.go, got %s then %s", slowest[0].Path, slowest[1].Path)
+	}
+	if files[0].Path != "a.go" {
+		t.Error("Expected the input slice to be left unsorted")
+	}
+}
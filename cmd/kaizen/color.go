@@ -0,0 +1,41 @@
+package main
+
+import (
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// ANSI color codes
+const (
+	colorReset  = "\033[0m"
+	colorRed    = "\033[31m"
+	colorGreen  = "\033[32m"
+	colorYellow = "\033[33m"
+	colorBlue   = "\033[34m"
+	colorCyan   = "\033[36m"
+	colorOrange = "\033[38;5;208m"
+)
+
+var (
+	noColor      bool
+	colorEnabled = true
+)
+
+// configureColor disables colored output when --no-color is given or NO_COLOR is set
+// (see https://no-color.org), for both the ANSI codes here and the terminal visualizer
+func configureColor(cmd *cobra.Command, args []string) {
+	if noColor || os.Getenv("NO_COLOR") != "" {
+		colorEnabled = false
+		color.NoColor = true
+	}
+}
+
+// ansi returns the escape code, or an empty string when colored output is disabled
+func ansi(code string) string {
+	if !colorEnabled {
+		return ""
+	}
+	return code
+}
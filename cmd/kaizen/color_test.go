@@ -0,0 +1,16 @@
+package main
+
+import "testing"
+
+func TestAnsiRespectsColorSetting(t *testing.T) {
+	defer func() { colorEnabled = true }()
+
+	if got := ansi(colorRed); got != colorRed {
+		t.Errorf("Expected color code when color is enabled, got %q", got)
+	}
+
+	colorEnabled = false
+	if got := ansi(colorRed); got != "" {
+		t.Errorf("Expected empty string when color is disabled, got %q", got)
+	}
+}
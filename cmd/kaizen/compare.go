@@ -0,0 +1,228 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+
+	"github.com/alexcollie/kaizen/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+// Metrics compare --functions can rank changed functions by
+const (
+	rankByComplexity      = "complexity"
+	rankByMaintainability = "maintainability"
+)
+
+var (
+	compareFunctions bool
+	compareTop       int
+	compareRankBy    string
+	compareFormat    string
+)
+
+var compareCmd = &cobra.Command{
+	Use:   "compare <id1|tag> <id2|tag>",
+	Short: "Compare two stored snapshots",
+	Long: `Shows how the summary metrics changed from the first snapshot to the second.
+
+With --functions, also lists the functions whose complexity or maintainability
+changed most between the two snapshots, matched by file and function name:
+the biggest improvements and the biggest regressions, each with its before and
+after values. Functions present in only one snapshot are counted as added or
+removed rather than ranked.`,
+	Args: cobra.ExactArgs(2),
+	Run:  runCompare,
+}
+
+// compareReport is the JSON form of kaizen compare
+type compareReport struct {
+	Snapshot1    storage.SnapshotSummary `json:"snapshot1"`
+	Snapshot2    storage.SnapshotSummary `json:"snapshot2"`
+	MetricDeltas map[string]float64      `json:"metric_deltas"`
+	Functions    *functionComparison     `json:"functions,omitempty"`
+}
+
+// functionComparison ranks the functions that changed between two snapshots
+type functionComparison struct {
+	RankBy               string          `json:"rank_by"`
+	NewFunctionCount     int             `json:"new_function_count"`
+	RemovedFunctionCount int             `json:"removed_function_count"`
+	Improved             []functionDelta `json:"improved"`
+	Regressed            []functionDelta `json:"regressed"`
+}
+
+// functionDelta is a changed function with its before and after values and their differences
+type functionDelta struct {
+	storage.FunctionChange
+	ComplexityDelta      int     `json:"complexity_delta"`
+	MaintainabilityDelta float64 `json:"maintainability_delta"`
+}
+
+func runCompare(cmd *cobra.Command, args []string) {
+	if compareFormat != "ascii" && compareFormat != "json" {
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (use ascii or json)\n", compareFormat)
+		os.Exit(1)
+	}
+	if compareRankBy != rankByComplexity && compareRankBy != rankByMaintainability {
+		fmt.Fprintf(os.Stderr, "Error: unknown --rank-by %q (use complexity or maintainability)\n", compareRankBy)
+		os.Exit(1)
+	}
+	if compareTop < 0 {
+		fmt.Fprintf(os.Stderr, "Error: --top must be 0 or more\n")
+		os.Exit(1)
+	}
+
+	backend := openHistoryBackend()
+	defer func() { _ = backend.Close() }()
+
+	comparison, err := backend.Compare(resolveSnapshotRef(backend, args[0]), resolveSnapshotRef(backend, args[1]))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not compare snapshots: %v\n", err)
+		os.Exit(1)
+	}
+
+	report := compareReport{
+		Snapshot1:    comparison.Snapshot1,
+		Snapshot2:    comparison.Snapshot2,
+		MetricDeltas: comparison.MetricDeltas,
+	}
+	if compareFunctions {
+		improved, regressed := rankFunctionChanges(comparison.ChangedFunctions, compareRankBy, compareTop)
+		report.Functions = &functionComparison{
+			RankBy:               compareRankBy,
+			NewFunctionCount:     comparison.NewFunctionCount,
+			RemovedFunctionCount: comparison.RemovedFunctionCount,
+			Improved:             improved,
+			Regressed:            regressed,
+		}
+	}
+
+	if compareFormat == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not encode comparison: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	printCompareReport(report, reportPrecision("."))
+}
+
+// rankFunctionChanges splits changes into improvements and regressions of the rankBy metric, each
+// ordered by the size of the change, largest first, and capped at top entries (0 = all). Changes
+// that leave the rankBy metric as it was are in neither list.
+func rankFunctionChanges(changes []storage.FunctionChange, rankBy string, top int) ([]functionDelta, []functionDelta) {
+	improved := []functionDelta{}
+	regressed := []functionDelta{}
+
+	for _, change := range changes {
+		delta := functionDelta{
+			FunctionChange:       change,
+			ComplexityDelta:      change.ComplexityDelta(),
+			MaintainabilityDelta: change.MaintainabilityDelta(),
+		}
+		switch worsening := rankedWorsening(delta, rankBy); {
+		case worsening > 0:
+			regressed = append(regressed, delta)
+		case worsening < 0:
+			improved = append(improved, delta)
+		}
+	}
+
+	for _, deltas := range [][]functionDelta{improved, regressed} {
+		sort.SliceStable(deltas, func(first, second int) bool {
+			return math.Abs(rankedWorsening(deltas[first], rankBy)) > math.Abs(rankedWorsening(deltas[second], rankBy))
+		})
+	}
+
+	if top > 0 && len(improved) > top {
+		improved = improved[:top]
+	}
+	if top > 0 && len(regressed) > top {
+		regressed = regressed[:top]
+	}
+	return improved, regressed
+}
+
+// rankedWorsening is how much worse the rankBy metric got: positive for a regression, negative
+// for an improvement
+func rankedWorsening(delta functionDelta, rankBy string) float64 {
+	if rankBy == rankByMaintainability {
+		return -delta.MaintainabilityDelta
+	}
+	return float64(delta.ComplexityDelta)
+}
+
+// printCompareReport prints the comparison as text, with scores to precision decimal places
+func printCompareReport(report compareReport, precision int) {
+	before, after := report.Snapshot1, report.Snapshot2
+	fmt.Printf("\n📊 Snapshot #%d → #%d\n\n", before.ID, after.ID)
+	fmt.Printf("Analyzed At:              %s → %s\n", before.AnalyzedAt.Format("2006-01-02 15:04"), after.AnalyzedAt.Format("2006-01-02 15:04"))
+	fmt.Printf("Overall Grade:            %s → %s\n", before.OverallGrade, after.OverallGrade)
+	fmt.Printf("Overall Score:            %s\n", formatCompareChange(before.OverallScore, after.OverallScore, precision))
+	fmt.Printf("Complexity Score:         %s\n", formatCompareChange(before.ComplexityScore, after.ComplexityScore, precision))
+	fmt.Printf("Maintainability Score:    %s\n", formatCompareChange(before.MaintainabilityScore, after.MaintainabilityScore, precision))
+	fmt.Printf("Churn Score:              %s\n", formatCompareChange(before.ChurnScore, after.ChurnScore, precision))
+	fmt.Printf("Avg Cyclomatic:           %s\n", formatCompareChange(before.AvgCyclomaticComplexity, after.AvgCyclomaticComplexity, 1))
+	fmt.Printf("Avg Maintainability:      %s\n", formatCompareChange(before.AvgMaintainabilityIndex, after.AvgMaintainabilityIndex, 1))
+	fmt.Printf("Total Files:              %s\n", formatCompareChange(float64(before.TotalFiles), float64(after.TotalFiles), 0))
+	fmt.Printf("Total Functions:          %s\n", formatCompareChange(float64(before.TotalFunctions), float64(after.TotalFunctions), 0))
+	fmt.Printf("Hotspot Count:            %s\n", formatCompareChange(float64(before.HotspotCount), float64(after.HotspotCount), 0))
+
+	if report.Functions != nil {
+		printFunctionComparison(report.Functions)
+	}
+	fmt.Println()
+}
+
+// formatCompareChange renders "before → after (+delta)" with the given number of decimals
+func formatCompareChange(before, after float64, decimals int) string {
+	return fmt.Sprintf("%.*f → %.*f (%+.*f)", decimals, before, decimals, after, decimals, after-before)
+}
+
+func printFunctionComparison(functions *functionComparison) {
+	fmt.Printf("\nFunctions: %d added, %d removed\n", functions.NewFunctionCount, functions.RemovedFunctionCount)
+
+	sections := []struct {
+		title  string
+		color  string
+		deltas []functionDelta
+	}{
+		{"🏆 Most Improved", colorGreen, functions.Improved},
+		{"⚠️  Most Regressed", colorRed, functions.Regressed},
+	}
+	for _, section := range sections {
+		fmt.Printf("\n%s (by %s):\n", section.title, functions.RankBy)
+		if len(section.deltas) == 0 {
+			fmt.Println("  (none)")
+			continue
+		}
+		for _, delta := range section.deltas {
+			fmt.Printf("  %s%-8s%s %s (%s)\n", ansi(section.color), formatRankedDelta(delta, functions.RankBy), ansi(colorReset), delta.FunctionName+delta.Overload, delta.FilePath)
+			fmt.Printf("           complexity %d → %d, maintainability %.1f → %.1f, length %d → %d\n",
+				delta.OldComplexity, delta.NewComplexity,
+				delta.OldMaintainability, delta.NewMaintainability,
+				delta.OldLength, delta.NewLength)
+		}
+	}
+}
+
+// formatRankedDelta renders the change in the ranked metric with its sign, e.g. "+6" or "-12.5"
+func formatRankedDelta(delta functionDelta, rankBy string) string {
+	if rankBy == rankByMaintainability {
+		return fmt.Sprintf("%+.1f", delta.MaintainabilityDelta)
+	}
+	return fmt.Sprintf("%+d", delta.ComplexityDelta)
+}
+
+func init() {
+	compareCmd.Flags().BoolVar(&compareFunctions, "functions", false, "Also rank the functions that improved or regressed most")
+	compareCmd.Flags().IntVarP(&compareTop, "top", "n", 10, "With --functions, functions to list per direction (0 = all)")
+	compareCmd.Flags().StringVar(&compareRankBy, "rank-by", rankByComplexity, "With --functions, metric to rank by (complexity, maintainability)")
+	compareCmd.Flags().StringVarP(&compareFormat, "format", "f", "ascii", "Output format (ascii or json)")
+}
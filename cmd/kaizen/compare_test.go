@@ -0,0 +1,53 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/alexcollie/kaizen/pkg/storage"
+)
+
+func functionNames(deltas []functionDelta) []string {
+	names := make([]string, len(deltas))
+	for index, delta := range deltas {
+		names[index] = delta.FunctionName
+	}
+	return names
+}
+
+func TestRankFunctionChanges(t *testing.T) {
+	changes := []storage.FunctionChange{
+		{FunctionName: "Slightly", OldComplexity: 5, NewComplexity: 4, OldMaintainability: 70, NewMaintainability: 60},
+		{FunctionName: "Refactored", OldComplexity: 20, NewComplexity: 6, OldMaintainability: 40, NewMaintainability: 75},
+		{FunctionName: "Grew", OldComplexity: 3, NewComplexity: 11, OldMaintainability: 80, NewMaintainability: 62},
+		{FunctionName: "Renamed", OldComplexity: 2, NewComplexity: 2, OldMaintainability: 90, NewMaintainability: 90, OldLength: 5, NewLength: 6},
+		{FunctionName: "Crept", OldComplexity: 7, NewComplexity: 9, OldMaintainability: 65, NewMaintainability: 66},
+	}
+
+	improved, regressed := rankFunctionChanges(changes, rankByComplexity, 0)
+	if got := functionNames(improved); len(got) != 2 || got[0] != "Refactored" || got[1] != "Slightly" {
+		t.Errorf("Expected improved [Refactored Slightly] by complexity, got %v", got)
+	}
+	if got := functionNames(regressed); len(got) != 2 || got[0] != "Grew" || got[1] != "Crept" {
+		t.Errorf("Expected regressed [Grew Crept] by complexity, got %v", got)
+	}
+	if improved[0].ComplexityDelta != -14 || improved[0].MaintainabilityDelta != 35 {
+		t.Errorf("Expected Refactored deltas -14 and +35, got %d and %.1f", improved[0].ComplexityDelta, improved[0].MaintainabilityDelta)
+	}
+
+	improved, regressed = rankFunctionChanges(changes, rankByMaintainability, 1)
+	if got := functionNames(improved); len(got) != 1 || got[0] != "Refactored" {
+		t.Errorf("Expected improved [Refactored] by maintainability with top 1, got %v", got)
+	}
+	if got := functionNames(regressed); len(got) != 1 || got[0] != "Grew" {
+		t.Errorf("Expected regressed [Grew] by maintainability with top 1, got %v", got)
+	}
+}
+
+func TestFormatCompareChange(t *testing.T) {
+	if got := formatCompareChange(72.25, 68.5, 1); got != "72.2 → 68.5 (-3.8)" {
+		t.Errorf("Expected \"72.2 → 68.5 (-3.8)\", got %q", got)
+	}
+	if got := formatCompareChange(10, 12, 0); got != "10 → 12 (+2)" {
+		t.Errorf("Expected \"10 → 12 (+2)\", got %q", got)
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	configPath   string
+	configFormat string
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect and check Kaizen configuration",
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check .kaizen.yaml for invalid settings",
+	Long: `Loads .kaizen.yaml and .kaizenignore from --path and its parent directories
+up to the git root, and reports every invalid setting of the merged configuration
+along with its key path. Exits 0 when the configuration is valid and 1 when it is
+not, so pipelines can gate on config correctness.`,
+	Run: runConfigValidate,
+}
+
+// configValidationResult is the JSON output of kaizen config validate
+type configValidationResult struct {
+	Valid  bool                     `json:"valid"`
+	Errors []config.ValidationError `json:"errors"`
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) {
+	if configFormat != "text" && configFormat != "json" {
+		fmt.Fprintf(os.Stderr, "Error: unsupported --format '%s' (use text or json)\n", configFormat)
+		os.Exit(1)
+	}
+
+	result := configValidationResult{Errors: []config.ValidationError{}}
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		// Unparseable YAML has no key path to point at
+		result.Errors = append(result.Errors, config.ValidationError{Message: err.Error()})
+	} else {
+		result.Errors = append(result.Errors, cfg.ValidationErrors()...)
+	}
+	result.Valid = len(result.Errors) == 0
+
+	if configFormat == "json" {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error marshaling JSON: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(data))
+	} else {
+		printConfigValidation(result)
+	}
+
+	if !result.Valid {
+		os.Exit(1)
+	}
+}
+
+func printConfigValidation(result configValidationResult) {
+	if result.Valid {
+		fmt.Println("✅ Configuration is valid")
+		return
+	}
+
+	fmt.Printf("❌ Configuration has %d error(s):\n", len(result.Errors))
+	for _, validationError := range result.Errors {
+		if validationError.Key == "" {
+			fmt.Printf("  - %s\n", validationError.Message)
+			continue
+		}
+		fmt.Printf("  - %s: %s\n", validationError.Key, validationError.Message)
+	}
+}
+
+func init() {
+	configValidateCmd.Flags().StringVarP(&configPath, "path", "p", ".", "Directory containing .kaizen.yaml")
+	configValidateCmd.Flags().StringVarP(&configFormat, "format", "f", "text", "Output format (text or json)")
+	configCmd.AddCommand(configValidateCmd)
+}
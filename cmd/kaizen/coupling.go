@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/alexcollie/kaizen/pkg/churn"
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/spf13/cobra"
+)
+
+var (
+	couplingPath      string
+	couplingSince     string
+	couplingMinShared int
+	couplingLimit     int
+	couplingFormat    string
+	couplingCrossOnly bool
+)
+
+var couplingCmd = &cobra.Command{
+	Use:   "coupling",
+	Short: "Find functions that frequently change together (co-change coupling)",
+	Long: `Reads git history and reports pairs of functions that appear in the
+same commits at least --min-shared times. The coupling degree is the share
+of the less frequently changed function's commits that also touched the other.
+
+Pairs living in different modules (directories) with a high coupling degree
+are reported as cross-module coupling concerns.`,
+	Run: runCoupling,
+}
+
+func runCoupling(cmd *cobra.Command, args []string) {
+	since, err := parseSinceTime(couplingSince)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error parsing --since: %v\n", err)
+		os.Exit(1)
+	}
+
+	churnAnalyzer := churn.NewGitChurnAnalyzer(couplingPath)
+	couplings, err := churnAnalyzer.GetCoChanges(since, couplingMinShared)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if couplingCrossOnly {
+		var crossModule []models.FunctionCoupling
+		for _, coupling := range couplings {
+			if coupling.CrossesBoundary {
+				crossModule = append(crossModule, coupling)
+			}
+		}
+		couplings = crossModule
+	}
+
+	concerns := churn.DetectCrossModuleCoupling(couplings)
+
+	if couplingLimit > 0 && len(couplings) > couplingLimit {
+		couplings = couplings[:couplingLimit]
+	}
+
+	if couplingFormat == "json" {
+		outputCouplingJSON(couplings, concerns)
+	} else {
+		outputCouplingText(couplings, concerns)
+	}
+}
+
+// outputCouplingText prints coupled function pairs in a table, followed by any concerns
+func outputCouplingText(couplings []models.FunctionCoupling, concerns []models.Concern) {
+	if len(couplings) == 0 {
+		fmt.Println("No co-changing functions found.")
+		return
+	}
+
+	tabWriter := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tabWriter, "FUNCTION\tFILE\tCO-CHANGES WITH\tFILE\tSHARED\tDEGREE\tCROSS-MODULE")
+	_, _ = fmt.Fprintln(tabWriter, "--------\t----\t---------------\t----\t------\t------\t------------")
+
+	for _, coupling := range couplings {
+		crossModule := "no"
+		if coupling.CrossesBoundary {
+			crossModule = "yes"
+		}
+
+		_, _ = fmt.Fprintf(tabWriter, "%s\t%s\t%s\t%s\t%d\t%.0f%%\t%s\n",
+			coupling.FirstFunction,
+			coupling.FirstFile,
+			coupling.SecondFunction,
+			coupling.SecondFile,
+			coupling.SharedCommits,
+			coupling.CouplingDegree*100,
+			crossModule)
+	}
+
+	_ = tabWriter.Flush()
+
+	for _, concern := range concerns {
+		fmt.Printf("\n⚠️  [%s] %s\n%s", concern.Severity, concern.Title, concern.Description)
+	}
+}
+
+// outputCouplingJSON marshals couplings and concerns to JSON and prints to stdout
+func outputCouplingJSON(couplings []models.FunctionCoupling, concerns []models.Concern) {
+	if couplings == nil {
+		couplings = []models.FunctionCoupling{}
+	}
+	if concerns == nil {
+		concerns = []models.Concern{}
+	}
+
+	data, err := json.MarshalIndent(map[string]interface{}{
+		"couplings": couplings,
+		"concerns":  concerns,
+	}, "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error marshaling JSON: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Println(string(data))
+}
+
+func init() {
+	couplingCmd.Flags().StringVarP(&couplingPath, "path", "p", ".", "Path to the git repository")
+	couplingCmd.Flags().StringVarP(&couplingSince, "since", "s", "90d", "Analyze commits since (e.g., 30d, 2024-01-01)")
+	couplingCmd.Flags().IntVar(&couplingMinShared, "min-shared", 3, "Minimum shared commits for a pair to be reported")
+	couplingCmd.Flags().IntVarP(&couplingLimit, "limit", "l", 20, "Maximum pairs to display (0 = all)")
+	couplingCmd.Flags().StringVarP(&couplingFormat, "format", "f", "text", "Output format (text or json)")
+	couplingCmd.Flags().BoolVar(&couplingCrossOnly, "cross-module", false, "Only show pairs that span different modules")
+}
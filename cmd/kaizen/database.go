@@ -0,0 +1,47 @@
+package main
+
+import (
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/alexcollie/kaizen/pkg/analyzer"
+	"github.com/alexcollie/kaizen/pkg/storage"
+)
+
+var (
+	databaseFlag    string // --db: snapshot database to use instead of the repository's own
+	allRepositories bool   // --all-repos: read snapshots of every repository in a shared database
+)
+
+// locateDatabase returns the snapshot database for the repository containing path: --db when
+// set, then storage.path from .kaizen.yaml, then kaizen.db or .kaizen/kaizen.db in path.
+// Relative paths are resolved against path.
+func locateDatabase(path string) (string, error) {
+	if databaseFlag != "" {
+		return storage.PrepareDatabasePath(databaseFlag, path)
+	}
+
+	if cfg, err := config.LoadConfig(path); err == nil && cfg.Storage.Path != "" {
+		return storage.PrepareDatabasePath(cfg.Storage.Path, path)
+	}
+
+	return storage.DetectOrCreateDatabase(path)
+}
+
+// snapshotRepository returns the key snapshots of the repository containing path are saved
+// under: the repository root
+func snapshotRepository(path string) string {
+	return analyzer.FindRepositoryRoot(path)
+}
+
+// repositoryScope returns the repository whose snapshots commands read at path, or "" to read
+// every repository with --all-repos
+func repositoryScope(path string) string {
+	if allRepositories {
+		return ""
+	}
+	return snapshotRepository(path)
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&databaseFlag, "db", "", "Snapshot database file, e.g. one shared by several repositories (default: storage.path, or .kaizen/kaizen.db in the repository)")
+	rootCmd.PersistentFlags().BoolVar(&allRepositories, "all-repos", false, "Read snapshots of every repository in the database, not just the current one")
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/alexcollie/kaizen/pkg/reports"
 	"github.com/alexcollie/kaizen/pkg/storage"
 )
 
@@ -117,8 +118,9 @@ func CompareAnalyses(previous, current *models.AnalysisResult) *AnalysisDiff {
 	return diff
 }
 
-// FormatDiffReport formats the diff as a readable report
-func FormatDiffReport(diff *AnalysisDiff, showTeams bool) string {
+// FormatDiffReport formats the diff as a readable report, with the score change to precision
+// decimal places
+func FormatDiffReport(diff *AnalysisDiff, showTeams bool, precision int) string {
 	var sb strings.Builder
 
 	sb.WriteString("════════════════════════════════════════════════════════════════════\n")
@@ -135,7 +137,7 @@ func FormatDiffReport(diff *AnalysisDiff, showTeams bool) string {
 	sb.WriteString("📈 Overall Metrics\n")
 	sb.WriteString("─────────────────────────────────────────────────────────────────\n")
 
-	scoreStr := fmt.Sprintf("%.1f", diff.GlobalMetrics.ScoreDelta)
+	scoreStr := reports.FormatScore(diff.GlobalMetrics.ScoreDelta, precision)
 	if diff.GlobalMetrics.ScoreDelta > 0 {
 		scoreStr = "+" + scoreStr + " ⬆️"
 	} else if diff.GlobalMetrics.ScoreDelta < 0 {
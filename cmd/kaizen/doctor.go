@@ -0,0 +1,351 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/alexcollie/kaizen/pkg/churn"
+	"github.com/alexcollie/kaizen/pkg/languages"
+	"github.com/alexcollie/kaizen/pkg/ownership"
+	"github.com/alexcollie/kaizen/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	doctorPath   string
+	doctorFormat string
+)
+
+// Doctor check outcomes
+const (
+	doctorPass = "pass"
+	doctorWarn = "warn" // Works, but a feature is unavailable
+	doctorFail = "fail"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check that the environment is set up for Kaizen",
+	Long: `Runs a checklist of everything Kaizen depends on and prints each result
+with a hint on how to fix it:
+  - git is installed and --path is inside a git repository (churn analysis)
+  - the history database is writable
+  - .kaizen.yaml parses and validates
+  - a CODEOWNERS file is discoverable (report owners)
+  - which files under --path have a language analyzer
+
+Exits 1 when any check fails, so it can run as a CI preflight step. Include
+its output when reporting a bug.`,
+	Args: cobra.NoArgs,
+	Run:  runDoctor,
+}
+
+// doctorCheck is the result of one doctor check
+type doctorCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"` // "pass", "warn", or "fail"
+	Detail string `json:"detail"`
+	Hint   string `json:"hint,omitempty"`
+}
+
+// unsupportedSourceExtensions maps common source extensions without an analyzer to their language
+var unsupportedSourceExtensions = map[string]string{
+	".c":     "C",
+	".cc":    "C++",
+	".cpp":   "C++",
+	".cs":    "C#",
+	".java":  "Java",
+	".js":    "JavaScript",
+	".jsx":   "JavaScript",
+	".php":   "PHP",
+	".rb":    "Ruby",
+	".rs":    "Rust",
+	".scala": "Scala",
+	".ts":    "TypeScript",
+	".tsx":   "TypeScript",
+}
+
+// scanSkippedDirs are never searched when counting source files
+var scanSkippedDirs = map[string]bool{
+	".git":         true,
+	".kaizen":      true,
+	"node_modules": true,
+	"vendor":       true,
+}
+
+func runDoctor(cmd *cobra.Command, args []string) {
+	if doctorFormat != "text" && doctorFormat != "json" {
+		fmt.Fprintf(os.Stderr, "Error: unsupported --format '%s' (use text or json)\n", doctorFormat)
+		os.Exit(1)
+	}
+
+	checks := runDoctorChecks(doctorPath)
+
+	if doctorFormat == "json" {
+		data, err := json.MarshalIndent(checks, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error marshaling JSON: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(data))
+	} else {
+		printDoctorChecks(checks)
+	}
+
+	for _, check := range checks {
+		if check.Status == doctorFail {
+			os.Exit(1)
+		}
+	}
+}
+
+// runDoctorChecks runs every check against rootPath, in checklist order
+func runDoctorChecks(rootPath string) []doctorCheck {
+	checks := checkGit(rootPath)
+	checks = append(checks, checkDatabase(rootPath))
+	checks = append(checks, checkConfig(rootPath))
+	checks = append(checks, checkCodeOwners(rootPath))
+	checks = append(checks, checkLanguages(rootPath)...)
+	return checks
+}
+
+// checkGit checks that git is on PATH and rootPath is inside a work tree
+func checkGit(rootPath string) []doctorCheck {
+	gitPath, err := exec.LookPath("git")
+	if err != nil {
+		return []doctorCheck{
+			{
+				Name:   "git installed",
+				Status: doctorFail,
+				Detail: "git was not found on PATH",
+				Hint:   "Install git, or run analyze with --skip-churn to skip churn and hotspot analysis",
+			},
+			{
+				Name:   "git repository",
+				Status: doctorFail,
+				Detail: "not checked: git is not installed",
+			},
+		}
+	}
+
+	checks := []doctorCheck{{Name: "git installed", Status: doctorPass, Detail: gitPath}}
+	if versionOutput, err := exec.Command(gitPath, "--version").Output(); err == nil {
+		checks[0].Detail = strings.TrimSpace(string(versionOutput))
+	}
+
+	if churn.NewGitChurnAnalyzer(rootPath).IsGitRepository(rootPath) {
+		checks = append(checks, doctorCheck{Name: "git repository", Status: doctorPass, Detail: rootPath + " is inside a git work tree"})
+	} else {
+		checks = append(checks, doctorCheck{
+			Name:   "git repository",
+			Status: doctorFail,
+			Detail: rootPath + " is not inside a git repository, so churn cannot be measured",
+			Hint:   "Run Kaizen from a git checkout (or git init), or pass --skip-churn to analyze",
+		})
+	}
+	return checks
+}
+
+// checkDatabase checks that the history database, or the directory it would be created in, is
+// writable. Nothing is created: a missing database is probed with a temporary file.
+func checkDatabase(rootPath string) doctorCheck {
+	check := doctorCheck{Name: "history database"}
+
+	for _, dbPath := range []string{filepath.Join(rootPath, "kaizen.db"), filepath.Join(rootPath, ".kaizen", "kaizen.db")} {
+		if _, err := os.Stat(dbPath); err != nil {
+			continue
+		}
+
+		file, err := os.OpenFile(dbPath, os.O_RDWR, 0)
+		if err != nil {
+			check.Status = doctorFail
+			check.Detail = fmt.Sprintf("%s is not writable: %v", dbPath, err)
+			check.Hint = "Fix the file's permissions; analyze saves a snapshot there after every run"
+			return check
+		}
+		_ = file.Close()
+
+		backend, err := storage.NewBackend(storage.BackendConfig{Type: "sqlite", Path: dbPath})
+		if err != nil {
+			check.Status = doctorFail
+			check.Detail = fmt.Sprintf("%s could not be opened: %v", dbPath, err)
+			check.Hint = "Move the file aside to start a fresh history; it may be corrupt or from an incompatible version"
+			return check
+		}
+		_ = backend.Close()
+
+		check.Status = doctorPass
+		check.Detail = dbPath + " is writable"
+		return check
+	}
+
+	probeDir := filepath.Join(rootPath, ".kaizen")
+	if _, err := os.Stat(probeDir); err != nil {
+		probeDir = rootPath
+	}
+	probe, err := os.CreateTemp(probeDir, ".kaizen-doctor-*")
+	if err != nil {
+		check.Status = doctorFail
+		check.Detail = fmt.Sprintf("cannot write to %s: %v", probeDir, err)
+		check.Hint = "Make the directory writable; analyze creates .kaizen/kaizen.db there to keep history"
+		return check
+	}
+	_ = probe.Close()
+	_ = os.Remove(probe.Name())
+
+	check.Status = doctorPass
+	check.Detail = "no database yet; analyze will create " + filepath.Join(rootPath, ".kaizen", "kaizen.db")
+	return check
+}
+
+// checkConfig checks that the config files for rootPath parse and validate
+func checkConfig(rootPath string) doctorCheck {
+	check := doctorCheck{Name: "configuration"}
+
+	cfg, err := config.LoadConfig(rootPath)
+	if err != nil {
+		check.Status = doctorFail
+		check.Detail = err.Error()
+		check.Hint = "Fix the YAML syntax in .kaizen.yaml, or regenerate it with kaizen init --force"
+		return check
+	}
+
+	if validationErrors := cfg.ValidationErrors(); len(validationErrors) > 0 {
+		first := validationErrors[0]
+		check.Status = doctorFail
+		check.Detail = fmt.Sprintf("%d invalid setting(s), e.g. %s: %s", len(validationErrors), first.Key, first.Message)
+		check.Hint = "Run kaizen config validate to list every invalid setting"
+		return check
+	}
+
+	check.Status = doctorPass
+	check.Detail = "no .kaizen.yaml found; using defaults"
+	if len(cfg.SourceFiles) > 0 {
+		check.Detail = "valid, using " + strings.Join(cfg.SourceFiles, ", ")
+	}
+	return check
+}
+
+// checkCodeOwners checks for a CODEOWNERS file, which report owners needs
+func checkCodeOwners(rootPath string) doctorCheck {
+	codeownersFiles := ownership.FindCodeOwnersFiles(rootPath)
+	if len(codeownersFiles) == 0 {
+		return doctorCheck{
+			Name:   "CODEOWNERS",
+			Status: doctorWarn,
+			Detail: "no CODEOWNERS file found; ownership metrics are skipped",
+			Hint:   "Add a CODEOWNERS or .github/CODEOWNERS file to enable kaizen report owners",
+		}
+	}
+
+	detail := codeownersFiles[0]
+	if len(codeownersFiles) > 1 {
+		detail = fmt.Sprintf("%s (+%d nested)", codeownersFiles[0], len(codeownersFiles)-1)
+	}
+	return doctorCheck{Name: "CODEOWNERS", Status: doctorPass, Detail: detail}
+}
+
+// checkLanguages counts the files under rootPath each analyzer will handle, and warns about
+// common source languages that have no analyzer
+func checkLanguages(rootPath string) []doctorCheck {
+	analyzedCounts := make(map[string]int)
+	unsupportedCounts := make(map[string]int)
+	for _, found := range scanLanguages(rootPath, nil, nil, nil) {
+		if found.Status == languageUnsupported {
+			unsupportedCounts[found.Language] = found.Files
+		} else {
+			analyzedCounts[found.Language] = found.Files
+		}
+	}
+
+	supported := languages.NewRegistry().GetSupportedLanguages()
+	checks := []doctorCheck{{
+		Name:   "language analyzers",
+		Status: doctorPass,
+		Detail: formatLanguageCounts(analyzedCounts),
+	}}
+	if len(analyzedCounts) == 0 {
+		checks[0].Status = doctorFail
+		checks[0].Detail = "no files in a supported language under " + rootPath
+		checks[0].Hint = "Point --path at your source tree; supported languages are " + strings.Join(supported, ", ")
+	}
+
+	if len(unsupportedCounts) > 0 {
+		checks = append(checks, doctorCheck{
+			Name:   "unsupported languages",
+			Status: doctorWarn,
+			Detail: formatLanguageCounts(unsupportedCounts) + " will not be analyzed",
+			Hint:   "Only " + strings.Join(supported, ", ") + " files are analyzed",
+		})
+	}
+	return checks
+}
+
+// formatLanguageCounts lists languages with their file counts, most files first, e.g.
+// "Go (42 files), Python (1 file)"
+func formatLanguageCounts(counts map[string]int) string {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(first, second int) bool {
+		if counts[names[first]] != counts[names[second]] {
+			return counts[names[first]] > counts[names[second]]
+		}
+		return names[first] < names[second]
+	})
+
+	parts := make([]string, len(names))
+	for index, name := range names {
+		noun := "files"
+		if counts[name] == 1 {
+			noun = "file"
+		}
+		parts[index] = fmt.Sprintf("%s (%d %s)", name, counts[name], noun)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// printDoctorChecks prints the checklist with a hint under each check that did not pass
+func printDoctorChecks(checks []doctorCheck) {
+	fmt.Printf("🩺 Kaizen Doctor\n\n")
+
+	failures, warnings := 0, 0
+	for _, check := range checks {
+		icon := "✅"
+		switch check.Status {
+		case doctorFail:
+			icon = "❌"
+			failures++
+		case doctorWarn:
+			icon = "⚠️ "
+			warnings++
+		}
+
+		fmt.Printf("%s %s: %s\n", icon, check.Name, check.Detail)
+		if check.Hint != "" && check.Status != doctorPass {
+			fmt.Printf("   → %s\n", check.Hint)
+		}
+	}
+
+	fmt.Println()
+	switch {
+	case failures > 0:
+		fmt.Printf("%d check(s) failed, %d warning(s)\n", failures, warnings)
+	case warnings > 0:
+		fmt.Printf("All required checks passed, %d warning(s)\n", warnings)
+	default:
+		fmt.Println("All checks passed")
+	}
+}
+
+func init() {
+	doctorCmd.Flags().StringVarP(&doctorPath, "path", "p", ".", "Directory to check")
+	doctorCmd.Flags().StringVarP(&doctorFormat, "format", "f", "text", "Output format (text or json)")
+}
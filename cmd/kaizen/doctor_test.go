@@ -0,0 +1,94 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeDoctorFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("MkdirAll failed: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+}
+
+func TestCheckConfig(t *testing.T) {
+	validDir := t.TempDir()
+	if check := checkConfig(validDir); check.Status != doctorPass {
+		t.Errorf("Expected defaults to pass, got %s: %s", check.Status, check.Detail)
+	}
+
+	invalidDir := t.TempDir()
+	writeDoctorFile(t, filepath.Join(invalidDir, ".kaizen.yaml"), "thresholds:\n  complexity:\n    warning: 500\n")
+	check := checkConfig(invalidDir)
+	if check.Status != doctorFail || !strings.Contains(check.Detail, "thresholds.complexity") {
+		t.Errorf("Expected an invalid threshold to fail with its key, got %s: %s", check.Status, check.Detail)
+	}
+
+	brokenDir := t.TempDir()
+	writeDoctorFile(t, filepath.Join(brokenDir, ".kaizen.yaml"), "thresholds: [unclosed\n")
+	if check := checkConfig(brokenDir); check.Status != doctorFail {
+		t.Errorf("Expected unparseable YAML to fail, got %s: %s", check.Status, check.Detail)
+	}
+}
+
+func TestCheckDatabaseLeavesNoFiles(t *testing.T) {
+	rootPath := t.TempDir()
+
+	check := checkDatabase(rootPath)
+
+	if check.Status != doctorPass {
+		t.Errorf("Expected a writable directory to pass, got %s: %s", check.Status, check.Detail)
+	}
+	entries, err := os.ReadDir(rootPath)
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Expected the probe to clean up after itself, found %d entries", len(entries))
+	}
+}
+
+func TestCheckCodeOwners(t *testing.T) {
+	rootPath := t.TempDir()
+	if check := checkCodeOwners(rootPath); check.Status != doctorWarn {
+		t.Errorf("Expected a missing CODEOWNERS to warn, got %s", check.Status)
+	}
+
+	writeDoctorFile(t, filepath.Join(rootPath, ".github", "CODEOWNERS"), "* @team\n")
+	if check := checkCodeOwners(rootPath); check.Status != doctorPass {
+		t.Errorf("Expected .github/CODEOWNERS to pass, got %s: %s", check.Status, check.Detail)
+	}
+}
+
+func TestCheckLanguages(t *testing.T) {
+	rootPath := t.TempDir()
+	writeDoctorFile(t, filepath.Join(rootPath, "main.go"), "package main\n")
+	writeDoctorFile(t, filepath.Join(rootPath, "pkg", "util.go"), "package pkg\n")
+	writeDoctorFile(t, filepath.Join(rootPath, "scripts", "tool.py"), "pass\n")
+	writeDoctorFile(t, filepath.Join(rootPath, "web", "app.ts"), "export {}\n")
+	writeDoctorFile(t, filepath.Join(rootPath, "vendor", "dep", "dep.go"), "package dep\n")
+	writeDoctorFile(t, filepath.Join(rootPath, "README.md"), "# readme\n")
+
+	checks := checkLanguages(rootPath)
+
+	if len(checks) != 2 {
+		t.Fatalf("Expected analyzer and unsupported language checks, got %+v", checks)
+	}
+	if checks[0].Status != doctorPass || checks[0].Detail != "Go (2 files), Python (1 file)" {
+		t.Errorf("Expected Go and Python counts without vendor, got %s: %s", checks[0].Status, checks[0].Detail)
+	}
+	if checks[1].Status != doctorWarn || !strings.HasPrefix(checks[1].Detail, "TypeScript (1 file)") {
+		t.Errorf("Expected a TypeScript warning, got %s: %s", checks[1].Status, checks[1].Detail)
+	}
+
+	emptyChecks := checkLanguages(t.TempDir())
+	if len(emptyChecks) != 1 || emptyChecks[0].Status != doctorFail {
+		t.Errorf("Expected a tree without source files to fail, got %+v", emptyChecks)
+	}
+}
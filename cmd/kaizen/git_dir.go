@@ -0,0 +1,35 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/alexcollie/kaizen/pkg/churn"
+)
+
+// gitDirFlag is the --git-dir flag: the git directory churn is read from, for worktrees and
+// checkouts whose .git is not in or above the analyzed path
+var gitDirFlag string
+
+// newChurnAnalyzer returns the churn analyzer for path, reading history from --git-dir when set
+// and from the repository enclosing path otherwise
+func newChurnAnalyzer(path string) *churn.GitChurnAnalyzer {
+	if gitDirFlag != "" {
+		return churn.NewGitChurnAnalyzerWithGitDir(path, gitDirFlag)
+	}
+	return churn.NewGitChurnAnalyzer(path)
+}
+
+// churnAvailable reports whether churn can be measured for path, warning once when it cannot
+// rather than leaving every file without churn data
+func churnAvailable(churnAnalyzer *churn.GitChurnAnalyzer, path string) bool {
+	if _, err := churnAnalyzer.RepositoryRoot(); err != nil {
+		if gitDirFlag != "" {
+			fmt.Fprintf(os.Stderr, "Warning: --git-dir %s is not a usable git directory for %s; skipping churn analysis\n", gitDirFlag, path)
+		} else {
+			fmt.Fprintf(os.Stderr, "Warning: %s is not inside a git repository; skipping churn analysis (use --git-dir to point at the repository, or --skip-churn)\n", path)
+		}
+		return false
+	}
+	return true
+}
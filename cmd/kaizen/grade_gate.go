@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/alexcollie/kaizen/pkg/reports"
+)
+
+// gradeGateExitCode is the exit status when --fail-on-grade trips, matching check's concern exit code
+const gradeGateExitCode = 2
+
+// gradeOrder lists the letter grades best first
+var gradeOrder = []string{"A", "B", "C", "D", "F"}
+
+var failOnGrade string
+
+// validateFailOnGrade normalizes --fail-on-grade to an upper-case letter grade, exiting on
+// anything else
+func validateFailOnGrade() {
+	if failOnGrade == "" {
+		return
+	}
+	failOnGrade = strings.ToUpper(failOnGrade)
+	if gradeRank(failOnGrade) < 0 {
+		fmt.Fprintf(os.Stderr, "Error: unknown --fail-on-grade %q (use %s)\n", failOnGrade, strings.Join(gradeOrder, ", "))
+		os.Exit(1)
+	}
+}
+
+// gradeRank is a grade's position in gradeOrder, or -1 for an unknown grade
+func gradeRank(grade string) int {
+	for index, candidate := range gradeOrder {
+		if candidate == grade {
+			return index
+		}
+	}
+	return -1
+}
+
+// gradeFailsGate reports whether grade is failGrade or worse
+func gradeFailsGate(grade, failGrade string) bool {
+	rank := gradeRank(grade)
+	return rank >= 0 && rank >= gradeRank(failGrade)
+}
+
+// enforceGradeGate exits with gradeGateExitCode when --fail-on-grade is set and the overall
+// grade is at or below it. It prints to stderr so the reason shows even with --quiet, with the
+// score to precision decimal places.
+func enforceGradeGate(result *models.AnalysisResult, precision int) {
+	if failOnGrade == "" || result.ScoreReport == nil {
+		return
+	}
+
+	grade := result.ScoreReport.OverallGrade
+	if !gradeFailsGate(grade, failOnGrade) {
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "❌ Grade %s (%s/100) is at or below --fail-on-grade=%s\n", grade, reports.FormatScore(result.ScoreReport.OverallScore, precision), failOnGrade)
+	os.Exit(gradeGateExitCode)
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/alexcollie/kaizen/pkg/analyzer"
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/alexcollie/kaizen/pkg/ownership"
+)
+
+// Grouping modes accepted by --group-by
+const (
+	groupByFolder = "folder"
+	groupByModule = "module"
+)
+
+// validateGroupBy exits with an error unless groupBy is a supported grouping mode
+func validateGroupBy(groupBy string) {
+	if groupBy != groupByFolder && groupBy != groupByModule {
+		fmt.Fprintf(os.Stderr, "Error: unsupported --group-by '%s' (use folder or module)\n", groupBy)
+		os.Exit(1)
+	}
+}
+
+// hasModuleStats reports whether the result was grouped by Go module, warning when
+// module grouping was requested but the tree is not a multi-module Go repository
+func hasModuleStats(result *models.AnalysisResult) bool {
+	if len(result.ModuleStats) > 0 {
+		return true
+	}
+	fmt.Fprintf(os.Stderr, "Warning: no multi-module Go layout detected, falling back to folder grouping\n")
+	return false
+}
+
+// printGroupBreakdown prints per-module metrics, or per-folder metrics when no modules were detected
+func printGroupBreakdown(result *models.AnalysisResult) {
+	groups := result.FolderStats
+	title := "📁 By folder"
+	if hasModuleStats(result) {
+		groups = result.ModuleStats
+		title = "📦 By module"
+	}
+
+	groupPaths := make([]string, 0, len(groups))
+	for groupPath := range groups {
+		groupPaths = append(groupPaths, groupPath)
+	}
+	sort.Strings(groupPaths)
+
+	fmt.Printf("\n%s:\n", title)
+	fmt.Printf("  %-40s %6s %6s %8s %8s %8s\n", "Path", "Files", "Funcs", "Cmplx", "Maint", "Hotspots")
+	for _, groupPath := range groupPaths {
+		group := groups[groupPath]
+		fmt.Printf("  %-40s %6d %6d %8.1f %8.1f %8d\n",
+			truncate(groupPath, 40),
+			group.TotalFiles,
+			group.TotalFunctions,
+			group.AverageComplexity,
+			group.AverageMaintainability,
+			group.HotspotCount,
+		)
+	}
+}
+
+// splitResultByModule partitions a result's files by the modules recorded in its ModuleStats
+func splitResultByModule(result *models.AnalysisResult) map[string]*models.AnalysisResult {
+	moduleDirs := make([]string, 0, len(result.ModuleStats))
+	for moduleDir := range result.ModuleStats {
+		if moduleDir != analyzer.NoModuleGroup {
+			moduleDirs = append(moduleDirs, moduleDir)
+		}
+	}
+
+	moduleResults := make(map[string]*models.AnalysisResult)
+	for _, file := range result.Files {
+		moduleDir := analyzer.ModuleForFile(file.Path, moduleDirs)
+		moduleResult, exists := moduleResults[moduleDir]
+		if !exists {
+			moduleResult = &models.AnalysisResult{
+				Repository: result.Repository,
+				AnalyzedAt: result.AnalyzedAt,
+				TimeRange:  result.TimeRange,
+			}
+			moduleResults[moduleDir] = moduleResult
+		}
+		moduleResult.Files = append(moduleResult.Files, file)
+	}
+
+	return moduleResults
+}
+
+// renderOwnerReportsByModule renders one ownership report per Go module, with health scores to
+// precision decimal places
+func renderOwnerReportsByModule(aggregator *ownership.Aggregator, snapshot *models.AnalysisResult, snapshotID int64, precision int) {
+	analyzedAt := snapshot.AnalyzedAt.Format("2006-01-02 15:04:05")
+	moduleResults := splitResultByModule(snapshot)
+
+	moduleDirs := make([]string, 0, len(moduleResults))
+	for moduleDir := range moduleResults {
+		moduleDirs = append(moduleDirs, moduleDir)
+	}
+	sort.Strings(moduleDirs)
+
+	reportsByModule := make(map[string]*ownership.OwnerReport, len(moduleResults))
+	for _, moduleDir := range moduleDirs {
+		reportsByModule[moduleDir] = aggregator.GetOwnerReport(moduleResults[moduleDir], snapshotID, analyzedAt)
+		applyShowUnowned(reportsByModule[moduleDir])
+	}
+
+	switch reportFormat {
+	case "ascii":
+		for _, moduleDir := range moduleDirs {
+			fmt.Printf("\n📦 Module: %s\n\n", moduleDir)
+			fmt.Print(ownership.RenderOwnerReportASCII(reportsByModule[moduleDir], precision))
+		}
+	case "json":
+		data, err := json.MarshalIndent(reportsByModule, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not format JSON: %v\n", err)
+			os.Exit(1)
+		}
+
+		if reportOutput == "" {
+			fmt.Println(string(data))
+			return
+		}
+		if err := os.WriteFile(reportOutput, data, 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not write file: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ Exported to: %s\n", reportOutput)
+	default:
+		fmt.Fprintf(os.Stderr, "Error: --group-by=module supports ascii and json formats only\n")
+		os.Exit(1)
+	}
+}
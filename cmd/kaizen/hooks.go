@@ -0,0 +1,300 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Markers around the lines kaizen owns in a hook script, so they can be updated or removed
+// without touching the rest of the hook
+const (
+	hookBlockStart = "# >>> kaizen quality gate >>>"
+	hookBlockEnd   = "# <<< kaizen quality gate <<<"
+)
+
+// hookShebang starts hook scripts that kaizen creates
+const hookShebang = "#!/bin/sh"
+
+// Outcomes of installing or uninstalling the quality gate in one hook script
+const (
+	hookCreated   = "created"
+	hookAppended  = "appended"
+	hookUpdated   = "updated"
+	hookSkipped   = "skipped"
+	hookRemoved   = "removed"
+	hookDeleted   = "deleted"
+	hookNotFound  = "not found"
+	hookUntouched = "untouched"
+)
+
+var (
+	hooksPath        string
+	hooksPrePush     bool
+	hooksPreCommit   bool
+	hooksFailOnGrade string
+	hooksSkipChurn   bool
+)
+
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Install or remove git hooks that gate on the Kaizen grade",
+}
+
+var hooksInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Add a Kaizen quality gate to the repository's git hooks",
+	Long: `Writes a pre-push hook (and with --pre-commit, a pre-commit hook) that runs
+kaizen analyze --fail-on-grade and stops the push or commit when the overall grade
+is at or below the given grade. Thresholds come from .kaizen.yaml as usual.
+
+The hook analyzes the whole repository, not only the changed files, since the
+grade it checks is the overall grade. Use --skip-churn to make it faster.
+
+Existing hooks are kept: the gate is appended to shell hooks between marker
+comments, and hooks in other languages are left alone with a warning. Running
+install again updates the gate in place.`,
+	Args: cobra.NoArgs,
+	Run:  runHooksInstall,
+}
+
+var hooksUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove the Kaizen quality gate from the repository's git hooks",
+	Long: `Removes the lines kaizen hooks install added to the pre-push and pre-commit hooks.
+A hook left with nothing but its shebang line is deleted; other hook content is kept.`,
+	Args: cobra.NoArgs,
+	Run:  runHooksUninstall,
+}
+
+func runHooksInstall(cmd *cobra.Command, args []string) {
+	failOnGrade = hooksFailOnGrade
+	validateFailOnGrade()
+	if !hooksPrePush && !hooksPreCommit {
+		fmt.Fprintf(os.Stderr, "Error: nothing to install (enable --pre-push or --pre-commit)\n")
+		os.Exit(1)
+	}
+
+	hooksDir := locateHooksDir(hooksPath)
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not create hooks directory: %v\n", err)
+		os.Exit(1)
+	}
+
+	block := renderHookBlock(failOnGrade, hooksSkipChurn)
+	for _, hookName := range selectedHooks() {
+		hookPath := filepath.Join(hooksDir, hookName)
+		outcome, err := installHook(hookPath, block)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not install %s hook: %v\n", hookName, err)
+			os.Exit(1)
+		}
+
+		switch outcome {
+		case hookSkipped:
+			fmt.Fprintf(os.Stderr, "⚠️  %s is not a shell script; left unchanged. Add this to it by hand:\n  kaizen analyze --quiet --output=/dev/null --fail-on-grade=%s\n", hookPath, failOnGrade)
+		case hookAppended:
+			fmt.Printf("✅ %s: quality gate appended to the existing hook\n", hookPath)
+		default:
+			fmt.Printf("✅ %s: quality gate %s (fails on grade %s or worse)\n", hookPath, outcome, failOnGrade)
+		}
+	}
+}
+
+func runHooksUninstall(cmd *cobra.Command, args []string) {
+	hooksDir := locateHooksDir(hooksPath)
+	for _, hookName := range []string{"pre-push", "pre-commit"} {
+		hookPath := filepath.Join(hooksDir, hookName)
+		outcome, err := uninstallHook(hookPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not update %s hook: %v\n", hookName, err)
+			os.Exit(1)
+		}
+
+		switch outcome {
+		case hookRemoved:
+			fmt.Printf("✅ %s: quality gate removed, rest of the hook kept\n", hookPath)
+		case hookDeleted:
+			fmt.Printf("✅ %s: quality gate removed and the empty hook deleted\n", hookPath)
+		case hookUntouched:
+			fmt.Printf("%s: no kaizen quality gate, left unchanged\n", hookPath)
+		}
+	}
+}
+
+// selectedHooks lists the hooks chosen with --pre-push and --pre-commit
+func selectedHooks() []string {
+	var hookNames []string
+	if hooksPrePush {
+		hookNames = append(hookNames, "pre-push")
+	}
+	if hooksPreCommit {
+		hookNames = append(hookNames, "pre-commit")
+	}
+	return hookNames
+}
+
+// locateHooksDir asks git where path's hooks live, which honours core.hooksPath and worktrees
+func locateHooksDir(path string) string {
+	command := exec.Command("git", "rev-parse", "--git-path", "hooks")
+	command.Dir = path
+	output, err := command.Output()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s is not inside a git repository\n", path)
+		os.Exit(1)
+	}
+
+	hooksDir := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(hooksDir) {
+		hooksDir = filepath.Join(path, hooksDir)
+	}
+	return hooksDir
+}
+
+// renderHookBlock builds the marked hook lines that run the quality gate over the whole tree. A missing kaizen
+// binary skips the gate rather than blocking every push.
+func renderHookBlock(grade string, skipChurn bool) string {
+	analyzeCommand := "kaizen analyze --quiet --output=/dev/null --fail-on-grade=" + grade
+	if skipChurn {
+		analyzeCommand += " --skip-churn"
+	}
+
+	return strings.Join([]string{
+		hookBlockStart,
+		"# Added by `kaizen hooks install`; remove with `kaizen hooks uninstall`",
+		"if command -v kaizen >/dev/null 2>&1; then",
+		"\t" + analyzeCommand + " || exit $?",
+		"else",
+		"\techo \"kaizen not found in PATH; skipping the quality gate\" >&2",
+		"fi",
+		hookBlockEnd,
+	}, "\n") + "\n"
+}
+
+// installHook writes block into the hook script at hookPath: a new script when there is none,
+// replacing an earlier block, or appended to an existing shell script. Scripts in other
+// languages are left alone and reported as skipped.
+func installHook(hookPath, block string) (string, error) {
+	existing, err := os.ReadFile(hookPath)
+	if os.IsNotExist(err) {
+		return hookCreated, os.WriteFile(hookPath, []byte(hookShebang+"\n\n"+block), 0755)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	content := string(existing)
+	outcome := hookUpdated
+	if remaining, found := removeHookBlock(content); found {
+		content = remaining
+	} else if isShellHook(content) {
+		outcome = hookAppended
+	} else {
+		return hookSkipped, nil
+	}
+
+	if !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	content += "\n" + block
+
+	info, err := os.Stat(hookPath)
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(hookPath, []byte(content), info.Mode()); err != nil {
+		return "", err
+	}
+	// git ignores hooks that are not executable
+	return outcome, os.Chmod(hookPath, info.Mode()|0111)
+}
+
+// uninstallHook removes the kaizen block from the hook script at hookPath, deleting the script
+// when only its shebang is left
+func uninstallHook(hookPath string) (string, error) {
+	existing, err := os.ReadFile(hookPath)
+	if os.IsNotExist(err) {
+		return hookNotFound, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	remaining, found := removeHookBlock(string(existing))
+	if !found {
+		return hookUntouched, nil
+	}
+
+	trimmed := strings.TrimSpace(remaining)
+	if trimmed == "" || (strings.HasPrefix(trimmed, "#!") && !strings.Contains(trimmed, "\n")) {
+		return hookDeleted, os.Remove(hookPath)
+	}
+
+	info, err := os.Stat(hookPath)
+	if err != nil {
+		return "", err
+	}
+	return hookRemoved, os.WriteFile(hookPath, []byte(strings.TrimRight(remaining, "\n")+"\n"), info.Mode())
+}
+
+// removeHookBlock cuts the marked kaizen block, and the blank line before it, out of a hook script
+func removeHookBlock(content string) (string, bool) {
+	start := strings.Index(content, hookBlockStart)
+	if start < 0 {
+		return content, false
+	}
+	end := strings.Index(content[start:], hookBlockEnd)
+	if end < 0 {
+		return content, false
+	}
+	end += start + len(hookBlockEnd)
+	if end < len(content) && content[end] == '\n' {
+		end++
+	}
+
+	before := strings.TrimSuffix(content[:start], "\n")
+	return before + "\n" + content[end:], true
+}
+
+// isShellHook reports whether a hook script runs under a POSIX-style shell, so shell lines can
+// be appended to it
+func isShellHook(content string) bool {
+	firstLine := strings.SplitN(content, "\n", 2)[0]
+	fields := strings.Fields(strings.TrimPrefix(firstLine, "#!"))
+	if !strings.HasPrefix(firstLine, "#!") || len(fields) == 0 {
+		return false
+	}
+
+	interpreter := filepath.Base(fields[0])
+	if interpreter == "env" {
+		// #!/usr/bin/env [-S] bash: the interpreter is the first argument that is not a flag
+		interpreter = ""
+		for _, field := range fields[1:] {
+			if !strings.HasPrefix(field, "-") {
+				interpreter = field
+				break
+			}
+		}
+	}
+
+	switch interpreter {
+	case "sh", "bash", "dash", "zsh", "ksh":
+		return true
+	}
+	return false
+}
+
+func init() {
+	hooksInstallCmd.Flags().StringVarP(&hooksPath, "path", "p", ".", "Path inside the git repository")
+	hooksInstallCmd.Flags().BoolVar(&hooksPrePush, "pre-push", true, "Install the gate as a pre-push hook")
+	hooksInstallCmd.Flags().BoolVar(&hooksPreCommit, "pre-commit", false, "Also install the gate as a pre-commit hook (runs on every commit)")
+	hooksInstallCmd.Flags().StringVar(&hooksFailOnGrade, "fail-on-grade", "D", "Block when the overall grade is this grade or worse (A-F)")
+	hooksInstallCmd.Flags().BoolVar(&hooksSkipChurn, "skip-churn", false, "Skip git churn analysis in the hook for faster runs")
+	hooksUninstallCmd.Flags().StringVarP(&hooksPath, "path", "p", ".", "Path inside the git repository")
+	hooksCmd.AddCommand(hooksInstallCmd)
+	hooksCmd.AddCommand(hooksUninstallCmd)
+}
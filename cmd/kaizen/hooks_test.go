@@ -0,0 +1,146 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestInstallHookCreatesAndUpdates(t *testing.T) {
+	hookPath := filepath.Join(t.TempDir(), "pre-push")
+
+	outcome, err := installHook(hookPath, renderHookBlock("D", false))
+	if err != nil || outcome != hookCreated {
+		t.Fatalf("Expected %q, got %q (%v)", hookCreated, outcome, err)
+	}
+	info, err := os.Stat(hookPath)
+	if err != nil || info.Mode()&0111 == 0 {
+		t.Fatalf("Expected an executable hook, got %v (%v)", info, err)
+	}
+
+	outcome, err = installHook(hookPath, renderHookBlock("C", true))
+	if err != nil || outcome != hookUpdated {
+		t.Fatalf("Expected %q, got %q (%v)", hookUpdated, outcome, err)
+	}
+
+	content, _ := os.ReadFile(hookPath)
+	script := string(content)
+	if !strings.HasPrefix(script, hookShebang+"\n") {
+		t.Errorf("Expected the hook to start with %q, got %q", hookShebang, script)
+	}
+	if strings.Count(script, hookBlockStart) != 1 {
+		t.Errorf("Expected one quality gate block after reinstalling, got:\n%s", script)
+	}
+	if !strings.Contains(script, "--fail-on-grade=C --skip-churn || exit $?") || strings.Contains(script, "--fail-on-grade=D") {
+		t.Errorf("Expected the block to be replaced with the new settings, got:\n%s", script)
+	}
+}
+
+func TestInstallHookKeepsExistingHooks(t *testing.T) {
+	hooksDir := t.TempDir()
+	shellHook := filepath.Join(hooksDir, "pre-push")
+	pythonHook := filepath.Join(hooksDir, "pre-commit")
+	shellScript := "#!/usr/bin/env bash\nmake lint\n"
+	pythonScript := "#!/usr/bin/env python3\nprint('checks')\n"
+	if err := os.WriteFile(shellHook, []byte(shellScript), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(pythonHook, []byte(pythonScript), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	block := renderHookBlock("D", false)
+	if outcome, err := installHook(shellHook, block); err != nil || outcome != hookAppended {
+		t.Errorf("Expected %q for a bash hook, got %q (%v)", hookAppended, outcome, err)
+	}
+	if outcome, err := installHook(pythonHook, block); err != nil || outcome != hookSkipped {
+		t.Errorf("Expected %q for a python hook, got %q (%v)", hookSkipped, outcome, err)
+	}
+
+	content, _ := os.ReadFile(shellHook)
+	if !strings.HasPrefix(string(content), shellScript) || !strings.Contains(string(content), hookBlockEnd) {
+		t.Errorf("Expected the gate appended after the existing hook, got:\n%s", content)
+	}
+	content, _ = os.ReadFile(pythonHook)
+	if string(content) != pythonScript {
+		t.Errorf("Expected the python hook unchanged, got:\n%s", content)
+	}
+}
+
+func TestUninstallHook(t *testing.T) {
+	hooksDir := t.TempDir()
+	createdHook := filepath.Join(hooksDir, "pre-push")
+	sharedHook := filepath.Join(hooksDir, "pre-commit")
+	sharedScript := "#!/bin/bash\nmake lint\n"
+	if err := os.WriteFile(sharedHook, []byte(sharedScript), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	block := renderHookBlock("D", false)
+	for _, hookPath := range []string{createdHook, sharedHook} {
+		if _, err := installHook(hookPath, block); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if outcome, err := uninstallHook(createdHook); err != nil || outcome != hookDeleted {
+		t.Errorf("Expected %q for a hook kaizen created, got %q (%v)", hookDeleted, outcome, err)
+	}
+	if _, err := os.Stat(createdHook); !os.IsNotExist(err) {
+		t.Errorf("Expected %s to be deleted", createdHook)
+	}
+
+	if outcome, err := uninstallHook(sharedHook); err != nil || outcome != hookRemoved {
+		t.Errorf("Expected %q for a shared hook, got %q (%v)", hookRemoved, outcome, err)
+	}
+	content, _ := os.ReadFile(sharedHook)
+	if string(content) != sharedScript {
+		t.Errorf("Expected the shared hook restored to %q, got %q", sharedScript, content)
+	}
+
+	if outcome, _ := uninstallHook(sharedHook); outcome != hookUntouched {
+		t.Errorf("Expected %q without a gate, got %q", hookUntouched, outcome)
+	}
+	if outcome, _ := uninstallHook(createdHook); outcome != hookNotFound {
+		t.Errorf("Expected %q for a missing hook, got %q", hookNotFound, outcome)
+	}
+}
+
+func TestIsShellHook(t *testing.T) {
+	tests := map[string]bool{
+		"#!/bin/sh\n":                true,
+		"#!/usr/bin/env bash\n":      true,
+		"#!/usr/bin/env -S zsh -e\n": true,
+		"#!/usr/bin/env python3\n":   false,
+		"#!/usr/bin/node\n":          false,
+		"echo no shebang\n":          false,
+		"#!\n":                       false,
+	}
+	for content, expected := range tests {
+		if got := isShellHook(content); got != expected {
+			t.Errorf("isShellHook(%q) = %v, expected %v", content, got, expected)
+		}
+	}
+}
+
+func TestGradeFailsGate(t *testing.T) {
+	tests := []struct {
+		grade     string
+		failGrade string
+		expected  bool
+	}{
+		{"A", "D", false},
+		{"C", "D", false},
+		{"D", "D", true},
+		{"F", "D", true},
+		{"B", "B", true},
+		{"A", "F", false},
+		{"", "D", false},
+	}
+	for _, test := range tests {
+		if got := gradeFailsGate(test.grade, test.failGrade); got != test.expected {
+			t.Errorf("gradeFailsGate(%q, %q) = %v, expected %v", test.grade, test.failGrade, got, test.expected)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// htmlTemplatePath is the --template flag shared by the commands that write HTML pages from a
+// replaceable html/template: visualize, trend, and callgraph
+var htmlTemplatePath string
+
+// loadHTMLTemplate returns the custom template named by --template, or "" for the built-in page,
+// exiting when the file cannot be read
+func loadHTMLTemplate() string {
+	if htmlTemplatePath == "" {
+		return ""
+	}
+
+	source, err := os.ReadFile(htmlTemplatePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not read --template: %v\n", err)
+		os.Exit(1)
+	}
+	return string(source)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/alexcollie/kaizen/pkg/reports"
+)
+
+// printIndustryComparison prints each language's averages against the built-in reference ranges
+func printIndustryComparison(result *models.AnalysisResult) {
+	comparisons := reports.CompareToIndustry(result)
+
+	fmt.Printf("\n🌍 Compared with typical open-source projects:\n")
+	if len(comparisons) == 0 {
+		fmt.Printf("  No language has reference data and at least %d functions to compare\n", reports.IndustryMinFunctions)
+		return
+	}
+
+	currentLanguage := ""
+	for _, comparison := range comparisons {
+		if comparison.Language != currentLanguage {
+			currentLanguage = comparison.Language
+			fmt.Printf("\n  %s (%d functions)\n", comparison.Language, comparison.FunctionCount)
+			fmt.Printf("    %-24s %8s %13s   %s\n", "Metric", "Average", "Typical", "Verdict")
+		}
+
+		fmt.Printf("    %-24s %8.1f %13s   %s\n",
+			comparison.Metric,
+			comparison.Average,
+			fmt.Sprintf("%.1f–%.1f", comparison.Reference.Low, comparison.Reference.High),
+			industryVerdictLabel(comparison.Verdict),
+		)
+	}
+
+	fmt.Printf("\n  Reference ranges are approximate and bundled with kaizen; nothing is sent anywhere.\n")
+}
+
+// industryVerdictLabel colors a comparison verdict for the terminal
+func industryVerdictLabel(verdict string) string {
+	switch verdict {
+	case reports.IndustryBetter:
+		return ansi(colorGreen) + "✓ better than typical" + ansi(colorReset)
+	case reports.IndustryWorse:
+		return ansi(colorRed) + "✗ worse than typical" + ansi(colorReset)
+	default:
+		return "• typical"
+	}
+}
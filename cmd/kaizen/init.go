@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	initPath  string
+	initForce bool
+)
+
+var initCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Create a commented .kaizen.yaml and starter .kaizenignore",
+	Long: `Writes a .kaizen.yaml listing every setting with its default value and a
+comment explaining it, plus a starter .kaizenignore. Existing files are left
+untouched unless --force is given.`,
+	Run: runInit,
+}
+
+func runInit(cmd *cobra.Command, args []string) {
+	configData, err := config.GenerateDefaultYAML()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	scaffoldFiles := []struct {
+		name string
+		data []byte
+	}{
+		{name: ".kaizen.yaml", data: configData},
+		{name: ".kaizenignore", data: []byte(config.DefaultIgnoreFile)},
+	}
+
+	// Check every file before writing any so a refusal leaves the directory unchanged
+	if !initForce {
+		for _, scaffold := range scaffoldFiles {
+			path := filepath.Join(initPath, scaffold.name)
+			if _, err := os.Stat(path); err == nil {
+				fmt.Fprintf(os.Stderr, "Error: %s already exists (use --force to overwrite)\n", path)
+				os.Exit(1)
+			}
+		}
+	}
+
+	for _, scaffold := range scaffoldFiles {
+		path := filepath.Join(initPath, scaffold.name)
+		if err := os.WriteFile(path, scaffold.data, 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not write %s: %v\n", path, err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ Wrote %s\n", path)
+	}
+}
+
+func init() {
+	initCmd.Flags().StringVarP(&initPath, "path", "p", ".", "Directory to write the config files to")
+	initCmd.Flags().BoolVar(&initForce, "force", false, "Overwrite existing config files")
+}
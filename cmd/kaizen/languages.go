@@ -0,0 +1,202 @@
+package main
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/alexcollie/kaizen/pkg/analyzer"
+	"github.com/alexcollie/kaizen/pkg/languages"
+	"github.com/spf13/cobra"
+)
+
+var (
+	languagesPath   string
+	languagesFormat string
+)
+
+// How kaizen analyze treats a language found by kaizen languages
+const (
+	languageAnalyzed    = "analyzed"
+	languageStub        = "stub"        // Has an analyzer that is not implemented yet
+	languageFiltered    = "filtered"    // Has an analyzer, but analysis.languages leaves it out
+	languageUnsupported = "unsupported" // A common source language with no analyzer
+)
+
+// languagePresence is one language found under a path, with its file count and whether
+// analyze will measure it
+type languagePresence struct {
+	Language   string   `json:"language"`
+	Files      int      `json:"files"`
+	Status     string   `json:"status"`
+	Extensions []string `json:"extensions"`
+}
+
+var languagesCmd = &cobra.Command{
+	Use:   "languages",
+	Short: "List the languages under a path and whether Kaizen analyzes them",
+	Long: `Walks --path, without reading or parsing any file, and lists each language
+found with its file count and status:
+  analyzed     files are measured by kaizen analyze
+  stub         the language has an analyzer that is not implemented yet
+  filtered     analysis.languages in .kaizen.yaml leaves the language out
+  unsupported  a common source language Kaizen has no analyzer for
+
+Files excluded by .kaizenignore, analysis.exclude, and analysis.exclude_dirs are
+not counted, so the counts match what analyze would consider. Use it before a
+first run to decide what to include, or to check that files are not being
+silently skipped.`,
+	Args: cobra.NoArgs,
+	Run:  runLanguages,
+}
+
+func runLanguages(cmd *cobra.Command, args []string) {
+	if languagesFormat != "text" && languagesFormat != "json" {
+		fmt.Fprintf(os.Stderr, "Error: unsupported --format '%s' (use text or json)\n", languagesFormat)
+		os.Exit(1)
+	}
+
+	cfg, err := config.LoadConfig(languagesPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not load config: %v\n", err)
+		cfg = config.DefaultConfig()
+	}
+
+	presence := scanLanguages(languagesPath, cfg.GetExcludePatterns(), cfg.Analysis.ExcludeDirs, cfg.Analysis.Languages)
+
+	if languagesFormat == "json" {
+		writeConcernsJSON(presence)
+		return
+	}
+	printLanguages(languagesPath, presence)
+}
+
+// scanLanguages counts the files under rootPath by language from their extensions alone. Files
+// matching excludePatterns and directories named in excludeDirs or scanSkippedDirs are skipped.
+// A non-empty includeLanguages marks analyzed languages missing from it as filtered. Languages
+// are ordered most files first.
+func scanLanguages(rootPath string, excludePatterns []string, excludeDirs []string, includeLanguages []string) []languagePresence {
+	registry := languages.NewRegistry()
+	byLanguage := make(map[string]*languagePresence)
+	extensions := make(map[string]map[string]bool)
+
+	_ = filepath.WalkDir(rootPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if entry.IsDir() {
+			if path != rootPath && (scanSkippedDirs[entry.Name()] || isExcludedDirName(entry.Name(), excludeDirs) || analyzer.MatchesExcludePattern(path, excludePatterns)) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if analyzer.MatchesExcludePattern(path, excludePatterns) {
+			return nil
+		}
+
+		var language, status string
+		if languageAnalyzer, err := registry.GetAnalyzerForFile(path); err == nil {
+			language = languageAnalyzer.Name()
+			switch {
+			case languageAnalyzer.IsStub():
+				status = languageStub
+			case !languageIncluded(language, includeLanguages):
+				status = languageFiltered
+			default:
+				status = languageAnalyzed
+			}
+		} else if unsupported, known := unsupportedSourceExtensions[filepath.Ext(path)]; known {
+			language, status = unsupported, languageUnsupported
+		} else {
+			return nil
+		}
+
+		presence, ok := byLanguage[language]
+		if !ok {
+			presence = &languagePresence{Language: language, Status: status}
+			byLanguage[language] = presence
+			extensions[language] = make(map[string]bool)
+		}
+		presence.Files++
+		extensions[language][filepath.Ext(path)] = true
+		return nil
+	})
+
+	found := make([]languagePresence, 0, len(byLanguage))
+	for language, presence := range byLanguage {
+		for extension := range extensions[language] {
+			presence.Extensions = append(presence.Extensions, extension)
+		}
+		sort.Strings(presence.Extensions)
+		found = append(found, *presence)
+	}
+	sort.Slice(found, func(first, second int) bool {
+		if found[first].Files != found[second].Files {
+			return found[first].Files > found[second].Files
+		}
+		return found[first].Language < found[second].Language
+	})
+	return found
+}
+
+// isExcludedDirName reports whether a directory name is one of the excluded directory names
+func isExcludedDirName(name string, excludeDirs []string) bool {
+	for _, excludeDir := range excludeDirs {
+		if name == excludeDir {
+			return true
+		}
+	}
+	return false
+}
+
+// languageIncluded reports whether analysis.languages lets a language through; an empty list
+// includes every language
+func languageIncluded(language string, includeLanguages []string) bool {
+	if len(includeLanguages) == 0 {
+		return true
+	}
+	for _, included := range includeLanguages {
+		if strings.EqualFold(language, included) {
+			return true
+		}
+	}
+	return false
+}
+
+// printLanguages prints one line per language with its file count, extensions, and status
+func printLanguages(rootPath string, presence []languagePresence) {
+	fmt.Printf("🗂️  Languages under %s\n\n", rootPath)
+	if len(presence) == 0 {
+		fmt.Println("No source files found")
+		return
+	}
+
+	for _, found := range presence {
+		noun := "files"
+		if found.Files == 1 {
+			noun = "file"
+		}
+
+		status := ansi(colorGreen) + "✓ analyzed" + ansi(colorReset)
+		switch found.Status {
+		case languageStub:
+			status = ansi(colorYellow) + "⚠ stub analyzer, skipped" + ansi(colorReset)
+		case languageFiltered:
+			status = ansi(colorYellow) + "⚠ left out by analysis.languages" + ansi(colorReset)
+		case languageUnsupported:
+			status = ansi(colorRed) + "✗ no analyzer, skipped" + ansi(colorReset)
+		}
+
+		count := fmt.Sprintf("%d %s", found.Files, noun)
+		fmt.Printf("  %-12s %10s  %-12s %s\n", found.Language, count, strings.Join(found.Extensions, ", "), status)
+	}
+}
+
+func init() {
+	languagesCmd.Flags().StringVarP(&languagesPath, "path", "p", ".", "Directory to scan")
+	languagesCmd.Flags().StringVarP(&languagesFormat, "format", "f", "text", "Output format (text or json)")
+}
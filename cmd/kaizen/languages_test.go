@@ -0,0 +1,42 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestScanLanguages(t *testing.T) {
+	rootPath := t.TempDir()
+	writeDoctorFile(t, filepath.Join(rootPath, "main.go"), "package main\n")
+	writeDoctorFile(t, filepath.Join(rootPath, "pkg", "util.go"), "package pkg\n")
+	writeDoctorFile(t, filepath.Join(rootPath, "pkg", "util_test.go"), "package pkg\n")
+	writeDoctorFile(t, filepath.Join(rootPath, "scripts", "tool.py"), "pass\n")
+	writeDoctorFile(t, filepath.Join(rootPath, "web", "app.ts"), "export {}\n")
+	writeDoctorFile(t, filepath.Join(rootPath, "web", "view.tsx"), "export {}\n")
+	writeDoctorFile(t, filepath.Join(rootPath, "testdata", "fixture.go"), "package fixture\n")
+	writeDoctorFile(t, filepath.Join(rootPath, "README.md"), "# readme\n")
+
+	presence := scanLanguages(rootPath, []string{"*_test.go"}, []string{"testdata"}, []string{"go"})
+
+	expected := []languagePresence{
+		{Language: "Go", Files: 2, Status: languageAnalyzed, Extensions: []string{".go"}},
+		{Language: "TypeScript", Files: 2, Status: languageUnsupported, Extensions: []string{".ts", ".tsx"}},
+		{Language: "Python", Files: 1, Status: languageFiltered, Extensions: []string{".py"}},
+	}
+	if !reflect.DeepEqual(presence, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, presence)
+	}
+}
+
+func TestLanguageIncluded(t *testing.T) {
+	if !languageIncluded("Go", nil) {
+		t.Error("An empty analysis.languages should include every language")
+	}
+	if !languageIncluded("Objective-C", []string{"objective-c"}) {
+		t.Error("analysis.languages should match case-insensitively")
+	}
+	if languageIncluded("Python", []string{"go"}) {
+		t.Error("Python should be left out by analysis.languages: [go]")
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -12,14 +13,15 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/alexcollie/kaizen/internal"
 	"github.com/alexcollie/kaizen/internal/config"
 	"github.com/alexcollie/kaizen/pkg/analyzer"
 	"github.com/alexcollie/kaizen/pkg/check"
-	"github.com/alexcollie/kaizen/pkg/churn"
 	"github.com/alexcollie/kaizen/pkg/languages"
 	"github.com/alexcollie/kaizen/pkg/languages/golang"
 	"github.com/alexcollie/kaizen/pkg/models"
 	"github.com/alexcollie/kaizen/pkg/ownership"
+	"github.com/alexcollie/kaizen/pkg/reports"
 	"github.com/alexcollie/kaizen/pkg/storage"
 	"github.com/alexcollie/kaizen/pkg/trending"
 	"github.com/alexcollie/kaizen/pkg/visualization"
@@ -32,7 +34,19 @@ var (
 	outputFile       string
 	includeLanguages []string
 	excludePatterns  []string
+	excludeDirs      []string
 	skipChurn        bool
+	maxFileSize      int64
+	quietMode        bool
+	jsonOnly         bool
+	compactJSON      bool
+	summaryGroupBy   string
+	snapshotNote     string
+	compareIndustry  bool
+	includeTests     bool
+	strictParse      bool
+	includeGenerated bool
+	coverageProfile  string
 
 	// Visualize flags
 	inputFile    string
@@ -45,28 +59,39 @@ var (
 	openBrowser  bool
 
 	// History flags
-	historyLimit int
+	historyLimit            int
+	historySignatureChanges bool
 
 	// Trend flags
-	trendDays    int
-	trendFolder  string
-	trendFormat  string
-	trendOutput  string
-	trendOpen    bool
+	trendDays        int
+	trendFolder      string
+	trendFormat      string
+	trendOutput      string
+	trendOpen        bool
+	trendFrom        string
+	trendGroupBy     string
+	trendAll         bool
+	trendMetricNames []string
 
 	// Report flags
 	reportFormat     string
 	reportOutput     string
 	reportOpen       bool
 	reportCodeOwnersPath string
+	reportOwnersBy       string
+	reportGroupBy        string
+	reportShowUnowned    bool
 
 	// Callgraph flags
-	callgraphPath   string
-	callgraphOutput string
-	callgraphFormat string
-	callgraphBase   string
-	saveJSON        bool
-	minCalls        int
+	callgraphPath    string
+	callgraphOutput  string
+	callgraphFormat  string
+	callgraphBase    string
+	callgraphExclude []string
+	callgraphCross   bool
+	callgraphWorkers int
+	saveJSON         bool
+	minCalls         int
 
 	// Sankey flags
 	sankeyInput     string
@@ -81,6 +106,7 @@ var (
 	diffCodeOwnersPath   string
 	diffOutput           string
 	diffSkipChurn        bool
+	diffAgainst          string
 )
 
 var rootCmd = &cobra.Command{
@@ -93,6 +119,7 @@ var rootCmd = &cobra.Command{
   - Hotspots (high churn + high complexity)
 
 Generates heat maps to visualize code health by folder.`,
+	PersistentPreRun: configureColor,
 }
 
 var historyCmd = &cobra.Command{
@@ -102,10 +129,17 @@ var historyCmd = &cobra.Command{
 }
 
 var trendCmd = &cobra.Command{
-	Use:   "trend <metric>",
+	Use:   "trend [metric]",
 	Short: "Visualize metric trends over time",
 	Long: `Visualize how code metrics have changed over time.
 
+With --all, prints a one-line sparkline for every metric with data instead,
+each with its latest value and the change over the time range.
+
+With --metrics, charts several metrics together: in HTML they are overlaid on
+one chart with a legend that toggles each one, scores and maintainability
+index as recorded and other metrics as a percentage of their peak.
+
 Supported metrics:
   - overall_score: Overall code health score
   - complexity_score: Code complexity score
@@ -113,15 +147,26 @@ Supported metrics:
   - churn_score: Code churn/volatility
   - avg_cyclomatic_complexity: Average cyclomatic complexity
   - avg_cognitive_complexity: Average cognitive complexity
+  - avg_function_length: Average function length
   - avg_maintainability_index: Average maintainability index
   - hotspot_count: Number of hotspots
+  - hotspot_density: Hotspots per 1000 code lines
+  - concern_density: Concern functions per 1000 code lines
 
 Examples:
   kaizen trend overall_score
   kaizen trend complexity_score --days=30
-  kaizen trend complexity_score --format=json`,
-	Args: cobra.ExactArgs(1),
-	Run:  runTrend,
+  kaizen trend overall_score --from=baseline
+  kaizen trend complexity_score --format=json
+  kaizen trend --all
+  kaizen trend --metrics=overall_score,complexity_score,churn_score --format=html`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if trendAll || len(trendMetricNames) > 0 {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.ExactArgs(1)(cmd, args)
+	},
+	Run: runTrend,
 }
 
 var reportCmd = &cobra.Command{
@@ -152,7 +197,10 @@ var visualizeCmd = &cobra.Command{
   - Top hotspots list
   - Folder breakdown by metric
 
-Supported metrics: complexity, cognitive, churn, hotspot, length, maintainability`,
+With --all, prints a one-line sparkline for every metric with data instead,
+each with its latest value and the change over the time range.
+
+Supported metrics: complexity, cognitive, churn, hotspot, length, maintainability, hotspot_density, risk`,
 	Run: runVisualize,
 }
 
@@ -166,7 +214,12 @@ var callgraphCmd = &cobra.Command{
   - Interactive D3.js force-directed graph visualization
 
 Node size represents how often a function is called.
-Node color represents complexity or other metrics.`,
+Node color represents complexity or other metrics.
+
+By default calls are matched by name within each package. With --cross-package,
+the enclosing Go module is type-checked so calls between its packages (including
+method calls) link to the right function, and functions are named by import path,
+e.g. github.com/org/repo/pkg/api.Server.Handle.`,
 	Run: runCallGraph,
 }
 
@@ -202,6 +255,9 @@ Useful for tracking progress over time and identifying regressions.`,
 }
 
 func init() {
+	// Global flags
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colored output (also set by the NO_COLOR environment variable)")
+
 	// Add commands
 	rootCmd.AddCommand(analyzeCmd)
 	rootCmd.AddCommand(visualizeCmd)
@@ -213,20 +269,40 @@ func init() {
 	rootCmd.AddCommand(diffCmd)
 	rootCmd.AddCommand(checkCmd)
 	rootCmd.AddCommand(prCommentCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(couplingCmd)
+	rootCmd.AddCommand(initCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(benchCmd)
+	rootCmd.AddCommand(mergeCmd)
+	rootCmd.AddCommand(statusCmd)
+	rootCmd.AddCommand(doctorCmd)
+	rootCmd.AddCommand(languagesCmd)
+	rootCmd.AddCommand(compareCmd)
+	rootCmd.AddCommand(hooksCmd)
+	rootCmd.AddCommand(backfillCmd)
+	rootCmd.AddCommand(schemaCmd)
 
 	// Report subcommands
 	reportOwnersCmd := &cobra.Command{
-		Use:   "owners [snapshot-id]",
+		Use:   "owners [snapshot-id|tag]",
 		Short: "Generate code ownership report",
 		Run:   runReportOwners,
 	}
 	reportCmd.AddCommand(reportOwnersCmd)
+	reportCmd.AddCommand(reportConcernsCmd)
+	reportCmd.AddCommand(reportFolderCmd)
+	reportCmd.AddCommand(reportTrendsCmd)
 
 	// Report flags
 	reportOwnersCmd.Flags().StringVarP(&reportCodeOwnersPath, "codeowners", "c", "", "Path to CODEOWNERS file (auto-detected if not specified)")
+	reportOwnersCmd.Flags().StringVar(&reportOwnersBy, "by", "codeowners", "Ownership source (codeowners, blame)")
+	reportOwnersCmd.Flags().StringVar(&reportGroupBy, "group-by", groupByFolder, "Split the report per Go module with 'module' (folder = single report)")
+	reportOwnersCmd.Flags().StringVar(&htmlTheme, "theme", themeNordic, "HTML theme (nordic, light); light suits printing and PDF export")
 	reportOwnersCmd.Flags().StringVarP(&reportFormat, "format", "f", "ascii", "Output format (ascii, json, html)")
 	reportOwnersCmd.Flags().StringVarP(&reportOutput, "output", "o", "", "Output file path")
 	reportOwnersCmd.Flags().BoolVar(&reportOpen, "open", true, "Open HTML in browser (format=html only)")
+	reportOwnersCmd.Flags().BoolVar(&reportShowUnowned, "show-unowned", false, "Also list files no CODEOWNERS pattern matches, with their health metrics (ascii, json)")
 
 	// History subcommands
 	historyListCmd := &cobra.Command{
@@ -235,7 +311,7 @@ func init() {
 		Run:   runHistoryList,
 	}
 	historyShowCmd := &cobra.Command{
-		Use:   "show <id>",
+		Use:   "show <id|tag>",
 		Short: "Display detailed snapshot information",
 		Args:  cobra.ExactArgs(1),
 		Run:   runHistoryShow,
@@ -245,13 +321,35 @@ func init() {
 		Short: "Remove old snapshots",
 		Run:   runHistoryPrune,
 	}
+	historyTagCmd := &cobra.Command{
+		Use:   "tag <id|tag> <label>",
+		Short: "Label a snapshot (tagged snapshots are kept by prune)",
+		Args:  cobra.ExactArgs(2),
+		Run:   runHistoryTag,
+	}
+	historyUntagCmd := &cobra.Command{
+		Use:   "untag <label>",
+		Short: "Remove a label from a snapshot",
+		Args:  cobra.ExactArgs(1),
+		Run:   runHistoryUntag,
+	}
 	historyCmd.AddCommand(historyListCmd)
 	historyCmd.AddCommand(historyShowCmd)
 	historyCmd.AddCommand(historyPruneCmd)
+	historyCmd.AddCommand(historyTagCmd)
+	historyAnnotateCmd := &cobra.Command{
+		Use:   "annotate <id|tag> <note>",
+		Short: "Attach a note to a snapshot (an empty note clears it)",
+		Args:  cobra.ExactArgs(2),
+		Run:   runHistoryAnnotate,
+	}
+	historyCmd.AddCommand(historyUntagCmd)
+	historyCmd.AddCommand(historyAnnotateCmd)
 
 	// History flags
 	historyListCmd.Flags().IntVarP(&historyLimit, "limit", "l", 20, "Maximum snapshots to display")
-	historyPruneCmd.Flags().IntVar(&historyLimit, "retention", 90, "Retention period in days")
+	historyShowCmd.Flags().BoolVar(&historySignatureChanges, "signature-changes", false, "List public functions whose parameter count changed since the previous snapshot")
+	historyPruneCmd.Flags().IntVar(&historyLimit, "retention", 90, "Retention period in days (tagged snapshots are kept)")
 
 	// Analyze flags
 	analyzeCmd.Flags().StringVarP(&rootPath, "path", "p", ".", "Path to analyze")
@@ -259,24 +357,60 @@ func init() {
 	analyzeCmd.Flags().StringVarP(&outputFile, "output", "o", "kaizen-results.json", "Output file path")
 	analyzeCmd.Flags().StringSliceVarP(&includeLanguages, "languages", "l", []string{}, "Languages to include (default: all)")
 	analyzeCmd.Flags().StringSliceVarP(&excludePatterns, "exclude", "e", []string{"vendor", "node_modules", "*_test.go"}, "Patterns to exclude")
+	analyzeCmd.Flags().StringSliceVar(&excludeDirs, "exclude-dir", []string{}, "Directory names to skip at any depth (repeatable, e.g. --exclude-dir=testdata)")
 	analyzeCmd.Flags().BoolVar(&skipChurn, "skip-churn", false, "Skip git churn analysis")
+	analyzeCmd.Flags().StringVar(&gitDirFlag, "git-dir", "", "Git directory to read churn from when the analyzed path's .git is elsewhere (default: the enclosing repository)")
+	analyzeCmd.Flags().Int64Var(&maxFileSize, "max-file-size", config.DefaultMaxFileSize, "Skip files larger than this many bytes (0 = no limit)")
+	analyzeCmd.Flags().BoolVarP(&quietMode, "quiet", "q", false, "Suppress progress and summary output (errors still go to stderr)")
+	analyzeCmd.Flags().BoolVar(&jsonOnly, "json-only", false, "Print only the results JSON to stdout (implies --quiet)")
+	analyzeCmd.Flags().BoolVar(&compactJSON, "compact-json", false, "Write the results JSON minified instead of indented (smaller CI artifacts)")
+	analyzeCmd.Flags().StringVar(&perFileOutput, "per-file-output", "", "Also write each file's analysis to <dir>/<source path>.json, mirroring the source tree")
+	analyzeCmd.Flags().StringVar(&summaryGroupBy, "group-by", groupByFolder, "Summary breakdown grouping (folder, module); module groups by enclosing go.mod")
+	analyzeCmd.Flags().BoolVar(&compareIndustry, "compare-industry", false, "Compare per-language averages with typical ranges for open-source projects (bundled, no network)")
+	analyzeCmd.Flags().BoolVar(&includeTests, "include-tests", false, "Also analyze test files, reported separately as test metrics and left out of the grade")
+	analyzeCmd.Flags().BoolVar(&includeGenerated, "include-generated", false, "Analyze generated files (protobuf stubs, mocks, \"Code generated\" headers) instead of skipping them")
+	analyzeCmd.Flags().BoolVar(&strictParse, "strict-parse", false, "Exit non-zero, listing the files, when any file fails to parse instead of analyzing the rest")
+	analyzeCmd.Flags().StringVar(&coverageProfile, "coverage-profile", "", "Go cover profile or LCOV tracefile giving each file's test coverage for the risk score (overrides analysis.coverage_profile)")
+	analyzeCmd.Flags().BoolVar(&trendAwareSeverity, "trend-aware-severity", false, "Escalate warning concerns to critical when the function's metric regressed across recent snapshots")
+	analyzeCmd.Flags().IntVar(&trendSnapshots, "trend-snapshots", reports.DefaultTrendSnapshots, "Stored snapshots a warning must have regressed across for --trend-aware-severity")
+	analyzeCmd.Flags().BoolVar(&alertRegressions, "alert-regressions", false, "Warn when a folder's complexity or hotspot score rose more than reports.folder_regression_percent since the previous snapshot")
+	analyzeCmd.Flags().StringVar(&failOnGrade, "fail-on-grade", "", "Exit with status 2 when the overall grade is this grade or worse (A-F), e.g. for git hooks")
+	analyzeCmd.Flags().StringVar(&snapshotNote, "note", "", "Note stored with the history snapshot, e.g. \"after auth refactor\"")
+	analyzeCmd.Flags().BoolVar(&analyzeStdin, "stdin", false, "Analyze a single source file read from stdin and print its analysis as JSON")
+	analyzeCmd.Flags().StringVar(&stdinLanguage, "lang", "", "Language of the --stdin source (e.g. go, python, swift)")
+	analyzeCmd.Flags().StringVar(&stdinPath, "stdin-path", "", "Path reported for the --stdin source; its extension picks the language when --lang is omitted")
+	analyzeCmd.Flags().StringVar(&cpuProfilePath, "profile", "", "Write a pprof CPU profile of the analysis to this file")
+	analyzeCmd.Flags().StringVar(&memProfilePath, "memprofile", "", "Write a pprof heap profile to this file when the analysis finishes")
+	_ = analyzeCmd.Flags().MarkHidden("profile")
+	_ = analyzeCmd.Flags().MarkHidden("memprofile")
 
 	// Visualize flags
-	visualizeCmd.Flags().StringVarP(&inputFile, "input", "i", "kaizen-results.json", "Input JSON file")
-	visualizeCmd.Flags().StringVarP(&metric, "metric", "m", "hotspot", "Metric to visualize (complexity, cognitive, churn, hotspot, length, maintainability)")
+	visualizeCmd.Flags().StringVarP(&inputFile, "input", "i", "kaizen-results.json", "Input JSON file (- for stdin)")
+	visualizeCmd.Flags().StringVarP(&metric, "metric", "m", "hotspot", "Metric to visualize (complexity, cognitive, churn, hotspot, length, maintainability, hotspot_density, risk)")
 	visualizeCmd.Flags().IntVarP(&topLimit, "limit", "l", 10, "Number of top hotspots to show")
-	visualizeCmd.Flags().StringVarP(&outputFormat, "format", "f", "terminal", "Output format (terminal, html, svg)")
-	visualizeCmd.Flags().StringVarP(&htmlOutput, "output", "o", "kaizen-heatmap.html", "HTML/SVG output file")
+	visualizeCmd.Flags().StringVarP(&outputFormat, "format", "f", "terminal", "Output format (terminal, html, svg, treejson)")
+	visualizeCmd.Flags().StringVarP(&htmlOutput, "output", "o", "kaizen-heatmap.html", "HTML/SVG/JSON output file")
 	visualizeCmd.Flags().IntVar(&svgWidth, "svg-width", 1200, "SVG width in pixels")
 	visualizeCmd.Flags().IntVar(&svgHeight, "svg-height", 800, "SVG height in pixels")
 	visualizeCmd.Flags().BoolVar(&openBrowser, "open", true, "Open HTML in browser automatically")
+	visualizeCmd.Flags().StringVar(&htmlTheme, "theme", themeNordic, "HTML theme (nordic, light); light suits printing and PDF export")
+	visualizeCmd.Flags().StringVar(&htmlTemplatePath, "template", "", "Custom html/template file for the HTML heat map (default: built-in)")
+	visualizeCmd.Flags().StringVar(&treemapSizeBy, "size-by", visualization.SizeByLines, "What HTML treemap cell area represents (lines, functions, hotspots)")
+	visualizeCmd.Flags().IntVar(&treemapMaxDepth, "max-depth", 0, "Collapse HTML treemap folders deeper than N into their ancestor (0 = no limit)")
+	visualizeCmd.Flags().IntVar(&treemapLabelThreshold, "label-threshold", visualization.DefaultLabelThreshold, "Narrowest HTML treemap cell in pixels that shows its name; names are shortened below twice this")
 
 	// Trend flags
 	trendCmd.Flags().IntVarP(&trendDays, "days", "d", 90, "Number of days to show (0 = all)")
 	trendCmd.Flags().StringVar(&trendFolder, "folder", "", "Show metrics for specific folder")
-	trendCmd.Flags().StringVarP(&trendFormat, "format", "f", "ascii", "Output format (ascii, json, html)")
+	trendCmd.Flags().StringVarP(&trendFormat, "format", "f", "ascii", "Output format (ascii, terminal, json, html)")
+	trendCmd.Flags().BoolVar(&trendAll, "all", false, "Show a sparkline for every metric instead of one chart")
+	trendCmd.Flags().StringSliceVar(&trendMetricNames, "metrics", []string{}, "Chart several metrics together, e.g. overall_score,churn_score (html, terminal)")
 	trendCmd.Flags().StringVarP(&trendOutput, "output", "o", "", "Output file path (required for json/html, optional for ascii)")
 	trendCmd.Flags().BoolVar(&trendOpen, "open", true, "Open HTML in browser (format=html only)")
+	trendCmd.Flags().StringVar(&trendFrom, "from", "", "Start the trend at a snapshot ID or tag (overrides --days)")
+	trendCmd.Flags().StringVar(&trendGroupBy, "group-by", groupByFolder, "Interpret --folder as a folder or a Go module directory (folder, module)")
+	trendCmd.Flags().StringVar(&htmlTheme, "theme", themeNordic, "HTML theme (nordic, light); light suits printing and PDF export")
+	trendCmd.Flags().StringVar(&htmlTemplatePath, "template", "", "Custom html/template file for the HTML chart (default: built-in)")
 
 	// Callgraph flags
 	callgraphCmd.Flags().StringVarP(&callgraphPath, "path", "p", ".", "Path to analyze")
@@ -288,9 +422,13 @@ func init() {
 	callgraphCmd.Flags().BoolVar(&saveJSON, "save-json", false, "Also save call graph data as JSON")
 	callgraphCmd.Flags().IntVar(&minCalls, "min-calls", 0, "Minimum call count to include a function (filters noise)")
 	callgraphCmd.Flags().StringVarP(&callgraphBase, "base", "b", "", "Base branch to diff against (filters to changed functions only)")
+	callgraphCmd.Flags().StringSliceVarP(&callgraphExclude, "exclude", "e", []string{"vendor", "node_modules", "*_test.go"}, "Patterns to exclude")
+	callgraphCmd.Flags().BoolVar(&callgraphCross, "cross-package", false, "Resolve calls across the module's packages with type information (names become import paths)")
+	callgraphCmd.Flags().IntVar(&callgraphWorkers, "workers", 0, "Files to parse concurrently (0 = one per CPU, 1 = serial)")
+	callgraphCmd.Flags().StringVar(&htmlTemplatePath, "template", "", "Custom html/template file for the HTML call graph (default: built-in)")
 
 	// Sankey flags
-	sankeyCmd.Flags().StringVarP(&sankeyInput, "input", "i", "kaizen-results.json", "Input analysis file")
+	sankeyCmd.Flags().StringVarP(&sankeyInput, "input", "i", "kaizen-results.json", "Input analysis file (- for stdin)")
 	sankeyCmd.Flags().StringVarP(&sankeyOutput, "output", "o", "kaizen-sankey.html", "Output HTML file")
 	sankeyCmd.Flags().IntVar(&sankeyMinOwners, "min-owners", 2, "Minimum owners calling a function to include it")
 	sankeyCmd.Flags().IntVar(&sankeyMinCalls, "min-calls", 1, "Minimum calls to include a function")
@@ -302,6 +440,8 @@ func init() {
 	diffCmd.Flags().StringVarP(&diffCodeOwnersPath, "codeowners", "c", "", "Path to CODEOWNERS file (auto-detected if not specified)")
 	diffCmd.Flags().StringVarP(&diffOutput, "output", "o", "", "Output file path (optional, default prints to terminal)")
 	diffCmd.Flags().BoolVar(&diffSkipChurn, "skip-churn", false, "Skip git churn analysis")
+	diffCmd.Flags().StringVar(&gitDirFlag, "git-dir", "", "Git directory to read churn from when the analyzed path's .git is elsewhere (default: the enclosing repository)")
+	diffCmd.Flags().StringVar(&diffAgainst, "against", "", "Snapshot ID or tag to compare with (default: latest snapshot)")
 }
 
 func main() {
@@ -312,8 +452,26 @@ func main() {
 }
 
 func runAnalyze(cmd *cobra.Command, args []string) {
-	fmt.Printf("🔍 Kaizen Code Analysis\n\n")
-	fmt.Printf("Analyzing: %s\n", rootPath)
+	stopProfiling := startProfiling(cpuProfilePath, memProfilePath)
+	defer stopProfiling()
+
+	if analyzeStdin {
+		runAnalyzeStdin()
+		return
+	}
+
+	if jsonOnly {
+		quietMode = true
+	}
+	validateGroupBy(summaryGroupBy)
+	validateFailOnGrade()
+	if trendAwareSeverity && trendSnapshots < 1 {
+		fmt.Fprintf(os.Stderr, "Error: --trend-snapshots must be at least 1\n")
+		os.Exit(1)
+	}
+
+	analyzeLogf("🔍 Kaizen Code Analysis\n\n")
+	analyzeLogf("Analyzing: %s\n", rootPath)
 
 	// Load configuration
 	cfg, err := config.LoadConfig(rootPath)
@@ -322,16 +480,16 @@ func runAnalyze(cmd *cobra.Command, args []string) {
 		cfg = config.DefaultConfig()
 	}
 
-	// Check if .kaizenignore exists
-	kaizenIgnorePath := filepath.Join(rootPath, ".kaizenignore")
-	if _, err := os.Stat(kaizenIgnorePath); err == nil {
-		fmt.Printf("📋 Using .kaizenignore (%d patterns)\n", len(cfg.IgnorePatterns))
+	// Report the config files found in rootPath and its parents
+	for _, sourceFile := range cfg.SourceFiles {
+		if filepath.Base(sourceFile) == ".kaizenignore" {
+			analyzeLogf("📋 Using %s\n", sourceFile)
+		} else {
+			analyzeLogf("⚙️  Using %s\n", sourceFile)
+		}
 	}
-
-	// Check if .kaizen.yaml exists
-	kaizenYamlPath := filepath.Join(rootPath, ".kaizen.yaml")
-	if _, err := os.Stat(kaizenYamlPath); err == nil {
-		fmt.Printf("⚙️  Using .kaizen.yaml config\n")
+	if len(cfg.IgnorePatterns) > 0 {
+		analyzeLogf("📋 %d .kaizenignore pattern(s)\n", len(cfg.IgnorePatterns))
 	}
 
 	// Parse since time (CLI overrides config)
@@ -346,8 +504,8 @@ func runAnalyze(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	fmt.Printf("Churn since: %s\n", since.Format("2006-01-02"))
-	fmt.Printf("Output: %s\n\n", outputFile)
+	analyzeLogf("Churn since: %s\n", since.Format("2006-01-02"))
+	analyzeLogf("Output: %s\n\n", outputFile)
 
 	// Merge CLI exclude patterns with config patterns
 	allExcludePatterns := cfg.GetExcludePatterns()
@@ -355,6 +513,10 @@ func runAnalyze(cmd *cobra.Command, args []string) {
 		allExcludePatterns = append(allExcludePatterns, excludePatterns...)
 	}
 
+	// Merge CLI exclude dirs with config dirs
+	allExcludeDirs := append([]string{}, cfg.Analysis.ExcludeDirs...)
+	allExcludeDirs = append(allExcludeDirs, excludeDirs...)
+
 	// Merge CLI languages with config languages
 	allLanguages := cfg.Analysis.Languages
 	if len(includeLanguages) > 0 {
@@ -364,22 +526,54 @@ func runAnalyze(cmd *cobra.Command, args []string) {
 	// CLI skip-churn overrides config
 	shouldSkipChurn := skipChurn || cfg.Analysis.SkipChurn
 
+	// CLI coverage profile overrides config
+	coverageProfilePath := cfg.Analysis.CoverageProfile
+	if coverageProfile != "" {
+		coverageProfilePath = coverageProfile
+	}
+
+	// CLI max-file-size overrides config when given, even at its default value
+	fileSizeLimit := cfg.Analysis.MaxFileSize
+	if cmd.Flags().Changed("max-file-size") {
+		fileSizeLimit = maxFileSize
+	}
+
 	// Create components
 	registry := languages.NewRegistry()
-	churnAnalyzer := churn.NewGitChurnAnalyzer(rootPath)
+	churnAnalyzer := newChurnAnalyzer(rootPath)
 	aggregator := analyzer.NewAggregator()
 	pipeline := analyzer.NewPipeline(registry, churnAnalyzer, aggregator)
 
 	// Configure analysis options
 	options := analyzer.AnalysisOptions{
-		RootPath:         rootPath,
-		Since:            since,
-		IncludeLanguages: allLanguages,
-		ExcludePatterns:  allExcludePatterns,
-		IncludeChurn:     !shouldSkipChurn,
-		MaxWorkers:       cfg.Analysis.MaxWorkers,
-		Thresholds:       cfg.Thresholds,
-		ProgressCallback: func(file string, current int, total int) {
+		RootPath:                   rootPath,
+		Since:                      since,
+		IncludeLanguages:           allLanguages,
+		ExcludePatterns:            allExcludePatterns,
+		ExcludeDirs:                allExcludeDirs,
+		IncludeChurn:               !shouldSkipChurn && churnAvailable(churnAnalyzer, rootPath),
+		MaxWorkers:                 cfg.Analysis.MaxWorkers,
+		MaxFileSize:                fileSizeLimit,
+		SkipGenerated:              cfg.Analysis.SkipGenerated && !includeGenerated,
+		MIVariant:                  cfg.Analysis.MIVariant,
+		CountStdlibCalls:           cfg.Analysis.CountStdlibCalls,
+		CountAnonymousFunctions:    cfg.Analysis.CountAnonymousFunctions,
+		ChurnMetric:                cfg.Analysis.ChurnMetric,
+		PathStyle:                  cfg.Analysis.PathStyle,
+		MinFunctionLines:           cfg.Analysis.MinFunctionLines,
+		ExcludeTrivialFromAverages: cfg.Analysis.ExcludeTrivialFromAverages,
+		AverageMethod:              cfg.Analysis.AverageMethod,
+		IncludeTests:               includeTests,
+		TimeoutPerFile:             cfg.Analysis.TimeoutPerFile,
+		StrictParse:                strictParse,
+		CoverageProfile:            coverageProfilePath,
+		Thresholds:                 cfg.Thresholds,
+		Scoring:                    cfg.Scoring,
+		Reports:                    cfg.Reports,
+	}
+
+	if !quietMode {
+		options.ProgressCallback = func(file string, current int, total int) {
 			percent := 0
 			if total > 0 {
 				percent = (current * 100) / total
@@ -388,7 +582,7 @@ func runAnalyze(cmd *cobra.Command, args []string) {
 			filledWidth := (percent * barWidth) / 100
 			bar := strings.Repeat("█", filledWidth) + strings.Repeat("░", barWidth-filledWidth)
 			fmt.Printf("\r📊 [%3d%%] [%s] [%d/%d] %s", percent, bar, current, total, truncate(file, 40))
-		},
+		}
 	}
 
 	// Run analysis
@@ -398,20 +592,34 @@ func runAnalyze(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	fmt.Printf("\n\n✅ Analysis complete!\n\n")
+	analyzeLogf("\n\n✅ Analysis complete!\n\n")
+
+	if trendAwareSeverity {
+		escalateWorseningConcerns(result, cfg.Thresholds)
+	}
 
 	// Print summary
-	printSummary(result)
+	if !quietMode {
+		printSummary(result, cfg.Reports.ScorePrecision())
+		if summaryGroupBy == groupByModule {
+			printGroupBreakdown(result)
+		}
+		if compareIndustry {
+			printIndustryComparison(result)
+		}
+	}
 
 	// Create storage backend with auto-detection
-	fmt.Printf("💾 Saving to database...\n")
-	dbPath, err := storage.DetectOrCreateDatabase(rootPath)
+	var regressionAlert *folderRegressionAlert
+	analyzeLogf("💾 Saving to database...\n")
+	dbPath, err := locateDatabase(rootPath)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: could not setup database: %v\n", err)
 	} else {
 		storageBackend, err := storage.NewBackend(storage.BackendConfig{
-			Type: "sqlite",
-			Path: dbPath,
+			Type:       "sqlite",
+			Path:       dbPath,
+			Repository: repositoryScope(rootPath),
 		})
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: could not create storage backend: %v\n", err)
@@ -420,25 +628,31 @@ func runAnalyze(cmd *cobra.Command, args []string) {
 
 			// Save to database
 			metadata := storage.SnapshotMetadata{
-				KaizenVersion: "1.0.0", // TODO: Use actual version
+				KaizenVersion: internal.Version,
+				Note:          snapshotNote,
+				Repository:    snapshotRepository(rootPath),
 			}
 
-			fmt.Printf("  [1/3] Writing snapshot data...")
+			analyzeLogf("  [1/3] Writing snapshot data...")
 			snapshotID, err := storageBackend.Save(result, metadata)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "\n  Warning: could not save to database: %v\n", err)
 			} else {
-				fmt.Printf(" ✓\n")
-				fmt.Printf("💾 Saved to database (ID: %d)\n", snapshotID)
+				analyzeLogf(" ✓\n")
+				analyzeLogf("💾 Saved to database (ID: %d)\n", snapshotID)
+
+				if alertRegressions {
+					regressionAlert = findFolderRegressions(storageBackend, snapshotID, cfg.Reports.FolderRegressionPercent)
+				}
 
 				// Try to save ownership data if CODEOWNERS exists
 				codeownersPath := findCodeOwnersFile(rootPath)
 				if codeownersPath != "" {
-					fmt.Printf("  [2/3] Parsing CODEOWNERS...")
-					codeowners, err := ownership.ParseCodeOwners(codeownersPath)
+					analyzeLogf("  [2/3] Parsing CODEOWNERS...")
+					codeowners, err := ownership.ParseCodeOwnersTree(rootPath)
 					if err == nil {
-						fmt.Printf(" ✓\n")
-						fmt.Printf("  [3/3] Aggregating team metrics...")
+						analyzeLogf(" ✓\n")
+						analyzeLogf("  [3/3] Aggregating team metrics...")
 						aggregator := ownership.NewAggregator(codeowners)
 						ownerMetrics, fileOwnership := aggregator.AggregateByOwner(result)
 
@@ -460,29 +674,58 @@ func runAnalyze(cmd *cobra.Command, args []string) {
 						if err != nil {
 							fmt.Fprintf(os.Stderr, "\n  Warning: could not save ownership data: %v\n", err)
 						} else {
-							fmt.Printf(" ✓\n")
-							fmt.Printf("👥 Saved ownership data for %d owner(s)\n", len(ownerMetrics))
+							analyzeLogf(" ✓\n")
+							analyzeLogf("👥 Saved ownership data for %d owner(s)\n", len(ownerMetrics))
 						}
 					} else {
-						fmt.Printf(" ✗\n")
+						analyzeLogf(" ✗\n")
 					}
 				} else {
-					fmt.Printf("  [2/3] No CODEOWNERS found (skipped)\n")
+					analyzeLogf("  [2/3] No CODEOWNERS found (skipped)\n")
 				}
 			}
 		}
 	}
 
 	// Save results to JSON file
-	err = saveResults(result, outputFile)
+	err = saveResults(result, outputFile, compactJSON)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error saving results: %v\n", err)
 		os.Exit(1)
 	}
 
-	fmt.Printf("💾 Results saved to: %s\n", outputFile)
-	fmt.Printf("\nNext steps:\n")
-	fmt.Printf("  kaizen visualize --input=%s --metric=hotspot\n", outputFile)
+	analyzeLogf("💾 Results saved to: %s\n", outputFile)
+
+	if perFileOutput != "" {
+		written, err := writePerFileResults(result, perFileOutput, compactJSON)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing per-file results: %v\n", err)
+			os.Exit(1)
+		}
+		analyzeLogf("📄 Per-file results (%d) saved to: %s\n", written, perFileOutput)
+	}
+
+	analyzeLogf("\nNext steps:\n")
+	analyzeLogf("  kaizen visualize --input=%s --metric=hotspot\n", outputFile)
+
+	if jsonOnly {
+		data, err := marshalResults(result, compactJSON)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error marshaling JSON: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(data))
+	}
+
+	printFolderRegressions(regressionAlert)
+	enforceGradeGate(result, cfg.Reports.ScorePrecision())
+}
+
+// analyzeLogf prints analyze progress output unless --quiet or --json-only is set
+func analyzeLogf(format string, args ...interface{}) {
+	if !quietMode {
+		fmt.Printf(format, args...)
+	}
 }
 
 func parseSinceTime(sinceStr string) (time.Time, error) {
@@ -505,16 +748,44 @@ func parseSinceTime(sinceStr string) (time.Time, error) {
 	return time.Time{}, fmt.Errorf("invalid --since format (use '30d' or '2024-01-01')")
 }
 
-func printSummary(result *models.AnalysisResult) {
+func printSummary(result *models.AnalysisResult, precision int) {
 	summary := result.Summary
 
 	fmt.Printf("📊 Summary:\n")
 	fmt.Printf("  Files analyzed:     %d\n", summary.TotalFiles)
 	fmt.Printf("  Total functions:    %d\n", summary.TotalFunctions)
+	if result.MinFunctionLines > 0 {
+		averagesNote := "still in averages"
+		if result.TrivialExcludedFromAverages {
+			averagesNote = "left out of averages"
+		}
+		fmt.Printf("  Trivial functions:  %d (under %d lines; no concerns, %s)\n", summary.TrivialFunctionCount, result.MinFunctionLines, averagesNote)
+	}
 	fmt.Printf("  Total lines:        %d\n", summary.TotalLines)
-	fmt.Printf("  Code lines:         %d\n\n", summary.TotalCodeLines)
+	fmt.Printf("  Code lines:         %d\n", summary.TotalCodeLines)
+	if len(result.APISurface) > 0 {
+		fmt.Printf("  Public API:         %d functions, %d types in %d package(s)\n",
+			summary.ExportedFunctionCount, summary.ExportedTypeCount, len(result.APISurface))
+	}
+	fmt.Println()
 
-	fmt.Printf("📈 Averages:\n")
+	if result.GeneratedFilesSkipped > 0 {
+		fmt.Printf("🧬 Skipped %d generated file(s) (--include-generated to analyze them)\n\n", result.GeneratedFilesSkipped)
+	}
+
+	if len(result.SkippedFiles) > 0 {
+		fmt.Printf("⚠️  Coverage incomplete: %d file(s) skipped\n", len(result.SkippedFiles))
+		for _, skipped := range result.SkippedFiles {
+			fmt.Printf("  %s (%s)\n", skipped.Path, skipped.Reason)
+		}
+		fmt.Println()
+	}
+
+	averageMethod := result.AverageMethod
+	if averageMethod == "" {
+		averageMethod = config.DefaultAverageMethod
+	}
+	fmt.Printf("📈 Averages (%s):\n", averageMethod)
 	fmt.Printf("  Cyclomatic complexity: %.1f\n", summary.AverageCyclomaticComplexity)
 	fmt.Printf("  Cognitive complexity:  %.1f\n", summary.AverageCognitiveComplexity)
 	fmt.Printf("  Function length:       %.1f lines\n", summary.AverageFunctionLength)
@@ -526,14 +797,33 @@ func printSummary(result *models.AnalysisResult) {
 	fmt.Printf("  Long functions (>50):       %d\n", summary.LongFunctionCount)
 	fmt.Printf("  Very long functions (>100): %d\n", summary.VeryLongFunctionCount)
 	fmt.Printf("  🔥 Hotspots:                %d\n", summary.HotspotCount)
+	fmt.Printf("  🎯 Hotspots per KLOC:       %.2f\n", summary.HotspotDensity)
+	fmt.Printf("  ⚠️  Concerns per KLOC:       %.2f\n", summary.ConcernDensity)
+
+	if result.TestStats != nil {
+		printTestStats(result.TestStats)
+	}
 
 	// Print score report if available
 	if result.ScoreReport != nil {
-		printScoreReport(result.ScoreReport)
+		printScoreReport(result.ScoreReport, precision)
 	}
 }
 
-func printScoreReport(report *models.ScoreReport) {
+// printTestStats prints the test code summary kept apart from the production metrics above
+func printTestStats(stats *models.TestMetrics) {
+	fmt.Printf("\n🧪 Test Code (not graded):\n")
+	fmt.Printf("  Test files:            %d\n", stats.TotalFiles)
+	fmt.Printf("  Test functions:        %d\n", stats.TotalFunctions)
+	fmt.Printf("  Code lines:            %d\n", stats.TotalCodeLines)
+	fmt.Printf("  Cyclomatic complexity: %.1f avg, %d max\n", stats.AverageCyclomaticComplexity, stats.MaxCyclomaticComplexity)
+	fmt.Printf("  Cognitive complexity:  %.1f avg\n", stats.AverageCognitiveComplexity)
+	fmt.Printf("  Function length:       %.1f lines avg\n", stats.AverageFunctionLength)
+	fmt.Printf("  High complexity (>10): %d\n", stats.HighComplexityCount)
+	fmt.Printf("  Long functions (>50):  %d\n", stats.LongFunctionCount)
+}
+
+func printScoreReport(report *models.ScoreReport, precision int) {
 	fmt.Printf("\n")
 	fmt.Printf("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━\n")
 	fmt.Printf("📋 Code Health Report\n")
@@ -541,26 +831,26 @@ func printScoreReport(report *models.ScoreReport) {
 
 	// Print grade with color coding
 	gradeColor := getGradeColor(report.OverallGrade)
-	fmt.Printf("Overall Grade: %s%s%s (%.0f/100)\n\n", gradeColor, report.OverallGrade, colorReset, report.OverallScore)
+	fmt.Printf("Overall Grade: %s%s%s (%s/100)\n\n", ansi(gradeColor), report.OverallGrade, ansi(colorReset), reports.FormatScore(report.OverallScore, precision))
 
 	// Print component scores
 	fmt.Printf("Component Scores:\n")
-	printComponentScore("Complexity", report.ComponentScores.Complexity)
-	printComponentScore("Maintainability", report.ComponentScores.Maintainability)
+	printComponentScore("Complexity", report.ComponentScores.Complexity, precision)
+	printComponentScore("Maintainability", report.ComponentScores.Maintainability, precision)
 	if report.HasChurnData {
-		printComponentScore("Churn", report.ComponentScores.Churn)
+		printComponentScore("Churn", report.ComponentScores.Churn, precision)
 	} else {
 		fmt.Printf("  %-17s %s (no churn data)\n", "Churn:", "N/A")
 	}
-	printComponentScore("Function Size", report.ComponentScores.FunctionSize)
-	printComponentScore("Code Structure", report.ComponentScores.CodeStructure)
+	printComponentScore("Function Size", report.ComponentScores.FunctionSize, precision)
+	printComponentScore("Code Structure", report.ComponentScores.CodeStructure, precision)
 	fmt.Printf("\n")
 
 	// Print concerns
 	printConcerns(report.Concerns)
 }
 
-func printComponentScore(name string, score models.CategoryScore) {
+func printComponentScore(name string, score models.CategoryScore, precision int) {
 	barWidth := 10
 	filled := int(score.Score / 10)
 	if filled > barWidth {
@@ -569,7 +859,7 @@ func printComponentScore(name string, score models.CategoryScore) {
 
 	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
 	color := getScoreColor(score.Score)
-	fmt.Printf("  %-17s %s%s%s %.0f/100 (%s)\n", name+":", color, bar, colorReset, score.Score, score.Category)
+	fmt.Printf("  %-17s %s%s%s %s/100 (%s)\n", name+":", ansi(color), bar, ansi(colorReset), reports.FormatScore(score.Score, precision), score.Category)
 }
 
 func printConcerns(concerns []models.Concern) {
@@ -603,12 +893,14 @@ func printConcerns(concerns []models.Concern) {
 }
 
 func printConcern(concern models.Concern, color string, label string) {
-	fmt.Printf("\n  %s[%s]%s %s\n", color, label, colorReset, concern.Title)
+	fmt.Printf("\n  %s[%s]%s %s\n", ansi(color), label, ansi(colorReset), concern.Title)
 	fmt.Printf("    %s\n", concern.Description)
 
 	for _, item := range concern.AffectedItems {
 		location := item.FilePath
-		if item.Line > 0 {
+		if item.Line > 0 && item.EndLine > item.Line {
+			location = fmt.Sprintf("%s:%d-%d", item.FilePath, item.Line, item.EndLine)
+		} else if item.Line > 0 {
 			location = fmt.Sprintf("%s:%d", item.FilePath, item.Line)
 		}
 		if item.FunctionName != "" {
@@ -617,6 +909,9 @@ func printConcern(concern models.Concern, color string, label string) {
 			fmt.Printf("    - %s\n", location)
 		}
 	}
+	if concern.OmittedItems > 0 {
+		fmt.Printf("    ... and %d more (raise reports.max_items_per_concern to list them)\n", concern.OmittedItems)
+	}
 }
 
 func filterConcernsBySeverity(concerns []models.Concern, severity string) []models.Concern {
@@ -661,19 +956,18 @@ func getScoreColor(score float64) string {
 	}
 }
 
-// ANSI color codes
-const (
-	colorReset  = "\033[0m"
-	colorRed    = "\033[31m"
-	colorGreen  = "\033[32m"
-	colorYellow = "\033[33m"
-	colorBlue   = "\033[34m"
-	colorCyan   = "\033[36m"
-	colorOrange = "\033[38;5;208m"
-)
+// loadExcludePatterns returns the .kaizenignore and analysis.exclude patterns for a source
+// tree, falling back to the default excludes when no config can be loaded
+func loadExcludePatterns(path string) []string {
+	cfg, err := config.LoadConfig(path)
+	if err != nil {
+		cfg = config.DefaultConfig()
+	}
+	return cfg.GetExcludePatterns()
+}
 
-func saveResults(result *models.AnalysisResult, filename string) error {
-	data, err := json.MarshalIndent(result, "", "  ")
+func saveResults(result *models.AnalysisResult, filename string, compact bool) error {
+	data, err := marshalResults(result, compact)
 	if err != nil {
 		return fmt.Errorf("failed to marshal results: %w", err)
 	}
@@ -686,6 +980,15 @@ func saveResults(result *models.AnalysisResult, filename string) error {
 	return nil
 }
 
+// marshalResults encodes result as JSON, indented with two spaces for reading or minified when
+// compact is set
+func marshalResults(result *models.AnalysisResult, compact bool) ([]byte, error) {
+	if compact {
+		return json.Marshal(result)
+	}
+	return json.MarshalIndent(result, "", "  ")
+}
+
 func truncate(str string, maxLen int) string {
 	if len(str) <= maxLen {
 		return str
@@ -694,39 +997,38 @@ func truncate(str string, maxLen int) string {
 }
 
 func runVisualize(cmd *cobra.Command, args []string) {
+	validateTheme(htmlTheme)
+	validateSizeBy(treemapSizeBy)
+	validateMaxDepth(treemapMaxDepth)
+	validateLabelThreshold(treemapLabelThreshold)
 	fmt.Printf("📊 Kaizen Visualization\n\n")
 
 	// Load results
-	data, err := os.ReadFile(inputFile)
+	result, err := loadAnalysisFromFile(inputFile)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading input file: %v\n", err)
-		os.Exit(1)
-	}
-
-	var result models.AnalysisResult
-	err = json.Unmarshal(data, &result)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error parsing JSON: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
 	// Handle different output formats
 	switch outputFormat {
 	case "html":
-		generateHTMLOutput(&result)
+		generateHTMLOutput(result)
 	case "svg":
-		generateSVGOutput(&result)
+		generateSVGOutput(result)
+	case "treejson":
+		generateTreeJSONOutput(result)
 	case "terminal":
-		generateTerminalOutput(&result)
+		generateTerminalOutput(result)
 	default:
-		fmt.Fprintf(os.Stderr, "Unknown format: %s (use 'terminal', 'html', or 'svg')\n", outputFormat)
+		fmt.Fprintf(os.Stderr, "Unknown format: %s (use 'terminal', 'html', 'svg', or 'treejson')\n", outputFormat)
 		os.Exit(1)
 	}
 }
 
 func generateTerminalOutput(result *models.AnalysisResult) {
 	// Create visualizer
-	visualizer := visualization.NewTerminalVisualizer()
+	visualizer := visualization.NewTerminalVisualizer(reportPrecision("."))
 
 	// Render heat map
 	heatMap := visualizer.RenderHeatMap(result, metric)
@@ -741,10 +1043,11 @@ func generateTerminalOutput(result *models.AnalysisResult) {
 
 func generateHTMLOutput(result *models.AnalysisResult) {
 	// Create HTML visualizer
-	htmlVisualizer := visualization.NewHTMLVisualizer()
+	htmlVisualizer := visualization.NewHTMLVisualizer(treemapSizeBy, treemapMaxDepth, treemapLabelThreshold, reportPrecision("."))
+	htmlVisualizer.SetTemplate(loadHTMLTemplate())
 
 	// Generate HTML
-	html, err := htmlVisualizer.GenerateHTML(result)
+	html, err := htmlVisualizer.GenerateHTML(result, htmlTheme == themeLight)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error generating HTML: %v\n", err)
 		os.Exit(1)
@@ -772,6 +1075,37 @@ func generateHTMLOutput(result *models.AnalysisResult) {
 	}
 }
 
+func generateTreeJSONOutput(result *models.AnalysisResult) {
+	// Determine output filename, deriving it from a custom --output like SVG does
+	outputFilename := "kaizen-tree.json"
+	if htmlOutput != "kaizen-heatmap.html" {
+		outputFilename = htmlOutput
+		if strings.HasSuffix(htmlOutput, ".html") {
+			outputFilename = strings.TrimSuffix(htmlOutput, ".html") + ".json"
+		}
+	}
+
+	htmlVisualizer := visualization.NewHTMLVisualizer(treemapSizeBy, treemapMaxDepth, treemapLabelThreshold, reportPrecision("."))
+
+	treeJSON, err := htmlVisualizer.GenerateTreeJSON(result)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error generating tree JSON: %v\n", err)
+		os.Exit(1)
+	}
+
+	err = os.WriteFile(outputFilename, treeJSON, 0644)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing tree JSON file: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✅ Treemap hierarchy written: %s\n", outputFilename)
+	fmt.Printf("   Cell size: %s\n", treemapSizeBy)
+	if treemapMaxDepth > 0 {
+		fmt.Printf("   Max depth: %d\n", treemapMaxDepth)
+	}
+}
+
 func generateSVGOutput(result *models.AnalysisResult) {
 	// Determine output filename
 	outputFilename := htmlOutput
@@ -784,7 +1118,7 @@ func generateSVGOutput(result *models.AnalysisResult) {
 	}
 
 	// Create SVG visualizer
-	svgVisualizer := visualization.NewSVGVisualizer(svgWidth, svgHeight)
+	svgVisualizer := visualization.NewSVGVisualizer(svgWidth, svgHeight, reportPrecision("."))
 
 	// Generate SVG
 	svg, err := svgVisualizer.GenerateSVG(result, metric)
@@ -834,51 +1168,37 @@ func openInBrowser(filename string) error {
 	return cmd.Start()
 }
 
+// findCodeOwnersFile returns the repository's CODEOWNERS file, or the first nested one when the
+// repository has none at its root, or "" when the tree has no CODEOWNERS file
 func findCodeOwnersFile(rootPath string) string {
-	// Check common locations
-	locations := []string{
-		filepath.Join(rootPath, ".github", "CODEOWNERS"),
-		filepath.Join(rootPath, "CODEOWNERS"),
-		filepath.Join(rootPath, ".gitlab", "CODEOWNERS"),
-		filepath.Join(rootPath, ".gitea", "CODEOWNERS"),
+	codeownersFiles := ownership.FindCodeOwnersFiles(rootPath)
+	if len(codeownersFiles) == 0 {
+		return ""
 	}
-
-	for _, loc := range locations {
-		if _, err := os.Stat(loc); err == nil {
-			return loc
-		}
-	}
-
-	return ""
+	return codeownersFiles[0]
 }
 
 func runReportOwners(cmd *cobra.Command, args []string) {
+	validateGroupBy(reportGroupBy)
+	validateTheme(htmlTheme)
+
 	cwd, err := os.Getwd()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: could not get current directory: %v\n", err)
 		os.Exit(1)
 	}
 
-	// Determine snapshot ID
-	var snapshotID int64
-	if len(args) > 0 {
-		_, err := fmt.Sscanf(args[0], "%d", &snapshotID)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: invalid snapshot ID: %v\n", err)
-			os.Exit(1)
-		}
-	}
-
 	// Create storage backend
-	dbPath, err := storage.DetectOrCreateDatabase(cwd)
+	dbPath, err := locateDatabase(cwd)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: could not locate database: %v\n", err)
 		os.Exit(1)
 	}
 
 	backend, err := storage.NewBackend(storage.BackendConfig{
-		Type: "sqlite",
-		Path: dbPath,
+		Type:       "sqlite",
+		Path:       dbPath,
+		Repository: repositoryScope(cwd),
 	})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: could not open database: %v\n", err)
@@ -887,6 +1207,11 @@ func runReportOwners(cmd *cobra.Command, args []string) {
 	defer func() { _ = backend.Close() }()
 
 	// Get snapshot
+	var snapshotID int64
+	if len(args) > 0 {
+		snapshotID = resolveSnapshotRef(backend, args[0])
+	}
+
 	var snapshot *models.AnalysisResult
 	if snapshotID > 0 {
 		snapshot, err = backend.GetByID(snapshotID)
@@ -899,42 +1224,85 @@ func runReportOwners(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	// Find CODEOWNERS file
-	codeownersPath := reportCodeOwnersPath
-	if codeownersPath == "" {
-		codeownersPath = findCodeOwnersFile(cwd)
-	}
+	// Resolve relative file paths against this checkout, where CODEOWNERS and git blame are read,
+	// rather than the machine the snapshot was taken on
+	snapshot.Repository = analyzer.FindRepositoryRoot(cwd)
 
-	if codeownersPath == "" {
-		fmt.Fprintf(os.Stderr, "Error: CODEOWNERS file not found (specify with --codeowners)\n")
-		os.Exit(1)
-	}
+	// Resolve ownership rules
+	var codeowners *ownership.CodeOwners
+	switch reportOwnersBy {
+	case "codeowners":
+		codeowners = loadReportCodeOwners(cwd)
+	case "blame":
+		filePaths := make([]string, 0, len(snapshot.Files))
+		for _, file := range snapshot.Files {
+			filePaths = append(filePaths, snapshot.ResolvePath(file.Path))
+		}
 
-	// Parse CODEOWNERS
-	codeowners, err := ownership.ParseCodeOwners(codeownersPath)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: could not parse CODEOWNERS: %v\n", err)
+		codeowners, err = ownership.AttributeByGitBlame(cwd, filePaths...)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not attribute ownership from git blame: %v\n", err)
+			os.Exit(1)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "Error: unsupported ownership source '%s' (use codeowners or blame)\n", reportOwnersBy)
 		os.Exit(1)
 	}
 
 	// Generate report
 	aggregator := ownership.NewAggregator(codeowners)
+	precision := reportPrecision(cwd)
+	if reportGroupBy == groupByModule && hasModuleStats(snapshot) {
+		renderOwnerReportsByModule(aggregator, snapshot, snapshotID, precision)
+		return
+	}
 	report := aggregator.GetOwnerReport(snapshot, snapshotID, snapshot.AnalyzedAt.Format("2006-01-02 15:04:05"))
+	applyShowUnowned(report)
 
 	// Render output
 	switch reportFormat {
 	case "ascii":
-		fmt.Print(ownership.RenderOwnerReportASCII(report))
+		fmt.Print(ownership.RenderOwnerReportASCII(report, precision))
 	case "json":
 		renderReportJSON(report, reportOutput)
 	case "html":
-		renderReportHTML(report, reportOutput, reportOpen)
+		renderReportHTML(report, reportOutput, reportOpen, precision)
 	default:
 		fmt.Fprintf(os.Stderr, "Error: unsupported format '%s'\n", reportFormat)
 		os.Exit(1)
 	}
 }
 
+// applyShowUnowned leaves the unowned files out of an owners report unless --show-unowned is set
+func applyShowUnowned(report *ownership.OwnerReport) {
+	if !reportShowUnowned {
+		report.UnownedFiles = nil
+	}
+}
+
+// loadReportCodeOwners finds and parses the CODEOWNERS files for the owners report. An
+// explicit --codeowners file is used on its own; otherwise nested CODEOWNERS files are included.
+func loadReportCodeOwners(cwd string) *ownership.CodeOwners {
+	var codeowners *ownership.CodeOwners
+	var err error
+	if reportCodeOwnersPath != "" {
+		codeowners, err = ownership.ParseCodeOwners(reportCodeOwnersPath)
+	} else {
+		codeowners, err = ownership.ParseCodeOwnersTree(cwd)
+	}
+
+	if errors.Is(err, ownership.ErrNoCodeOwners) {
+		fmt.Fprintf(os.Stderr, "Error: CODEOWNERS file not found (specify with --codeowners)\n")
+		os.Exit(1)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not parse CODEOWNERS: %v\n", err)
+		os.Exit(1)
+	}
+
+	return codeowners
+}
+
 func renderReportJSON(report *ownership.OwnerReport, outputPath string) {
 	jsonStr, err := ownership.RenderOwnerReportJSON(report)
 	if err != nil {
@@ -954,8 +1322,8 @@ func renderReportJSON(report *ownership.OwnerReport, outputPath string) {
 	}
 }
 
-func renderReportHTML(report *ownership.OwnerReport, outputPath string, open bool) {
-	html, err := ownership.RenderOwnerReportHTML(report)
+func renderReportHTML(report *ownership.OwnerReport, outputPath string, open bool, precision int) {
+	html, err := ownership.RenderOwnerReportHTML(report, htmlTheme == themeLight, precision)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: could not generate report: %v\n", err)
 		os.Exit(1)
@@ -992,15 +1360,16 @@ func runHistoryList(cmd *cobra.Command, args []string) {
 	}
 
 	// Create storage backend
-	dbPath, err := storage.DetectOrCreateDatabase(cwd)
+	dbPath, err := locateDatabase(cwd)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: could not locate database: %v\n", err)
 		os.Exit(1)
 	}
 
 	backend, err := storage.NewBackend(storage.BackendConfig{
-		Type: "sqlite",
-		Path: dbPath,
+		Type:       "sqlite",
+		Path:       dbPath,
+		Repository: repositoryScope(cwd),
 	})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: could not open database: %v\n", err)
@@ -1023,8 +1392,8 @@ func runHistoryList(cmd *cobra.Command, args []string) {
 	// Print header
 	fmt.Printf("\n📋 Analysis Snapshots (%d)\n", len(snapshots))
 	fmt.Println("─────────────────────────────────────────────────────────────────────────────")
-	fmt.Printf("%-4s │ %-19s │ %-8s │ %-8s │ %-5s │ %-7s │ %s\n",
-		"ID", "Date", "Grade", "Score", "Files", "Funcs", "Commit")
+	fmt.Printf("%-4s │ %-19s │ %-8s │ %-8s │ %-5s │ %-7s │ %-7s │ %s\n",
+		"ID", "Date", "Grade", "Score", "Files", "Funcs", "Commit", "Tags / Note")
 	fmt.Println("─────────────────────────────────────────────────────────────────────────────")
 
 	// Print snapshots
@@ -1037,7 +1406,12 @@ func runHistoryList(cmd *cobra.Command, args []string) {
 			commit = "-"
 		}
 
-		fmt.Printf("%-4d │ %s │ %-8s │ %7.1f │ %-5d │ %-7d │ %s\n",
+		label := historyListLabel(snap.Tags, snap.Note)
+		if allRepositories && snap.Repository != "" {
+			label = strings.TrimSpace("[" + filepath.Base(snap.Repository) + "] " + label)
+		}
+
+		fmt.Printf("%-4d │ %s │ %-8s │ %7.1f │ %-5d │ %-7d │ %-7s │ %s\n",
 			snap.ID,
 			snap.AnalyzedAt.Format("2006-01-02 15:04:05"),
 			snap.OverallGrade,
@@ -1045,20 +1419,13 @@ func runHistoryList(cmd *cobra.Command, args []string) {
 			snap.TotalFiles,
 			snap.TotalFunctions,
 			commit,
+			label,
 		)
 	}
 	fmt.Println()
 }
 
 func runHistoryShow(cmd *cobra.Command, args []string) {
-	// Parse ID
-	var snapshotID int64
-	_, err := fmt.Sscanf(args[0], "%d", &snapshotID)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: invalid snapshot ID: %v\n", err)
-		os.Exit(1)
-	}
-
 	// Get current directory
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -1067,15 +1434,16 @@ func runHistoryShow(cmd *cobra.Command, args []string) {
 	}
 
 	// Create storage backend
-	dbPath, err := storage.DetectOrCreateDatabase(cwd)
+	dbPath, err := locateDatabase(cwd)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: could not locate database: %v\n", err)
 		os.Exit(1)
 	}
 
 	backend, err := storage.NewBackend(storage.BackendConfig{
-		Type: "sqlite",
-		Path: dbPath,
+		Type:       "sqlite",
+		Path:       dbPath,
+		Repository: repositoryScope(cwd),
 	})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: could not open database: %v\n", err)
@@ -1084,7 +1452,7 @@ func runHistoryShow(cmd *cobra.Command, args []string) {
 	defer func() { _ = backend.Close() }()
 
 	// Get snapshot
-	summary, err := backend.GetByIDSummary(snapshotID)
+	summary, err := backend.GetByIDSummary(resolveSnapshotRef(backend, args[0]))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: could not retrieve snapshot: %v\n", err)
 		os.Exit(1)
@@ -1095,21 +1463,56 @@ func runHistoryShow(cmd *cobra.Command, args []string) {
 	fmt.Printf("Analyzed At:              %s\n", summary.AnalyzedAt.Format("2006-01-02 15:04:05"))
 	fmt.Printf("Git Commit:               %s\n", summary.GitCommitHash)
 	fmt.Printf("Git Branch:               %s\n", summary.GitBranch)
+	if len(summary.Tags) > 0 {
+		fmt.Printf("Tags:                     %s\n", strings.Join(summary.Tags, ", "))
+	}
+	if summary.Note != "" {
+		fmt.Printf("Note:                     %s\n", summary.Note)
+	}
+	precision := reportPrecision(cwd)
 	fmt.Printf("\nMetrics:\n")
 	fmt.Printf("  Overall Grade:          %s\n", summary.OverallGrade)
-	fmt.Printf("  Overall Score:          %.1f/100\n", summary.OverallScore)
-	fmt.Printf("  Complexity Score:       %.1f/100\n", summary.ComplexityScore)
-	fmt.Printf("  Maintainability Score:  %.1f/100\n", summary.MaintainabilityScore)
-	fmt.Printf("  Churn Score:            %.1f/100\n", summary.ChurnScore)
+	fmt.Printf("  Overall Score:          %s/100\n", reports.FormatScore(summary.OverallScore, precision))
+	fmt.Printf("  Complexity Score:       %s/100\n", reports.FormatScore(summary.ComplexityScore, precision))
+	fmt.Printf("  Maintainability Score:  %s/100\n", reports.FormatScore(summary.MaintainabilityScore, precision))
+	fmt.Printf("  Churn Score:            %s/100\n", reports.FormatScore(summary.ChurnScore, precision))
 	fmt.Printf("\nCode Metrics:\n")
 	fmt.Printf("  Total Files:            %d\n", summary.TotalFiles)
 	fmt.Printf("  Total Functions:        %d\n", summary.TotalFunctions)
 	fmt.Printf("  Avg Cyclomatic:         %.1f\n", summary.AvgCyclomaticComplexity)
 	fmt.Printf("  Avg Maintainability:    %.1f\n", summary.AvgMaintainabilityIndex)
 	fmt.Printf("  Hotspot Count:          %d\n", summary.HotspotCount)
+
+	if historySignatureChanges {
+		printSignatureChanges(backend, summary.ID)
+	}
 	fmt.Println()
 }
 
+// printSignatureChanges lists public functions whose parameter count changed since the previous snapshot
+func printSignatureChanges(backend storage.StorageBackend, snapshotID int64) {
+	previousID, changes, err := backend.GetSignatureChanges(snapshotID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not detect signature changes: %v\n", err)
+		os.Exit(1)
+	}
+
+	if previousID == 0 {
+		fmt.Printf("\nSignature Changes: no earlier snapshot to compare with\n")
+		return
+	}
+
+	fmt.Printf("\nSignature Changes (since snapshot #%d):\n", previousID)
+	if len(changes) == 0 {
+		fmt.Printf("  No public function changed its parameter count\n")
+		return
+	}
+
+	for _, change := range changes {
+		fmt.Printf("  %s: %s  %d → %d parameters\n", change.FilePath, change.FunctionName, change.PreviousParameterCount, change.ParameterCount)
+	}
+}
+
 func runHistoryPrune(cmd *cobra.Command, args []string) {
 	// Get current directory
 	cwd, err := os.Getwd()
@@ -1119,15 +1522,16 @@ func runHistoryPrune(cmd *cobra.Command, args []string) {
 	}
 
 	// Create storage backend
-	dbPath, err := storage.DetectOrCreateDatabase(cwd)
+	dbPath, err := locateDatabase(cwd)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: could not locate database: %v\n", err)
 		os.Exit(1)
 	}
 
 	backend, err := storage.NewBackend(storage.BackendConfig{
-		Type: "sqlite",
-		Path: dbPath,
+		Type:       "sqlite",
+		Path:       dbPath,
+		Repository: repositoryScope(cwd),
 	})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: could not open database: %v\n", err)
@@ -1145,8 +1549,130 @@ func runHistoryPrune(cmd *cobra.Command, args []string) {
 	fmt.Printf("✅ Removed %d snapshot(s) older than %d days\n", deleted, historyLimit)
 }
 
+func runHistoryTag(cmd *cobra.Command, args []string) {
+	backend := openHistoryBackend()
+	defer func() { _ = backend.Close() }()
+
+	snapshotID := resolveSnapshotRef(backend, args[0])
+	label := args[1]
+
+	if err := backend.TagSnapshot(snapshotID, label); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not tag snapshot: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✅ Tagged snapshot #%d as '%s'\n", snapshotID, label)
+}
+
+func runHistoryAnnotate(cmd *cobra.Command, args []string) {
+	backend := openHistoryBackend()
+	defer func() { _ = backend.Close() }()
+
+	snapshotID := resolveSnapshotRef(backend, args[0])
+	note := strings.TrimSpace(args[1])
+
+	if err := backend.AnnotateSnapshot(snapshotID, note); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not annotate snapshot: %v\n", err)
+		os.Exit(1)
+	}
+
+	if note == "" {
+		fmt.Printf("✅ Cleared note on snapshot #%d\n", snapshotID)
+		return
+	}
+	fmt.Printf("✅ Annotated snapshot #%d\n", snapshotID)
+}
+
+// historyListLabel joins a snapshot's tags and note for the last column of history list,
+// shortening long notes so rows stay on one line
+func historyListLabel(tags []string, note string) string {
+	const maxNoteLength = 40
+
+	label := strings.Join(tags, ", ")
+	if note == "" {
+		return label
+	}
+
+	if runes := []rune(note); len(runes) > maxNoteLength {
+		note = string(runes[:maxNoteLength-1]) + "…"
+	}
+	if label == "" {
+		return note
+	}
+	return label + " · " + note
+}
+
+func runHistoryUntag(cmd *cobra.Command, args []string) {
+	backend := openHistoryBackend()
+	defer func() { _ = backend.Close() }()
+
+	if err := backend.UntagSnapshot(args[0]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not untag snapshot: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✅ Removed tag '%s'\n", args[0])
+}
+
+// openHistoryBackend opens the snapshot database for the current directory, exiting on failure
+func openHistoryBackend() storage.StorageBackend {
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not get current directory: %v\n", err)
+		os.Exit(1)
+	}
+
+	dbPath, err := locateDatabase(cwd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not locate database: %v\n", err)
+		os.Exit(1)
+	}
+
+	backend, err := storage.NewBackend(storage.BackendConfig{
+		Type:       "sqlite",
+		Path:       dbPath,
+		Repository: repositoryScope(cwd),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not open database: %v\n", err)
+		os.Exit(1)
+	}
+
+	return backend
+}
+
+// resolveSnapshotRef turns a snapshot ID or tag into a snapshot ID, exiting on failure
+func resolveSnapshotRef(backend storage.StorageBackend, ref string) int64 {
+	snapshotID, err := backend.ResolveSnapshotRef(ref)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid snapshot reference: %v\n", err)
+		os.Exit(1)
+	}
+	return snapshotID
+}
+
 func runTrend(cmd *cobra.Command, args []string) {
-	metricName := args[0]
+	if trendAll && trendFormat != "ascii" && trendFormat != "terminal" {
+		fmt.Fprintf(os.Stderr, "Error: --all only supports the terminal format\n")
+		os.Exit(1)
+	}
+	if len(trendMetricNames) > 0 {
+		if trendAll {
+			fmt.Fprintf(os.Stderr, "Error: --metrics and --all cannot be combined\n")
+			os.Exit(1)
+		}
+		if trendFormat != "html" && trendFormat != "terminal" {
+			fmt.Fprintf(os.Stderr, "Error: --metrics only supports the html and terminal formats\n")
+			os.Exit(1)
+		}
+	}
+
+	metricName := ""
+	if len(args) > 0 {
+		metricName = args[0]
+	}
+	validateGroupBy(trendGroupBy)
+	validateTheme(htmlTheme)
 
 	// Get current directory
 	cwd, err := os.Getwd()
@@ -1156,15 +1682,16 @@ func runTrend(cmd *cobra.Command, args []string) {
 	}
 
 	// Create storage backend
-	dbPath, err := storage.DetectOrCreateDatabase(cwd)
+	dbPath, err := locateDatabase(cwd)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: could not locate database: %v\n", err)
 		os.Exit(1)
 	}
 
 	backend, err := storage.NewBackend(storage.BackendConfig{
-		Type: "sqlite",
-		Path: dbPath,
+		Type:       "sqlite",
+		Path:       dbPath,
+		Repository: repositoryScope(cwd),
 	})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: could not open database: %v\n", err)
@@ -1175,14 +1702,29 @@ func runTrend(cmd *cobra.Command, args []string) {
 	// Calculate time range
 	endTime := time.Now()
 	var startTime time.Time
-	if trendDays > 0 {
+	if trendFrom != "" {
+		fromSnapshot, err := backend.GetByIDSummary(resolveSnapshotRef(backend, trendFrom))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not retrieve snapshot: %v\n", err)
+			os.Exit(1)
+		}
+		startTime = fromSnapshot.AnalyzedAt
+	} else if trendDays > 0 {
 		startTime = endTime.AddDate(0, 0, -trendDays)
 	} else {
 		startTime = time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
 	}
 
-	// Get time-series data
-	points, err := backend.GetTimeSeries(metricName, trendFolder, startTime, endTime)
+	if trendAll {
+		renderTrendDashboard(backend, startTime, endTime)
+		return
+	}
+	if len(trendMetricNames) > 0 {
+		renderTrendMetrics(backend, startTime, endTime)
+		return
+	}
+
+	points, err := loadTrendPoints(backend, metricName, startTime, endTime, true)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: could not retrieve metric data: %v\n", err)
 		os.Exit(1)
@@ -1197,16 +1739,83 @@ func runTrend(cmd *cobra.Command, args []string) {
 	switch trendFormat {
 	case "ascii":
 		renderTrendASCII(metricName, trendFolder, points)
+	case "terminal":
+		fmt.Print(trending.RenderSparklineDashboard([]trending.MetricSeries{{MetricName: metricName, Points: points}}, trendFolder))
 	case "json":
 		renderTrendJSON(metricName, trendFolder, points, trendOutput)
 	case "html":
-		renderTrendHTML(metricName, trendFolder, points, trendOutput, trendOpen)
+		renderTrendHTML([]trending.MetricSeries{{MetricName: metricName, Points: points}}, trendFolder, trendOutput, trendOpen)
 	default:
 		fmt.Fprintf(os.Stderr, "Error: unsupported format '%s'\n", trendFormat)
 		os.Exit(1)
 	}
 }
 
+// loadTrendPoints fetches a metric's time series for --folder, preferring module-scoped metrics
+// when grouping by module
+func loadTrendPoints(backend storage.StorageBackend, metricName string, startTime, endTime time.Time, warnOnFallback bool) ([]storage.TimeSeriesPoint, error) {
+	if trendGroupBy == groupByModule && trendFolder != "" {
+		points, err := backend.GetScopedTimeSeries(metricName, storage.ScopeModule, trendFolder, startTime, endTime)
+		if err != nil || len(points) > 0 {
+			return points, err
+		}
+		if warnOnFallback {
+			fmt.Fprintf(os.Stderr, "Warning: no module metrics recorded for '%s', falling back to folder grouping\n", trendFolder)
+		}
+	}
+	return backend.GetTimeSeries(metricName, trendFolder, startTime, endTime)
+}
+
+// renderTrendDashboard prints a sparkline for every metric that has data in the time range
+func renderTrendDashboard(backend storage.StorageBackend, startTime, endTime time.Time) {
+	series := make([]trending.MetricSeries, 0, len(trending.DashboardMetrics))
+	for _, metricName := range trending.DashboardMetrics {
+		points, err := loadTrendPoints(backend, metricName, startTime, endTime, false)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not retrieve metric data: %v\n", err)
+			os.Exit(1)
+		}
+		if len(points) > 0 {
+			series = append(series, trending.MetricSeries{MetricName: metricName, Points: points})
+		}
+	}
+
+	if len(series) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: no metric data available (run 'kaizen analyze' first)\n")
+		os.Exit(1)
+	}
+
+	fmt.Print(trending.RenderSparklineDashboard(series, trendFolder))
+}
+
+// renderTrendMetrics charts every --metrics metric together, leaving out metrics without data
+func renderTrendMetrics(backend storage.StorageBackend, startTime, endTime time.Time) {
+	series := make([]trending.MetricSeries, 0, len(trendMetricNames))
+	for _, metricName := range trendMetricNames {
+		points, err := loadTrendPoints(backend, metricName, startTime, endTime, false)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not retrieve metric data: %v\n", err)
+			os.Exit(1)
+		}
+		if len(points) == 0 {
+			fmt.Fprintf(os.Stderr, "Warning: no data available for metric '%s', leaving it out\n", metricName)
+			continue
+		}
+		series = append(series, trending.MetricSeries{MetricName: metricName, Points: points})
+	}
+
+	if len(series) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: no data available for any of the metrics %s\n", strings.Join(trendMetricNames, ", "))
+		os.Exit(1)
+	}
+
+	if trendFormat == "terminal" {
+		fmt.Print(trending.RenderSparklineDashboard(series, trendFolder))
+		return
+	}
+	renderTrendHTML(series, trendFolder, trendOutput, trendOpen)
+}
+
 func renderTrendASCII(metricName, folder string, points []storage.TimeSeriesPoint) {
 	output := trending.RenderASCIIChart(metricName, points, folder)
 	fmt.Print(output)
@@ -1238,8 +1847,14 @@ func renderTrendJSON(metricName, folder string, points []storage.TimeSeriesPoint
 	}
 }
 
-func renderTrendHTML(metricName, folder string, points []storage.TimeSeriesPoint, outputPath string, open bool) {
-	html, err := trending.RenderHTMLChart(metricName, points, folder)
+func renderTrendHTML(series []trending.MetricSeries, folder string, outputPath string, open bool) {
+	var html string
+	var err error
+	if customTemplate := loadHTMLTemplate(); customTemplate != "" {
+		html, err = trending.RenderHTMLChartTemplate(series, folder, htmlTheme == themeLight, customTemplate)
+	} else {
+		html, err = trending.RenderHTMLChart(series, folder, htmlTheme == themeLight)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: could not generate chart: %v\n", err)
 		os.Exit(1)
@@ -1247,7 +1862,11 @@ func renderTrendHTML(metricName, folder string, points []storage.TimeSeriesPoint
 
 	// Determine output file
 	if outputPath == "" {
-		outputPath = trending.FormatChartFilename(metricName)
+		metricNames := make([]string, len(series))
+		for index, metric := range series {
+			metricNames[index] = metric.MetricName
+		}
+		outputPath = trending.FormatChartFilename(strings.Join(metricNames, "-"))
 	}
 
 	err = trending.WriteHTMLToFile(html, outputPath)
@@ -1272,11 +1891,16 @@ func runCallGraph(cmd *cobra.Command, args []string) {
 	fmt.Printf("🔗 Kaizen Call Graph Analysis\n\n")
 	fmt.Printf("Analyzing: %s\n\n", callgraphPath)
 
+	// Merge CLI exclude patterns with config patterns
+	allExcludePatterns := append(loadExcludePatterns(callgraphPath), callgraphExclude...)
+
 	// Create call graph analyzer
 	analyzer := golang.NewCallGraphAnalyzer()
+	analyzer.SetCrossPackage(callgraphCross)
+	analyzer.SetWorkers(callgraphWorkers)
 
 	// Analyze directory
-	graph, err := analyzer.AnalyzeDirectory(callgraphPath)
+	graph, err := analyzer.AnalyzeDirectory(callgraphPath, allExcludePatterns)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error analyzing call graph: %v\n", err)
 		os.Exit(1)
@@ -1423,7 +2047,7 @@ func generateCallGraphHTML(graph *models.CallGraph) {
 		outputFilename += ".html"
 	}
 
-	err := visualization.GenerateCallGraphHTML(graph, outputFilename)
+	err := visualization.GenerateCallGraphHTML(graph, outputFilename, loadHTMLTemplate())
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error generating HTML: %v\n", err)
 		os.Exit(1)
@@ -1465,16 +2089,9 @@ func runSankey(cmd *cobra.Command, args []string) {
 	fmt.Printf("🔄 Generating Sankey diagram...\n\n")
 
 	// Step 1: Load analysis result
-	data, err := os.ReadFile(sankeyInput)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading input file: %v\n", err)
-		os.Exit(1)
-	}
-
-	var result models.AnalysisResult
-	err = json.Unmarshal(data, &result)
+	result, err := loadAnalysisFromFile(sankeyInput)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error parsing JSON: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
@@ -1493,7 +2110,7 @@ func runSankey(cmd *cobra.Command, args []string) {
 
 	fmt.Printf("Analyzing call graph from: %s\n", rootDir)
 	callGraphAnalyzer := golang.NewCallGraphAnalyzer()
-	callGraph, err := callGraphAnalyzer.AnalyzeDirectory(rootDir)
+	callGraph, err := callGraphAnalyzer.AnalyzeDirectory(rootDir, loadExcludePatterns(rootDir))
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error analyzing call graph: %v\n", err)
 		os.Exit(1)
@@ -1517,8 +2134,8 @@ func runSankey(cmd *cobra.Command, args []string) {
 
 	fmt.Printf("Using CODEOWNERS: %s\n", codeownersPath)
 
-	// Step 4: Parse CODEOWNERS
-	codeowners, err := ownership.ParseCodeOwners(codeownersPath)
+	// Step 4: Parse CODEOWNERS, including nested ones
+	codeowners, err := ownership.ParseCodeOwnersTree(rootDir)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error parsing CODEOWNERS: %v\n", err)
 		os.Exit(1)
@@ -1526,7 +2143,7 @@ func runSankey(cmd *cobra.Command, args []string) {
 
 	// Step 5: Aggregate ownership data
 	aggregator := ownership.NewAggregator(codeowners)
-	ownerMetricsMap, fileOwnership := aggregator.AggregateByOwner(&result)
+	ownerMetricsMap, fileOwnership := aggregator.AggregateByOwner(result)
 
 	// Convert map to slice for OwnerReport
 	ownerMetricsList := make([]ownership.OwnerMetrics, 0, len(ownerMetricsMap))
@@ -1544,7 +2161,7 @@ func runSankey(cmd *cobra.Command, args []string) {
 	// Step 6: Build Sankey data
 	fmt.Printf("Aggregating owner → function calls...\n")
 	sankeyData, err := visualization.BuildSankeyData(
-		&result,
+		result,
 		ownerReport,
 		callGraph,
 		sankeyMinOwners,
@@ -1606,15 +2223,16 @@ func runDiff(cmd *cobra.Command, args []string) {
 	}
 
 	// Create storage backend
-	dbPath, err := storage.DetectOrCreateDatabase(cwd)
+	dbPath, err := locateDatabase(cwd)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: could not locate database: %v\n", err)
 		os.Exit(1)
 	}
 
 	backend, err := storage.NewBackend(storage.BackendConfig{
-		Type: "sqlite",
-		Path: dbPath,
+		Type:       "sqlite",
+		Path:       dbPath,
+		Repository: repositoryScope(cwd),
 	})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: could not open database: %v\n", err)
@@ -1622,8 +2240,13 @@ func runDiff(cmd *cobra.Command, args []string) {
 	}
 	defer func() { _ = backend.Close() }()
 
-	// Get the last snapshot for comparison
-	lastSnapshot, err := backend.GetLatest()
+	// Get the snapshot to compare against, defaulting to the most recent one
+	var lastSnapshot *models.AnalysisResult
+	if diffAgainst != "" {
+		lastSnapshot, err = backend.GetByID(resolveSnapshotRef(backend, diffAgainst))
+	} else {
+		lastSnapshot, err = backend.GetLatest()
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: could not retrieve last snapshot: %v\n", err)
 		os.Exit(1)
@@ -1639,7 +2262,7 @@ func runDiff(cmd *cobra.Command, args []string) {
 
 	// Create analysis pipeline with registry
 	languageRegistry := languages.NewRegistry()
-	churnAnalyzer := churn.NewGitChurnAnalyzer(diffPath)
+	churnAnalyzer := newChurnAnalyzer(diffPath)
 	aggregatorImpl := analyzer.NewAggregator()
 
 	pipeline := analyzer.NewPipeline(languageRegistry, churnAnalyzer, aggregatorImpl)
@@ -1653,11 +2276,25 @@ func runDiff(cmd *cobra.Command, args []string) {
 	}
 
 	options := analyzer.AnalysisOptions{
-		RootPath:     diffPath,
-		Since:        since,
-		IncludeChurn: !diffSkipChurn,
-		MaxWorkers:   4,
-		Thresholds:   diffCfg.Thresholds,
+		RootPath:                   diffPath,
+		Since:                      since,
+		IncludeChurn:               !diffSkipChurn && churnAvailable(churnAnalyzer, diffPath),
+		ExcludeDirs:                diffCfg.Analysis.ExcludeDirs,
+		MaxWorkers:                 4,
+		MaxFileSize:                diffCfg.Analysis.MaxFileSize,
+		SkipGenerated:              diffCfg.Analysis.SkipGenerated,
+		MIVariant:                  diffCfg.Analysis.MIVariant,
+		CountStdlibCalls:           diffCfg.Analysis.CountStdlibCalls,
+		CountAnonymousFunctions:    diffCfg.Analysis.CountAnonymousFunctions,
+		ChurnMetric:                diffCfg.Analysis.ChurnMetric,
+		PathStyle:                  diffCfg.Analysis.PathStyle,
+		MinFunctionLines:           diffCfg.Analysis.MinFunctionLines,
+		ExcludeTrivialFromAverages: diffCfg.Analysis.ExcludeTrivialFromAverages,
+		AverageMethod:              diffCfg.Analysis.AverageMethod,
+		TimeoutPerFile:             diffCfg.Analysis.TimeoutPerFile,
+		Thresholds:                 diffCfg.Thresholds,
+		Scoring:                    diffCfg.Scoring,
+		Reports:                    diffCfg.Reports,
 	}
 
 	result, err := pipeline.Analyze(options)
@@ -1670,7 +2307,7 @@ func runDiff(cmd *cobra.Command, args []string) {
 	diff := CompareAnalyses(lastSnapshot, result)
 
 	// Format and output report
-	report := FormatDiffReport(diff, diffShowTeams)
+	report := FormatDiffReport(diff, diffShowTeams, diffCfg.Reports.ScorePrecision())
 
 	if diffOutput == "" {
 		fmt.Print(report)
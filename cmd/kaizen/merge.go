@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/alexcollie/kaizen/pkg/analyzer"
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/alexcollie/kaizen/pkg/reports"
+	"github.com/spf13/cobra"
+)
+
+var (
+	mergeOutput      string
+	mergeCompactJSON bool
+)
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge <results.json> <results.json>...",
+	Short: "Combine analysis results from several runs into one",
+	Long: `Merges kaizen analyze JSON results, e.g. from per-language or per-module CI
+matrix jobs, into a single result. Files are concatenated and folder stats,
+module stats, summary, and score report are rebuilt for the combined set using
+the thresholds from .kaizen.yaml in the current directory.
+
+A file path that appears in more than one input is kept from the first input
+that contains it, with a warning. One input may be "-" to read it from stdin.`,
+	Args: cobra.MinimumNArgs(2),
+	Run:  runMerge,
+}
+
+func runMerge(cmd *cobra.Command, args []string) {
+	if err := checkSingleStdinInput(args...); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	var inputs []*models.AnalysisResult
+	for _, path := range args {
+		result, err := loadAnalysisFromFile(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		inputs = append(inputs, result)
+	}
+
+	cfg, err := config.LoadConfig(".")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not load config: %v\n", err)
+		cfg = config.DefaultConfig()
+	}
+
+	merged, duplicates := mergeAnalysisResults(inputs, args)
+	for _, duplicate := range duplicates {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", duplicate)
+	}
+
+	analyzer.NewAggregator().Recompute(merged, cfg.Thresholds, cfg.Scoring, cfg.Reports)
+
+	if err := saveResults(merged, mergeOutput, mergeCompactJSON); err != nil {
+		fmt.Fprintf(os.Stderr, "Error saving results: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✅ Merged %d results (%d files) into %s\n", len(inputs), len(merged.Files), mergeOutput)
+	if merged.ScoreReport != nil {
+		fmt.Printf("   Overall grade: %s (%s/100)\n", merged.ScoreReport.OverallGrade, reports.FormatScore(merged.ScoreReport.OverallScore, cfg.Reports.ScorePrecision()))
+	}
+}
+
+// mergeAnalysisResults concatenates the files of several results, keeping the first copy of
+// any path that appears in more than one input. The time range is widened to cover every
+// input, and module directories and churn presence are carried over so Recompute groups and
+// weights the merged files the same way as the originals. It returns one message per
+// duplicate path; aggregates are left for the caller to rebuild.
+func mergeAnalysisResults(inputs []*models.AnalysisResult, inputNames []string) (*models.AnalysisResult, []string) {
+	merged := &models.AnalysisResult{SchemaVersion: models.SchemaVersion}
+	seenIn := make(map[string]string)
+	var duplicates []string
+	hasChurnData := false
+
+	for index, input := range inputs {
+		if merged.Repository == "" {
+			merged.Repository = input.Repository
+		}
+		if merged.ChurnMetric == "" {
+			merged.ChurnMetric = input.ChurnMetric
+		}
+		if merged.MinFunctionLines == 0 {
+			merged.MinFunctionLines = input.MinFunctionLines
+			merged.TrivialExcludedFromAverages = input.TrivialExcludedFromAverages
+		}
+		if merged.AverageMethod == "" {
+			merged.AverageMethod = input.AverageMethod
+		}
+		if input.AnalyzedAt.After(merged.AnalyzedAt) {
+			merged.AnalyzedAt = input.AnalyzedAt
+		}
+		if !input.TimeRange.Since.IsZero() && (merged.TimeRange.Since.IsZero() || input.TimeRange.Since.Before(merged.TimeRange.Since)) {
+			merged.TimeRange.Since = input.TimeRange.Since
+		}
+		if input.TimeRange.Until.After(merged.TimeRange.Until) {
+			merged.TimeRange.Until = input.TimeRange.Until
+		}
+
+		for _, file := range input.Files {
+			if firstInput, seen := seenIn[file.Path]; seen {
+				duplicates = append(duplicates, fmt.Sprintf("%s appears in both %s and %s; keeping %s", file.Path, firstInput, inputNames[index], firstInput))
+				continue
+			}
+			seenIn[file.Path] = inputNames[index]
+			merged.Files = append(merged.Files, file)
+		}
+		for _, file := range input.TestFiles {
+			if firstInput, seen := seenIn[file.Path]; seen {
+				duplicates = append(duplicates, fmt.Sprintf("%s appears in both %s and %s; keeping %s", file.Path, firstInput, inputNames[index], firstInput))
+				continue
+			}
+			seenIn[file.Path] = inputNames[index]
+			merged.TestFiles = append(merged.TestFiles, file)
+		}
+		merged.SkippedFiles = append(merged.SkippedFiles, input.SkippedFiles...)
+		merged.GeneratedFilesSkipped += input.GeneratedFilesSkipped
+
+		for moduleDir := range input.ModuleStats {
+			if merged.ModuleStats == nil {
+				merged.ModuleStats = make(map[string]models.FolderMetrics)
+			}
+			merged.ModuleStats[moduleDir] = models.FolderMetrics{}
+		}
+		if input.ScoreReport != nil && input.ScoreReport.HasChurnData {
+			hasChurnData = true
+		}
+	}
+
+	if hasChurnData {
+		merged.ScoreReport = &models.ScoreReport{HasChurnData: true}
+	}
+
+	return merged, duplicates
+}
+
+func init() {
+	mergeCmd.Flags().StringVarP(&mergeOutput, "output", "o", "kaizen-results.json", "Output file path")
+	mergeCmd.Flags().BoolVar(&mergeCompactJSON, "compact-json", false, "Write the merged JSON minified instead of indented")
+}
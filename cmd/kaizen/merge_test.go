@@ -0,0 +1,118 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+func TestMergeAnalysisResults(t *testing.T) {
+	earlier := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	goResult := &models.AnalysisResult{
+		Repository: "repo",
+		AnalyzedAt: earlier,
+		TimeRange:  models.TimeRange{Since: earlier, Until: earlier},
+		Files: []models.FileAnalysis{
+			{Path: "api/handler.go", CodeLines: 100},
+			{Path: "shared/util.go", CodeLines: 10},
+		},
+		ModuleStats: map[string]models.FolderMetrics{"api": {}},
+	}
+	kotlinResult := &models.AnalysisResult{
+		AnalyzedAt:   later,
+		TimeRange:    models.TimeRange{Since: later, Until: later},
+		Files:        []models.FileAnalysis{{Path: "app/Main.kt", CodeLines: 50}, {Path: "shared/util.go", CodeLines: 99}},
+		SkippedFiles: []models.SkippedFile{{Path: "app/Huge.kt", Reason: "timeout"}},
+		ScoreReport:  &models.ScoreReport{HasChurnData: true, OverallScore: 40},
+	}
+
+	merged, duplicates := mergeAnalysisResults([]*models.AnalysisResult{goResult, kotlinResult}, []string{"go.json", "kotlin.json"})
+
+	if len(merged.Files) != 3 {
+		t.Fatalf("Expected 3 files after dropping the duplicate, got %d", len(merged.Files))
+	}
+	for _, file := range merged.Files {
+		if file.Path == "shared/util.go" && file.CodeLines != 10 {
+			t.Errorf("Expected the first input's copy of a duplicate to be kept, got %d code lines", file.CodeLines)
+		}
+	}
+	if len(duplicates) != 1 || !strings.Contains(duplicates[0], "shared/util.go") || !strings.Contains(duplicates[0], "kotlin.json") {
+		t.Errorf("Expected one warning naming shared/util.go and kotlin.json, got %v", duplicates)
+	}
+
+	if merged.Repository != "repo" || !merged.AnalyzedAt.Equal(later) {
+		t.Errorf("Expected repository from the first input and the latest analysis time, got %s at %v", merged.Repository, merged.AnalyzedAt)
+	}
+	if !merged.TimeRange.Since.Equal(earlier) || !merged.TimeRange.Until.Equal(later) {
+		t.Errorf("Expected the time range to cover every input, got %+v", merged.TimeRange)
+	}
+	if len(merged.SkippedFiles) != 1 {
+		t.Errorf("Expected skipped files to be carried over, got %+v", merged.SkippedFiles)
+	}
+	if _, ok := merged.ModuleStats["api"]; !ok {
+		t.Errorf("Expected module directories to be carried over for Recompute, got %v", merged.ModuleStats)
+	}
+	if merged.ScoreReport == nil || !merged.ScoreReport.HasChurnData || merged.ScoreReport.OverallScore != 0 {
+		t.Errorf("Expected only churn presence to be carried over from the score reports, got %+v", merged.ScoreReport)
+	}
+}
+
+func TestMergeAnalysisResultsKeepsTestFiles(t *testing.T) {
+	goResult := &models.AnalysisResult{
+		Files:     []models.FileAnalysis{{Path: "api/handler.go"}},
+		TestFiles: []models.FileAnalysis{{Path: "api/handler_test.go"}},
+	}
+	pythonResult := &models.AnalysisResult{
+		Files:     []models.FileAnalysis{{Path: "tools/sync.py"}},
+		TestFiles: []models.FileAnalysis{{Path: "tools/test_sync.py"}, {Path: "api/handler_test.go"}},
+	}
+
+	merged, duplicates := mergeAnalysisResults([]*models.AnalysisResult{goResult, pythonResult}, []string{"go.json", "python.json"})
+
+	if len(merged.Files) != 2 {
+		t.Errorf("Expected test files to stay out of the production files, got %+v", merged.Files)
+	}
+	if len(merged.TestFiles) != 2 {
+		t.Errorf("Expected 2 test files after dropping the duplicate, got %+v", merged.TestFiles)
+	}
+	if len(duplicates) != 1 || !strings.Contains(duplicates[0], "api/handler_test.go") {
+		t.Errorf("Expected one warning naming api/handler_test.go, got %v", duplicates)
+	}
+}
+
+func TestSaveResultsCompact(t *testing.T) {
+	result := &models.AnalysisResult{
+		Repository: "repo",
+		Files:      []models.FileAnalysis{{Path: "api/handler.go", CodeLines: 100}},
+	}
+	directory := t.TempDir()
+	indentedPath := filepath.Join(directory, "indented.json")
+	compactPath := filepath.Join(directory, "compact.json")
+
+	if err := saveResults(result, indentedPath, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := saveResults(result, compactPath, true); err != nil {
+		t.Fatal(err)
+	}
+
+	indented, _ := os.ReadFile(indentedPath)
+	compact, _ := os.ReadFile(compactPath)
+	if !strings.Contains(string(indented), "\n  \"repository\": \"repo\"") {
+		t.Errorf("Expected two-space indentation by default, got:\n%s", indented)
+	}
+	if strings.Contains(string(compact), "\n") || len(compact) >= len(indented) {
+		t.Errorf("Expected minified JSON smaller than the indented form, got:\n%s", compact)
+	}
+
+	loaded, err := loadAnalysisFromFile(compactPath)
+	if err != nil || loaded.Repository != "repo" || len(loaded.Files) != 1 {
+		t.Errorf("Expected the compact file to load back, got %+v (%v)", loaded, err)
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+var perFileOutput string // --per-file-output: directory receiving one JSON file per analyzed file
+
+// writePerFileResults writes each analyzed file's FileAnalysis, test files included, to
+// outputDir as <source path>.json, mirroring the source tree, e.g. pkg/api/handler.go becomes
+// outputDir/pkg/api/handler.go.json. Files whose path would land outside outputDir are skipped.
+// It returns the number of files written.
+func writePerFileResults(result *models.AnalysisResult, outputDir string, compact bool) (int, error) {
+	files := append(append([]models.FileAnalysis{}, result.Files...), result.TestFiles...)
+
+	written := 0
+	for index := range files {
+		file := &files[index]
+		if !filepath.IsLocal(filepath.FromSlash(file.Path)) {
+			continue
+		}
+
+		var data []byte
+		var err error
+		if compact {
+			data, err = json.Marshal(file)
+		} else {
+			data, err = json.MarshalIndent(file, "", "  ")
+		}
+		if err != nil {
+			return written, fmt.Errorf("failed to marshal %s: %w", file.Path, err)
+		}
+
+		target := filepath.Join(outputDir, filepath.FromSlash(file.Path)) + ".json"
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return written, fmt.Errorf("failed to create directory for %s: %w", file.Path, err)
+		}
+		if err := os.WriteFile(target, data, 0644); err != nil {
+			return written, fmt.Errorf("failed to write %s: %w", target, err)
+		}
+		written++
+	}
+
+	return written, nil
+}
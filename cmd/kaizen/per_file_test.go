@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+func TestWritePerFileResults(t *testing.T) {
+	outputDir := t.TempDir()
+	result := &models.AnalysisResult{
+		Files: []models.FileAnalysis{
+			{Path: "pkg/api/handler.go", Language: "Go", CodeLines: 120},
+			{Path: "main.go", Language: "Go", CodeLines: 10},
+			{Path: "../outside.go", Language: "Go"},
+		},
+		TestFiles: []models.FileAnalysis{
+			{Path: "pkg/api/handler_test.go", Language: "Go"},
+		},
+	}
+
+	written, err := writePerFileResults(result, outputDir, true)
+	if err != nil {
+		t.Fatalf("writePerFileResults failed: %v", err)
+	}
+	if written != 3 {
+		t.Errorf("Expected 3 files written (the path outside the output directory skipped), got %d", written)
+	}
+
+	data, err := os.ReadFile(filepath.Join(outputDir, "pkg", "api", "handler.go.json"))
+	if err != nil {
+		t.Fatalf("Expected the source tree to be mirrored: %v", err)
+	}
+	var file models.FileAnalysis
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatalf("Per-file result is not valid JSON: %v", err)
+	}
+	if file.Path != "pkg/api/handler.go" || file.CodeLines != 120 {
+		t.Errorf("Expected handler.go's analysis, got %s with %d code lines", file.Path, file.CodeLines)
+	}
+
+	for _, path := range []string{"main.go.json", filepath.Join("pkg", "api", "handler_test.go.json")} {
+		if _, err := os.Stat(filepath.Join(outputDir, path)); err != nil {
+			t.Errorf("Expected %s to be written: %v", path, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(outputDir), "outside.go.json")); err == nil {
+		t.Errorf("Expected paths outside the output directory to be skipped")
+	}
+}
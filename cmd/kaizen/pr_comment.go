@@ -6,7 +6,9 @@ import (
 	"os"
 	"strings"
 
+	"github.com/alexcollie/kaizen/internal/config"
 	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/alexcollie/kaizen/pkg/reports"
 	"github.com/spf13/cobra"
 )
 
@@ -15,6 +17,7 @@ var (
 	prHeadAnalysis string
 	prCheckJSON    string
 	prOutput       string
+	prPrecision    int
 )
 
 var prCommentCmd = &cobra.Command{
@@ -32,10 +35,11 @@ Designed for use in CI pipelines and GitHub Actions.`,
 }
 
 func init() {
-	prCommentCmd.Flags().StringVar(&prBaseAnalysis, "base-analysis", "", "Path to baseline analysis JSON")
-	prCommentCmd.Flags().StringVar(&prHeadAnalysis, "head-analysis", "", "Path to current (PR head) analysis JSON")
-	prCommentCmd.Flags().StringVar(&prCheckJSON, "check-json", "", "Path to kaizen check --format=json output (optional)")
+	prCommentCmd.Flags().StringVar(&prBaseAnalysis, "base-analysis", "", "Path to baseline analysis JSON (- for stdin)")
+	prCommentCmd.Flags().StringVar(&prHeadAnalysis, "head-analysis", "", "Path to current (PR head) analysis JSON (- for stdin)")
+	prCommentCmd.Flags().StringVar(&prCheckJSON, "check-json", "", "Path to kaizen check --format=json output (optional, - for stdin)")
 	prCommentCmd.Flags().StringVarP(&prOutput, "output", "o", "", "Write markdown to file (default: stdout)")
+	prCommentCmd.Flags().IntVar(&prPrecision, "precision", 0, "Decimal places of the overall score (0-4); the metrics table and score change show at least one")
 }
 
 func runPRComment(cmd *cobra.Command, args []string) {
@@ -43,6 +47,14 @@ func runPRComment(cmd *cobra.Command, args []string) {
 		fmt.Fprintln(os.Stderr, "Error: --base-analysis and --head-analysis are required")
 		os.Exit(1)
 	}
+	if prPrecision < 0 || prPrecision > config.MaxReportPrecision {
+		fmt.Fprintf(os.Stderr, "Error: --precision must be between 0 and %d\n", config.MaxReportPrecision)
+		os.Exit(1)
+	}
+	if err := checkSingleStdinInput(prBaseAnalysis, prHeadAnalysis, prCheckJSON); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 
 	baseResult, err := loadAnalysisFromFile(prBaseAnalysis)
 	if err != nil {
@@ -65,7 +77,7 @@ func runPRComment(cmd *cobra.Command, args []string) {
 	}
 
 	diff := CompareAnalyses(baseResult, headResult)
-	markdown := FormatDiffMarkdown(diff, headResult, concerns)
+	markdown := FormatDiffMarkdown(diff, headResult, concerns, prPrecision)
 
 	if prOutput != "" {
 		err := os.WriteFile(prOutput, []byte(markdown), 0644)
@@ -78,40 +90,48 @@ func runPRComment(cmd *cobra.Command, args []string) {
 	}
 }
 
+// loadAnalysisFromFile loads an analysis result from a JSON file, or from stdin when path is "-".
+// Results in a newer schema version than this build knows are rejected rather than misread.
 func loadAnalysisFromFile(path string) (*models.AnalysisResult, error) {
-	data, err := os.ReadFile(path)
+	data, err := readInput(path)
 	if err != nil {
-		return nil, fmt.Errorf("could not read file %s: %w", path, err)
+		return nil, err
 	}
 
 	var result models.AnalysisResult
 	if err := json.Unmarshal(data, &result); err != nil {
-		return nil, fmt.Errorf("could not parse JSON from %s: %w", path, err)
+		return nil, fmt.Errorf("could not parse JSON from %s: %w", inputName(path), err)
+	}
+	if result.SchemaVersion > models.SchemaVersion {
+		return nil, fmt.Errorf("%s uses schema version %d, but this kaizen reads up to version %d; upgrade kaizen to read it",
+			inputName(path), result.SchemaVersion, models.SchemaVersion)
 	}
 
 	return &result, nil
 }
 
 func loadConcernsFromFile(path string) ([]models.Concern, error) {
-	data, err := os.ReadFile(path)
+	data, err := readInput(path)
 	if err != nil {
-		return nil, fmt.Errorf("could not read file %s: %w", path, err)
+		return nil, err
 	}
 
 	var concerns []models.Concern
 	if err := json.Unmarshal(data, &concerns); err != nil {
-		return nil, fmt.Errorf("could not parse JSON from %s: %w", path, err)
+		return nil, fmt.Errorf("could not parse JSON from %s: %w", inputName(path), err)
 	}
 
 	return concerns, nil
 }
 
-// FormatDiffMarkdown generates a GitHub-flavored markdown comment from analysis diff
-func FormatDiffMarkdown(diff *AnalysisDiff, headResult *models.AnalysisResult, concerns []models.Concern) string {
+// FormatDiffMarkdown generates a GitHub-flavored markdown comment from analysis diff. The
+// overall score in the heading has precision decimal places; the metrics table and the score
+// change have at least one, so small changes do not round away.
+func FormatDiffMarkdown(diff *AnalysisDiff, headResult *models.AnalysisResult, concerns []models.Concern, precision int) string {
 	var builder strings.Builder
 
-	writeHeader(&builder, headResult, diff)
-	writeMetricsTable(&builder, headResult, diff)
+	writeHeader(&builder, headResult, diff, precision)
+	writeMetricsTable(&builder, headResult, diff, max(precision, 1))
 	writeHotspotChanges(&builder, diff)
 	writeBlastRadiusWarnings(&builder, concerns)
 	writeMetricsExplainer(&builder)
@@ -120,7 +140,7 @@ func FormatDiffMarkdown(diff *AnalysisDiff, headResult *models.AnalysisResult, c
 	return builder.String()
 }
 
-func writeHeader(builder *strings.Builder, headResult *models.AnalysisResult, diff *AnalysisDiff) {
+func writeHeader(builder *strings.Builder, headResult *models.AnalysisResult, diff *AnalysisDiff, precision int) {
 	grade := "N/A"
 	score := 0.0
 	if headResult.ScoreReport != nil {
@@ -129,24 +149,21 @@ func writeHeader(builder *strings.Builder, headResult *models.AnalysisResult, di
 	}
 
 	gradeEmoji := gradeToEmoji(grade)
-	fmt.Fprintf(builder, "## %s Kaizen Code Analysis \u2014 Grade %s (%.0f/100)\n\n", gradeEmoji, grade, score)
+	fmt.Fprintf(builder, "## %s Kaizen Code Analysis \u2014 Grade %s (%s/100)\n\n", gradeEmoji, grade, reports.FormatScore(score, precision))
 
 	delta := diff.GlobalMetrics.ScoreDelta
 	deltaIndicator := scoreDeltaIndicator(delta)
-	fmt.Fprintf(builder, "**Score Change:** %s **%+.1f** points\n\n", deltaIndicator, delta)
+	fmt.Fprintf(builder, "**Score Change:** %s **%s** points\n\n", deltaIndicator, reports.FormatScoreChange(delta, max(precision, 1)))
 }
 
-func writeMetricsTable(builder *strings.Builder, headResult *models.AnalysisResult, diff *AnalysisDiff) {
+func writeMetricsTable(builder *strings.Builder, headResult *models.AnalysisResult, diff *AnalysisDiff, scorePrecision int) {
 	builder.WriteString("### 📊 Metrics\n\n")
 	builder.WriteString("| Metric | Previous | Current | Delta |\n")
 	builder.WriteString("|--------|----------|---------|-------|\n")
 
 	previousScore := headResult.ScoreReport.OverallScore - diff.GlobalMetrics.ScoreDelta
 	currentScore := headResult.ScoreReport.OverallScore
-	writeMetricRow(builder, "Overall Score",
-		fmt.Sprintf("%.1f", previousScore),
-		fmt.Sprintf("%.1f", currentScore),
-		diff.GlobalMetrics.ScoreDelta, false)
+	writeScoreRow(builder, "Overall Score", previousScore, currentScore, diff.GlobalMetrics.ScoreDelta, scorePrecision)
 
 	prevComplexity := headResult.Summary.AverageCyclomaticComplexity - diff.GlobalMetrics.ComplexityDelta
 	writeMetricRow(builder, "Avg Complexity",
@@ -174,6 +191,12 @@ func writeMetricRow(builder *strings.Builder, name, previous, current string, de
 	fmt.Fprintf(builder, "| %s | %s | %s | %s %+.1f |\n", name, previous, current, indicator, delta)
 }
 
+// writeScoreRow writes a metric row for a score, to precision decimal places
+func writeScoreRow(builder *strings.Builder, name string, previous, current, delta float64, precision int) {
+	indicator := metricDeltaIndicator(delta, false)
+	fmt.Fprintf(builder, "| %s | %s | %s | %s %s |\n", name, reports.FormatScore(previous, precision), reports.FormatScore(current, precision), indicator, reports.FormatScoreChange(delta, precision))
+}
+
 func writeMetricRowInt(builder *strings.Builder, name string, previous, current, delta int, invertArrow bool) {
 	indicator := metricDeltaIndicatorInt(delta, invertArrow)
 	fmt.Fprintf(builder, "| %s | %d | %d | %s %+d |\n", name, previous, current, indicator, delta)
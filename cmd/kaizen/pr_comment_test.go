@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -16,7 +17,7 @@ func TestFormatDiffMarkdown_BasicOutput(t *testing.T) {
 	headResult := createTestAnalysisResult(82.0, "B", 4.8, 85.4, 3, 358, 52)
 
 	diff := CompareAnalyses(baseResult, headResult)
-	markdown := FormatDiffMarkdown(diff, headResult, nil)
+	markdown := FormatDiffMarkdown(diff, headResult, nil, 0)
 
 	assertContains(t, markdown, "🟡 Kaizen Code Analysis")
 	assertContains(t, markdown, "Grade B")
@@ -33,11 +34,23 @@ func TestFormatDiffMarkdown_ScoreDelta(t *testing.T) {
 	headResult := createTestAnalysisResult(82.0, "B", 4.8, 85.4, 3, 358, 52)
 
 	diff := CompareAnalyses(baseResult, headResult)
-	markdown := FormatDiffMarkdown(diff, headResult, nil)
+	markdown := FormatDiffMarkdown(diff, headResult, nil, 0)
 
 	assertContains(t, markdown, "-2.3")
 }
 
+func TestFormatDiffMarkdown_Precision(t *testing.T) {
+	baseResult := createTestAnalysisResult(84.3, "B", 4.2, 87.1, 2, 350, 50)
+	headResult := createTestAnalysisResult(82.04, "B", 4.8, 85.4, 3, 358, 52)
+
+	diff := CompareAnalyses(baseResult, headResult)
+	markdown := FormatDiffMarkdown(diff, headResult, nil, 2)
+
+	assertContains(t, markdown, "82.04/100")
+	assertContains(t, markdown, "**-2.26** points")
+	assertContains(t, markdown, "| Overall Score | 84.30 | 82.04 |")
+}
+
 func TestFormatDiffMarkdown_WithHotspotChanges(t *testing.T) {
 	baseResult := createTestAnalysisResultWithHotspots(80.0, "B",
 		[]hotspotEntry{{file: "pkg/a.go", function: "oldHotspot"}})
@@ -45,7 +58,7 @@ func TestFormatDiffMarkdown_WithHotspotChanges(t *testing.T) {
 		[]hotspotEntry{{file: "pkg/b.go", function: "newHotspot"}})
 
 	diff := CompareAnalyses(baseResult, headResult)
-	markdown := FormatDiffMarkdown(diff, headResult, nil)
+	markdown := FormatDiffMarkdown(diff, headResult, nil, 0)
 
 	assertContains(t, markdown, "🔥 Hotspot Changes")
 	assertContains(t, markdown, "🔴 New")
@@ -74,7 +87,7 @@ func TestFormatDiffMarkdown_WithBlastRadiusConcerns(t *testing.T) {
 	}
 
 	diff := CompareAnalyses(baseResult, headResult)
-	markdown := FormatDiffMarkdown(diff, headResult, concerns)
+	markdown := FormatDiffMarkdown(diff, headResult, concerns, 0)
 
 	assertContains(t, markdown, "💥 Blast-Radius Warnings")
 	assertContains(t, markdown, "CompareAnalyses")
@@ -88,7 +101,7 @@ func TestFormatDiffMarkdown_NoConcernsOmitsSection(t *testing.T) {
 	headResult := createTestAnalysisResult(80.0, "B", 4.0, 85.0, 0, 100, 10)
 
 	diff := CompareAnalyses(baseResult, headResult)
-	markdown := FormatDiffMarkdown(diff, headResult, nil)
+	markdown := FormatDiffMarkdown(diff, headResult, nil, 0)
 
 	if strings.Contains(markdown, "💥 Blast-Radius Warnings") {
 		t.Error("should not contain blast-radius section when no concerns")
@@ -103,7 +116,7 @@ func TestFormatDiffMarkdown_ContainsExplainer(t *testing.T) {
 	headResult := createTestAnalysisResult(80.0, "B", 4.0, 85.0, 0, 100, 10)
 
 	diff := CompareAnalyses(baseResult, headResult)
-	markdown := FormatDiffMarkdown(diff, headResult, nil)
+	markdown := FormatDiffMarkdown(diff, headResult, nil, 0)
 
 	assertContains(t, markdown, "<details>")
 	assertContains(t, markdown, "What do these metrics mean?")
@@ -142,6 +155,27 @@ func TestLoadAnalysisFromFile_NotFound(t *testing.T) {
 	}
 }
 
+func TestLoadAnalysisFromFile_NewerSchemaVersion(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "analysis.json")
+	data := fmt.Sprintf(`{"schema_version": %d, "files": []}`, models.SchemaVersion+1)
+	if err := os.WriteFile(filePath, []byte(data), 0644); err != nil {
+		t.Fatalf("failed to write: %v", err)
+	}
+
+	_, err := loadAnalysisFromFile(filePath)
+	if err == nil || !strings.Contains(err.Error(), "schema version") {
+		t.Errorf("expected a schema version error, got %v", err)
+	}
+
+	// Results written before schema_version existed still load
+	if err := os.WriteFile(filePath, []byte(`{"files": []}`), 0644); err != nil {
+		t.Fatalf("failed to write: %v", err)
+	}
+	if _, err := loadAnalysisFromFile(filePath); err != nil {
+		t.Errorf("expected an unversioned result to load, got %v", err)
+	}
+}
+
 func TestLoadConcernsFromFile(t *testing.T) {
 	concerns := []models.Concern{
 		{
@@ -0,0 +1,16 @@
+package main
+
+import (
+	"github.com/alexcollie/kaizen/internal/config"
+)
+
+// reportPrecision returns the decimal places scores are shown with, from reports.precision in
+// the .kaizen.yaml for path, for commands that need nothing else from the config. A config
+// that cannot be loaded gives the default; commands that load it report its errors themselves.
+func reportPrecision(path string) int {
+	cfg, err := config.LoadConfig(path)
+	if err != nil {
+		return config.DefaultReportPrecision
+	}
+	return cfg.Reports.ScorePrecision()
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+var (
+	cpuProfilePath string
+	memProfilePath string
+)
+
+// startProfiling starts a CPU profile when cpuProfile is set and returns a function that stops
+// it and, when memProfile is set, writes a heap profile. Both files are read with
+// `go tool pprof`. A CPU profile that cannot be started is fatal, since it is checked before
+// any work is done; a heap profile that cannot be written at the end is only a warning.
+func startProfiling(cpuProfile, memProfile string) func() {
+	var cpuFile *os.File
+	if cpuProfile != "" {
+		file, err := os.Create(cpuProfile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not create CPU profile: %v\n", err)
+			os.Exit(1)
+		}
+		if err := pprof.StartCPUProfile(file); err != nil {
+			_ = file.Close()
+			fmt.Fprintf(os.Stderr, "Error: could not start CPU profile: %v\n", err)
+			os.Exit(1)
+		}
+		cpuFile = file
+	}
+
+	return func() {
+		if cpuFile != nil {
+			pprof.StopCPUProfile()
+			if err := cpuFile.Close(); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not write CPU profile: %v\n", err)
+			}
+		}
+
+		if memProfile != "" {
+			writeHeapProfile(memProfile)
+		}
+	}
+}
+
+// writeHeapProfile writes the live heap after a garbage collection, so it shows what is
+// still retained rather than short-lived garbage
+func writeHeapProfile(path string) {
+	file, err := os.Create(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not create heap profile: %v\n", err)
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(file); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not write heap profile: %v\n", err)
+	}
+}
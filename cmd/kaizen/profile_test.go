@@ -0,0 +1,42 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStartProfilingWritesProfiles(t *testing.T) {
+	dir := t.TempDir()
+	cpuProfile := filepath.Join(dir, "cpu.prof")
+	memProfile := filepath.Join(dir, "mem.prof")
+
+	stopProfiling := startProfiling(cpuProfile, memProfile)
+	stopProfiling()
+
+	for _, path := range []string{cpuProfile, memProfile} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Errorf("Expected %s to be written: %v", filepath.Base(path), err)
+			continue
+		}
+		if info.Size() == 0 {
+			t.Errorf("Expected %s to be non-empty", filepath.Base(path))
+		}
+	}
+}
+
+func TestStartProfilingDisabled(t *testing.T) {
+	dir := t.TempDir()
+
+	stopProfiling := startProfiling("", "")
+	stopProfiling()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir failed: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Expected no profiles without paths, got %d files", len(entries))
+	}
+}
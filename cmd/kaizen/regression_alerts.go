@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/alexcollie/kaizen/pkg/storage"
+)
+
+// regressionAlertMetrics are the folder metrics --alert-regressions watches; each rises as a
+// folder gets worse. The scores are percentile ranks against the other folders, so the worst
+// folder's score stays at 100 however much worse it gets; its average complexity catches that.
+var regressionAlertMetrics = []string{"avg_cyclomatic_complexity", "complexity_score", "hotspot_score"}
+
+// regressionAlertLimit is the most regressions --alert-regressions lists before counting the rest
+const regressionAlertLimit = 10
+
+var alertRegressions bool
+
+// folderRegressionAlert is the outcome of comparing a saved snapshot's folders with the
+// snapshot before it
+type folderRegressionAlert struct {
+	previousID  int64
+	minPercent  float64
+	regressions []storage.FolderRegression
+}
+
+// findFolderRegressions compares the folder scores stored for snapshotID with the previous
+// snapshot's, returning nil when they could not be compared
+func findFolderRegressions(backend storage.StorageBackend, snapshotID int64, minPercent float64) *folderRegressionAlert {
+	previousID, regressions, err := backend.GetFolderRegressions(snapshotID, regressionAlertMetrics, minPercent)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not check folder regressions: %v\n", err)
+		return nil
+	}
+	return &folderRegressionAlert{previousID: previousID, minPercent: minPercent, regressions: regressions}
+}
+
+// printFolderRegressions lists the worst folder regressions, largest first. It prints to stderr
+// so the alert shows even with --quiet or --json-only.
+func printFolderRegressions(alert *folderRegressionAlert) {
+	if alert == nil {
+		return
+	}
+	if alert.previousID == 0 {
+		fmt.Fprintln(os.Stderr, "ℹ️  No previous snapshot to check folder regressions against")
+		return
+	}
+	if len(alert.regressions) == 0 {
+		fmt.Fprintf(os.Stderr, "✅ No folder regressed more than %.0f%% since snapshot #%d\n", alert.minPercent, alert.previousID)
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "\n%s⚠️  %d folder score(s) regressed more than %.0f%% since snapshot #%d:%s\n",
+		ansi(colorYellow), len(alert.regressions), alert.minPercent, alert.previousID, ansi(colorReset))
+	for index, regression := range alert.regressions {
+		if index == regressionAlertLimit {
+			fmt.Fprintf(os.Stderr, "  ...and %d more\n", len(alert.regressions)-regressionAlertLimit)
+			break
+		}
+		fmt.Fprintf(os.Stderr, "  %-40s %-25s %6.1f → %-6.1f (+%.0f%%)\n",
+			regression.FolderPath, regression.MetricName, regression.PreviousValue, regression.Value, regression.PercentChange)
+	}
+}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/alexcollie/kaizen/pkg/reports"
+	"github.com/alexcollie/kaizen/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	concernsRecurring bool
+	concernsSince     string
+	concernsFormat    string
+	concernsLimit     int
+	concernsGroupBy   string
+)
+
+// Groupings accepted by report concerns --group-by
+const (
+	concernsGroupByType = "type"
+	concernsGroupByFile = "file"
+)
+
+var reportConcernsCmd = &cobra.Command{
+	Use:   "concerns [snapshot-id|tag]",
+	Short: "Show a snapshot's concerns, or concerns that keep coming back",
+	Long: `Prints the concerns stored with a snapshot (the latest by default).
+
+With --recurring, walks every function's history across stored snapshots
+instead and lists functions that went over a warning threshold, dropped back
+under it, and went over it again. Cyclomatic complexity, cognitive complexity,
+function length, and maintainability index are checked against the warning
+thresholds in .kaizen.yaml. Code that keeps regressing after being fixed
+points to a systemic problem rather than a one-off.
+
+With --group-by=file, lists every concern affecting each file together, with
+line numbers, worst file first, for when you are about to edit a file and want
+to know what is wrong with it.`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runReportConcerns,
+}
+
+func runReportConcerns(cmd *cobra.Command, args []string) {
+	if concernsFormat != "text" && concernsFormat != "json" {
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (use text or json)\n", concernsFormat)
+		os.Exit(1)
+	}
+	if concernsGroupBy != concernsGroupByType && concernsGroupBy != concernsGroupByFile {
+		fmt.Fprintf(os.Stderr, "Error: unsupported --group-by '%s' (use type or file)\n", concernsGroupBy)
+		os.Exit(1)
+	}
+
+	backend := openHistoryBackend()
+	defer func() { _ = backend.Close() }()
+
+	if concernsRecurring {
+		if len(args) > 0 {
+			fmt.Fprintf(os.Stderr, "Error: --recurring looks across all snapshots and takes no snapshot argument\n")
+			os.Exit(1)
+		}
+		if concernsGroupBy == concernsGroupByFile {
+			fmt.Fprintf(os.Stderr, "Error: --group-by=file applies to a snapshot's concerns, not --recurring\n")
+			os.Exit(1)
+		}
+		runRecurringConcerns(backend)
+		return
+	}
+
+	snapshotID := int64(0)
+	if len(args) > 0 {
+		snapshotID = resolveSnapshotRef(backend, args[0])
+	} else {
+		summary, err := backend.GetLatestSummary()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: no snapshots found (run 'kaizen analyze' first): %v\n", err)
+			os.Exit(1)
+		}
+		snapshotID = summary.ID
+	}
+
+	snapshot, err := backend.GetByID(snapshotID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	concerns := []models.Concern{}
+	if snapshot.ScoreReport != nil && snapshot.ScoreReport.Concerns != nil {
+		concerns = snapshot.ScoreReport.Concerns
+	}
+
+	if concernsGroupBy == concernsGroupByFile {
+		files := reports.GroupConcernsByFile(concerns)
+		if concernsFormat == "json" {
+			writeConcernsJSON(files)
+			return
+		}
+		fmt.Printf("Snapshot #%d (%s)\n\n", snapshotID, snapshot.AnalyzedAt.Format("2006-01-02 15:04"))
+		printConcernsByFile(files)
+		return
+	}
+
+	if concernsFormat == "json" {
+		writeConcernsJSON(concerns)
+		return
+	}
+	fmt.Printf("Snapshot #%d (%s)\n\n", snapshotID, snapshot.AnalyzedAt.Format("2006-01-02 15:04"))
+	printConcerns(concerns)
+}
+
+// printConcernsByFile lists every concern affecting each file, worst file first
+func printConcernsByFile(files []reports.FileConcerns) {
+	if len(files) == 0 {
+		fmt.Printf("✨ No concerns detected\n")
+		return
+	}
+
+	fmt.Printf("Files with Concerns (%d):\n", len(files))
+	for _, file := range files {
+		fmt.Printf("\n  %s (%s)\n", file.FilePath, severityTally(file))
+		for _, concern := range file.Concerns {
+			color, label := colorCyan, "INFO"
+			switch concern.Severity {
+			case "critical":
+				color, label = colorRed, "CRITICAL"
+			case "warning":
+				color, label = colorYellow, "WARNING"
+			}
+
+			location := "-"
+			if concern.Line > 0 && concern.EndLine > concern.Line {
+				location = fmt.Sprintf("%d-%d", concern.Line, concern.EndLine)
+			} else if concern.Line > 0 {
+				location = fmt.Sprintf("%d", concern.Line)
+			}
+			fmt.Printf("    %5s  %s[%s]%s %s", location, ansi(color), label, ansi(colorReset), concern.Title)
+			if concern.FunctionName != "" {
+				fmt.Printf(" (%s)", concern.FunctionName)
+			}
+			fmt.Println()
+		}
+	}
+}
+
+// severityTally summarizes a file's concern counts, e.g. "1 critical, 2 warning"
+func severityTally(file reports.FileConcerns) string {
+	var parts []string
+	for _, tally := range []struct {
+		count    int
+		severity string
+	}{{file.Critical, "critical"}, {file.Warning, "warning"}, {file.Info, "info"}} {
+		if tally.count > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", tally.count, tally.severity))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// runRecurringConcerns loads function history since --since and prints the recurring concerns
+func runRecurringConcerns(backend storage.StorageBackend) {
+	var since time.Time
+	if concernsSince != "" {
+		parsedSince, err := parseSinceTime(concernsSince)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing --since: %v\n", err)
+			os.Exit(1)
+		}
+		since = parsedSince
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not get current directory: %v\n", err)
+		os.Exit(1)
+	}
+	cfg, err := config.LoadConfig(cwd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	records, err := backend.GetFunctionHistory(since)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	recurring := reports.DetectRecurringConcerns(records, cfg.Thresholds)
+	if concernsLimit > 0 && len(recurring) > concernsLimit {
+		recurring = recurring[:concernsLimit]
+	}
+
+	if concernsFormat == "json" {
+		writeConcernsJSON(recurring)
+		return
+	}
+	printRecurringConcerns(recurring)
+}
+
+// printRecurringConcerns lists each recurring concern with its value in every snapshot, flagged
+// values highlighted
+func printRecurringConcerns(recurring []reports.RecurringConcern) {
+	if len(recurring) == 0 {
+		fmt.Println("✨ No recurring concerns found")
+		return
+	}
+
+	fmt.Printf("🔁 Recurring Concerns (%d):\n", len(recurring))
+	for _, concern := range recurring {
+		comparison := ">"
+		if concern.Metric == "maintainability_index" {
+			comparison = "<"
+		}
+
+		fmt.Printf("\n  %s%s in %s\n", concern.FunctionName, concern.Overload, concern.FilePath)
+		fmt.Printf("    %s %s %.0f, flagged %d separate times\n", concern.Metric, comparison, concern.Threshold, concern.FlaggedRuns)
+		fmt.Printf("    History: %s\n", recurringHistoryTrail(concern.History))
+	}
+}
+
+// recurringHistoryTrail renders values oldest first, e.g. "12 → 8 → 14", with flagged values in red
+func recurringHistoryTrail(history []reports.RecurringConcernPoint) string {
+	values := make([]string, 0, len(history))
+	for _, point := range history {
+		value := fmt.Sprintf("%.0f", point.Value)
+		if point.Flagged {
+			value = ansi(colorRed) + value + ansi(colorReset)
+		}
+		values = append(values, value)
+	}
+	return strings.Join(values, " → ")
+}
+
+func writeConcernsJSON(value interface{}) {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not encode concerns: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func init() {
+	reportConcernsCmd.Flags().BoolVar(&concernsRecurring, "recurring", false, "List functions flagged, fixed, and flagged again across snapshots")
+	reportConcernsCmd.Flags().StringVarP(&concernsSince, "since", "s", "", "With --recurring, only consider snapshots since (e.g., 90d, 2024-01-01; default all)")
+	reportConcernsCmd.Flags().StringVarP(&concernsFormat, "format", "f", "text", "Output format (text or json)")
+	reportConcernsCmd.Flags().StringVar(&concernsGroupBy, "group-by", concernsGroupByType, "Group a snapshot's concerns by concern type or by the file they affect (type, file)")
+	reportConcernsCmd.Flags().IntVarP(&concernsLimit, "limit", "l", 20, "With --recurring, maximum concerns to display (0 = all)")
+}
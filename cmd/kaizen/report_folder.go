@@ -0,0 +1,163 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/alexcollie/kaizen/pkg/analyzer"
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/alexcollie/kaizen/pkg/reports"
+	"github.com/spf13/cobra"
+)
+
+var folderReportFormat string
+
+var reportFolderCmd = &cobra.Command{
+	Use:   "folder <path> [snapshot-id|tag]",
+	Short: "Explain a folder's score with the functions and files dragging it down",
+	Long: `Scores a folder on its own from a snapshot (the latest by default) and lists,
+for each score component, the concerns found in the folder's files, worst
+component first. Use it to see exactly what to fix in a folder your team owns
+rather than just its grade.
+
+The folder's files are scored with the thresholds and reports settings from
+.kaizen.yaml in the current directory, so concerns elsewhere in the repository
+do not crowd out the folder's own.`,
+	Args: cobra.RangeArgs(1, 2),
+	Run:  runReportFolder,
+}
+
+func runReportFolder(cmd *cobra.Command, args []string) {
+	if folderReportFormat != "text" && folderReportFormat != "json" {
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (use text or json)\n", folderReportFormat)
+		os.Exit(1)
+	}
+
+	backend := openHistoryBackend()
+	defer func() { _ = backend.Close() }()
+
+	snapshotID := int64(0)
+	if len(args) > 1 {
+		snapshotID = resolveSnapshotRef(backend, args[1])
+	} else {
+		summary, err := backend.GetLatestSummary()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: no snapshots found (run 'kaizen analyze' first): %v\n", err)
+			os.Exit(1)
+		}
+		snapshotID = summary.ID
+	}
+
+	snapshot, err := backend.GetByID(snapshotID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	folder := normalizeFolderPath(args[0])
+	folderResult := resultForFolder(snapshot, folder)
+	if len(folderResult.Files) == 0 {
+		fmt.Fprintf(os.Stderr, "Error: snapshot #%d has no analyzed files in %s\n", snapshotID, folder)
+		os.Exit(1)
+	}
+
+	cfg, err := config.LoadConfig(".")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not load config: %v\n", err)
+		cfg = config.DefaultConfig()
+	}
+	analyzer.NewAggregator().Recompute(folderResult, cfg.Thresholds, cfg.Scoring, cfg.Reports)
+
+	breakdowns := reports.BreakdownByComponent(folderResult.ScoreReport)
+	if folderReportFormat == "json" {
+		writeConcernsJSON(struct {
+			Folder     string                       `json:"folder"`
+			SnapshotID int64                        `json:"snapshot_id"`
+			Grade      string                       `json:"grade"`
+			Score      float64                      `json:"score"`
+			Components []reports.ComponentBreakdown `json:"components"`
+		}{folder, snapshotID, folderResult.ScoreReport.OverallGrade, folderResult.ScoreReport.OverallScore, breakdowns})
+		return
+	}
+
+	grade := folderResult.ScoreReport.OverallGrade
+	fmt.Printf("📁 %s — snapshot #%d (%s)\n", folder, snapshotID, snapshot.AnalyzedAt.Format("2006-01-02 15:04"))
+	precision := cfg.Reports.ScorePrecision()
+	fmt.Printf("   Grade: %s%s%s (%s/100), %d files, %d functions\n",
+		ansi(getGradeColor(grade)), grade, ansi(colorReset),
+		reports.FormatScore(folderResult.ScoreReport.OverallScore, precision),
+		folderResult.Summary.TotalFiles,
+		folderResult.Summary.TotalFunctions,
+	)
+	for _, breakdown := range breakdowns {
+		printComponentBreakdown(breakdown, precision)
+	}
+}
+
+// normalizeFolderPath turns a folder argument into the slash-separated, relative form file
+// paths are stored in, e.g. "./pkg/api/" becomes "pkg/api"
+func normalizeFolderPath(folder string) string {
+	return strings.TrimPrefix(filepath.ToSlash(filepath.Clean(folder)), "./")
+}
+
+// resultForFolder returns a result holding only the snapshot's files under folder ("." for
+// all), carrying over the settings Recompute needs to score them like the full snapshot
+func resultForFolder(snapshot *models.AnalysisResult, folder string) *models.AnalysisResult {
+	folderResult := &models.AnalysisResult{
+		Repository:                  snapshot.Repository,
+		AnalyzedAt:                  snapshot.AnalyzedAt,
+		TimeRange:                   snapshot.TimeRange,
+		ChurnMetric:                 snapshot.ChurnMetric,
+		MinFunctionLines:            snapshot.MinFunctionLines,
+		TrivialExcludedFromAverages: snapshot.TrivialExcludedFromAverages,
+		AverageMethod:               snapshot.AverageMethod,
+		// Recompute reads churn presence from the score report when files carry no churn
+		ScoreReport: snapshot.ScoreReport,
+	}
+
+	for _, file := range snapshot.Files {
+		if folder == "." || file.Path == folder || strings.HasPrefix(file.Path, folder+"/") {
+			folderResult.Files = append(folderResult.Files, file)
+		}
+	}
+	return folderResult
+}
+
+// printComponentBreakdown prints a component's score, to precision decimal places, followed by
+// the concerns behind it
+func printComponentBreakdown(breakdown reports.ComponentBreakdown, precision int) {
+	if breakdown.Component == reports.ComponentOther {
+		fmt.Printf("\nOther\n")
+	} else {
+		scoreColor := getGradeColor(reports.CalculateGrade(breakdown.Score.Score))
+		fmt.Printf("\n%s: %s%s/100%s (%s)\n", componentLabel(breakdown.Component), ansi(scoreColor), reports.FormatScore(breakdown.Score.Score, precision), ansi(colorReset), breakdown.Score.Category)
+	}
+
+	if len(breakdown.Concerns) == 0 {
+		fmt.Printf("  ✨ Nothing flagged\n")
+		return
+	}
+	for _, concern := range breakdown.Concerns {
+		switch concern.Severity {
+		case "critical":
+			printConcern(concern, colorRed, "CRITICAL")
+		case "warning":
+			printConcern(concern, colorYellow, "WARNING")
+		default:
+			printConcern(concern, colorCyan, "INFO")
+		}
+	}
+}
+
+// componentLabel returns the display name of a score component, e.g. "Function size"
+func componentLabel(component string) string {
+	label := strings.ReplaceAll(component, "_", " ")
+	return strings.ToUpper(label[:1]) + label[1:]
+}
+
+func init() {
+	reportFolderCmd.Flags().StringVarP(&folderReportFormat, "format", "f", "text", "Output format (text or json)")
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+func TestNormalizeFolderPath(t *testing.T) {
+	tests := map[string]string{
+		"./pkg/api/": "pkg/api",
+		"pkg/api":    "pkg/api",
+		".":          ".",
+		"./":         ".",
+	}
+	for input, expected := range tests {
+		if got := normalizeFolderPath(input); got != expected {
+			t.Errorf("normalizeFolderPath(%q) = %q, want %q", input, got, expected)
+		}
+	}
+}
+
+func TestResultForFolder(t *testing.T) {
+	snapshot := &models.AnalysisResult{
+		Repository:       "repo",
+		MinFunctionLines: 3,
+		Files: []models.FileAnalysis{
+			{Path: "pkg/api/handler.go"},
+			{Path: "pkg/api/v2/routes.go"},
+			{Path: "pkg/apiclient/client.go"},
+			{Path: "main.go"},
+		},
+	}
+
+	folderResult := resultForFolder(snapshot, "pkg/api")
+	if len(folderResult.Files) != 2 {
+		t.Fatalf("Expected the 2 files under pkg/api (not pkg/apiclient), got %d", len(folderResult.Files))
+	}
+	if folderResult.Files[1].Path != "pkg/api/v2/routes.go" {
+		t.Errorf("Expected nested folders to be included, got %s", folderResult.Files[1].Path)
+	}
+	if folderResult.MinFunctionLines != 3 {
+		t.Errorf("Expected min_function_lines to carry over, got %d", folderResult.MinFunctionLines)
+	}
+
+	if everything := resultForFolder(snapshot, "."); len(everything.Files) != 4 {
+		t.Errorf("Expected . to select every file, got %d", len(everything.Files))
+	}
+}
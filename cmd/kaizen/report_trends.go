@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/alexcollie/kaizen/pkg/analyzer"
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/alexcollie/kaizen/pkg/storage"
+	"github.com/alexcollie/kaizen/pkg/trending"
+	"github.com/spf13/cobra"
+)
+
+var (
+	trendsSummary bool
+	trendsPeriod  string
+	trendsFormat  string
+)
+
+var reportTrendsCmd = &cobra.Command{
+	Use:   "trends",
+	Short: "Summarize how code health changed over a period",
+	Long: `Prints a sparkline for every metric over --period (the last 7 days by default).
+
+With --summary, prints a digest of the period instead, ready to paste into a
+standup or chat channel: the overall grade trend, the five metrics that
+regressed most and the five that improved most (ranked by relative change),
+the functions that became hotspots, and the concerns that were resolved.
+
+Hotspots and concerns are compared between the oldest and newest snapshots in
+the period. Concerns are re-detected for both with the thresholds from
+.kaizen.yaml in the current directory and without reports.max_items_per_concern,
+so an item dropping off a capped list is not mistaken for a fix.`,
+	Args: cobra.NoArgs,
+	Run:  runReportTrends,
+}
+
+func runReportTrends(cmd *cobra.Command, args []string) {
+	if trendsFormat != "ascii" && trendsFormat != "markdown" && trendsFormat != "json" {
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (use ascii, markdown, or json)\n", trendsFormat)
+		os.Exit(1)
+	}
+	if !trendsSummary && trendsFormat != "ascii" {
+		fmt.Fprintf(os.Stderr, "Error: --format %s requires --summary\n", trendsFormat)
+		os.Exit(1)
+	}
+
+	startTime, err := parseSinceTime(trendsPeriod)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: invalid --period %q (use e.g. 7d or 2024-01-01)\n", trendsPeriod)
+		os.Exit(1)
+	}
+	endTime := time.Now()
+
+	backend := openHistoryBackend()
+	defer func() { _ = backend.Close() }()
+
+	if !trendsSummary {
+		renderTrendDashboard(backend, startTime, endTime)
+		return
+	}
+
+	digest := buildTrendsDigest(backend, startTime, endTime)
+	switch trendsFormat {
+	case "json":
+		writeConcernsJSON(digest)
+	case "markdown":
+		fmt.Print(trending.RenderDigestMarkdown(digest, reportPrecision(".")))
+	default:
+		fmt.Print(trending.RenderDigestASCII(digest, reportPrecision(".")))
+	}
+}
+
+// buildTrendsDigest loads the snapshots and metric history between startTime and endTime and
+// summarizes them, exiting when the period holds fewer than two snapshots to compare
+func buildTrendsDigest(backend storage.StorageBackend, startTime, endTime time.Time) trending.Digest {
+	summaries, err := backend.GetRange(startTime, endTime, 0)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not list snapshots: %v\n", err)
+		os.Exit(1)
+	}
+	if len(summaries) < 2 {
+		fmt.Fprintf(os.Stderr, "Error: found %d snapshot(s) since %s; a digest needs at least two\n", len(summaries), startTime.Format("2006-01-02"))
+		os.Exit(1)
+	}
+
+	// GetRange lists the newest snapshot first
+	snapshots := make([]storage.SnapshotSummary, len(summaries))
+	for index, summary := range summaries {
+		snapshots[len(summaries)-1-index] = summary
+	}
+
+	series := make([]trending.MetricSeries, 0, len(trending.DashboardMetrics))
+	for _, metricName := range trending.DashboardMetrics {
+		points, err := backend.GetTimeSeries(metricName, "", startTime, endTime)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: could not retrieve metric data: %v\n", err)
+			os.Exit(1)
+		}
+		series = append(series, trending.MetricSeries{MetricName: metricName, Points: points})
+	}
+
+	cfg, err := config.LoadConfig(".")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not load config: %v\n", err)
+		cfg = config.DefaultConfig()
+	}
+	first := loadDigestSnapshot(backend, snapshots[0].ID, cfg)
+	last := loadDigestSnapshot(backend, snapshots[len(snapshots)-1].ID, cfg)
+
+	return trending.BuildDigest(snapshots, series, first, last, trending.DigestTopMetrics)
+}
+
+// loadDigestSnapshot loads a snapshot with its concerns re-detected under cfg's thresholds and
+// with every affected item listed
+func loadDigestSnapshot(backend storage.StorageBackend, snapshotID int64, cfg *config.Config) *models.AnalysisResult {
+	snapshot, err := backend.GetByID(snapshotID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not load snapshot #%d: %v\n", snapshotID, err)
+		os.Exit(1)
+	}
+	analyzer.NewAggregator().Recompute(snapshot, cfg.Thresholds, cfg.Scoring, config.ReportsConfig{MaxItemsPerConcern: 0})
+	return snapshot
+}
+
+func init() {
+	reportTrendsCmd.Flags().BoolVar(&trendsSummary, "summary", false, "Print a digest of grade, metric regressions and improvements, new hotspots, and resolved concerns")
+	reportTrendsCmd.Flags().StringVar(&trendsPeriod, "period", "7d", "Period to cover (e.g., 7d, 30d, 2024-01-01)")
+	reportTrendsCmd.Flags().StringVarP(&trendsFormat, "format", "f", "ascii", "With --summary, output format (ascii, markdown, or json)")
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// stdinInput is the input path that reads results JSON from stdin instead of a file, so
+// commands can be piped: kaizen analyze --json-only | kaizen visualize --input=-
+const stdinInput = "-"
+
+// readInput reads an input file, or all of stdin when path is stdinInput
+func readInput(path string) ([]byte, error) {
+	if path == stdinInput {
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("could not read stdin: %w", err)
+		}
+		return data, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read file %s: %w", path, err)
+	}
+	return data, nil
+}
+
+// inputName names an input path in messages
+func inputName(path string) string {
+	if path == stdinInput {
+		return "stdin"
+	}
+	return path
+}
+
+// checkSingleStdinInput returns an error when more than one of paths reads stdin, which can
+// only be read once
+func checkSingleStdinInput(paths ...string) error {
+	count := 0
+	for _, path := range paths {
+		if path == stdinInput {
+			count++
+		}
+	}
+	if count > 1 {
+		return fmt.Errorf("only one input can be read from stdin (%q)", stdinInput)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package main
+
+import "testing"
+
+func TestCheckSingleStdinInput(t *testing.T) {
+	if err := checkSingleStdinInput("base.json", "-", ""); err != nil {
+		t.Errorf("checkSingleStdinInput with one stdin input returned error: %v", err)
+	}
+	if err := checkSingleStdinInput("-", "head.json", "-"); err == nil {
+		t.Error("checkSingleStdinInput with two stdin inputs should return an error")
+	}
+}
+
+func TestInputName(t *testing.T) {
+	if got := inputName("-"); got != "stdin" {
+		t.Errorf("inputName(\"-\") = %q, expected stdin", got)
+	}
+	if got := inputName("kaizen-results.json"); got != "kaizen-results.json" {
+		t.Errorf("inputName(file) = %q, expected the path", got)
+	}
+}
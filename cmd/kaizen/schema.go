@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/alexcollie/kaizen/pkg/schema"
+	"github.com/spf13/cobra"
+)
+
+var schemaOutput string
+
+var schemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of analysis results",
+	Long: `Prints the JSON Schema (draft 2020-12) of the results JSON written by
+kaizen analyze, generated from the same structs the results are marshaled from.
+
+Every result carries a schema_version field, and the schema pins it to the
+version this build writes. The version is bumped whenever a field is removed,
+renamed, retyped, or changes meaning, so downstream tools can check it before
+reading a result. New optional fields do not bump it.`,
+	Args: cobra.NoArgs,
+	Run:  runSchema,
+}
+
+func runSchema(cmd *cobra.Command, args []string) {
+	data, err := schema.Marshal(schema.AnalysisResult())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not generate schema: %v\n", err)
+		os.Exit(1)
+	}
+
+	if schemaOutput == "" {
+		fmt.Print(string(data))
+		return
+	}
+	if err := os.WriteFile(schemaOutput, data, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not write %s: %v\n", schemaOutput, err)
+		os.Exit(1)
+	}
+	fmt.Printf("✅ Schema written to %s\n", schemaOutput)
+}
+
+func init() {
+	schemaCmd.Flags().StringVarP(&schemaOutput, "output", "o", "", "Write the schema to a file instead of stdout")
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/alexcollie/kaizen/pkg/server"
+	"github.com/alexcollie/kaizen/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+var (
+	servePath           string
+	servePort           int
+	serveHost           string
+	serveCodeOwnersPath string
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve an interactive dashboard from the snapshot database",
+	Long: `Starts an HTTP server that renders Kaizen dashboards live from the
+SQLite snapshot database instead of writing static HTML files:
+  - /            Dashboard with snapshot and view pickers
+  - /heatmap     Treemap heat map (?snapshot=<id>, latest by default)
+  - /trends      Metric trend chart (?metric=<name>&days=<n>&folder=<path>)
+  - /callgraph   Function call graph of the source tree
+  - /owners      Code ownership report (requires CODEOWNERS)
+  - /api/snapshots  Snapshot list as JSON
+
+Press Ctrl+C to stop the server.`,
+	Run: runServe,
+}
+
+func init() {
+	serveCmd.Flags().StringVarP(&servePath, "path", "p", ".", "Project path containing the .kaizen database")
+	serveCmd.Flags().IntVar(&servePort, "port", 8080, "Port to listen on")
+	serveCmd.Flags().StringVar(&serveHost, "host", "localhost", "Interface to bind (use 0.0.0.0 to share with a team)")
+	serveCmd.Flags().StringVarP(&serveCodeOwnersPath, "codeowners", "c", "", "Path to CODEOWNERS file (auto-detected if not specified)")
+}
+
+func runServe(cmd *cobra.Command, args []string) {
+	dbPath, err := locateDatabase(servePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not locate database: %v\n", err)
+		os.Exit(1)
+	}
+
+	backend, err := storage.NewBackend(storage.BackendConfig{
+		Type:       "sqlite",
+		Path:       dbPath,
+		Repository: repositoryScope(servePath),
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not open database: %v\n", err)
+		os.Exit(1)
+	}
+	defer func() { _ = backend.Close() }()
+
+	codeownersPath := serveCodeOwnersPath
+	if codeownersPath == "" {
+		codeownersPath = findCodeOwnersFile(servePath)
+	}
+
+	dashboard := server.NewServer(backend, server.Options{
+		RootPath:        servePath,
+		CodeOwnersPath:  codeownersPath,
+		ExcludePatterns: loadExcludePatterns(servePath),
+		Precision:       reportPrecision(servePath),
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	address := fmt.Sprintf("%s:%d", serveHost, servePort)
+	fmt.Printf("🌐 Kaizen dashboard running at http://%s\n", address)
+	fmt.Printf("   Database: %s\n", dbPath)
+	fmt.Printf("   Press Ctrl+C to stop\n")
+
+	err = dashboard.ListenAndServe(ctx, address)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: server failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("\n👋 Server stopped\n")
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/alexcollie/kaizen/pkg/visualization"
+)
+
+// treemapSizeBy is the --size-by flag choosing what the HTML treemap cell area represents
+var treemapSizeBy string
+
+// validateSizeBy exits with an error unless sizeBy is a supported treemap sizing dimension
+func validateSizeBy(sizeBy string) {
+	switch sizeBy {
+	case visualization.SizeByLines, visualization.SizeByFunctions, visualization.SizeByHotspots:
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Error: unsupported --size-by '%s' (use lines, functions, or hotspots)\n", sizeBy)
+	os.Exit(1)
+}
+
+// treemapMaxDepth is the --max-depth flag folding deeper HTML treemap folders into their
+// ancestor at that depth; 0 keeps every level
+var treemapMaxDepth int
+
+// validateMaxDepth exits with an error when maxDepth is negative
+func validateMaxDepth(maxDepth int) {
+	if maxDepth < 0 {
+		fmt.Fprintf(os.Stderr, "Error: --max-depth must be 0 (no limit) or a positive depth, got %d\n", maxDepth)
+		os.Exit(1)
+	}
+}
+
+// treemapLabelThreshold is the --label-threshold flag: the narrowest HTML treemap cell, in
+// pixels, that shows its folder name
+var treemapLabelThreshold int
+
+// validateLabelThreshold exits with an error unless labelThreshold is a positive width
+func validateLabelThreshold(labelThreshold int) {
+	if labelThreshold < 1 {
+		fmt.Fprintf(os.Stderr, "Error: --label-threshold must be a positive width in pixels, got %d\n", labelThreshold)
+		os.Exit(1)
+	}
+}
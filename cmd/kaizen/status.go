@@ -0,0 +1,121 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/alexcollie/kaizen/pkg/reports"
+	"github.com/alexcollie/kaizen/pkg/storage"
+	"github.com/spf13/cobra"
+)
+
+var statusFormat string
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the grade and score of the latest stored snapshot",
+	Long: `Reads the most recent snapshot from the history database and prints its
+grade and score without re-analyzing.
+
+Formats:
+  text     Human-readable one-liner (default)
+  json     {"grade":"B","score":78.4,"snapshot_id":42,"analyzed_at":"..."}
+  shields  shields.io endpoint JSON, for README badges`,
+	Run: runStatus,
+}
+
+// statusReport is the json output of the status command
+type statusReport struct {
+	Grade      string    `json:"grade"`
+	Score      float64   `json:"score"`
+	SnapshotID int64     `json:"snapshot_id"`
+	AnalyzedAt time.Time `json:"analyzed_at"`
+}
+
+// shieldsBadge is a shields.io endpoint response (https://shields.io/badges/endpoint-badge)
+type shieldsBadge struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+func runStatus(cmd *cobra.Command, args []string) {
+	if statusFormat != "text" && statusFormat != "json" && statusFormat != "shields" {
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q (use text, json, or shields)\n", statusFormat)
+		os.Exit(1)
+	}
+
+	backend := openHistoryBackend()
+	defer func() { _ = backend.Close() }()
+
+	summary, err := backend.GetLatestSummary()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: no snapshots found (run 'kaizen analyze' first): %v\n", err)
+		os.Exit(1)
+	}
+
+	switch statusFormat {
+	case "json":
+		writeStatusJSON(buildStatusReport(summary))
+	case "shields":
+		writeStatusJSON(buildShieldsBadge(summary))
+	default:
+		gradeColor := getGradeColor(summary.OverallGrade)
+		fmt.Printf("%s%s%s (%s/100) — snapshot #%d, %s\n",
+			ansi(gradeColor), summary.OverallGrade, ansi(colorReset),
+			reports.FormatScore(summary.OverallScore, reportPrecision(".")), summary.ID, summary.AnalyzedAt.Format("2006-01-02 15:04"))
+	}
+}
+
+// buildStatusReport reduces a snapshot summary to the fields the json format prints
+func buildStatusReport(summary *storage.SnapshotSummary) statusReport {
+	return statusReport{
+		Grade:      summary.OverallGrade,
+		Score:      summary.OverallScore,
+		SnapshotID: summary.ID,
+		AnalyzedAt: summary.AnalyzedAt,
+	}
+}
+
+// buildShieldsBadge renders a snapshot summary as a shields.io badge, e.g. "B (78)" in green
+func buildShieldsBadge(summary *storage.SnapshotSummary) shieldsBadge {
+	return shieldsBadge{
+		SchemaVersion: 1,
+		Label:         "kaizen",
+		Message:       fmt.Sprintf("%s (%.0f)", summary.OverallGrade, summary.OverallScore),
+		Color:         shieldsGradeColor(summary.OverallGrade),
+	}
+}
+
+// shieldsGradeColor maps a letter grade to a shields.io named color
+func shieldsGradeColor(grade string) string {
+	switch grade {
+	case "A":
+		return "brightgreen"
+	case "B":
+		return "green"
+	case "C":
+		return "yellow"
+	case "D":
+		return "orange"
+	case "F":
+		return "red"
+	default:
+		return "lightgrey"
+	}
+}
+
+func writeStatusJSON(value interface{}) {
+	encoder := json.NewEncoder(os.Stdout)
+	if err := encoder.Encode(value); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not encode status: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func init() {
+	statusCmd.Flags().StringVarP(&statusFormat, "format", "f", "text", "Output format (text, json, or shields)")
+}
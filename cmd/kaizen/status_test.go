@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alexcollie/kaizen/pkg/storage"
+)
+
+func TestBuildStatusReport(t *testing.T) {
+	analyzedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	summary := &storage.SnapshotSummary{ID: 42, AnalyzedAt: analyzedAt, OverallGrade: "B", OverallScore: 78.4}
+
+	encoded, err := json.Marshal(buildStatusReport(summary))
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	expected := `{"grade":"B","score":78.4,"snapshot_id":42,"analyzed_at":"2026-03-01T12:00:00Z"}`
+	if string(encoded) != expected {
+		t.Errorf("Expected %s, got %s", expected, encoded)
+	}
+}
+
+func TestBuildShieldsBadge(t *testing.T) {
+	summary := &storage.SnapshotSummary{OverallGrade: "D", OverallScore: 61.6}
+
+	badge := buildShieldsBadge(summary)
+
+	if badge.SchemaVersion != 1 {
+		t.Errorf("Expected schemaVersion 1, got %d", badge.SchemaVersion)
+	}
+	if badge.Label != "kaizen" {
+		t.Errorf("Expected label kaizen, got %q", badge.Label)
+	}
+	if badge.Message != "D (62)" {
+		t.Errorf("Expected message \"D (62)\", got %q", badge.Message)
+	}
+	if badge.Color != "orange" {
+		t.Errorf("Expected color orange, got %q", badge.Color)
+	}
+}
+
+func TestShieldsGradeColorUnknownGrade(t *testing.T) {
+	if color := shieldsGradeColor(""); color != "lightgrey" {
+		t.Errorf("Expected lightgrey for an unknown grade, got %q", color)
+	}
+}
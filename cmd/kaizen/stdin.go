@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/alexcollie/kaizen/pkg/analyzer"
+	"github.com/alexcollie/kaizen/pkg/languages"
+)
+
+var (
+	analyzeStdin  bool
+	stdinLanguage string
+	stdinPath     string
+)
+
+// runAnalyzeStdin analyzes a single source buffer read from stdin and prints its FileAnalysis
+// as JSON. Nothing is written to disk and no snapshot is saved, so editors can call it on every
+// change.
+func runAnalyzeStdin() {
+	registry := languages.NewRegistry()
+	languageAnalyzer, err := resolveStdinAnalyzer(registry, stdinLanguage, stdinPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	cfg, err := config.LoadConfig(rootPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not load config: %v\n", err)
+		cfg = config.DefaultConfig()
+	}
+
+	source, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: could not read stdin: %v\n", err)
+		os.Exit(1)
+	}
+
+	reportedPath := stdinPath
+	if reportedPath == "" {
+		reportedPath = "<stdin>"
+	}
+
+	options := analyzer.AnalysisOptions{
+		MIVariant:               cfg.Analysis.MIVariant,
+		CountStdlibCalls:        cfg.Analysis.CountStdlibCalls,
+		CountAnonymousFunctions: cfg.Analysis.CountAnonymousFunctions,
+	}
+	analysis, err := analyzer.AnalyzeSource(languageAnalyzer, reportedPath, source, options)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	// Keep "<stdin>" readable instead of escaping it for HTML
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(analysis); err != nil {
+		fmt.Fprintf(os.Stderr, "Error marshaling JSON: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// resolveStdinAnalyzer picks the analyzer for a stdin buffer. --lang accepts a language name
+// or one of its file extensions, case-insensitively (go, python, py, objective-c, m); without
+// it the extension of --stdin-path decides.
+func resolveStdinAnalyzer(registry *languages.Registry, language string, path string) (analyzer.LanguageAnalyzer, error) {
+	if language == "" {
+		if path == "" {
+			return nil, fmt.Errorf("--stdin needs --lang or a --stdin-path with a known extension")
+		}
+		return registry.GetAnalyzerForFile(path)
+	}
+
+	extension := "." + strings.TrimPrefix(strings.ToLower(language), ".")
+	for _, languageAnalyzer := range registry.GetAllAnalyzers() {
+		if strings.EqualFold(languageAnalyzer.Name(), language) {
+			return languageAnalyzer, nil
+		}
+		for _, supported := range languageAnalyzer.FileExtensions() {
+			if supported == extension {
+				return languageAnalyzer, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("unsupported --lang '%s' (supported: %s)", language, strings.Join(registry.GetSupportedLanguages(), ", "))
+}
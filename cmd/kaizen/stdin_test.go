@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/alexcollie/kaizen/pkg/languages"
+)
+
+func TestResolveStdinAnalyzer(t *testing.T) {
+	registry := languages.NewRegistry()
+
+	cases := []struct {
+		language string
+		path     string
+		expected string
+	}{
+		{language: "go", expected: "Go"},
+		{language: "Python", expected: "Python"},
+		{language: "py", expected: "Python"},
+		{language: ".swift", expected: "Swift"},
+		{language: "objective-c", expected: "Objective-C"},
+		{path: "src/app/main.kt", expected: "Kotlin"},
+		{language: "go", path: "script.py", expected: "Go"},
+	}
+
+	for _, testCase := range cases {
+		languageAnalyzer, err := resolveStdinAnalyzer(registry, testCase.language, testCase.path)
+		if err != nil {
+			t.Errorf("resolveStdinAnalyzer(%q, %q) returned error: %v", testCase.language, testCase.path, err)
+			continue
+		}
+		if languageAnalyzer.Name() != testCase.expected {
+			t.Errorf("resolveStdinAnalyzer(%q, %q) = %s, expected %s", testCase.language, testCase.path, languageAnalyzer.Name(), testCase.expected)
+		}
+	}
+}
+
+func TestResolveStdinAnalyzerErrors(t *testing.T) {
+	registry := languages.NewRegistry()
+
+	if _, err := resolveStdinAnalyzer(registry, "", ""); err == nil {
+		t.Error("Expected an error when neither --lang nor --stdin-path is given")
+	}
+	if _, err := resolveStdinAnalyzer(registry, "rust", ""); err == nil {
+		t.Error("Expected an error for an unsupported language")
+	}
+	if _, err := resolveStdinAnalyzer(registry, "", "notes.txt"); err == nil {
+		t.Error("Expected an error for an unknown extension")
+	}
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// Themes accepted by --theme for HTML output
+const (
+	themeNordic = "nordic"
+	themeLight  = "light"
+)
+
+// htmlTheme is the --theme flag shared by the commands that write HTML
+var htmlTheme string
+
+// validateTheme exits with an error unless theme is a supported HTML theme
+func validateTheme(theme string) {
+	if theme != themeNordic && theme != themeLight {
+		fmt.Fprintf(os.Stderr, "Error: unsupported --theme '%s' (use nordic or light)\n", theme)
+		os.Exit(1)
+	}
+}
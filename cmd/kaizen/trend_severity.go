@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/alexcollie/kaizen/pkg/reports"
+	"github.com/alexcollie/kaizen/pkg/storage"
+)
+
+var (
+	trendAwareSeverity bool
+	trendSnapshots     int
+)
+
+// escalateWorseningConcerns applies --trend-aware-severity to result's concerns, comparing the
+// current metrics with the function history stored for the analyzed path. It runs before the
+// snapshot is saved, so the history does not yet include this analysis.
+func escalateWorseningConcerns(result *models.AnalysisResult, thresholds config.ThresholdConfig) {
+	if result.ScoreReport == nil || len(result.ScoreReport.Concerns) == 0 {
+		return
+	}
+
+	dbPath, err := locateDatabase(rootPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: trend-aware severity skipped, could not setup database: %v\n", err)
+		return
+	}
+	backend, err := storage.NewBackend(storage.BackendConfig{Type: "sqlite", Path: dbPath, Repository: repositoryScope(rootPath)})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: trend-aware severity skipped, could not create storage backend: %v\n", err)
+		return
+	}
+	defer func() { _ = backend.Close() }()
+
+	records, err := backend.GetFunctionHistory(time.Time{})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: trend-aware severity skipped: %v\n", err)
+		return
+	}
+
+	concerns := reports.EscalateWorseningConcerns(result.ScoreReport.Concerns, records, trendSnapshots, thresholds)
+	result.ScoreReport.Concerns = concerns
+	result.ScoreReport.ConcernSummary = reports.SummarizeConcerns(concerns)
+
+	escalatedCount := 0
+	for _, concern := range concerns {
+		if concern.EscalatedFrom != "" {
+			escalatedCount += len(concern.AffectedItems)
+		}
+	}
+	if escalatedCount > 0 {
+		analyzeLogf("📈 Escalated %d worsening warning(s) to critical (regressed over the last %d snapshots)\n\n", escalatedCount, trendSnapshots)
+	}
+}
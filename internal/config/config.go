@@ -4,8 +4,11 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -24,30 +27,108 @@ type Config struct {
 	// Storage settings
 	Storage StorageConfig `yaml:"storage"`
 
+	// Overall score settings
+	Scoring ScoringConfig `yaml:"scoring"`
+
+	// Score report settings
+	Reports ReportsConfig `yaml:"reports"`
+
 	// Ignore patterns from .kaizenignore
 	IgnorePatterns []string `yaml:"-"`
+
+	// .kaizen.yaml and .kaizenignore files that were loaded, farthest from the analyzed path first
+	SourceFiles []string `yaml:"-"`
 }
 
+// DefaultMaxFileSize is the default analysis.max_file_size (1MB)
+const DefaultMaxFileSize int64 = 1024 * 1024
+
+// DefaultMIVariant is the default analysis.mi_variant
+const DefaultMIVariant = "classic"
+
+// DefaultTimeoutPerFile is the default analysis.timeout_per_file
+const DefaultTimeoutPerFile = 30 * time.Second
+
+// Churn metrics accepted by analysis.churn_metric
+const (
+	ChurnMetricCommits = "commits" // Commits touching a function
+	ChurnMetricLines   = "lines"   // Lines added plus deleted in a function
+	ChurnMetricBoth    = "both"    // Either count crossing its threshold
+)
+
+// DefaultChurnMetric is the default analysis.churn_metric
+const DefaultChurnMetric = ChurnMetricCommits
+
+// How thresholds.hotspot.combine joins the complexity and churn conditions
+const (
+	HotspotCombineAnd = "and" // Complex and churning
+	HotspotCombineOr  = "or"  // Complex or churning
+)
+
+// DefaultHotspotCombine is the default thresholds.hotspot.combine
+const DefaultHotspotCombine = HotspotCombineAnd
+
+// File path styles accepted by analysis.path_style
+const (
+	PathStyleRelative = "relative" // Relative to the repository root recorded in the result
+	PathStyleAbsolute = "absolute" // Absolute paths on the analyzing machine
+)
+
+// DefaultPathStyle is the default analysis.path_style
+const DefaultPathStyle = PathStyleRelative
+
+// Averaging methods accepted by analysis.average_method
+const (
+	AverageMethodPerFunction   = "per_function"    // Mean over every function; large files weigh more
+	AverageMethodPerFile       = "per_file"        // Mean of each file's function mean; every file weighs the same
+	AverageMethodWeightedByLOC = "weighted_by_loc" // Mean of each file's function mean, weighted by the file's code lines
+)
+
+// DefaultAverageMethod is the default analysis.average_method
+const DefaultAverageMethod = AverageMethodPerFunction
+
 // AnalysisConfig contains analysis-specific settings
 type AnalysisConfig struct {
-	Since          string   `yaml:"since"`           // Default time range for churn (e.g., "90d")
-	Languages      []string `yaml:"languages"`       // Languages to analyze
-	ExcludePattern []string `yaml:"exclude"`         // Additional exclude patterns
-	SkipChurn      bool     `yaml:"skip_churn"`      // Skip git churn analysis
-	MaxWorkers     int      `yaml:"max_workers"`     // Number of parallel workers
+	Since                      string        `yaml:"since"`                         // Default time range for churn (e.g., "90d")
+	Languages                  []string      `yaml:"languages"`                     // Languages to analyze
+	ExcludePattern             []string      `yaml:"exclude"`                       // Additional exclude patterns
+	ExcludeDirs                []string      `yaml:"exclude_dirs"`                  // Directory names skipped wherever they appear
+	SkipChurn                  bool          `yaml:"skip_churn"`                    // Skip git churn analysis
+	MaxWorkers                 int           `yaml:"max_workers"`                   // Number of parallel workers
+	MaxFileSize                int64         `yaml:"max_file_size"`                 // Skip files larger than this many bytes (0 = no limit)
+	SkipGenerated              bool          `yaml:"skip_generated"`                // Skip generated files, by file name or header marker
+	MIVariant                  string        `yaml:"mi_variant"`                    // Maintainability index formula: classic, microsoft, or sei
+	CountStdlibCalls           bool          `yaml:"count_stdlib_calls"`            // Count calls to built-ins and the standard library in fan-out
+	CountAnonymousFunctions    bool          `yaml:"count_anonymous_functions"`     // Report closures, lambdas, and blocks as functions of their own
+	TimeoutPerFile             time.Duration `yaml:"timeout_per_file"`              // Skip files whose analysis takes longer than this (0 = no limit)
+	ChurnMetric                string        `yaml:"churn_metric"`                  // Churn count used for hotspots and churn concerns: commits, lines, or both
+	MinFunctionLines           int           `yaml:"min_function_lines"`            // Functions shorter than this are trivial and skipped by concern detection (0 = off)
+	ExcludeTrivialFromAverages bool          `yaml:"exclude_trivial_from_averages"` // Also leave trivial functions out of the summary averages
+	PathStyle                  string        `yaml:"path_style"`                    // File paths in results: relative to the repository root, or absolute
+	AverageMethod              string        `yaml:"average_method"`                // How summary and folder averages combine functions: per_function, per_file, or weighted_by_loc
+	CoverageProfile            string        `yaml:"coverage_profile"`              // Go cover profile or LCOV tracefile giving each file's test coverage (none when empty)
 }
 
 // ThresholdConfig contains all configurable thresholds for concern detection
 type ThresholdConfig struct {
-	Complexity           SeverityThresholds        `yaml:"complexity"`
-	CognitiveComplexity  SeverityThresholds        `yaml:"cognitive_complexity"`
-	FunctionLength       SeverityThresholds        `yaml:"function_length"`
-	NestingDepth         SeverityThresholds        `yaml:"nesting_depth"`
-	ParameterCount       SeverityThresholds        `yaml:"parameter_count"`
-	MaintainabilityIndex MaintainabilityThresholds `yaml:"maintainability_index"`
-	Churn                SeverityThresholds        `yaml:"churn"`
-	GodFunction          GodFunctionThresholds     `yaml:"god_function"`
-	Hotspot              HotspotThresholds         `yaml:"hotspot"`
+	Complexity           SeverityThresholds            `yaml:"complexity"`
+	CognitiveComplexity  SeverityThresholds            `yaml:"cognitive_complexity"`
+	FunctionLength       SeverityThresholds            `yaml:"function_length"`
+	NestingDepth         SeverityThresholds            `yaml:"nesting_depth"`
+	ParameterCount       SeverityThresholds            `yaml:"parameter_count"`
+	MaintainabilityIndex MaintainabilityThresholds     `yaml:"maintainability_index"`
+	FileMaintainability  FileMaintainabilityThresholds `yaml:"file_maintainability"`
+	Churn                SeverityThresholds            `yaml:"churn"`
+	ChurnLines           SeverityThresholds            `yaml:"churn_lines"`
+	WeightedMethods      SeverityThresholds            `yaml:"weighted_methods"`
+	FanOut               SeverityThresholds            `yaml:"fan_out"`
+	LocalVariables       SeverityThresholds            `yaml:"local_variables"`
+	MethodChain          SeverityThresholds            `yaml:"method_chain"`
+	GodFunction          GodFunctionThresholds         `yaml:"god_function"`
+	Hotspot              HotspotThresholds             `yaml:"hotspot"`
+	CommentDensity       CommentDensityThresholds      `yaml:"comment_density"`
+	TestRatio            TestRatioThresholds           `yaml:"test_ratio"`
+	CustomRules          []CustomRule                  `yaml:"custom_rules"`
 }
 
 // SeverityThresholds defines info/warning/critical levels for upward metrics
@@ -65,18 +146,56 @@ type MaintainabilityThresholds struct {
 	Critical int `yaml:"critical"` // Below this = critical concern
 }
 
+// FileMaintainabilityThresholds are inverted like maintainability (lower values = worse): a
+// file's maintainability index is the mean of its functions', weighted by function length
+type FileMaintainabilityThresholds struct {
+	Warning      int `yaml:"warning"`       // Below this = warning concern
+	Critical     int `yaml:"critical"`      // Below this = critical concern
+	MinFunctions int `yaml:"min_functions"` // Files with fewer functions are not checked
+}
+
 // GodFunctionThresholds require both conditions to be met
 type GodFunctionThresholds struct {
 	MinParameters int `yaml:"min_parameters"`
 	MinFanIn      int `yaml:"min_fan_in"`
 }
 
-// HotspotThresholds require both conditions to be met
+// HotspotThresholds flag complex, churning functions; Combine decides whether both conditions
+// must hold (and) or either is enough (or). Which churn minimum applies follows
+// analysis.churn_metric
 type HotspotThresholds struct {
-	MinComplexity int `yaml:"min_complexity"`
-	MinChurn      int `yaml:"min_churn"`
+	MinComplexity int    `yaml:"min_complexity"`
+	MinChurn      int    `yaml:"min_churn"`
+	MinChurnLines int    `yaml:"min_churn_lines"`
+	Combine       string `yaml:"combine"`
 }
 
+// CommentDensityThresholds define the healthy comment-density range (percent of lines)
+type CommentDensityThresholds struct {
+	Min      int `yaml:"min"`       // Below this = possibly undocumented; 0 = never
+	Max      int `yaml:"max"`       // Above this = possibly over-commented or commented-out code; 0 = never
+	MinLines int `yaml:"min_lines"` // Files with fewer code lines are not checked
+}
+
+// TestRatioThresholds are inverted like maintainability (lower values = worse): a folder's test
+// code lines divided by its production code lines, checked when tests are analyzed
+type TestRatioThresholds struct {
+	Info     float64 `yaml:"info"`      // Below this = info concern
+	Warning  float64 `yaml:"warning"`   // Below this = warning concern
+	MinLines int     `yaml:"min_lines"` // Folders with fewer production code lines are not checked
+}
+
+// CustomRule is a named regular expression; every source line it matches is reported as a concern
+type CustomRule struct {
+	Name     string `yaml:"name"`     // Shown as the concern title
+	Pattern  string `yaml:"pattern"`  // Regular expression matched against each source line
+	Severity string `yaml:"severity"` // info, warning or critical (default: warning)
+	Message  string `yaml:"message"`  // Why the pattern is forbidden or what to use instead
+}
+
+// DefaultCustomRuleSeverity is used for custom rules that do not set a severity
+const DefaultCustomRuleSeverity = "warning"
+
 // VisualizationConfig contains visualization settings
 type VisualizationConfig struct {
 	DefaultMetric    string `yaml:"default_metric"`     // Default metric to show
@@ -94,6 +213,49 @@ type StorageConfig struct {
 	AutoPrune      bool   `yaml:"auto_prune"`       // Auto-prune on each analyze
 }
 
+// ScoringConfig contains settings for the overall score computation
+type ScoringConfig struct {
+	ChurnWeighted bool        `yaml:"churn_weighted"` // Weight each file's functions by its churn (needs churn data)
+	Risk          RiskWeights `yaml:"risk"`           // Blend of the per-folder risk score
+}
+
+// ReportsConfig contains settings for the concerns in score reports
+type ReportsConfig struct {
+	MaxItemsPerConcern      int     `yaml:"max_items_per_concern"`     // Affected items listed per concern (0 = unlimited)
+	FolderRegressionPercent float64 `yaml:"folder_regression_percent"` // Rise in a folder's complexity or hotspot score that analyze --alert-regressions reports
+	Precision               int     `yaml:"precision"`                 // Decimal places scores are shown with in ascii, markdown, and html output
+}
+
+// DefaultMaxItemsPerConcern is the default reports.max_items_per_concern
+const DefaultMaxItemsPerConcern = 5
+
+// DefaultFolderRegressionPercent is the default reports.folder_regression_percent
+const DefaultFolderRegressionPercent = 20.0
+
+// DefaultReportPrecision is the default reports.precision, e.g. 78.4
+const DefaultReportPrecision = 1
+
+// MaxReportPrecision is the largest reports.precision allowed
+const MaxReportPrecision = 4
+
+// ScorePrecision returns the decimal places reports show scores with: Precision, or
+// DefaultReportPrecision when it is out of range, which ValidationErrors reports
+func (reportsConfig ReportsConfig) ScorePrecision() int {
+	if reportsConfig.Precision < 0 || reportsConfig.Precision > MaxReportPrecision {
+		return DefaultReportPrecision
+	}
+	return reportsConfig.Precision
+}
+
+// RiskWeights set how much each signal contributes to the risk score. Signals without data
+// (churn when churn is skipped, coverage when no coverage is recorded) are left out and the
+// remaining weights are rescaled to sum to one.
+type RiskWeights struct {
+	Churn      float64 `yaml:"churn"`      // Weight of the churn percentile
+	Complexity float64 `yaml:"complexity"` // Weight of the complexity percentile
+	Coverage   float64 `yaml:"coverage"`   // Weight of the uncovered line percentage
+}
+
 // DefaultConfig returns the default configuration
 func DefaultConfig() *Config {
 	return &Config{
@@ -101,8 +263,16 @@ func DefaultConfig() *Config {
 			Since:      "90d",
 			Languages:  []string{},
 			ExcludePattern: []string{"vendor", "node_modules", "*_test.go"},
+			ExcludeDirs:    []string{},
 			SkipChurn:  false,
 			MaxWorkers: 8,
+			MaxFileSize:   DefaultMaxFileSize,
+			SkipGenerated: true,
+			MIVariant:     DefaultMIVariant,
+			TimeoutPerFile: DefaultTimeoutPerFile,
+			ChurnMetric:    DefaultChurnMetric,
+			PathStyle:      DefaultPathStyle,
+			AverageMethod:  DefaultAverageMethod,
 		},
 		Thresholds: ThresholdConfig{
 			Complexity: SeverityThresholds{
@@ -123,14 +293,38 @@ func DefaultConfig() *Config {
 			MaintainabilityIndex: MaintainabilityThresholds{
 				Info: 60, Warning: 40, Critical: 20,
 			},
+			FileMaintainability: FileMaintainabilityThresholds{
+				Warning: 50, Critical: 40, MinFunctions: 3,
+			},
 			Churn: SeverityThresholds{
 				Info: 5, Warning: 10, Critical: 20,
 			},
+			ChurnLines: SeverityThresholds{
+				Info: 100, Warning: 250, Critical: 500,
+			},
+			WeightedMethods: SeverityThresholds{
+				Info: 20, Warning: 50, Critical: 100,
+			},
+			FanOut: SeverityThresholds{
+				Info: 20, Warning: 30, Critical: 60,
+			},
+			LocalVariables: SeverityThresholds{
+				Info: 7, Warning: 10, Critical: 15,
+			},
+			MethodChain: SeverityThresholds{
+				Info: 2, Warning: 3, Critical: 5,
+			},
 			GodFunction: GodFunctionThresholds{
 				MinParameters: 6, MinFanIn: 10,
 			},
 			Hotspot: HotspotThresholds{
-				MinComplexity: 10, MinChurn: 10,
+				MinComplexity: 10, MinChurn: 10, MinChurnLines: 250, Combine: DefaultHotspotCombine,
+			},
+			CommentDensity: CommentDensityThresholds{
+				Min: 5, Max: 40, MinLines: 20,
+			},
+			TestRatio: TestRatioThresholds{
+				Info: 0.5, Warning: 0.2, MinLines: 50,
 			},
 		},
 		Visualization: VisualizationConfig{
@@ -146,35 +340,82 @@ func DefaultConfig() *Config {
 			RetentionDays:  90,
 			AutoPrune:      false,
 		},
+		Scoring: ScoringConfig{
+			Risk: RiskWeights{
+				Churn: 0.4, Complexity: 0.4, Coverage: 0.2,
+			},
+		},
+		Reports: ReportsConfig{
+			MaxItemsPerConcern:      DefaultMaxItemsPerConcern,
+			FolderRegressionPercent: DefaultFolderRegressionPercent,
+			Precision:               DefaultReportPrecision,
+		},
 		IgnorePatterns: []string{},
 	}
 }
 
-// LoadConfig loads configuration from .kaizen.yaml and .kaizenignore
+// LoadConfig loads configuration from .kaizen.yaml and .kaizenignore in rootPath and in each
+// parent directory up to the git root (or the filesystem root outside a repository).
+//
+// Files are applied from the farthest directory to rootPath, so the nearest .kaizen.yaml wins
+// for every key it sets and inherits the rest; lists such as analysis.exclude are replaced, not
+// appended. Patterns from every .kaizenignore are combined.
 func LoadConfig(rootPath string) (*Config, error) {
 	config := DefaultConfig()
 
-	// Try to load .kaizen.yaml
-	yamlPath := filepath.Join(rootPath, ".kaizen.yaml")
-	if _, err := os.Stat(yamlPath); err == nil {
-		err = config.loadYAML(yamlPath)
-		if err != nil {
-			return nil, err
-		}
+	searchDirs, err := configSearchDirs(rootPath)
+	if err != nil {
+		return nil, err
 	}
 
-	// Load .kaizenignore
-	ignorePath := filepath.Join(rootPath, ".kaizenignore")
-	if _, err := os.Stat(ignorePath); err == nil {
-		err = config.loadIgnoreFile(ignorePath)
-		if err != nil {
-			return nil, err
+	for _, dir := range searchDirs {
+		yamlPath := filepath.Join(dir, ".kaizen.yaml")
+		if _, err := os.Stat(yamlPath); err == nil {
+			if err := config.loadYAML(yamlPath); err != nil {
+				return nil, fmt.Errorf("%s: %w", yamlPath, err)
+			}
+			config.SourceFiles = append(config.SourceFiles, yamlPath)
+		}
+
+		ignorePath := filepath.Join(dir, ".kaizenignore")
+		if _, err := os.Stat(ignorePath); err == nil {
+			if err := config.loadIgnoreFile(ignorePath); err != nil {
+				return nil, fmt.Errorf("%s: %w", ignorePath, err)
+			}
+			config.SourceFiles = append(config.SourceFiles, ignorePath)
 		}
 	}
 
 	return config, nil
 }
 
+// configSearchDirs returns rootPath and its parents up to the nearest directory containing
+// .git, or the filesystem root when there is none, ordered from the farthest to rootPath
+func configSearchDirs(rootPath string) ([]string, error) {
+	absolutePath, err := filepath.Abs(rootPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var dirs []string
+	for dir := absolutePath; ; {
+		dirs = append(dirs, dir)
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			break
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+
+	for left, right := 0, len(dirs)-1; left < right; left, right = left+1, right-1 {
+		dirs[left], dirs[right] = dirs[right], dirs[left]
+	}
+	return dirs, nil
+}
+
 // loadYAML loads configuration from a YAML file
 func (config *Config) loadYAML(path string) error {
 	data, err := os.ReadFile(path)
@@ -213,6 +454,21 @@ func (tc *ThresholdConfig) Validate() error {
 	if err := validateSeverityOrder("churn", tc.Churn); err != nil {
 		return err
 	}
+	if err := validateSeverityOrder("churn_lines", tc.ChurnLines); err != nil {
+		return err
+	}
+	if err := validateSeverityOrder("weighted_methods", tc.WeightedMethods); err != nil {
+		return err
+	}
+	if err := validateSeverityOrder("fan_out", tc.FanOut); err != nil {
+		return err
+	}
+	if err := validateSeverityOrder("local_variables", tc.LocalVariables); err != nil {
+		return err
+	}
+	if err := validateSeverityOrder("method_chain", tc.MethodChain); err != nil {
+		return err
+	}
 	// Maintainability is inverted: critical <= warning <= info
 	mi := tc.MaintainabilityIndex
 	if mi.Critical > mi.Warning {
@@ -221,9 +477,39 @@ func (tc *ThresholdConfig) Validate() error {
 	if mi.Warning > mi.Info {
 		return fmt.Errorf("maintainability_index: warning (%d) must be <= info (%d)", mi.Warning, mi.Info)
 	}
+	if tc.FileMaintainability.Critical > tc.FileMaintainability.Warning {
+		return fmt.Errorf("file_maintainability: critical (%d) must be <= warning (%d)", tc.FileMaintainability.Critical, tc.FileMaintainability.Warning)
+	}
+	if tc.CommentDensity.Max > 0 && tc.CommentDensity.Min > tc.CommentDensity.Max {
+		return fmt.Errorf("comment_density: min (%d) must be <= max (%d)", tc.CommentDensity.Min, tc.CommentDensity.Max)
+	}
+	for _, rule := range tc.CustomRules {
+		if err := rule.validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// validate checks that a custom rule is named, compiles and has a known severity
+func (rule CustomRule) validate() error {
+	if rule.Name == "" {
+		return fmt.Errorf("custom_rules: rule with pattern %q must have a name", rule.Pattern)
+	}
+	if rule.Pattern == "" {
+		return fmt.Errorf("custom_rules: %s must have a pattern", rule.Name)
+	}
+	if _, err := regexp.Compile(rule.Pattern); err != nil {
+		return fmt.Errorf("custom_rules: %s has an invalid pattern: %w", rule.Name, err)
+	}
+	switch rule.Severity {
+	case "", "info", "warning", "critical":
+		return nil
+	default:
+		return fmt.Errorf("custom_rules: %s severity must be info, warning or critical, got %q", rule.Name, rule.Severity)
+	}
+}
+
 func validateSeverityOrder(name string, thresholds SeverityThresholds) error {
 	if thresholds.Info > thresholds.Warning {
 		return fmt.Errorf("%s: info (%d) must be <= warning (%d)", name, thresholds.Info, thresholds.Warning)
@@ -243,9 +529,21 @@ func (tc *ThresholdConfig) applyDefaultThresholds() {
 	applySeverityDefaults(&tc.NestingDepth, defaults.NestingDepth)
 	applySeverityDefaults(&tc.ParameterCount, defaults.ParameterCount)
 	applySeverityDefaults(&tc.Churn, defaults.Churn)
+	applySeverityDefaults(&tc.ChurnLines, defaults.ChurnLines)
+	applySeverityDefaults(&tc.WeightedMethods, defaults.WeightedMethods)
+	applySeverityDefaults(&tc.FanOut, defaults.FanOut)
+	applySeverityDefaults(&tc.LocalVariables, defaults.LocalVariables)
+	applySeverityDefaults(&tc.MethodChain, defaults.MethodChain)
 	applyMaintainabilityDefaults(&tc.MaintainabilityIndex, defaults.MaintainabilityIndex)
+	applyFileMaintainabilityDefaults(&tc.FileMaintainability, defaults.FileMaintainability)
 	applyGodFunctionDefaults(&tc.GodFunction, defaults.GodFunction)
 	applyHotspotDefaults(&tc.Hotspot, defaults.Hotspot)
+	applyTestRatioDefaults(&tc.TestRatio, defaults.TestRatio)
+	for index := range tc.CustomRules {
+		if tc.CustomRules[index].Severity == "" {
+			tc.CustomRules[index].Severity = DefaultCustomRuleSeverity
+		}
+	}
 }
 
 func applySeverityDefaults(target *SeverityThresholds, defaults SeverityThresholds) {
@@ -272,6 +570,18 @@ func applyMaintainabilityDefaults(target *MaintainabilityThresholds, defaults Ma
 	}
 }
 
+func applyFileMaintainabilityDefaults(target *FileMaintainabilityThresholds, defaults FileMaintainabilityThresholds) {
+	if target.Warning == 0 {
+		target.Warning = defaults.Warning
+	}
+	if target.Critical == 0 {
+		target.Critical = defaults.Critical
+	}
+	if target.MinFunctions == 0 {
+		target.MinFunctions = defaults.MinFunctions
+	}
+}
+
 func applyGodFunctionDefaults(target *GodFunctionThresholds, defaults GodFunctionThresholds) {
 	if target.MinParameters == 0 {
 		target.MinParameters = defaults.MinParameters
@@ -288,6 +598,24 @@ func applyHotspotDefaults(target *HotspotThresholds, defaults HotspotThresholds)
 	if target.MinChurn == 0 {
 		target.MinChurn = defaults.MinChurn
 	}
+	if target.MinChurnLines == 0 {
+		target.MinChurnLines = defaults.MinChurnLines
+	}
+	if target.Combine == "" {
+		target.Combine = defaults.Combine
+	}
+}
+
+func applyTestRatioDefaults(target *TestRatioThresholds, defaults TestRatioThresholds) {
+	if target.Info == 0 {
+		target.Info = defaults.Info
+	}
+	if target.Warning == 0 {
+		target.Warning = defaults.Warning
+	}
+	if target.MinLines == 0 {
+		target.MinLines = defaults.MinLines
+	}
 }
 
 // loadIgnoreFile loads ignore patterns from .kaizenignore file
@@ -332,25 +660,29 @@ func (config *Config) ShouldIgnore(path string) bool {
 	return false
 }
 
-// matchesPattern checks if a path matches a gitignore-style pattern
-func matchesPattern(path string, pattern string) bool {
+// matchesPattern checks if a path matches a gitignore-style pattern. Both are compared with
+// forward slashes, as .kaizenignore patterns are written, whatever the OS separator.
+func matchesPattern(filePath string, pattern string) bool {
+	filePath = filepath.ToSlash(filePath)
+	pattern = filepath.ToSlash(pattern)
+
 	// Handle negation patterns (starting with !)
 	if strings.HasPrefix(pattern, "!") {
 		pattern = pattern[1:]
-		return !matchesPattern(path, pattern)
+		return !matchesPattern(filePath, pattern)
 	}
 
 	// Handle directory-only patterns (ending with /)
 	if strings.HasSuffix(pattern, "/") {
 		pattern = pattern[:len(pattern)-1]
 		// Check if path starts with this directory
-		return strings.HasPrefix(path, pattern+"/") || path == pattern
+		return strings.HasPrefix(filePath, pattern+"/") || filePath == pattern
 	}
 
 	// Handle patterns starting with / (absolute from project root)
 	if strings.HasPrefix(pattern, "/") {
 		pattern = pattern[1:]
-		matched, _ := filepath.Match(pattern, path)
+		matched, _ := path.Match(pattern, filePath)
 		return matched
 	}
 
@@ -362,26 +694,26 @@ func matchesPattern(path string, pattern string) bool {
 			prefix := parts[0]
 			suffix := parts[1]
 
-			if strings.HasPrefix(path, prefix) && strings.HasSuffix(path, suffix) {
+			if strings.HasPrefix(filePath, prefix) && strings.HasSuffix(filePath, suffix) {
 				return true
 			}
 		}
 	}
 
 	// Check if pattern matches the basename
-	basename := filepath.Base(path)
-	matched, _ := filepath.Match(pattern, basename)
+	basename := path.Base(filePath)
+	matched, _ := path.Match(pattern, basename)
 	if matched {
 		return true
 	}
 
 	// Check if pattern matches any part of the path
-	if strings.Contains(path, pattern) {
+	if strings.Contains(filePath, pattern) {
 		return true
 	}
 
 	// Standard glob pattern matching
-	matched, _ = filepath.Match(pattern, path)
+	matched, _ = path.Match(pattern, filePath)
 	return matched
 }
 
@@ -393,9 +725,30 @@ func (config *Config) GetExcludePatterns() []string {
 	return patterns
 }
 
+// ValidationError is a single configuration problem, with the dotted YAML key path of the
+// offending setting when it can be determined
+type ValidationError struct {
+	Key     string `json:"key,omitempty"`
+	Message string `json:"message"`
+}
+
 // ValidateConfiguration validates the configuration values and returns errors if any are invalid
 func (config *Config) ValidateConfiguration() []string {
-	var errors []string
+	validationErrors := config.ValidationErrors()
+	if len(validationErrors) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(validationErrors))
+	for _, validationError := range validationErrors {
+		messages = append(messages, validationError.Message)
+	}
+	return messages
+}
+
+// ValidationErrors validates the configuration values and returns each problem with its key path
+func (config *Config) ValidationErrors() []ValidationError {
+	var errors []ValidationError
 
 	// Validate severity thresholds (info < warning < critical)
 	errors = append(errors, validateSeverityThresholds("complexity", config.Thresholds.Complexity, 1, 100)...)
@@ -404,99 +757,218 @@ func (config *Config) ValidateConfiguration() []string {
 	errors = append(errors, validateSeverityThresholds("nesting_depth", config.Thresholds.NestingDepth, 1, 20)...)
 	errors = append(errors, validateSeverityThresholds("parameter_count", config.Thresholds.ParameterCount, 1, 20)...)
 	errors = append(errors, validateSeverityThresholds("churn", config.Thresholds.Churn, 1, 1000)...)
+	errors = append(errors, validateSeverityThresholds("churn_lines", config.Thresholds.ChurnLines, 1, 100000)...)
+	errors = append(errors, validateSeverityThresholds("weighted_methods", config.Thresholds.WeightedMethods, 1, 1000)...)
+	errors = append(errors, validateSeverityThresholds("fan_out", config.Thresholds.FanOut, 1, 1000)...)
+	errors = append(errors, validateSeverityThresholds("local_variables", config.Thresholds.LocalVariables, 1, 100)...)
+	errors = append(errors, validateSeverityThresholds("method_chain", config.Thresholds.MethodChain, 1, 100)...)
 
 	// Validate maintainability thresholds (inverted: critical < warning < info)
 	errors = append(errors, validateMaintainabilityThresholds(config.Thresholds.MaintainabilityIndex)...)
 
+	// Validate file maintainability thresholds (inverted: critical < warning; zero values fall back to defaults)
+	fileMaintainability := config.Thresholds.FileMaintainability
+	if fileMaintainability.Warning < 0 || fileMaintainability.Warning > 100 || fileMaintainability.Critical < 0 || fileMaintainability.Critical > 100 {
+		errors = append(errors, ValidationError{Key: "thresholds.file_maintainability", Message: "file_maintainability warning and critical must be between 0 and 100"})
+	}
+	if fileMaintainability.Warning > 0 && fileMaintainability.Critical >= fileMaintainability.Warning {
+		errors = append(errors, ValidationError{Key: "thresholds.file_maintainability.critical", Message: "file_maintainability critical threshold must be less than warning threshold"})
+	}
+	if fileMaintainability.MinFunctions < 0 {
+		errors = append(errors, ValidationError{Key: "thresholds.file_maintainability.min_functions", Message: "file_maintainability min_functions must not be negative"})
+	}
+
 	// Validate god function thresholds
 	if config.Thresholds.GodFunction.MinParameters < 1 || config.Thresholds.GodFunction.MinParameters > 20 {
-		errors = append(errors, "god_function min_parameters must be between 1 and 20")
+		errors = append(errors, ValidationError{Key: "thresholds.god_function.min_parameters", Message: "god_function min_parameters must be between 1 and 20"})
 	}
 	if config.Thresholds.GodFunction.MinFanIn < 1 || config.Thresholds.GodFunction.MinFanIn > 100 {
-		errors = append(errors, "god_function min_fan_in must be between 1 and 100")
+		errors = append(errors, ValidationError{Key: "thresholds.god_function.min_fan_in", Message: "god_function min_fan_in must be between 1 and 100"})
 	}
 
 	// Validate hotspot thresholds
 	if config.Thresholds.Hotspot.MinComplexity < 1 || config.Thresholds.Hotspot.MinComplexity > 100 {
-		errors = append(errors, "hotspot min_complexity must be between 1 and 100")
+		errors = append(errors, ValidationError{Key: "thresholds.hotspot.min_complexity", Message: "hotspot min_complexity must be between 1 and 100"})
 	}
 	if config.Thresholds.Hotspot.MinChurn < 1 || config.Thresholds.Hotspot.MinChurn > 1000 {
-		errors = append(errors, "hotspot min_churn must be between 1 and 1000")
+		errors = append(errors, ValidationError{Key: "thresholds.hotspot.min_churn", Message: "hotspot min_churn must be between 1 and 1000"})
+	}
+	if config.Thresholds.Hotspot.MinChurnLines < 1 || config.Thresholds.Hotspot.MinChurnLines > 100000 {
+		errors = append(errors, ValidationError{Key: "thresholds.hotspot.min_churn_lines", Message: "hotspot min_churn_lines must be between 1 and 100000"})
+	}
+	switch config.Thresholds.Hotspot.Combine {
+	case "", HotspotCombineAnd, HotspotCombineOr:
+	default:
+		errors = append(errors, ValidationError{Key: "thresholds.hotspot.combine", Message: "unsupported hotspot combine: " + config.Thresholds.Hotspot.Combine + " (use \"and\" or \"or\")"})
+	}
+
+	// Validate comment density range; min 0 never flags sparse comments, max 0 never flags
+	// heavy comments, and min_lines 0 checks every file
+	commentDensity := config.Thresholds.CommentDensity
+	if commentDensity.Min < 0 || commentDensity.Max < 0 || commentDensity.Max > 100 {
+		errors = append(errors, ValidationError{Key: "thresholds.comment_density", Message: "comment_density min and max must be between 0 and 100"})
+	}
+	if commentDensity.MinLines < 0 {
+		errors = append(errors, ValidationError{Key: "thresholds.comment_density.min_lines", Message: "comment_density min_lines must not be negative"})
+	}
+	if commentDensity.Max > 0 && commentDensity.Min >= commentDensity.Max {
+		errors = append(errors, ValidationError{Key: "thresholds.comment_density.min", Message: "comment_density min must be less than max"})
+	}
+
+	// Validate test ratio thresholds (zero values fall back to defaults)
+	testRatio := config.Thresholds.TestRatio
+	if testRatio.Info < 0 || testRatio.Warning < 0 || testRatio.MinLines < 0 {
+		errors = append(errors, ValidationError{Key: "thresholds.test_ratio", Message: "test_ratio info, warning, and min_lines must not be negative"})
+	}
+	if testRatio.Info > 0 && testRatio.Warning > testRatio.Info {
+		errors = append(errors, ValidationError{Key: "thresholds.test_ratio.warning", Message: "test_ratio warning must be <= info"})
+	}
+
+	// Validate custom rules
+	for index, rule := range config.Thresholds.CustomRules {
+		if err := rule.validate(); err != nil {
+			errors = append(errors, ValidationError{Key: "thresholds.custom_rules[" + stringFromInt(index) + "]", Message: err.Error()})
+		}
 	}
 
 	// Validate analysis settings
 	if config.Analysis.MaxWorkers < 0 {
-		errors = append(errors, "max_workers must be non-negative")
+		errors = append(errors, ValidationError{Key: "analysis.max_workers", Message: "max_workers must be non-negative"})
+	}
+	if config.Analysis.TimeoutPerFile < 0 {
+		errors = append(errors, ValidationError{Key: "analysis.timeout_per_file", Message: "timeout_per_file must be non-negative"})
+	}
+	if config.Analysis.MinFunctionLines < 0 || config.Analysis.MinFunctionLines > 1000 {
+		errors = append(errors, ValidationError{Key: "analysis.min_function_lines", Message: "min_function_lines must be between 0 and 1000"})
+	}
+	if config.Reports.MaxItemsPerConcern < 0 {
+		errors = append(errors, ValidationError{Key: "reports.max_items_per_concern", Message: "max_items_per_concern must be non-negative (0 = unlimited)"})
+	}
+	if config.Reports.FolderRegressionPercent < 0 {
+		errors = append(errors, ValidationError{Key: "reports.folder_regression_percent", Message: "folder_regression_percent must be non-negative"})
+	}
+	if config.Reports.Precision < 0 || config.Reports.Precision > MaxReportPrecision {
+		errors = append(errors, ValidationError{Key: "reports.precision", Message: "precision must be between 0 and " + stringFromInt(MaxReportPrecision)})
+	}
+	for index, dirName := range config.Analysis.ExcludeDirs {
+		if dirName == "" || strings.ContainsAny(dirName, `/\`) {
+			errors = append(errors, ValidationError{Key: "analysis.exclude_dirs[" + stringFromInt(index) + "]", Message: "exclude_dirs entries must be directory names, not paths: " + dirName})
+		}
 	}
 
-	// Validate language settings
-	validLanguages := map[string]bool{
-		"go":     true,
-		"python": true,
-		"kotlin": true,
-		"swift":  true,
-		"java":   true,
+	switch config.Analysis.MIVariant {
+	case "", "classic", "microsoft", "sei":
+	default:
+		errors = append(errors, ValidationError{Key: "analysis.mi_variant", Message: "unsupported mi_variant: " + config.Analysis.MIVariant + " (use classic, microsoft, or sei)"})
 	}
 
-	for _, lang := range config.Analysis.Languages {
+	switch config.Analysis.ChurnMetric {
+	case "", ChurnMetricCommits, ChurnMetricLines, ChurnMetricBoth:
+	default:
+		errors = append(errors, ValidationError{Key: "analysis.churn_metric", Message: "unsupported churn_metric: " + config.Analysis.ChurnMetric + " (use commits, lines, or both)"})
+	}
+
+	switch config.Analysis.PathStyle {
+	case "", PathStyleRelative, PathStyleAbsolute:
+	default:
+		errors = append(errors, ValidationError{Key: "analysis.path_style", Message: "unsupported path_style: " + config.Analysis.PathStyle + " (use relative or absolute)"})
+	}
+
+	switch config.Analysis.AverageMethod {
+	case "", AverageMethodPerFunction, AverageMethodPerFile, AverageMethodWeightedByLOC:
+	default:
+		errors = append(errors, ValidationError{Key: "analysis.average_method", Message: "unsupported average_method: " + config.Analysis.AverageMethod + " (use per_function, per_file, or weighted_by_loc)"})
+	}
+
+	// Validate language settings
+	validLanguages := map[string]bool{
+		"go":          true,
+		"python":      true,
+		"kotlin":      true,
+		"swift":       true,
+		"objective-c": true,
+		"lua":         true,
+		"sql":         true,
+		"dart":        true,
+		"java":        true,
+	}
+
+	for index, lang := range config.Analysis.Languages {
 		normalizedLang := strings.ToLower(strings.TrimSpace(lang))
 		if !validLanguages[normalizedLang] {
-			errors = append(errors, "unsupported language: "+lang)
+			errors = append(errors, ValidationError{Key: "analysis.languages[" + stringFromInt(index) + "]", Message: "unsupported language: " + lang})
 		}
 	}
 
 	// Validate storage settings
 	if config.Storage.Type != "" && config.Storage.Type != "sqlite" {
-		errors = append(errors, "unsupported storage type: "+config.Storage.Type)
+		errors = append(errors, ValidationError{Key: "storage.type", Message: "unsupported storage type: " + config.Storage.Type})
+	}
+
+	// Validate risk weights
+	riskWeights := []struct {
+		key    string
+		weight float64
+	}{
+		{"scoring.risk.churn", config.Scoring.Risk.Churn},
+		{"scoring.risk.complexity", config.Scoring.Risk.Complexity},
+		{"scoring.risk.coverage", config.Scoring.Risk.Coverage},
+	}
+	for _, riskWeight := range riskWeights {
+		if riskWeight.weight < 0 {
+			errors = append(errors, ValidationError{Key: riskWeight.key, Message: "risk weights must be non-negative"})
+		}
 	}
 
 	return errors
 }
 
 // validateSeverityThresholds checks that info < warning < critical and all are in valid range
-func validateSeverityThresholds(name string, thresholds SeverityThresholds, min, max int) []string {
-	var errors []string
+func validateSeverityThresholds(name string, thresholds SeverityThresholds, min, max int) []ValidationError {
+	var errors []ValidationError
+	keyPath := "thresholds." + name
 
 	if thresholds.Info < min || thresholds.Info > max {
-		errors = append(errors, name+" info threshold must be between "+stringFromInt(min)+" and "+stringFromInt(max))
+		errors = append(errors, ValidationError{Key: keyPath + ".info", Message: name + " info threshold must be between " + stringFromInt(min) + " and " + stringFromInt(max)})
 	}
 	if thresholds.Warning < min || thresholds.Warning > max {
-		errors = append(errors, name+" warning threshold must be between "+stringFromInt(min)+" and "+stringFromInt(max))
+		errors = append(errors, ValidationError{Key: keyPath + ".warning", Message: name + " warning threshold must be between " + stringFromInt(min) + " and " + stringFromInt(max)})
 	}
 	if thresholds.Critical < min || thresholds.Critical > max {
-		errors = append(errors, name+" critical threshold must be between "+stringFromInt(min)+" and "+stringFromInt(max))
+		errors = append(errors, ValidationError{Key: keyPath + ".critical", Message: name + " critical threshold must be between " + stringFromInt(min) + " and " + stringFromInt(max)})
 	}
 
 	if thresholds.Info >= thresholds.Warning {
-		errors = append(errors, name+" info threshold must be less than warning threshold")
+		errors = append(errors, ValidationError{Key: keyPath + ".info", Message: name + " info threshold must be less than warning threshold"})
 	}
 	if thresholds.Warning >= thresholds.Critical {
-		errors = append(errors, name+" warning threshold must be less than critical threshold")
+		errors = append(errors, ValidationError{Key: keyPath + ".warning", Message: name + " warning threshold must be less than critical threshold"})
 	}
 
 	return errors
 }
 
 // validateMaintainabilityThresholds checks that critical < warning < info (inverted)
-func validateMaintainabilityThresholds(thresholds MaintainabilityThresholds) []string {
-	var errors []string
+func validateMaintainabilityThresholds(thresholds MaintainabilityThresholds) []ValidationError {
+	var errors []ValidationError
+	keyPath := "thresholds.maintainability_index"
 
 	if thresholds.Info < 0 || thresholds.Info > 100 {
-		errors = append(errors, "maintainability_index info threshold must be between 0 and 100")
+		errors = append(errors, ValidationError{Key: keyPath + ".info", Message: "maintainability_index info threshold must be between 0 and 100"})
 	}
 	if thresholds.Warning < 0 || thresholds.Warning > 100 {
-		errors = append(errors, "maintainability_index warning threshold must be between 0 and 100")
+		errors = append(errors, ValidationError{Key: keyPath + ".warning", Message: "maintainability_index warning threshold must be between 0 and 100"})
 	}
 	if thresholds.Critical < 0 || thresholds.Critical > 100 {
-		errors = append(errors, "maintainability_index critical threshold must be between 0 and 100")
+		errors = append(errors, ValidationError{Key: keyPath + ".critical", Message: "maintainability_index critical threshold must be between 0 and 100"})
 	}
 
 	// Inverted: lower is worse, so critical < warning < info
 	if thresholds.Critical >= thresholds.Warning {
-		errors = append(errors, "maintainability_index critical threshold must be less than warning threshold")
+		errors = append(errors, ValidationError{Key: keyPath + ".critical", Message: "maintainability_index critical threshold must be less than warning threshold"})
 	}
 	if thresholds.Warning >= thresholds.Info {
-		errors = append(errors, "maintainability_index warning threshold must be less than info threshold")
+		errors = append(errors, ValidationError{Key: keyPath + ".warning", Message: "maintainability_index warning threshold must be less than info threshold"})
 	}
 
 	return errors
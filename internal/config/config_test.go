@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestDefaultConfigValues(t *testing.T) {
@@ -173,6 +174,408 @@ func TestLoadConfigNoFile(t *testing.T) {
 	}
 }
 
+func TestLoadConfigFileSizeGuard(t *testing.T) {
+	tmpDir := t.TempDir()
+	configYAML := `
+analysis:
+  max_file_size: 2048
+  skip_generated: false
+`
+	configPath := filepath.Join(tmpDir, ".kaizen.yaml")
+	if err := os.WriteFile(configPath, []byte(configYAML), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := LoadConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if cfg.Analysis.MaxFileSize != 2048 {
+		t.Errorf("Expected max_file_size 2048, got %d", cfg.Analysis.MaxFileSize)
+	}
+	if cfg.Analysis.SkipGenerated {
+		t.Errorf("Expected skip_generated to be disabled")
+	}
+}
+
+func TestLoadConfigFileSizeGuardDefaults(t *testing.T) {
+	cfg, err := LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if cfg.Analysis.MaxFileSize != DefaultMaxFileSize {
+		t.Errorf("Expected default max_file_size %d, got %d", DefaultMaxFileSize, cfg.Analysis.MaxFileSize)
+	}
+	if !cfg.Analysis.SkipGenerated {
+		t.Errorf("Expected skip_generated to be enabled by default")
+	}
+}
+
+func TestLoadConfigMIVariant(t *testing.T) {
+	cfg, err := LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Analysis.MIVariant != DefaultMIVariant {
+		t.Errorf("Expected default mi_variant %q, got %q", DefaultMIVariant, cfg.Analysis.MIVariant)
+	}
+
+	tmpDir := t.TempDir()
+	configYAML := `analysis:
+  mi_variant: sei
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".kaizen.yaml"), []byte(configYAML), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err = LoadConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Analysis.MIVariant != "sei" {
+		t.Errorf("Expected mi_variant sei, got %q", cfg.Analysis.MIVariant)
+	}
+	if errors := cfg.ValidateConfiguration(); len(errors) != 0 {
+		t.Errorf("Expected sei to be valid, got %v", errors)
+	}
+
+	cfg.Analysis.MIVariant = "halstead"
+	if errors := cfg.ValidateConfiguration(); len(errors) != 1 {
+		t.Errorf("Expected one validation error for unknown mi_variant, got %v", errors)
+	}
+}
+
+func TestLoadConfigTimeoutPerFile(t *testing.T) {
+	cfg, err := LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Analysis.TimeoutPerFile != DefaultTimeoutPerFile {
+		t.Errorf("Expected default timeout_per_file %v, got %v", DefaultTimeoutPerFile, cfg.Analysis.TimeoutPerFile)
+	}
+
+	tmpDir := t.TempDir()
+	configYAML := `analysis:
+  timeout_per_file: 5s
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".kaizen.yaml"), []byte(configYAML), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err = LoadConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Analysis.TimeoutPerFile != 5*time.Second {
+		t.Errorf("Expected timeout_per_file 5s, got %v", cfg.Analysis.TimeoutPerFile)
+	}
+
+	cfg.Analysis.TimeoutPerFile = -time.Second
+	if cfg.IsValid() {
+		t.Errorf("Expected negative timeout_per_file to be invalid")
+	}
+}
+
+func TestLoadConfigMinFunctionLines(t *testing.T) {
+	tmpDir := t.TempDir()
+	configYAML := `analysis:
+  min_function_lines: 3
+  exclude_trivial_from_averages: true
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".kaizen.yaml"), []byte(configYAML), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := LoadConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Analysis.MinFunctionLines != 3 || !cfg.Analysis.ExcludeTrivialFromAverages {
+		t.Errorf("Expected min_function_lines 3 with trivial functions excluded from averages, got %d and %v",
+			cfg.Analysis.MinFunctionLines, cfg.Analysis.ExcludeTrivialFromAverages)
+	}
+
+	cfg.Analysis.MinFunctionLines = -1
+	if cfg.IsValid() {
+		t.Errorf("Expected negative min_function_lines to be invalid")
+	}
+}
+
+func TestLoadConfigPathStyle(t *testing.T) {
+	cfg, err := LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Analysis.PathStyle != DefaultPathStyle {
+		t.Errorf("Expected default path_style %q, got %q", DefaultPathStyle, cfg.Analysis.PathStyle)
+	}
+
+	tmpDir := t.TempDir()
+	configYAML := `analysis:
+  path_style: absolute
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".kaizen.yaml"), []byte(configYAML), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err = LoadConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Analysis.PathStyle != PathStyleAbsolute {
+		t.Errorf("Expected path_style absolute, got %q", cfg.Analysis.PathStyle)
+	}
+
+	cfg.Analysis.PathStyle = "windows"
+	if cfg.IsValid() {
+		t.Errorf("Expected unknown path_style to be invalid")
+	}
+}
+
+func TestLoadConfigHotspotCombine(t *testing.T) {
+	cfg, err := LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Thresholds.Hotspot.Combine != DefaultHotspotCombine {
+		t.Errorf("Expected default hotspot combine %q, got %q", DefaultHotspotCombine, cfg.Thresholds.Hotspot.Combine)
+	}
+
+	tmpDir := t.TempDir()
+	configYAML := `thresholds:
+  hotspot:
+    combine: or
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".kaizen.yaml"), []byte(configYAML), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err = LoadConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Thresholds.Hotspot.Combine != HotspotCombineOr {
+		t.Errorf("Expected hotspot combine or, got %q", cfg.Thresholds.Hotspot.Combine)
+	}
+	if cfg.Thresholds.Hotspot.MinComplexity != 10 {
+		t.Errorf("Expected other hotspot thresholds to keep their defaults, got min_complexity %d", cfg.Thresholds.Hotspot.MinComplexity)
+	}
+
+	cfg.Thresholds.Hotspot.Combine = "xor"
+	if cfg.IsValid() {
+		t.Errorf("Expected unknown hotspot combine to be invalid")
+	}
+}
+
+func TestLoadConfigAverageMethod(t *testing.T) {
+	cfg, err := LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Analysis.AverageMethod != AverageMethodPerFunction {
+		t.Errorf("Expected default average_method %q, got %q", AverageMethodPerFunction, cfg.Analysis.AverageMethod)
+	}
+
+	tmpDir := t.TempDir()
+	configYAML := `analysis:
+  average_method: weighted_by_loc
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".kaizen.yaml"), []byte(configYAML), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err = LoadConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Analysis.AverageMethod != AverageMethodWeightedByLOC {
+		t.Errorf("Expected average_method weighted_by_loc, got %q", cfg.Analysis.AverageMethod)
+	}
+
+	cfg.Analysis.AverageMethod = "median"
+	if cfg.IsValid() {
+		t.Errorf("Expected unknown average_method to be invalid")
+	}
+}
+
+func TestLoadConfigScoring(t *testing.T) {
+	cfg, err := LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Scoring.ChurnWeighted {
+		t.Errorf("Expected churn_weighted to be disabled by default")
+	}
+
+	tmpDir := t.TempDir()
+	configYAML := `scoring:
+  churn_weighted: true
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".kaizen.yaml"), []byte(configYAML), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err = LoadConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if !cfg.Scoring.ChurnWeighted {
+		t.Errorf("Expected churn_weighted to be enabled")
+	}
+}
+
+func TestLoadConfigRiskWeights(t *testing.T) {
+	tmpDir := t.TempDir()
+	configYAML := `scoring:
+  risk:
+    churn: 0.5
+    complexity: 0.5
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".kaizen.yaml"), []byte(configYAML), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := LoadConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Scoring.Risk.Churn != 0.5 || cfg.Scoring.Risk.Complexity != 0.5 {
+		t.Errorf("Expected churn and complexity weights of 0.5, got %+v", cfg.Scoring.Risk)
+	}
+	if cfg.Scoring.Risk.Coverage != 0.2 {
+		t.Errorf("Expected coverage weight to keep its default of 0.2, got %v", cfg.Scoring.Risk.Coverage)
+	}
+
+	cfg.Scoring.Risk.Coverage = -1
+	errors := cfg.ValidationErrors()
+	if len(errors) != 1 || errors[0].Key != "scoring.risk.coverage" {
+		t.Errorf("Expected a scoring.risk.coverage error, got %+v", errors)
+	}
+}
+
+func TestLoadConfigMaxItemsPerConcern(t *testing.T) {
+	cfg, err := LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Reports.MaxItemsPerConcern != DefaultMaxItemsPerConcern {
+		t.Errorf("Expected max_items_per_concern to default to %d, got %d", DefaultMaxItemsPerConcern, cfg.Reports.MaxItemsPerConcern)
+	}
+
+	tmpDir := t.TempDir()
+	configYAML := `reports:
+  max_items_per_concern: 0
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".kaizen.yaml"), []byte(configYAML), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err = LoadConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Reports.MaxItemsPerConcern != 0 {
+		t.Errorf("Expected an explicit 0 (unlimited) to be kept, got %d", cfg.Reports.MaxItemsPerConcern)
+	}
+
+	cfg.Reports.MaxItemsPerConcern = -1
+	errors := cfg.ValidationErrors()
+	if len(errors) != 1 || errors[0].Key != "reports.max_items_per_concern" {
+		t.Errorf("Expected a reports.max_items_per_concern error, got %+v", errors)
+	}
+}
+
+func TestLoadConfigFolderRegressionPercent(t *testing.T) {
+	tmpDir := t.TempDir()
+	configYAML := `reports:
+  max_items_per_concern: 3
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".kaizen.yaml"), []byte(configYAML), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := LoadConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Reports.FolderRegressionPercent != DefaultFolderRegressionPercent {
+		t.Errorf("Expected folder_regression_percent to default to %.0f, got %.1f", DefaultFolderRegressionPercent, cfg.Reports.FolderRegressionPercent)
+	}
+
+	cfg.Reports.FolderRegressionPercent = -5
+	errors := cfg.ValidationErrors()
+	if len(errors) != 1 || errors[0].Key != "reports.folder_regression_percent" {
+		t.Errorf("Expected a reports.folder_regression_percent error, got %+v", errors)
+	}
+}
+
+func TestLoadConfigReportPrecision(t *testing.T) {
+	cfg, err := LoadConfig(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Reports.Precision != DefaultReportPrecision {
+		t.Errorf("Expected precision to default to %d, got %d", DefaultReportPrecision, cfg.Reports.Precision)
+	}
+
+	tmpDir := t.TempDir()
+	configYAML := `reports:
+  precision: 0
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".kaizen.yaml"), []byte(configYAML), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err = LoadConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if cfg.Reports.Precision != 0 {
+		t.Errorf("Expected an explicit 0 (whole numbers) to be kept, got %d", cfg.Reports.Precision)
+	}
+
+	if cfg.Reports.ScorePrecision() != 0 {
+		t.Errorf("Expected ScorePrecision to be 0, got %d", cfg.Reports.ScorePrecision())
+	}
+
+	cfg.Reports.Precision = MaxReportPrecision + 1
+	errors := cfg.ValidationErrors()
+	if len(errors) != 1 || errors[0].Key != "reports.precision" {
+		t.Errorf("Expected a reports.precision error, got %+v", errors)
+	}
+	if cfg.Reports.ScorePrecision() != DefaultReportPrecision {
+		t.Errorf("Expected an out-of-range precision to fall back to %d, got %d", DefaultReportPrecision, cfg.Reports.ScorePrecision())
+	}
+}
+
+func TestLoadConfigTestRatio(t *testing.T) {
+	tmpDir := t.TempDir()
+	configYAML := `thresholds:
+  test_ratio:
+    info: 0.8
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".kaizen.yaml"), []byte(configYAML), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := LoadConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	testRatio := cfg.Thresholds.TestRatio
+	if testRatio.Info != 0.8 || testRatio.Warning != 0.2 || testRatio.MinLines != 50 {
+		t.Errorf("Expected info 0.8 with default warning and min_lines, got %+v", testRatio)
+	}
+
+	cfg.Thresholds.TestRatio.Warning = 1
+	errors := cfg.ValidationErrors()
+	if len(errors) != 1 || errors[0].Key != "thresholds.test_ratio.warning" {
+		t.Errorf("Expected a thresholds.test_ratio.warning error, got %+v", errors)
+	}
+}
+
 func TestThresholdValidationValid(t *testing.T) {
 	thresholds := DefaultConfig().Thresholds
 	if err := thresholds.Validate(); err != nil {
@@ -280,3 +683,267 @@ func TestApplyDefaultThresholdsZeroValues(t *testing.T) {
 			defaults.GodFunction.MinParameters, tc.GodFunction.MinParameters)
 	}
 }
+
+func TestLoadConfigCommentDensity(t *testing.T) {
+	tmpDir := t.TempDir()
+	configYAML := `
+thresholds:
+  comment_density:
+    min: 10
+`
+	configPath := filepath.Join(tmpDir, ".kaizen.yaml")
+	if err := os.WriteFile(configPath, []byte(configYAML), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := LoadConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if cfg.Thresholds.CommentDensity.Min != 10 {
+		t.Errorf("Expected comment_density min 10, got %d", cfg.Thresholds.CommentDensity.Min)
+	}
+	if cfg.Thresholds.CommentDensity.Max != 40 {
+		t.Errorf("Expected default comment_density max 40, got %d", cfg.Thresholds.CommentDensity.Max)
+	}
+	if cfg.Thresholds.CommentDensity.MinLines != 20 {
+		t.Errorf("Expected default comment_density min_lines 20, got %d", cfg.Thresholds.CommentDensity.MinLines)
+	}
+}
+
+func TestLoadConfigCommentDensityKeepsZero(t *testing.T) {
+	tmpDir := t.TempDir()
+	configYAML := `
+thresholds:
+  comment_density:
+    min: 0
+    min_lines: 0
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".kaizen.yaml"), []byte(configYAML), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := LoadConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if cfg.Thresholds.CommentDensity.Min != 0 {
+		t.Errorf("Expected an explicit comment_density min 0 to be kept, got %d", cfg.Thresholds.CommentDensity.Min)
+	}
+	if cfg.Thresholds.CommentDensity.MinLines != 0 {
+		t.Errorf("Expected an explicit comment_density min_lines 0 to be kept, got %d", cfg.Thresholds.CommentDensity.MinLines)
+	}
+	if cfg.Thresholds.CommentDensity.Max != 40 {
+		t.Errorf("Expected default comment_density max 40, got %d", cfg.Thresholds.CommentDensity.Max)
+	}
+	if errors := cfg.ValidationErrors(); len(errors) != 0 {
+		t.Errorf("Expected no validation errors, got %+v", errors)
+	}
+}
+
+func TestThresholdValidationCommentDensity(t *testing.T) {
+	thresholds := DefaultConfig().Thresholds
+	thresholds.CommentDensity.Min = 50
+
+	if err := thresholds.Validate(); err == nil {
+		t.Error("Expected error when comment_density min exceeds max")
+	}
+}
+
+func TestLoadConfigFileMaintainability(t *testing.T) {
+	tmpDir := t.TempDir()
+	configYAML := `
+thresholds:
+  file_maintainability:
+    warning: 55
+`
+	configPath := filepath.Join(tmpDir, ".kaizen.yaml")
+	if err := os.WriteFile(configPath, []byte(configYAML), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := LoadConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	fileMaintainability := cfg.Thresholds.FileMaintainability
+	if fileMaintainability.Warning != 55 {
+		t.Errorf("Expected file_maintainability warning 55, got %d", fileMaintainability.Warning)
+	}
+	if fileMaintainability.Critical != 40 || fileMaintainability.MinFunctions != 3 {
+		t.Errorf("Expected default critical 40 and min_functions 3, got %+v", fileMaintainability)
+	}
+
+	thresholds := DefaultConfig().Thresholds
+	thresholds.FileMaintainability.Critical = 60
+	if err := thresholds.Validate(); err == nil {
+		t.Error("Expected error when file_maintainability critical exceeds warning")
+	}
+}
+
+func TestLoadConfigCustomRules(t *testing.T) {
+	tmpDir := t.TempDir()
+	configYAML := `
+thresholds:
+  custom_rules:
+    - name: No panics
+      pattern: '\bpanic\('
+      severity: critical
+      message: Return errors instead.
+    - name: Open TODOs
+      pattern: TODO
+`
+	configPath := filepath.Join(tmpDir, ".kaizen.yaml")
+	if err := os.WriteFile(configPath, []byte(configYAML), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := LoadConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	rules := cfg.Thresholds.CustomRules
+	if len(rules) != 2 {
+		t.Fatalf("Expected 2 custom rules, got %d", len(rules))
+	}
+	if rules[0].Pattern != `\bpanic\(` || rules[0].Severity != "critical" {
+		t.Errorf("Unexpected first rule: %+v", rules[0])
+	}
+	if rules[1].Severity != DefaultCustomRuleSeverity {
+		t.Errorf("Expected default severity %q, got %q", DefaultCustomRuleSeverity, rules[1].Severity)
+	}
+	if err := cfg.Thresholds.Validate(); err != nil {
+		t.Errorf("Expected valid custom rules, got %v", err)
+	}
+}
+
+func TestThresholdValidationCustomRules(t *testing.T) {
+	invalidRules := []CustomRule{
+		{Pattern: "TODO"},
+		{Name: "Empty"},
+		{Name: "Broken", Pattern: "("},
+		{Name: "Severity", Pattern: "TODO", Severity: "fatal"},
+	}
+
+	for _, rule := range invalidRules {
+		thresholds := DefaultConfig().Thresholds
+		thresholds.CustomRules = []CustomRule{rule}
+
+		if err := thresholds.Validate(); err == nil {
+			t.Errorf("Expected validation error for rule %+v", rule)
+		}
+	}
+}
+
+func TestLoadConfigInheritsParentConfig(t *testing.T) {
+	repoRoot := t.TempDir()
+	serviceDir := filepath.Join(repoRoot, "services", "api")
+	if err := os.MkdirAll(filepath.Join(repoRoot, ".git"), 0755); err != nil {
+		t.Fatalf("Failed to create .git: %v", err)
+	}
+	if err := os.MkdirAll(serviceDir, 0755); err != nil {
+		t.Fatalf("Failed to create service dir: %v", err)
+	}
+
+	rootYAML := `analysis:
+  mi_variant: sei
+  exclude:
+    - "*_test.go"
+    - "fixtures"
+thresholds:
+  complexity:
+    warning: 12
+    critical: 30
+`
+	serviceYAML := `analysis:
+  exclude:
+    - "generated"
+thresholds:
+  complexity:
+    critical: 25
+`
+	writeTestFile(t, filepath.Join(repoRoot, ".kaizen.yaml"), rootYAML)
+	writeTestFile(t, filepath.Join(repoRoot, ".kaizenignore"), "docs/\n")
+	writeTestFile(t, filepath.Join(serviceDir, ".kaizen.yaml"), serviceYAML)
+	writeTestFile(t, filepath.Join(serviceDir, ".kaizenignore"), "*.pb.go\n")
+
+	cfg, err := LoadConfig(serviceDir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if cfg.Analysis.MIVariant != "sei" {
+		t.Errorf("Expected mi_variant inherited from the repo root, got %q", cfg.Analysis.MIVariant)
+	}
+	if cfg.Thresholds.Complexity.Warning != 12 {
+		t.Errorf("Expected complexity warning 12 inherited from the repo root, got %d", cfg.Thresholds.Complexity.Warning)
+	}
+	if cfg.Thresholds.Complexity.Critical != 25 {
+		t.Errorf("Expected the nearest config to win for complexity critical, got %d", cfg.Thresholds.Complexity.Critical)
+	}
+	if len(cfg.Analysis.ExcludePattern) != 1 || cfg.Analysis.ExcludePattern[0] != "generated" {
+		t.Errorf("Expected the nearest exclude list to replace the parent's, got %v", cfg.Analysis.ExcludePattern)
+	}
+	if len(cfg.IgnorePatterns) != 2 || cfg.IgnorePatterns[0] != "docs/" || cfg.IgnorePatterns[1] != "*.pb.go" {
+		t.Errorf("Expected ignore patterns from both levels, parent first, got %v", cfg.IgnorePatterns)
+	}
+	if len(cfg.SourceFiles) != 4 || cfg.SourceFiles[0] != filepath.Join(repoRoot, ".kaizen.yaml") {
+		t.Errorf("Expected four source files starting at the repo root, got %v", cfg.SourceFiles)
+	}
+}
+
+func TestLoadConfigStopsAtGitRoot(t *testing.T) {
+	outerDir := t.TempDir()
+	repoRoot := filepath.Join(outerDir, "repo")
+	if err := os.MkdirAll(filepath.Join(repoRoot, ".git"), 0755); err != nil {
+		t.Fatalf("Failed to create .git: %v", err)
+	}
+	writeTestFile(t, filepath.Join(outerDir, ".kaizen.yaml"), "analysis:\n  mi_variant: sei\n")
+
+	cfg, err := LoadConfig(repoRoot)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+
+	if cfg.Analysis.MIVariant != DefaultMIVariant {
+		t.Errorf("Config above the git root should be ignored, got mi_variant %q", cfg.Analysis.MIVariant)
+	}
+	if len(cfg.SourceFiles) != 0 {
+		t.Errorf("Expected no source files, got %v", cfg.SourceFiles)
+	}
+}
+
+func TestShouldIgnoreMatchesOSPaths(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.IgnorePatterns = []string{"docs/", "/scripts/*.go", "**/generated.go", "*.pb.go"}
+
+	cases := []struct {
+		path    string
+		ignored bool
+	}{
+		{"docs/guide.go", true},
+		{"scripts/build.go", true},
+		{"pkg/api/generated.go", true},
+		{"api/service.pb.go", true},
+		{"pkg/docs.go", false},
+		{"pkg/scripts/build.go", false},
+	}
+	for _, testCase := range cases {
+		// filepath.FromSlash gives the native form, backslashes on Windows
+		osPath := filepath.FromSlash(testCase.path)
+		if got := cfg.ShouldIgnore(osPath); got != testCase.ignored {
+			t.Errorf("ShouldIgnore(%q) = %v, want %v", osPath, got, testCase.ignored)
+		}
+	}
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
@@ -0,0 +1,219 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configDocs explains each .kaizen.yaml setting, keyed by its dotted YAML path. Keys of the
+// form "*.name" apply to any setting called name that has no entry of its own.
+var configDocs = map[string]string{
+	"analysis":                               "Analysis settings",
+	"analysis.since":                         "Default time range for churn (e.g. 30d, 2024-01-01)",
+	"analysis.languages":                     "Languages to analyze (empty = all supported languages)",
+	"analysis.exclude":                       "Additional exclude patterns",
+	"analysis.exclude_dirs":                  "Directory names to skip wherever they appear (e.g. testdata, generated)",
+	"analysis.skip_churn":                    "Skip git churn analysis",
+	"analysis.max_workers":                   "Number of parallel workers",
+	"analysis.max_file_size":                 "Skip files larger than this many bytes (0 = no limit)",
+	"analysis.skip_generated":                "Skip generated files: names such as *.pb.go and mock_*.go, or a \"Code generated\", \"DO NOT EDIT\", \"@generated\" or \"autogenerated\" marker in the first 5 lines",
+	"analysis.mi_variant":                    "Maintainability index formula: classic, microsoft, or sei",
+	"analysis.count_anonymous_functions":     "Report closures, lambdas, and blocks as functions of their own, named after the enclosing function (e.g. Serve.func1)",
+	"analysis.count_stdlib_calls":            "Count calls to built-ins and the standard library (e.g. len, print, strings.Split) in fan-out",
+	"analysis.timeout_per_file":              "Skip files whose analysis takes longer than this (0 = no limit)",
+	"analysis.churn_metric":                  "Churn count behind hotspots and churn concerns: commits, lines (added + deleted), or both (either crossing its threshold)",
+	"analysis.min_function_lines":            "Functions shorter than this many lines are trivial: counted separately and skipped by concern detection (0 = off)",
+	"analysis.exclude_trivial_from_averages": "Also leave trivial functions out of the summary averages (and so the complexity and maintainability scores)",
+	"analysis.path_style":                    "File paths in results: relative (to the repository root recorded once as repository) or absolute",
+	"analysis.average_method":                "How summary and folder averages combine functions: per_function (every function counts once), per_file (every file counts once), or weighted_by_loc (files weighted by code lines)",
+	"analysis.coverage_profile":              "Go cover profile (go test -coverprofile) or LCOV tracefile giving each file's test coverage, for the risk score (empty = no coverage)",
+
+	"thresholds":                       "Metric thresholds for concerns (info < warning < critical)",
+	"thresholds.complexity":            "Cyclomatic complexity per function",
+	"thresholds.cognitive_complexity":  "Cognitive complexity per function",
+	"thresholds.function_length":       "Function length in lines",
+	"thresholds.nesting_depth":         "Maximum nesting depth per function",
+	"thresholds.parameter_count":       "Parameters per function",
+	"thresholds.maintainability_index": "Maintainability index (0-100, lower is worse)",
+	"thresholds.file_maintainability":  "Maintainability index of a whole file, its functions' mean weighted by length (lower is worse)",
+	"thresholds.churn":                 "Commits touching a function within the churn time range",
+	"thresholds.churn_lines":           "Lines added plus deleted in a function within the churn time range (analysis.churn_metric lines or both)",
+	"thresholds.weighted_methods":      "Weighted methods per class: the summed cyclomatic complexity of a type's methods",
+	"thresholds.fan_out":               "Function calls made from within a function (fan-out)",
+	"thresholds.local_variables":       "Local variables declared within a function",
+	"thresholds.method_chain":          "Calls chained on a single expression, e.g. a.b().c().d()",
+	"thresholds.god_function":          "Functions with many parameters that many callers depend on (both conditions must hold)",
+	"thresholds.hotspot":               "Functions that are complex and frequently changed (combine decides whether both must hold)",
+	"thresholds.comment_density":       "Healthy comment density range, as a percentage of lines",
+	"thresholds.test_ratio":            "Test code lines per production code line in each folder, checked with --include-tests (lower is worse)",
+	"thresholds.custom_rules":          "Regular expressions reported as concerns; each rule has name, pattern, severity, and message",
+
+	"*.info":     "Above this = info concern",
+	"*.warning":  "Above this = warning concern",
+	"*.critical": "Above this = critical concern",
+
+	"thresholds.maintainability_index.info":     "Below this = info concern",
+	"thresholds.maintainability_index.warning":  "Below this = warning concern",
+	"thresholds.maintainability_index.critical": "Below this = critical concern",
+
+	"thresholds.file_maintainability.warning":       "Below this = warning concern",
+	"thresholds.file_maintainability.critical":      "Below this = critical concern",
+	"thresholds.file_maintainability.min_functions": "Files with fewer functions are not checked",
+
+	"thresholds.god_function.min_parameters": "Minimum parameter count",
+	"thresholds.god_function.min_fan_in":     "Minimum number of callers",
+	"thresholds.hotspot.min_complexity":      "Minimum cyclomatic complexity",
+	"thresholds.hotspot.min_churn":           "Minimum commits within the churn time range",
+	"thresholds.hotspot.min_churn_lines":     "Minimum lines changed within the churn time range (analysis.churn_metric lines or both)",
+	"thresholds.hotspot.combine":             "and: complex and churning (default); or: complex or churning, which flags more functions",
+	"thresholds.comment_density.min":         "Below this = possibly undocumented (0 = never)",
+	"thresholds.comment_density.max":         "Above this = possibly over-commented or commented-out code (0 = never)",
+	"thresholds.comment_density.min_lines":   "Files with fewer code lines are not checked",
+	"thresholds.test_ratio.info":             "Below this = info concern",
+	"thresholds.test_ratio.warning":          "Below this = warning concern",
+	"thresholds.test_ratio.min_lines":        "Folders with fewer production code lines are not checked",
+
+	"visualization":                   "Visualization settings",
+	"visualization.default_metric":    "Default metric to show",
+	"visualization.color_scheme":      "Color scheme name",
+	"visualization.show_percentages":  "Show percentages in output",
+	"visualization.auto_open_browser": "Auto-open HTML in browser",
+
+	"storage":                  "Storage settings",
+	"storage.type":             "Storage backend: sqlite",
+	"storage.path":             "Path to database file; relative paths resolve against the analyzed directory, ~ is expanded (empty = .kaizen/kaizen.db)",
+	"storage.keep_json_backup": "Also save JSON files",
+	"storage.retention_days":   "Auto-prune after N days (0 = disabled)",
+	"storage.auto_prune":       "Auto-prune on each analyze",
+
+	"scoring":                 "Overall score settings",
+	"scoring.churn_weighted":  "Weight each file's functions by its churn (needs churn data)",
+	"scoring.risk":            "Weights blended into each folder's 0-100 risk score; signals without data are left out",
+	"scoring.risk.churn":      "Weight of the folder's churn percentile",
+	"scoring.risk.complexity": "Weight of the folder's complexity percentile",
+	"scoring.risk.coverage":   "Weight of the share of lines not covered by tests",
+
+	"reports":                           "Score report settings",
+	"reports.max_items_per_concern":     "Affected items listed per concern; the rest are counted (0 = unlimited)",
+	"reports.folder_regression_percent": "Percent rise in a folder's complexity or hotspot score since the last snapshot that analyze --alert-regressions warns about",
+	"reports.precision":                 "Decimal places scores are shown with in ascii, markdown, and html reports: 0 (78), 1 (78.4), or 2 (78.42), up to 4",
+}
+
+// DefaultIgnoreFile is the starter .kaizenignore written by kaizen init
+const DefaultIgnoreFile = `# Paths Kaizen should not analyze, one pattern per line.
+# Patterns match file names, paths, or directories; "#" starts a comment.
+
+# Dependencies
+vendor/
+node_modules/
+
+# Build output
+dist/
+build/
+
+# Generated code
+*.pb.go
+*_generated.go
+`
+
+// GenerateDefaultYAML renders DefaultConfig as a .kaizen.yaml in which every setting carries a
+// comment explaining it and its default value. Settings and defaults are taken from the config
+// structs, so the output stays in sync as settings are added.
+func GenerateDefaultYAML() ([]byte, error) {
+	var document yaml.Node
+	if err := document.Encode(DefaultConfig()); err != nil {
+		return nil, fmt.Errorf("failed to encode default config: %w", err)
+	}
+
+	if err := annotateConfigNode(&document, ""); err != nil {
+		return nil, err
+	}
+	document.HeadComment = "Kaizen configuration. Generated by `kaizen init`; every value shown is the default."
+
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return nil, fmt.Errorf("failed to render default config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to render default config: %w", err)
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// annotateConfigNode attaches the documentation comment for each key of a mapping node,
+// recursing into nested sections. Sections and lists get a comment above them; scalar values
+// get an inline comment ending with their default.
+func annotateConfigNode(node *yaml.Node, path string) error {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for index := 0; index+1 < len(node.Content); index += 2 {
+		keyNode := node.Content[index]
+		valueNode := node.Content[index+1]
+
+		keyPath := keyNode.Value
+		if path != "" {
+			keyPath = path + "." + keyNode.Value
+		}
+
+		doc, exists := lookupConfigDoc(keyPath)
+		if !exists {
+			return fmt.Errorf("no documentation for config setting %s", keyPath)
+		}
+
+		if valueNode.Kind == yaml.MappingNode {
+			keyNode.HeadComment = doc
+			if err := annotateConfigNode(valueNode, keyPath); err != nil {
+				return err
+			}
+			continue
+		}
+
+		comment := fmt.Sprintf("%s. Default: %s", doc, formatDefaultValue(valueNode))
+		if valueNode.Kind == yaml.SequenceNode {
+			// Inline comments on flow-style empty lists end up on the wrong line
+			keyNode.HeadComment = comment
+		} else {
+			keyNode.LineComment = comment
+		}
+	}
+
+	return nil
+}
+
+// lookupConfigDoc returns the documentation for a setting, falling back to its "*.name" entry
+func lookupConfigDoc(keyPath string) (string, bool) {
+	if doc, exists := configDocs[keyPath]; exists {
+		return doc, true
+	}
+
+	name := keyPath[strings.LastIndex(keyPath, ".")+1:]
+	doc, exists := configDocs["*."+name]
+	return doc, exists
+}
+
+// formatDefaultValue renders a scalar or sequence node for use in a comment
+func formatDefaultValue(node *yaml.Node) string {
+	if node.Kind != yaml.SequenceNode {
+		if node.Value == "" {
+			return "empty"
+		}
+		return node.Value
+	}
+
+	if len(node.Content) == 0 {
+		return "none"
+	}
+	values := make([]string, 0, len(node.Content))
+	for _, item := range node.Content {
+		values = append(values, item.Value)
+	}
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGenerateDefaultYAMLRoundTrips(t *testing.T) {
+	data, err := GenerateDefaultYAML()
+	if err != nil {
+		t.Fatalf("GenerateDefaultYAML failed: %v", err)
+	}
+
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, ".kaizen.yaml"), data, 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	cfg, err := LoadConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed on generated config: %v", err)
+	}
+
+	defaults := DefaultConfig()
+	if !reflect.DeepEqual(cfg.Analysis, defaults.Analysis) {
+		t.Errorf("Generated analysis settings differ from defaults: %+v", cfg.Analysis)
+	}
+	if !reflect.DeepEqual(cfg.Thresholds.Complexity, defaults.Thresholds.Complexity) ||
+		!reflect.DeepEqual(cfg.Thresholds.MaintainabilityIndex, defaults.Thresholds.MaintainabilityIndex) ||
+		!reflect.DeepEqual(cfg.Thresholds.CommentDensity, defaults.Thresholds.CommentDensity) {
+		t.Errorf("Generated thresholds differ from defaults: %+v", cfg.Thresholds)
+	}
+	if !reflect.DeepEqual(cfg.Storage, defaults.Storage) || cfg.Scoring != defaults.Scoring {
+		t.Errorf("Generated storage or scoring settings differ from defaults")
+	}
+}
+
+func TestGenerateDefaultYAMLDocumentsDefaults(t *testing.T) {
+	data, err := GenerateDefaultYAML()
+	if err != nil {
+		t.Fatalf("GenerateDefaultYAML failed: %v", err)
+	}
+	output := string(data)
+
+	expectedLines := []string{
+		"# Cyclomatic complexity per function",
+		"warning: 10 # Above this = warning concern. Default: 10",
+		"critical: 20 # Below this = critical concern. Default: 20",
+		"timeout_per_file: 30s # Skip files whose analysis takes longer than this (0 = no limit). Default: 30s",
+	}
+	for _, expected := range expectedLines {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected generated config to contain %q", expected)
+		}
+	}
+}
+
+func TestConfigDocsCoverEverySetting(t *testing.T) {
+	// annotateConfigNode fails on any setting without documentation
+	if _, err := GenerateDefaultYAML(); err != nil {
+		t.Errorf("Expected every setting to be documented: %v", err)
+	}
+
+	if _, exists := lookupConfigDoc("thresholds.unknown_setting"); exists {
+		t.Errorf("Expected no documentation for an unknown setting")
+	}
+}
+
+func TestDefaultIgnoreFileLoads(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, ".kaizenignore"), []byte(DefaultIgnoreFile), 0644); err != nil {
+		t.Fatalf("Failed to write ignore file: %v", err)
+	}
+
+	cfg, err := LoadConfig(tmpDir)
+	if err != nil {
+		t.Fatalf("LoadConfig failed: %v", err)
+	}
+	if len(cfg.IgnorePatterns) == 0 {
+		t.Errorf("Expected patterns from the starter .kaizenignore")
+	}
+	for _, pattern := range cfg.IgnorePatterns {
+		if strings.HasPrefix(pattern, "#") {
+			t.Errorf("Comment loaded as pattern: %q", pattern)
+		}
+	}
+}
@@ -40,6 +40,11 @@ func TestValidateConfiguration(t *testing.T) {
 					ParameterCount:       DefaultConfig().Thresholds.ParameterCount,
 					MaintainabilityIndex: DefaultConfig().Thresholds.MaintainabilityIndex,
 					Churn:                DefaultConfig().Thresholds.Churn,
+					ChurnLines:           DefaultConfig().Thresholds.ChurnLines,
+					WeightedMethods:      DefaultConfig().Thresholds.WeightedMethods,
+					FanOut:               DefaultConfig().Thresholds.FanOut,
+					LocalVariables:       DefaultConfig().Thresholds.LocalVariables,
+					MethodChain:          DefaultConfig().Thresholds.MethodChain,
 					GodFunction:          DefaultConfig().Thresholds.GodFunction,
 					Hotspot:              DefaultConfig().Thresholds.Hotspot,
 				},
@@ -62,6 +67,11 @@ func TestValidateConfiguration(t *testing.T) {
 					ParameterCount:       DefaultConfig().Thresholds.ParameterCount,
 					MaintainabilityIndex: DefaultConfig().Thresholds.MaintainabilityIndex,
 					Churn:                DefaultConfig().Thresholds.Churn,
+					ChurnLines:           DefaultConfig().Thresholds.ChurnLines,
+					WeightedMethods:      DefaultConfig().Thresholds.WeightedMethods,
+					FanOut:               DefaultConfig().Thresholds.FanOut,
+					LocalVariables:       DefaultConfig().Thresholds.LocalVariables,
+					MethodChain:          DefaultConfig().Thresholds.MethodChain,
 					GodFunction:          DefaultConfig().Thresholds.GodFunction,
 					Hotspot:              DefaultConfig().Thresholds.Hotspot,
 				},
@@ -83,14 +93,29 @@ func TestValidateConfiguration(t *testing.T) {
 						Warning:  40,
 						Critical: 60, // Should be lowest
 					},
-					Churn:       DefaultConfig().Thresholds.Churn,
-					GodFunction: DefaultConfig().Thresholds.GodFunction,
-					Hotspot:     DefaultConfig().Thresholds.Hotspot,
+					Churn:           DefaultConfig().Thresholds.Churn,
+					ChurnLines:      DefaultConfig().Thresholds.ChurnLines,
+					WeightedMethods: DefaultConfig().Thresholds.WeightedMethods,
+					FanOut:          DefaultConfig().Thresholds.FanOut,
+					LocalVariables:  DefaultConfig().Thresholds.LocalVariables,
+					MethodChain:     DefaultConfig().Thresholds.MethodChain,
+					GodFunction:     DefaultConfig().Thresholds.GodFunction,
+					Hotspot:         DefaultConfig().Thresholds.Hotspot,
 				},
 			},
 			expectedCount: 2,
 			shouldContain: "maintainability_index",
 		},
+		{
+			name: "lexically analyzed languages",
+			config: &Config{
+				Thresholds: DefaultConfig().Thresholds,
+				Analysis: AnalysisConfig{
+					Languages: []string{"objective-c", "sql", "Dart"},
+				},
+			},
+			expectedCount: 0,
+		},
 		{
 			name: "invalid language",
 			config: &Config{
@@ -124,6 +149,11 @@ func TestValidateConfiguration(t *testing.T) {
 					ParameterCount:       DefaultConfig().Thresholds.ParameterCount,
 					MaintainabilityIndex: DefaultConfig().Thresholds.MaintainabilityIndex,
 					Churn:                DefaultConfig().Thresholds.Churn,
+					ChurnLines:           DefaultConfig().Thresholds.ChurnLines,
+					WeightedMethods:      DefaultConfig().Thresholds.WeightedMethods,
+					FanOut:               DefaultConfig().Thresholds.FanOut,
+					LocalVariables:       DefaultConfig().Thresholds.LocalVariables,
+					MethodChain:          DefaultConfig().Thresholds.MethodChain,
 					GodFunction: GodFunctionThresholds{
 						MinParameters: 0,   // Too low
 						MinFanIn:      200, // Too high
@@ -145,6 +175,17 @@ func TestValidateConfiguration(t *testing.T) {
 			expectedCount: 1,
 			shouldContain: "max_workers",
 		},
+		{
+			name: "exclude dir given as path",
+			config: &Config{
+				Thresholds: DefaultConfig().Thresholds,
+				Analysis: AnalysisConfig{
+					ExcludeDirs: []string{"testdata", "pkg/generated"},
+				},
+			},
+			expectedCount: 1,
+			shouldContain: "exclude_dirs",
+		},
 	}
 
 	for _, testCase := range tests {
@@ -224,3 +265,34 @@ func matchesAt(str, substr string, pos int) bool {
 	}
 	return true
 }
+
+func TestValidationErrorsKeyPaths(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Thresholds.NestingDepth.Critical = 3
+	cfg.Analysis.Languages = []string{"go", "cobol"}
+	cfg.Storage.Type = "postgres"
+
+	expectedKeys := map[string]bool{
+		"thresholds.nesting_depth.warning": false,
+		"analysis.languages[1]":            false,
+		"storage.type":                     false,
+	}
+
+	validationErrors := cfg.ValidationErrors()
+	for _, validationError := range validationErrors {
+		if _, expected := expectedKeys[validationError.Key]; !expected {
+			t.Errorf("unexpected validation error: %+v", validationError)
+			continue
+		}
+		expectedKeys[validationError.Key] = true
+	}
+	for key, found := range expectedKeys {
+		if !found {
+			t.Errorf("expected a validation error for %s, got %+v", key, validationErrors)
+		}
+	}
+
+	if len(cfg.ValidateConfiguration()) != len(validationErrors) {
+		t.Errorf("ValidateConfiguration and ValidationErrors should report the same problems")
+	}
+}
@@ -0,0 +1,6 @@
+// Package internal holds build information shared by the kaizen command and its packages.
+package internal
+
+// Version is the Kaizen release recorded in saved snapshots. Release builds set it with
+// -ldflags "-X github.com/alexcollie/kaizen/internal.Version=v1.2.3".
+var Version = "dev"
@@ -2,10 +2,13 @@ package analyzer
 
 import (
 	"math"
-	"path/filepath"
+	"path"
 	"sort"
+	"time"
 
+	"github.com/alexcollie/kaizen/internal/config"
 	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/alexcollie/kaizen/pkg/reports"
 )
 
 // DefaultAggregator implements the Aggregator interface
@@ -16,19 +19,40 @@ func NewAggregator() Aggregator {
 	return &DefaultAggregator{}
 }
 
-// AggregateByFolder groups file analyses by folder and calculates folder metrics
-func (aggregator *DefaultAggregator) AggregateByFolder(files []models.FileAnalysis) map[string]models.FolderMetrics {
+// AggregateByFolder groups file analyses by folder and calculates folder metrics, averaging
+// functions with averageMethod (an analysis.average_method; empty means per_function) and
+// counting concerns against thresholds
+func (aggregator *DefaultAggregator) AggregateByFolder(files []models.FileAnalysis, averageMethod string, thresholds config.ThresholdConfig) map[string]models.FolderMetrics {
+	return aggregateFiles(files, averageMethod, thresholds, func(filePath string) string {
+		return path.Dir(filePath)
+	})
+}
+
+// AggregateByModule groups file analyses by their nearest enclosing Go module, keyed by
+// module directory. Files outside every module are grouped under NoModuleGroup.
+func (aggregator *DefaultAggregator) AggregateByModule(files []models.FileAnalysis, moduleDirs []string, averageMethod string, thresholds config.ThresholdConfig) map[string]models.FolderMetrics {
+	return aggregateFiles(files, averageMethod, thresholds, func(filePath string) string {
+		return ModuleForFile(filePath, moduleDirs)
+	})
+}
+
+// aggregateFiles groups file analyses by the key returned for each file path and calculates group metrics
+func aggregateFiles(files []models.FileAnalysis, averageMethod string, thresholds config.ThresholdConfig, groupKey func(filePath string) string) map[string]models.FolderMetrics {
 	folderMap := make(map[string]*models.FolderMetrics)
+	folderAverages := make(map[string]*averageAccumulator)
+	coveredLines := make(map[string]float64)
+	coverageCodeLines := make(map[string]int)
 
-	// Group files by directory
+	// Group files by key
 	for _, file := range files {
-		dir := filepath.Dir(file.Path)
+		dir := groupKey(file.Path)
 
 		// Initialize folder if not exists
 		if _, exists := folderMap[dir]; !exists {
 			folderMap[dir] = &models.FolderMetrics{
 				Path: dir,
 			}
+			folderAverages[dir] = newAverageAccumulator(averageMethod, 6)
 		}
 
 		folder := folderMap[dir]
@@ -36,38 +60,58 @@ func (aggregator *DefaultAggregator) AggregateByFolder(files []models.FileAnalys
 		folder.TotalLines += file.TotalLines
 		folder.TotalCodeLines += file.CodeLines
 
+		if file.Coverage != nil {
+			coveredLines[dir] += *file.Coverage * float64(file.CodeLines)
+			coverageCodeLines[dir] += file.CodeLines
+		}
+
 		// Aggregate function metrics
 		for _, function := range file.Functions {
 			folder.TotalFunctions++
 
-			// Sum for averaging
-			folder.AverageComplexity += float64(function.CyclomaticComplexity)
-			folder.AverageCognitive += float64(function.CognitiveComplexity)
-			folder.AverageLength += float64(function.Length)
-			folder.AverageMaintainability += function.MaintainabilityIndex
+			functionChurn := 0
+			if function.Churn != nil {
+				functionChurn = function.Churn.TotalChanges
+			}
+			folderAverages[dir].addFunction(
+				float64(function.CyclomaticComplexity),
+				float64(function.CognitiveComplexity),
+				float64(function.Length),
+				function.MaintainabilityIndex,
+				function.HalsteadTime,
+				float64(functionChurn),
+			)
 
 			// Count hotspots
 			if function.IsHotspot {
 				folder.HotspotCount++
 			}
 
-			// Sum churn
-			if function.Churn != nil {
-				folder.TotalChurn += function.Churn.TotalChanges
-				folder.AverageChurn += float64(function.Churn.TotalChanges)
+			if isConcernFunction(function, thresholds) {
+				folder.ConcernCount++
 			}
+
+			// Sum churn
+			folder.TotalChurn += functionChurn
 		}
+		folderAverages[dir].endFile(file.CodeLines)
 	}
 
 	// Calculate averages
 	result := make(map[string]models.FolderMetrics)
 	for path, folder := range folderMap {
-		if folder.TotalFunctions > 0 {
-			folder.AverageComplexity /= float64(folder.TotalFunctions)
-			folder.AverageCognitive /= float64(folder.TotalFunctions)
-			folder.AverageLength /= float64(folder.TotalFunctions)
-			folder.AverageMaintainability /= float64(folder.TotalFunctions)
-			folder.AverageChurn /= float64(folder.TotalFunctions)
+		averages := folderAverages[path].averages()
+		folder.AverageComplexity = averages[0]
+		folder.AverageCognitive = averages[1]
+		folder.AverageLength = averages[2]
+		folder.AverageMaintainability = averages[3]
+		folder.AverageHalsteadTime = averages[4]
+		folder.AverageChurn = averages[5]
+		folder.HotspotDensity = perKLOC(folder.HotspotCount, folder.TotalCodeLines)
+		folder.ConcernDensity = perKLOC(folder.ConcernCount, folder.TotalCodeLines)
+		if coverageCodeLines[path] > 0 {
+			testCoverage := coveredLines[path] / float64(coverageCodeLines[path])
+			folder.TestCoverage = &testCoverage
 		}
 		result[path] = *folder
 	}
@@ -75,6 +119,78 @@ func (aggregator *DefaultAggregator) AggregateByFolder(files []models.FileAnalys
 	return result
 }
 
+// Recompute rebuilds FolderStats, ModuleStats, APISurface, Summary, TestStats, and ScoreReport from result.Files
+// without re-running language analysis, e.g. after merging incremental results. Module
+// grouping reuses the modules already recorded in ModuleStats, and churn weighting follows
+// the existing score report or the presence of churn data on the files.
+//
+// Only result is written, so concurrent calls on distinct results are safe.
+func (aggregator *DefaultAggregator) Recompute(result *models.AnalysisResult, thresholds config.ThresholdConfig, scoring config.ScoringConfig, reportsConfig config.ReportsConfig) {
+	var moduleDirs []string
+	for moduleDir := range result.ModuleStats {
+		if moduleDir != NoModuleGroup {
+			moduleDirs = append(moduleDirs, moduleDir)
+		}
+	}
+	sort.Strings(moduleDirs)
+
+	rebuildAggregates(aggregator, result, moduleDirs, resultHasChurnData(result), thresholds, scoring, reportsConfig, nil)
+}
+
+// rebuildAggregates derives every aggregate of an analysis result from its files. Both full
+// analysis and Recompute go through here so their output is identical for the same files.
+// Aggregation and scoring times are added to timings when it is not nil.
+func rebuildAggregates(
+	aggregator Aggregator,
+	result *models.AnalysisResult,
+	moduleDirs []string,
+	hasChurnData bool,
+	thresholds config.ThresholdConfig,
+	scoring config.ScoringConfig,
+	reportsConfig config.ReportsConfig,
+	timings *PhaseTimings,
+) {
+	aggregationStart := time.Now()
+	folderStats := aggregator.AggregateByFolder(result.Files, result.AverageMethod, thresholds)
+	if result.TestsIncluded {
+		applyTestRatios(folderStats, result.TestFiles)
+	}
+	var moduleStats map[string]models.FolderMetrics
+	if len(moduleDirs) > 0 {
+		moduleStats = aggregator.AggregateByModule(result.Files, moduleDirs, result.AverageMethod, thresholds)
+	}
+	result.APISurface = aggregator.AggregateAPISurface(result.Files)
+	result.Summary = generateSummary(result.Files, result.MinFunctionLines, result.TrivialExcludedFromAverages, result.AverageMethod, thresholds)
+	result.TestStats = generateTestStats(result.TestFiles)
+	timings.record(PhaseAggregation, time.Since(aggregationStart))
+
+	scoringStart := time.Now()
+	result.FolderStats = aggregator.CalculateScores(folderStats)
+	applyRiskScores(result.FolderStats, hasChurnData, scoring.Risk)
+
+	result.ModuleStats = nil
+	if moduleStats != nil {
+		result.ModuleStats = aggregator.CalculateScores(moduleStats)
+		applyRiskScores(result.ModuleStats, hasChurnData, scoring.Risk)
+	}
+
+	result.ScoreReport = reports.GenerateScoreReport(result, hasChurnData, thresholds, scoring, reportsConfig)
+	timings.record(PhaseScoring, time.Since(scoringStart))
+}
+
+// resultHasChurnData reports whether a result was analyzed with churn
+func resultHasChurnData(result *models.AnalysisResult) bool {
+	if result.ScoreReport != nil && result.ScoreReport.HasChurnData {
+		return true
+	}
+	for _, file := range result.Files {
+		if file.Churn != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // CalculateScores normalizes raw metrics to 0-100 scores for visualization
 func (aggregator *DefaultAggregator) CalculateScores(folders map[string]models.FolderMetrics) map[string]models.FolderMetrics {
 	if len(folders) == 0 {
@@ -86,12 +202,14 @@ func (aggregator *DefaultAggregator) CalculateScores(folders map[string]models.F
 	churns := make([]float64, 0, len(folders))
 	lengths := make([]float64, 0, len(folders))
 	maintainabilities := make([]float64, 0, len(folders))
+	hotspotDensities := make([]float64, 0, len(folders))
 
 	for _, folder := range folders {
 		complexities = append(complexities, folder.AverageComplexity)
 		churns = append(churns, folder.AverageChurn)
 		lengths = append(lengths, folder.AverageLength)
 		maintainabilities = append(maintainabilities, folder.AverageMaintainability)
+		hotspotDensities = append(hotspotDensities, folder.HotspotDensity)
 	}
 
 	// Sort for percentile calculation
@@ -99,6 +217,7 @@ func (aggregator *DefaultAggregator) CalculateScores(folders map[string]models.F
 	sort.Float64s(churns)
 	sort.Float64s(lengths)
 	sort.Float64s(maintainabilities)
+	sort.Float64s(hotspotDensities)
 
 	// Calculate scores for each folder
 	result := make(map[string]models.FolderMetrics)
@@ -113,6 +232,11 @@ func (aggregator *DefaultAggregator) CalculateScores(folders map[string]models.F
 		// Hotspot score combines complexity and churn
 		folder.HotspotScore = (folder.ComplexityScore + folder.ChurnScore) / 2
 
+		// Folders without hotspots stay at zero rather than ranking against each other
+		if folder.HotspotDensity > 0 {
+			folder.HotspotDensityScore = percentileRank(folder.HotspotDensity, hotspotDensities)
+		}
+
 		result[path] = folder
 	}
 
@@ -151,3 +275,87 @@ func percentileRank(value float64, sortedValues []float64) float64 {
 
 	return percentile
 }
+
+// isConcernFunction reports whether a function crosses the complexity or function length
+// warning threshold, the levels at which the report starts flagging it
+func isConcernFunction(function models.FunctionAnalysis, thresholds config.ThresholdConfig) bool {
+	return function.CyclomaticComplexity > thresholds.Complexity.Warning || function.Length > thresholds.FunctionLength.Warning
+}
+
+// perKLOC normalizes a count to occurrences per 1000 code lines, returning 0 for empty folders
+func perKLOC(count int, codeLines int) float64 {
+	if codeLines == 0 {
+		return 0
+	}
+	return float64(count) * 1000 / float64(codeLines)
+}
+
+// generateSummary creates summary metrics from all file analyses, counting concerns against
+// thresholds. Functions shorter than minFunctionLines are tallied as trivial and not counted as
+// concerns; with excludeTrivialFromAverages they are also left out of the averages, which
+// combine functions with averageMethod.
+func generateSummary(files []models.FileAnalysis, minFunctionLines int, excludeTrivialFromAverages bool, averageMethod string, thresholds config.ThresholdConfig) models.SummaryMetrics {
+	summary := models.SummaryMetrics{}
+	summaryAverages := newAverageAccumulator(averageMethod, 4)
+
+	for _, file := range files {
+		summary.TotalFiles++
+		summary.TotalLines += file.TotalLines
+		summary.TotalCodeLines += file.CodeLines
+		summary.TotalTypes += len(file.Types)
+
+		exportedFunctions, exportedTypes := countExported(file)
+		summary.ExportedFunctionCount += exportedFunctions
+		summary.ExportedTypeCount += exportedTypes
+
+		for _, function := range file.Functions {
+			summary.TotalFunctions++
+
+			isTrivial := reports.IsTrivialFunction(function, minFunctionLines)
+			if isTrivial {
+				summary.TrivialFunctionCount++
+			}
+
+			if !isTrivial || !excludeTrivialFromAverages {
+				summaryAverages.addFunction(
+					float64(function.CyclomaticComplexity),
+					float64(function.CognitiveComplexity),
+					float64(function.Length),
+					function.MaintainabilityIndex,
+				)
+			}
+
+			// Count categories
+			if function.CyclomaticComplexity > 10 {
+				summary.HighComplexityCount++
+			}
+			if function.CyclomaticComplexity > 20 {
+				summary.VeryHighComplexityCount++
+			}
+			if function.Length > 50 {
+				summary.LongFunctionCount++
+			}
+			if function.Length > 100 {
+				summary.VeryLongFunctionCount++
+			}
+			if function.IsHotspot {
+				summary.HotspotCount++
+			}
+			if !isTrivial && isConcernFunction(function, thresholds) {
+				summary.ConcernCount++
+			}
+		}
+		summaryAverages.endFile(file.CodeLines)
+	}
+
+	summary.HotspotDensity = perKLOC(summary.HotspotCount, summary.TotalCodeLines)
+	summary.ConcernDensity = perKLOC(summary.ConcernCount, summary.TotalCodeLines)
+
+	averages := summaryAverages.averages()
+	summary.AverageCyclomaticComplexity = averages[0]
+	summary.AverageCognitiveComplexity = averages[1]
+	summary.AverageFunctionLength = averages[2]
+	summary.AverageMaintainabilityIndex = averages[3]
+
+	return summary
+}
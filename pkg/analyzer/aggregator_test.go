@@ -4,6 +4,7 @@ import (
 	"math"
 	"testing"
 
+	"github.com/alexcollie/kaizen/internal/config"
 	"github.com/alexcollie/kaizen/pkg/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -11,7 +12,7 @@ import (
 
 func TestAggregateByFolderEmptyList(t *testing.T) {
 	aggregator := NewAggregator()
-	result := aggregator.AggregateByFolder([]models.FileAnalysis{})
+	result := aggregator.AggregateByFolder([]models.FileAnalysis{}, "", config.DefaultConfig().Thresholds)
 	assert.Empty(t, result)
 }
 
@@ -36,7 +37,7 @@ func TestAggregateByFolderSingleFile(t *testing.T) {
 		},
 	}
 
-	result := aggregator.AggregateByFolder(files)
+	result := aggregator.AggregateByFolder(files, "", config.DefaultConfig().Thresholds)
 	require.Len(t, result, 1)
 
 	folder, exists := result["pkg/analyzer"]
@@ -94,7 +95,7 @@ func TestAggregateByFolderMultipleFilesInFolder(t *testing.T) {
 		},
 	}
 
-	result := aggregator.AggregateByFolder(files)
+	result := aggregator.AggregateByFolder(files, "", config.DefaultConfig().Thresholds)
 	require.Len(t, result, 1)
 
 	folder := result["pkg/analyzer"]
@@ -148,7 +149,7 @@ func TestAggregateByFolderNestedFolders(t *testing.T) {
 		},
 	}
 
-	result := aggregator.AggregateByFolder(files)
+	result := aggregator.AggregateByFolder(files, "", config.DefaultConfig().Thresholds)
 	require.Len(t, result, 2)
 
 	_, hasAnalyzer := result["pkg/analyzer"]
@@ -185,7 +186,7 @@ func TestAggregateByFolderWithHotspots(t *testing.T) {
 		},
 	}
 
-	result := aggregator.AggregateByFolder(files)
+	result := aggregator.AggregateByFolder(files, "", config.DefaultConfig().Thresholds)
 	folder := result["pkg/analyzer"]
 	assert.Equal(t, 1, folder.HotspotCount)
 }
@@ -222,7 +223,7 @@ func TestAggregateByFolderWithChurn(t *testing.T) {
 		},
 	}
 
-	result := aggregator.AggregateByFolder(files)
+	result := aggregator.AggregateByFolder(files, "", config.DefaultConfig().Thresholds)
 	folder := result["pkg/analyzer"]
 	assert.Equal(t, 30, folder.TotalChurn)
 	assert.InDelta(t, 15.0, folder.AverageChurn, 0.01)
@@ -421,7 +422,7 @@ func TestAggregateByFolderRootFiles(t *testing.T) {
 		},
 	}
 
-	result := aggregator.AggregateByFolder(files)
+	result := aggregator.AggregateByFolder(files, "", config.DefaultConfig().Thresholds)
 	require.Len(t, result, 1)
 
 	folder := result["."]
@@ -465,3 +466,203 @@ func TestCalculateScoresRanking(t *testing.T) {
 	assert.Less(t, aComplexity, bComplexity)
 	assert.Less(t, bComplexity, cComplexity)
 }
+
+func TestAggregateByFolderDensity(t *testing.T) {
+	aggregator := NewAggregator()
+	files := []models.FileAnalysis{
+		{
+			Path:      "small/dense.go",
+			CodeLines: 200,
+			Functions: []models.FunctionAnalysis{
+				{Name: "Hot", CyclomaticComplexity: 15, Length: 30, IsHotspot: true, HalsteadTime: 120},
+				{Name: "Long", CyclomaticComplexity: 2, Length: 80, HalsteadTime: 60},
+			},
+		},
+		{
+			Path:      "large/clean.go",
+			CodeLines: 4000,
+			Functions: []models.FunctionAnalysis{
+				{Name: "HotA", CyclomaticComplexity: 12, Length: 20, IsHotspot: true},
+				{Name: "HotB", CyclomaticComplexity: 12, Length: 20, IsHotspot: true},
+			},
+		},
+		{
+			Path:      "empty/doc.go",
+			CodeLines: 0,
+		},
+	}
+
+	result := aggregator.CalculateScores(aggregator.AggregateByFolder(files, "", config.DefaultConfig().Thresholds))
+
+	small := result["small"]
+	assert.Equal(t, 2, small.ConcernCount)
+	assert.InDelta(t, 5.0, small.HotspotDensity, 0.001)
+	assert.InDelta(t, 10.0, small.ConcernDensity, 0.001)
+	assert.InDelta(t, 90.0, small.AverageHalsteadTime, 0.001)
+
+	large := result["large"]
+	assert.Equal(t, 2, large.HotspotCount)
+	assert.InDelta(t, 0.5, large.HotspotDensity, 0.001)
+	assert.Greater(t, small.HotspotDensityScore, large.HotspotDensityScore)
+
+	empty := result["empty"]
+	assert.Equal(t, 0.0, empty.HotspotDensity)
+	assert.Equal(t, 0.0, empty.ConcernDensity)
+	assert.Equal(t, 0.0, empty.HotspotDensityScore)
+}
+
+func TestPerKLOC(t *testing.T) {
+	assert.Equal(t, 0.0, perKLOC(5, 0))
+	assert.InDelta(t, 2.5, perKLOC(5, 2000), 0.001)
+}
+
+func TestAggregateByModule(t *testing.T) {
+	aggregator := NewAggregator()
+	files := []models.FileAnalysis{
+		{
+			Path:      "services/api/handler.go",
+			CodeLines: 100,
+			Functions: []models.FunctionAnalysis{{Name: "Handle", CyclomaticComplexity: 4, Length: 20}},
+		},
+		{
+			Path:      "services/api/internal/store/store.go",
+			CodeLines: 50,
+			Functions: []models.FunctionAnalysis{{Name: "Load", CyclomaticComplexity: 2, Length: 10}},
+		},
+		{
+			Path:      "services/worker/main.go",
+			CodeLines: 30,
+			Functions: []models.FunctionAnalysis{{Name: "main", CyclomaticComplexity: 1, Length: 5}},
+		},
+		{
+			Path:      "web/app.ts",
+			CodeLines: 10,
+		},
+	}
+
+	result := aggregator.AggregateByModule(files, []string{"services/api", "services/worker"}, "", config.DefaultConfig().Thresholds)
+	require.Len(t, result, 3)
+
+	api := result["services/api"]
+	assert.Equal(t, "services/api", api.Path)
+	assert.Equal(t, 2, api.TotalFiles)
+	assert.Equal(t, 2, api.TotalFunctions)
+	assert.Equal(t, 150, api.TotalCodeLines)
+	assert.Equal(t, 3.0, api.AverageComplexity)
+
+	assert.Equal(t, 1, result["services/worker"].TotalFiles)
+	assert.Equal(t, 1, result[NoModuleGroup].TotalFiles)
+}
+
+func TestGenerateSummaryTrivialFunctions(t *testing.T) {
+	files := []models.FileAnalysis{
+		{
+			Path: "user.go",
+			Functions: []models.FunctionAnalysis{
+				{Name: "Name", CyclomaticComplexity: 1, Length: 1, MaintainabilityIndex: 100},
+				{Name: "ID", CyclomaticComplexity: 1, Length: 1, MaintainabilityIndex: 100},
+				{Name: "Validate", CyclomaticComplexity: 7, Length: 30, MaintainabilityIndex: 60},
+			},
+		},
+	}
+
+	counted := generateSummary(files, 3, false, "", config.DefaultConfig().Thresholds)
+	assert.Equal(t, 3, counted.TotalFunctions)
+	assert.Equal(t, 2, counted.TrivialFunctionCount)
+	assert.InDelta(t, 3.0, counted.AverageCyclomaticComplexity, 0.001)
+
+	excluded := generateSummary(files, 3, true, "", config.DefaultConfig().Thresholds)
+	assert.Equal(t, 3, excluded.TotalFunctions)
+	assert.Equal(t, 2, excluded.TrivialFunctionCount)
+	assert.InDelta(t, 7.0, excluded.AverageCyclomaticComplexity, 0.001)
+	assert.InDelta(t, 30.0, excluded.AverageFunctionLength, 0.001)
+	assert.InDelta(t, 60.0, excluded.AverageMaintainabilityIndex, 0.001)
+
+	disabled := generateSummary(files, 0, true, "", config.DefaultConfig().Thresholds)
+	assert.Equal(t, 0, disabled.TrivialFunctionCount)
+	assert.InDelta(t, 3.0, disabled.AverageCyclomaticComplexity, 0.001)
+}
+
+func TestConcernCountFollowsThresholds(t *testing.T) {
+	files := []models.FileAnalysis{
+		{
+			Path:      "pkg/orders.go",
+			CodeLines: 500,
+			Functions: []models.FunctionAnalysis{
+				{Name: "Submit", CyclomaticComplexity: 12, Length: 40},
+				{Name: "Validate", CyclomaticComplexity: 7, Length: 30},
+				{Name: "Total", CyclomaticComplexity: 2, Length: 8},
+			},
+		},
+	}
+
+	defaults := config.DefaultConfig().Thresholds
+	summary := generateSummary(files, 0, false, "", defaults)
+	assert.Equal(t, 1, summary.ConcernCount)
+	assert.InDelta(t, 2.0, summary.ConcernDensity, 0.001)
+
+	strict := config.DefaultConfig().Thresholds
+	strict.Complexity.Warning = 5
+	strict.FunctionLength.Warning = 25
+
+	summary = generateSummary(files, 0, false, "", strict)
+	assert.Equal(t, 2, summary.ConcernCount, "Validate crosses the lower complexity and length thresholds")
+	assert.InDelta(t, 4.0, summary.ConcernDensity, 0.001)
+
+	folder := NewAggregator().AggregateByFolder(files, "", strict)["pkg"]
+	assert.Equal(t, 2, folder.ConcernCount)
+
+	relaxed := config.DefaultConfig().Thresholds
+	relaxed.Complexity.Warning = 15
+	relaxed.FunctionLength.Warning = 60
+
+	summary = generateSummary(files, 0, false, "", relaxed)
+	assert.Equal(t, 0, summary.ConcernCount)
+}
+
+func TestAverageMethods(t *testing.T) {
+	files := []models.FileAnalysis{
+		{
+			Path:      "pkg/config.go",
+			CodeLines: 10,
+			Functions: []models.FunctionAnalysis{
+				{Name: "Load", CyclomaticComplexity: 10, Length: 10},
+			},
+		},
+		{
+			Path:      "pkg/handlers.go",
+			CodeLines: 90,
+			Functions: []models.FunctionAnalysis{
+				{Name: "List", CyclomaticComplexity: 2, Length: 30},
+				{Name: "Get", CyclomaticComplexity: 2, Length: 30},
+				{Name: "Delete", CyclomaticComplexity: 2, Length: 30},
+			},
+		},
+		{Path: "pkg/doc.go", CodeLines: 1},
+	}
+
+	tests := []struct {
+		method             string
+		expectedComplexity float64
+		expectedLength     float64
+	}{
+		{method: "", expectedComplexity: 4.0, expectedLength: 25.0},
+		{method: "per_function", expectedComplexity: 4.0, expectedLength: 25.0},
+		{method: "per_file", expectedComplexity: 6.0, expectedLength: 20.0},
+		{method: "weighted_by_loc", expectedComplexity: 2.8, expectedLength: 28.0},
+	}
+
+	aggregator := NewAggregator()
+	for _, test := range tests {
+		t.Run(test.method, func(t *testing.T) {
+			summary := generateSummary(files, 0, false, test.method, config.DefaultConfig().Thresholds)
+			assert.InDelta(t, test.expectedComplexity, summary.AverageCyclomaticComplexity, 0.001)
+			assert.InDelta(t, test.expectedLength, summary.AverageFunctionLength, 0.001)
+
+			folder := aggregator.AggregateByFolder(files, test.method, config.DefaultConfig().Thresholds)["pkg"]
+			assert.InDelta(t, test.expectedComplexity, folder.AverageComplexity, 0.001, "folder stats average the same way as the summary")
+			assert.InDelta(t, test.expectedLength, folder.AverageLength, 0.001)
+			assert.Equal(t, 4, folder.TotalFunctions)
+		})
+	}
+}
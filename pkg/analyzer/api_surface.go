@@ -0,0 +1,45 @@
+package analyzer
+
+import (
+	"path"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+// AggregateAPISurface counts the exported functions and types of each package, keyed by
+// directory. Packages without any are left out, as are the languages that do not record
+// visibility.
+func (aggregator *DefaultAggregator) AggregateAPISurface(files []models.FileAnalysis) map[string]models.APISurface {
+	surfaces := make(map[string]models.APISurface)
+
+	for _, file := range files {
+		exportedFunctions, exportedTypes := countExported(file)
+		if exportedFunctions == 0 && exportedTypes == 0 {
+			continue
+		}
+
+		dir := path.Dir(file.Path)
+		surface := surfaces[dir]
+		surface.Package = dir
+		surface.ExportedFunctions += exportedFunctions
+		surface.ExportedTypes += exportedTypes
+		surfaces[dir] = surface
+	}
+
+	return surfaces
+}
+
+// countExported counts a file's exported functions, including methods, and exported types
+func countExported(file models.FileAnalysis) (functions, types int) {
+	for _, function := range file.Functions {
+		if function.IsExported {
+			functions++
+		}
+	}
+	for _, typeAnalysis := range file.Types {
+		if typeAnalysis.IsExported {
+			types++
+		}
+	}
+	return functions, types
+}
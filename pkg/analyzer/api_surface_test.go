@@ -0,0 +1,46 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregateAPISurface(t *testing.T) {
+	files := []models.FileAnalysis{
+		{
+			Path: "pkg/api/client.go",
+			Functions: []models.FunctionAnalysis{
+				{Name: "Fetch", IsExported: true},
+				{Name: "retry"},
+			},
+			Types: []models.TypeAnalysis{{Name: "Client", IsExported: true}},
+		},
+		{
+			Path:      "pkg/api/server.go",
+			Functions: []models.FunctionAnalysis{{Name: "Serve", IsExported: true}},
+			Types:     []models.TypeAnalysis{{Name: "handler"}},
+		},
+		{
+			Path:      "internal/cache/cache.go",
+			Functions: []models.FunctionAnalysis{{Name: "get"}},
+		},
+		{
+			Path:  "lib/models.py",
+			Types: []models.TypeAnalysis{{Name: "User", IsExported: true}},
+		},
+	}
+
+	surfaces := NewAggregator().AggregateAPISurface(files)
+
+	assert.Equal(t, map[string]models.APISurface{
+		"pkg/api": {Package: "pkg/api", ExportedFunctions: 2, ExportedTypes: 1},
+		"lib":     {Package: "lib", ExportedTypes: 1},
+	}, surfaces, "packages without exported symbols are left out")
+
+	summary := generateSummary(files, 0, false, "", config.DefaultConfig().Thresholds)
+	assert.Equal(t, 2, summary.ExportedFunctionCount)
+	assert.Equal(t, 2, summary.ExportedTypeCount)
+}
@@ -0,0 +1,74 @@
+package analyzer
+
+import "github.com/alexcollie/kaizen/internal/config"
+
+// averageAccumulator averages a fixed set of per-function metrics the way analysis.average_method
+// asks: over every function, over files with each file's own mean counting once, or over files
+// weighted by their code lines. Feed it each file's functions with addFunction, then endFile.
+type averageAccumulator struct {
+	method   string
+	sums     []float64 // Metric sums (per_function) or weighted sums of file means
+	weight   float64   // Functions, files, or code lines behind sums
+	fileSums []float64 // Metric sums of the file being accumulated
+	fileSize int       // Functions added for the file being accumulated
+}
+
+// newAverageAccumulator creates an accumulator for metricCount metrics; an empty method means
+// per_function
+func newAverageAccumulator(method string, metricCount int) *averageAccumulator {
+	if method == "" {
+		method = config.DefaultAverageMethod
+	}
+	return &averageAccumulator{
+		method:   method,
+		sums:     make([]float64, metricCount),
+		fileSums: make([]float64, metricCount),
+	}
+}
+
+// addFunction adds one function's metrics, in the order the accumulator averages them
+func (accumulator *averageAccumulator) addFunction(values ...float64) {
+	if accumulator.method == config.AverageMethodPerFunction {
+		for index, value := range values {
+			accumulator.sums[index] += value
+		}
+		accumulator.weight++
+		return
+	}
+
+	for index, value := range values {
+		accumulator.fileSums[index] += value
+	}
+	accumulator.fileSize++
+}
+
+// endFile folds the current file's mean into the averages; codeLines weighs it for
+// weighted_by_loc. Files without functions are ignored.
+func (accumulator *averageAccumulator) endFile(codeLines int) {
+	if accumulator.fileSize == 0 {
+		return
+	}
+
+	fileWeight := 1.0
+	if accumulator.method == config.AverageMethodWeightedByLOC && codeLines > 0 {
+		fileWeight = float64(codeLines)
+	}
+	for index, sum := range accumulator.fileSums {
+		accumulator.sums[index] += sum / float64(accumulator.fileSize) * fileWeight
+		accumulator.fileSums[index] = 0
+	}
+	accumulator.weight += fileWeight
+	accumulator.fileSize = 0
+}
+
+// averages returns the averaged metrics, all zero when nothing was added
+func (accumulator *averageAccumulator) averages() []float64 {
+	averages := make([]float64, len(accumulator.sums))
+	if accumulator.weight == 0 {
+		return averages
+	}
+	for index, sum := range accumulator.sums {
+		averages[index] = sum / accumulator.weight
+	}
+	return averages
+}
@@ -0,0 +1,94 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+// CommentSyntax describes a language's line comments for commented-out code detection
+type CommentSyntax struct {
+	LinePrefix string   // Line comment marker, e.g. "//" or "#"
+	Keywords   []string // Words that start a statement, e.g. "return" or "func"
+}
+
+// codeLineEndings are trailing characters that prose rarely ends with but code often does
+var codeLineEndings = []string{"{", "}", ";", ")", "(", "[", "]"}
+
+// codeOperators are assignment and comparison operators that mark a line as code
+var codeOperators = []string{" = ", ":=", "==", "!=", "+=", "-=", "->", "=>"}
+
+// FindCommentedCode returns runs of two or more consecutive line comments in which at least
+// half the lines look like code in the given syntax. The check is a heuristic: a line looks
+// like code when it ends with a bracket or semicolon, contains an assignment or comparison,
+// or starts with a keyword followed by a single operand or code punctuation. Lines ending in
+// a full stop are treated as prose.
+func FindCommentedCode(sourceCode string, syntax CommentSyntax) []models.CommentBlock {
+	keywords := make(map[string]bool, len(syntax.Keywords))
+	for _, keyword := range syntax.Keywords {
+		keywords[keyword] = true
+	}
+
+	var blocks []models.CommentBlock
+	var current models.CommentBlock
+
+	closeBlock := func() {
+		lineCount := current.EndLine - current.StartLine + 1
+		if current.StartLine > 0 && lineCount >= 2 && current.CodeLines*2 >= lineCount {
+			blocks = append(blocks, current)
+		}
+		current = models.CommentBlock{}
+	}
+
+	for index, line := range strings.Split(sourceCode, "\n") {
+		trimmedLine := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmedLine, syntax.LinePrefix) {
+			closeBlock()
+			continue
+		}
+
+		lineNumber := index + 1
+		if current.StartLine == 0 {
+			current.StartLine = lineNumber
+		}
+		current.EndLine = lineNumber
+
+		commentText := strings.TrimSpace(strings.TrimPrefix(trimmedLine, syntax.LinePrefix))
+		if looksLikeCode(commentText, keywords) {
+			current.CodeLines++
+		}
+	}
+	closeBlock()
+
+	return blocks
+}
+
+// looksLikeCode reports whether the text of a comment line reads as a statement rather than prose
+func looksLikeCode(commentText string, keywords map[string]bool) bool {
+	if commentText == "" || strings.HasSuffix(commentText, ".") {
+		return false
+	}
+
+	for _, ending := range codeLineEndings {
+		if strings.HasSuffix(commentText, ending) {
+			return true
+		}
+	}
+
+	for _, operator := range codeOperators {
+		if strings.Contains(commentText, operator) {
+			return true
+		}
+	}
+
+	words := strings.FieldsFunc(commentText, func(character rune) bool {
+		return character == ' ' || character == '\t' || character == '('
+	})
+	if len(words) == 0 || !keywords[words[0]] {
+		return false
+	}
+
+	// A keyword with at most one operand, such as "end" or "return total", or a keyword
+	// followed by code punctuation
+	return len(words) <= 2 || strings.ContainsAny(commentText, "(=:{")
+}
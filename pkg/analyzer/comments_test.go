@@ -0,0 +1,86 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var testGoSyntax = CommentSyntax{LinePrefix: "//", Keywords: []string{"func", "if", "return", "for"}}
+
+func TestFindCommentedCode(t *testing.T) {
+	source := `package main
+
+// Process handles the request and returns the
+// number of records written.
+func Process() int {
+	// total := 0
+	// for _, record := range records {
+	//     total += record.Size
+	// }
+	// return total
+	return 0
+}
+`
+
+	blocks := FindCommentedCode(source, testGoSyntax)
+
+	assert.Equal(t, []models.CommentBlock{{StartLine: 6, EndLine: 10, CodeLines: 5}}, blocks)
+}
+
+func TestFindCommentedCodeIgnoresProse(t *testing.T) {
+	source := `// Package main wires the service together.
+//
+// It reads configuration (from flags or the environment),
+// opens the database, and starts the HTTP server.
+// If startup fails the process exits with status 1.
+package main
+`
+
+	assert.Empty(t, FindCommentedCode(source, testGoSyntax))
+}
+
+func TestFindCommentedCodeRequiresMostLinesToLookLikeCode(t *testing.T) {
+	source := `// Example usage follows, taken from the handler tests
+// which exercise the retry path
+// in detail
+//	client.Retry(3)
+`
+
+	assert.Empty(t, FindCommentedCode(source, testGoSyntax))
+}
+
+func TestFindCommentedCodeSkipsSingleLines(t *testing.T) {
+	source := `x := 1
+// y := 2
+z := 3
+`
+
+	assert.Empty(t, FindCommentedCode(source, testGoSyntax))
+}
+
+func TestLooksLikeCode(t *testing.T) {
+	keywords := map[string]bool{"return": true, "end": true, "if": true}
+
+	testCases := []struct {
+		text     string
+		expected bool
+	}{
+		{"x := compute()", true},
+		{"if err != nil {", true},
+		{"}", true},
+		{"end", true},
+		{"return value", true},
+		{"return the cached value", false},
+		{"return(value)", true},
+		{"if the cache is cold we fall back to disk", false},
+		{"See handler.go (line 40) for details.", false},
+		{"counter = counter + 1", true},
+		{"", false},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, looksLikeCode(testCase.text, keywords), testCase.text)
+	}
+}
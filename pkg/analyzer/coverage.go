@@ -0,0 +1,205 @@
+package analyzer
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+// LoadCoverageProfile reads a Go cover profile (go test -coverprofile) or an LCOV tracefile and
+// returns each file's covered percentage (0-100), keyed by the path the profile records. Go
+// profiles count statements and LCOV files count lines; a block or line listed more than once,
+// as in merged profiles, is covered when any listing covers it.
+func LoadCoverageProfile(profilePath string) (map[string]float64, error) {
+	profileFile, err := os.Open(profilePath)
+	if err != nil {
+		return nil, err
+	}
+	defer profileFile.Close()
+
+	scanner := bufio.NewScanner(profileFile)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var coverage map[string]float64
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "mode:") {
+			coverage, err = parseGoCoverProfile(scanner)
+		} else {
+			coverage, err = parseLCOV(line, scanner)
+		}
+		break
+	}
+	if err != nil {
+		return nil, err
+	}
+	if scanErr := scanner.Err(); scanErr != nil {
+		return nil, scanErr
+	}
+	if coverage == nil {
+		return nil, fmt.Errorf("%s is not a Go cover profile or an LCOV tracefile", profilePath)
+	}
+	return coverage, nil
+}
+
+// parseGoCoverProfile reads the blocks following a Go cover profile's mode line, each
+// "file:startLine.startCol,endLine.endCol statements count"
+func parseGoCoverProfile(scanner *bufio.Scanner) (map[string]float64, error) {
+	type block struct {
+		statements int
+		covered    bool
+	}
+	blocks := make(map[string]map[string]block)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "mode:") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		colon := strings.LastIndex(fields[0], ":")
+		if len(fields) != 3 || colon < 0 {
+			return nil, fmt.Errorf("malformed cover profile line: %q", line)
+		}
+		statements, statementsErr := strconv.Atoi(fields[1])
+		count, countErr := strconv.Atoi(fields[2])
+		if statementsErr != nil || countErr != nil {
+			return nil, fmt.Errorf("malformed cover profile line: %q", line)
+		}
+
+		filePath, position := fields[0][:colon], fields[0][colon+1:]
+		if blocks[filePath] == nil {
+			blocks[filePath] = make(map[string]block)
+		}
+		existing := blocks[filePath][position]
+		blocks[filePath][position] = block{statements: statements, covered: existing.covered || count > 0}
+	}
+
+	coverage := make(map[string]float64)
+	for filePath, fileBlocks := range blocks {
+		total, covered := 0, 0
+		for _, fileBlock := range fileBlocks {
+			total += fileBlock.statements
+			if fileBlock.covered {
+				covered += fileBlock.statements
+			}
+		}
+		if total > 0 {
+			coverage[filePath] = float64(covered) * 100 / float64(total)
+		}
+	}
+	return coverage, nil
+}
+
+// parseLCOV reads the records of an LCOV tracefile whose first line is firstLine. Each record
+// names its file with SF: and lists executable lines as DA:line,hits.
+func parseLCOV(firstLine string, scanner *bufio.Scanner) (map[string]float64, error) {
+	lines := make(map[string]map[int]bool)
+	currentFile := ""
+	sawRecord := false
+
+	processLine := func(line string) error {
+		switch {
+		case strings.HasPrefix(line, "SF:"):
+			currentFile = strings.TrimPrefix(line, "SF:")
+			sawRecord = true
+			if lines[currentFile] == nil {
+				lines[currentFile] = make(map[int]bool)
+			}
+		case strings.HasPrefix(line, "DA:") && currentFile != "":
+			fields := strings.Split(strings.TrimPrefix(line, "DA:"), ",")
+			if len(fields) < 2 {
+				return fmt.Errorf("malformed LCOV line: %q", line)
+			}
+			lineNumber, lineErr := strconv.Atoi(fields[0])
+			hits, hitsErr := strconv.ParseFloat(fields[1], 64)
+			if lineErr != nil || hitsErr != nil {
+				return fmt.Errorf("malformed LCOV line: %q", line)
+			}
+			lines[currentFile][lineNumber] = lines[currentFile][lineNumber] || hits > 0
+		case line == "end_of_record":
+			currentFile = ""
+		}
+		return nil
+	}
+
+	if err := processLine(firstLine); err != nil {
+		return nil, err
+	}
+	for scanner.Scan() {
+		if err := processLine(strings.TrimSpace(scanner.Text())); err != nil {
+			return nil, err
+		}
+	}
+	if !sawRecord {
+		return nil, nil
+	}
+
+	coverage := make(map[string]float64)
+	for filePath, fileLines := range lines {
+		if len(fileLines) == 0 {
+			continue
+		}
+		covered := 0
+		for _, isCovered := range fileLines {
+			if isCovered {
+				covered++
+			}
+		}
+		coverage[filePath] = float64(covered) * 100 / float64(len(fileLines))
+	}
+	return coverage, nil
+}
+
+// applyCoverage sets Coverage on each file the profile has data for and returns how many
+// matched. Profiles record paths relative to wherever the tests ran, or as Go import paths, so
+// a profile path matches a file when either path ends with the other at a directory boundary;
+// the longest such match wins.
+func applyCoverage(files []models.FileAnalysis, coverage map[string]float64) int {
+	profilePathsByBase := make(map[string][]string)
+	for profilePath := range coverage {
+		base := path.Base(filepath.ToSlash(profilePath))
+		profilePathsByBase[base] = append(profilePathsByBase[base], profilePath)
+	}
+	for _, profilePaths := range profilePathsByBase {
+		sort.Strings(profilePaths)
+	}
+
+	matched := 0
+	for index := range files {
+		filePath := filepath.ToSlash(files[index].Path)
+
+		bestPath, bestLength := "", 0
+		for _, profilePath := range profilePathsByBase[path.Base(filePath)] {
+			slashPath := filepath.ToSlash(profilePath)
+			if length := min(len(slashPath), len(filePath)); length > bestLength && pathsOverlap(slashPath, filePath) {
+				bestPath, bestLength = profilePath, length
+			}
+		}
+
+		if bestPath != "" {
+			fileCoverage := coverage[bestPath]
+			files[index].Coverage = &fileCoverage
+			matched++
+		}
+	}
+	return matched
+}
+
+// pathsOverlap reports whether two slash-separated paths are equal or one ends with the other
+// at a directory boundary, e.g. github.com/org/repo/pkg/a.go and pkg/a.go
+func pathsOverlap(first, second string) bool {
+	first, second = strings.TrimPrefix(first, "./"), strings.TrimPrefix(second, "./")
+	return first == second || strings.HasSuffix(first, "/"+second) || strings.HasSuffix(second, "/"+first)
+}
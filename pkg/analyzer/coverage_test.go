@@ -0,0 +1,129 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadCoverageProfileGo(t *testing.T) {
+	profile := writeSourceFile(t, t.TempDir(), "cover.out", `mode: count
+example.com/shop/pkg/orders/orders.go:10.2,12.3 3 1
+example.com/shop/pkg/orders/orders.go:14.2,16.3 1 0
+example.com/shop/pkg/orders/orders.go:18.2,20.3 4 0
+example.com/shop/pkg/orders/orders.go:18.2,20.3 4 2
+example.com/shop/pkg/orders/tax.go:5.2,7.3 2 0
+`)
+
+	coverage, err := LoadCoverageProfile(profile)
+	require.NoError(t, err)
+
+	// 3 + 4 of 8 statements; the repeated block counts once and is covered by its second listing
+	assert.InDelta(t, 87.5, coverage["example.com/shop/pkg/orders/orders.go"], 0.001)
+	assert.InDelta(t, 0.0, coverage["example.com/shop/pkg/orders/tax.go"], 0.001)
+	assert.Len(t, coverage, 2)
+}
+
+func TestLoadCoverageProfileLCOV(t *testing.T) {
+	profile := writeSourceFile(t, t.TempDir(), "lcov.info", `TN:
+SF:lib/cart.dart
+DA:1,1
+DA:2,0
+DA:3,5
+DA:4,0
+LF:4
+LH:2
+end_of_record
+SF:/home/ci/app/src/util.py
+DA:10,0
+end_of_record
+SF:src/empty.py
+end_of_record
+`)
+
+	coverage, err := LoadCoverageProfile(profile)
+	require.NoError(t, err)
+
+	assert.InDelta(t, 50.0, coverage["lib/cart.dart"], 0.001)
+	assert.InDelta(t, 0.0, coverage["/home/ci/app/src/util.py"], 0.001)
+	assert.NotContains(t, coverage, "src/empty.py", "files without executable lines have no coverage")
+}
+
+func TestLoadCoverageProfileRejectsOtherFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := LoadCoverageProfile(writeSourceFile(t, dir, "notes.txt", "not a coverage profile\n"))
+	assert.Error(t, err)
+
+	_, err = LoadCoverageProfile(writeSourceFile(t, dir, "broken.out", "mode: set\norders.go:10.2,12.3 three 1\n"))
+	assert.Error(t, err)
+
+	_, err = LoadCoverageProfile(dir + "/missing.out")
+	assert.Error(t, err)
+}
+
+func TestApplyCoverageMatchesPathSuffixes(t *testing.T) {
+	files := []models.FileAnalysis{
+		{Path: "pkg/orders/orders.go"},
+		{Path: "src/util.py"},
+		{Path: "lib/cart.dart"},
+		{Path: "pkg/border/orders.go"},
+		{Path: "cmd/main.go"},
+	}
+	coverage := map[string]float64{
+		"example.com/shop/pkg/orders/orders.go": 80,
+		"/home/ci/app/src/util.py":              40,
+		"./lib/cart.dart":                       60,
+		"orders.go":                             10,
+	}
+
+	matched := applyCoverage(files, coverage)
+
+	assert.Equal(t, 4, matched)
+	require.NotNil(t, files[0].Coverage)
+	assert.Equal(t, 80.0, *files[0].Coverage, "the longest matching path wins")
+	assert.Equal(t, 40.0, *files[1].Coverage)
+	assert.Equal(t, 60.0, *files[2].Coverage)
+	assert.Equal(t, 10.0, *files[3].Coverage, "pkg/border/orders.go does not end with pkg/orders/orders.go")
+	assert.Nil(t, files[4].Coverage)
+}
+
+func TestAnalyzeRecordsCoverageProfile(t *testing.T) {
+	root := writeRecomputeFixture(t)
+	profile := writeSourceFile(t, t.TempDir(), "cover.out", `mode: set
+example.com/alpha/a.go:1.1,2.1 3 1
+example.com/alpha/a.go:3.1,4.1 1 0
+`)
+
+	pipeline := NewPipeline(fixtureRegistry{}, nil, NewAggregator())
+	result, err := pipeline.Analyze(AnalysisOptions{
+		RootPath:        root,
+		CoverageProfile: profile,
+		Thresholds:      config.DefaultConfig().Thresholds,
+	})
+	require.NoError(t, err)
+
+	for _, file := range result.Files {
+		if file.Path == "alpha/a.go" {
+			require.NotNil(t, file.Coverage)
+			assert.InDelta(t, 75.0, *file.Coverage, 0.001)
+		} else {
+			assert.Nil(t, file.Coverage, file.Path)
+		}
+	}
+
+	alpha := result.FolderStats["alpha"]
+	require.NotNil(t, alpha.TestCoverage, "folder coverage feeds the risk score")
+	assert.InDelta(t, 75.0, *alpha.TestCoverage, 0.001)
+	assert.Nil(t, result.FolderStats["beta"].TestCoverage)
+
+	_, err = pipeline.Analyze(AnalysisOptions{
+		RootPath:        root,
+		CoverageProfile: root + "/missing.out",
+		Thresholds:      config.DefaultConfig().Thresholds,
+	})
+	assert.ErrorContains(t, err, "coverage profile")
+}
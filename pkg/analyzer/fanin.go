@@ -0,0 +1,89 @@
+package analyzer
+
+import (
+	"bufio"
+	"os"
+	"regexp"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+// callSitePattern matches an identifier immediately followed by an opening parenthesis
+var callSitePattern = regexp.MustCompile(`([A-Za-z_][A-Za-z0-9_]*)\s*\(`)
+
+// functionNamePattern matches names that can appear at a parenthesized call site; selector-style
+// names such as Objective-C "setName:age:" never do and are left at zero fan-in
+var functionNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// populateFanIn sets FanIn on every function to the number of call sites referencing its name
+// across all analyzed files of the same language. Matching is by bare name, so calls to
+// same-named functions or methods all count; calls inside a function's own body (its
+// declaration and any recursion) are not counted toward it.
+func populateFanIn(files []models.FileAnalysis) {
+	definedNames := make(map[string]map[string]bool)
+	for _, file := range files {
+		for _, function := range file.Functions {
+			if !functionNamePattern.MatchString(function.Name) {
+				continue
+			}
+			if definedNames[file.Language] == nil {
+				definedNames[file.Language] = make(map[string]bool)
+			}
+			definedNames[file.Language][function.Name] = true
+		}
+	}
+
+	callCounts := make(map[string]map[string]int)
+	for _, file := range files {
+		names := definedNames[file.Language]
+		if len(names) == 0 {
+			continue
+		}
+		if callCounts[file.Language] == nil {
+			callCounts[file.Language] = make(map[string]int)
+		}
+		countCallSites(file, names, callCounts[file.Language])
+	}
+
+	for fileIndex := range files {
+		file := &files[fileIndex]
+		for functionIndex := range file.Functions {
+			function := &file.Functions[functionIndex]
+			function.FanIn = callCounts[file.Language][function.Name]
+		}
+	}
+}
+
+// countCallSites adds the call sites in a file that reference one of the given names to counts
+func countCallSites(file models.FileAnalysis, names map[string]bool, counts map[string]int) {
+	sourceFile, err := os.Open(file.Path)
+	if err != nil {
+		return
+	}
+	defer func() { _ = sourceFile.Close() }()
+
+	scanner := bufio.NewScanner(sourceFile)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		for _, match := range callSitePattern.FindAllStringSubmatch(scanner.Text(), -1) {
+			name := match[1]
+			if !names[name] || isWithinFunctionNamed(file.Functions, name, lineNumber) {
+				continue
+			}
+			counts[name]++
+		}
+	}
+}
+
+// isWithinFunctionNamed reports whether the line falls inside a function with the given name
+func isWithinFunctionNamed(functions []models.FunctionAnalysis, name string, line int) bool {
+	for _, function := range functions {
+		if function.Name == name && line >= function.StartLine && line <= function.EndLine {
+			return true
+		}
+	}
+	return false
+}
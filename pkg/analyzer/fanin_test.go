@@ -0,0 +1,91 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPopulateFanInCountsPythonCallSites(t *testing.T) {
+	root := t.TempDir()
+	helpersPath := writeSourceFile(t, root, "helpers.py", `def normalize(value):
+    if value:
+        return normalize(value[1:])
+    return value
+
+
+def unused():
+    return 1
+`)
+	ordersPath := writeSourceFile(t, root, "orders.py", `from helpers import normalize
+
+
+def load_order(raw):
+    return normalize(raw)
+
+
+def save_order(order):
+    key = normalize(order.key)
+    return helpers.normalize (order.value)
+`)
+	reportsPath := writeSourceFile(t, root, "reports.py", `def render(rows):
+    return [normalize(row) for row in rows]
+`)
+
+	files := []models.FileAnalysis{
+		{Path: helpersPath, Language: "Python", Functions: []models.FunctionAnalysis{
+			{Name: "normalize", StartLine: 1, EndLine: 4},
+			{Name: "unused", StartLine: 7, EndLine: 8},
+		}},
+		{Path: ordersPath, Language: "Python", Functions: []models.FunctionAnalysis{
+			{Name: "load_order", StartLine: 4, EndLine: 5},
+			{Name: "save_order", StartLine: 8, EndLine: 10},
+		}},
+		{Path: reportsPath, Language: "Python", Functions: []models.FunctionAnalysis{
+			{Name: "render", StartLine: 1, EndLine: 2},
+		}},
+	}
+
+	populateFanIn(files)
+
+	// Four call sites outside normalize itself; the recursive call is not counted
+	assert.Equal(t, 4, files[0].Functions[0].FanIn)
+	assert.Equal(t, 0, files[0].Functions[1].FanIn)
+	assert.Equal(t, 0, files[1].Functions[0].FanIn)
+	assert.Equal(t, 0, files[2].Functions[0].FanIn)
+}
+
+func TestPopulateFanInKeepsLanguagesSeparate(t *testing.T) {
+	root := t.TempDir()
+	pythonPath := writeSourceFile(t, root, "a.py", "def process(x):\n    return x\n")
+	kotlinPath := writeSourceFile(t, root, "B.kt", "fun main() {\n    process(1)\n    process(2)\n}\n")
+
+	files := []models.FileAnalysis{
+		{Path: pythonPath, Language: "Python", Functions: []models.FunctionAnalysis{
+			{Name: "process", StartLine: 1, EndLine: 2},
+		}},
+		{Path: kotlinPath, Language: "Kotlin", Functions: []models.FunctionAnalysis{
+			{Name: "main", StartLine: 1, EndLine: 4},
+		}},
+	}
+
+	populateFanIn(files)
+
+	assert.Equal(t, 0, files[0].Functions[0].FanIn)
+}
+
+func TestPopulateFanInSkipsSelectorNames(t *testing.T) {
+	root := t.TempDir()
+	objcPath := writeSourceFile(t, root, "a.m", "- (void)setName:(NSString *)name {\n}\n")
+
+	files := []models.FileAnalysis{
+		{Path: objcPath, Language: "Objective-C", Functions: []models.FunctionAnalysis{
+			{Name: "setName:", StartLine: 1, EndLine: 2, FanIn: 3},
+		}},
+	}
+
+	populateFanIn(files)
+
+	assert.Equal(t, 0, files[0].Functions[0].FanIn)
+}
@@ -0,0 +1,190 @@
+package analyzer
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Linguist attributes read from .gitattributes, as GitHub uses them for language statistics
+const (
+	attributeVendored  = "linguist-vendored"
+	attributeGenerated = "linguist-generated"
+	attributeLanguage  = "linguist-language"
+)
+
+// gitAttributeRule is one pattern line of a .gitattributes file with the attributes it sets.
+// An attribute mapped to "" was unset with "!attr", which cancels earlier rules.
+type gitAttributeRule struct {
+	baseDir    string // Absolute directory holding the .gitattributes file
+	pattern    string
+	attributes map[string]string
+}
+
+// gitAttributes holds the rules of every .gitattributes file loaded so far, shallowest first,
+// so that later rules override earlier ones as they do in git
+type gitAttributes struct {
+	rules []gitAttributeRule
+}
+
+// newGitAttributes loads the .gitattributes files from the repository root down to, but not
+// including, rootPath. The walk loads rootPath's own file and those below it as it goes.
+func newGitAttributes(rootPath string) *gitAttributes {
+	attributes := &gitAttributes{}
+
+	absoluteRoot, err := filepath.Abs(rootPath)
+	if err != nil {
+		return attributes
+	}
+	repositoryRoot := FindRepositoryRoot(absoluteRoot)
+
+	var ancestors []string
+	for dir := filepath.Dir(absoluteRoot); ; dir = filepath.Dir(dir) {
+		relativePath, err := filepath.Rel(repositoryRoot, dir)
+		if err != nil || isOutside(relativePath) {
+			break
+		}
+		ancestors = append(ancestors, dir)
+		if relativePath == "." {
+			break
+		}
+	}
+	for index := len(ancestors) - 1; index >= 0; index-- {
+		attributes.load(ancestors[index])
+	}
+	return attributes
+}
+
+// load adds the rules of dir's .gitattributes file, if it has one
+func (attributes *gitAttributes) load(dir string) {
+	absoluteDir, err := filepath.Abs(dir)
+	if err != nil {
+		return
+	}
+	file, err := os.Open(filepath.Join(absoluteDir, ".gitattributes"))
+	if err != nil {
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if rule, ok := parseGitAttributeLine(absoluteDir, scanner.Text()); ok {
+			attributes.rules = append(attributes.rules, rule)
+		}
+	}
+}
+
+// parseGitAttributeLine parses a "pattern attr -attr !attr attr=value" line. Comments, macro
+// definitions, and directory-only patterns (which never apply to files) are skipped.
+func parseGitAttributeLine(baseDir string, line string) (gitAttributeRule, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 || strings.HasPrefix(fields[0], "#") || strings.HasPrefix(fields[0], "[attr]") {
+		return gitAttributeRule{}, false
+	}
+	if strings.HasSuffix(fields[0], "/") {
+		return gitAttributeRule{}, false
+	}
+
+	rule := gitAttributeRule{baseDir: baseDir, pattern: fields[0], attributes: map[string]string{}}
+	for _, field := range fields[1:] {
+		switch {
+		case strings.HasPrefix(field, "-"):
+			rule.attributes[field[1:]] = "false"
+		case strings.HasPrefix(field, "!"):
+			rule.attributes[field[1:]] = ""
+		case strings.Contains(field, "="):
+			name, value, _ := strings.Cut(field, "=")
+			rule.attributes[name] = value
+		default:
+			rule.attributes[field] = "true"
+		}
+	}
+	return rule, true
+}
+
+// value returns the attribute's value for a file from the last matching rule that mentions it,
+// or "" when no rule sets it
+func (attributes *gitAttributes) value(filePath string, name string) string {
+	absolutePath, err := filepath.Abs(filePath)
+	if err != nil {
+		return ""
+	}
+
+	for index := len(attributes.rules) - 1; index >= 0; index-- {
+		rule := attributes.rules[index]
+		value, mentioned := rule.attributes[name]
+		if mentioned && matchesAttributePattern(rule.baseDir, rule.pattern, absolutePath) {
+			return value
+		}
+	}
+	return ""
+}
+
+// isVendoredOrGenerated reports whether .gitattributes marks a file linguist-vendored or
+// linguist-generated, which GitHub leaves out of a repository's language statistics
+func (attributes *gitAttributes) isVendoredOrGenerated(filePath string) bool {
+	for _, name := range []string{attributeVendored, attributeGenerated} {
+		if value := attributes.value(filePath, name); value != "" && value != "false" {
+			return true
+		}
+	}
+	return false
+}
+
+// languageOverride returns the language .gitattributes assigns a file with linguist-language,
+// or "" to detect it from the file extension
+func (attributes *gitAttributes) languageOverride(filePath string) string {
+	return attributes.value(filePath, attributeLanguage)
+}
+
+// isOutside reports whether a relative path climbs out of the directory it is relative to
+func isOutside(relativePath string) bool {
+	return relativePath == ".." || strings.HasPrefix(relativePath, ".."+string(filepath.Separator))
+}
+
+// matchesAttributePattern checks a file against a .gitattributes pattern. As in git, a pattern
+// without a slash matches the file name at any depth, and one with a slash is matched against
+// the path relative to the .gitattributes directory, with "**" spanning any number of
+// directories. Unlike .gitignore, a pattern naming a directory does not cover the files in it.
+func matchesAttributePattern(baseDir string, pattern string, absolutePath string) bool {
+	relativePath, err := filepath.Rel(baseDir, absolutePath)
+	if err != nil || isOutside(relativePath) {
+		return false
+	}
+	relativePath = filepath.ToSlash(relativePath)
+
+	if !strings.Contains(pattern, "/") {
+		matched, err := path.Match(pattern, path.Base(relativePath))
+		return err == nil && matched
+	}
+
+	patternSegments := strings.Split(strings.TrimPrefix(pattern, "/"), "/")
+	return matchAttributeSegments(patternSegments, strings.Split(relativePath, "/"))
+}
+
+// matchAttributeSegments reports whether the pattern segments match every path segment
+func matchAttributeSegments(patternSegments, pathSegments []string) bool {
+	if len(patternSegments) == 0 {
+		return len(pathSegments) == 0
+	}
+
+	if patternSegments[0] == "**" {
+		for consumed := 0; consumed <= len(pathSegments); consumed++ {
+			if matchAttributeSegments(patternSegments[1:], pathSegments[consumed:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(pathSegments) == 0 {
+		return false
+	}
+	if matched, err := path.Match(patternSegments[0], pathSegments[0]); err != nil || !matched {
+		return false
+	}
+	return matchAttributeSegments(patternSegments[1:], pathSegments[1:])
+}
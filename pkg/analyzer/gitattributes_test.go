@@ -0,0 +1,109 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchesAttributePattern(t *testing.T) {
+	base := filepath.FromSlash("/repo")
+	tests := []struct {
+		pattern  string
+		path     string
+		expected bool
+	}{
+		{"*.pb.go", "/repo/api/v1/service.pb.go", true},
+		{"*.pb.go", "/repo/api/v1/service.go", false},
+		{"third_party/**", "/repo/third_party/lib/x.go", true},
+		{"/third_party/**", "/repo/third_party/x.go", true},
+		{"third_party/**", "/repo/src/third_party/x.go", false},
+		{"vendor/*", "/repo/vendor/x.go", true},
+		{"vendor/*", "/repo/vendor/pkg/x.go", false},
+		{"vendor", "/repo/vendor/x.go", false},
+		{"**/gen/*.go", "/repo/a/b/gen/x.go", true},
+		{"*.go", "/elsewhere/x.go", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.expected, matchesAttributePattern(base, tt.pattern, filepath.FromSlash(tt.path)))
+		})
+	}
+}
+
+func TestParseGitAttributeLine(t *testing.T) {
+	rule, ok := parseGitAttributeLine("/repo", "scripts/* linguist-language=Python -linguist-vendored !linguist-generated text")
+	require.True(t, ok)
+	assert.Equal(t, "scripts/*", rule.pattern)
+	assert.Equal(t, map[string]string{
+		"linguist-language":  "Python",
+		"linguist-vendored":  "false",
+		"linguist-generated": "",
+		"text":               "true",
+	}, rule.attributes)
+
+	for _, line := range []string{"", "# comment linguist-vendored", "[attr]binary -diff -text", "docs/ linguist-documentation", "*.go"} {
+		_, ok := parseGitAttributeLine("/repo", line)
+		assert.False(t, ok, "line %q should be skipped", line)
+	}
+}
+
+func TestGitAttributesLaterRulesOverride(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "vendor", "ours"), 0755))
+	writeSourceFile(t, root, ".gitattributes", "vendor/** linguist-vendored\n*.pb.go linguist-generated\n")
+	writeSourceFile(t, root, "vendor/ours/.gitattributes", "* -linguist-vendored\n")
+
+	attributes := newGitAttributes(root)
+	attributes.load(root)
+	attributes.load(filepath.Join(root, "vendor", "ours"))
+
+	assert.True(t, attributes.isVendoredOrGenerated(filepath.Join(root, "vendor", "lib.go")))
+	assert.False(t, attributes.isVendoredOrGenerated(filepath.Join(root, "vendor", "ours", "lib.go")), "a deeper file overrides the root")
+	assert.True(t, attributes.isVendoredOrGenerated(filepath.Join(root, "api", "service.pb.go")))
+	assert.False(t, attributes.isVendoredOrGenerated(filepath.Join(root, "api", "service.go")))
+}
+
+func TestNewGitAttributesLoadsAncestors(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".git"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "services", "api"), 0755))
+	writeSourceFile(t, root, ".gitattributes", "*.tmpl linguist-language=Go\n")
+
+	attributes := newGitAttributes(filepath.Join(root, "services", "api"))
+
+	assert.Equal(t, "Go", attributes.languageOverride(filepath.Join(root, "services", "api", "page.tmpl")))
+	assert.Equal(t, "", attributes.languageOverride(filepath.Join(root, "services", "api", "page.go")))
+}
+
+// namedRegistry is stubRegistry with lookup by language name, so language overrides apply
+type namedRegistry struct{ stubRegistry }
+
+func (registry namedRegistry) GetAnalyzerByName(name string) (LanguageAnalyzer, error) {
+	if name != "Go" {
+		return nil, os.ErrNotExist
+	}
+	return stubAnalyzer{}, nil
+}
+
+func TestDiscoverFilesFollowsGitAttributes(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "third_party"), 0755))
+	writeSourceFile(t, root, ".gitattributes", "third_party/** linguist-vendored\n*_gen.go linguist-generated=true\n*.gotmpl linguist-language=Go\nlegacy.go linguist-language=Text\n")
+	mainFile := writeSourceFile(t, root, "main.go", "package sample\n")
+	writeSourceFile(t, root, "third_party/lib.go", "package lib\n")
+	writeSourceFile(t, root, "types_gen.go", "package sample\n")
+	templateFile := writeSourceFile(t, root, "page.gotmpl", "package sample\n")
+	writeSourceFile(t, root, "legacy.go", "package sample\n")
+
+	pipeline := NewPipeline(namedRegistry{}, nil, NewAggregator())
+	files, err := pipeline.discoverFiles(AnalysisOptions{RootPath: root})
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{mainFile, templateFile}, files, "vendored, generated, and unsupported-language files are left out")
+	assert.Equal(t, map[string]string{templateFile: "Go"}, pipeline.languageOverrides)
+}
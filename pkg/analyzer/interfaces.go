@@ -3,6 +3,7 @@ package analyzer
 import (
 	"time"
 
+	"github.com/alexcollie/kaizen/internal/config"
 	"github.com/alexcollie/kaizen/pkg/models"
 )
 
@@ -20,6 +21,9 @@ type LanguageAnalyzer interface {
 	// AnalyzeFile performs full analysis on a single file
 	AnalyzeFile(filePath string) (*models.FileAnalysis, error)
 
+	// AnalyzeSource performs full analysis on in-memory source, reporting it under filePath
+	AnalyzeSource(filePath string, source []byte) (*models.FileAnalysis, error)
+
 	// IsStub indicates if this is a stub implementation (not fully functional)
 	IsStub() bool
 }
@@ -107,9 +111,20 @@ type MetricCalculator interface {
 
 // Aggregator aggregates file-level metrics to folder-level metrics
 type Aggregator interface {
-	// AggregateByFolder groups file analyses by folder and calculates folder metrics
-	AggregateByFolder(files []models.FileAnalysis) map[string]models.FolderMetrics
+	// AggregateByFolder groups file analyses by folder and calculates folder metrics, averaging
+	// functions with averageMethod (an analysis.average_method) and counting concerns against
+	// thresholds
+	AggregateByFolder(files []models.FileAnalysis, averageMethod string, thresholds config.ThresholdConfig) map[string]models.FolderMetrics
+
+	// AggregateByModule groups file analyses by enclosing Go module and calculates module metrics
+	AggregateByModule(files []models.FileAnalysis, moduleDirs []string, averageMethod string, thresholds config.ThresholdConfig) map[string]models.FolderMetrics
+
+	// AggregateAPISurface counts the exported functions and types of each package, keyed by directory
+	AggregateAPISurface(files []models.FileAnalysis) map[string]models.APISurface
 
 	// CalculateScores normalizes raw metrics to 0-100 scores for visualization
 	CalculateScores(folders map[string]models.FolderMetrics) map[string]models.FolderMetrics
+
+	// Recompute rebuilds folder/module stats, API surface, summary, and score report from result.Files
+	Recompute(result *models.AnalysisResult, thresholds config.ThresholdConfig, scoring config.ScoringConfig, reportsConfig config.ReportsConfig)
 }
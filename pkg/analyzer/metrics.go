@@ -14,14 +14,27 @@ func NewMetricCalculator() MetricCalculator {
 	return &DefaultMetricCalculator{}
 }
 
-// CalculateMaintainabilityIndex computes the maintainability index
-// Formula: MI = 171 - 5.2 * ln(HV) - 0.23 * CC - 16.2 * ln(LOC)
-// Where HV = Halstead Volume, CC = Cyclomatic Complexity, LOC = Lines of Code
-func (calculator *DefaultMetricCalculator) CalculateMaintainabilityIndex(
-	halsteadVolume float64,
-	cyclomaticComplexity int,
-	linesOfCode int,
-) float64 {
+// Maintainability index formula variants selectable with analysis.mi_variant
+const (
+	// MIVariantClassic is the original 171-based formula clamped to 0-100
+	MIVariantClassic = "classic"
+
+	// MIVariantMicrosoft rescales the classic formula to 0-100 as Visual Studio does
+	MIVariantMicrosoft = "microsoft"
+
+	// MIVariantSEI adds the SEI comment-weight term to the classic formula
+	MIVariantSEI = "sei"
+)
+
+// MaintainabilityIndex computes the maintainability index using the given formula variant.
+//
+//	classic:   MI = 171 - 5.2*ln(HV) - 0.23*CC - 16.2*ln(LOC)
+//	microsoft: MI = classic * 100 / 171
+//	sei:       MI = classic + 50*sin(sqrt(2.4*CM)), CM = comment percentage (0-100)
+//
+// commentRatio is the share of comment lines (0-1) and only affects the sei variant.
+// Unknown variants fall back to classic. The result is clamped to 0-100.
+func MaintainabilityIndex(variant string, halsteadVolume float64, cyclomaticComplexity int, linesOfCode int, commentRatio float64) float64 {
 	if linesOfCode == 0 {
 		return 100.0
 	}
@@ -36,6 +49,14 @@ func (calculator *DefaultMetricCalculator) CalculateMaintainabilityIndex(
 
 	maintainabilityIndex := 171.0 - hvTerm - ccTerm - locTerm
 
+	switch variant {
+	case MIVariantMicrosoft:
+		maintainabilityIndex = maintainabilityIndex * 100.0 / 171.0
+	case MIVariantSEI:
+		commentPercent := math.Max(0, math.Min(commentRatio, 1)) * 100.0
+		maintainabilityIndex += 50.0 * math.Sin(math.Sqrt(2.4*commentPercent))
+	}
+
 	// Normalize to 0-100 range
 	if maintainabilityIndex < 0 {
 		maintainabilityIndex = 0
@@ -47,6 +68,17 @@ func (calculator *DefaultMetricCalculator) CalculateMaintainabilityIndex(
 	return maintainabilityIndex
 }
 
+// CalculateMaintainabilityIndex computes the classic maintainability index
+// Formula: MI = 171 - 5.2 * ln(HV) - 0.23 * CC - 16.2 * ln(LOC)
+// Where HV = Halstead Volume, CC = Cyclomatic Complexity, LOC = Lines of Code
+func (calculator *DefaultMetricCalculator) CalculateMaintainabilityIndex(
+	halsteadVolume float64,
+	cyclomaticComplexity int,
+	linesOfCode int,
+) float64 {
+	return MaintainabilityIndex(MIVariantClassic, halsteadVolume, cyclomaticComplexity, linesOfCode, 0)
+}
+
 // CalculateHalsteadMetrics computes Halstead complexity metrics
 func (calculator *DefaultMetricCalculator) CalculateHalsteadMetrics(
 	distinctOperators int,
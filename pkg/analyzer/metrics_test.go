@@ -0,0 +1,54 @@
+package analyzer
+
+import (
+	"math"
+	"testing"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaintainabilityIndexClassic(t *testing.T) {
+	// 171 - 5.2*ln(100) - 0.23*5 - 16.2*ln(20)
+	expected := 171 - 5.2*math.Log(100) - 0.23*5 - 16.2*math.Log(20)
+	assert.InDelta(t, expected, MaintainabilityIndex(MIVariantClassic, 100, 5, 20, 0.5), 0.001)
+
+	assert.Equal(t, 100.0, MaintainabilityIndex(MIVariantClassic, 100, 5, 0, 0))
+	assert.Equal(t, 0.0, MaintainabilityIndex(MIVariantClassic, 1e9, 200, 5000, 0))
+	assert.Equal(t, MaintainabilityIndex(MIVariantClassic, 100, 5, 20, 0), MaintainabilityIndex("unknown", 100, 5, 20, 0))
+}
+
+func TestMaintainabilityIndexMicrosoft(t *testing.T) {
+	classicRaw := 171 - 5.2*math.Log(100) - 0.23*5 - 16.2*math.Log(20)
+	assert.InDelta(t, classicRaw*100/171, MaintainabilityIndex(MIVariantMicrosoft, 100, 5, 20, 0), 0.001)
+
+	// A trivially small function scores close to 100 rather than being clamped from 171
+	assert.Less(t, MaintainabilityIndex(MIVariantMicrosoft, 10, 1, 2, 0), 100.0)
+}
+
+func TestMaintainabilityIndexSEI(t *testing.T) {
+	withoutComments := MaintainabilityIndex(MIVariantSEI, 1000, 10, 60, 0)
+	assert.InDelta(t, MaintainabilityIndex(MIVariantClassic, 1000, 10, 60, 0), withoutComments, 0.001)
+
+	// 10% comments: sin(sqrt(24)) is negative, 20% comments: sin(sqrt(48)) is positive
+	assert.InDelta(t, withoutComments+50*math.Sin(math.Sqrt(24)), MaintainabilityIndex(MIVariantSEI, 1000, 10, 60, 0.1), 0.001)
+	assert.Greater(t, MaintainabilityIndex(MIVariantSEI, 1000, 10, 60, 0.2), withoutComments)
+}
+
+func TestApplyMaintainabilityVariant(t *testing.T) {
+	analysis := &models.FileAnalysis{
+		TotalLines:   100,
+		CommentLines: 20,
+		Functions: []models.FunctionAnalysis{
+			{Name: "scored", HalsteadVolume: 1000, CyclomaticComplexity: 10, Length: 60, MaintainabilityIndex: 1},
+			{Name: "unscored", MaintainabilityIndex: 0},
+		},
+	}
+
+	applyMaintainabilityVariant(analysis, MIVariantClassic)
+	assert.Equal(t, 1.0, analysis.Functions[0].MaintainabilityIndex, "classic keeps the analyzer's value")
+
+	applyMaintainabilityVariant(analysis, MIVariantSEI)
+	assert.InDelta(t, MaintainabilityIndex(MIVariantSEI, 1000, 10, 60, 0.2), analysis.Functions[0].MaintainabilityIndex, 0.001)
+	assert.Equal(t, 0.0, analysis.Functions[1].MaintainabilityIndex, "functions without Halstead data are left alone")
+}
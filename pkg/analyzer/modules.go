@@ -0,0 +1,126 @@
+package analyzer
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// NoModuleGroup is the ModuleStats key for files that sit outside every detected Go module
+const NoModuleGroup = "(no module)"
+
+// skippedModuleDirs are directories never searched for go.mod files
+var skippedModuleDirs = map[string]bool{
+	"vendor":       true,
+	"node_modules": true,
+	"testdata":     true,
+}
+
+// DetectGoModules walks rootPath for go.mod files and returns a map of module directory
+// to module path. Directory keys use the same form as the analyzed file paths.
+func DetectGoModules(rootPath string) map[string]string {
+	modules := make(map[string]string)
+
+	_ = filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+
+		if info.IsDir() {
+			name := info.Name()
+			if path != rootPath && (skippedModuleDirs[name] || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if info.Name() == "go.mod" {
+			modules[filepath.Dir(path)] = readModulePath(path)
+		}
+		return nil
+	})
+
+	return modules
+}
+
+// readModulePath returns the module path declared in a go.mod file, or "" if none is found
+func readModulePath(goModPath string) string {
+	goModFile, err := os.Open(goModPath)
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = goModFile.Close() }()
+
+	scanner := bufio.NewScanner(goModFile)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`)
+		}
+	}
+	return ""
+}
+
+// FindModuleRoot walks up from path to the nearest go.mod and returns its directory and module
+// path, or two empty strings when path is not inside a Go module
+func FindModuleRoot(path string) (moduleDir, modulePath string) {
+	dir, err := filepath.Abs(path)
+	if err != nil {
+		return "", ""
+	}
+
+	for {
+		goModPath := filepath.Join(dir, "go.mod")
+		if _, statErr := os.Stat(goModPath); statErr == nil {
+			return dir, readModulePath(goModPath)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", ""
+		}
+		dir = parent
+	}
+}
+
+// ModuleForFile returns the directory of the nearest module enclosing filePath,
+// or NoModuleGroup when no module contains it
+func ModuleForFile(filePath string, moduleDirs []string) string {
+	nearest := ""
+	found := false
+
+	for _, moduleDir := range moduleDirs {
+		if !isWithinDir(filePath, moduleDir) {
+			continue
+		}
+		if !found || len(moduleDir) > len(nearest) {
+			nearest = moduleDir
+			found = true
+		}
+	}
+
+	if !found {
+		return NoModuleGroup
+	}
+	return nearest
+}
+
+// SortedModuleDirs returns the directories of a module map in lexical order
+func SortedModuleDirs(modules map[string]string) []string {
+	moduleDirs := make([]string, 0, len(modules))
+	for moduleDir := range modules {
+		moduleDirs = append(moduleDirs, moduleDir)
+	}
+	sort.Strings(moduleDirs)
+	return moduleDirs
+}
+
+// isWithinDir reports whether filePath lies inside dir
+func isWithinDir(filePath, dir string) bool {
+	if dir == "." {
+		return !filepath.IsAbs(filePath) && !strings.HasPrefix(filePath, "..")
+	}
+	return strings.HasPrefix(filePath, dir+"/")
+}
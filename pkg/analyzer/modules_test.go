@@ -0,0 +1,66 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeGoMod(t *testing.T, dir, modulePath string) {
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module "+modulePath+"\n\ngo 1.21\n"), 0644))
+}
+
+func TestDetectGoModules(t *testing.T) {
+	root := t.TempDir()
+	writeGoMod(t, root, "example.com/root")
+	writeGoMod(t, filepath.Join(root, "services", "api"), "example.com/api")
+	writeGoMod(t, filepath.Join(root, "vendor", "dep"), "example.com/dep")
+	writeGoMod(t, filepath.Join(root, ".cache", "mod"), "example.com/cached")
+
+	modules := DetectGoModules(root)
+
+	assert.Equal(t, map[string]string{
+		root:                                   "example.com/root",
+		filepath.Join(root, "services", "api"): "example.com/api",
+	}, modules)
+	assert.Equal(t, []string{root, filepath.Join(root, "services", "api")}, SortedModuleDirs(modules))
+}
+
+func TestDetectGoModulesNonGoTree(t *testing.T) {
+	assert.Empty(t, DetectGoModules(t.TempDir()))
+}
+
+func TestFindModuleRoot(t *testing.T) {
+	root := t.TempDir()
+	writeGoMod(t, root, "example.com/root")
+	nested := filepath.Join(root, "pkg", "api")
+	require.NoError(t, os.MkdirAll(nested, 0755))
+
+	moduleDir, modulePath := FindModuleRoot(nested)
+
+	assert.Equal(t, root, moduleDir)
+	assert.Equal(t, "example.com/root", modulePath)
+}
+
+func TestFindModuleRootOutsideModule(t *testing.T) {
+	moduleDir, modulePath := FindModuleRoot(t.TempDir())
+
+	assert.Empty(t, moduleDir)
+	assert.Empty(t, modulePath)
+}
+
+func TestModuleForFile(t *testing.T) {
+	moduleDirs := []string{".", "services/api", "services/api/internal/tool"}
+
+	assert.Equal(t, ".", ModuleForFile("main.go", moduleDirs))
+	assert.Equal(t, ".", ModuleForFile("pkg/util/util.go", moduleDirs))
+	assert.Equal(t, "services/api", ModuleForFile("services/api/handler.go", moduleDirs))
+	assert.Equal(t, "services/api/internal/tool", ModuleForFile("services/api/internal/tool/main.go", moduleDirs))
+	assert.Equal(t, ".", ModuleForFile("services/apiserver/main.go", moduleDirs), "prefix must match whole directory names")
+
+	assert.Equal(t, NoModuleGroup, ModuleForFile("web/app.ts", []string{"services/api"}))
+}
@@ -0,0 +1,85 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+// FindRepositoryRoot walks up from path to the nearest directory containing .git (a directory,
+// or a file for worktrees and submodules) and returns it as an absolute path. Outside a git
+// repository the absolute form of path itself is the root.
+func FindRepositoryRoot(path string) string {
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+
+	for dir := absolutePath; ; {
+		if _, statErr := os.Stat(filepath.Join(dir, ".git")); statErr == nil {
+			return dir
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return absolutePath
+		}
+		dir = parent
+	}
+}
+
+// normalizePath rewrites a discovered file path in the given path style: relative to
+// repositoryRoot, or absolute. Paths that cannot be expressed relative to the root stay absolute.
+// Either way the result uses forward slashes, so results compare the same on every OS.
+func normalizePath(path, repositoryRoot, pathStyle string) string {
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		return slashPath(path)
+	}
+	if pathStyle == config.PathStyleAbsolute {
+		return slashPath(absolutePath)
+	}
+
+	relativePath, err := filepath.Rel(repositoryRoot, absolutePath)
+	if err != nil || relativePath == ".." || strings.HasPrefix(relativePath, ".."+string(filepath.Separator)) {
+		return slashPath(absolutePath)
+	}
+	return slashPath(relativePath)
+}
+
+// slashPath rewrites an OS path with forward slashes, the separator every stored and compared
+// path uses; folder stats, module grouping and the treemap all split paths on "/"
+func slashPath(path string) string {
+	return toSlashPath(path, filepath.Separator)
+}
+
+// toSlashPath replaces separator in path with a forward slash. filepath.ToSlash does the same
+// for the current OS only, which leaves Windows paths untestable elsewhere.
+func toSlashPath(path string, separator rune) string {
+	if separator == '/' {
+		return path
+	}
+	return strings.ReplaceAll(path, string(separator), "/")
+}
+
+// normalizeResultPaths records repositoryRoot on result and rewrites every file path in it, and
+// the module directories files are grouped by, in the given path style
+func normalizeResultPaths(result *models.AnalysisResult, moduleDirs []string, repositoryRoot, pathStyle string) {
+	result.Repository = repositoryRoot
+
+	for index := range result.Files {
+		result.Files[index].Path = normalizePath(result.Files[index].Path, repositoryRoot, pathStyle)
+	}
+	for index := range result.TestFiles {
+		result.TestFiles[index].Path = normalizePath(result.TestFiles[index].Path, repositoryRoot, pathStyle)
+	}
+	for index := range result.SkippedFiles {
+		result.SkippedFiles[index].Path = normalizePath(result.SkippedFiles[index].Path, repositoryRoot, pathStyle)
+	}
+	for index := range moduleDirs {
+		moduleDirs[index] = normalizePath(moduleDirs[index], repositoryRoot, pathStyle)
+	}
+}
@@ -0,0 +1,87 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindRepositoryRoot(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".git"), 0755))
+	nested := filepath.Join(root, "services", "api")
+	require.NoError(t, os.MkdirAll(nested, 0755))
+
+	assert.Equal(t, root, FindRepositoryRoot(nested))
+	assert.Equal(t, root, FindRepositoryRoot(root))
+
+	outside := t.TempDir()
+	assert.Equal(t, outside, FindRepositoryRoot(outside), "falls back to the path outside a repository")
+}
+
+func TestNormalizePath(t *testing.T) {
+	root := t.TempDir()
+	filePath := filepath.Join(root, "pkg", "a.go")
+
+	assert.Equal(t, "pkg/a.go", normalizePath(filePath, root, config.PathStyleRelative))
+	assert.Equal(t, filepath.ToSlash(filePath), normalizePath(filePath, root, config.PathStyleAbsolute))
+
+	outsidePath := filepath.Join(filepath.Dir(root), "elsewhere.go")
+	assert.Equal(t, filepath.ToSlash(outsidePath), normalizePath(outsidePath, root, config.PathStyleRelative), "paths outside the root stay absolute")
+}
+
+func TestToSlashPath(t *testing.T) {
+	assert.Equal(t, "pkg/api/handler.go", toSlashPath(`pkg\api\handler.go`, '\\'))
+	assert.Equal(t, "C:/src/repo/main.go", toSlashPath(`C:\src\repo\main.go`, '\\'))
+	assert.Equal(t, "pkg/api/handler.go", toSlashPath("pkg/api/handler.go", '\\'), "forward slashes are kept")
+	assert.Equal(t, `odd\name.go`, toSlashPath(`odd\name.go`, '/'), "a backslash is a file name character on Unix")
+}
+
+func TestWindowsPathsGroupLikeUnixPaths(t *testing.T) {
+	files := []models.FileAnalysis{
+		{Path: toSlashPath(`pkg\api\handler.go`, '\\'), CodeLines: 10},
+		{Path: "pkg/api/routes.go", CodeLines: 20},
+		{Path: toSlashPath(`services\billing\invoice.go`, '\\'), CodeLines: 30},
+	}
+
+	folders := NewAggregator().AggregateByFolder(files, "", config.DefaultConfig().Thresholds)
+	require.Len(t, folders, 2)
+	assert.Equal(t, 2, folders["pkg/api"].TotalFiles)
+	assert.Equal(t, 1, folders["services/billing"].TotalFiles)
+
+	moduleDirs := []string{".", toSlashPath(`services\billing`, '\\')}
+	assert.Equal(t, "services/billing", ModuleForFile(files[2].Path, moduleDirs))
+	assert.Equal(t, ".", ModuleForFile(files[0].Path, moduleDirs))
+}
+
+func TestMatchesExcludePatternUsesForwardSlashes(t *testing.T) {
+	patterns := []string{"vendor/", "*.pb.go"}
+
+	assert.True(t, MatchesExcludePattern(filepath.Join("src", "vendor", "lib.go"), patterns))
+	assert.True(t, MatchesExcludePattern(filepath.Join("api", "service.pb.go"), patterns))
+	assert.False(t, MatchesExcludePattern(filepath.Join("src", "vendored.go"), patterns))
+}
+
+func TestNormalizeResultPaths(t *testing.T) {
+	root := t.TempDir()
+	result := &models.AnalysisResult{
+		Files:        []models.FileAnalysis{{Path: filepath.Join(root, "a.go")}},
+		TestFiles:    []models.FileAnalysis{{Path: filepath.Join(root, "a_test.go")}},
+		SkippedFiles: []models.SkippedFile{{Path: filepath.Join(root, "big.go")}},
+	}
+	moduleDirs := []string{root, filepath.Join(root, "tools")}
+
+	normalizeResultPaths(result, moduleDirs, root, config.PathStyleRelative)
+
+	assert.Equal(t, root, result.Repository)
+	assert.Equal(t, "a.go", result.Files[0].Path)
+	assert.Equal(t, "a_test.go", result.TestFiles[0].Path)
+	assert.Equal(t, "big.go", result.SkippedFiles[0].Path)
+	assert.Equal(t, []string{".", "tools"}, moduleDirs)
+	assert.Equal(t, filepath.Join(root, "a.go"), result.ResolvePath(result.Files[0].Path))
+}
@@ -1,8 +1,12 @@
 package analyzer
 
 import (
+	"bufio"
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
@@ -14,21 +18,63 @@ import (
 
 // AnalysisOptions contains configuration for the analysis
 type AnalysisOptions struct {
-	RootPath         string
-	Since            time.Time
-	IncludeLanguages []string
-	ExcludePatterns  []string
-	IncludeChurn     bool
-	MaxWorkers       int
-	Thresholds       config.ThresholdConfig
-	ProgressCallback func(file string, current int, total int)
+	RootPath                   string
+	Since                      time.Time
+	IncludeLanguages           []string
+	ExcludePatterns            []string
+	ExcludeDirs                []string // Directory names skipped at any depth
+	IncludeChurn               bool
+	MaxWorkers                 int
+	MaxFileSize                int64         // Skip files larger than this many bytes (0 = no limit)
+	SkipGenerated              bool          // Skip files whose name or first lines mark them as generated
+	MIVariant                  string        // Maintainability index formula (MIVariantClassic when empty)
+	CountStdlibCalls           bool          // Keep calls to built-ins and the standard library in fan-out
+	CountAnonymousFunctions    bool          // Report closures, lambdas, and blocks as functions of their own
+	ChurnMetric                string        // Churn count behind hotspots and churn concerns (commits when empty)
+	PathStyle                  string        // File paths in the result: relative to the repository root (default) or absolute
+	MinFunctionLines           int           // Shorter functions are trivial: tallied, but skipped by concern detection (0 = off)
+	ExcludeTrivialFromAverages bool          // Also leave trivial functions out of the summary averages
+	AverageMethod              string        // How summary and folder averages combine functions (per_function when empty)
+	IncludeTests               bool          // Analyze test files despite exclude patterns, into TestFiles and TestStats
+	TimeoutPerFile             time.Duration // Skip files whose analysis takes longer than this (0 = no limit)
+	StrictParse                bool          // Fail the analysis when any file cannot be analyzed, instead of leaving it out
+	CoverageProfile            string        // Go cover profile or LCOV tracefile whose coverage is recorded on each file (none when empty)
+	Thresholds                 config.ThresholdConfig
+	Scoring                    config.ScoringConfig
+	Reports                    config.ReportsConfig
+	ProgressCallback           func(file string, current int, total int)
+	Timings                    *PhaseTimings // Filled with per-phase and per-file timings when set
+}
+
+// ParseFailuresError is returned by Analyze with StrictParse set when any file could not be
+// analyzed. Every file is still attempted, so it lists all of them rather than the first.
+type ParseFailuresError struct {
+	Failures []models.SkippedFile // Path and the analyzer's error, in analysis order
+}
+
+// Error lists each failed file on its own line
+func (failuresError *ParseFailuresError) Error() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "%d file(s) failed to parse:", len(failuresError.Failures))
+	for _, failure := range failuresError.Failures {
+		fmt.Fprintf(&builder, "\n  %s: %s", failure.Path, failure.Reason)
+	}
+	return builder.String()
 }
 
 // Pipeline orchestrates the analysis process
 type Pipeline struct {
-	registry      interface{ GetAnalyzerForFile(string) (LanguageAnalyzer, error) }
-	churnAnalyzer ChurnAnalyzer
-	aggregator    Aggregator
+	registry          interface{ GetAnalyzerForFile(string) (LanguageAnalyzer, error) }
+	churnAnalyzer     ChurnAnalyzer
+	aggregator        Aggregator
+	languageOverrides map[string]string // linguist-language from .gitattributes, by discovered path
+	generatedSkipped  int               // Files discovery left out as generated
+}
+
+// namedAnalyzerRegistry is implemented by registries that can look an analyzer up by language
+// name, which .gitattributes language overrides need
+type namedAnalyzerRegistry interface {
+	GetAnalyzerByName(string) (LanguageAnalyzer, error)
 }
 
 // NewPipeline creates a new analysis pipeline
@@ -47,7 +93,9 @@ func NewPipeline(
 // Analyze performs the complete analysis on a codebase
 func (pipeline *Pipeline) Analyze(options AnalysisOptions) (*models.AnalysisResult, error) {
 	// Discover all analyzable files
+	walkStart := time.Now()
 	files, err := pipeline.discoverFiles(options)
+	options.Timings.record(PhaseFileWalk, time.Since(walkStart))
 	if err != nil {
 		return nil, fmt.Errorf("failed to discover files: %w", err)
 	}
@@ -58,13 +106,27 @@ func (pipeline *Pipeline) Analyze(options AnalysisOptions) (*models.AnalysisResu
 
 	// Analyze each file
 	fileAnalyses := make([]models.FileAnalysis, 0, len(files))
+	var skippedFiles []models.SkippedFile
+	var parseFailures []models.SkippedFile
 	for index, file := range files {
 		if options.ProgressCallback != nil {
 			options.ProgressCallback(file, index+1, len(files))
 		}
 
-		analysis, err := pipeline.analyzeFile(file, options)
+		analysis, err := pipeline.analyzeFileWithTimeout(file, options)
+		if errors.Is(err, context.DeadlineExceeded) {
+			fmt.Fprintf(os.Stderr, "Warning: skipping %s (analysis exceeded timeout of %s)\n", file, options.TimeoutPerFile)
+			skippedFiles = append(skippedFiles, models.SkippedFile{
+				Path:   file,
+				Reason: fmt.Sprintf("analysis exceeded timeout of %s", options.TimeoutPerFile),
+			})
+			continue
+		}
 		if err != nil {
+			if options.StrictParse {
+				parseFailures = append(parseFailures, models.SkippedFile{Path: file, Reason: err.Error()})
+				continue
+			}
 			// Log error but continue with other files
 			fmt.Fprintf(os.Stderr, "Warning: failed to analyze %s: %v\n", file, err)
 			continue
@@ -73,31 +135,63 @@ func (pipeline *Pipeline) Analyze(options AnalysisOptions) (*models.AnalysisResu
 		fileAnalyses = append(fileAnalyses, *analysis)
 	}
 
-	// Aggregate by folder
-	folderStats := pipeline.aggregator.AggregateByFolder(fileAnalyses)
+	if len(parseFailures) > 0 {
+		return nil, &ParseFailuresError{Failures: parseFailures}
+	}
 
-	// Calculate normalized scores
-	folderStats = pipeline.aggregator.CalculateScores(folderStats)
+	// Test files are kept out of every production aggregate, fan-in included
+	var testFiles []models.FileAnalysis
+	if options.IncludeTests {
+		fileAnalyses, testFiles = partitionTestFiles(fileAnalyses)
+	}
 
-	// Generate summary
-	summary := pipeline.generateSummary(fileAnalyses)
+	// Fan-in needs every file's functions, so it is computed once all files are analyzed
+	fanInStart := time.Now()
+	populateFanIn(fileAnalyses)
+	options.Timings.record(PhaseFanIn, time.Since(fanInStart))
 
-	// Build result for score report generation
 	result := &models.AnalysisResult{
-		Repository: options.RootPath,
-		AnalyzedAt: time.Now(),
+		SchemaVersion: models.SchemaVersion,
+		AnalyzedAt:    time.Now(),
 		TimeRange: models.TimeRange{
 			Since: options.Since,
 			Until: time.Now(),
 		},
-		Files:       fileAnalyses,
-		FolderStats: folderStats,
-		Summary:     summary,
+		ChurnMetric:                 options.ChurnMetric,
+		MinFunctionLines:            options.MinFunctionLines,
+		TrivialExcludedFromAverages: options.ExcludeTrivialFromAverages,
+		AverageMethod:               options.AverageMethod,
+		Files:                       fileAnalyses,
+		SkippedFiles:                skippedFiles,
+		GeneratedFilesSkipped:       pipeline.generatedSkipped,
+		TestFiles:                   testFiles,
+		TestsIncluded:               options.IncludeTests,
+	}
+
+	// Group by Go module when the tree holds more than one; single-module repos keep folder grouping only
+	var moduleDirs []string
+	if modules := DetectGoModules(options.RootPath); len(modules) > 1 {
+		moduleDirs = SortedModuleDirs(modules)
+	}
+
+	// Paths are normalized once the files have been read, since discovered paths are relative to
+	// the working directory rather than the repository root
+	normalizeResultPaths(result, moduleDirs, FindRepositoryRoot(options.RootPath), options.PathStyle)
+
+	// Coverage is matched against the normalized paths, before folder coverage and risk are aggregated
+	if options.CoverageProfile != "" {
+		coverage, err := LoadCoverageProfile(options.CoverageProfile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read coverage profile: %w", err)
+		}
+		if applyCoverage(result.Files, coverage) == 0 {
+			fmt.Fprintf(os.Stderr, "Warning: no analyzed file matches a path in coverage profile %s\n", options.CoverageProfile)
+		}
 	}
 
-	// Generate score report
+	// Aggregate folder/module stats, summary, and score report
 	hasChurnData := options.IncludeChurn && pipeline.churnAnalyzer != nil
-	result.ScoreReport = reports.GenerateScoreReport(result, hasChurnData, options.Thresholds)
+	rebuildAggregates(pipeline.aggregator, result, moduleDirs, hasChurnData, options.Thresholds, options.Scoring, options.Reports, options.Timings)
 
 	return result, nil
 }
@@ -105,6 +199,9 @@ func (pipeline *Pipeline) Analyze(options AnalysisOptions) (*models.AnalysisResu
 // discoverFiles finds all files that can be analyzed
 func (pipeline *Pipeline) discoverFiles(options AnalysisOptions) ([]string, error) {
 	var files []string
+	attributes := newGitAttributes(options.RootPath)
+	pipeline.languageOverrides = map[string]string{}
+	pipeline.generatedSkipped = 0
 
 	err := filepath.Walk(options.RootPath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -114,19 +211,36 @@ func (pipeline *Pipeline) discoverFiles(options AnalysisOptions) ([]string, erro
 		// Skip directories
 		if info.IsDir() {
 			// Check if directory should be excluded
+			if path != options.RootPath && isExcludedDir(info.Name(), options.ExcludeDirs) {
+				return filepath.SkipDir
+			}
 			if pipeline.shouldExclude(path, options.ExcludePatterns) {
 				return filepath.SkipDir
 			}
+			attributes.load(path)
+			return nil
+		}
+
+		// Check if file should be excluded; with IncludeTests, test files are analyzed regardless
+		if pipeline.shouldExclude(path, options.ExcludePatterns) && !(options.IncludeTests && IsTestFile(path)) {
+			return nil
+		}
+
+		// Skip oversized files (minified bundles, vendored single-file libs)
+		if options.MaxFileSize > 0 && info.Size() > options.MaxFileSize {
+			fmt.Fprintf(os.Stderr, "Warning: skipping %s (%d bytes exceeds max file size of %d bytes)\n",
+				path, info.Size(), options.MaxFileSize)
 			return nil
 		}
 
-		// Check if file should be excluded
-		if pipeline.shouldExclude(path, options.ExcludePatterns) {
+		// Leave out what GitHub's linguist leaves out of language statistics
+		if attributes.isVendoredOrGenerated(path) {
 			return nil
 		}
 
-		// Check if we can analyze this file
-		analyzer, err := pipeline.registry.GetAnalyzerForFile(path)
+		// Check if we can analyze this file, in the language .gitattributes assigns it if any
+		language := attributes.languageOverride(path)
+		analyzer, err := pipeline.analyzerForFile(path, language)
 		if err != nil {
 			// No analyzer for this file type, skip
 			return nil
@@ -147,6 +261,15 @@ func (pipeline *Pipeline) discoverFiles(options AnalysisOptions) ([]string, erro
 			}
 		}
 
+		// Skip generated files
+		if options.SkipGenerated && isGeneratedFile(path) {
+			pipeline.generatedSkipped++
+			return nil
+		}
+
+		if language != "" {
+			pipeline.languageOverrides[path] = language
+		}
 		files = append(files, path)
 		return nil
 	})
@@ -154,26 +277,139 @@ func (pipeline *Pipeline) discoverFiles(options AnalysisOptions) ([]string, erro
 	return files, err
 }
 
+// analyzerForFile returns the analyzer for language when .gitattributes overrides a file's
+// language, and otherwise the analyzer for the file's extension
+func (pipeline *Pipeline) analyzerForFile(filePath string, language string) (LanguageAnalyzer, error) {
+	if named, ok := pipeline.registry.(namedAnalyzerRegistry); ok && language != "" {
+		return named.GetAnalyzerByName(language)
+	}
+	return pipeline.registry.GetAnalyzerForFile(filePath)
+}
+
+// isExcludedDir reports whether a directory's base name is one of the excluded directory names
+func isExcludedDir(dirName string, excludeDirs []string) bool {
+	for _, excludeDir := range excludeDirs {
+		if dirName == excludeDir {
+			return true
+		}
+	}
+	return false
+}
+
+// generatedFileMarkers identify files produced by code generators, in any language's comment
+// syntax. "@generated" is Facebook's convention (Thrift, Relay, Buck).
+var generatedFileMarkers = []string{"Code generated", "DO NOT EDIT", "@generated"}
+
+// generatedFileWords identify generated files however they are capitalized, e.g. "Autogenerated"
+// or "AUTO-GENERATED"
+var generatedFileWords = []string{"autogenerated", "auto-generated"}
+
+// generatedHeaderLines is how many lines from the top of a file are searched for a marker;
+// license headers and shebangs can push it past the first line
+const generatedHeaderLines = 5
+
+// generatedFileNames are file name patterns of common generators' output: protobuf and gRPC
+// stubs, go generate and mockgen conventions, and Kubernetes deepcopy code
+var generatedFileNames = []string{
+	"*.pb.go", "*.pb.gw.go", "*_gen.go", "*.gen.go", "*_generated.go", "zz_generated*",
+	"mock_*.go", "*_mock.go", "*_pb2.py", "*_pb2_grpc.py", "*.pb.swift", "*.grpc.swift",
+}
+
+// isGeneratedFile checks whether a file's name matches a generated file pattern, or its first
+// lines carry a generated file marker
+func isGeneratedFile(path string) bool {
+	baseName := filepath.Base(path)
+	for _, pattern := range generatedFileNames {
+		if matched, _ := filepath.Match(pattern, baseName); matched {
+			return true
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer func() { _ = file.Close() }()
+
+	scanner := bufio.NewScanner(file)
+	for lineIndex := 0; lineIndex < generatedHeaderLines && scanner.Scan(); lineIndex++ {
+		line := scanner.Text()
+		for _, marker := range generatedFileMarkers {
+			if strings.Contains(line, marker) {
+				return true
+			}
+		}
+		lowerLine := strings.ToLower(line)
+		for _, word := range generatedFileWords {
+			if strings.Contains(lowerLine, word) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // shouldExclude checks if a path matches any exclude pattern
 func (pipeline *Pipeline) shouldExclude(path string, patterns []string) bool {
+	return MatchesExcludePattern(path, patterns)
+}
+
+// MatchesExcludePattern reports whether a path matches any analysis exclude pattern, either as
+// a glob against its base name or as a substring of the path. Path and patterns are compared
+// with forward slashes, so "vendor/" excludes the same files on Windows. Other tools that walk
+// the source tree, such as the call graph, use it so their excludes behave like analyze's.
+func MatchesExcludePattern(filePath string, patterns []string) bool {
+	filePath = slashPath(filePath)
 	for _, pattern := range patterns {
-		matched, err := filepath.Match(pattern, filepath.Base(path))
+		pattern = slashPath(pattern)
+		matched, err := path.Match(pattern, path.Base(filePath))
 		if err == nil && matched {
 			return true
 		}
 
 		// Also check if pattern is in the path
-		if strings.Contains(path, pattern) {
+		if strings.Contains(filePath, pattern) {
 			return true
 		}
 	}
 	return false
 }
 
+// analyzeFileWithTimeout analyzes a single file, giving up once options.TimeoutPerFile elapses.
+// Language analyzers cannot be interrupted, so a timed-out analysis is abandoned and left to
+// finish in the background; its result is discarded.
+func (pipeline *Pipeline) analyzeFileWithTimeout(filePath string, options AnalysisOptions) (*models.FileAnalysis, error) {
+	if options.TimeoutPerFile <= 0 {
+		return pipeline.analyzeFile(filePath, options)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), options.TimeoutPerFile)
+	defer cancel()
+
+	type fileResult struct {
+		analysis *models.FileAnalysis
+		err      error
+	}
+
+	// Buffered so an abandoned analysis can still deliver its result and exit
+	done := make(chan fileResult, 1)
+	go func() {
+		analysis, err := pipeline.analyzeFile(filePath, options)
+		done <- fileResult{analysis: analysis, err: err}
+	}()
+
+	select {
+	case result := <-done:
+		return result.analysis, result.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 // analyzeFile analyzes a single file
 func (pipeline *Pipeline) analyzeFile(filePath string, options AnalysisOptions) (*models.FileAnalysis, error) {
 	// Get the appropriate analyzer
-	analyzer, err := pipeline.registry.GetAnalyzerForFile(filePath)
+	analyzer, err := pipeline.analyzerForFile(filePath, pipeline.languageOverrides[filePath])
 	if err != nil {
 		return nil, err
 	}
@@ -184,12 +420,18 @@ func (pipeline *Pipeline) analyzeFile(filePath string, options AnalysisOptions)
 	}
 
 	// Analyze the file
+	parseStart := time.Now()
 	analysis, err := analyzer.AnalyzeFile(filePath)
+	timing := FileTiming{Path: filePath, Language: analyzer.Name(), Parsing: time.Since(parseStart)}
 	if err != nil {
+		options.Timings.recordFile(timing)
 		return nil, err
 	}
 
+	applyAnonymousFunctionPolicy(analysis, options.CountAnonymousFunctions)
+
 	// Add churn metrics if enabled
+	churnStart := time.Now()
 	if options.IncludeChurn && pipeline.churnAnalyzer != nil {
 		churn, err := pipeline.churnAnalyzer.GetFileChurn(filePath, options.Since)
 		if err != nil {
@@ -211,72 +453,94 @@ func (pipeline *Pipeline) analyzeFile(filePath string, options AnalysisOptions)
 			}
 		}
 	}
+	timing.Churn = time.Since(churnStart)
+	options.Timings.recordFile(timing)
+
+	applyMaintainabilityVariant(analysis, options.MIVariant)
+	applyStdlibCallPolicy(analysis, options.CountStdlibCalls)
 
 	// Mark hotspots using configurable thresholds
+	hotspotThresholds := options.Thresholds.Hotspot
 	for index := range analysis.Functions {
 		function := &analysis.Functions[index]
-		if function.Churn != nil {
-			if function.Churn.TotalCommits > options.Thresholds.Hotspot.MinChurn &&
-				function.CyclomaticComplexity > options.Thresholds.Hotspot.MinComplexity {
-				function.IsHotspot = true
-			}
-		}
+		function.IsHotspot = reports.IsHotspot(*function, hotspotThresholds, options.ChurnMetric)
 	}
 
 	return analysis, nil
 }
 
-// generateSummary creates summary metrics from all file analyses
-func (pipeline *Pipeline) generateSummary(files []models.FileAnalysis) models.SummaryMetrics {
-	summary := models.SummaryMetrics{}
-
-	totalComplexity := 0
-	totalCognitive := 0
-	totalLength := 0
-	totalMaintainability := 0.0
-	functionCount := 0
-
-	for _, file := range files {
-		summary.TotalFiles++
-		summary.TotalLines += file.TotalLines
-		summary.TotalCodeLines += file.CodeLines
-		summary.TotalTypes += len(file.Types)
-
-		for _, function := range file.Functions {
-			functionCount++
-			summary.TotalFunctions++
-
-			totalComplexity += function.CyclomaticComplexity
-			totalCognitive += function.CognitiveComplexity
-			totalLength += function.Length
-			totalMaintainability += function.MaintainabilityIndex
-
-			// Count categories
-			if function.CyclomaticComplexity > 10 {
-				summary.HighComplexityCount++
-			}
-			if function.CyclomaticComplexity > 20 {
-				summary.VeryHighComplexityCount++
-			}
-			if function.Length > 50 {
-				summary.LongFunctionCount++
-			}
-			if function.Length > 100 {
-				summary.VeryLongFunctionCount++
-			}
-			if function.IsHotspot {
-				summary.HotspotCount++
-			}
+// AnalyzeSource analyzes in-memory source with the given analyzer and applies the same per-file
+// post-processing as Analyze, except churn and hotspots, which need the file's git history
+func AnalyzeSource(languageAnalyzer LanguageAnalyzer, filePath string, source []byte, options AnalysisOptions) (*models.FileAnalysis, error) {
+	if languageAnalyzer.IsStub() {
+		return nil, fmt.Errorf("analyzer for %s is a stub (not implemented)", languageAnalyzer.Name())
+	}
+
+	analysis, err := languageAnalyzer.AnalyzeSource(filePath, source)
+	if err != nil {
+		return nil, err
+	}
+
+	applyAnonymousFunctionPolicy(analysis, options.CountAnonymousFunctions)
+	applyMaintainabilityVariant(analysis, options.MIVariant)
+	applyStdlibCallPolicy(analysis, options.CountStdlibCalls)
+	return analysis, nil
+}
+
+// applyAnonymousFunctionPolicy drops anonymous functions unless countAnonymousFunctions is set.
+// Language analyzers report every closure, lambda, and block, flagged as anonymous; their code
+// also counts towards the enclosing function either way.
+func applyAnonymousFunctionPolicy(analysis *models.FileAnalysis, countAnonymousFunctions bool) {
+	if countAnonymousFunctions {
+		return
+	}
+	named := analysis.Functions[:0]
+	for _, function := range analysis.Functions {
+		if !function.IsAnonymous {
+			named = append(named, function)
 		}
 	}
+	analysis.Functions = named
+}
 
-	// Calculate averages
-	if functionCount > 0 {
-		summary.AverageCyclomaticComplexity = float64(totalComplexity) / float64(functionCount)
-		summary.AverageCognitiveComplexity = float64(totalCognitive) / float64(functionCount)
-		summary.AverageFunctionLength = float64(totalLength) / float64(functionCount)
-		summary.AverageMaintainabilityIndex = totalMaintainability / float64(functionCount)
+// applyStdlibCallPolicy takes calls to built-ins and the standard library out of function
+// fan-out unless countStdlibCalls is set, so fan-out reflects first-party dependencies.
+// Language analyzers count every call and report the built-in and standard library share.
+func applyStdlibCallPolicy(analysis *models.FileAnalysis, countStdlibCalls bool) {
+	if countStdlibCalls {
+		return
+	}
+	for index := range analysis.Functions {
+		function := &analysis.Functions[index]
+		function.FanOut -= function.StdlibCalls
+	}
+}
+
+// applyMaintainabilityVariant recomputes function maintainability indexes with a non-classic
+// formula. Language analyzers report the classic variant; the SEI comment weight uses the
+// file's comment ratio since comments are not counted per function.
+func applyMaintainabilityVariant(analysis *models.FileAnalysis, variant string) {
+	if variant == "" || variant == MIVariantClassic {
+		return
+	}
+
+	commentRatio := 0.0
+	if analysis.TotalLines > 0 {
+		commentRatio = float64(analysis.CommentLines) / float64(analysis.TotalLines)
 	}
 
-	return summary
+	for index := range analysis.Functions {
+		function := &analysis.Functions[index]
+		// Analyzers without Halstead data do not score maintainability
+		if function.HalsteadVolume == 0 {
+			continue
+		}
+		function.MaintainabilityIndex = MaintainabilityIndex(
+			variant,
+			function.HalsteadVolume,
+			function.CyclomaticComplexity,
+			function.Length,
+			commentRatio,
+		)
+	}
 }
@@ -0,0 +1,534 @@
+package analyzer
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubRegistry returns a fixed analyzer for every .go file
+type stubRegistry struct{}
+
+func (registry stubRegistry) GetAnalyzerForFile(path string) (LanguageAnalyzer, error) {
+	if filepath.Ext(path) != ".go" {
+		return nil, os.ErrNotExist
+	}
+	return stubAnalyzer{}, nil
+}
+
+type stubAnalyzer struct{}
+
+func (stub stubAnalyzer) Name() string                { return "Go" }
+func (stub stubAnalyzer) FileExtensions() []string    { return []string{".go"} }
+func (stub stubAnalyzer) CanAnalyze(path string) bool { return filepath.Ext(path) == ".go" }
+func (stub stubAnalyzer) IsStub() bool                { return false }
+func (stub stubAnalyzer) AnalyzeFile(path string) (*models.FileAnalysis, error) {
+	return &models.FileAnalysis{Path: path, Language: "Go"}, nil
+}
+func (stub stubAnalyzer) AnalyzeSource(path string, source []byte) (*models.FileAnalysis, error) {
+	return &models.FileAnalysis{Path: path, Language: "Go"}, nil
+}
+
+func writeSourceFile(testingT *testing.T, dir string, name string, content string) string {
+	path := filepath.Join(dir, name)
+	require.NoError(testingT, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+func TestDiscoverFilesSkipsOversizedFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	smallFile := writeSourceFile(t, tempDir, "small.go", "package sample\n")
+	writeSourceFile(t, tempDir, "huge.go", "package sample\n"+strings.Repeat("// filler\n", 200))
+
+	pipeline := NewPipeline(stubRegistry{}, nil, NewAggregator())
+	files, err := pipeline.discoverFiles(AnalysisOptions{RootPath: tempDir, MaxFileSize: 100})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{smallFile}, files)
+}
+
+func TestDiscoverFilesNoSizeLimit(t *testing.T) {
+	tempDir := t.TempDir()
+	writeSourceFile(t, tempDir, "small.go", "package sample\n")
+	writeSourceFile(t, tempDir, "huge.go", "package sample\n"+strings.Repeat("// filler\n", 200))
+
+	pipeline := NewPipeline(stubRegistry{}, nil, NewAggregator())
+	files, err := pipeline.discoverFiles(AnalysisOptions{RootPath: tempDir})
+
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+}
+
+func TestDiscoverFilesSkipsGeneratedFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	handWritten := writeSourceFile(t, tempDir, "handwritten.go", "package sample\n")
+	writeSourceFile(t, tempDir, "generated.go", "// Code generated by protoc-gen-go. DO NOT EDIT.\npackage sample\n")
+
+	pipeline := NewPipeline(stubRegistry{}, nil, NewAggregator())
+
+	files, err := pipeline.discoverFiles(AnalysisOptions{RootPath: tempDir, SkipGenerated: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{handWritten}, files)
+
+	files, err = pipeline.discoverFiles(AnalysisOptions{RootPath: tempDir, SkipGenerated: false})
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+}
+
+func TestDiscoverFilesSkipsExcludedDirsAtAnyDepth(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, dir := range []string{"testdata", "pkg/generated", "pkg/api/testdata", "pkg/testdata_helpers"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(tempDir, dir), 0755))
+		writeSourceFile(t, tempDir, filepath.Join(dir, "file.go"), "package sample\n")
+	}
+	kept := writeSourceFile(t, tempDir, "main.go", "package sample\n")
+
+	pipeline := NewPipeline(stubRegistry{}, nil, NewAggregator())
+	files, err := pipeline.discoverFiles(AnalysisOptions{
+		RootPath:    tempDir,
+		ExcludeDirs: []string{"testdata", "generated"},
+	})
+
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{kept, filepath.Join(tempDir, "pkg/testdata_helpers/file.go")}, files)
+}
+
+func TestDiscoverFilesDoesNotExcludeRoot(t *testing.T) {
+	tempDir := filepath.Join(t.TempDir(), "testdata")
+	require.NoError(t, os.MkdirAll(tempDir, 0755))
+	kept := writeSourceFile(t, tempDir, "main.go", "package sample\n")
+
+	pipeline := NewPipeline(stubRegistry{}, nil, NewAggregator())
+	files, err := pipeline.discoverFiles(AnalysisOptions{RootPath: tempDir, ExcludeDirs: []string{"testdata"}})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{kept}, files)
+}
+
+func TestIsGeneratedFile(t *testing.T) {
+	tempDir := t.TempDir()
+
+	assert.True(t, isGeneratedFile(writeSourceFile(t, tempDir, "a.go", "// Code generated by mockgen.\n")))
+	assert.True(t, isGeneratedFile(writeSourceFile(t, tempDir, "b.go", "// DO NOT EDIT\n")))
+	assert.True(t, isGeneratedFile(writeSourceFile(t, tempDir, "c.go", "package sample\n// DO NOT EDIT\n")))
+	assert.False(t, isGeneratedFile(writeSourceFile(t, tempDir, "d.go", "")))
+	assert.False(t, isGeneratedFile(filepath.Join(tempDir, "missing.go")))
+}
+
+func TestIsGeneratedFileMarkersInHeader(t *testing.T) {
+	tempDir := t.TempDir()
+	license := "// Copyright 2024 Example\n// Licensed under the Apache License\n\n"
+
+	assert.True(t, isGeneratedFile(writeSourceFile(t, tempDir, "licensed.go", license+"// Code generated by stringer. DO NOT EDIT.\n")))
+	assert.True(t, isGeneratedFile(writeSourceFile(t, tempDir, "schema.py", "#!/usr/bin/env python\n# @generated by thrift\n")))
+	assert.True(t, isGeneratedFile(writeSourceFile(t, tempDir, "Api.kt", "/*\n * Autogenerated by OpenAPI Generator\n */\n")))
+	assert.True(t, isGeneratedFile(writeSourceFile(t, tempDir, "Model.swift", "// AUTO-GENERATED FILE\n")))
+	assert.False(t, isGeneratedFile(writeSourceFile(t, tempDir, "late.go", "package sample\n\n\n\n\n// Code generated by hand\n")), "markers below the first 5 lines are ignored")
+	assert.False(t, isGeneratedFile(writeSourceFile(t, tempDir, "generator.go", "package sample\n\n// generateCode writes output\n")))
+}
+
+func TestIsGeneratedFileNames(t *testing.T) {
+	tempDir := t.TempDir()
+
+	for _, name := range []string{"api.pb.go", "api.pb.gw.go", "enums_gen.go", "zz_generated.deepcopy.go", "mock_store.go", "store_mock.go", "api_pb2.py", "api_pb2_grpc.py", "Api.pb.swift"} {
+		assert.True(t, isGeneratedFile(writeSourceFile(t, tempDir, name, "package sample\n")), name)
+	}
+	for _, name := range []string{"gen.go", "generator.go", "mock.go", "store.go"} {
+		assert.False(t, isGeneratedFile(writeSourceFile(t, tempDir, name, "package sample\n")), name)
+	}
+}
+
+func TestAnalyzeCountsSkippedGeneratedFiles(t *testing.T) {
+	root := t.TempDir()
+	writeSourceFile(t, root, "handwritten.go", "package sample\n")
+	writeSourceFile(t, root, "api.pb.go", "package sample\n")
+	writeSourceFile(t, root, "stringer.go", "// Code generated by stringer. DO NOT EDIT.\npackage sample\n")
+
+	pipeline := NewPipeline(stubRegistry{}, nil, NewAggregator())
+	result, err := pipeline.Analyze(AnalysisOptions{
+		RootPath:      root,
+		SkipGenerated: true,
+		Thresholds:    config.DefaultConfig().Thresholds,
+	})
+	require.NoError(t, err)
+	assert.Len(t, result.Files, 1)
+	assert.Equal(t, 2, result.GeneratedFilesSkipped)
+
+	result, err = pipeline.Analyze(AnalysisOptions{RootPath: root, Thresholds: config.DefaultConfig().Thresholds})
+	require.NoError(t, err)
+	assert.Len(t, result.Files, 3)
+	assert.Zero(t, result.GeneratedFilesSkipped)
+}
+
+// fixtureRegistry analyzes every .go file with fixtureAnalyzer
+type fixtureRegistry struct{}
+
+func (registry fixtureRegistry) GetAnalyzerForFile(path string) (LanguageAnalyzer, error) {
+	if filepath.Ext(path) != ".go" {
+		return nil, os.ErrNotExist
+	}
+	return fixtureAnalyzer{}, nil
+}
+
+// fixtureAnalyzer reports one function per non-empty line, with the line length as its complexity
+type fixtureAnalyzer struct{ stubAnalyzer }
+
+func (fixture fixtureAnalyzer) AnalyzeFile(path string) (*models.FileAnalysis, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	analysis := &models.FileAnalysis{Path: path, Language: "Go"}
+	for index, line := range strings.Split(string(content), "\n") {
+		if line == "" {
+			continue
+		}
+		analysis.TotalLines++
+		analysis.CodeLines++
+		analysis.Functions = append(analysis.Functions, models.FunctionAnalysis{
+			Name:                 line,
+			StartLine:            index + 1,
+			EndLine:              index + 1,
+			Length:               len(line) * 4,
+			CyclomaticComplexity: len(line),
+			CognitiveComplexity:  len(line) / 2,
+			MaintainabilityIndex: 100 - float64(len(line)),
+		})
+	}
+	return analysis, nil
+}
+
+func writeRecomputeFixture(t *testing.T) string {
+	root := t.TempDir()
+	for _, moduleDir := range []string{"alpha", "beta"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, moduleDir, "internal"), 0755))
+		writeSourceFile(t, root, filepath.Join(moduleDir, "go.mod"), "module example.com/"+moduleDir+"\n")
+	}
+	writeSourceFile(t, root, "alpha/a.go", "short\na much longer function line\n")
+	writeSourceFile(t, root, "alpha/internal/b.go", "mid length line\n")
+	writeSourceFile(t, root, "beta/c.go", "tiny\nanother considerably long line here\n")
+	writeSourceFile(t, root, "beta/internal/d.go", "x\n")
+	return root
+}
+
+func analyzeFixture(t *testing.T, root string) *models.AnalysisResult {
+	pipeline := NewPipeline(fixtureRegistry{}, nil, NewAggregator())
+	result, err := pipeline.Analyze(AnalysisOptions{
+		RootPath:   root,
+		Thresholds: config.DefaultConfig().Thresholds,
+	})
+	require.NoError(t, err)
+	return result
+}
+
+func assertSameAggregates(t *testing.T, expected, actual *models.AnalysisResult) {
+	assert.Equal(t, expected.FolderStats, actual.FolderStats)
+	assert.Equal(t, expected.ModuleStats, actual.ModuleStats)
+	assert.Equal(t, expected.Summary, actual.Summary)
+	assert.Equal(t, expected.ScoreReport, actual.ScoreReport)
+}
+
+func TestRecomputeMatchesFreshAggregation(t *testing.T) {
+	fresh := analyzeFixture(t, writeRecomputeFixture(t))
+	require.Len(t, fresh.ModuleStats, 2)
+
+	stale := &models.AnalysisResult{
+		Files:       fresh.Files,
+		FolderStats: map[string]models.FolderMetrics{"stale": {}},
+		ModuleStats: fresh.ModuleStats,
+		Summary:     models.SummaryMetrics{TotalFiles: 99},
+		ScoreReport: &models.ScoreReport{OverallGrade: "F"},
+	}
+
+	NewAggregator().Recompute(stale, config.DefaultConfig().Thresholds, config.ScoringConfig{}, config.DefaultConfig().Reports)
+	assertSameAggregates(t, fresh, stale)
+}
+
+func TestRecomputeAfterDroppingFile(t *testing.T) {
+	root := writeRecomputeFixture(t)
+	result := analyzeFixture(t, root)
+
+	// Simulate an incremental merge in which beta/c.go was deleted
+	removedPath := filepath.Join("beta", "c.go")
+	var remaining []models.FileAnalysis
+	for _, file := range result.Files {
+		if file.Path != removedPath {
+			remaining = append(remaining, file)
+		}
+	}
+	require.Len(t, remaining, len(result.Files)-1)
+	result.Files = remaining
+	NewAggregator().Recompute(result, config.DefaultConfig().Thresholds, config.ScoringConfig{}, config.DefaultConfig().Reports)
+
+	require.NoError(t, os.Remove(filepath.Join(root, removedPath)))
+	assertSameAggregates(t, analyzeFixture(t, root), result)
+}
+
+func TestRecomputeConcurrently(t *testing.T) {
+	fresh := analyzeFixture(t, writeRecomputeFixture(t))
+	aggregator := NewAggregator()
+
+	results := make([]*models.AnalysisResult, 8)
+	var waitGroup sync.WaitGroup
+	for index := range results {
+		results[index] = &models.AnalysisResult{Files: fresh.Files, ModuleStats: fresh.ModuleStats}
+		waitGroup.Add(1)
+		go func(result *models.AnalysisResult) {
+			defer waitGroup.Done()
+			aggregator.Recompute(result, config.DefaultConfig().Thresholds, config.ScoringConfig{}, config.DefaultConfig().Reports)
+		}(results[index])
+	}
+	waitGroup.Wait()
+
+	for _, result := range results {
+		assertSameAggregates(t, fresh, result)
+	}
+}
+
+// hangingRegistry serves hangingAnalyzer for files named hang.go and stubAnalyzer otherwise
+type hangingRegistry struct{ release chan struct{} }
+
+func (registry hangingRegistry) GetAnalyzerForFile(path string) (LanguageAnalyzer, error) {
+	if filepath.Base(path) == "hang.go" {
+		return hangingAnalyzer{release: registry.release}, nil
+	}
+	return stubRegistry{}.GetAnalyzerForFile(path)
+}
+
+// hangingAnalyzer blocks until released, standing in for a pathological file
+type hangingAnalyzer struct {
+	stubAnalyzer
+	release chan struct{}
+}
+
+func (hanging hangingAnalyzer) AnalyzeFile(path string) (*models.FileAnalysis, error) {
+	<-hanging.release
+	return hanging.stubAnalyzer.AnalyzeFile(path)
+}
+
+func TestAnalyzeSkipsFilesExceedingTimeout(t *testing.T) {
+	root := t.TempDir()
+	writeSourceFile(t, root, "ok.go", "package ok\n")
+	writeSourceFile(t, root, "hang.go", "package hang\n")
+
+	release := make(chan struct{})
+	defer close(release)
+
+	pipeline := NewPipeline(hangingRegistry{release: release}, nil, NewAggregator())
+	result, err := pipeline.Analyze(AnalysisOptions{
+		RootPath:       root,
+		TimeoutPerFile: 50 * time.Millisecond,
+		Thresholds:     config.DefaultConfig().Thresholds,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, result.Files, 1)
+	assert.Equal(t, "ok.go", result.Files[0].Path)
+	require.Len(t, result.SkippedFiles, 1)
+	assert.Equal(t, "hang.go", result.SkippedFiles[0].Path)
+	assert.Contains(t, result.SkippedFiles[0].Reason, "timeout")
+}
+
+func TestAnalyzeWithoutTimeoutRecordsNoSkippedFiles(t *testing.T) {
+	root := t.TempDir()
+	writeSourceFile(t, root, "ok.go", "package ok\n")
+
+	pipeline := NewPipeline(stubRegistry{}, nil, NewAggregator())
+	result, err := pipeline.Analyze(AnalysisOptions{
+		RootPath:   root,
+		Thresholds: config.DefaultConfig().Thresholds,
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, result.Files, 1)
+	assert.Empty(t, result.SkippedFiles)
+}
+
+// brokenRegistry serves brokenAnalyzer for files named broken*.go and stubAnalyzer otherwise
+type brokenRegistry struct{}
+
+func (registry brokenRegistry) GetAnalyzerForFile(path string) (LanguageAnalyzer, error) {
+	if strings.HasPrefix(filepath.Base(path), "broken") {
+		return brokenAnalyzer{}, nil
+	}
+	return stubRegistry{}.GetAnalyzerForFile(path)
+}
+
+// brokenAnalyzer fails every file, standing in for source that does not parse
+type brokenAnalyzer struct{ stubAnalyzer }
+
+func (broken brokenAnalyzer) AnalyzeFile(path string) (*models.FileAnalysis, error) {
+	return nil, errors.New("failed to parse Go file: expected declaration")
+}
+
+func TestAnalyzeLenientSkipsUnparsableFiles(t *testing.T) {
+	root := t.TempDir()
+	writeSourceFile(t, root, "ok.go", "package ok\n")
+	writeSourceFile(t, root, "broken.go", "package broken\nfunc {\n")
+
+	pipeline := NewPipeline(brokenRegistry{}, nil, NewAggregator())
+	result, err := pipeline.Analyze(AnalysisOptions{
+		RootPath:   root,
+		Thresholds: config.DefaultConfig().Thresholds,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, result.Files, 1)
+	assert.Equal(t, "ok.go", result.Files[0].Path)
+}
+
+func TestAnalyzeStrictParseListsEveryFailedFile(t *testing.T) {
+	root := t.TempDir()
+	writeSourceFile(t, root, "ok.go", "package ok\n")
+	firstPath := writeSourceFile(t, root, "broken_a.go", "package broken\nfunc {\n")
+	secondPath := writeSourceFile(t, root, "broken_b.go", "package broken\nfunc {\n")
+
+	pipeline := NewPipeline(brokenRegistry{}, nil, NewAggregator())
+	result, err := pipeline.Analyze(AnalysisOptions{
+		RootPath:    root,
+		StrictParse: true,
+		Thresholds:  config.DefaultConfig().Thresholds,
+	})
+	require.Error(t, err)
+	assert.Nil(t, result)
+
+	var failuresError *ParseFailuresError
+	require.ErrorAs(t, err, &failuresError)
+	require.Len(t, failuresError.Failures, 2)
+	assert.Equal(t, firstPath, failuresError.Failures[0].Path)
+	assert.Equal(t, secondPath, failuresError.Failures[1].Path)
+	assert.Contains(t, failuresError.Failures[0].Reason, "expected declaration")
+	assert.Contains(t, err.Error(), "2 file(s) failed to parse")
+	assert.Contains(t, err.Error(), secondPath)
+}
+
+func TestAnalyzeRecordsPhaseTimings(t *testing.T) {
+	root := t.TempDir()
+	firstPath := writeSourceFile(t, root, "first.go", "package sample\n")
+	secondPath := writeSourceFile(t, root, "second.go", "package sample\n")
+
+	timings := NewPhaseTimings()
+	pipeline := NewPipeline(stubRegistry{}, nil, NewAggregator())
+	_, err := pipeline.Analyze(AnalysisOptions{
+		RootPath:   root,
+		Thresholds: config.DefaultConfig().Thresholds,
+		Timings:    timings,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, timings.Files, 2)
+	assert.Equal(t, firstPath, timings.Files[0].Path)
+	assert.Equal(t, secondPath, timings.Files[1].Path)
+	assert.Equal(t, "Go", timings.Files[0].Language)
+	for _, phase := range []string{PhaseFileWalk, PhaseFanIn, PhaseAggregation, PhaseScoring} {
+		assert.Contains(t, timings.Phases, phase)
+	}
+	assert.Equal(t, timings.Files[0].Parsing+timings.Files[1].Parsing, timings.Languages["Go"])
+}
+
+func analyzeWithTests(t *testing.T, root string, includeTests bool) *models.AnalysisResult {
+	pipeline := NewPipeline(fixtureRegistry{}, nil, NewAggregator())
+	result, err := pipeline.Analyze(AnalysisOptions{
+		RootPath:        root,
+		ExcludePatterns: []string{"vendor", "*_test.go"},
+		IncludeTests:    includeTests,
+		Thresholds:      config.DefaultConfig().Thresholds,
+	})
+	require.NoError(t, err)
+	return result
+}
+
+func TestAnalyzeIncludeTestsKeepsTestsOutOfProductionMetrics(t *testing.T) {
+	root := t.TempDir()
+	writeSourceFile(t, root, "service.go", "short\nmedium line\n")
+	writeSourceFile(t, root, "service_test.go", "a considerably longer test function line\n")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "vendor"), 0755))
+	writeSourceFile(t, root, "vendor/lib_test.go", "vendored test\n")
+
+	withoutTests := analyzeWithTests(t, root, false)
+	withTests := analyzeWithTests(t, root, true)
+
+	assert.Nil(t, withoutTests.TestStats)
+	assert.Empty(t, withoutTests.TestFiles)
+	assert.Nil(t, withoutTests.FolderStats["."].TestRatio)
+
+	// Folders gain their test ratio; every production metric stays the same
+	folder := withTests.FolderStats["."]
+	assert.Equal(t, 1, folder.TestCodeLines)
+	require.NotNil(t, folder.TestRatio)
+	assert.InDelta(t, 0.5, *folder.TestRatio, 0.001)
+	folder.TestCodeLines, folder.TestRatio = 0, nil
+	withTests.FolderStats["."] = folder
+	assertSameAggregates(t, withoutTests, withTests)
+
+	require.Len(t, withTests.TestFiles, 1, "excluded directories still apply to test files")
+	assert.Equal(t, "service_test.go", withTests.TestFiles[0].Path)
+	require.NotNil(t, withTests.TestStats)
+	assert.Equal(t, 1, withTests.TestStats.TotalFiles)
+	assert.Equal(t, 1, withTests.TestStats.TotalFunctions)
+	assert.Equal(t, 40, withTests.TestStats.MaxCyclomaticComplexity)
+}
+
+func TestRecomputeRebuildsTestStats(t *testing.T) {
+	root := t.TempDir()
+	writeSourceFile(t, root, "service.go", "short\n")
+	writeSourceFile(t, root, "service_test.go", "first test\nsecond test line\n")
+
+	result := analyzeWithTests(t, root, true)
+	expected := *result.TestStats
+	result.TestStats = nil
+
+	NewAggregator().Recompute(result, config.DefaultConfig().Thresholds, config.ScoringConfig{}, config.DefaultConfig().Reports)
+
+	require.NotNil(t, result.TestStats)
+	assert.Equal(t, expected, *result.TestStats)
+}
+
+func TestApplyStdlibCallPolicy(t *testing.T) {
+	analysis := &models.FileAnalysis{
+		Functions: []models.FunctionAnalysis{
+			{Name: "render", FanOut: 12, StdlibCalls: 5},
+			{Name: "helper", FanOut: 3},
+		},
+	}
+
+	applyStdlibCallPolicy(analysis, true)
+	assert.Equal(t, 12, analysis.Functions[0].FanOut, "counting stdlib calls keeps the analyzer's value")
+
+	applyStdlibCallPolicy(analysis, false)
+	assert.Equal(t, 7, analysis.Functions[0].FanOut)
+	assert.Equal(t, 5, analysis.Functions[0].StdlibCalls)
+	assert.Equal(t, 3, analysis.Functions[1].FanOut)
+}
+
+func TestApplyAnonymousFunctionPolicy(t *testing.T) {
+	newAnalysis := func() *models.FileAnalysis {
+		return &models.FileAnalysis{
+			Functions: []models.FunctionAnalysis{
+				{Name: "Serve"},
+				{Name: "Serve.func1", IsAnonymous: true},
+				{Name: "helper"},
+			},
+		}
+	}
+
+	counted := newAnalysis()
+	applyAnonymousFunctionPolicy(counted, true)
+	assert.Len(t, counted.Functions, 3)
+
+	excluded := newAnalysis()
+	applyAnonymousFunctionPolicy(excluded, false)
+	require.Len(t, excluded.Functions, 2)
+	assert.Equal(t, "Serve", excluded.Functions[0].Name)
+	assert.Equal(t, "helper", excluded.Functions[1].Name)
+}
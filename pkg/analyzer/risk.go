@@ -0,0 +1,47 @@
+package analyzer
+
+import (
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+// applyRiskScores sets RiskScore on every folder to the weighted average of its complexity
+// percentile, churn percentile, and uncovered line percentage:
+//
+//	risk = (complexity*ComplexityScore + churn*ChurnScore + coverage*(100-TestCoverage)) / total weight
+//
+// Churn is left out when the analysis has no churn data and coverage is left out for folders
+// without coverage data, so missing signals neither raise nor lower the score.
+func applyRiskScores(folders map[string]models.FolderMetrics, hasChurnData bool, weights config.RiskWeights) {
+	for path, folder := range folders {
+		weightedSum := weights.Complexity * folder.ComplexityScore
+		totalWeight := weights.Complexity
+
+		if hasChurnData {
+			weightedSum += weights.Churn * folder.ChurnScore
+			totalWeight += weights.Churn
+		}
+
+		if folder.TestCoverage != nil {
+			weightedSum += weights.Coverage * (100 - *folder.TestCoverage)
+			totalWeight += weights.Coverage
+		}
+
+		folder.RiskScore = 0
+		if totalWeight > 0 {
+			folder.RiskScore = clampScore(weightedSum / totalWeight)
+		}
+		folders[path] = folder
+	}
+}
+
+// clampScore limits a score to the 0-100 range
+func clampScore(score float64) float64 {
+	if score < 0 {
+		return 0
+	}
+	if score > 100 {
+		return 100
+	}
+	return score
+}
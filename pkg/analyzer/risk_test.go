@@ -0,0 +1,63 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func coveragePercent(value float64) *float64 {
+	return &value
+}
+
+func TestApplyRiskScoresBlendsAllSignals(t *testing.T) {
+	folders := map[string]models.FolderMetrics{
+		"pkg/api": {ComplexityScore: 80, ChurnScore: 60, TestCoverage: coveragePercent(30)},
+	}
+
+	applyRiskScores(folders, true, config.RiskWeights{Churn: 0.4, Complexity: 0.4, Coverage: 0.2})
+
+	// (0.4*60 + 0.4*80 + 0.2*70) / 1.0
+	assert.InDelta(t, 70.0, folders["pkg/api"].RiskScore, 0.001)
+}
+
+func TestApplyRiskScoresSkipsMissingSignals(t *testing.T) {
+	folders := map[string]models.FolderMetrics{
+		"pkg/api": {ComplexityScore: 80, ChurnScore: 100},
+	}
+
+	applyRiskScores(folders, false, config.RiskWeights{Churn: 0.4, Complexity: 0.4, Coverage: 0.2})
+
+	// Without churn or coverage data only complexity remains
+	assert.InDelta(t, 80.0, folders["pkg/api"].RiskScore, 0.001)
+}
+
+func TestApplyRiskScoresZeroWeights(t *testing.T) {
+	folders := map[string]models.FolderMetrics{
+		"pkg/api": {ComplexityScore: 80, ChurnScore: 60},
+	}
+
+	applyRiskScores(folders, true, config.RiskWeights{})
+
+	assert.Equal(t, 0.0, folders["pkg/api"].RiskScore)
+}
+
+func TestAggregateByFolderTestCoverage(t *testing.T) {
+	aggregator := NewAggregator()
+	files := []models.FileAnalysis{
+		{Path: "pkg/api/a.go", CodeLines: 300, Coverage: coveragePercent(100)},
+		{Path: "pkg/api/b.go", CodeLines: 100, Coverage: coveragePercent(20)},
+		{Path: "pkg/api/c.go", CodeLines: 500},
+		{Path: "pkg/web/d.go", CodeLines: 50},
+	}
+
+	result := aggregator.AggregateByFolder(files, "", config.DefaultConfig().Thresholds)
+
+	// Line-weighted over the files that have coverage: (300*100 + 100*20) / 400
+	require.NotNil(t, result["pkg/api"].TestCoverage)
+	assert.InDelta(t, 80.0, *result["pkg/api"].TestCoverage, 0.001)
+	assert.Nil(t, result["pkg/web"].TestCoverage)
+}
@@ -0,0 +1,105 @@
+package analyzer
+
+import (
+	"path"
+	"path/filepath"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+// testFilePatterns are the base-name patterns of test files, by language
+var testFilePatterns = map[string][]string{
+	"go":     {"*_test.go"},
+	"python": {"test_*.py", "*_test.py"},
+	"kotlin": {"*Test.kt", "*Tests.kt"},
+	"swift":  {"*Test.swift", "*Tests.swift"},
+	"objc":   {"*Test.m", "*Tests.m"},
+	"lua":    {"*_spec.lua", "*_test.lua"},
+	"dart":   {"*_test.dart"},
+}
+
+// IsTestFile reports whether path is a test file by its language's naming convention
+func IsTestFile(path string) bool {
+	baseName := filepath.Base(path)
+	for _, patterns := range testFilePatterns {
+		for _, pattern := range patterns {
+			if matched, _ := filepath.Match(pattern, baseName); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// partitionTestFiles splits analyzed files into production and test files, keeping their order
+func partitionTestFiles(files []models.FileAnalysis) (production, tests []models.FileAnalysis) {
+	production = make([]models.FileAnalysis, 0, len(files))
+	for _, file := range files {
+		if IsTestFile(file.Path) {
+			tests = append(tests, file)
+		} else {
+			production = append(production, file)
+		}
+	}
+	return production, tests
+}
+
+// applyTestRatios records each folder's test code lines and their ratio to its production code
+// lines. Test files count towards the folder they are in, so tests kept in a separate tree
+// (tests/, src/test/) leave their production folders at 0. Folders holding only tests have no
+// production metrics and are not added.
+func applyTestRatios(folders map[string]models.FolderMetrics, testFiles []models.FileAnalysis) {
+	testCodeLines := make(map[string]int)
+	for _, file := range testFiles {
+		testCodeLines[path.Dir(file.Path)] += file.CodeLines
+	}
+
+	for path, folder := range folders {
+		folder.TestCodeLines = testCodeLines[path]
+		if folder.TotalCodeLines > 0 {
+			ratio := float64(folder.TestCodeLines) / float64(folder.TotalCodeLines)
+			folder.TestRatio = &ratio
+		}
+		folders[path] = folder
+	}
+}
+
+// generateTestStats summarizes test files, or returns nil when there are none
+func generateTestStats(testFiles []models.FileAnalysis) *models.TestMetrics {
+	if len(testFiles) == 0 {
+		return nil
+	}
+
+	stats := &models.TestMetrics{}
+	totalComplexity := 0
+	totalCognitive := 0
+	totalLength := 0
+
+	for _, file := range testFiles {
+		stats.TotalFiles++
+		stats.TotalCodeLines += file.CodeLines
+
+		for _, function := range file.Functions {
+			stats.TotalFunctions++
+			totalComplexity += function.CyclomaticComplexity
+			totalCognitive += function.CognitiveComplexity
+			totalLength += function.Length
+
+			stats.MaxCyclomaticComplexity = max(stats.MaxCyclomaticComplexity, function.CyclomaticComplexity)
+			if function.CyclomaticComplexity > 10 {
+				stats.HighComplexityCount++
+			}
+			if function.Length > 50 {
+				stats.LongFunctionCount++
+			}
+		}
+	}
+
+	if stats.TotalFunctions > 0 {
+		stats.AverageCyclomaticComplexity = float64(totalComplexity) / float64(stats.TotalFunctions)
+		stats.AverageCognitiveComplexity = float64(totalCognitive) / float64(stats.TotalFunctions)
+		stats.AverageFunctionLength = float64(totalLength) / float64(stats.TotalFunctions)
+	}
+
+	return stats
+}
@@ -0,0 +1,96 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTestFile(t *testing.T) {
+	testFiles := []string{
+		"pkg/api/handler_test.go",
+		"tests/test_parser.py",
+		"app/parser_test.py",
+		"src/test/kotlin/ParserTest.kt",
+		"Tests/ParserTests.swift",
+		"Tests/ParserTests.m",
+		"spec/parser_spec.lua",
+		"test/widget_test.dart",
+	}
+	for _, path := range testFiles {
+		assert.True(t, IsTestFile(path), path)
+	}
+
+	productionFiles := []string{
+		"pkg/api/handler.go",
+		"pkg/testing/helpers.go",
+		"app/contest.py",
+		"src/main/kotlin/Parser.kt",
+		"Sources/Latest.swift",
+		"lua/inspect.lua",
+		"lib/src/latest.dart",
+	}
+	for _, path := range productionFiles {
+		assert.False(t, IsTestFile(path), path)
+	}
+}
+
+func TestGenerateTestStats(t *testing.T) {
+	testFiles := []models.FileAnalysis{
+		{
+			Path:      "a_test.go",
+			CodeLines: 80,
+			Functions: []models.FunctionAnalysis{
+				{Name: "TestSmall", CyclomaticComplexity: 2, CognitiveComplexity: 1, Length: 10},
+				{Name: "TestTable", CyclomaticComplexity: 12, CognitiveComplexity: 9, Length: 60},
+			},
+		},
+		{
+			Path:      "b_test.go",
+			CodeLines: 20,
+			Functions: []models.FunctionAnalysis{
+				{Name: "TestOther", CyclomaticComplexity: 4, CognitiveComplexity: 2, Length: 20},
+			},
+		},
+	}
+
+	stats := generateTestStats(testFiles)
+
+	require.NotNil(t, stats)
+	assert.Equal(t, 2, stats.TotalFiles)
+	assert.Equal(t, 3, stats.TotalFunctions)
+	assert.Equal(t, 100, stats.TotalCodeLines)
+	assert.InDelta(t, 6.0, stats.AverageCyclomaticComplexity, 0.001)
+	assert.InDelta(t, 4.0, stats.AverageCognitiveComplexity, 0.001)
+	assert.InDelta(t, 30.0, stats.AverageFunctionLength, 0.001)
+	assert.Equal(t, 12, stats.MaxCyclomaticComplexity)
+	assert.Equal(t, 1, stats.HighComplexityCount)
+	assert.Equal(t, 1, stats.LongFunctionCount)
+}
+
+func TestGenerateTestStatsWithoutTestFiles(t *testing.T) {
+	assert.Nil(t, generateTestStats(nil))
+}
+
+func TestApplyTestRatios(t *testing.T) {
+	folders := map[string]models.FolderMetrics{
+		"pkg/api":  {Path: "pkg/api", TotalCodeLines: 200},
+		"pkg/util": {Path: "pkg/util", TotalCodeLines: 100},
+	}
+	testFiles := []models.FileAnalysis{
+		{Path: "pkg/api/handler_test.go", CodeLines: 80},
+		{Path: "pkg/api/router_test.go", CodeLines: 20},
+		{Path: "tests/integration_test.go", CodeLines: 500},
+	}
+
+	applyTestRatios(folders, testFiles)
+
+	require.Len(t, folders, 2, "folders holding only tests are not added")
+	assert.Equal(t, 100, folders["pkg/api"].TestCodeLines)
+	require.NotNil(t, folders["pkg/api"].TestRatio)
+	assert.InDelta(t, 0.5, *folders["pkg/api"].TestRatio, 0.001)
+	require.NotNil(t, folders["pkg/util"].TestRatio)
+	assert.Equal(t, 0.0, *folders["pkg/util"].TestRatio)
+}
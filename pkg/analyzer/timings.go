@@ -0,0 +1,74 @@
+package analyzer
+
+import (
+	"sync"
+	"time"
+)
+
+// Analysis phases recorded in PhaseTimings, in the order a run goes through them
+const (
+	PhaseFileWalk    = "file walk"
+	PhaseParsing     = "parsing"
+	PhaseChurn       = "churn"
+	PhaseFanIn       = "fan-in"
+	PhaseAggregation = "aggregation"
+	PhaseScoring     = "scoring"
+)
+
+// AnalysisPhases lists every phase in run order
+var AnalysisPhases = []string{PhaseFileWalk, PhaseParsing, PhaseChurn, PhaseFanIn, PhaseAggregation, PhaseScoring}
+
+// PhaseTimings collects how long each phase of an analysis run takes. Set
+// AnalysisOptions.Timings to a value from NewPhaseTimings to have the pipeline fill it in.
+type PhaseTimings struct {
+	Phases    map[string]time.Duration // Total time per phase
+	Languages map[string]time.Duration // Parsing time per language
+	Files     []FileTiming             // Per-file timings, in analysis order
+
+	mutex sync.Mutex
+}
+
+// FileTiming is the time spent on a single file
+type FileTiming struct {
+	Path     string
+	Language string
+	Parsing  time.Duration
+	Churn    time.Duration
+}
+
+// Total returns the parsing and churn time of the file combined
+func (timing FileTiming) Total() time.Duration {
+	return timing.Parsing + timing.Churn
+}
+
+// NewPhaseTimings creates an empty set of phase timings
+func NewPhaseTimings() *PhaseTimings {
+	return &PhaseTimings{
+		Phases:    make(map[string]time.Duration),
+		Languages: make(map[string]time.Duration),
+	}
+}
+
+// record adds elapsed time to a phase; recording on nil timings does nothing
+func (timings *PhaseTimings) record(phase string, elapsed time.Duration) {
+	if timings == nil {
+		return
+	}
+	timings.mutex.Lock()
+	defer timings.mutex.Unlock()
+	timings.Phases[phase] += elapsed
+}
+
+// recordFile adds a file's timing and counts it toward the parsing and churn phases. Files
+// abandoned after a timeout may still record once they finish.
+func (timings *PhaseTimings) recordFile(timing FileTiming) {
+	if timings == nil {
+		return
+	}
+	timings.mutex.Lock()
+	defer timings.mutex.Unlock()
+	timings.Files = append(timings.Files, timing)
+	timings.Phases[PhaseParsing] += timing.Parsing
+	timings.Phases[PhaseChurn] += timing.Churn
+	timings.Languages[timing.Language] += timing.Parsing
+}
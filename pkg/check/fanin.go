@@ -62,7 +62,8 @@ func ComputeFanIn(changedFunctions []ChangedFunction, repoPath string) ([]FanInR
 // computeGoFanIn computes fan-in for Go functions using the call graph
 func computeGoFanIn(goFunctions []ChangedFunction, repoPath string) ([]FanInResult, error) {
 	analyzer := golang.NewCallGraphAnalyzer()
-	graph, err := analyzer.AnalyzeDirectory(repoPath)
+	// Every caller counts toward blast radius, so nothing is excluded
+	graph, err := analyzer.AnalyzeDirectory(repoPath, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build call graph: %w", err)
 	}
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/alexcollie/kaizen/pkg/models"
@@ -14,6 +15,11 @@ import (
 // GitChurnAnalyzer implements the ChurnAnalyzer interface using git commands
 type GitChurnAnalyzer struct {
 	repoPath string
+	gitDir   string // Explicit git directory; empty to use the repository enclosing repoPath
+
+	rootOnce sync.Once
+	repoRoot string
+	rootErr  error
 }
 
 // NewGitChurnAnalyzer creates a new git churn analyzer
@@ -23,19 +29,55 @@ func NewGitChurnAnalyzer(repoPath string) *GitChurnAnalyzer {
 	}
 }
 
+// NewGitChurnAnalyzerWithGitDir creates a git churn analyzer that reads history from gitDir,
+// for worktrees and checkouts whose .git is not in or above repoPath. Unless the repository
+// sets core.worktree, repoPath is taken as the top of its work tree, as git does.
+func NewGitChurnAnalyzerWithGitDir(repoPath string, gitDir string) *GitChurnAnalyzer {
+	if absoluteGitDir, err := filepath.Abs(gitDir); err == nil {
+		gitDir = absoluteGitDir
+	}
+	return &GitChurnAnalyzer{
+		repoPath: repoPath,
+		gitDir:   gitDir,
+	}
+}
+
+// gitCommand builds a git command run in dir, pointed at the explicit git directory when set
+func (analyzer *GitChurnAnalyzer) gitCommand(dir string, args ...string) *exec.Cmd {
+	if analyzer.gitDir != "" {
+		args = append([]string{"--git-dir=" + analyzer.gitDir}, args...)
+	}
+	command := exec.Command("git", args...)
+	command.Dir = dir
+	return command
+}
+
 // IsGitRepository checks if the path is in a git repository
 func (analyzer *GitChurnAnalyzer) IsGitRepository(repoPath string) bool {
-	command := exec.Command("git", "rev-parse", "--is-inside-work-tree")
-	command.Dir = repoPath
+	command := analyzer.gitCommand(repoPath, "rev-parse", "--is-inside-work-tree")
 	err := command.Run()
 	return err == nil
 }
 
+// RepositoryRoot returns the top of the work tree churn is read from, found once with
+// git rev-parse --show-toplevel. It fails when repoPath is not inside a git repository.
+func (analyzer *GitChurnAnalyzer) RepositoryRoot() (string, error) {
+	analyzer.rootOnce.Do(func() {
+		output, err := analyzer.gitCommand(analyzer.repoPath, "rev-parse", "--show-toplevel").Output()
+		if err != nil {
+			analyzer.rootErr = fmt.Errorf("not a git repository: %s", analyzer.repoPath)
+			return
+		}
+		analyzer.repoRoot = resolvePath(strings.TrimSpace(string(output)))
+	})
+	return analyzer.repoRoot, analyzer.rootErr
+}
+
 // GetFileChurn analyzes churn for a specific file
 func (analyzer *GitChurnAnalyzer) GetFileChurn(filePath string, since time.Time) (*models.ChurnMetric, error) {
-	// Check if we're in a git repository
-	if !analyzer.IsGitRepository(analyzer.repoPath) {
-		return nil, fmt.Errorf("not a git repository: %s", analyzer.repoPath)
+	repoRoot, err := analyzer.RepositoryRoot()
+	if err != nil {
+		return nil, err
 	}
 
 	// Make path relative to repo root
@@ -46,14 +88,13 @@ func (analyzer *GitChurnAnalyzer) GetFileChurn(filePath string, since time.Time)
 
 	// Get numstat data: lines added/deleted per commit
 	sinceStr := since.Format("2006-01-02")
-	command := exec.Command("git", "log",
+	command := analyzer.gitCommand(repoRoot, "log",
 		fmt.Sprintf("--since=%s", sinceStr),
 		"--numstat",
 		"--follow",
 		"--format=%H|%an|%ae|%ad",
 		"--date=iso",
 		"--", relPath)
-	command.Dir = analyzer.repoPath
 
 	output, err := command.Output()
 	if err != nil {
@@ -67,8 +108,9 @@ func (analyzer *GitChurnAnalyzer) GetFileChurn(filePath string, since time.Time)
 // GetFunctionChurn analyzes churn for a specific function
 // Uses git log -L to track function changes
 func (analyzer *GitChurnAnalyzer) GetFunctionChurn(filePath string, functionName string, since time.Time) (*models.ChurnMetric, error) {
-	if !analyzer.IsGitRepository(analyzer.repoPath) {
-		return nil, fmt.Errorf("not a git repository: %s", analyzer.repoPath)
+	repoRoot, err := analyzer.RepositoryRoot()
+	if err != nil {
+		return nil, err
 	}
 
 	relPath, err := analyzer.getRelativePath(filePath)
@@ -80,12 +122,11 @@ func (analyzer *GitChurnAnalyzer) GetFunctionChurn(filePath string, functionName
 
 	// git log -L :<funcname>:<file>
 	// This tracks a function by name through history
-	command := exec.Command("git", "log",
+	command := analyzer.gitCommand(repoRoot, "log",
 		fmt.Sprintf("-L:^func %s:,%s", functionName, relPath),
 		fmt.Sprintf("--since=%s", sinceStr),
 		"--format=%H|%an|%ae|%ad",
 		"--date=iso")
-	command.Dir = analyzer.repoPath
 
 	output, err := command.Output()
 	if err != nil {
@@ -96,23 +137,36 @@ func (analyzer *GitChurnAnalyzer) GetFunctionChurn(filePath string, functionName
 	return analyzer.parseFunctionLogOutput(string(output))
 }
 
-// getRelativePath converts an absolute path to a path relative to the repo root
+// getRelativePath converts a file path, absolute or relative to the working directory, to the
+// slash-separated path relative to the repository root that git log expects
 func (analyzer *GitChurnAnalyzer) getRelativePath(filePath string) (string, error) {
-	// Get git root
-	command := exec.Command("git", "rev-parse", "--show-toplevel")
-	command.Dir = analyzer.repoPath
-	output, err := command.Output()
+	repoRoot, err := analyzer.RepositoryRoot()
 	if err != nil {
 		return "", err
 	}
 
-	gitRoot := strings.TrimSpace(string(output))
-	relPath, err := filepath.Rel(gitRoot, filePath)
+	relPath, err := filepath.Rel(repoRoot, resolvePath(filePath))
 	if err != nil {
 		return "", err
 	}
+	if relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside the git repository at %s", filePath, repoRoot)
+	}
+
+	return filepath.ToSlash(relPath), nil
+}
 
-	return relPath, nil
+// resolvePath returns the absolute path with symlinks resolved, so paths compare equal to
+// those git reports (e.g. /tmp and /private/tmp on macOS); it falls back to the absolute path
+func resolvePath(path string) string {
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		return path
+	}
+	if resolvedPath, err := filepath.EvalSymlinks(absolutePath); err == nil {
+		return resolvedPath
+	}
+	return absolutePath
 }
 
 // parseNumstatOutput parses the output of git log --numstat
@@ -374,3 +374,72 @@ func TestChurnMetricFields(t *testing.T) {
 	assert.Equal(t, "Alice", metric.Contributors[0])
 	assert.False(t, metric.LastModified.IsZero())
 }
+
+// initCommittedRepository creates a git repository holding one committed file at relativePath
+func initCommittedRepository(testingT *testing.T, relativePath string) string {
+	if _, err := exec.LookPath("git"); err != nil {
+		testingT.Skip("git not available")
+	}
+
+	repoDir := testingT.TempDir()
+	filePath := filepath.Join(repoDir, relativePath)
+	require.NoError(testingT, os.MkdirAll(filepath.Dir(filePath), 0755))
+	require.NoError(testingT, os.WriteFile(filePath, []byte("package main\n"), 0644))
+
+	for _, args := range [][]string{
+		{"init"},
+		{"add", "."},
+		{"-c", "user.email=test@example.com", "-c", "user.name=Test User", "commit", "-m", "test commit"},
+	} {
+		command := exec.Command("git", args...)
+		command.Dir = repoDir
+		require.NoError(testingT, command.Run())
+	}
+	return repoDir
+}
+
+func TestGetFileChurnFromSubdirectory(t *testing.T) {
+	repoDir := initCommittedRepository(t, "pkg/api/handler.go")
+
+	analyzer := NewGitChurnAnalyzer(filepath.Join(repoDir, "pkg"))
+	metric, err := analyzer.GetFileChurn(filepath.Join(repoDir, "pkg", "api", "handler.go"), time.Now().AddDate(0, 0, -30))
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, metric.TotalCommits, "paths are made relative to the repository root, not the analyzed path")
+}
+
+func TestGetRelativePath(t *testing.T) {
+	repoDir := initCommittedRepository(t, "pkg/api/handler.go")
+	analyzer := NewGitChurnAnalyzer(filepath.Join(repoDir, "pkg"))
+
+	relPath, err := analyzer.getRelativePath(filepath.Join(repoDir, "pkg", "api", "handler.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "pkg/api/handler.go", relPath)
+
+	_, err = analyzer.getRelativePath(filepath.Join(t.TempDir(), "elsewhere.go"))
+	assert.ErrorContains(t, err, "outside the git repository")
+}
+
+func TestGetFileChurnWithGitDir(t *testing.T) {
+	repoDir := initCommittedRepository(t, "main.go")
+
+	// Move the git directory away, as for a checkout whose .git lives elsewhere
+	gitDir := filepath.Join(t.TempDir(), "repo.git")
+	require.NoError(t, os.Rename(filepath.Join(repoDir, ".git"), gitDir))
+
+	assert.False(t, NewGitChurnAnalyzer(repoDir).IsGitRepository(repoDir))
+
+	analyzer := NewGitChurnAnalyzerWithGitDir(repoDir, gitDir)
+	metric, err := analyzer.GetFileChurn(filepath.Join(repoDir, "main.go"), time.Now().AddDate(0, 0, -30))
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, metric.TotalCommits)
+}
+
+func TestRepositoryRootNotGitRepo(t *testing.T) {
+	tempDir := t.TempDir()
+
+	_, err := NewGitChurnAnalyzer(tempDir).RepositoryRoot()
+
+	assert.ErrorContains(t, err, "not a git repository")
+}
@@ -0,0 +1,78 @@
+package churn
+
+import (
+	"fmt"
+	"strings"
+)
+
+// uncommittedAuthorMail is the placeholder git blame reports for lines not yet committed
+const uncommittedAuthorMail = "not.committed.yet"
+
+// GetLineAuthors counts the surviving lines of a file attributed to each author by git blame.
+// Authors are identified by email, falling back to their name when no email is recorded.
+func (analyzer *GitChurnAnalyzer) GetLineAuthors(filePath string) (map[string]int, error) {
+	if !analyzer.IsGitRepository(analyzer.repoPath) {
+		return nil, fmt.Errorf("not a git repository: %s", analyzer.repoPath)
+	}
+
+	command := analyzer.gitCommand(analyzer.repoPath, "blame", "--line-porcelain", "-w", "--", filePath)
+
+	output, err := command.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git blame failed for %s: %w", filePath, err)
+	}
+
+	return parseBlameAuthors(string(output)), nil
+}
+
+// ListTrackedFiles returns the paths of all files tracked by git, relative to the repository path
+func (analyzer *GitChurnAnalyzer) ListTrackedFiles() ([]string, error) {
+	if !analyzer.IsGitRepository(analyzer.repoPath) {
+		return nil, fmt.Errorf("not a git repository: %s", analyzer.repoPath)
+	}
+
+	command := analyzer.gitCommand(analyzer.repoPath, "ls-files")
+
+	output, err := command.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git ls-files failed: %w", err)
+	}
+
+	var files []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+// parseBlameAuthors tallies lines per author from git blame --line-porcelain output,
+// where every source line is preceded by its full commit header
+func parseBlameAuthors(output string) map[string]int {
+	lineCounts := make(map[string]int)
+	currentName := ""
+
+	for _, line := range strings.Split(output, "\n") {
+		switch {
+		case strings.HasPrefix(line, "author "):
+			currentName = strings.TrimPrefix(line, "author ")
+
+		case strings.HasPrefix(line, "author-mail "):
+			email := strings.Trim(strings.TrimPrefix(line, "author-mail "), "<>")
+			if email == uncommittedAuthorMail {
+				continue
+			}
+
+			author := email
+			if author == "" {
+				author = currentName
+			}
+			if author != "" {
+				lineCounts[author]++
+			}
+		}
+	}
+
+	return lineCounts
+}
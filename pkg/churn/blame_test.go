@@ -0,0 +1,53 @@
+package churn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const sampleBlameOutput = `aaa111 1 1 2
+author Alice
+author-mail <alice@example.com>
+author-time 1700000000
+filename main.go
+	package main
+aaa111 2 2
+author Alice
+author-mail <alice@example.com>
+filename main.go
+	
+bbb222 3 3 1
+author Bob
+author-mail <bob@example.com>
+filename main.go
+	func main() {}
+0000000000000000000000000000000000000000 4 4 1
+author Not Committed Yet
+author-mail <not.committed.yet>
+filename main.go
+	// local edit
+ccc333 5 5 1
+author Carol
+author-mail <>
+filename main.go
+	// end
+`
+
+func TestParseBlameAuthors(t *testing.T) {
+	lineCounts := parseBlameAuthors(sampleBlameOutput)
+
+	assert.Equal(t, map[string]int{
+		"alice@example.com": 2,
+		"bob@example.com":   1,
+		"Carol":             1,
+	}, lineCounts)
+}
+
+func TestGetLineAuthorsNotGitRepo(t *testing.T) {
+	analyzer := NewGitChurnAnalyzer(t.TempDir())
+
+	_, err := analyzer.GetLineAuthors("main.go")
+
+	assert.Error(t, err)
+}
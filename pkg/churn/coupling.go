@@ -0,0 +1,273 @@
+package churn
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+// Co-change detection thresholds
+const (
+	// maxFunctionsPerCommit skips sweeping commits (renames, reformatting) that would
+	// otherwise couple every function they touch
+	maxFunctionsPerCommit = 30
+
+	ThresholdCouplingDegreeWarning  = 0.7
+	ThresholdCouplingDegreeCritical = 0.9
+	maxCouplingItems                = 5
+)
+
+// commitHeaderPrefix marks commit boundaries in the git log output
+const commitHeaderPrefix = "commit "
+
+// hunkFunctionRegex extracts a function name from the context git prints after a hunk header
+var hunkFunctionRegex = regexp.MustCompile(`\b(?:func|def|fun)\s+(?:\([^)]*\)\s*)?([A-Za-z_][A-Za-z0-9_]*)`)
+
+// functionKey identifies a function within the repository
+type functionKey struct {
+	filePath     string
+	functionName string
+}
+
+// GetCoChanges finds pairs of functions that change together in at least minSharedCommits commits
+func (analyzer *GitChurnAnalyzer) GetCoChanges(since time.Time, minSharedCommits int) ([]models.FunctionCoupling, error) {
+	if !analyzer.IsGitRepository(analyzer.repoPath) {
+		return nil, fmt.Errorf("not a git repository: %s", analyzer.repoPath)
+	}
+
+	sinceStr := since.Format("2006-01-02")
+	command := analyzer.gitCommand(analyzer.repoPath, "log",
+		fmt.Sprintf("--since=%s", sinceStr),
+		"--no-merges",
+		"--no-color",
+		"--unified=0",
+		"--format="+commitHeaderPrefix+"%H")
+
+	output, err := command.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log failed: %w", err)
+	}
+
+	commits := parseCoChangeLog(string(output))
+	return buildCouplings(commits, minSharedCommits), nil
+}
+
+// parseCoChangeLog groups the functions touched by each commit using the
+// function context git appends to hunk headers (@@ -a,b +c,d @@ func Name(...))
+func parseCoChangeLog(output string) [][]functionKey {
+	var commits [][]functionKey
+	var currentFunctions []functionKey
+	seen := make(map[functionKey]bool)
+	currentFile := ""
+	inCommit := false
+
+	flushCommit := func() {
+		if inCommit && len(currentFunctions) > 0 {
+			commits = append(commits, currentFunctions)
+		}
+		currentFunctions = nil
+		seen = make(map[functionKey]bool)
+		currentFile = ""
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		switch {
+		case strings.HasPrefix(line, commitHeaderPrefix):
+			flushCommit()
+			inCommit = true
+
+		case strings.HasPrefix(line, "diff --git a/"):
+			currentFile = ""
+			parts := strings.Fields(line)
+			if len(parts) >= 4 && strings.HasPrefix(parts[len(parts)-1], "b/") {
+				currentFile = parts[len(parts)-1][2:]
+			}
+
+		case strings.HasPrefix(line, "@@") && currentFile != "":
+			functionName := extractHunkFunction(line)
+			if functionName == "" {
+				continue
+			}
+
+			key := functionKey{filePath: currentFile, functionName: functionName}
+			if !seen[key] {
+				seen[key] = true
+				currentFunctions = append(currentFunctions, key)
+			}
+		}
+	}
+	flushCommit()
+
+	return commits
+}
+
+// extractHunkFunction returns the function name from a hunk header's trailing context
+func extractHunkFunction(hunkHeader string) string {
+	closing := strings.Index(hunkHeader[2:], "@@")
+	if closing < 0 {
+		return ""
+	}
+
+	context := hunkHeader[closing+4:]
+	match := hunkFunctionRegex.FindStringSubmatch(context)
+	if len(match) < 2 {
+		return ""
+	}
+	return match[1]
+}
+
+// buildCouplings counts shared commits for every function pair and keeps those above the support threshold
+func buildCouplings(commits [][]functionKey, minSharedCommits int) []models.FunctionCoupling {
+	type functionPair struct {
+		first  functionKey
+		second functionKey
+	}
+
+	commitCounts := make(map[functionKey]int)
+	sharedCounts := make(map[functionPair]int)
+
+	for _, functions := range commits {
+		for _, function := range functions {
+			commitCounts[function]++
+		}
+
+		if len(functions) > maxFunctionsPerCommit {
+			continue
+		}
+
+		for firstIndex := 0; firstIndex < len(functions); firstIndex++ {
+			for secondIndex := firstIndex + 1; secondIndex < len(functions); secondIndex++ {
+				first, second := functions[firstIndex], functions[secondIndex]
+				if second.filePath < first.filePath ||
+					(second.filePath == first.filePath && second.functionName < first.functionName) {
+					first, second = second, first
+				}
+				sharedCounts[functionPair{first: first, second: second}]++
+			}
+		}
+	}
+
+	var couplings []models.FunctionCoupling
+	for pair, shared := range sharedCounts {
+		if shared < minSharedCommits {
+			continue
+		}
+
+		firstCommits := commitCounts[pair.first]
+		secondCommits := commitCounts[pair.second]
+		fewestCommits := firstCommits
+		if secondCommits < fewestCommits {
+			fewestCommits = secondCommits
+		}
+
+		couplings = append(couplings, models.FunctionCoupling{
+			FirstFile:       pair.first.filePath,
+			FirstFunction:   pair.first.functionName,
+			SecondFile:      pair.second.filePath,
+			SecondFunction:  pair.second.functionName,
+			SharedCommits:   shared,
+			FirstCommits:    firstCommits,
+			SecondCommits:   secondCommits,
+			CouplingDegree:  float64(shared) / float64(fewestCommits),
+			CrossesBoundary: filepath.Dir(pair.first.filePath) != filepath.Dir(pair.second.filePath),
+		})
+	}
+
+	sort.Slice(couplings, func(i, j int) bool {
+		if couplings[i].SharedCommits != couplings[j].SharedCommits {
+			return couplings[i].SharedCommits > couplings[j].SharedCommits
+		}
+		if couplings[i].CouplingDegree != couplings[j].CouplingDegree {
+			return couplings[i].CouplingDegree > couplings[j].CouplingDegree
+		}
+		if couplings[i].FirstFile != couplings[j].FirstFile {
+			return couplings[i].FirstFile < couplings[j].FirstFile
+		}
+		return couplings[i].FirstFunction < couplings[j].FirstFunction
+	})
+
+	return couplings
+}
+
+// DetectCrossModuleCoupling flags tightly coupled function pairs that live in different modules
+func DetectCrossModuleCoupling(couplings []models.FunctionCoupling) []models.Concern {
+	var warningItems []models.AffectedItem
+	var criticalItems []models.AffectedItem
+
+	for _, coupling := range couplings {
+		if !coupling.CrossesBoundary || coupling.CouplingDegree < ThresholdCouplingDegreeWarning {
+			continue
+		}
+
+		item := models.AffectedItem{
+			FilePath:     coupling.FirstFile,
+			FunctionName: fmt.Sprintf("%s ↔ %s (%s)", coupling.FirstFunction, coupling.SecondFunction, coupling.SecondFile),
+			Metrics: map[string]float64{
+				"shared_commits":  float64(coupling.SharedCommits),
+				"coupling_degree": coupling.CouplingDegree,
+			},
+		}
+
+		if coupling.CouplingDegree >= ThresholdCouplingDegreeCritical {
+			criticalItems = append(criticalItems, item)
+		} else {
+			warningItems = append(warningItems, item)
+		}
+	}
+
+	var concerns []models.Concern
+
+	if len(criticalItems) > 0 {
+		criticalItems = limitCouplingItems(criticalItems)
+		concerns = append(concerns, models.Concern{
+			Type:          "cross_module_coupling",
+			Severity:      "critical",
+			Title:         "Tight Cross-Module Coupling",
+			Description:   buildCouplingDescription(criticalItems),
+			AffectedItems: criticalItems,
+		})
+	}
+
+	if len(warningItems) > 0 {
+		warningItems = limitCouplingItems(warningItems)
+		concerns = append(concerns, models.Concern{
+			Type:          "cross_module_coupling",
+			Severity:      "warning",
+			Title:         "Cross-Module Coupling",
+			Description:   buildCouplingDescription(warningItems),
+			AffectedItems: warningItems,
+		})
+	}
+
+	return concerns
+}
+
+// buildCouplingDescription creates a human-readable description of coupling concerns
+func buildCouplingDescription(items []models.AffectedItem) string {
+	var buffer strings.Builder
+
+	buffer.WriteString("Functions in different modules keep changing in the same commits. ")
+	buffer.WriteString("This hidden dependency suggests a leaky abstraction or misplaced responsibility.\n\n")
+
+	buffer.WriteString("Coupled functions:\n")
+	for index, item := range items {
+		buffer.WriteString(fmt.Sprintf("%d. %s in %s - %d shared commits (%.0f%% coupling)\n",
+			index+1, item.FunctionName, item.FilePath,
+			int(item.Metrics["shared_commits"]), item.Metrics["coupling_degree"]*100))
+	}
+
+	return buffer.String()
+}
+
+// limitCouplingItems keeps the most coupled items (input is already sorted by shared commits)
+func limitCouplingItems(items []models.AffectedItem) []models.AffectedItem {
+	if len(items) > maxCouplingItems {
+		return items[:maxCouplingItems]
+	}
+	return items
+}
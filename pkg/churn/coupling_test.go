@@ -0,0 +1,183 @@
+package churn
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleCoChangeLog = `commit aaa111
+diff --git a/pkg/api/handler.go b/pkg/api/handler.go
+index 111..222 100644
+--- a/pkg/api/handler.go
++++ b/pkg/api/handler.go
+@@ -10,0 +11,2 @@ func (server *Server) HandleRequest(writer http.ResponseWriter) {
++	validate()
+@@ -30 +32 @@ func (server *Server) HandleRequest(writer http.ResponseWriter) {
+-	old()
++	new()
+diff --git a/pkg/storage/store.go b/pkg/storage/store.go
+@@ -5 +5 @@ func Save(record Record) error {
+-	a
++	b
+commit bbb222
+diff --git a/pkg/api/handler.go b/pkg/api/handler.go
+@@ -12 +12 @@ func (server *Server) HandleRequest(writer http.ResponseWriter) {
+-	x
++	y
+diff --git a/pkg/storage/store.go b/pkg/storage/store.go
+@@ -6 +6 @@ func Save(record Record) error {
+-	c
++	d
+@@ -1 +1 @@
+-package storage
++package store
+commit ccc333
+diff --git a/README.md b/README.md
+@@ -1 +1 @@ Title
+-old
++new
+`
+
+func TestParseCoChangeLog(t *testing.T) {
+	commits := parseCoChangeLog(sampleCoChangeLog)
+
+	require.Len(t, commits, 2)
+	assert.Equal(t, []functionKey{
+		{filePath: "pkg/api/handler.go", functionName: "HandleRequest"},
+		{filePath: "pkg/storage/store.go", functionName: "Save"},
+	}, commits[0])
+	assert.Len(t, commits[1], 2)
+}
+
+func TestExtractHunkFunction(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected string
+	}{
+		{"@@ -1,2 +1,3 @@ func Analyze(path string) error {", "Analyze"},
+		{"@@ -1 +1 @@ func (pipeline *Pipeline) Run() {", "Run"},
+		{"@@ -4 +4 @@ def parse_config(path):", "parse_config"},
+		{"@@ -4 +4 @@ fun render(view: View) {", "render"},
+		{"@@ -4 +4 @@ import (", ""},
+		{"@@ -4 +4 @@", ""},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, extractHunkFunction(test.header), test.header)
+	}
+}
+
+func TestBuildCouplings(t *testing.T) {
+	handler := functionKey{filePath: "pkg/api/handler.go", functionName: "HandleRequest"}
+	save := functionKey{filePath: "pkg/storage/store.go", functionName: "Save"}
+	load := functionKey{filePath: "pkg/storage/store.go", functionName: "Load"}
+
+	commits := [][]functionKey{
+		{handler, save},
+		{save, handler},
+		{handler, save, load},
+		{handler},
+		{save, load},
+	}
+
+	couplings := buildCouplings(commits, 2)
+
+	require.Len(t, couplings, 2)
+	assert.Equal(t, "HandleRequest", couplings[0].FirstFunction)
+	assert.Equal(t, "Save", couplings[0].SecondFunction)
+	assert.Equal(t, 3, couplings[0].SharedCommits)
+	assert.Equal(t, 4, couplings[0].FirstCommits)
+	assert.Equal(t, 4, couplings[0].SecondCommits)
+	assert.InDelta(t, 0.75, couplings[0].CouplingDegree, 0.001)
+	assert.True(t, couplings[0].CrossesBoundary)
+
+	assert.Equal(t, "Load", couplings[1].FirstFunction)
+	assert.Equal(t, "Save", couplings[1].SecondFunction)
+	assert.InDelta(t, 1.0, couplings[1].CouplingDegree, 0.001)
+	assert.False(t, couplings[1].CrossesBoundary)
+}
+
+func TestBuildCouplingsSkipsSweepingCommits(t *testing.T) {
+	var sweeping []functionKey
+	for index := 0; index <= maxFunctionsPerCommit; index++ {
+		sweeping = append(sweeping, functionKey{filePath: "pkg/a.go", functionName: string(rune('A' + index))})
+	}
+
+	couplings := buildCouplings([][]functionKey{sweeping, sweeping}, 1)
+
+	assert.Empty(t, couplings)
+}
+
+func TestDetectCrossModuleCoupling(t *testing.T) {
+	handler := functionKey{filePath: "pkg/api/handler.go", functionName: "HandleRequest"}
+	save := functionKey{filePath: "pkg/storage/store.go", functionName: "Save"}
+	load := functionKey{filePath: "pkg/storage/store.go", functionName: "Load"}
+
+	couplings := buildCouplings([][]functionKey{
+		{handler, save},
+		{handler, save},
+		{save, load},
+		{save, load},
+	}, 2)
+
+	concerns := DetectCrossModuleCoupling(couplings)
+
+	require.Len(t, concerns, 1)
+	assert.Equal(t, "cross_module_coupling", concerns[0].Type)
+	assert.Equal(t, "critical", concerns[0].Severity)
+	require.Len(t, concerns[0].AffectedItems, 1)
+	assert.Equal(t, "pkg/api/handler.go", concerns[0].AffectedItems[0].FilePath)
+}
+
+func TestGetCoChangesNotGitRepo(t *testing.T) {
+	analyzer := NewGitChurnAnalyzer(t.TempDir())
+
+	_, err := analyzer.GetCoChanges(time.Now().AddDate(0, 0, -30), 1)
+
+	assert.Error(t, err)
+}
+
+func TestGetCoChangesInGitRepo(t *testing.T) {
+	tempDir := t.TempDir()
+
+	runGit := func(args ...string) {
+		command := exec.Command("git", append([]string{"-c", "user.email=test@example.com", "-c", "user.name=Test User"}, args...)...)
+		command.Dir = tempDir
+		output, err := command.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+
+	writeFiles := func(version string) {
+		require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "api"), 0755))
+		require.NoError(t, os.MkdirAll(filepath.Join(tempDir, "store"), 0755))
+		handler := "package api\n\nfunc Handle() {\n\tprintln(\"" + version + "\")\n}\n"
+		store := "package store\n\nfunc Save() {\n\tprintln(\"" + version + "\")\n}\n"
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, "api", "handler.go"), []byte(handler), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, "store", "store.go"), []byte(store), 0644))
+	}
+
+	runGit("init")
+	writeFiles("v1")
+	runGit("add", ".")
+	runGit("commit", "-m", "initial")
+	for _, version := range []string{"v2", "v3"} {
+		writeFiles(version)
+		runGit("commit", "-am", "change "+version)
+	}
+
+	analyzer := NewGitChurnAnalyzer(tempDir)
+	couplings, err := analyzer.GetCoChanges(time.Now().AddDate(0, 0, -1), 2)
+
+	require.NoError(t, err)
+	require.Len(t, couplings, 1)
+	assert.Equal(t, "Handle", couplings[0].FirstFunction)
+	assert.Equal(t, "Save", couplings[0].SecondFunction)
+	assert.Equal(t, 2, couplings[0].SharedCommits)
+	assert.True(t, couplings[0].CrossesBoundary)
+}
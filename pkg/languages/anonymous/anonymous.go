@@ -0,0 +1,29 @@
+// Package anonymous names the anonymous functions language analyzers report (Go function
+// literals, Python and Kotlin lambdas, Swift and Dart closures, Lua function expressions,
+// Objective-C blocks). Naming them the same way in every language keeps their results
+// comparable.
+package anonymous
+
+import "fmt"
+
+// Namer numbers the anonymous functions in one file by the named function enclosing them
+type Namer struct {
+	counts map[string]int
+}
+
+// NewNamer creates a Namer for one file
+func NewNamer() *Namer {
+	return &Namer{counts: make(map[string]int)}
+}
+
+// Name returns the name of the next anonymous function of kind (e.g. "func", "lambda") within
+// the named function enclosing, numbered from 1 in source order: "handle.lambda2" for the
+// second lambda in handle. Anonymous functions outside any named function have no prefix, as
+// in "lambda1".
+func (namer *Namer) Name(enclosing string, kind string) string {
+	namer.counts[enclosing]++
+	if enclosing == "" {
+		return fmt.Sprintf("%s%d", kind, namer.counts[enclosing])
+	}
+	return fmt.Sprintf("%s.%s%d", enclosing, kind, namer.counts[enclosing])
+}
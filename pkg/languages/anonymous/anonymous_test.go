@@ -0,0 +1,17 @@
+package anonymous
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamerNumbersByEnclosingFunction(t *testing.T) {
+	namer := NewNamer()
+
+	assert.Equal(t, "handle.lambda1", namer.Name("handle", "lambda"))
+	assert.Equal(t, "lambda1", namer.Name("", "lambda"))
+	assert.Equal(t, "handle.lambda2", namer.Name("handle", "lambda"))
+	assert.Equal(t, "serve.lambda1", namer.Name("serve", "lambda"))
+	assert.Equal(t, "lambda2", namer.Name("", "lambda"))
+}
@@ -0,0 +1,120 @@
+// Package chain measures chains of calls made on a single expression, such as
+// a.b().c().d(), by walking a function's syntax tree. Long chains reach through several
+// objects to get at the one they need (a Law of Demeter smell), so each link couples the
+// caller to another type's internals.
+package chain
+
+import (
+	"go/ast"
+	"go/token"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Language lists the tree-sitter node types that make up a call chain in one language
+type Language struct {
+	// CallTypes are the node types of a call; the callee is their first named child
+	CallTypes map[string]bool
+	// LinkTypes are the node types that continue a chain through their first named child
+	// without adding a call, e.g. member access, indexing, and parentheses
+	LinkTypes map[string]bool
+	// Skip reports subtrees that are not entered, e.g. nested functions analyzed on their own.
+	// May be nil.
+	Skip func(node *sitter.Node) bool
+}
+
+// Longest returns the number of calls in the longest chain below root and the 1-based line
+// where that chain starts; 0, 0 when root makes no calls. A plain call such as f() or
+// a.f() is a chain of one.
+func Longest(root *sitter.Node, language Language) (length, line int) {
+	if root == nil {
+		return 0, 0
+	}
+
+	for childIndex := 0; childIndex < int(root.ChildCount()); childIndex++ {
+		walkChains(root.Child(childIndex), language, &length, &line)
+	}
+	return length, line
+}
+
+// walkChains visits node and its descendants, recording the longest chain found. Parents are
+// visited first, so among chains of equal length the earliest and outermost one wins.
+func walkChains(node *sitter.Node, language Language, longest, line *int) {
+	if node == nil {
+		return
+	}
+	if language.Skip != nil && language.Skip(node) {
+		return
+	}
+
+	if language.CallTypes[node.Type()] {
+		if length := chainLength(node, language); length > *longest {
+			*longest = length
+			*line = int(node.StartPoint().Row) + 1
+		}
+	}
+
+	for childIndex := 0; childIndex < int(node.ChildCount()); childIndex++ {
+		walkChains(node.Child(childIndex), language, longest, line)
+	}
+}
+
+// chainLength counts the calls from call down through the expression it is made on
+func chainLength(call *sitter.Node, language Language) int {
+	length := 0
+	for node := call; node != nil; node = node.NamedChild(0) {
+		switch {
+		case language.CallTypes[node.Type()]:
+			length++
+		case language.LinkTypes[node.Type()]:
+		default:
+			return length
+		}
+	}
+	return length
+}
+
+// LongestAST returns the number of calls in the longest chain below root and the position
+// where that chain starts, for languages parsed with go/ast rather than tree-sitter; 0 and
+// token.NoPos when root makes no calls
+func LongestAST(root ast.Node) (length int, pos token.Pos) {
+	pos = token.NoPos
+
+	ast.Inspect(root, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if callLength := astChainLength(call); callLength > length {
+			length = callLength
+			pos = call.Pos()
+		}
+		return true
+	})
+
+	return length, pos
+}
+
+// astChainLength counts the calls from call down through the expression it is made on,
+// following selectors, indexing (including generic instantiation), and parentheses
+func astChainLength(call *ast.CallExpr) int {
+	length := 0
+	var expr ast.Expr = call
+	for {
+		switch typedExpr := expr.(type) {
+		case *ast.CallExpr:
+			length++
+			expr = typedExpr.Fun
+		case *ast.SelectorExpr:
+			expr = typedExpr.X
+		case *ast.IndexExpr:
+			expr = typedExpr.X
+		case *ast.IndexListExpr:
+			expr = typedExpr.X
+		case *ast.ParenExpr:
+			expr = typedExpr.X
+		default:
+			return length
+		}
+	}
+}
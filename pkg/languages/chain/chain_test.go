@@ -0,0 +1,109 @@
+package chain
+
+import (
+	"context"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/kotlin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var kotlinChains = Language{
+	CallTypes: map[string]bool{"call_expression": true},
+	LinkTypes: map[string]bool{
+		"navigation_expression":    true,
+		"parenthesized_expression": true,
+	},
+	Skip: func(node *sitter.Node) bool {
+		return node.Type() == "function_declaration"
+	},
+}
+
+// parseKotlinFunction returns the first function declaration in source
+func parseKotlinFunction(t *testing.T, source string) *sitter.Node {
+	parser := sitter.NewParser()
+	parser.SetLanguage(kotlin.GetLanguage())
+	tree, err := parser.ParseCtx(context.Background(), nil, []byte(source))
+	require.NoError(t, err)
+	t.Cleanup(tree.Close)
+
+	function := tree.RootNode().NamedChild(0)
+	require.Equal(t, "function_declaration", function.Type())
+	return function
+}
+
+func TestLongestNoCalls(t *testing.T) {
+	function := parseKotlinFunction(t, "fun idle(a: Int): Int {\n    return a + 1\n}\n")
+
+	length, line := Longest(function, kotlinChains)
+	assert.Equal(t, 0, length)
+	assert.Equal(t, 0, line)
+}
+
+func TestLongestChain(t *testing.T) {
+	function := parseKotlinFunction(t, `fun report(order: Order) {
+    println(order.id)
+    val city = order.customer().address().city().name()
+    log(city)
+}
+`)
+
+	length, line := Longest(function, kotlinChains)
+	assert.Equal(t, 4, length)
+	assert.Equal(t, 3, line)
+}
+
+func TestLongestChainAcrossLinesAndLambdas(t *testing.T) {
+	function := parseKotlinFunction(t, `fun names(items: List<Item>): List<String> {
+    return items
+        .filter { it.active }
+        .map { it.name }
+        .sorted()
+}
+`)
+
+	length, line := Longest(function, kotlinChains)
+	assert.Equal(t, 3, length)
+	assert.Equal(t, 2, line)
+}
+
+func TestLongestSkipsSubtrees(t *testing.T) {
+	function := parseKotlinFunction(t, `fun outer() {
+    fun local() {
+        a.b().c().d().e()
+    }
+    println(1)
+}
+`)
+
+	length, _ := Longest(function, kotlinChains)
+	assert.Equal(t, 1, length)
+}
+
+func TestLongestNilRoot(t *testing.T) {
+	length, line := Longest(nil, kotlinChains)
+	assert.Equal(t, 0, length)
+	assert.Equal(t, 0, line)
+}
+
+func TestLongestAST(t *testing.T) {
+	source := `package sample
+
+func sample(cmd *Command) {
+	println(cmd.Name)
+	cmd.Flags().Lookup("verbose").Value.Set("true")
+	(cmd.Root()).Context().Done()
+}
+`
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, "sample.go", source, 0)
+	require.NoError(t, err)
+
+	length, pos := LongestAST(file.Decls[0])
+	assert.Equal(t, 3, length)
+	assert.Equal(t, 5, fileSet.Position(pos).Line)
+}
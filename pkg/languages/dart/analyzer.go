@@ -0,0 +1,755 @@
+package dart
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alexcollie/kaizen/pkg/analyzer"
+	"github.com/alexcollie/kaizen/pkg/languages/anonymous"
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+// go-tree-sitter ships no Dart grammar, so this analyzer works on a lexical pass like the
+// Objective-C one: comments and string literals are blanked out, the rest is split into
+// tokens, and declarations are found by matching parentheses and braces. Top-level
+// declarations and the members of classes, mixins, enums and extensions are scanned for
+// functions; function bodies are scanned for local functions and closures.
+
+// typeKeywords introduce the type declarations reported as TypeAnalysis, by kind
+var typeKeywords = map[string]string{
+	"class": "class",
+	"mixin": "mixin",
+	"enum":  "enum",
+}
+
+// controlKeywords are followed by a parenthesized expression and a block, so they never name
+// a function
+var controlKeywords = map[string]bool{
+	"if": true, "for": true, "while": true, "switch": true, "catch": true, "on": true,
+	"assert": true, "return": true, "await": true, "yield": true, "throw": true,
+	"new": true, "const": true, "super": true, "this": true, "case": true, "when": true,
+}
+
+// parameterModifiers may precede a parameter's type
+var parameterModifiers = map[string]bool{
+	"required": true, "covariant": true, "final": true, "var": true, "late": true,
+}
+
+// DartAnalyzer implements the LanguageAnalyzer interface for Dart
+type DartAnalyzer struct{}
+
+// NewDartAnalyzer creates a new Dart analyzer
+func NewDartAnalyzer() analyzer.LanguageAnalyzer {
+	return &DartAnalyzer{}
+}
+
+// Name returns the language name
+func (dartAnalyzer *DartAnalyzer) Name() string {
+	return "Dart"
+}
+
+// FileExtensions returns the file extensions this analyzer handles
+func (dartAnalyzer *DartAnalyzer) FileExtensions() []string {
+	return []string{".dart"}
+}
+
+// CanAnalyze checks if this analyzer can handle the given file
+func (dartAnalyzer *DartAnalyzer) CanAnalyze(filePath string) bool {
+	ext := filepath.Ext(filePath)
+	for _, supportedExt := range dartAnalyzer.FileExtensions() {
+		if ext == supportedExt {
+			return true
+		}
+	}
+	return false
+}
+
+// IsStub indicates if this is a stub implementation
+func (dartAnalyzer *DartAnalyzer) IsStub() bool {
+	return false
+}
+
+// dartCommentSyntax drives commented-out code detection
+var dartCommentSyntax = analyzer.CommentSyntax{
+	LinePrefix: "//",
+	Keywords:   []string{"if", "else", "for", "while", "return", "switch", "case", "final", "var", "print", "import", "class"},
+}
+
+// AnalyzeFile performs full analysis on a single Dart file
+func (dartAnalyzer *DartAnalyzer) AnalyzeFile(filePath string) (*models.FileAnalysis, error) {
+	sourceBytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return dartAnalyzer.AnalyzeSource(filePath, sourceBytes)
+}
+
+// AnalyzeSource performs full analysis on in-memory Dart source reported under filePath
+func (dartAnalyzer *DartAnalyzer) AnalyzeSource(filePath string, sourceBytes []byte) (*models.FileAnalysis, error) {
+	sourceCode := string(sourceBytes)
+
+	// Count lines
+	totalLines, codeLines, commentLines, blankLines := dartAnalyzer.countLines(sourceCode)
+
+	// Calculate comment density
+	commentDensity := 0.0
+	if totalLines > 0 {
+		commentDensity = float64(commentLines) / float64(totalLines) * 100
+	}
+
+	// Count imports
+	importCount := dartAnalyzer.countImports(sourceCode)
+
+	// Blank out comments and literals so braces and keywords inside them are ignored
+	scanner := &declarationScanner{
+		tokens: tokenize(stripCommentsAndStrings(sourceCode)),
+		namer:  anonymous.NewNamer(),
+	}
+	scanner.scanDeclarations(0, len(scanner.tokens), nil)
+
+	return &models.FileAnalysis{
+		Path:                  filePath,
+		Language:              dartAnalyzer.Name(),
+		TotalLines:            totalLines,
+		CodeLines:             codeLines,
+		CommentLines:          commentLines,
+		BlankLines:            blankLines,
+		CommentDensity:        commentDensity,
+		DuplicatedLines:       0, // TODO: Implement duplication detection
+		DuplicationPercentage: 0,
+		ImportCount:           importCount,
+		Functions:             scanner.functions,
+		Types:                 scanner.types,
+		CommentedCode:         analyzer.FindCommentedCode(sourceCode, dartCommentSyntax),
+	}, nil
+}
+
+// countLines counts different types of lines in the source
+func (dartAnalyzer *DartAnalyzer) countLines(sourceCode string) (total, code, comment, blank int) {
+	lines := strings.Split(sourceCode, "\n")
+	total = len(lines)
+
+	inBlockComment := false
+
+	for _, line := range lines {
+		trimmedLine := strings.TrimSpace(line)
+
+		if !inBlockComment && strings.HasPrefix(trimmedLine, "/*") {
+			inBlockComment = true
+		}
+
+		if inBlockComment {
+			comment++
+			if strings.Contains(trimmedLine, "*/") {
+				inBlockComment = false
+			}
+		} else if trimmedLine == "" {
+			blank++
+		} else if strings.HasPrefix(trimmedLine, "//") {
+			comment++
+		} else {
+			code++
+		}
+	}
+
+	return
+}
+
+// countImports counts import and export directives
+func (dartAnalyzer *DartAnalyzer) countImports(sourceCode string) int {
+	lines := strings.Split(sourceCode, "\n")
+
+	count := 0
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "import ") || strings.HasPrefix(trimmed, "export ") {
+			count++
+		}
+	}
+	return count
+}
+
+// declarationScanner collects the functions and types of one file from its tokens
+type declarationScanner struct {
+	tokens    []token
+	namer     *anonymous.Namer
+	functions []models.FunctionAnalysis
+	types     []models.TypeAnalysis
+}
+
+// scanDeclarations walks the declarations between tokens from and to: the top level of the
+// file, or the body of the type at typeIndex in scanner.types
+func (scanner *declarationScanner) scanDeclarations(from, to int, typeIndex *int) {
+	className := ""
+	if typeIndex != nil {
+		className = scanner.types[*typeIndex].Name
+	}
+
+	declarationStart := -1
+
+	for index := from; index < to; index++ {
+		text := scanner.tokens[index].text
+
+		switch {
+		case text == ";" || text == "}":
+			declarationStart = -1
+			continue
+		case strings.HasPrefix(text, "@"):
+			// Annotations such as @override or @Deprecated('...') are not part of the declaration
+			if index+1 < to && scanner.tokens[index+1].text == "(" {
+				index = scanner.matching(index + 1)
+			}
+			continue
+		case declarationStart < 0 && (text == "import" || text == "export" || text == "part" || text == "library" || text == "typedef"):
+			index = scanner.statementEnd(index, to)
+			continue
+		}
+
+		if declarationStart < 0 {
+			declarationStart = index
+		}
+
+		switch {
+		case text == "=":
+			// Field or variable initializer: closures in it belong to no named function
+			end := scanner.statementEnd(index, to)
+			scanner.scanBody(index+1, end, "")
+			index = end
+			declarationStart = -1
+		case text == "{":
+			// A block that is not a body
+			index = scanner.matching(index)
+			declarationStart = -1
+		case text == "(" || text == "[":
+			index = scanner.matching(index)
+		case typeKeywords[text] != "" || text == "extension":
+			index = scanner.scanType(index, to)
+			declarationStart = -1
+		case text == "get" && index+2 < to && isIdentifier(scanner.tokens[index+1].text) && startsBody(scanner.tokens[index+2].text):
+			// Getter: get name { ... } or get name => ...
+			index = scanner.addFunction(scanner.tokens[index+1].text, declarationStart, index+2, 0, typeIndex)
+			declarationStart = -1
+		case text == "operator" && index+1 < to:
+			openParen := index + 1
+			for openParen < to && scanner.tokens[openParen].text != "(" {
+				openParen++
+			}
+			if openParen >= to {
+				// A declaration cut off before its parameters
+				continue
+			}
+			name := "operator " + joinTokens(scanner.tokens[index+1:openParen])
+			if end := scanner.scanFunction(name, declarationStart, openParen, to, className, typeIndex); end > index {
+				index = end
+				declarationStart = -1
+			}
+		case isIdentifier(text) && !controlKeywords[text] && index+1 < to:
+			openParen := scanner.skipTypeArguments(index+1, to)
+			if openParen >= to || scanner.tokens[openParen].text != "(" {
+				continue
+			}
+
+			name := text
+			// Named constructor: ClassName.name(...)
+			if className != "" && index >= 2 && scanner.tokens[index-1].text == "." && scanner.tokens[index-2].text == className {
+				name = className + "." + text
+			}
+			if end := scanner.scanFunction(name, declarationStart, openParen, to, className, typeIndex); end > index {
+				index = end
+				declarationStart = -1
+			}
+		}
+	}
+}
+
+// scanType records the class, mixin, enum or extension declared at index and scans its
+// members, returning the index of its closing brace
+func (scanner *declarationScanner) scanType(index, to int) int {
+	keyword := scanner.tokens[index].text
+	nameIndex := index + 1
+	// "mixin class Name" declares a class usable as a mixin
+	if keyword == "mixin" && nameIndex < to && scanner.tokens[nameIndex].text == "class" {
+		keyword = "class"
+		nameIndex++
+	}
+
+	name := ""
+	if nameIndex < to && isIdentifier(scanner.tokens[nameIndex].text) && scanner.tokens[nameIndex].text != "on" {
+		name = scanner.tokens[nameIndex].text
+	}
+
+	// Find the body, skipping the header: type parameters, extends, with, implements, on
+	bodyStart := nameIndex
+	for bodyStart < to && scanner.tokens[bodyStart].text != "{" {
+		if scanner.tokens[bodyStart].text == ";" {
+			// Mixin application: class Name = Base with Mixin;
+			if kind := typeKeywords[keyword]; kind != "" && name != "" {
+				scanner.types = append(scanner.types, models.TypeAnalysis{Name: name, Kind: kind})
+			}
+			return bodyStart
+		}
+		bodyStart++
+	}
+	if bodyStart >= to {
+		return to
+	}
+	bodyEnd := scanner.matching(bodyStart)
+
+	if kind := typeKeywords[keyword]; kind != "" && name != "" {
+		scanner.types = append(scanner.types, models.TypeAnalysis{Name: name, Kind: kind})
+		typeIndex := len(scanner.types) - 1
+		scanner.scanDeclarations(bodyStart+1, bodyEnd, &typeIndex)
+	} else {
+		// Extension members are functions of the file but no type of their own
+		scanner.scanDeclarations(bodyStart+1, bodyEnd, nil)
+	}
+
+	return bodyEnd
+}
+
+// scanFunction records the function whose parameter list opens at openParen, when a body or,
+// for constructors, an initializer list follows it. It returns the index of the function's
+// last token, or -1 when the parentheses belong to something else, such as an abstract
+// method or an enum value.
+func (scanner *declarationScanner) scanFunction(name string, declarationStart, openParen, to int, className string, typeIndex *int) int {
+	if openParen >= to || scanner.tokens[openParen].text != "(" {
+		return -1
+	}
+	closeParen := scanner.matching(openParen)
+	if closeParen >= to || scanner.tokens[closeParen].text != ")" {
+		return -1
+	}
+	parameterCount := countParameters(scanner.tokens[openParen+1 : closeParen])
+
+	bodyStart := skipAsyncModifier(scanner.tokens, closeParen+1, to)
+	if bodyStart >= to {
+		return -1
+	}
+
+	isConstructor := className != "" && (name == className || strings.HasPrefix(name, className+"."))
+	switch scanner.tokens[bodyStart].text {
+	case "{", "=>":
+		return scanner.addFunction(name, declarationStart, bodyStart, parameterCount, typeIndex)
+	case ":", ";", "=":
+		if !isConstructor {
+			return -1
+		}
+		// Constructor with an initializer list, a redirect, or no body at all
+		for bodyStart < to && !startsBody(scanner.tokens[bodyStart].text) && scanner.tokens[bodyStart].text != ";" {
+			if opener := scanner.tokens[bodyStart].text; opener == "(" || opener == "[" {
+				bodyStart = scanner.matching(bodyStart)
+			}
+			bodyStart++
+		}
+		if bodyStart < to && startsBody(scanner.tokens[bodyStart].text) {
+			return scanner.addFunction(name, declarationStart, bodyStart, parameterCount, typeIndex)
+		}
+		return scanner.addDeclaration(name, declarationStart, min(bodyStart, to-1), parameterCount, typeIndex)
+	}
+	return -1
+}
+
+// addFunction records a function whose body starts at bodyStart with "{" or "=>", then scans
+// the body for local functions and closures. It returns the index of the body's last token.
+func (scanner *declarationScanner) addFunction(name string, declarationStart, bodyStart, parameterCount int, typeIndex *int) int {
+	bodyEnd := scanner.bodyEnd(bodyStart)
+	scanner.recordFunction(name, declarationStart, bodyStart, bodyEnd, parameterCount, false, typeIndex)
+	scanner.scanBody(bodyStart+1, bodyEnd, name)
+	return bodyEnd
+}
+
+// addDeclaration records a constructor without a body, ending at the token at end
+func (scanner *declarationScanner) addDeclaration(name string, declarationStart, end, parameterCount int, typeIndex *int) int {
+	scanner.recordFunction(name, declarationStart, end, end, parameterCount, false, typeIndex)
+	return end
+}
+
+// recordFunction appends the analysis of the function spanning declarationStart to bodyEnd,
+// whose metrics come from the tokens between bodyStart and bodyEnd
+func (scanner *declarationScanner) recordFunction(name string, declarationStart, bodyStart, bodyEnd, parameterCount int, isAnonymous bool, typeIndex *int) {
+	startLine := scanner.tokens[declarationStart].line
+	endLine := scanner.tokens[bodyEnd].line
+
+	function := NewDartFunction(name, startLine, endLine, parameterCount, scanner.tokens[bodyStart:bodyEnd+1])
+	analysis := analyzeBody(function, startLine, endLine)
+	analysis.IsAnonymous = isAnonymous
+	scanner.functions = append(scanner.functions, analysis)
+
+	if typeIndex != nil {
+		typeAnalysis := &scanner.types[*typeIndex]
+		typeAnalysis.Functions = append(typeAnalysis.Functions, analysis)
+		typeAnalysis.MethodCount++
+		typeAnalysis.WeightedMethodsPerClass += analysis.CyclomaticComplexity
+	}
+}
+
+// scanBody finds the local functions and closures between tokens from and to, inside the
+// function named enclosing, and records each one together with those nested in it
+func (scanner *declarationScanner) scanBody(from, to int, enclosing string) {
+	for index := from; index < to; index++ {
+		text := scanner.tokens[index].text
+
+		// Local function: a type or statement boundary, then name(...) { or name(...) =>
+		if isIdentifier(text) && !controlKeywords[text] && index > 0 {
+			openParen := scanner.skipTypeArguments(index+1, to)
+			if openParen < to && scanner.tokens[openParen].text == "(" && startsLocalFunction(scanner.tokens[index-1].text) {
+				closeParen := scanner.matching(openParen)
+				bodyStart := skipAsyncModifier(scanner.tokens, closeParen+1, to)
+				if bodyStart < to && (scanner.tokens[bodyStart].text == "{" || (scanner.tokens[bodyStart].text == "=>" && !isStatementBoundary(scanner.tokens[index-1].text))) {
+					bodyEnd := scanner.bodyEnd(bodyStart)
+					scanner.recordFunction(text, index, bodyStart, bodyEnd, countParameters(scanner.tokens[openParen+1:closeParen]), false, nil)
+					scanner.scanBody(bodyStart+1, bodyEnd, text)
+					index = bodyEnd
+				}
+				continue
+			}
+		}
+
+		// Closure: (parameters) { ... } or (parameters) => expression, where the parentheses
+		// do not follow a name, keyword, or other expression
+		if text != "(" || (index > 0 && !startsClosure(scanner.tokens[index-1].text)) {
+			continue
+		}
+		closeParen := scanner.matching(index)
+		bodyStart := skipAsyncModifier(scanner.tokens, closeParen+1, to)
+		if closeParen >= to || bodyStart >= to || !startsBody(scanner.tokens[bodyStart].text) {
+			continue
+		}
+
+		bodyEnd := scanner.bodyEnd(bodyStart)
+		if bodyEnd > to {
+			bodyEnd = to - 1
+		}
+		name := scanner.namer.Name(enclosing, "closure")
+		scanner.recordFunction(name, index, bodyStart, bodyEnd, countParameters(scanner.tokens[index+1:closeParen]), true, nil)
+		scanner.scanBody(bodyStart+1, bodyEnd, enclosing)
+		index = bodyEnd
+	}
+}
+
+// bodyEnd returns the index of the last token of the body starting at bodyStart: the closing
+// brace of a block, or the end of the expression after "=>"
+func (scanner *declarationScanner) bodyEnd(bodyStart int) int {
+	if scanner.tokens[bodyStart].text == "{" {
+		return min(scanner.matching(bodyStart), len(scanner.tokens)-1)
+	}
+
+	// An arrow body runs to the ; ending the statement, or to the , or closing bracket ending
+	// the argument it is passed in
+	for index := bodyStart + 1; index < len(scanner.tokens); index++ {
+		switch scanner.tokens[index].text {
+		case "(", "[", "{":
+			index = scanner.matching(index)
+		case ";":
+			return index
+		case ",", ")", "]", "}":
+			return index - 1
+		}
+	}
+	return len(scanner.tokens) - 1
+}
+
+// matching returns the index of the bracket closing the one at openIndex, or the last token
+// when it is never closed or openIndex is past the end
+func (scanner *declarationScanner) matching(openIndex int) int {
+	if openIndex >= len(scanner.tokens) {
+		return len(scanner.tokens) - 1
+	}
+	opener := scanner.tokens[openIndex].text
+	closer := map[string]string{"(": ")", "[": "]", "{": "}"}[opener]
+
+	depth := 0
+	for index := openIndex; index < len(scanner.tokens); index++ {
+		switch scanner.tokens[index].text {
+		case opener:
+			depth++
+		case closer:
+			depth--
+			if depth == 0 {
+				return index
+			}
+		}
+	}
+	return len(scanner.tokens) - 1
+}
+
+// statementEnd returns the index of the ; ending the statement at index, skipping any
+// brackets in it
+func (scanner *declarationScanner) statementEnd(index, to int) int {
+	for ; index < to; index++ {
+		switch scanner.tokens[index].text {
+		case "(", "[", "{":
+			index = scanner.matching(index)
+		case ";":
+			return index
+		}
+	}
+	return to - 1
+}
+
+// skipTypeArguments returns the index after the <...> type parameters starting at index, or
+// index itself when there are none
+func (scanner *declarationScanner) skipTypeArguments(index, to int) int {
+	if index >= to || scanner.tokens[index].text != "<" {
+		return index
+	}
+	depth := 0
+	for ; index < to; index++ {
+		switch scanner.tokens[index].text {
+		case "<":
+			depth++
+		case ">":
+			depth--
+			if depth == 0 {
+				return index + 1
+			}
+		case ";", "{", "}", "=":
+			return to
+		}
+	}
+	return to
+}
+
+// skipAsyncModifier returns the index after an async, async* or sync* modifier at index
+func skipAsyncModifier(tokens []token, index, to int) int {
+	if index < to && (tokens[index].text == "async" || tokens[index].text == "sync") {
+		index++
+		if index < to && tokens[index].text == "*" {
+			index++
+		}
+	}
+	return index
+}
+
+// countParameters counts the parameters in a parameter list, named and optional ones included.
+// Initializing formals (this.name) and super parameters (super.name) only pass a value on to a
+// field or the superclass constructor, so they are not counted.
+func countParameters(parameterTokens []token) int {
+	count := 0
+	depth := 0
+	segmentStart := true
+	forwarded := false
+	empty := true
+
+	finishSegment := func() {
+		if !empty && !forwarded {
+			count++
+		}
+		segmentStart, forwarded, empty = true, false, true
+	}
+
+	for index, parameterToken := range parameterTokens {
+		text := parameterToken.text
+		switch text {
+		case "(", "<":
+			depth++
+		case ")", ">":
+			depth--
+		case "{", "}", "[", "]":
+			// Named and optional parameter groups are transparent at the top level
+			if depth == 0 {
+				continue
+			}
+		case ",":
+			if depth == 0 {
+				finishSegment()
+				continue
+			}
+		}
+
+		if depth == 0 && (text == "this" || text == "super") && index+1 < len(parameterTokens) && parameterTokens[index+1].text == "." && (segmentStart || !empty) {
+			forwarded = true
+		}
+		if !strings.HasPrefix(text, "@") && !parameterModifiers[text] {
+			segmentStart = false
+		}
+		empty = false
+	}
+	finishSegment()
+
+	return count
+}
+
+// analyzeBody builds the analysis of a function or closure from its lexical metrics
+func analyzeBody(function *DartFunction, startLine, endLine int) models.FunctionAnalysis {
+	cyclomaticComplexity := function.CalculateCyclomaticComplexity()
+	halsteadVol, halsteadDiff, halsteadEffort, halsteadTime := function.CalculateHalstead()
+
+	return models.FunctionAnalysis{
+		Name:                 function.Name(),
+		StartLine:            startLine,
+		EndLine:              endLine,
+		Length:               function.LineCount(),
+		LogicalLines:         function.LogicalLineCount(),
+		ParameterCount:       function.ParameterCount(),
+		ReturnCount:          function.ReturnCount(),
+		CyclomaticComplexity: cyclomaticComplexity,
+		CognitiveComplexity:  function.CalculateCognitiveComplexity(),
+		NestingDepth:         function.MaxNestingDepth(),
+		HalsteadVolume:       halsteadVol,
+		HalsteadDifficulty:   halsteadDiff,
+		HalsteadEffort:       halsteadEffort,
+		HalsteadTime:         halsteadTime,
+		MaintainabilityIndex: analyzer.MaintainabilityIndex(analyzer.MIVariantClassic, halsteadVol, cyclomaticComplexity, function.LineCount(), 0),
+	}
+}
+
+// startsBody reports whether a token opens a function body
+func startsBody(text string) bool {
+	return text == "{" || text == "=>"
+}
+
+// startsLocalFunction reports whether a token can precede the name of a local function
+// declaration: the end of its return type, or the boundary of the previous statement
+func startsLocalFunction(text string) bool {
+	return (isIdentifier(text) && !controlKeywords[text] && text != "else" && text != "in" && text != "is" && text != "as") ||
+		text == ">" || text == "?" || isStatementBoundary(text)
+}
+
+// isStatementBoundary reports whether a token ends a statement or opens a block
+func isStatementBoundary(text string) bool {
+	return text == ";" || text == "{" || text == "}"
+}
+
+// startsClosure reports whether parentheses after a token can be a closure's parameter list
+// rather than a call, condition, or grouping after an operand
+func startsClosure(text string) bool {
+	switch text {
+	case "(", "[", "{", ",", "=", ":", "=>", "??", "?", "return", "await", ";", "&&", "||":
+		return true
+	}
+	return false
+}
+
+// isIdentifier reports whether a token is a name or keyword
+func isIdentifier(text string) bool {
+	return text != "" && isIdentifierStart(text[0])
+}
+
+// joinTokens concatenates token texts, e.g. the symbol of a user-defined operator
+func joinTokens(tokens []token) string {
+	var builder strings.Builder
+	for _, operatorToken := range tokens {
+		builder.WriteString(operatorToken.text)
+	}
+	return builder.String()
+}
+
+// stripCommentsAndStrings replaces comments and the contents of string literals with spaces,
+// preserving newlines so offsets and line numbers still match the original source.
+// Interpolations are blanked with the string they are in.
+func stripCommentsAndStrings(sourceCode string) string {
+	stripped := []byte(sourceCode)
+
+	blank := func(from, to int) {
+		for index := from; index < to && index < len(stripped); index++ {
+			if stripped[index] != '\n' {
+				stripped[index] = ' '
+			}
+		}
+	}
+
+	for offset := 0; offset < len(sourceCode); offset++ {
+		char := sourceCode[offset]
+		rest := sourceCode[offset:]
+
+		switch {
+		case strings.HasPrefix(rest, "//"):
+			end := lineEnd(sourceCode, offset)
+			blank(offset, end)
+			offset = end - 1
+		case strings.HasPrefix(rest, "/*"):
+			end := blockCommentEnd(sourceCode, offset)
+			blank(offset, end+1)
+			offset = end
+		case char == '"' || char == '\'':
+			// A raw string (r'...') has no escapes or interpolation
+			raw := offset > 0 && sourceCode[offset-1] == 'r' && (offset < 2 || !isIdentifierPart(sourceCode[offset-2]))
+			if raw {
+				blank(offset-1, offset)
+			}
+			end := stringEnd(sourceCode, offset, raw)
+			blank(offset+1, end)
+			offset = end
+		}
+	}
+
+	return string(stripped)
+}
+
+// blockCommentEnd returns the offset of the / closing the block comment at offset. Dart block
+// comments nest.
+func blockCommentEnd(source string, offset int) int {
+	depth := 0
+	for ; offset < len(source)-1; offset++ {
+		switch source[offset : offset+2] {
+		case "/*":
+			depth++
+			offset++
+		case "*/":
+			depth--
+			offset++
+			if depth == 0 {
+				return offset
+			}
+		}
+	}
+	return len(source) - 1
+}
+
+// stringEnd returns the offset of the last quote of the string literal opening at offset, or
+// of the newline ending an unterminated single-line string
+func stringEnd(source string, offset int, raw bool) int {
+	delimiter := source[offset : offset+1]
+	if strings.HasPrefix(source[offset:], strings.Repeat(delimiter, 3)) {
+		delimiter = strings.Repeat(delimiter, 3)
+	}
+	multiline := len(delimiter) == 3
+
+	for index := offset + len(delimiter); index < len(source); index++ {
+		switch {
+		case strings.HasPrefix(source[index:], delimiter):
+			return index + len(delimiter) - 1
+		case source[index] == '\n' && !multiline:
+			return index
+		case raw:
+			continue
+		case source[index] == '\\':
+			index++
+		case strings.HasPrefix(source[index:], "${"):
+			index = interpolationEnd(source, index+2)
+		}
+	}
+	return len(source) - 1
+}
+
+// interpolationEnd returns the offset of the } closing the ${ interpolation whose expression
+// starts at offset, skipping strings nested in it
+func interpolationEnd(source string, offset int) int {
+	depth := 1
+	for ; offset < len(source); offset++ {
+		switch source[offset] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return offset
+			}
+		case '"', '\'':
+			offset = stringEnd(source, offset, false)
+		}
+	}
+	return len(source) - 1
+}
+
+// lineEnd returns the offset of the newline ending the line at offset, or the source length
+func lineEnd(source string, offset int) int {
+	end := strings.IndexByte(source[offset:], '\n')
+	if end < 0 {
+		return len(source)
+	}
+	return offset + end
+}
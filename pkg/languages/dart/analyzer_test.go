@@ -0,0 +1,277 @@
+package dart
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexcollie/kaizen/pkg/analyzer"
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+const sampleDartSource = `import 'package:flutter/material.dart';
+import 'dart:async' as async;
+export 'src/counter.dart';
+
+/* A counter widget. /* Nested comments { are skipped */ too. */
+@immutable
+class Counter extends StatelessWidget {
+  final int start;
+  final String? label;
+
+  const Counter(this.start, {super.key, this.label});
+
+  Counter.zero({Key? key}) : this(0, key: key);
+
+  factory Counter.fromJson(Map<String, dynamic> json) = _Counter;
+
+  @override
+  Widget build(BuildContext context) => Text(label ?? '$start');
+
+  int get doubled => start * 2;
+
+  void update(int a, [int? b], {required String c, bool d = false}) {
+    if (a > 0 && b != null) {
+      print('if { ${a > 1 ? "}" : 'x'} for');
+    } else if (b == null || d) {
+      for (var i = 0; i < a; i++) {}
+    }
+    try {
+      risky();
+    } on FormatException catch (error) {
+      log(error);
+    } catch (error) {
+      log(error);
+    }
+    switch (a) {
+      case 1:
+        return;
+      case 2:
+        return;
+    }
+    final value = d ? 1 : 2;
+  }
+
+  void reset();
+}
+
+mixin Logging {
+  void log(String message) {}
+}
+
+enum Status {
+  active(1), inactive(0);
+
+  const Status(this.code);
+  final int code;
+}
+
+int add(int a, int b) => a + b;
+`
+
+func analyzeSample(testingT *testing.T, source string) *models.FileAnalysis {
+	testFile := filepath.Join(testingT.TempDir(), "counter.dart")
+	require.NoError(testingT, os.WriteFile(testFile, []byte(source), 0644))
+
+	result, err := NewDartAnalyzer().AnalyzeFile(testFile)
+	require.NoError(testingT, err)
+	return result
+}
+
+func functionNames(functions []models.FunctionAnalysis) []string {
+	names := make([]string, len(functions))
+	for index, function := range functions {
+		names[index] = function.Name
+	}
+	return names
+}
+
+func TestCanAnalyze(t *testing.T) {
+	analyzer := NewDartAnalyzer()
+
+	assert.Equal(t, "Dart", analyzer.Name())
+	assert.False(t, analyzer.IsStub())
+	assert.True(t, analyzer.CanAnalyze("lib/main.dart"))
+	assert.False(t, analyzer.CanAnalyze("main.kt"))
+	assert.False(t, analyzer.CanAnalyze("pubspec.yaml"))
+}
+
+func TestAnalyzeFileFunctions(t *testing.T) {
+	result := analyzeSample(t, sampleDartSource)
+
+	assert.Equal(t, "Dart", result.Language)
+	assert.Equal(t, 3, result.ImportCount)
+	assert.Equal(t, []string{
+		"Counter", "Counter.zero", "Counter.fromJson", "build", "doubled", "update",
+		"log", "Status", "add",
+	}, functionNames(result.Functions), "abstract methods and enum values are not functions")
+
+	constructor := result.Functions[0]
+	assert.Equal(t, 11, constructor.StartLine)
+	assert.Equal(t, 0, constructor.ParameterCount, "this. and super. parameters are not counted")
+
+	assert.Equal(t, 1, result.Functions[1].ParameterCount)
+	assert.Equal(t, 1, result.Functions[2].ParameterCount)
+
+	build := result.Functions[3]
+	assert.Equal(t, 18, build.StartLine, "annotations are not part of the declaration")
+	assert.Equal(t, 18, build.EndLine)
+	assert.Equal(t, 2, build.CyclomaticComplexity) // ??
+
+	update := result.Functions[5]
+	assert.Equal(t, 22, update.StartLine)
+	assert.Equal(t, 42, update.EndLine)
+	assert.Equal(t, 4, update.ParameterCount, "positional, optional and named parameters")
+	// if, &&, else if, ||, for, on/catch, catch, 2 cases, ?: (the ?: and braces inside the
+	// string literal and its interpolation are ignored)
+	assert.Equal(t, 11, update.CyclomaticComplexity)
+	assert.Equal(t, 2, update.NestingDepth)
+	assert.Equal(t, 2, update.ReturnCount)
+	assert.Greater(t, update.MaintainabilityIndex, 0.0)
+
+	add := result.Functions[8]
+	assert.Equal(t, 2, add.ParameterCount)
+	assert.Equal(t, 1, add.CyclomaticComplexity)
+}
+
+func TestAnalyzeFileTypes(t *testing.T) {
+	result := analyzeSample(t, sampleDartSource)
+
+	require.Len(t, result.Types, 3)
+	assert.Equal(t, "Counter", result.Types[0].Name)
+	assert.Equal(t, "class", result.Types[0].Kind)
+	assert.Equal(t, 6, result.Types[0].MethodCount)
+	assert.Equal(t, 17, result.Types[0].WeightedMethodsPerClass)
+	assert.Equal(t, "Logging", result.Types[1].Name)
+	assert.Equal(t, "mixin", result.Types[1].Kind)
+	assert.Equal(t, 1, result.Types[1].MethodCount)
+	assert.Equal(t, "Status", result.Types[2].Name)
+	assert.Equal(t, "enum", result.Types[2].Kind)
+	assert.Equal(t, 1, result.Types[2].MethodCount)
+}
+
+func TestCountParameters(t *testing.T) {
+	tests := []struct {
+		parameters string
+		count      int
+	}{
+		{"", 0},
+		{"int a, String b", 2},
+		{"int a, [int? b, int c = 0]", 3},
+		{"{required String name, int? age,}", 2},
+		{"this.x, {super.key, required this.y, int z = 1}", 1},
+		{"void Function(int a, int b) callback, Map<String, int> counts", 2},
+		{"@required String name", 1},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.count, countParameters(tokenize(tt.parameters)), "parameters %q", tt.parameters)
+	}
+}
+
+func TestCognitiveComplexityNesting(t *testing.T) {
+	body := `{
+    if (a) {
+        for (final item in items) {
+            if (b && c && d) {
+            }
+        }
+    } else if (e) {
+    } else {
+    }
+    do {
+    } while (f);
+}`
+	function := NewDartFunction("sample", 1, 12, 0, tokenize(body))
+
+	// if(1) + for(2) + if(3) + && sequence(1) + else if(1) + else(1) + do(1)
+	assert.Equal(t, 10, function.CalculateCognitiveComplexity())
+	assert.Equal(t, 3, function.MaxNestingDepth())
+}
+
+const closureSource = `class CounterPage extends StatelessWidget {
+  Widget build(BuildContext context) {
+    items.forEach((item) {
+      if (item.done) print(item);
+    });
+    final sorted = items.where((item) => item.visible).toList();
+    int twice(int x) => x * 2;
+    return ElevatedButton(onPressed: () async {
+      await save();
+    });
+  }
+}
+
+final formatter = (int value) => '$value';
+`
+
+func TestClosuresAreAnonymousFunctions(t *testing.T) {
+	result, err := analyzer.AnalyzeSource(NewDartAnalyzer(), "counter_page.dart", []byte(closureSource), analyzer.AnalysisOptions{CountAnonymousFunctions: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"build", "build.closure1", "build.closure2", "twice", "build.closure3", "closure1",
+	}, functionNames(result.Functions))
+
+	forEachClosure := result.Functions[1]
+	assert.True(t, forEachClosure.IsAnonymous)
+	assert.Equal(t, 3, forEachClosure.StartLine)
+	assert.Equal(t, 5, forEachClosure.EndLine)
+	assert.Equal(t, 1, forEachClosure.ParameterCount)
+	assert.Equal(t, 2, forEachClosure.CyclomaticComplexity)
+
+	assert.False(t, result.Functions[3].IsAnonymous, "local functions are named")
+	assert.Equal(t, 0, result.Functions[4].ParameterCount)
+	assert.Equal(t, 8, result.Functions[4].StartLine)
+
+	require.Len(t, result.Types, 1)
+	assert.Equal(t, 1, result.Types[0].MethodCount, "closures are not methods")
+}
+
+func TestClosuresDroppedWithoutCountAnonymousFunctions(t *testing.T) {
+	result, err := analyzer.AnalyzeSource(NewDartAnalyzer(), "counter_page.dart", []byte(closureSource), analyzer.AnalysisOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"build", "twice"}, functionNames(result.Functions))
+}
+
+func TestStripCommentsAndStrings(t *testing.T) {
+	source := "var a = 'it\\'s { here'; // if {\nvar b = r'\\' + \"\"\"\nfor {\"\"\";\n"
+	stripped := stripCommentsAndStrings(source)
+
+	assert.Len(t, stripped, len(source))
+	assert.NotContains(t, stripped, "{")
+	assert.NotContains(t, stripped, "for")
+	assert.NotContains(t, stripped, "if")
+	assert.Contains(t, stripped, "+", "a raw string ends at its first quote")
+	assert.Equal(t, 3, strings.Count(stripped, "\n"))
+}
+
+func TestTruncatedOperatorDeclaration(t *testing.T) {
+	result := analyzeSample(t, "class A {}\nbool operator ==")
+
+	assert.Empty(t, result.Functions)
+	require.Len(t, result.Types, 1)
+	assert.Equal(t, "A", result.Types[0].Name)
+}
+
+func TestUnbalancedSourceDoesNotPanic(t *testing.T) {
+	// Every prefix of a file leaves brackets, strings or comments unclosed somewhere
+	for end := range sampleDartSource {
+		source := sampleDartSource[:end]
+		assert.NotPanics(t, func() {
+			_, err := NewDartAnalyzer().AnalyzeSource("counter.dart", []byte(source))
+			assert.NoError(t, err)
+		}, "prefix of %d bytes", end)
+	}
+	for _, source := range []string{"}}}", "))(", "void f(int a", "class A { void f() {", "get x =>", "operator", "@Foo("} {
+		assert.NotPanics(t, func() {
+			_, err := NewDartAnalyzer().AnalyzeSource("counter.dart", []byte(source))
+			assert.NoError(t, err)
+		}, "source %q", source)
+	}
+}
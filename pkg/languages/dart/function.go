@@ -0,0 +1,370 @@
+package dart
+
+import (
+	"math"
+	"strings"
+)
+
+// DartFunction holds the source of a single Dart function, method, constructor, or closure
+// for metric calculations
+type DartFunction struct {
+	name           string
+	startLine      int
+	endLine        int
+	parameterCount int
+	tokens         []token // Tokens of the body, from stripped source
+}
+
+// NewDartFunction creates a new DartFunction from the tokens of its body
+func NewDartFunction(name string, startLine, endLine, parameterCount int, bodyTokens []token) *DartFunction {
+	return &DartFunction{
+		name:           name,
+		startLine:      startLine,
+		endLine:        endLine,
+		parameterCount: parameterCount,
+		tokens:         bodyTokens,
+	}
+}
+
+// Name returns the function name
+func (dartFunction *DartFunction) Name() string {
+	return dartFunction.name
+}
+
+// LineCount returns the total lines (including blank/comments)
+func (dartFunction *DartFunction) LineCount() int {
+	return dartFunction.endLine - dartFunction.startLine + 1
+}
+
+// LogicalLineCount returns the number of lines containing code other than lone braces
+func (dartFunction *DartFunction) LogicalLineCount() int {
+	lines := make(map[int]bool)
+	for _, bodyToken := range dartFunction.tokens {
+		if bodyToken.text != "{" && bodyToken.text != "}" {
+			lines[bodyToken.line] = true
+		}
+	}
+	return len(lines)
+}
+
+// ParameterCount returns the number of declared parameters
+func (dartFunction *DartFunction) ParameterCount() int {
+	return dartFunction.parameterCount
+}
+
+// ReturnCount returns the number of return statements
+func (dartFunction *DartFunction) ReturnCount() int {
+	count := 0
+	for _, bodyToken := range dartFunction.tokens {
+		if bodyToken.text == "return" {
+			count++
+		}
+	}
+	return count
+}
+
+// CalculateCyclomaticComplexity calculates McCabe's cyclomatic complexity. Each if, for,
+// while, case, catch clause, &&, ||, ?? and ?: adds a path; else adds none of its own, and an
+// else if counts through its if.
+func (dartFunction *DartFunction) CalculateCyclomaticComplexity() int {
+	complexity := 1
+
+	for index, bodyToken := range dartFunction.tokens {
+		switch bodyToken.text {
+		case "if", "for", "while", "case", "&&", "||", "??", "??=", "?":
+			complexity++
+		case "on", "catch":
+			if isCatchClause(dartFunction.tokens, index) {
+				complexity++
+			}
+		}
+	}
+
+	return complexity
+}
+
+// isCatchClause reports whether the on or catch at index starts a clause of a try statement.
+// "on Type catch (error)" is one clause, counted at its on.
+func isCatchClause(tokens []token, index int) bool {
+	if index == 0 || tokens[index-1].text != "}" {
+		if tokens[index].text != "catch" {
+			return false
+		}
+		// catch after "on Type" belongs to the on clause
+		for previous := index - 1; previous >= 0; previous-- {
+			switch tokens[previous].text {
+			case "on":
+				return false
+			case "}", ";", "{":
+				return true
+			}
+		}
+	}
+	return true
+}
+
+// CalculateCognitiveComplexity calculates cognitive complexity, penalising
+// control structures by how deeply they are nested
+func (dartFunction *DartFunction) CalculateCognitiveComplexity() int {
+	complexity := 0
+	dartFunction.walkControlFlow(func(keyword string, nesting int, afterElse bool) {
+		switch keyword {
+		case "if":
+			if afterElse {
+				complexity++
+			} else {
+				complexity += 1 + nesting
+			}
+		case "else", "&&", "||":
+			complexity++
+		default:
+			complexity += 1 + nesting
+		}
+	})
+	return complexity
+}
+
+// MaxNestingDepth returns the deepest nesting of control structures
+func (dartFunction *DartFunction) MaxNestingDepth() int {
+	maxDepth := 0
+	dartFunction.walkControlFlow(func(keyword string, nesting int, afterElse bool) {
+		switch keyword {
+		case "&&", "||", "?", "??", "else":
+			return
+		}
+		if afterElse {
+			return
+		}
+		if nesting+1 > maxDepth {
+			maxDepth = nesting + 1
+		}
+	})
+	return maxDepth
+}
+
+// walkControlFlow visits each control structure in the body along with the number
+// of enclosing control blocks. "else if" is reported as a single "if" with afterElse
+// set, and sequences of the same boolean operator are reported once.
+func (dartFunction *DartFunction) walkControlFlow(visit func(keyword string, nesting int, afterElse bool)) {
+	type braceFrame struct {
+		isControl bool
+		isDo      bool
+	}
+
+	var braceStack []braceFrame
+	nesting := 0
+	pendingBlock := false
+	pendingDo := false
+	closedDoBlock := false
+	parenDepth := 0
+	lastLogicalOperator := ""
+
+	tokens := dartFunction.tokens
+	// Skip the function's own braces
+	if len(tokens) >= 2 && tokens[0].text == "{" {
+		tokens = tokens[1 : len(tokens)-1]
+	}
+
+	for index, bodyToken := range tokens {
+		previousText := ""
+		if index > 0 {
+			previousText = tokens[index-1].text
+		}
+
+		switch bodyToken.text {
+		case "if":
+			visit(bodyToken.text, nesting, previousText == "else")
+			pendingBlock = true
+		case "for", "switch":
+			visit(bodyToken.text, nesting, false)
+			pendingBlock = true
+		case "on", "catch":
+			if isCatchClause(tokens, index) {
+				visit("catch", nesting, false)
+			}
+			pendingBlock = true
+		case "do":
+			visit(bodyToken.text, nesting, false)
+			pendingBlock = true
+			pendingDo = true
+		case "while":
+			// The trailing while of a do/while loop was already counted at "do"
+			if !closedDoBlock {
+				visit(bodyToken.text, nesting, false)
+				pendingBlock = true
+			}
+		case "else":
+			if index+1 >= len(tokens) || tokens[index+1].text != "if" {
+				visit(bodyToken.text, nesting, false)
+			}
+			pendingBlock = true
+		case "?", "??", "??=":
+			visit("?", nesting, false)
+		case "&&", "||":
+			if bodyToken.text != lastLogicalOperator {
+				visit(bodyToken.text, nesting, false)
+			}
+			lastLogicalOperator = bodyToken.text
+		case "(":
+			parenDepth++
+		case ")":
+			if parenDepth > 0 {
+				parenDepth--
+			}
+		case ";":
+			lastLogicalOperator = ""
+			if parenDepth == 0 {
+				pendingBlock = false
+			}
+		case "{":
+			lastLogicalOperator = ""
+			braceStack = append(braceStack, braceFrame{isControl: pendingBlock, isDo: pendingDo})
+			if pendingBlock {
+				nesting++
+			}
+			pendingBlock = false
+			pendingDo = false
+		}
+
+		closedDoBlock = false
+		if bodyToken.text == "}" {
+			lastLogicalOperator = ""
+			if len(braceStack) > 0 {
+				frame := braceStack[len(braceStack)-1]
+				braceStack = braceStack[:len(braceStack)-1]
+				if frame.isControl {
+					nesting--
+				}
+				closedDoBlock = frame.isDo
+			}
+		}
+	}
+}
+
+// CalculateHalstead calculates Halstead volume and difficulty for the function body
+func (dartFunction *DartFunction) CalculateHalstead() (volume, difficulty, effort, timeToUnderstand float64) {
+	operators := make(map[string]bool)
+	operands := make(map[string]bool)
+	totalOperators := 0
+	totalOperands := 0
+
+	for _, bodyToken := range dartFunction.tokens {
+		if isIdentifierStart(bodyToken.text[0]) || isDigit(bodyToken.text[0]) {
+			operands[bodyToken.text] = true
+			totalOperands++
+		} else {
+			operators[bodyToken.text] = true
+			totalOperators++
+		}
+	}
+
+	distinctOperators := len(operators)
+	distinctOperands := len(operands)
+
+	if distinctOperators == 0 || distinctOperands == 0 {
+		return 0, 0, 0, 0
+	}
+
+	// Halstead Volume = (N1 + N2) * log2(n1 + n2)
+	vocab := float64(distinctOperators + distinctOperands)
+	length := float64(totalOperators + totalOperands)
+	volume = length * math.Log2(vocab)
+
+	// Halstead Difficulty = (n1/2) * (N2/n2)
+	difficulty = (float64(distinctOperators) / 2.0) * (float64(totalOperands) / float64(distinctOperands))
+
+	// Effort = Volume * Difficulty
+	effort = volume * difficulty
+
+	// Time to understand in seconds = Effort / 18
+	timeToUnderstand = effort / 18.0
+
+	return volume, difficulty, effort, timeToUnderstand
+}
+
+// token is a lexical token of stripped source with the line it starts on
+type token struct {
+	text string
+	line int
+}
+
+// multiCharOperators are the operators tokenized as one token, longest first
+var multiCharOperators = []string{"??=", "?..", "...", "&&", "||", "??", "?.", "=>", "==", "!=", "<=", ">=", ".."}
+
+// tokenize splits stripped source into identifiers, numbers, @annotations, the operators in
+// multiCharOperators and single punctuation characters. The ? of a nullable type (String?)
+// is dropped so that only conditional expressions remain.
+func tokenize(strippedSource string) []token {
+	var tokens []token
+	line := 1
+
+	for offset := 0; offset < len(strippedSource); offset++ {
+		char := strippedSource[offset]
+
+		switch {
+		case char == '\n':
+			line++
+			continue
+		case char == ' ' || char == '\t' || char == '\r':
+			continue
+		case isIdentifierStart(char) || (char == '@' && offset+1 < len(strippedSource) && isIdentifierStart(strippedSource[offset+1])):
+			end := offset + 1
+			for end < len(strippedSource) && isIdentifierPart(strippedSource[end]) {
+				end++
+			}
+			tokens = append(tokens, token{text: strippedSource[offset:end], line: line})
+			offset = end - 1
+			continue
+		case isDigit(char):
+			end := offset + 1
+			for end < len(strippedSource) && (isIdentifierPart(strippedSource[end]) || (strippedSource[end] == '.' && end+1 < len(strippedSource) && isDigit(strippedSource[end+1]))) {
+				end++
+			}
+			tokens = append(tokens, token{text: strippedSource[offset:end], line: line})
+			offset = end - 1
+			continue
+		case char == '?' && isNullableMarker(strippedSource, offset):
+			continue
+		}
+
+		operator := string(char)
+		for _, candidate := range multiCharOperators {
+			if strings.HasPrefix(strippedSource[offset:], candidate) {
+				operator = candidate
+				break
+			}
+		}
+		tokens = append(tokens, token{text: operator, line: line})
+		offset += len(operator) - 1
+	}
+
+	return tokens
+}
+
+// isNullableMarker reports whether the ? at offset follows a type (String?, List<int>?) rather
+// than a condition
+func isNullableMarker(source string, offset int) bool {
+	if offset == 0 || offset+1 >= len(source) {
+		return false
+	}
+	previous := source[offset-1]
+	if !isIdentifierPart(previous) && previous != '>' && previous != ')' && previous != ']' {
+		return false
+	}
+	return strings.IndexByte(" \t\r\n,)>;=]}", source[offset+1]) >= 0
+}
+
+// isIdentifierStart checks if a character can begin an identifier
+func isIdentifierStart(char byte) bool {
+	return (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z') || char == '_' || char == '$'
+}
+
+// isIdentifierPart checks if a character can continue an identifier
+func isIdentifierPart(char byte) bool {
+	return isIdentifierStart(char) || isDigit(char)
+}
+
+// isDigit checks if a character is a decimal digit
+func isDigit(char byte) bool {
+	return char >= '0' && char <= '9'
+}
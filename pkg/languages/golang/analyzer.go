@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/alexcollie/kaizen/pkg/analyzer"
+	"github.com/alexcollie/kaizen/pkg/languages/anonymous"
 	"github.com/alexcollie/kaizen/pkg/models"
 )
 
@@ -47,13 +48,23 @@ func (goAnalyzer *GoAnalyzer) IsStub() bool {
 	return false // Go analyzer is fully implemented
 }
 
+// goCommentSyntax drives commented-out code detection
+var goCommentSyntax = analyzer.CommentSyntax{
+	LinePrefix: "//",
+	Keywords:   []string{"func", "if", "else", "for", "return", "var", "const", "type", "switch", "case", "defer", "go", "import", "package"},
+}
+
 // AnalyzeFile performs full analysis on a single Go file
 func (goAnalyzer *GoAnalyzer) AnalyzeFile(filePath string) (*models.FileAnalysis, error) {
-	// Read source code
 	sourceBytes, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
+	return goAnalyzer.AnalyzeSource(filePath, sourceBytes)
+}
+
+// AnalyzeSource performs full analysis on in-memory Go source reported under filePath
+func (goAnalyzer *GoAnalyzer) AnalyzeSource(filePath string, sourceBytes []byte) (*models.FileAnalysis, error) {
 	sourceCode := string(sourceBytes)
 
 	// Parse the file
@@ -94,6 +105,7 @@ func (goAnalyzer *GoAnalyzer) AnalyzeFile(filePath string) (*models.FileAnalysis
 		ImportCount:           importCount,
 		Functions:             functions,
 		Types:                 types,
+		CommentedCode:         analyzer.FindCommentedCode(sourceCode, goCommentSyntax),
 	}, nil
 }
 
@@ -138,62 +150,99 @@ func (goAnalyzer *GoAnalyzer) countLines(sourceCode string, astFile *ast.File) (
 	return
 }
 
-// extractFunctions extracts and analyzes all functions in the file
+// extractFunctions extracts and analyzes all functions in the file. Function literals are
+// reported as anonymous functions named after the declaration enclosing them.
 func (goAnalyzer *GoAnalyzer) extractFunctions(astFile *ast.File, fileSet *token.FileSet, sourceCode string) []models.FunctionAnalysis {
 	var functions []models.FunctionAnalysis
+	stdlibPackages := stdlibImportNames(astFile)
+	namer := anonymous.NewNamer()
+	enclosing := ""
 
 	ast.Inspect(astFile, func(node ast.Node) bool {
-		funcDecl, ok := node.(*ast.FuncDecl)
-		if !ok {
-			return true
-		}
-
-		goFunc := NewGoFunction(funcDecl, fileSet, sourceCode)
-
-		// Calculate all metrics
-		cyclomaticComplexity := goFunc.CalculateCyclomaticComplexity()
-		cognitiveComplexity := goFunc.CalculateCognitiveComplexity()
-
-		// Calculate Halstead metrics
-		halsteadVol, halsteadDiff := goAnalyzer.calculateHalsteadForFunction(funcDecl)
-
-		// Calculate maintainability index
-		maintainabilityIndex := calculateMaintainabilityIndex(
-			halsteadVol,
-			cyclomaticComplexity,
-			goFunc.LineCount(),
-		)
-
-		functionAnalysis := models.FunctionAnalysis{
-			Name:                 goFunc.Name(),
-			StartLine:            goFunc.StartLine(),
-			EndLine:              goFunc.EndLine(),
-			Length:               goFunc.LineCount(),
-			LogicalLines:         goFunc.LogicalLineCount(),
-			ParameterCount:       goFunc.ParameterCount(),
-			LocalVariableCount:   goFunc.GetLocalVariableCount(),
-			ReturnCount:          goFunc.ReturnCount(),
-			CyclomaticComplexity: cyclomaticComplexity,
-			CognitiveComplexity:  cognitiveComplexity,
-			NestingDepth:         goFunc.MaxNestingDepth(),
-			HalsteadVolume:       halsteadVol,
-			HalsteadDifficulty:   halsteadDiff,
-			MaintainabilityIndex: maintainabilityIndex,
-			FanIn:                0, // TODO: Implement call graph analysis
-			FanOut:               goAnalyzer.countFunctionCalls(funcDecl),
+		switch typedNode := node.(type) {
+		case *ast.GenDecl:
+			// Function literals in package-level variables have no enclosing function
+			enclosing = ""
+		case *ast.FuncDecl:
+			goFunc := NewGoFunction(typedNode, fileSet, sourceCode)
+			enclosing = goFunc.Name()
+			functionAnalysis := goAnalyzer.analyzeFunction(goFunc, stdlibPackages)
+			functionAnalysis.IsExported = goFunc.IsExported()
+			functions = append(functions, functionAnalysis)
+		case *ast.FuncLit:
+			goFunc := NewGoFuncLit(namer.Name(enclosing, "func"), typedNode, fileSet, sourceCode)
+			functionAnalysis := goAnalyzer.analyzeFunction(goFunc, stdlibPackages)
+			functionAnalysis.IsAnonymous = true
+			functions = append(functions, functionAnalysis)
 		}
-
-		functions = append(functions, functionAnalysis)
 		return true
 	})
 
 	return functions
 }
 
+// analyzeFunction calculates all metrics for one function
+func (goAnalyzer *GoAnalyzer) analyzeFunction(goFunc *GoFunction, stdlibPackages map[string]bool) models.FunctionAnalysis {
+	funcDecl := goFunc.declaration
+	cyclomaticComplexity := goFunc.CalculateCyclomaticComplexity()
+	cognitiveComplexity := goFunc.CalculateCognitiveComplexity()
+
+	// Calculate Halstead metrics
+	halsteadVol, halsteadDiff, halsteadEffort, halsteadTime := goAnalyzer.calculateHalsteadForFunction(funcDecl)
+
+	// Calculate maintainability index
+	maintainabilityIndex := analyzer.MaintainabilityIndex(
+		analyzer.MIVariantClassic,
+		halsteadVol,
+		cyclomaticComplexity,
+		goFunc.LineCount(),
+		0,
+	)
+
+	chainLength, chainLine := goFunc.LongestMethodChain()
+
+	return models.FunctionAnalysis{
+		Name:                 goFunc.Name(),
+		StartLine:            goFunc.StartLine(),
+		EndLine:              goFunc.EndLine(),
+		Length:               goFunc.LineCount(),
+		LogicalLines:         goFunc.LogicalLineCount(),
+		ParameterCount:       goFunc.ParameterCount(),
+		LocalVariableCount:   goFunc.GetLocalVariableCount(),
+		ReturnCount:          goFunc.ReturnCount(),
+		CyclomaticComplexity: cyclomaticComplexity,
+		CognitiveComplexity:  cognitiveComplexity,
+		NestingDepth:         goFunc.MaxNestingDepth(),
+		HalsteadVolume:       halsteadVol,
+		HalsteadDifficulty:   halsteadDiff,
+		HalsteadEffort:       halsteadEffort,
+		HalsteadTime:         halsteadTime,
+		MaintainabilityIndex: maintainabilityIndex,
+		HasDocComment:        goFunc.HasDocComment(),
+		FanIn:                0, // Set by the pipeline once all files are analyzed
+		FanOut:               goAnalyzer.countFunctionCalls(funcDecl),
+		StdlibCalls:          countStdlibCalls(funcDecl, stdlibPackages),
+		UnreachableLine:      goFunc.UnreachableLine(),
+		MissingReturnLine:    goFunc.MissingReturnLine(),
+		MethodChainLength:    chainLength,
+		MethodChainLine:      chainLine,
+	}
+}
+
 // extractTypes extracts and analyzes types (structs, interfaces)
 func (goAnalyzer *GoAnalyzer) extractTypes(astFile *ast.File, fileSet *token.FileSet, sourceCode string) []models.TypeAnalysis {
 	var types []models.TypeAnalysis
 
+	methodsByReceiver := goAnalyzer.collectMethods(astFile, fileSet, sourceCode)
+
+	// Types declared inside functions are never exported
+	packageLevel := make(map[*ast.GenDecl]bool)
+	for _, decl := range astFile.Decls {
+		if genDecl, ok := decl.(*ast.GenDecl); ok {
+			packageLevel[genDecl] = true
+		}
+	}
+
 	ast.Inspect(astFile, func(node ast.Node) bool {
 		genDecl, ok := node.(*ast.GenDecl)
 		if !ok || genDecl.Tok != token.TYPE {
@@ -218,17 +267,24 @@ func (goAnalyzer *GoAnalyzer) extractTypes(astFile *ast.File, fileSet *token.Fil
 			}
 
 			typeAnalysis := models.TypeAnalysis{
-				Name:                    typeSpec.Name.Name,
-				Kind:                    kind,
-				AfferentCoupling:        0, // TODO: Implement coupling analysis
-				EfferentCoupling:        0,
-				Instability:             0,
-				LCOM:                    0, // TODO: Implement cohesion analysis
-				DepthOfInheritance:      0, // Go doesn't have inheritance
-				NumberOfChildren:        0,
-				MethodCount:             0, // Will be filled by method analysis
-				WeightedMethodsPerClass: 0,
-				PublicMethodCount:       0,
+				Name:               typeSpec.Name.Name,
+				Kind:               kind,
+				IsExported:         packageLevel[genDecl] && typeSpec.Name.IsExported(),
+				AfferentCoupling:   0, // TODO: Implement coupling analysis
+				EfferentCoupling:   0,
+				Instability:        0,
+				LCOM:               0, // TODO: Implement cohesion analysis
+				DepthOfInheritance: 0, // Go doesn't have inheritance
+				NumberOfChildren:   0,
+			}
+
+			// Only methods declared in this file are seen
+			for _, method := range methodsByReceiver[typeSpec.Name.Name] {
+				typeAnalysis.MethodCount++
+				typeAnalysis.WeightedMethodsPerClass += method.complexity
+				if method.exported {
+					typeAnalysis.PublicMethodCount++
+				}
 			}
 
 			types = append(types, typeAnalysis)
@@ -240,6 +296,53 @@ func (goAnalyzer *GoAnalyzer) extractTypes(astFile *ast.File, fileSet *token.Fil
 	return types
 }
 
+// goMethod is the part of a method declaration that type metrics need
+type goMethod struct {
+	complexity int
+	exported   bool
+}
+
+// collectMethods groups the file's method declarations by receiver type name
+func (goAnalyzer *GoAnalyzer) collectMethods(astFile *ast.File, fileSet *token.FileSet, sourceCode string) map[string][]goMethod {
+	methodsByReceiver := make(map[string][]goMethod)
+
+	for _, decl := range astFile.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+			continue
+		}
+
+		receiverName := receiverTypeName(funcDecl.Recv.List[0].Type)
+		if receiverName == "" {
+			continue
+		}
+
+		methodsByReceiver[receiverName] = append(methodsByReceiver[receiverName], goMethod{
+			complexity: NewGoFunction(funcDecl, fileSet, sourceCode).CalculateCyclomaticComplexity(),
+			exported:   funcDecl.Name.IsExported(),
+		})
+	}
+
+	return methodsByReceiver
+}
+
+// receiverTypeName returns the type name of a method receiver, unwrapping pointers and
+// type parameters (*Stack[T] is Stack)
+func receiverTypeName(receiverType ast.Expr) string {
+	switch typeExpr := receiverType.(type) {
+	case *ast.StarExpr:
+		return receiverTypeName(typeExpr.X)
+	case *ast.IndexExpr:
+		return receiverTypeName(typeExpr.X)
+	case *ast.IndexListExpr:
+		return receiverTypeName(typeExpr.X)
+	case *ast.Ident:
+		return typeExpr.Name
+	default:
+		return ""
+	}
+}
+
 // countFunctionCalls counts the number of function calls (fan-out)
 func (goAnalyzer *GoAnalyzer) countFunctionCalls(funcDecl *ast.FuncDecl) int {
 	count := 0
@@ -252,8 +355,66 @@ func (goAnalyzer *GoAnalyzer) countFunctionCalls(funcDecl *ast.FuncDecl) int {
 	return count
 }
 
+// goBuiltins are Go's predeclared functions and the predeclared types, whose conversions parse
+// as calls
+var goBuiltins = map[string]bool{
+	"append": true, "cap": true, "clear": true, "close": true, "complex": true, "copy": true,
+	"delete": true, "imag": true, "len": true, "make": true, "max": true, "min": true,
+	"new": true, "panic": true, "print": true, "println": true, "real": true, "recover": true,
+	"any": true, "bool": true, "byte": true, "complex64": true, "complex128": true,
+	"error": true, "float32": true, "float64": true, "int": true, "int8": true, "int16": true,
+	"int32": true, "int64": true, "rune": true, "string": true, "uint": true, "uint8": true,
+	"uint16": true, "uint32": true, "uint64": true, "uintptr": true,
+}
+
+// stdlibImportNames returns the names a file refers to its standard library imports by. An
+// import path whose first element has no dot, such as "net/http", is in the standard library.
+func stdlibImportNames(astFile *ast.File) map[string]bool {
+	names := make(map[string]bool)
+	for _, importSpec := range astFile.Imports {
+		importPath := strings.Trim(importSpec.Path.Value, "\"`")
+		if strings.Contains(strings.SplitN(importPath, "/", 2)[0], ".") {
+			continue
+		}
+		name := importPath[strings.LastIndex(importPath, "/")+1:]
+		if importSpec.Name != nil {
+			name = importSpec.Name.Name
+		}
+		if name != "_" && name != "." {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// countStdlibCalls counts the calls included in countFunctionCalls that go to a builtin, such as
+// len(x), or to a standard library package, such as strings.Split(s, ",")
+func countStdlibCalls(funcDecl *ast.FuncDecl, stdlibPackages map[string]bool) int {
+	count := 0
+	ast.Inspect(funcDecl, func(node ast.Node) bool {
+		callExpr, ok := node.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		// The parser resolves names declared in the file, so a builtin or package name that
+		// resolves to an object has been shadowed by a local declaration
+		switch callee := callExpr.Fun.(type) {
+		case *ast.Ident:
+			if callee.Obj == nil && goBuiltins[callee.Name] {
+				count++
+			}
+		case *ast.SelectorExpr:
+			if packageIdent, ok := callee.X.(*ast.Ident); ok && packageIdent.Obj == nil && stdlibPackages[packageIdent.Name] {
+				count++
+			}
+		}
+		return true
+	})
+	return count
+}
+
 // calculateHalsteadForFunction calculates Halstead metrics for a function
-func (goAnalyzer *GoAnalyzer) calculateHalsteadForFunction(funcDecl *ast.FuncDecl) (volume, difficulty float64) {
+func (goAnalyzer *GoAnalyzer) calculateHalsteadForFunction(funcDecl *ast.FuncDecl) (volume, difficulty, effort, timeToUnderstand float64) {
 	operators := make(map[string]bool)
 	operands := make(map[string]bool)
 	totalOperators := 0
@@ -284,7 +445,7 @@ func (goAnalyzer *GoAnalyzer) calculateHalsteadForFunction(funcDecl *ast.FuncDec
 	distinctOperands := len(operands)
 
 	if distinctOperators == 0 || distinctOperands == 0 {
-		return 0, 0
+		return 0, 0, 0, 0
 	}
 
 	// Halstead Volume = (N1 + N2) * log2(n1 + n2)
@@ -297,34 +458,11 @@ func (goAnalyzer *GoAnalyzer) calculateHalsteadForFunction(funcDecl *ast.FuncDec
 		difficulty = (float64(distinctOperators) / 2.0) * (float64(totalOperands) / float64(distinctOperands))
 	}
 
-	return volume, difficulty
-}
-
-// calculateMaintainabilityIndex calculates the maintainability index
-func calculateMaintainabilityIndex(halsteadVolume float64, cyclomaticComplexity int, linesOfCode int) float64 {
-	if linesOfCode == 0 {
-		return 100
-	}
-
-	// MI = 171 - 5.2 * ln(HV) - 0.23 * CC - 16.2 * ln(LOC)
-	// Normalized to 0-100 scale
-	hvTerm := 0.0
-	if halsteadVolume > 0 {
-		hvTerm = 5.2 * log(halsteadVolume)
-	}
-
-	ccTerm := 0.23 * float64(cyclomaticComplexity)
-	locTerm := 16.2 * log(float64(linesOfCode))
-
-	maintainabilityIndex := 171 - hvTerm - ccTerm - locTerm
+	// Effort = Volume * Difficulty
+	effort = volume * difficulty
 
-	// Normalize to 0-100
-	if maintainabilityIndex < 0 {
-		maintainabilityIndex = 0
-	}
-	if maintainabilityIndex > 100 {
-		maintainabilityIndex = 100
-	}
+	// Time to understand in seconds = Effort / 18
+	timeToUnderstand = effort / 18.0
 
-	return maintainabilityIndex
+	return volume, difficulty, effort, timeToUnderstand
 }
@@ -78,6 +78,105 @@ func HelloFunc() {
 	assert.Len(t, result.Functions, 1)
 }
 
+func TestAnalyzeSourceMatchesAnalyzeFile(t *testing.T) {
+	code := `package main
+
+func Max(first int, second int) int {
+	if first > second {
+		return first
+	}
+	return second
+}
+`
+
+	filePath := filepath.Join(t.TempDir(), "max.go")
+	require.NoError(t, os.WriteFile(filePath, []byte(code), 0644))
+
+	analyzer := NewGoAnalyzer()
+	fromFile, err := analyzer.AnalyzeFile(filePath)
+	require.NoError(t, err)
+	fromSource, err := analyzer.AnalyzeSource(filePath, []byte(code))
+	require.NoError(t, err)
+
+	assert.Equal(t, fromFile, fromSource)
+	require.Len(t, fromSource.Functions, 1)
+	assert.Equal(t, 2, fromSource.Functions[0].ParameterCount)
+}
+
+func TestAnalyzeSourceCountsStdlibCalls(t *testing.T) {
+	code := `package main
+
+import (
+	"fmt"
+	str "strings"
+
+	"github.com/example/render"
+)
+
+func Describe(names []string) string {
+	joined := str.Join(names, ", ")
+	if len(names) > 3 {
+		joined = render.Truncate(joined, 40)
+	}
+	return fmt.Sprintf("%s (%d)", describeCount(names), len(joined)) + string(rune(65))
+}
+
+func Shadowed(values []int) int {
+	len := func(values []int) int { return 0 }
+	return len(values)
+}
+`
+
+	result, err := NewGoAnalyzer().AnalyzeSource("describe.go", []byte(code))
+	require.NoError(t, err)
+	require.Len(t, result.Functions, 3) // Shadowed's function literal is reported too
+
+	// str.Join, len twice, fmt.Sprintf, and the string and rune conversions; render.Truncate
+	// is a third-party call and describeCount is first-party
+	assert.Equal(t, 8, result.Functions[0].FanOut)
+	assert.Equal(t, 6, result.Functions[0].StdlibCalls)
+
+	// A local len shadows the builtin
+	assert.Equal(t, 0, result.Functions[1].StdlibCalls)
+}
+
+func TestAnalyzeSourceReportsFunctionLiterals(t *testing.T) {
+	code := `package main
+
+var fallback = func() int { return 0 }
+
+func Serve(handlers []func(int) error) {
+	retry := func(attempt int) error {
+		check := func() bool { return attempt < 3 }
+		if check() {
+			return nil
+		}
+		return nil
+	}
+	for _, handler := range handlers {
+		_ = handler(1)
+	}
+	_ = retry(1)
+}
+`
+
+	result, err := NewGoAnalyzer().AnalyzeSource("serve.go", []byte(code))
+	require.NoError(t, err)
+
+	var names []string
+	for _, function := range result.Functions {
+		names = append(names, function.Name)
+		assert.Equal(t, function.Name != "Serve", function.IsAnonymous, function.Name)
+	}
+	assert.Equal(t, []string{"func1", "Serve", "Serve.func1", "Serve.func2"}, names)
+
+	retry := result.Functions[2]
+	assert.Equal(t, 6, retry.StartLine)
+	assert.Equal(t, 12, retry.EndLine)
+	assert.Equal(t, 1, retry.ParameterCount)
+	assert.Equal(t, 2, retry.CyclomaticComplexity)
+}
+
 func TestAnalyzeFileWithComments(t *testing.T) {
 	code := `package main
 
@@ -299,6 +398,93 @@ type MyInterface interface {
 	assert.NotEmpty(t, result.Types)
 }
 
+func TestExtractTypesWeightedMethods(t *testing.T) {
+	code := `package main
+
+type Stack[T any] struct {
+	items []T
+}
+
+func (stack *Stack[T]) Push(item T) {
+	stack.items = append(stack.items, item)
+}
+
+func (stack *Stack[T]) Pop() (T, bool) {
+	var zero T
+	if len(stack.items) == 0 {
+		return zero, false
+	}
+	item := stack.items[len(stack.items)-1]
+	stack.items = stack.items[:len(stack.items)-1]
+	return item, true
+}
+
+func (stack Stack[T]) isEmpty() bool {
+	return len(stack.items) == 0 && stack.items != nil
+}
+
+type Empty struct{}
+
+func standalone() {}
+`
+
+	result, err := NewGoAnalyzer().AnalyzeSource("stack.go", []byte(code))
+	require.NoError(t, err)
+	require.Len(t, result.Types, 2)
+
+	stack := result.Types[0]
+	assert.Equal(t, "Stack", stack.Name)
+	assert.Equal(t, 3, stack.MethodCount)
+	assert.Equal(t, 2, stack.PublicMethodCount)
+	// Push 1 + Pop 2 + isEmpty 2
+	assert.Equal(t, 5, stack.WeightedMethodsPerClass)
+
+	assert.Equal(t, "Empty", result.Types[1].Name)
+	assert.Equal(t, 0, result.Types[1].WeightedMethodsPerClass)
+}
+
+func TestAnalyzeSourceMarksExportedSymbols(t *testing.T) {
+	code := `package api
+
+type Client struct{}
+
+func (client *Client) Fetch() {}
+
+func (client *Client) retry() {}
+
+type cache struct{}
+
+func (c *cache) Get() {}
+
+func Connect() {
+	type Options struct{}
+	_ = func() {}
+}
+
+func dial() {}
+`
+
+	result, err := NewGoAnalyzer().AnalyzeSource("api.go", []byte(code))
+	require.NoError(t, err)
+
+	exported := make(map[string]bool)
+	for _, function := range result.Functions {
+		exported[function.Name] = function.IsExported
+	}
+	assert.True(t, exported["Fetch"])
+	assert.False(t, exported["retry"])
+	assert.False(t, exported["Get"], "methods of unexported types are not part of the API")
+	assert.True(t, exported["Connect"])
+	assert.False(t, exported["dial"])
+	assert.False(t, exported["Connect.func1"], "function literals are never exported")
+
+	exportedTypes := make(map[string]bool)
+	for _, typeAnalysis := range result.Types {
+		exportedTypes[typeAnalysis.Name] = typeAnalysis.IsExported
+	}
+	assert.Equal(t, map[string]bool{"Client": true, "cache": false, "Options": false}, exportedTypes)
+}
+
 func TestAnalyzeFileCommentDensity(t *testing.T) {
 	code := `package main
 
@@ -342,3 +528,29 @@ func init() {
 	assert.Len(t, result.Functions, 1)
 	assert.Equal(t, "init", result.Functions[0].Name)
 }
+
+func TestAnalyzeFileHalsteadEffortAndTime(t *testing.T) {
+	code := `package main
+
+func Add(first, second int) int {
+	total := first + second
+	if total > 10 {
+		total = total - 10
+	}
+	return total
+}
+`
+
+	tmpDir := t.TempDir()
+	filePath := filepath.Join(tmpDir, "test.go")
+	require.NoError(t, os.WriteFile(filePath, []byte(code), 0644))
+
+	result, err := NewGoAnalyzer().AnalyzeFile(filePath)
+	require.NoError(t, err)
+	require.Len(t, result.Functions, 1)
+
+	fn := result.Functions[0]
+	assert.Greater(t, fn.HalsteadVolume, 0.0)
+	assert.InDelta(t, fn.HalsteadVolume*fn.HalsteadDifficulty, fn.HalsteadEffort, 0.0001)
+	assert.InDelta(t, fn.HalsteadEffort/18, fn.HalsteadTime, 0.0001)
+}
@@ -5,19 +5,26 @@ import (
 	"go/ast"
 	"go/parser"
 	"go/token"
+	"go/types"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
 
+	"github.com/alexcollie/kaizen/pkg/analyzer"
 	"github.com/alexcollie/kaizen/pkg/models"
 )
 
 // CallGraphAnalyzer builds a call graph for Go code
 type CallGraphAnalyzer struct {
-	graph       *models.CallGraph
-	currentFile string
-	packageName string
-	fileSet     *token.FileSet
+	graph        *models.CallGraph
+	currentFile  string
+	packageName  string
+	fileSet      *token.FileSet
+	crossPackage bool
+	workers      int         // Files parsed concurrently; 0 means one per CPU
+	typesInfo    *types.Info // Type information for the current package, set in cross-package mode
 }
 
 // NewCallGraphAnalyzer creates a new call graph analyzer
@@ -28,35 +35,172 @@ func NewCallGraphAnalyzer() *CallGraphAnalyzer {
 	}
 }
 
-// AnalyzeDirectory analyzes all Go files in a directory and builds a call graph
-func (analyzer *CallGraphAnalyzer) AnalyzeDirectory(rootPath string) (*models.CallGraph, error) {
+// SetCrossPackage makes AnalyzeDirectory type-check the enclosing Go module and resolve calls
+// across its packages. Functions are then named by import path, e.g.
+// "github.com/org/repo/pkg/api.Server.Handle", rather than by package name.
+func (analyzer *CallGraphAnalyzer) SetCrossPackage(enabled bool) {
+	analyzer.crossPackage = enabled
+}
+
+// SetWorkers sets how many files AnalyzeDirectory parses concurrently. 0 (the default) uses one
+// worker per CPU and 1 parses serially; the graph is the same either way. Cross-package mode
+// type-checks packages in order and ignores it.
+func (analyzer *CallGraphAnalyzer) SetWorkers(workers int) {
+	analyzer.workers = workers
+}
+
+// AnalyzeDirectory analyzes all non-test Go files in a directory and builds a call graph.
+// Files and directories matching excludePatterns are skipped using the same rules as analyze,
+// so passing the config's exclude patterns keeps vendored and generated code out of the graph.
+// Files are parsed in parallel into graphs of their own, which are then merged in walk order:
+// all nodes before any edges, so a call into a file analyzed later links to that file's node.
+func (analyzer *CallGraphAnalyzer) AnalyzeDirectory(rootPath string, excludePatterns []string) (*models.CallGraph, error) {
+	if analyzer.crossPackage {
+		return analyzer.analyzeModule(rootPath, excludePatterns)
+	}
+
+	filePaths, err := collectGoFiles(rootPath, excludePatterns)
+	if err != nil {
+		return nil, err
+	}
+
+	fileGraphs := analyzer.parseFileGraphs(filePaths)
+	for index, fileGraph := range fileGraphs {
+		if fileGraph.err != nil {
+			// Log error but continue processing other files
+			fmt.Fprintf(os.Stderr, "Warning: failed to analyze %s: %v\n", filePaths[index], fileGraph.err)
+		}
+	}
+	analyzer.mergeFileGraphs(fileGraphs)
+
+	// Calculate statistics after all files are processed
+	analyzer.graph.CalculateStats()
+
+	return analyzer.graph, nil
+}
+
+// collectGoFiles walks rootPath and returns the non-test Go files that are not excluded, in
+// walk (lexical) order
+func collectGoFiles(rootPath string, excludePatterns []string) ([]string, error) {
+	var filePaths []string
+
 	err := filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
 		if info.IsDir() {
+			if path != rootPath && isExcludedPath(path, excludePatterns) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if isExcludedPath(path, excludePatterns) {
 			return nil
 		}
 
 		if filepath.Ext(path) == ".go" && !strings.HasSuffix(path, "_test.go") {
-			if analyzeErr := analyzer.analyzeFile(path); analyzeErr != nil {
-				// Log error but continue processing other files
-				fmt.Fprintf(os.Stderr, "Warning: failed to analyze %s: %v\n", path, analyzeErr)
-			}
+			filePaths = append(filePaths, path)
 		}
 
 		return nil
 	})
 
-	if err != nil {
-		return nil, err
+	return filePaths, err
+}
+
+// fileGraph is the call graph of a single file, before calls are resolved against other files
+type fileGraph struct {
+	graph *models.CallGraph
+	err   error
+}
+
+// parseFileGraphs parses each file into a graph of its own with a bounded pool of workers.
+// Results are indexed like filePaths, so the order they are merged in never depends on timing.
+func (analyzer *CallGraphAnalyzer) parseFileGraphs(filePaths []string) []fileGraph {
+	fileGraphs := make([]fileGraph, len(filePaths))
+
+	workers := analyzer.workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	workers = min(workers, len(filePaths))
+
+	jobs := make(chan int)
+	var waitGroup sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			for index := range jobs {
+				fileGraphs[index] = analyzer.parseFileGraph(filePaths[index])
+			}
+		}()
 	}
 
-	// Calculate statistics after all files are processed
-	analyzer.graph.CalculateStats()
+	for index := range filePaths {
+		jobs <- index
+	}
+	close(jobs)
+	waitGroup.Wait()
 
-	return analyzer.graph, nil
+	return fileGraphs
+}
+
+// parseFileGraph builds the call graph of one file with an analyzer of its own. Only the file
+// set is shared, and token.FileSet is safe for concurrent use.
+func (analyzer *CallGraphAnalyzer) parseFileGraph(filePath string) fileGraph {
+	fileAnalyzer := &CallGraphAnalyzer{
+		graph:   models.NewCallGraph(),
+		fileSet: analyzer.fileSet,
+	}
+	if err := fileAnalyzer.analyzeFile(filePath); err != nil {
+		return fileGraph{err: err}
+	}
+	return fileGraph{graph: fileAnalyzer.graph}
+}
+
+// mergeFileGraphs adds every file's declared functions to the graph, then replays every file's
+// calls against the complete set of nodes. Callees declared in no file become external nodes.
+func (analyzer *CallGraphAnalyzer) mergeFileGraphs(fileGraphs []fileGraph) {
+	for _, file := range fileGraphs {
+		if file.graph == nil {
+			continue
+		}
+
+		// Files are merged in walk order, so a function declared in two files keeps the later one
+		for _, fileNode := range file.graph.Nodes {
+			if fileNode.IsExternal {
+				continue
+			}
+			node := *fileNode
+			node.CallCount = 0
+			node.CallsOut = 0
+			analyzer.graph.AddNode(&node)
+		}
+	}
+
+	for _, file := range fileGraphs {
+		if file.graph == nil {
+			continue
+		}
+
+		for _, edge := range file.graph.Edges {
+			if _, exists := analyzer.graph.Nodes[edge.To]; !exists {
+				analyzer.addExternalNode(edge.To)
+			}
+			// An edge's weight is the number of calls the file makes along it, each counted once
+			for call := 0; call < edge.Weight; call++ {
+				analyzer.graph.AddEdge(edge)
+			}
+		}
+	}
+}
+
+// isExcludedPath applies analyze's exclude rules to a call graph path
+func isExcludedPath(path string, excludePatterns []string) bool {
+	return analyzer.MatchesExcludePattern(path, excludePatterns)
 }
 
 // analyzeFile parses a single Go file and extracts call graph information
@@ -74,6 +218,16 @@ func (analyzer *CallGraphAnalyzer) analyzeFile(filePath string) error {
 	}
 
 	// First pass: collect all function declarations
+	analyzer.addFunctionNodes(file)
+
+	// Second pass: extract call relationships
+	analyzer.extractCalls(file)
+
+	return nil
+}
+
+// addFunctionNodes creates a CallNode for every function declaration in a file
+func (analyzer *CallGraphAnalyzer) addFunctionNodes(file *ast.File) {
 	ast.Inspect(file, func(node ast.Node) bool {
 		switch funcDecl := node.(type) {
 		case *ast.FuncDecl:
@@ -81,8 +235,10 @@ func (analyzer *CallGraphAnalyzer) analyzeFile(filePath string) error {
 		}
 		return true
 	})
+}
 
-	// Second pass: extract call relationships
+// extractCalls adds the call edges of every function declaration in a file
+func (analyzer *CallGraphAnalyzer) extractCalls(file *ast.File) {
 	ast.Inspect(file, func(node ast.Node) bool {
 		switch funcDecl := node.(type) {
 		case *ast.FuncDecl:
@@ -90,8 +246,6 @@ func (analyzer *CallGraphAnalyzer) analyzeFile(filePath string) error {
 		}
 		return true
 	})
-
-	return nil
 }
 
 // addFunctionNode creates a CallNode for a function declaration
@@ -155,6 +309,12 @@ func (analyzer *CallGraphAnalyzer) extractCallsFromFunction(funcDecl *ast.FuncDe
 
 // extractCalleeName extracts the called function name from a CallExpr
 func (analyzer *CallGraphAnalyzer) extractCalleeName(callExpr *ast.CallExpr) string {
+	if analyzer.typesInfo != nil {
+		if calleeName, resolved := analyzer.resolveCalleeName(callExpr); resolved {
+			return calleeName
+		}
+	}
+
 	switch fun := callExpr.Fun.(type) {
 	case *ast.Ident:
 		// Direct function call: foo()
@@ -226,6 +386,12 @@ func (analyzer *CallGraphAnalyzer) extractReceiverType(expr ast.Expr) string {
 		return t.Name
 	case *ast.StarExpr:
 		return analyzer.extractReceiverType(t.X)
+	case *ast.IndexExpr:
+		// Generic receiver: Stack[T]
+		return analyzer.extractReceiverType(t.X)
+	case *ast.IndexListExpr:
+		// Generic receiver: Pair[K, V]
+		return analyzer.extractReceiverType(t.X)
 	default:
 		return "Unknown"
 	}
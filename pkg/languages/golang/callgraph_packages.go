@@ -0,0 +1,310 @@
+package golang
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/alexcollie/kaizen/pkg/analyzer"
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+// typedPackage is a type-checked package of the analyzed module
+type typedPackage struct {
+	types     *types.Package
+	files     []*ast.File
+	filePaths []string
+	info      *types.Info
+}
+
+// modulePackageLoader type-checks the packages of one Go module from source. Standard library
+// imports come from the compiler's export data; any other import that cannot be found is left
+// unresolved, and calls into it fall back to their syntactic names.
+type modulePackageLoader struct {
+	fileSet    *token.FileSet
+	moduleDir  string
+	modulePath string
+	fallback   types.Importer
+	packages   map[string]*typedPackage
+	filePaths  map[string][]string // Files to check for packages under the analyzed root, by import path
+}
+
+// analyzeModule builds the call graph for rootPath with calls resolved across the packages of
+// its enclosing Go module. All nodes are added before any edges, so a call into a package that
+// is analyzed later still links to that package's node rather than an external placeholder.
+func (analyzer *CallGraphAnalyzer) analyzeModule(rootPath string, excludePatterns []string) (*models.CallGraph, error) {
+	moduleDir, modulePath := findModuleRoot(rootPath)
+	if moduleDir == "" || modulePath == "" {
+		return nil, fmt.Errorf("no go.mod found for %s: cross-package analysis needs a Go module", rootPath)
+	}
+
+	filesByDir, err := collectPackageFiles(rootPath, excludePatterns)
+	if err != nil {
+		return nil, err
+	}
+
+	loader := &modulePackageLoader{
+		fileSet:    analyzer.fileSet,
+		moduleDir:  moduleDir,
+		modulePath: modulePath,
+		fallback:   importer.Default(),
+		packages:   make(map[string]*typedPackage),
+		filePaths:  make(map[string][]string),
+	}
+
+	importPaths := make([]string, 0, len(filesByDir))
+	for dir, filePaths := range filesByDir {
+		importPath, pathErr := loader.importPathForDir(dir)
+		if pathErr != nil {
+			return nil, pathErr
+		}
+		loader.filePaths[importPath] = filePaths
+		importPaths = append(importPaths, importPath)
+	}
+	sort.Strings(importPaths)
+
+	packages := make([]*typedPackage, 0, len(importPaths))
+	for _, importPath := range importPaths {
+		pkg, loadErr := loader.load(importPath)
+		if loadErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to analyze package %s: %v\n", importPath, loadErr)
+			continue
+		}
+		packages = append(packages, pkg)
+	}
+
+	for _, pkg := range packages {
+		analyzer.enterPackage(pkg)
+		for index, file := range pkg.files {
+			analyzer.currentFile = pkg.filePaths[index]
+			analyzer.addFunctionNodes(file)
+		}
+	}
+
+	for _, pkg := range packages {
+		analyzer.enterPackage(pkg)
+		for index, file := range pkg.files {
+			analyzer.currentFile = pkg.filePaths[index]
+			analyzer.extractCalls(file)
+		}
+	}
+
+	analyzer.typesInfo = nil
+	analyzer.graph.CalculateStats()
+
+	return analyzer.graph, nil
+}
+
+// findModuleRoot returns the directory and module path of the go.mod enclosing rootPath
+func findModuleRoot(rootPath string) (string, string) {
+	return analyzer.FindModuleRoot(rootPath)
+}
+
+// enterPackage makes pkg the package whose functions are being named and resolved
+func (analyzer *CallGraphAnalyzer) enterPackage(pkg *typedPackage) {
+	analyzer.packageName = pkg.types.Path()
+	analyzer.typesInfo = pkg.info
+}
+
+// collectPackageFiles walks rootPath like AnalyzeDirectory and groups the non-test Go files it
+// would analyze by directory
+func collectPackageFiles(rootPath string, excludePatterns []string) (map[string][]string, error) {
+	filesByDir := make(map[string][]string)
+
+	err := filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if path != rootPath && isExcludedPath(path, excludePatterns) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if isExcludedPath(path, excludePatterns) {
+			return nil
+		}
+
+		if filepath.Ext(path) == ".go" && !strings.HasSuffix(path, "_test.go") {
+			dir := filepath.Dir(path)
+			filesByDir[dir] = append(filesByDir[dir], path)
+		}
+
+		return nil
+	})
+
+	return filesByDir, err
+}
+
+// importPathForDir maps a directory inside the module to its import path
+func (loader *modulePackageLoader) importPathForDir(dir string) (string, error) {
+	absoluteDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	relativeDir, err := filepath.Rel(loader.moduleDir, absoluteDir)
+	if err != nil || strings.HasPrefix(relativeDir, "..") {
+		return "", fmt.Errorf("%s is outside module %s", dir, loader.modulePath)
+	}
+	if relativeDir == "." {
+		return loader.modulePath, nil
+	}
+	return loader.modulePath + "/" + filepath.ToSlash(relativeDir), nil
+}
+
+// isModulePackage reports whether importPath belongs to the analyzed module
+func (loader *modulePackageLoader) isModulePackage(importPath string) bool {
+	return importPath == loader.modulePath || strings.HasPrefix(importPath, loader.modulePath+"/")
+}
+
+// Import implements types.Importer, type-checking module packages from source
+func (loader *modulePackageLoader) Import(importPath string) (*types.Package, error) {
+	if !loader.isModulePackage(importPath) {
+		return loader.fallback.Import(importPath)
+	}
+
+	pkg, err := loader.load(importPath)
+	if err != nil {
+		return nil, err
+	}
+	return pkg.types, nil
+}
+
+// load parses and type-checks a module package once, caching the result. Type errors are
+// tolerated so that a package with an unresolvable import still yields partial type information.
+func (loader *modulePackageLoader) load(importPath string) (*typedPackage, error) {
+	if pkg, seen := loader.packages[importPath]; seen {
+		if pkg == nil {
+			return nil, fmt.Errorf("import cycle through %s", importPath)
+		}
+		return pkg, nil
+	}
+	loader.packages[importPath] = nil
+
+	filePaths, analyzed := loader.filePaths[importPath]
+	if !analyzed {
+		dependencyFiles, err := loader.dependencyFiles(importPath)
+		if err != nil {
+			delete(loader.packages, importPath)
+			return nil, err
+		}
+		filePaths = dependencyFiles
+	}
+
+	pkg := &typedPackage{
+		info: &types.Info{
+			Uses: make(map[*ast.Ident]types.Object),
+		},
+	}
+	for _, filePath := range filePaths {
+		file, err := parser.ParseFile(loader.fileSet, filePath, nil, parser.ParseComments)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to analyze %s: %v\n", filePath, err)
+			continue
+		}
+		pkg.files = append(pkg.files, file)
+		pkg.filePaths = append(pkg.filePaths, filePath)
+	}
+
+	config := types.Config{
+		Importer: loader,
+		Error:    func(error) {},
+	}
+	pkg.types, _ = config.Check(importPath, loader.fileSet, pkg.files, pkg.info)
+
+	loader.packages[importPath] = pkg
+	return pkg, nil
+}
+
+// dependencyFiles lists the non-test Go files of a module package outside the analyzed root,
+// honouring build constraints for the current platform
+func (loader *modulePackageLoader) dependencyFiles(importPath string) ([]string, error) {
+	relativeDir := strings.TrimPrefix(strings.TrimPrefix(importPath, loader.modulePath), "/")
+	dir := filepath.Join(loader.moduleDir, filepath.FromSlash(relativeDir))
+
+	buildPackage, err := build.Default.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	fileNames := append(append([]string{}, buildPackage.GoFiles...), buildPackage.CgoFiles...)
+	filePaths := make([]string, 0, len(fileNames))
+	for _, fileName := range fileNames {
+		filePaths = append(filePaths, filepath.Join(dir, fileName))
+	}
+	return filePaths, nil
+}
+
+// resolveCalleeName names the function a call resolves to using type information, e.g.
+// "github.com/org/repo/pkg/api.Server.Handle". It returns "" with resolved true for calls that
+// are not static function calls (builtins, conversions, calls through function values), and
+// resolved false when the callee has no type information and the syntactic name should be used.
+func (analyzer *CallGraphAnalyzer) resolveCalleeName(callExpr *ast.CallExpr) (string, bool) {
+	ident := calleeIdent(callExpr.Fun)
+	if ident == nil {
+		return "", false
+	}
+
+	object := analyzer.typesInfo.Uses[ident]
+	if object == nil {
+		return "", false
+	}
+
+	function, ok := object.(*types.Func)
+	if !ok {
+		return "", true
+	}
+	return qualifiedFunctionName(function.Origin()), true
+}
+
+// calleeIdent returns the identifier naming the called function: foo, pkg.Foo, obj.Method, or
+// an explicitly instantiated generic function such as Map[int]
+func calleeIdent(expr ast.Expr) *ast.Ident {
+	switch fun := expr.(type) {
+	case *ast.Ident:
+		return fun
+	case *ast.SelectorExpr:
+		return fun.Sel
+	case *ast.IndexExpr:
+		return calleeIdent(fun.X)
+	case *ast.IndexListExpr:
+		return calleeIdent(fun.X)
+	case *ast.ParenExpr:
+		return calleeIdent(fun.X)
+	default:
+		return nil
+	}
+}
+
+// qualifiedFunctionName formats a function the way cross-package nodes are named:
+// importpath.Func, or importpath.Type.Method for methods
+func qualifiedFunctionName(function *types.Func) string {
+	if function.Pkg() == nil {
+		// Universe methods such as error.Error
+		return function.Name()
+	}
+
+	signature, _ := function.Type().(*types.Signature)
+	if signature != nil && signature.Recv() != nil {
+		receiverType := signature.Recv().Type()
+		if pointer, isPointer := receiverType.(*types.Pointer); isPointer {
+			receiverType = pointer.Elem()
+		}
+		if named, isNamed := receiverType.(*types.Named); isNamed {
+			return fmt.Sprintf("%s.%s.%s", function.Pkg().Path(), named.Obj().Name(), function.Name())
+		}
+	}
+
+	return fmt.Sprintf("%s.%s", function.Pkg().Path(), function.Name())
+}
@@ -0,0 +1,263 @@
+package golang
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeCallGraphFixture(t *testing.T) string {
+	root := t.TempDir()
+	files := map[string]string{
+		"main.go":                 "package main\n\nfunc main() { helper() }\n\nfunc helper() {}\n",
+		"main_test.go":            "package main\n\nfunc testHelper() { helper() }\n",
+		"vendor/lib/lib.go":       "package lib\n\nfunc Vendored() {}\n",
+		"generated/models_gen.go": "package generated\n\nfunc Generated() {}\n",
+		"internal/store/store.go": "package store\n\nfunc Save() {}\n",
+	}
+	for relativePath, content := range files {
+		fullPath := filepath.Join(root, relativePath)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0644))
+	}
+	return root
+}
+
+func callGraphFunctionNames(t *testing.T, root string, excludePatterns []string) []string {
+	graph, err := NewCallGraphAnalyzer().AnalyzeDirectory(root, excludePatterns)
+	require.NoError(t, err)
+
+	var names []string
+	for _, node := range graph.Nodes {
+		names = append(names, node.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestCallGraphSkipsTestFiles(t *testing.T) {
+	names := callGraphFunctionNames(t, writeCallGraphFixture(t), nil)
+
+	assert.Equal(t, []string{"Generated", "Save", "Vendored", "helper", "main"}, names)
+}
+
+func TestCallGraphExcludePatterns(t *testing.T) {
+	names := callGraphFunctionNames(t, writeCallGraphFixture(t), []string{"vendor", "*_gen.go"})
+
+	assert.Equal(t, []string{"Save", "helper", "main"}, names)
+}
+
+func writeCrossPackageFixture(t *testing.T) string {
+	root := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module example.com/app\n\ngo 1.21\n",
+		"cmd/app/main.go": `package main
+
+import (
+	"fmt"
+
+	"example.com/app/store"
+)
+
+func main() {
+	repository := store.NewRepository()
+	repository.Save("key")
+	var saver store.Saver = repository
+	saver.Save("other")
+	fmt.Println(len(store.Keys()))
+}
+`,
+		"store/store.go": `package store
+
+type Saver interface{ Save(key string) }
+
+type Repository struct{ keys []string }
+
+func NewRepository() *Repository { return &Repository{} }
+
+func (repository *Repository) Save(key string) { repository.keys = append(repository.keys, key) }
+
+func Keys() []string { return Collect[string](nil) }
+`,
+		"store/generic.go": `package store
+
+type Stack[T any] struct{ items []T }
+
+func (stack *Stack[T]) Push(item T) { stack.items = append(stack.items, item) }
+
+func Collect[T any](items []T) []T {
+	stack := &Stack[T]{}
+	for _, item := range items {
+		stack.Push(item)
+	}
+	return stack.items
+}
+`,
+	}
+	for relativePath, content := range files {
+		fullPath := filepath.Join(root, relativePath)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0644))
+	}
+	return root
+}
+
+func callGraphEdgeSet(edges []models.CallEdge) map[string]bool {
+	edgeSet := make(map[string]bool, len(edges))
+	for _, edge := range edges {
+		edgeSet[edge.From+" -> "+edge.To] = true
+	}
+	return edgeSet
+}
+
+func TestCallGraphCrossPackage(t *testing.T) {
+	callGraphAnalyzer := NewCallGraphAnalyzer()
+	callGraphAnalyzer.SetCrossPackage(true)
+
+	graph, err := callGraphAnalyzer.AnalyzeDirectory(writeCrossPackageFixture(t), nil)
+	require.NoError(t, err)
+
+	edges := callGraphEdgeSet(graph.Edges)
+	assert.True(t, edges["example.com/app/cmd/app.main -> example.com/app/store.NewRepository"])
+	assert.True(t, edges["example.com/app/cmd/app.main -> example.com/app/store.Repository.Save"])
+	assert.True(t, edges["example.com/app/cmd/app.main -> example.com/app/store.Saver.Save"])
+	assert.True(t, edges["example.com/app/cmd/app.main -> example.com/app/store.Keys"])
+	assert.True(t, edges["example.com/app/cmd/app.main -> fmt.Println"])
+	assert.True(t, edges["example.com/app/store.Keys -> example.com/app/store.Collect"])
+	assert.True(t, edges["example.com/app/store.Collect -> example.com/app/store.Stack.Push"])
+	assert.Len(t, graph.Edges, 7, "builtins such as len and append are not calls")
+
+	repositorySave := graph.Nodes["example.com/app/store.Repository.Save"]
+	require.NotNil(t, repositorySave)
+	assert.False(t, repositorySave.IsExternal)
+	assert.Equal(t, 1, repositorySave.CallCount)
+	assert.Equal(t, "example.com/app/store", repositorySave.Package)
+
+	require.NotNil(t, graph.Nodes["fmt.Println"])
+	assert.True(t, graph.Nodes["fmt.Println"].IsExternal)
+}
+
+func TestCallGraphCrossPackageSubdirectory(t *testing.T) {
+	root := writeCrossPackageFixture(t)
+	callGraphAnalyzer := NewCallGraphAnalyzer()
+	callGraphAnalyzer.SetCrossPackage(true)
+
+	graph, err := callGraphAnalyzer.AnalyzeDirectory(filepath.Join(root, "cmd"), nil)
+	require.NoError(t, err)
+
+	edges := callGraphEdgeSet(graph.Edges)
+	assert.True(t, edges["example.com/app/cmd/app.main -> example.com/app/store.Repository.Save"])
+	require.NotNil(t, graph.Nodes["example.com/app/store.Repository.Save"])
+	assert.True(t, graph.Nodes["example.com/app/store.Repository.Save"].IsExternal, "packages outside the root are resolved but not analyzed")
+}
+
+func TestCallGraphCrossPackageRequiresModule(t *testing.T) {
+	callGraphAnalyzer := NewCallGraphAnalyzer()
+	callGraphAnalyzer.SetCrossPackage(true)
+
+	_, err := callGraphAnalyzer.AnalyzeDirectory(writeCallGraphFixture(t), nil)
+
+	assert.ErrorContains(t, err, "no go.mod")
+}
+
+func TestCallGraphGenericReceiverNames(t *testing.T) {
+	graph, err := NewCallGraphAnalyzer().AnalyzeDirectory(filepath.Join(writeCrossPackageFixture(t), "store"), nil)
+	require.NoError(t, err)
+
+	assert.NotNil(t, graph.Nodes["store.Stack.Push"])
+}
+
+// writeLargeCallGraphFixture writes one package of fileCount files whose functions call each
+// other across files, standing in for a large Go package
+func writeLargeCallGraphFixture(tb testing.TB, fileCount int) string {
+	root := tb.TempDir()
+	for fileIndex := 0; fileIndex < fileCount; fileIndex++ {
+		var source strings.Builder
+		source.WriteString("package large\n")
+		for funcIndex := 0; funcIndex < 20; funcIndex++ {
+			next := (fileIndex + 1) % fileCount
+			fmt.Fprintf(&source, `
+func F%[1]d_%[2]d(value int) int {
+	if value > %[2]d {
+		return F%[3]d_%[2]d(value - 1)
+	}
+	for index := 0; index < value; index++ {
+		value += helper%[1]d(index)
+	}
+	return F%[3]d_0(value) + len(strconv.Itoa(value))
+}
+`, fileIndex, funcIndex, next)
+		}
+		fmt.Fprintf(&source, "\nfunc helper%d(value int) int { return value * 2 }\n", fileIndex)
+
+		path := filepath.Join(root, fmt.Sprintf("file%03d.go", fileIndex))
+		require.NoError(tb, os.WriteFile(path, []byte(source.String()), 0644))
+	}
+	return root
+}
+
+func TestCallGraphWorkersProduceTheSameGraph(t *testing.T) {
+	root := writeLargeCallGraphFixture(t, 40)
+
+	serialAnalyzer := NewCallGraphAnalyzer()
+	serialAnalyzer.SetWorkers(1)
+	serial, err := serialAnalyzer.AnalyzeDirectory(root, nil)
+	require.NoError(t, err)
+
+	for attempt := 0; attempt < 3; attempt++ {
+		parallelAnalyzer := NewCallGraphAnalyzer()
+		parallelAnalyzer.SetWorkers(8)
+		parallel, err := parallelAnalyzer.AnalyzeDirectory(root, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, serial.Nodes, parallel.Nodes)
+		assert.Equal(t, serial.Edges, parallel.Edges, "edges are in the same order")
+		assert.Equal(t, serial.Stats.TotalEdges, parallel.Stats.TotalEdges)
+	}
+}
+
+func TestCallGraphLinksCallsIntoLaterFiles(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "a.go"), []byte("package app\n\nfunc Run() { helper(); helper() }\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "b.go"), []byte("package app\n\nfunc helper() {}\n"), 0644))
+
+	graph, err := NewCallGraphAnalyzer().AnalyzeDirectory(root, nil)
+	require.NoError(t, err)
+
+	helper := graph.Nodes["app.helper"]
+	require.NotNil(t, helper)
+	assert.False(t, helper.IsExternal, "a function declared in a later file is not external")
+	assert.Equal(t, 2, helper.CallCount)
+	assert.Equal(t, 2, graph.Nodes["app.Run"].CallsOut)
+	require.Len(t, graph.Edges, 1)
+	assert.Equal(t, 2, graph.Edges[0].Weight)
+	assert.Equal(t, 3, graph.Edges[0].Line)
+}
+
+func BenchmarkCallGraphAnalyzeDirectory(b *testing.B) {
+	root := writeLargeCallGraphFixture(b, 200)
+
+	for _, workers := range []int{1, 0} {
+		name := "serial"
+		if workers == 0 {
+			name = "parallel"
+		}
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				callGraphAnalyzer := NewCallGraphAnalyzer()
+				callGraphAnalyzer.SetWorkers(workers)
+				if _, err := callGraphAnalyzer.AnalyzeDirectory(root, nil); err != nil {
+					b.Fatalf("AnalyzeDirectory failed: %v", err)
+				}
+			}
+		})
+	}
+}
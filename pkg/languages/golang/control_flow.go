@@ -0,0 +1,230 @@
+package golang
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// UnreachableLine returns the line of the first statement that can never run because it follows
+// a terminating statement (return, goto, panic, or a branch-covering if/switch/for) in the same
+// block, including blocks of function literals; 0 when every statement is reachable. Labeled
+// statements are treated as reachable since a goto may jump to them.
+func (goFunc *GoFunction) UnreachableLine() int {
+	if goFunc.declaration.Body == nil {
+		return 0
+	}
+
+	unreachableLine := 0
+	ast.Inspect(goFunc.declaration.Body, func(node ast.Node) bool {
+		if unreachableLine > 0 {
+			return false
+		}
+
+		var statements []ast.Stmt
+		switch typedNode := node.(type) {
+		case *ast.BlockStmt:
+			statements = typedNode.List
+		case *ast.CaseClause:
+			statements = typedNode.Body
+		case *ast.CommClause:
+			statements = typedNode.Body
+		default:
+			return true
+		}
+
+		if statement := firstUnreachableStatement(statements); statement != nil {
+			unreachableLine = goFunc.fileSet.Position(statement.Pos()).Line
+			return false
+		}
+		return true
+	})
+
+	return unreachableLine
+}
+
+// MissingReturnLine returns the line of the closing brace that control can reach without a
+// return in a function or function literal declaring results; 0 when every path returns. Code
+// with this problem does not compile, so it shows up in sources kaizen analyzes before the
+// compiler does, such as work in progress and generated templates.
+func (goFunc *GoFunction) MissingReturnLine() int {
+	if goFunc.declaration.Body == nil {
+		return 0
+	}
+	if fallsOffEnd(goFunc.declaration.Type, goFunc.declaration.Body) {
+		return goFunc.fileSet.Position(goFunc.declaration.Body.Rbrace).Line
+	}
+
+	missingLine := 0
+	ast.Inspect(goFunc.declaration.Body, func(node ast.Node) bool {
+		funcLit, ok := node.(*ast.FuncLit)
+		if !ok || missingLine > 0 {
+			return missingLine == 0
+		}
+		if fallsOffEnd(funcLit.Type, funcLit.Body) {
+			missingLine = goFunc.fileSet.Position(funcLit.Body.Rbrace).Line
+			return false
+		}
+		return true
+	})
+
+	return missingLine
+}
+
+// fallsOffEnd reports whether a function declaring results has a body that can end without
+// returning
+func fallsOffEnd(funcType *ast.FuncType, body *ast.BlockStmt) bool {
+	if funcType.Results == nil || len(funcType.Results.List) == 0 {
+		return false
+	}
+	return !isTerminatingList(body.List)
+}
+
+// firstUnreachableStatement returns the first statement after a terminating statement in
+// statements, skipping empty statements, or nil when there is none
+func firstUnreachableStatement(statements []ast.Stmt) ast.Stmt {
+	for index, statement := range statements {
+		if !isTerminating(statement) {
+			continue
+		}
+		for _, following := range statements[index+1:] {
+			switch following.(type) {
+			case *ast.EmptyStmt:
+				continue
+			case *ast.LabeledStmt:
+				return nil
+			}
+			return following
+		}
+		return nil
+	}
+	return nil
+}
+
+// isTerminatingList reports whether a statement list ends in a terminating statement
+func isTerminatingList(statements []ast.Stmt) bool {
+	// Trailing empty statements do not change whether a list terminates
+	for len(statements) > 0 {
+		if _, empty := statements[len(statements)-1].(*ast.EmptyStmt); !empty {
+			break
+		}
+		statements = statements[:len(statements)-1]
+	}
+	if len(statements) == 0 {
+		return false
+	}
+	return isTerminating(statements[len(statements)-1])
+}
+
+// isTerminating reports whether statement is a terminating statement as defined by the Go
+// specification, so no statement after it in the same block can run
+func isTerminating(statement ast.Stmt) bool {
+	return isTerminatingLabeled(statement, "")
+}
+
+// isTerminatingLabeled is isTerminating for a statement carrying label, which a break inside
+// it may name
+func isTerminatingLabeled(statement ast.Stmt, label string) bool {
+	switch typedStatement := statement.(type) {
+	case *ast.ReturnStmt:
+		return true
+	case *ast.BranchStmt:
+		return typedStatement.Tok == token.GOTO
+	case *ast.ExprStmt:
+		return isPanicCall(typedStatement.X)
+	case *ast.BlockStmt:
+		return isTerminatingList(typedStatement.List)
+	case *ast.IfStmt:
+		return typedStatement.Else != nil &&
+			isTerminatingList(typedStatement.Body.List) &&
+			isTerminating(typedStatement.Else)
+	case *ast.ForStmt:
+		return typedStatement.Cond == nil && !hasBreak(typedStatement.Body, label)
+	case *ast.SwitchStmt:
+		return clausesTerminate(typedStatement.Body, label)
+	case *ast.TypeSwitchStmt:
+		return clausesTerminate(typedStatement.Body, label)
+	case *ast.SelectStmt:
+		return clausesTerminate(typedStatement.Body, label)
+	case *ast.LabeledStmt:
+		return isTerminatingLabeled(typedStatement.Stmt, typedStatement.Label.Name)
+	}
+	return false
+}
+
+// clausesTerminate reports whether a switch or select body has no break out of it and every
+// clause ends in a terminating statement (or fallthrough); switches also need a default clause
+func clausesTerminate(body *ast.BlockStmt, label string) bool {
+	if hasBreak(body, label) {
+		return false
+	}
+
+	hasDefault := false
+	for _, clause := range body.List {
+		var statements []ast.Stmt
+		switch typedClause := clause.(type) {
+		case *ast.CaseClause:
+			statements = typedClause.Body
+			hasDefault = hasDefault || typedClause.List == nil
+		case *ast.CommClause:
+			statements = typedClause.Body
+			// A select without a default still blocks until one of its cases runs
+			hasDefault = true
+		}
+
+		if len(statements) > 0 {
+			if branch, ok := statements[len(statements)-1].(*ast.BranchStmt); ok && branch.Tok == token.FALLTHROUGH {
+				continue
+			}
+		}
+		if !isTerminatingList(statements) {
+			return false
+		}
+	}
+
+	return hasDefault
+}
+
+// hasBreak reports whether body contains a break leaving the enclosing statement: an unlabeled
+// break outside any nested loop, switch, or select, or a break naming label
+func hasBreak(body *ast.BlockStmt, label string) bool {
+	found := false
+	var visit func(node ast.Node, nested bool)
+	visit = func(node ast.Node, nested bool) {
+		ast.Inspect(node, func(child ast.Node) bool {
+			if found || child == nil {
+				return false
+			}
+			switch typedChild := child.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.BranchStmt:
+				if typedChild.Tok == token.BREAK {
+					if typedChild.Label == nil {
+						found = !nested
+					} else {
+						found = typedChild.Label.Name == label
+					}
+				}
+				return false
+			case *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
+				if child != node {
+					visit(child, true)
+					return false
+				}
+			}
+			return true
+		})
+	}
+	visit(body, false)
+	return found
+}
+
+// isPanicCall reports whether expression calls the built-in panic
+func isPanicCall(expression ast.Expr) bool {
+	call, ok := expression.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	ident, ok := call.Fun.(*ast.Ident)
+	return ok && ident.Name == "panic"
+}
@@ -0,0 +1,230 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnreachableLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		expected int
+	}{
+		{
+			name: "statement after return",
+			code: `package main
+
+func MyFunction(value int) int {
+	return value
+	value++
+	return value
+}
+`,
+			expected: 5,
+		},
+		{
+			name: "statement after panic inside a branch",
+			code: `package main
+
+func MyFunction(value int) {
+	if value < 0 {
+		panic("negative")
+		println(value)
+	}
+}
+`,
+			expected: 6,
+		},
+		{
+			name: "statement after an if and else that both return",
+			code: `package main
+
+func MyFunction(value int) int {
+	if value > 0 {
+		return 1
+	} else {
+		return -1
+	}
+	return 0
+}
+`,
+			expected: 9,
+		},
+		{
+			name: "statement after an infinite loop",
+			code: `package main
+
+func MyFunction(work chan int) {
+	for {
+		<-work
+	}
+	close(work)
+}
+`,
+			expected: 7,
+		},
+		{
+			name: "loop with a break keeps later statements reachable",
+			code: `package main
+
+func MyFunction(work chan int) {
+	for {
+		if <-work == 0 {
+			break
+		}
+	}
+	close(work)
+}
+`,
+		},
+		{
+			name: "switch without default keeps later statements reachable",
+			code: `package main
+
+func MyFunction(value int) int {
+	switch value {
+	case 1:
+		return 1
+	}
+	return 0
+}
+`,
+		},
+		{
+			name: "labeled statement after goto is a jump target",
+			code: `package main
+
+func MyFunction(value int) int {
+	goto done
+done:
+	return value
+}
+`,
+		},
+		{
+			name: "statement after return in a function literal",
+			code: `package main
+
+func MyFunction() func() int {
+	return func() int {
+		return 1
+		println("never")
+	}
+}
+`,
+			expected: 6,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			goFunc := parseGoFunction(t, test.code)
+			assert.Equal(t, test.expected, goFunc.UnreachableLine())
+		})
+	}
+}
+
+func TestMissingReturnLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		expected int
+	}{
+		{
+			name: "if without else",
+			code: `package main
+
+func MyFunction(value int) int {
+	if value > 0 {
+		return value
+	}
+}
+`,
+			expected: 7,
+		},
+		{
+			name: "every path returns",
+			code: `package main
+
+func MyFunction(value int) int {
+	if value > 0 {
+		return value
+	}
+	return -value
+}
+`,
+		},
+		{
+			name: "switch with default returning in every case",
+			code: `package main
+
+func MyFunction(value int) string {
+	switch {
+	case value > 0:
+		return "positive"
+	case value < 0:
+		fallthrough
+	default:
+		return "other"
+	}
+}
+`,
+		},
+		{
+			name: "loop with a labeled break",
+			code: `package main
+
+func MyFunction(work chan int) int {
+outer:
+	for {
+		select {
+		case value := <-work:
+			if value == 0 {
+				break outer
+			}
+		}
+	}
+}
+`,
+			expected: 13,
+		},
+		{
+			name: "ends in panic",
+			code: `package main
+
+func MyFunction() error {
+	panic("not implemented")
+}
+`,
+		},
+		{
+			name: "function literal missing a return",
+			code: `package main
+
+func MyFunction() {
+	check := func(value int) bool {
+		if value > 0 {
+			return true
+		}
+	}
+	_ = check
+}
+`,
+			expected: 8,
+		},
+		{
+			name:     "no results",
+			code:     "package main\n\nfunc MyFunction() {\n}\n",
+			expected: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			goFunc := parseGoFunction(t, test.code)
+			assert.Equal(t, test.expected, goFunc.MissingReturnLine())
+		})
+	}
+}
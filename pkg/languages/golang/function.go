@@ -3,6 +3,9 @@ package golang
 import (
 	"go/ast"
 	"go/token"
+
+	"github.com/alexcollie/kaizen/pkg/languages/chain"
+	"github.com/alexcollie/kaizen/pkg/languages/nesting"
 )
 
 // GoFunction implements the FunctionNode interface for Go functions
@@ -21,6 +24,18 @@ func NewGoFunction(declaration *ast.FuncDecl, fileSet *token.FileSet, sourceCode
 	}
 }
 
+// NewGoFuncLit creates a GoFunction for a function literal, given the name to report it under.
+// The literal is wrapped in a declaration without a receiver or doc comment, so it is measured
+// exactly like a declared function.
+func NewGoFuncLit(name string, literal *ast.FuncLit, fileSet *token.FileSet, sourceCode string) *GoFunction {
+	declaration := &ast.FuncDecl{
+		Name: ast.NewIdent(name),
+		Type: literal.Type,
+		Body: literal.Body,
+	}
+	return NewGoFunction(declaration, fileSet, sourceCode)
+}
+
 // Name returns the function name
 func (goFunc *GoFunction) Name() string {
 	return goFunc.declaration.Name.Name
@@ -57,6 +72,21 @@ func (goFunc *GoFunction) LogicalLineCount() int {
 	return count
 }
 
+// HasDocComment reports whether the function has a doc comment directly above it
+func (goFunc *GoFunction) HasDocComment() bool {
+	return goFunc.declaration.Doc != nil && len(goFunc.declaration.Doc.List) > 0
+}
+
+// IsExported reports whether the function is part of its package's API: an exported function,
+// or an exported method of an exported type
+func (goFunc *GoFunction) IsExported() bool {
+	if !goFunc.declaration.Name.IsExported() {
+		return false
+	}
+	receiver := goFunc.declaration.Recv
+	return receiver == nil || len(receiver.List) == 0 || ast.IsExported(receiverTypeName(receiver.List[0].Type))
+}
+
 // ParameterCount returns the number of parameters
 func (goFunc *GoFunction) ParameterCount() int {
 	if goFunc.declaration.Type.Params == nil {
@@ -86,30 +116,43 @@ func (goFunc *GoFunction) ReturnCount() int {
 	return count
 }
 
-// MaxNestingDepth returns the maximum nesting level
+// MaxNestingDepth returns the maximum nesting level of control structures and function
+// literals. An else-if continues its chain rather than nesting inside it.
 func (goFunc *GoFunction) MaxNestingDepth() int {
-	maxDepth := 0
-	currentDepth := 0
-
+	elseIfs := make(map[*ast.IfStmt]bool)
 	ast.Inspect(goFunc.declaration, func(node ast.Node) bool {
-		if node == nil {
-			currentDepth--
-			return false
-		}
-
-		switch node.(type) {
-		case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt,
-			*ast.SwitchStmt, *ast.TypeSwitchStmt, *ast.SelectStmt:
-			currentDepth++
-			if currentDepth > maxDepth {
-				maxDepth = currentDepth
+		if ifStmt, ok := node.(*ast.IfStmt); ok {
+			if elseIf, isElseIf := ifStmt.Else.(*ast.IfStmt); isElseIf {
+				elseIfs[elseIf] = true
 			}
 		}
-
 		return true
 	})
 
-	return maxDepth
+	return nesting.MaxASTDepth(goFunc.declaration, func(node ast.Node) bool {
+		switch typedNode := node.(type) {
+		case *ast.IfStmt:
+			return !elseIfs[typedNode]
+		case *ast.ForStmt, *ast.RangeStmt, *ast.SwitchStmt, *ast.TypeSwitchStmt,
+			*ast.SelectStmt, *ast.FuncLit:
+			return true
+		}
+		return false
+	})
+}
+
+// LongestMethodChain returns the number of calls in the longest chain made on one expression,
+// such as a.b().c(), and the line where it starts; 0, 0 for a function without calls
+func (goFunc *GoFunction) LongestMethodChain() (length, line int) {
+	if goFunc.declaration.Body == nil {
+		return 0, 0
+	}
+
+	length, pos := chain.LongestAST(goFunc.declaration.Body)
+	if length == 0 {
+		return 0, 0
+	}
+	return length, goFunc.fileSet.Position(pos).Line
 }
 
 // CalculateCyclomaticComplexity calculates McCabe's cyclomatic complexity
@@ -8,6 +8,9 @@ import (
 	"strings"
 
 	"github.com/alexcollie/kaizen/pkg/analyzer"
+	"github.com/alexcollie/kaizen/pkg/languages/anonymous"
+	"github.com/alexcollie/kaizen/pkg/languages/chain"
+	"github.com/alexcollie/kaizen/pkg/languages/nesting"
 	"github.com/alexcollie/kaizen/pkg/models"
 	"github.com/smacker/go-tree-sitter"
 	"github.com/smacker/go-tree-sitter/kotlin"
@@ -51,13 +54,23 @@ func (kotlinAnalyzer *KotlinAnalyzer) IsStub() bool {
 	return false
 }
 
+// kotlinCommentSyntax drives commented-out code detection
+var kotlinCommentSyntax = analyzer.CommentSyntax{
+	LinePrefix: "//",
+	Keywords:   []string{"fun", "val", "var", "if", "else", "when", "for", "while", "return", "class", "import", "package", "override"},
+}
+
 // AnalyzeFile performs full analysis on a single Kotlin file
 func (kotlinAnalyzer *KotlinAnalyzer) AnalyzeFile(filePath string) (*models.FileAnalysis, error) {
-	// Read source code
 	sourceBytes, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
+	return kotlinAnalyzer.AnalyzeSource(filePath, sourceBytes)
+}
+
+// AnalyzeSource performs full analysis on in-memory Kotlin source reported under filePath
+func (kotlinAnalyzer *KotlinAnalyzer) AnalyzeSource(filePath string, sourceBytes []byte) (*models.FileAnalysis, error) {
 	sourceCode := string(sourceBytes)
 
 	// Count lines
@@ -85,7 +98,7 @@ func (kotlinAnalyzer *KotlinAnalyzer) AnalyzeFile(filePath string) (*models.File
 	functions := kotlinAnalyzer.extractFunctions(tree.RootNode(), sourceBytes)
 
 	// Analyze types (classes, interfaces)
-	types := kotlinAnalyzer.extractTypes(tree.RootNode())
+	types := kotlinAnalyzer.extractTypes(tree.RootNode(), sourceBytes)
 
 	return &models.FileAnalysis{
 		Path:                  filePath,
@@ -100,6 +113,7 @@ func (kotlinAnalyzer *KotlinAnalyzer) AnalyzeFile(filePath string) (*models.File
 		ImportCount:           importCount,
 		Functions:             functions,
 		Types:                 types,
+		CommentedCode:         analyzer.FindCommentedCode(sourceCode, kotlinCommentSyntax),
 	}, nil
 }
 
@@ -149,6 +163,40 @@ func (kotlinAnalyzer *KotlinAnalyzer) countImports(sourceCode string) int {
 	return count
 }
 
+// kotlinNesting lists the Kotlin constructs that open a nesting level, lambdas included. An if
+// that follows else continues its chain rather than nesting, and local functions are analyzed
+// on their own.
+var kotlinNesting = nesting.Language{
+	BlockTypes: map[string]bool{
+		"if_expression":      true,
+		"when_expression":    true,
+		"for_statement":      true,
+		"while_statement":    true,
+		"do_while_statement": true,
+		"try_expression":     true,
+		"lambda_literal":     true,
+		"anonymous_function": true,
+	},
+	IfTypes: map[string]bool{"if_expression": true},
+	Skip: func(node *sitter.Node) bool {
+		return node.Type() == "function_declaration"
+	},
+}
+
+// kotlinChains describes how Kotlin calls chain: through member access, including safe calls
+// (?.), indexing, and parentheses. Local functions are analyzed on their own.
+var kotlinChains = chain.Language{
+	CallTypes: map[string]bool{"call_expression": true},
+	LinkTypes: map[string]bool{
+		"navigation_expression":    true,
+		"indexing_expression":      true,
+		"parenthesized_expression": true,
+	},
+	Skip: func(node *sitter.Node) bool {
+		return node.Type() == "function_declaration"
+	},
+}
+
 // extractFunctions extracts and analyzes all functions in the file using AST
 func (kotlinAnalyzer *KotlinAnalyzer) extractFunctions(node *sitter.Node, sourceBytes []byte) []models.FunctionAnalysis {
 	var functions []models.FunctionAnalysis
@@ -156,27 +204,37 @@ func (kotlinAnalyzer *KotlinAnalyzer) extractFunctions(node *sitter.Node, source
 	cursor := sitter.NewTreeCursor(node)
 	defer cursor.Close()
 
-	kotlinAnalyzer.walkFunctions(cursor, &functions, sourceBytes)
+	kotlinAnalyzer.walkFunctions(cursor, &functions, anonymous.NewNamer(), sourceBytes)
 
 	return functions
 }
 
-// walkFunctions recursively walks the AST to find function declarations
-func (kotlinAnalyzer *KotlinAnalyzer) walkFunctions(cursor *sitter.TreeCursor, functions *[]models.FunctionAnalysis, sourceBytes []byte) {
+// walkFunctions recursively walks the AST to find function declarations. Lambdas and anonymous
+// functions are reported as anonymous functions named after the function enclosing them.
+func (kotlinAnalyzer *KotlinAnalyzer) walkFunctions(cursor *sitter.TreeCursor, functions *[]models.FunctionAnalysis, namer *anonymous.Namer, sourceBytes []byte) {
 	node := cursor.CurrentNode()
 
-	// Check if this is a function declaration
-	if node.Type() == "function_declaration" {
-		funcAnalysis := kotlinAnalyzer.analyzeFunctionNode(node, sourceBytes)
+	switch node.Type() {
+	case "function_declaration":
+		funcAnalysis := kotlinAnalyzer.analyzeFunctionNode(node, kotlinAnalyzer.extractFunctionName(node, sourceBytes), sourceBytes)
 		if funcAnalysis != nil {
+			funcAnalysis.IsExported = isPublicDeclaration(node, sourceBytes)
 			*functions = append(*functions, *funcAnalysis)
 		}
+	case "lambda_literal", "anonymous_function":
+		name := namer.Name(kotlinAnalyzer.enclosingFunctionName(node, sourceBytes), "lambda")
+		funcAnalysis := kotlinAnalyzer.analyzeFunctionNode(node, name, sourceBytes)
+		funcAnalysis.IsAnonymous = true
+		if node.Type() == "lambda_literal" {
+			funcAnalysis.ParameterCount = countLambdaParameters(node)
+		}
+		*functions = append(*functions, *funcAnalysis)
 	}
 
 	// Recursively visit children
 	if cursor.GoToFirstChild() {
 		for {
-			kotlinAnalyzer.walkFunctions(cursor, functions, sourceBytes)
+			kotlinAnalyzer.walkFunctions(cursor, functions, namer, sourceBytes)
 			if !cursor.GoToNextSibling() {
 				break
 			}
@@ -185,10 +243,32 @@ func (kotlinAnalyzer *KotlinAnalyzer) walkFunctions(cursor *sitter.TreeCursor, f
 	}
 }
 
-// analyzeFunctionNode analyzes a single function declaration node
-func (kotlinAnalyzer *KotlinAnalyzer) analyzeFunctionNode(node *sitter.Node, sourceBytes []byte) *models.FunctionAnalysis {
-	// Extract function name
-	functionName := kotlinAnalyzer.extractFunctionName(node, sourceBytes)
+// enclosingFunctionName returns the name of the nearest function declaration around node, or ""
+// outside any function
+func (kotlinAnalyzer *KotlinAnalyzer) enclosingFunctionName(node *sitter.Node, sourceBytes []byte) string {
+	for parent := node.Parent(); parent != nil; parent = parent.Parent() {
+		if parent.Type() == "function_declaration" {
+			return kotlinAnalyzer.extractFunctionName(parent, sourceBytes)
+		}
+	}
+	return ""
+}
+
+// countLambdaParameters counts the parameters a lambda declares before its arrow; a lambda
+// using the implicit it parameter declares none
+func countLambdaParameters(node *sitter.Node) int {
+	for childIdx := 0; childIdx < int(node.NamedChildCount()); childIdx++ {
+		child := node.NamedChild(childIdx)
+		if child.Type() == "lambda_parameters" {
+			return int(child.NamedChildCount())
+		}
+	}
+	return 0
+}
+
+// analyzeFunctionNode analyzes a single function declaration, lambda, or anonymous function
+// node, reported under functionName
+func (kotlinAnalyzer *KotlinAnalyzer) analyzeFunctionNode(node *sitter.Node, functionName string, sourceBytes []byte) *models.FunctionAnalysis {
 	if functionName == "" {
 		return nil
 	}
@@ -206,15 +286,19 @@ func (kotlinAnalyzer *KotlinAnalyzer) analyzeFunctionNode(node *sitter.Node, sou
 	// Calculate metrics
 	cyclomaticComplexity := kotlinFunc.CalculateCyclomaticComplexity()
 	cognitiveComplexity := kotlinFunc.CalculateCognitiveComplexity()
-	halsteadVol, halsteadDiff := kotlinAnalyzer.calculateHalsteadForFunction(functionText)
+	halsteadVol, halsteadDiff, halsteadEffort, halsteadTime := kotlinAnalyzer.calculateHalsteadForFunction(functionText)
 
 	// Calculate maintainability index
-	maintainabilityIndex := calculateMaintainabilityIndex(
+	maintainabilityIndex := analyzer.MaintainabilityIndex(
+		analyzer.MIVariantClassic,
 		halsteadVol,
 		cyclomaticComplexity,
 		kotlinFunc.LineCount(),
+		0,
 	)
 
+	chainLength, chainLine := chain.Longest(node, kotlinChains)
+
 	return &models.FunctionAnalysis{
 		Name:                 functionName,
 		StartLine:            startLine,
@@ -226,12 +310,17 @@ func (kotlinAnalyzer *KotlinAnalyzer) analyzeFunctionNode(node *sitter.Node, sou
 		ReturnCount:          kotlinFunc.ReturnCount(),
 		CyclomaticComplexity: cyclomaticComplexity,
 		CognitiveComplexity:  cognitiveComplexity,
-		NestingDepth:         kotlinFunc.MaxNestingDepth(),
+		NestingDepth:         nesting.MaxDepth(node, kotlinNesting),
 		HalsteadVolume:       halsteadVol,
 		HalsteadDifficulty:   halsteadDiff,
+		HalsteadEffort:       halsteadEffort,
+		HalsteadTime:         halsteadTime,
 		MaintainabilityIndex: maintainabilityIndex,
-		FanIn:                0, // TODO: Implement call graph analysis
+		FanIn:                0, // Set by the pipeline once all files are analyzed
 		FanOut:               kotlinAnalyzer.countFunctionCalls(functionText),
+		StdlibCalls:          kotlinAnalyzer.countStdlibCalls(functionText),
+		MethodChainLength:    chainLength,
+		MethodChainLine:      chainLine,
 	}
 }
 
@@ -254,24 +343,24 @@ func (kotlinAnalyzer *KotlinAnalyzer) extractFunctionName(node *sitter.Node, sou
 }
 
 // extractTypes extracts and analyzes types (classes, interfaces) from AST
-func (kotlinAnalyzer *KotlinAnalyzer) extractTypes(node *sitter.Node) []models.TypeAnalysis {
+func (kotlinAnalyzer *KotlinAnalyzer) extractTypes(node *sitter.Node, sourceBytes []byte) []models.TypeAnalysis {
 	var types []models.TypeAnalysis
 
 	cursor := sitter.NewTreeCursor(node)
 	defer cursor.Close()
 
-	kotlinAnalyzer.walkTypes(cursor, &types)
+	kotlinAnalyzer.walkTypes(cursor, &types, sourceBytes)
 
 	return types
 }
 
 // walkTypes recursively walks the AST to find type declarations
-func (kotlinAnalyzer *KotlinAnalyzer) walkTypes(cursor *sitter.TreeCursor, types *[]models.TypeAnalysis) {
+func (kotlinAnalyzer *KotlinAnalyzer) walkTypes(cursor *sitter.TreeCursor, types *[]models.TypeAnalysis, sourceBytes []byte) {
 	node := cursor.CurrentNode()
 
 	// Check if this is a class or interface declaration
 	if node.Type() == "class_declaration" || node.Type() == "interface_declaration" || node.Type() == "object_declaration" {
-		typeAnalysis := kotlinAnalyzer.analyzeTypeNode(node)
+		typeAnalysis := kotlinAnalyzer.analyzeTypeNode(node, sourceBytes)
 		if typeAnalysis != nil {
 			*types = append(*types, *typeAnalysis)
 		}
@@ -280,7 +369,7 @@ func (kotlinAnalyzer *KotlinAnalyzer) walkTypes(cursor *sitter.TreeCursor, types
 	// Recursively visit children
 	if cursor.GoToFirstChild() {
 		for {
-			kotlinAnalyzer.walkTypes(cursor, types)
+			kotlinAnalyzer.walkTypes(cursor, types, sourceBytes)
 			if !cursor.GoToNextSibling() {
 				break
 			}
@@ -290,11 +379,15 @@ func (kotlinAnalyzer *KotlinAnalyzer) walkTypes(cursor *sitter.TreeCursor, types
 }
 
 // analyzeTypeNode analyzes a single type declaration node
-func (kotlinAnalyzer *KotlinAnalyzer) analyzeTypeNode(node *sitter.Node) *models.TypeAnalysis {
+func (kotlinAnalyzer *KotlinAnalyzer) analyzeTypeNode(node *sitter.Node, sourceBytes []byte) *models.TypeAnalysis {
 	kind := ""
 	switch node.Type() {
 	case "class_declaration":
 		kind = "class"
+		// Interfaces parse as class declarations with an "interface" keyword
+		if hasChildOfType(node, "interface") {
+			kind = "interface"
+		}
 	case "interface_declaration":
 		kind = "interface"
 	case "object_declaration":
@@ -309,8 +402,8 @@ func (kotlinAnalyzer *KotlinAnalyzer) analyzeTypeNode(node *sitter.Node) *models
 	var typeName string
 	for childIdx := 0; childIdx < int(node.ChildCount()); childIdx++ {
 		child := node.Child(childIdx)
-		if child != nil && child.Type() == "simple_identifier" {
-			typeName = child.Content(nil)
+		if child != nil && (child.Type() == "type_identifier" || child.Type() == "simple_identifier") {
+			typeName = child.Content(sourceBytes)
 			break
 		}
 	}
@@ -319,19 +412,100 @@ func (kotlinAnalyzer *KotlinAnalyzer) analyzeTypeNode(node *sitter.Node) *models
 		return nil
 	}
 
+	methodCount, weightedMethods, publicMethods := kotlinAnalyzer.analyzeMethods(node, sourceBytes)
+
 	return &models.TypeAnalysis{
 		Name:                    typeName,
 		Kind:                    kind,
+		IsExported:              isPublicDeclaration(node, sourceBytes),
 		AfferentCoupling:        0, // TODO: Implement coupling analysis
 		EfferentCoupling:        0,
 		Instability:             0,
 		LCOM:                    0, // TODO: Implement cohesion analysis
 		DepthOfInheritance:      0,
 		NumberOfChildren:        0,
-		MethodCount:             0, // Will be filled by method analysis
-		WeightedMethodsPerClass: 0,
-		PublicMethodCount:       0,
+		MethodCount:             methodCount,
+		WeightedMethodsPerClass: weightedMethods,
+		PublicMethodCount:       publicMethods,
+	}
+}
+
+// analyzeMethods counts the functions declared directly in a type's body, sums their
+// cyclomatic complexity, and counts those without a private, protected, or internal modifier.
+// Functions of nested types and companion objects belong to those types.
+func (kotlinAnalyzer *KotlinAnalyzer) analyzeMethods(typeNode *sitter.Node, sourceBytes []byte) (count, weighted, public int) {
+	for childIdx := 0; childIdx < int(typeNode.ChildCount()); childIdx++ {
+		body := typeNode.Child(childIdx)
+		if body == nil || (body.Type() != "class_body" && body.Type() != "enum_class_body") {
+			continue
+		}
+
+		for memberIdx := 0; memberIdx < int(body.ChildCount()); memberIdx++ {
+			member := body.Child(memberIdx)
+			if member == nil || member.Type() != "function_declaration" {
+				continue
+			}
+
+			functionName := kotlinAnalyzer.extractFunctionName(member, sourceBytes)
+			startLine := int(member.StartPoint().Row) + 1
+			endLine := int(member.EndPoint().Row) + 1
+			kotlinFunc := NewKotlinFunction(functionName, startLine, endLine, member.Content(sourceBytes))
+
+			count++
+			weighted += kotlinFunc.CalculateCyclomaticComplexity()
+			if !hasRestrictedVisibility(member, sourceBytes) {
+				public++
+			}
+		}
+	}
+	return count, weighted, public
+}
+
+// hasRestrictedVisibility reports whether a declaration is private, protected, or internal
+func hasRestrictedVisibility(declaration *sitter.Node, sourceBytes []byte) bool {
+	for childIdx := 0; childIdx < int(declaration.ChildCount()); childIdx++ {
+		modifiers := declaration.Child(childIdx)
+		if modifiers == nil || modifiers.Type() != "modifiers" {
+			continue
+		}
+		for modifierIdx := 0; modifierIdx < int(modifiers.ChildCount()); modifierIdx++ {
+			modifier := modifiers.Child(modifierIdx)
+			if modifier != nil && modifier.Type() == "visibility_modifier" && modifier.Content(sourceBytes) != "public" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isPublicDeclaration reports whether a declaration is part of its package's API: neither it nor
+// a type around it is private, protected, or internal, and it is not local to a function
+func isPublicDeclaration(declaration *sitter.Node, sourceBytes []byte) bool {
+	if hasRestrictedVisibility(declaration, sourceBytes) {
+		return false
+	}
+	for parent := declaration.Parent(); parent != nil; parent = parent.Parent() {
+		switch parent.Type() {
+		case "function_declaration", "lambda_literal", "anonymous_function":
+			return false
+		case "class_declaration", "interface_declaration", "object_declaration", "companion_object":
+			if hasRestrictedVisibility(parent, sourceBytes) {
+				return false
+			}
+		}
 	}
+	return true
+}
+
+// hasChildOfType reports whether node has a direct child of the given type
+func hasChildOfType(node *sitter.Node, childType string) bool {
+	for childIdx := 0; childIdx < int(node.ChildCount()); childIdx++ {
+		child := node.Child(childIdx)
+		if child != nil && child.Type() == childType {
+			return true
+		}
+	}
+	return false
 }
 
 // countFunctionCalls counts the number of function calls (fan-out)
@@ -350,6 +524,42 @@ func (kotlinAnalyzer *KotlinAnalyzer) countFunctionCalls(functionBody string) in
 	return count
 }
 
+// kotlinStdlibFunctions are top-level Kotlin standard library functions, called without a
+// receiver or import
+var kotlinStdlibFunctions = map[string]bool{
+	"TODO": true, "arrayOf": true, "arrayOfNulls": true, "buildList": true, "buildMap": true,
+	"buildSet": true, "buildString": true, "check": true, "checkNotNull": true, "emptyArray": true,
+	"emptyList": true, "emptyMap": true, "emptySequence": true, "emptySet": true, "error": true,
+	"hashMapOf": true, "hashSetOf": true, "intArrayOf": true, "lazy": true, "listOf": true,
+	"listOfNotNull": true, "mapOf": true, "maxOf": true, "minOf": true, "mutableListOf": true,
+	"mutableMapOf": true, "mutableSetOf": true, "print": true, "println": true, "readLine": true,
+	"readln": true, "repeat": true, "require": true, "requireNotNull": true, "run": true,
+	"runCatching": true, "sequenceOf": true, "setOf": true, "synchronized": true, "with": true,
+}
+
+// countStdlibCalls counts the calls included in countFunctionCalls that go to a top-level
+// standard library function, such as listOf(...). Calls on a receiver, such as items.map(...),
+// are not counted, since the receiver's type is unknown without resolving it.
+func (kotlinAnalyzer *KotlinAnalyzer) countStdlibCalls(functionBody string) int {
+	count := 0
+	for idx := 0; idx < len(functionBody)-1; idx++ {
+		if functionBody[idx+1] != '(' || !isIdentifierChar(functionBody[idx]) {
+			continue
+		}
+		start := idx
+		for start > 0 && isIdentifierChar(functionBody[start-1]) {
+			start--
+		}
+		if start > 0 && functionBody[start-1] == '.' {
+			continue
+		}
+		if kotlinStdlibFunctions[functionBody[start:idx+1]] {
+			count++
+		}
+	}
+	return count
+}
+
 // isIdentifierChar checks if a character can be part of an identifier
 func isIdentifierChar(char byte) bool {
 	return (char >= 'a' && char <= 'z') ||
@@ -359,7 +569,7 @@ func isIdentifierChar(char byte) bool {
 }
 
 // calculateHalsteadForFunction calculates Halstead metrics for a function
-func (kotlinAnalyzer *KotlinAnalyzer) calculateHalsteadForFunction(functionBody string) (volume, difficulty float64) {
+func (kotlinAnalyzer *KotlinAnalyzer) calculateHalsteadForFunction(functionBody string) (volume, difficulty, effort, timeToUnderstand float64) {
 	operators := make(map[string]bool)
 	operands := make(map[string]bool)
 	totalOperators := 0
@@ -433,7 +643,7 @@ func (kotlinAnalyzer *KotlinAnalyzer) calculateHalsteadForFunction(functionBody
 	distinctOperands := len(operands)
 
 	if distinctOperators == 0 || distinctOperands == 0 {
-		return 0, 0
+		return 0, 0, 0, 0
 	}
 
 	// Halstead Volume = (N1 + N2) * log2(n1 + n2)
@@ -446,7 +656,13 @@ func (kotlinAnalyzer *KotlinAnalyzer) calculateHalsteadForFunction(functionBody
 		difficulty = (float64(distinctOperators) / 2.0) * (float64(totalOperands) / float64(distinctOperands))
 	}
 
-	return volume, difficulty
+	// Effort = Volume * Difficulty
+	effort = volume * difficulty
+
+	// Time to understand in seconds = Effort / 18
+	timeToUnderstand = effort / 18.0
+
+	return volume, difficulty, effort, timeToUnderstand
 }
 
 // isOperatorChar checks if a character is an operator
@@ -459,31 +675,3 @@ func isOperatorChar(char byte) bool {
 	}
 	return false
 }
-
-// calculateMaintainabilityIndex calculates the maintainability index
-func calculateMaintainabilityIndex(halsteadVolume float64, cyclomaticComplexity int, linesOfCode int) float64 {
-	if linesOfCode == 0 {
-		return 100
-	}
-
-	// MI = 171 - 5.2 * ln(HV) - 0.23 * CC - 16.2 * ln(LOC)
-	hvTerm := 0.0
-	if halsteadVolume > 0 {
-		hvTerm = 5.2 * log(halsteadVolume)
-	}
-
-	ccTerm := 0.23 * float64(cyclomaticComplexity)
-	locTerm := 16.2 * log(float64(linesOfCode))
-
-	maintainabilityIndex := 171 - hvTerm - ccTerm - locTerm
-
-	// Normalize to 0-100
-	if maintainabilityIndex < 0 {
-		maintainabilityIndex = 0
-	}
-	if maintainabilityIndex > 100 {
-		maintainabilityIndex = 100
-	}
-
-	return maintainabilityIndex
-}
@@ -0,0 +1,104 @@
+package kotlin
+
+import (
+	"testing"
+
+	"github.com/alexcollie/kaizen/pkg/analyzer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const lambdaSource = `fun process(items: List<Int>) {
+    items.forEach { item ->
+        if (item > 0) println(item)
+    }
+    val square = fun(x: Int): Int { return x * x }
+    run {
+        val next = { value: Int -> value + 1 }
+    }
+}
+`
+
+func TestLambdasAreAnonymousFunctions(t *testing.T) {
+	result, err := analyzer.AnalyzeSource(NewKotlinAnalyzer(), "process.kt", []byte(lambdaSource), analyzer.AnalysisOptions{CountAnonymousFunctions: true})
+	require.NoError(t, err)
+
+	var names []string
+	for _, function := range result.Functions {
+		names = append(names, function.Name)
+		assert.Equal(t, function.Name != "process", function.IsAnonymous, function.Name)
+	}
+	// Lambdas and anonymous functions are numbered in order, nested ones included
+	assert.Equal(t, []string{"process", "process.lambda1", "process.lambda2", "process.lambda3", "process.lambda4"}, names)
+
+	forEach := result.Functions[1]
+	assert.Equal(t, 2, forEach.StartLine)
+	assert.Equal(t, 4, forEach.EndLine)
+	assert.Equal(t, 1, forEach.ParameterCount)
+	assert.Equal(t, 2, forEach.CyclomaticComplexity)
+
+	// run's lambda uses no parameters
+	assert.Equal(t, 0, result.Functions[3].ParameterCount)
+}
+
+func TestLambdasDroppedWithoutCountAnonymousFunctions(t *testing.T) {
+	result, err := analyzer.AnalyzeSource(NewKotlinAnalyzer(), "process.kt", []byte(lambdaSource), analyzer.AnalysisOptions{})
+	require.NoError(t, err)
+
+	require.Len(t, result.Functions, 1)
+	assert.Equal(t, "process", result.Functions[0].Name)
+	assert.False(t, result.Functions[0].IsAnonymous)
+}
+
+func TestPublicDeclarations(t *testing.T) {
+	code := `class Client {
+    fun fetch(): String {
+        fun parse(body: String) = body
+        return parse("")
+    }
+
+    private fun retry() {}
+
+    internal fun stats() {}
+
+    protected fun close() {}
+}
+
+private class Cache {
+    fun get(key: String) {}
+}
+
+fun connect() {
+    listOf(1).forEach {
+        fun log(value: Int) {}
+    }
+}
+
+private fun dial() {}
+`
+
+	result, err := NewKotlinAnalyzer().AnalyzeSource("Client.kt", []byte(code))
+	require.NoError(t, err)
+
+	exported := make(map[string]bool)
+	for _, function := range result.Functions {
+		if !function.IsAnonymous {
+			exported[function.Name] = function.IsExported
+		}
+	}
+	assert.Equal(t, map[string]bool{
+		"fetch":   true,
+		"parse":   false, // Local to fetch
+		"retry":   false,
+		"stats":   false,
+		"close":   false,
+		"get":     false, // Member of a private class
+		"connect": true,
+		"log":     false, // Local to a lambda
+		"dial":    false,
+	}, exported)
+
+	for _, typeAnalysis := range result.Types {
+		assert.Equal(t, typeAnalysis.Name == "Client", typeAnalysis.IsExported, typeAnalysis.Name)
+	}
+}
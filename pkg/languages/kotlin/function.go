@@ -99,26 +99,6 @@ func (kotlinFunc *KotlinFunction) ReturnCount() int {
 	return len(matches)
 }
 
-// MaxNestingDepth returns the maximum nesting level
-func (kotlinFunc *KotlinFunction) MaxNestingDepth() int {
-	maxDepth := 0
-	currentDepth := 0
-
-	for _, char := range kotlinFunc.functionBody {
-		switch char {
-		case '{':
-			currentDepth++
-			if currentDepth > maxDepth {
-				maxDepth = currentDepth
-			}
-		case '}':
-			currentDepth--
-		}
-	}
-
-	return maxDepth
-}
-
 // CalculateCyclomaticComplexity calculates McCabe's cyclomatic complexity
 func (kotlinFunc *KotlinFunction) CalculateCyclomaticComplexity() int {
 	complexity := 1 // Base complexity
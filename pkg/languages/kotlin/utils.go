@@ -9,11 +9,3 @@ func log2(value float64) float64 {
 	}
 	return math.Log2(value)
 }
-
-// log calculates the natural logarithm
-func log(value float64) float64 {
-	if value <= 0 {
-		return 0
-	}
-	return math.Log(value)
-}
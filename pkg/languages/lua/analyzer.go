@@ -0,0 +1,392 @@
+package lua
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/alexcollie/kaizen/pkg/analyzer"
+	"github.com/alexcollie/kaizen/pkg/languages/anonymous"
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/lua"
+)
+
+// LuaAnalyzer implements the LanguageAnalyzer interface for Lua
+type LuaAnalyzer struct {
+	language *sitter.Language
+}
+
+// NewLuaAnalyzer creates a new Lua analyzer
+func NewLuaAnalyzer() analyzer.LanguageAnalyzer {
+	return &LuaAnalyzer{
+		language: lua.GetLanguage(),
+	}
+}
+
+// Name returns the language name
+func (luaAnalyzer *LuaAnalyzer) Name() string {
+	return "Lua"
+}
+
+// FileExtensions returns the file extensions this analyzer handles
+func (luaAnalyzer *LuaAnalyzer) FileExtensions() []string {
+	return []string{".lua"}
+}
+
+// CanAnalyze checks if this analyzer can handle the given file
+func (luaAnalyzer *LuaAnalyzer) CanAnalyze(filePath string) bool {
+	ext := filepath.Ext(filePath)
+	for _, supportedExt := range luaAnalyzer.FileExtensions() {
+		if ext == supportedExt {
+			return true
+		}
+	}
+	return false
+}
+
+// IsStub indicates if this is a stub implementation
+func (luaAnalyzer *LuaAnalyzer) IsStub() bool {
+	return false
+}
+
+// luaCommentSyntax drives commented-out code detection
+var luaCommentSyntax = analyzer.CommentSyntax{
+	LinePrefix: "--",
+	Keywords:   []string{"local", "function", "if", "then", "else", "elseif", "end", "for", "while", "return", "repeat", "until"},
+}
+
+// AnalyzeFile performs full analysis on a single Lua file
+func (luaAnalyzer *LuaAnalyzer) AnalyzeFile(filePath string) (*models.FileAnalysis, error) {
+	sourceBytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return luaAnalyzer.AnalyzeSource(filePath, sourceBytes)
+}
+
+// AnalyzeSource performs full analysis on in-memory Lua source reported under filePath
+func (luaAnalyzer *LuaAnalyzer) AnalyzeSource(filePath string, sourceBytes []byte) (*models.FileAnalysis, error) {
+	sourceCode := string(sourceBytes)
+
+	// Count lines
+	totalLines, codeLines, commentLines, blankLines := luaAnalyzer.countLines(sourceCode)
+
+	// Calculate comment density
+	commentDensity := 0.0
+	if totalLines > 0 {
+		commentDensity = float64(commentLines) / float64(totalLines) * 100
+	}
+
+	// Parse with tree-sitter
+	parser := sitter.NewParser()
+	parser.SetLanguage(luaAnalyzer.language)
+	tree, err := parser.ParseCtx(context.Background(), nil, sourceBytes)
+	if err != nil || tree == nil {
+		return nil, fmt.Errorf("failed to parse Lua file: %w", err)
+	}
+	defer tree.Close()
+
+	// Count require calls as imports
+	importCount := luaAnalyzer.countRequires(tree.RootNode(), sourceBytes)
+
+	// Extract and analyze functions
+	functions := luaAnalyzer.extractFunctions(tree.RootNode(), sourceBytes)
+
+	// Lua has no classes; tables with methods stand in for them
+	types := luaAnalyzer.extractTableClasses(tree.RootNode(), sourceBytes)
+
+	return &models.FileAnalysis{
+		Path:                  filePath,
+		Language:              luaAnalyzer.Name(),
+		TotalLines:            totalLines,
+		CodeLines:             codeLines,
+		CommentLines:          commentLines,
+		BlankLines:            blankLines,
+		CommentDensity:        commentDensity,
+		DuplicatedLines:       0,
+		DuplicationPercentage: 0,
+		ImportCount:           importCount,
+		Functions:             functions,
+		Types:                 types,
+		CommentedCode:         analyzer.FindCommentedCode(sourceCode, luaCommentSyntax),
+	}, nil
+}
+
+// longBracketOpen matches the opening of a long bracket comment such as --[[ or --[==[
+var longBracketOpen = regexp.MustCompile(`^--\[(=*)\[`)
+
+// countLines counts different types of lines in Lua source
+func (luaAnalyzer *LuaAnalyzer) countLines(sourceCode string) (total, code, comment, blank int) {
+	lines := strings.Split(sourceCode, "\n")
+	total = len(lines)
+
+	blockCommentClose := ""
+
+	for _, line := range lines {
+		trimmedLine := strings.TrimSpace(line)
+
+		// Inside a block comment
+		if blockCommentClose != "" {
+			comment++
+			if strings.Contains(line, blockCommentClose) {
+				blockCommentClose = ""
+			}
+			continue
+		}
+
+		if trimmedLine == "" {
+			blank++
+			continue
+		}
+
+		// Start of a block comment, which may close on the same line
+		if match := longBracketOpen.FindStringSubmatch(trimmedLine); match != nil {
+			comment++
+			closing := "]" + match[1] + "]"
+			if !strings.Contains(trimmedLine[len(match[0]):], closing) {
+				blockCommentClose = closing
+			}
+			continue
+		}
+
+		// Single-line comment
+		if strings.HasPrefix(trimmedLine, "--") {
+			comment++
+			continue
+		}
+
+		code++
+	}
+
+	return
+}
+
+// countRequires counts calls to require, with or without parentheses
+func (luaAnalyzer *LuaAnalyzer) countRequires(rootNode *sitter.Node, sourceBytes []byte) int {
+	count := 0
+	cursor := sitter.NewTreeCursor(rootNode)
+	defer cursor.Close()
+
+	walkAllNodes(cursor, func(node *sitter.Node) {
+		if node.Type() != "function_call" {
+			return
+		}
+		prefix := node.ChildByFieldName("prefix")
+		if prefix != nil && nodeText(prefix, sourceBytes) == "require" {
+			count++
+		}
+	})
+	return count
+}
+
+// walkAllNodes recursively visits the cursor's node and all of its descendants
+func walkAllNodes(cursor *sitter.TreeCursor, visit func(node *sitter.Node)) {
+	visit(cursor.CurrentNode())
+
+	if cursor.GoToFirstChild() {
+		for {
+			walkAllNodes(cursor, visit)
+			if !cursor.GoToNextSibling() {
+				break
+			}
+		}
+		cursor.GoToParent()
+	}
+}
+
+// extractFunctions extracts and analyzes named, local, and method function definitions,
+// function expressions assigned to a variable, and anonymous function expressions, which are
+// named after the function enclosing them
+func (luaAnalyzer *LuaAnalyzer) extractFunctions(rootNode *sitter.Node, sourceBytes []byte) []models.FunctionAnalysis {
+	var functions []models.FunctionAnalysis
+	namer := anonymous.NewNamer()
+
+	cursor := sitter.NewTreeCursor(rootNode)
+	defer cursor.Close()
+
+	walkAllNodes(cursor, func(node *sitter.Node) {
+		switch {
+		case isNestedDefinition(node):
+			functions = append(functions, luaAnalyzer.analyzeFunctionNode(node, sourceBytes))
+		case isAnonymousFunction(node):
+			function := luaAnalyzer.analyzeFunctionNode(node, sourceBytes)
+			function.Name = namer.Name(enclosingFunctionName(node, sourceBytes), "function")
+			function.IsAnonymous = true
+			functions = append(functions, function)
+		}
+	})
+	return functions
+}
+
+// enclosingFunctionName returns the name of the nearest named function containing node, or ""
+// at the top level
+func enclosingFunctionName(node *sitter.Node, sourceBytes []byte) string {
+	for parent := node.Parent(); parent != nil; parent = parent.Parent() {
+		if isNestedDefinition(parent) {
+			return NewLuaFunction(parent, sourceBytes).Name()
+		}
+	}
+	return ""
+}
+
+// analyzeFunctionNode analyzes a single function node
+func (luaAnalyzer *LuaAnalyzer) analyzeFunctionNode(node *sitter.Node, sourceBytes []byte) models.FunctionAnalysis {
+	luaFunc := NewLuaFunction(node, sourceBytes)
+
+	// Calculate Halstead metrics
+	halsteadVol, halsteadDiff, halsteadEffort, halsteadTime := luaAnalyzer.calculateHalsteadMetrics(node, sourceBytes)
+
+	// Calculate maintainability index
+	maintainabilityIndex := analyzer.MaintainabilityIndex(
+		analyzer.MIVariantClassic,
+		halsteadVol,
+		luaFunc.CalculateCyclomaticComplexity(),
+		luaFunc.LineCount(),
+		0,
+	)
+
+	return models.FunctionAnalysis{
+		Name:                 luaFunc.Name(),
+		StartLine:            luaFunc.StartLine(),
+		EndLine:              luaFunc.EndLine(),
+		Length:               luaFunc.LineCount(),
+		LogicalLines:         luaFunc.LogicalLineCount(),
+		ParameterCount:       luaFunc.ParameterCount(),
+		LocalVariableCount:   luaFunc.CountLocalVariables(),
+		ReturnCount:          luaFunc.ReturnCount(),
+		CyclomaticComplexity: luaFunc.CalculateCyclomaticComplexity(),
+		CognitiveComplexity:  luaFunc.CalculateCognitiveComplexity(),
+		NestingDepth:         luaFunc.MaxNestingDepth(),
+		HalsteadVolume:       halsteadVol,
+		HalsteadDifficulty:   halsteadDiff,
+		HalsteadEffort:       halsteadEffort,
+		HalsteadTime:         halsteadTime,
+		MaintainabilityIndex: maintainabilityIndex,
+		HasDocComment:        luaFunc.HasDocComment(),
+		FanIn:                0, // Set by the pipeline once all files are analyzed
+		FanOut:               luaFunc.CountFunctionCalls(),
+		StdlibCalls:          luaFunc.CountStdlibCalls(),
+	}
+}
+
+// operandTypes are the leaf node types counted as Halstead operands; every other token is
+// an operator
+var operandTypes = map[string]bool{
+	"identifier":     true,
+	"number":         true,
+	"string_content": true,
+	"boolean":        true,
+	"nil":            true,
+	"ellipsis":       true,
+}
+
+// calculateHalsteadMetrics calculates Halstead complexity metrics from the tokens of a function
+func (luaAnalyzer *LuaAnalyzer) calculateHalsteadMetrics(node *sitter.Node, sourceBytes []byte) (volume, difficulty, effort, timeToUnderstand float64) {
+	operators := make(map[string]bool)
+	operands := make(map[string]bool)
+	totalOperators := 0
+	totalOperands := 0
+
+	cursor := sitter.NewTreeCursor(node)
+	defer cursor.Close()
+
+	walkAllNodes(cursor, func(token *sitter.Node) {
+		if token.ChildCount() > 0 {
+			return
+		}
+		text := nodeText(token, sourceBytes)
+		if text == "" {
+			return
+		}
+		if operandTypes[token.Type()] {
+			operands[text] = true
+			totalOperands++
+			return
+		}
+		operators[text] = true
+		totalOperators++
+	})
+
+	distinctOperators := len(operators)
+	distinctOperands := len(operands)
+
+	if distinctOperators == 0 || distinctOperands == 0 {
+		return 0, 0, 0, 0
+	}
+
+	vocab := float64(distinctOperators + distinctOperands)
+	length := float64(totalOperators + totalOperands)
+
+	volume = length * math.Log2(vocab)
+	difficulty = (float64(distinctOperators) / 2.0) * (float64(totalOperands) / float64(distinctOperands))
+
+	// Effort = Volume * Difficulty
+	effort = volume * difficulty
+
+	// Time to understand in seconds = Effort / 18
+	timeToUnderstand = effort / 18.0
+
+	return volume, difficulty, effort, timeToUnderstand
+}
+
+// extractTableClasses reports tables used as classes: any table with at least one method
+// declared with colon syntax (function Table:name). Every function declared on the table,
+// with either "." or ":", counts toward its methods.
+func (luaAnalyzer *LuaAnalyzer) extractTableClasses(rootNode *sitter.Node, sourceBytes []byte) []models.TypeAnalysis {
+	var tableOrder []string
+	methodCounts := make(map[string]int)
+	weightedMethods := make(map[string]int)
+	hasColonMethod := make(map[string]bool)
+
+	cursor := sitter.NewTreeCursor(rootNode)
+	defer cursor.Close()
+
+	walkAllNodes(cursor, func(node *sitter.Node) {
+		if node.Type() != "function_statement" {
+			return
+		}
+		luaFunc := NewLuaFunction(node, sourceBytes)
+		tableName := ownerTable(luaFunc.Name())
+		if tableName == "" {
+			return
+		}
+
+		if _, seen := methodCounts[tableName]; !seen {
+			tableOrder = append(tableOrder, tableName)
+		}
+		methodCounts[tableName]++
+		weightedMethods[tableName] += luaFunc.CalculateCyclomaticComplexity()
+		if luaFunc.IsMethod() {
+			hasColonMethod[tableName] = true
+		}
+	})
+
+	var types []models.TypeAnalysis
+	for _, tableName := range tableOrder {
+		if !hasColonMethod[tableName] {
+			continue
+		}
+		types = append(types, models.TypeAnalysis{
+			Name:                    tableName,
+			Kind:                    "table",
+			MethodCount:             methodCounts[tableName],
+			WeightedMethodsPerClass: weightedMethods[tableName],
+		})
+	}
+	return types
+}
+
+// ownerTable returns the table a function is declared on ("Account" for "Account:deposit" and
+// "Account.new", "a.b" for "a.b.c"), or "" for a plain function name
+func ownerTable(functionName string) string {
+	separator := strings.LastIndexAny(functionName, ".:")
+	if separator <= 0 {
+		return ""
+	}
+	return functionName[:separator]
+}
@@ -0,0 +1,245 @@
+package lua
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const accountSource = `local json = require("json")
+local util = require 'util'
+
+--[[ Account
+     class ]]
+Account = {}
+Account.__index = Account
+
+--- Creates an account
+function Account.new(balance)
+  return setmetatable({balance = balance}, Account)
+end
+
+function Account:deposit(amount, note)
+  if amount and amount > 0 or note then
+    self.balance = self.balance + amount
+  elseif amount == 0 then
+    return false
+  end
+  return true
+end
+
+-- Walks a list
+local function helper(list, ...)
+  for index = 1, #list do
+    while list[index] do
+      if index > 2 then break end
+    end
+  end
+  repeat local done = true until done
+  table.sort(list, function(first, second) return first < second end)
+end
+
+local handler = function(event)
+  print(event)
+end
+`
+
+func analyzeAccountSource(testingT *testing.T) *models.FileAnalysis {
+	result, err := NewLuaAnalyzer().AnalyzeSource("account.lua", []byte(accountSource))
+	require.NoError(testingT, err)
+	return result
+}
+
+func findFunction(testingT *testing.T, result *models.FileAnalysis, name string) models.FunctionAnalysis {
+	for _, function := range result.Functions {
+		if function.Name == name {
+			return function
+		}
+	}
+	testingT.Fatalf("function %s not found", name)
+	return models.FunctionAnalysis{}
+}
+
+func TestNewLuaAnalyzer(t *testing.T) {
+	analyzer := NewLuaAnalyzer()
+
+	assert.Equal(t, "Lua", analyzer.Name())
+	assert.Equal(t, []string{".lua"}, analyzer.FileExtensions())
+	assert.False(t, analyzer.IsStub())
+	assert.True(t, analyzer.CanAnalyze("scripts/init.lua"))
+	assert.False(t, analyzer.CanAnalyze("main.go"))
+}
+
+func TestAnalyzeFileMatchesAnalyzeSource(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "account.lua")
+	require.NoError(t, os.WriteFile(filePath, []byte(accountSource), 0644))
+
+	analyzer := NewLuaAnalyzer()
+	fromFile, err := analyzer.AnalyzeFile(filePath)
+	require.NoError(t, err)
+	fromSource, err := analyzer.AnalyzeSource(filePath, []byte(accountSource))
+	require.NoError(t, err)
+
+	assert.Equal(t, fromFile, fromSource)
+	assert.Equal(t, "Lua", fromFile.Language)
+}
+
+func TestAnalyzeLineCountsAndImports(t *testing.T) {
+	result := analyzeAccountSource(t)
+
+	assert.Equal(t, 2, result.ImportCount)
+	assert.Equal(t, 4, result.CommentLines)
+	assert.Equal(t, 6, result.BlankLines)
+	assert.Equal(t, result.TotalLines, result.CodeLines+result.CommentLines+result.BlankLines)
+}
+
+func TestExtractFunctions(t *testing.T) {
+	result := analyzeAccountSource(t)
+
+	var names []string
+	for _, function := range result.Functions {
+		names = append(names, function.Name)
+	}
+	assert.Equal(t, []string{"Account.new", "Account:deposit", "helper", "helper.function1", "handler"}, names)
+
+	constructor := findFunction(t, result, "Account.new")
+	assert.Equal(t, 10, constructor.StartLine)
+	assert.Equal(t, 12, constructor.EndLine)
+	assert.True(t, constructor.HasDocComment)
+
+	handler := findFunction(t, result, "handler")
+	assert.Equal(t, 34, handler.StartLine)
+	assert.Equal(t, 1, handler.ParameterCount)
+}
+
+func TestAnonymousFunctions(t *testing.T) {
+	result := analyzeAccountSource(t)
+
+	// The sort callback is reported on its own; the assigned handler is a named function
+	callback := findFunction(t, result, "helper.function1")
+	assert.True(t, callback.IsAnonymous)
+	assert.Equal(t, 31, callback.StartLine)
+	assert.Equal(t, 31, callback.EndLine)
+	assert.Equal(t, 2, callback.ParameterCount)
+	assert.False(t, findFunction(t, result, "handler").IsAnonymous)
+
+	source := `local function run(items)
+  each(items, function(item)
+    if item then return function() end end
+  end)
+end
+
+register(function(...) end)
+`
+	result, err := NewLuaAnalyzer().AnalyzeSource("run.lua", []byte(source))
+	require.NoError(t, err)
+
+	var names []string
+	for _, function := range result.Functions {
+		names = append(names, function.Name)
+	}
+	assert.Equal(t, []string{"run", "run.function1", "run.function2", "function1"}, names)
+	assert.Equal(t, 2, findFunction(t, result, "run.function1").CyclomaticComplexity)
+}
+
+func TestParameterCountExcludesImplicitSelf(t *testing.T) {
+	result := analyzeAccountSource(t)
+
+	assert.Equal(t, 2, findFunction(t, result, "Account:deposit").ParameterCount)
+	// Varargs count as one parameter
+	assert.Equal(t, 2, findFunction(t, result, "helper").ParameterCount)
+}
+
+func TestComplexity(t *testing.T) {
+	result := analyzeAccountSource(t)
+
+	// if + and + or + elseif
+	deposit := findFunction(t, result, "Account:deposit")
+	assert.Equal(t, 5, deposit.CyclomaticComplexity)
+	assert.Equal(t, 2, deposit.ReturnCount)
+	assert.False(t, deposit.HasDocComment)
+
+	// for + while + if + repeat; the sort callback nests but adds no branches
+	helper := findFunction(t, result, "helper")
+	assert.Equal(t, 5, helper.CyclomaticComplexity)
+	assert.Equal(t, 3, helper.NestingDepth)
+	assert.True(t, helper.HasDocComment)
+	assert.Equal(t, 1, helper.LocalVariableCount)
+	assert.Greater(t, helper.HalsteadVolume, 0.0)
+}
+
+func TestCognitiveComplexity(t *testing.T) {
+	source := `function classify(value, strict)
+  if value then            -- +1
+    for _, item in ipairs(value) do  -- +2 (nested once)
+      if item and strict then        -- +3 (nested twice), +1 for and
+        return item
+      end
+    end
+  elseif strict then       -- +1
+    return nil
+  else                     -- +1
+    return false
+  end
+end
+`
+	result, err := NewLuaAnalyzer().AnalyzeSource("classify.lua", []byte(source))
+	require.NoError(t, err)
+	require.Len(t, result.Functions, 1)
+
+	assert.Equal(t, 9, result.Functions[0].CognitiveComplexity)
+}
+
+func TestExtractTableClasses(t *testing.T) {
+	source := `local M = {}
+
+function M.helper() end
+
+Stack = {}
+
+function Stack.new() end
+function Stack:push(value) end
+function Stack:pop() end
+`
+	result, err := NewLuaAnalyzer().AnalyzeSource("stack.lua", []byte(source))
+	require.NoError(t, err)
+
+	// M only has dot functions, so it is a module rather than a class
+	require.Len(t, result.Types, 1)
+	assert.Equal(t, "Stack", result.Types[0].Name)
+	assert.Equal(t, "table", result.Types[0].Kind)
+	assert.Equal(t, 3, result.Types[0].MethodCount)
+	assert.Equal(t, 3, result.Types[0].WeightedMethodsPerClass)
+}
+
+func TestOwnerTable(t *testing.T) {
+	assert.Equal(t, "Account", ownerTable("Account:deposit"))
+	assert.Equal(t, "Account", ownerTable("Account.new"))
+	assert.Equal(t, "a.b", ownerTable("a.b.c"))
+	assert.Equal(t, "", ownerTable("helper"))
+}
+
+func TestCountStdlibCalls(t *testing.T) {
+	source := `local function render(items)
+  local lines = {}
+  for _, item in ipairs(items) do
+    table.insert(lines, string.format("%s=%d", item.name, tostring(item.value)))
+  end
+  print "rendered"
+  lines:sort()
+  return formatter.join(lines, emit(lines))
+end
+`
+	result, err := NewLuaAnalyzer().AnalyzeSource("render.lua", []byte(source))
+	require.NoError(t, err)
+	require.Len(t, result.Functions, 1)
+
+	// ipairs, table.insert, string.format, tostring, and print; the method call and the
+	// first-party calls are not standard library calls
+	assert.Equal(t, 8, result.Functions[0].FanOut)
+	assert.Equal(t, 5, result.Functions[0].StdlibCalls)
+}
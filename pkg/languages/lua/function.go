@@ -0,0 +1,500 @@
+package lua
+
+import (
+	"strings"
+
+	"github.com/alexcollie/kaizen/pkg/languages/nesting"
+	"github.com/smacker/go-tree-sitter"
+)
+
+// LuaFunction represents a Lua function for complexity analysis
+type LuaFunction struct {
+	node        *sitter.Node
+	sourceBytes []byte
+}
+
+// NewLuaFunction creates a new Lua function node from a function_statement or a function
+// expression
+func NewLuaFunction(node *sitter.Node, sourceBytes []byte) *LuaFunction {
+	return &LuaFunction{
+		node:        node,
+		sourceBytes: sourceBytes,
+	}
+}
+
+// Name returns the function name as written, including any table prefix ("M.helper",
+// "Account:deposit"); functions assigned to a variable take the variable's name
+func (luaFunc *LuaFunction) Name() string {
+	if luaFunc.node.Type() == "function" {
+		return assignedFunctionName(luaFunc.node, luaFunc.sourceBytes)
+	}
+
+	nameNode := luaFunc.node.ChildByFieldName("name")
+	if nameNode == nil {
+		return "unknown"
+	}
+	return nodeText(nameNode, luaFunc.sourceBytes)
+}
+
+// IsMethod reports whether the function is declared with method syntax (Table:name), which
+// receives an implicit self parameter
+func (luaFunc *LuaFunction) IsMethod() bool {
+	nameNode := luaFunc.node.ChildByFieldName("name")
+	if nameNode == nil {
+		return false
+	}
+	for index := 0; index < int(nameNode.ChildCount()); index++ {
+		if nameNode.Child(index).Type() == "table_colon" {
+			return true
+		}
+	}
+	return false
+}
+
+// IsLocal reports whether the function is declared with the local keyword
+func (luaFunc *LuaFunction) IsLocal() bool {
+	declaration := luaFunc.declaration()
+	for index := 0; index < int(declaration.NamedChildCount()); index++ {
+		if declaration.NamedChild(index).Type() == "local" {
+			return true
+		}
+	}
+	return false
+}
+
+// StartLine returns the starting line number. Function expressions start at their
+// assignment, so "local f = function" starts on the line of "local".
+func (luaFunc *LuaFunction) StartLine() int {
+	declaration := luaFunc.declaration()
+	for index := 0; index < int(declaration.ChildCount()); index++ {
+		child := declaration.Child(index)
+		if child.Type() != "emmy_documentation" && child.Type() != "comment" {
+			return startLine(child, luaFunc.sourceBytes)
+		}
+	}
+	return startLine(declaration, luaFunc.sourceBytes)
+}
+
+// EndLine returns the ending line number
+func (luaFunc *LuaFunction) EndLine() int {
+	return int(luaFunc.node.EndPoint().Row) + 1
+}
+
+// LineCount returns the total number of lines
+func (luaFunc *LuaFunction) LineCount() int {
+	return luaFunc.EndLine() - luaFunc.StartLine() + 1
+}
+
+// declaration returns the statement declaring the function: the function_statement itself,
+// the assignment holding a function expression, or an anonymous function expression itself
+func (luaFunc *LuaFunction) declaration() *sitter.Node {
+	if luaFunc.node.Type() == "function" && assignedDeclarator(luaFunc.node) != nil {
+		return luaFunc.node.Parent()
+	}
+	return luaFunc.node
+}
+
+// HasDocComment reports whether the function has a "---" documentation block or a comment
+// ending on the line directly above it
+func (luaFunc *LuaFunction) HasDocComment() bool {
+	declaration := luaFunc.declaration()
+	for index := 0; index < int(declaration.NamedChildCount()); index++ {
+		if declaration.NamedChild(index).Type() == "emmy_documentation" {
+			return true
+		}
+	}
+
+	previous := declaration.PrevSibling()
+	if previous == nil || previous.Type() != "comment" {
+		return false
+	}
+	return int(previous.EndPoint().Row)+1 >= luaFunc.StartLine()-1
+}
+
+// ParameterCount counts declared parameters, with varargs (...) counting as one. The implicit
+// self of method syntax is not declared and so not counted.
+func (luaFunc *LuaFunction) ParameterCount() int {
+	for index := 0; index < int(luaFunc.node.NamedChildCount()); index++ {
+		child := luaFunc.node.NamedChild(index)
+		if child.Type() == "parameter_list" {
+			return int(child.NamedChildCount())
+		}
+	}
+	return 0
+}
+
+// body returns the function_body node, or nil for an empty function
+func (luaFunc *LuaFunction) body() *sitter.Node {
+	for index := 0; index < int(luaFunc.node.NamedChildCount()); index++ {
+		child := luaFunc.node.NamedChild(index)
+		if child.Type() == "function_body" {
+			return child
+		}
+	}
+	return nil
+}
+
+// LogicalLineCount counts statements in the function body
+func (luaFunc *LuaFunction) LogicalLineCount() int {
+	count := 0
+	luaFunc.walkBody(func(node *sitter.Node) {
+		if statementTypes[node.Type()] || (node.Type() == "function_call" && isStatementPosition(node)) {
+			count++
+		}
+	})
+	return count
+}
+
+// statementTypes are the node types counted as logical lines
+var statementTypes = map[string]bool{
+	"variable_declaration": true,
+	"return_statement":     true,
+	"break_statement":      true,
+	"if_statement":         true,
+	"for_statement":        true,
+	"while_statement":      true,
+	"repeat_statement":     true,
+	"do_statement":         true,
+	"goto_statement":       true,
+}
+
+// isStatementPosition reports whether a node appears directly in a block rather than inside
+// an expression
+func isStatementPosition(node *sitter.Node) bool {
+	parent := node.Parent()
+	if parent == nil {
+		return false
+	}
+	switch parent.Type() {
+	case "function_body", "program", "if_statement", "for_statement", "while_statement",
+		"repeat_statement", "do_statement":
+		return true
+	}
+	return false
+}
+
+// ReturnCount counts return statements
+func (luaFunc *LuaFunction) ReturnCount() int {
+	count := 0
+	luaFunc.walkBody(func(node *sitter.Node) {
+		if node.Type() == "return_statement" {
+			count++
+		}
+	})
+	return count
+}
+
+// CountLocalVariables counts distinct names declared with local
+func (luaFunc *LuaFunction) CountLocalVariables() int {
+	names := make(map[string]bool)
+	luaFunc.walkBody(func(node *sitter.Node) {
+		if node.Type() != "variable_declaration" {
+			return
+		}
+		isLocal := false
+		for index := 0; index < int(node.NamedChildCount()); index++ {
+			if node.NamedChild(index).Type() == "local" {
+				isLocal = true
+			}
+		}
+		if !isLocal {
+			return
+		}
+		for index := 0; index < int(node.NamedChildCount()); index++ {
+			child := node.NamedChild(index)
+			if child.Type() == "variable_declarator" {
+				names[nodeText(child, luaFunc.sourceBytes)] = true
+			}
+		}
+	})
+	return len(names)
+}
+
+// CountFunctionCalls counts function and method calls (fan-out)
+func (luaFunc *LuaFunction) CountFunctionCalls() int {
+	count := 0
+	luaFunc.walkBody(func(node *sitter.Node) {
+		if node.Type() == "function_call" {
+			count++
+		}
+	})
+	return count
+}
+
+// luaBuiltins are the global functions Lua's base library provides
+var luaBuiltins = map[string]bool{
+	"assert": true, "collectgarbage": true, "dofile": true, "error": true, "getmetatable": true,
+	"ipairs": true, "load": true, "loadfile": true, "next": true, "pairs": true, "pcall": true,
+	"print": true, "rawequal": true, "rawget": true, "rawlen": true, "rawset": true,
+	"require": true, "select": true, "setmetatable": true, "tonumber": true, "tostring": true,
+	"type": true, "unpack": true, "xpcall": true,
+}
+
+// luaStdlibModules are the standard library tables, such as string in string.format(...)
+var luaStdlibModules = map[string]bool{
+	"coroutine": true, "debug": true, "io": true, "math": true, "os": true, "package": true,
+	"string": true, "table": true, "utf8": true,
+}
+
+// CountStdlibCalls counts the calls included in CountFunctionCalls that go to a base library
+// function, such as pairs(t), or through a standard library table, such as table.insert(t, v).
+// Method calls, such as s:upper(), are not counted, since the receiver's type is unknown.
+func (luaFunc *LuaFunction) CountStdlibCalls() int {
+	count := 0
+	luaFunc.walkBody(func(node *sitter.Node) {
+		if node.Type() == "function_call" && luaFunc.isStdlibCall(node) {
+			count++
+		}
+	})
+	return count
+}
+
+// isStdlibCall reports whether a function call's callee, the identifiers before its argument
+// list, is a base library function or a field of a standard library table
+func (luaFunc *LuaFunction) isStdlibCall(call *sitter.Node) bool {
+	var calleeNames []string
+	for index := 0; index < int(call.ChildCount()); index++ {
+		child := call.Child(index)
+		if child.Type() == "function_call_paren" || call.FieldNameForChild(index) == "args" {
+			break
+		}
+		switch child.Type() {
+		case "identifier":
+			calleeNames = append(calleeNames, nodeText(child, luaFunc.sourceBytes))
+		case ".":
+		default:
+			// A method call, or a callee that is not a plain name path
+			return false
+		}
+	}
+
+	switch len(calleeNames) {
+	case 0:
+		return false
+	case 1:
+		return luaBuiltins[calleeNames[0]]
+	default:
+		return luaStdlibModules[calleeNames[0]]
+	}
+}
+
+// luaNesting lists the Lua constructs that open a nesting level; named nested functions are
+// analyzed on their own and not entered
+var luaNesting = nesting.Language{
+	BlockTypes: map[string]bool{
+		"if_statement":     true,
+		"for_statement":    true,
+		"while_statement":  true,
+		"repeat_statement": true,
+		"function":         true,
+	},
+	Skip: isNestedDefinition,
+}
+
+// MaxNestingDepth calculates the maximum nesting depth of control structures
+func (luaFunc *LuaFunction) MaxNestingDepth() int {
+	return nesting.MaxDepth(luaFunc.body(), luaNesting)
+}
+
+// CalculateCyclomaticComplexity calculates the cyclomatic complexity
+// CC = 1 + count of decision points (if, elseif, for, while, repeat, and, or)
+func (luaFunc *LuaFunction) CalculateCyclomaticComplexity() int {
+	complexity := 1
+	luaFunc.walkBody(func(node *sitter.Node) {
+		switch node.Type() {
+		case "if_statement", "if_elseif", "for_statement", "while_statement", "repeat_statement":
+			complexity++
+		case "binary_operation":
+			if logicalOperator(node) != "" {
+				complexity++
+			}
+		}
+	})
+	return complexity
+}
+
+// CalculateCognitiveComplexity calculates cognitive complexity
+// Adds nesting penalty on top of cyclomatic complexity
+func (luaFunc *LuaFunction) CalculateCognitiveComplexity() int {
+	complexity := 0
+	nestingLevel := 0
+
+	body := luaFunc.body()
+	if body == nil {
+		return 0
+	}
+
+	cursor := sitter.NewTreeCursor(body)
+	defer cursor.Close()
+
+	luaFunc.countCognitiveComplexity(cursor, &complexity, &nestingLevel)
+	return complexity
+}
+
+// countCognitiveComplexity recursively counts cognitive complexity with nesting penalties
+func (luaFunc *LuaFunction) countCognitiveComplexity(cursor *sitter.TreeCursor, complexity *int, nestingLevel *int) {
+	node := cursor.CurrentNode()
+	if isNestedDefinition(node) {
+		return
+	}
+
+	isNesting := false
+	switch node.Type() {
+	case "if_statement", "for_statement", "while_statement", "repeat_statement":
+		*complexity += 1 + *nestingLevel
+		isNesting = true
+	case "if_elseif", "if_else":
+		// Branches add one without a nesting penalty
+		*complexity++
+	case "function":
+		// Anonymous functions nest their contents without adding to complexity
+		isNesting = true
+	case "binary_operation":
+		// A run of the same logical operator counts once
+		operator := logicalOperator(node)
+		if operator != "" {
+			parent := node.Parent()
+			if parent == nil || parent.Type() != "binary_operation" || logicalOperator(parent) != operator {
+				*complexity++
+			}
+		}
+	}
+
+	if isNesting {
+		*nestingLevel++
+	}
+
+	// Recurse to children
+	if cursor.GoToFirstChild() {
+		for {
+			luaFunc.countCognitiveComplexity(cursor, complexity, nestingLevel)
+			if !cursor.GoToNextSibling() {
+				break
+			}
+		}
+		cursor.GoToParent()
+	}
+
+	if isNesting {
+		*nestingLevel--
+	}
+}
+
+// logicalOperator returns "and" or "or" for a binary operation using one, and "" otherwise
+func logicalOperator(node *sitter.Node) string {
+	for index := 0; index < int(node.ChildCount()); index++ {
+		child := node.Child(index)
+		if child.IsNamed() {
+			continue
+		}
+		if operator := child.Type(); operator == "and" || operator == "or" {
+			return operator
+		}
+	}
+	return ""
+}
+
+// walkBody calls visit for every node in the function body, skipping nested functions that
+// are analyzed on their own
+func (luaFunc *LuaFunction) walkBody(visit func(node *sitter.Node)) {
+	body := luaFunc.body()
+	if body == nil {
+		return
+	}
+
+	cursor := sitter.NewTreeCursor(body)
+	defer cursor.Close()
+
+	walkNodes(cursor, visit)
+}
+
+// walkNodes recursively visits the cursor's node and its descendants
+func walkNodes(cursor *sitter.TreeCursor, visit func(node *sitter.Node)) {
+	node := cursor.CurrentNode()
+	if isNestedDefinition(node) {
+		return
+	}
+	visit(node)
+
+	if cursor.GoToFirstChild() {
+		for {
+			walkNodes(cursor, visit)
+			if !cursor.GoToNextSibling() {
+				break
+			}
+		}
+		cursor.GoToParent()
+	}
+}
+
+// isNestedDefinition reports whether a node is a function reported as its own entry
+func isNestedDefinition(node *sitter.Node) bool {
+	switch node.Type() {
+	case "function_statement":
+		return true
+	case "function":
+		return assignedDeclarator(node) != nil
+	}
+	return false
+}
+
+// isAnonymousFunction reports whether a node is a function expression not assigned to a
+// variable, such as a callback passed as an argument. Unlike nested definitions, its body
+// still counts towards the enclosing function.
+func isAnonymousFunction(node *sitter.Node) bool {
+	return node.Type() == "function" && assignedDeclarator(node) == nil
+}
+
+// assignedFunctionName returns the name of the variable a function expression is assigned to,
+// or "" when it is not assigned to exactly one variable
+func assignedFunctionName(node *sitter.Node, sourceBytes []byte) string {
+	declarator := assignedDeclarator(node)
+	if declarator == nil {
+		return ""
+	}
+	return nodeText(declarator, sourceBytes)
+}
+
+// assignedDeclarator returns the variable a function expression is assigned to, as in
+// "local f = function() end" or "M.handler = function() end". Functions passed as arguments,
+// stored in table constructors, or assigned alongside other values return nil.
+func assignedDeclarator(node *sitter.Node) *sitter.Node {
+	parent := node.Parent()
+	if parent == nil || parent.Type() != "variable_declaration" {
+		return nil
+	}
+
+	var declarator *sitter.Node
+	values := 0
+	for index := 0; index < int(parent.NamedChildCount()); index++ {
+		child := parent.NamedChild(index)
+		switch child.Type() {
+		case "local":
+		case "variable_declarator":
+			if declarator != nil {
+				return nil
+			}
+			declarator = child
+		default:
+			values++
+		}
+	}
+	if values != 1 {
+		return nil
+	}
+	return declarator
+}
+
+// nodeText returns a node's source with surrounding whitespace removed; this grammar
+// attaches leading whitespace to the first token of a node
+func nodeText(node *sitter.Node, sourceBytes []byte) string {
+	return strings.TrimSpace(node.Content(sourceBytes))
+}
+
+// startLine returns the 1-based line of a node's first token, skipping leading whitespace that
+// this grammar attaches to it
+func startLine(node *sitter.Node, sourceBytes []byte) int {
+	content := node.Content(sourceBytes)
+	leadingWhitespace := content[:len(content)-len(strings.TrimLeft(content, " \t\r\n"))]
+	return int(node.StartPoint().Row) + 1 + strings.Count(leadingWhitespace, "\n")
+}
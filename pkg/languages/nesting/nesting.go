@@ -0,0 +1,101 @@
+// Package nesting computes how deeply control structures nest inside a function by walking
+// its syntax tree. Counting block-opening nodes rather than braces or indentation keeps the
+// depth independent of formatting style and consistent across languages.
+package nesting
+
+import (
+	"go/ast"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Language lists the tree-sitter node types that open a nesting level in one language
+type Language struct {
+	// BlockTypes are the node types that open a nesting level, e.g. if and loop statements
+	BlockTypes map[string]bool
+	// IfTypes are block types that stay at their parent's level when they directly follow an
+	// else keyword, so an else-if chain counts once however long it is
+	IfTypes map[string]bool
+	// Skip reports subtrees that are not entered, e.g. nested functions analyzed on their own.
+	// May be nil.
+	Skip func(node *sitter.Node) bool
+}
+
+// MaxDepth returns the deepest nesting of language's block types below root. root itself is
+// not counted, so a function without control structures has depth 0.
+func MaxDepth(root *sitter.Node, language Language) int {
+	if root == nil {
+		return 0
+	}
+
+	maxDepth := 0
+	for childIndex := 0; childIndex < int(root.ChildCount()); childIndex++ {
+		walkDepth(root.Child(childIndex), language, 0, &maxDepth)
+	}
+	return maxDepth
+}
+
+// walkDepth visits node at depth, recording the deepest block level reached
+func walkDepth(node *sitter.Node, language Language, depth int, maxDepth *int) {
+	if node == nil {
+		return
+	}
+	if language.Skip != nil && language.Skip(node) {
+		return
+	}
+
+	nodeType := node.Type()
+	if language.BlockTypes[nodeType] && !(language.IfTypes[nodeType] && followsElse(node)) {
+		depth++
+		*maxDepth = max(*maxDepth, depth)
+	}
+
+	for childIndex := 0; childIndex < int(node.ChildCount()); childIndex++ {
+		walkDepth(node.Child(childIndex), language, depth, maxDepth)
+	}
+}
+
+// followsElse reports whether node is the else branch of an if, either directly after the
+// else keyword or as the only child of a wrapper node that is
+func followsElse(node *sitter.Node) bool {
+	for {
+		if previous := node.PrevSibling(); previous != nil {
+			return previous.Type() == "else"
+		}
+
+		parent := node.Parent()
+		if parent == nil || parent.ChildCount() != 1 {
+			return false
+		}
+		node = parent
+	}
+}
+
+// MaxASTDepth returns the deepest nesting of nodes for which opensBlock is true below root,
+// for languages parsed with go/ast rather than tree-sitter
+func MaxASTDepth(root ast.Node, opensBlock func(node ast.Node) bool) int {
+	maxDepth := 0
+	depth := 0
+	var opened []bool // Whether each node on the current path opened a level
+
+	ast.Inspect(root, func(node ast.Node) bool {
+		if node == nil {
+			// Leaving the most recently entered node
+			if opened[len(opened)-1] {
+				depth--
+			}
+			opened = opened[:len(opened)-1]
+			return true
+		}
+
+		opens := node != root && opensBlock(node)
+		if opens {
+			depth++
+			maxDepth = max(maxDepth, depth)
+		}
+		opened = append(opened, opens)
+		return true
+	})
+
+	return maxDepth
+}
@@ -0,0 +1,144 @@
+package nesting
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/kotlin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var kotlinBlocks = Language{
+	BlockTypes: map[string]bool{
+		"if_expression":   true,
+		"for_statement":   true,
+		"when_expression": true,
+		"lambda_literal":  true,
+	},
+	IfTypes: map[string]bool{"if_expression": true},
+	Skip: func(node *sitter.Node) bool {
+		return node.Type() == "function_declaration"
+	},
+}
+
+// parseKotlinFunction returns the first function declaration in source
+func parseKotlinFunction(t *testing.T, source string) *sitter.Node {
+	parser := sitter.NewParser()
+	parser.SetLanguage(kotlin.GetLanguage())
+	tree, err := parser.ParseCtx(context.Background(), nil, []byte(source))
+	require.NoError(t, err)
+	t.Cleanup(tree.Close)
+
+	function := tree.RootNode().NamedChild(0)
+	require.Equal(t, "function_declaration", function.Type())
+	return function
+}
+
+func TestMaxDepthFlat(t *testing.T) {
+	function := parseKotlinFunction(t, "fun flat() {\n    println(1)\n}\n")
+
+	assert.Equal(t, 0, MaxDepth(function, kotlinBlocks))
+}
+
+func TestMaxDepthNestedBlocks(t *testing.T) {
+	function := parseKotlinFunction(t, `fun nested(items: List<Int>) {
+    if (items.isEmpty()) {
+        println(0)
+    }
+    for (item in items) {
+        when (item) {
+            1 -> if (item > 0) { println(item) }
+            else -> println(item)
+        }
+    }
+}
+`)
+
+	assert.Equal(t, 3, MaxDepth(function, kotlinBlocks))
+}
+
+func TestMaxDepthIgnoresFormatting(t *testing.T) {
+	compact := parseKotlinFunction(t, "fun f(a: Boolean) { if (a) { for (x in xs) { println(x) } } }\n")
+	spread := parseKotlinFunction(t, `fun f(a: Boolean)
+{
+    if (a)
+    {
+        for (x in xs)
+            println(x)
+    }
+}
+`)
+
+	assert.Equal(t, 2, MaxDepth(compact, kotlinBlocks))
+	assert.Equal(t, 2, MaxDepth(spread, kotlinBlocks))
+}
+
+func TestMaxDepthElseIfChain(t *testing.T) {
+	function := parseKotlinFunction(t, `fun chained(value: Int) {
+    if (value == 1) {
+        println(1)
+    } else if (value == 2) {
+        println(2)
+    } else if (value == 3) {
+        println(3)
+    }
+}
+`)
+
+	assert.Equal(t, 1, MaxDepth(function, kotlinBlocks))
+}
+
+func TestMaxDepthNestedIfInThenBranch(t *testing.T) {
+	function := parseKotlinFunction(t, "fun f(a: Boolean, b: Boolean) {\n    if (a) if (b) println(1)\n}\n")
+
+	assert.Equal(t, 2, MaxDepth(function, kotlinBlocks))
+}
+
+func TestMaxDepthSkipsSubtrees(t *testing.T) {
+	function := parseKotlinFunction(t, `fun outer() {
+    fun local() {
+        if (true) { if (true) { println(1) } }
+    }
+    listOf(1).forEach { println(it) }
+}
+`)
+
+	assert.Equal(t, 1, MaxDepth(function, kotlinBlocks))
+}
+
+func TestMaxDepthNilRoot(t *testing.T) {
+	assert.Equal(t, 0, MaxDepth(nil, kotlinBlocks))
+}
+
+func TestMaxASTDepth(t *testing.T) {
+	source := `package sample
+
+func sample(items []int) {
+	for _, item := range items {
+		if item > 0 {
+			println(item)
+		}
+	}
+	if len(items) == 0 {
+		println("empty")
+	}
+}
+`
+	file, err := parser.ParseFile(token.NewFileSet(), "sample.go", source, 0)
+	require.NoError(t, err)
+
+	depth := MaxASTDepth(file.Decls[0], func(node ast.Node) bool {
+		switch node.(type) {
+		case *ast.IfStmt, *ast.RangeStmt:
+			return true
+		}
+		return false
+	})
+
+	assert.Equal(t, 2, depth)
+}
@@ -0,0 +1,498 @@
+package objc
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/alexcollie/kaizen/pkg/analyzer"
+	"github.com/alexcollie/kaizen/pkg/languages/anonymous"
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+// go-tree-sitter ships no Objective-C grammar, so this analyzer works on a
+// lexical pass over the source: comments and string literals are blanked out
+// and method bodies are found by matching braces.
+
+// typeDeclarationRegex matches @interface/@protocol/@implementation headers
+var typeDeclarationRegex = regexp.MustCompile(`(?m)^[ \t]*@(interface|protocol|implementation)[ \t]+([A-Za-z_][A-Za-z0-9_]*)[ \t]*(\(?)([^\n]*)$`)
+
+// blockLiteralRegex matches the head of a block literal starting at a caret, with its optional
+// return type and parameter list: "^{", "^(int value) {", "^BOOL (id item) {"
+var blockLiteralRegex = regexp.MustCompile(`^\^[ \t\n]*(?:[A-Za-z_][A-Za-z0-9_ \t*]*)?(?:\(([^()]*)\))?[ \t\n]*\{`)
+
+// ObjCAnalyzer implements the LanguageAnalyzer interface for Objective-C
+type ObjCAnalyzer struct{}
+
+// NewObjCAnalyzer creates a new Objective-C analyzer
+func NewObjCAnalyzer() analyzer.LanguageAnalyzer {
+	return &ObjCAnalyzer{}
+}
+
+// Name returns the language name
+func (objcAnalyzer *ObjCAnalyzer) Name() string {
+	return "Objective-C"
+}
+
+// FileExtensions returns the file extensions this analyzer handles
+func (objcAnalyzer *ObjCAnalyzer) FileExtensions() []string {
+	return []string{".m", ".mm"}
+}
+
+// CanAnalyze checks if this analyzer can handle the given file
+func (objcAnalyzer *ObjCAnalyzer) CanAnalyze(filePath string) bool {
+	ext := filepath.Ext(filePath)
+	for _, supportedExt := range objcAnalyzer.FileExtensions() {
+		if ext == supportedExt {
+			return true
+		}
+	}
+	return false
+}
+
+// IsStub indicates if this is a stub implementation
+func (objcAnalyzer *ObjCAnalyzer) IsStub() bool {
+	return false
+}
+
+// objcCommentSyntax drives commented-out code detection
+var objcCommentSyntax = analyzer.CommentSyntax{
+	LinePrefix: "//",
+	Keywords:   []string{"if", "else", "for", "while", "return", "switch", "case", "NSLog", "@property", "@interface", "@implementation"},
+}
+
+// AnalyzeFile performs full analysis on a single Objective-C file
+func (objcAnalyzer *ObjCAnalyzer) AnalyzeFile(filePath string) (*models.FileAnalysis, error) {
+	sourceBytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return objcAnalyzer.AnalyzeSource(filePath, sourceBytes)
+}
+
+// AnalyzeSource performs full analysis on in-memory Objective-C source reported under filePath
+func (objcAnalyzer *ObjCAnalyzer) AnalyzeSource(filePath string, sourceBytes []byte) (*models.FileAnalysis, error) {
+	sourceCode := string(sourceBytes)
+
+	// Count lines
+	totalLines, codeLines, commentLines, blankLines := objcAnalyzer.countLines(sourceCode)
+
+	// Calculate comment density
+	commentDensity := 0.0
+	if totalLines > 0 {
+		commentDensity = float64(commentLines) / float64(totalLines) * 100
+	}
+
+	// Count imports
+	importCount := objcAnalyzer.countImports(sourceCode)
+
+	// Blank out comments and literals so braces and keywords inside them are ignored
+	strippedSource := stripCommentsAndStrings(sourceCode)
+
+	functions := objcAnalyzer.extractMethods(sourceCode, strippedSource)
+	types := objcAnalyzer.extractTypes(strippedSource, functions)
+
+	return &models.FileAnalysis{
+		Path:                  filePath,
+		Language:              objcAnalyzer.Name(),
+		TotalLines:            totalLines,
+		CodeLines:             codeLines,
+		CommentLines:          commentLines,
+		BlankLines:            blankLines,
+		CommentDensity:        commentDensity,
+		DuplicatedLines:       0, // TODO: Implement duplication detection
+		DuplicationPercentage: 0,
+		ImportCount:           importCount,
+		Functions:             functions,
+		Types:                 types,
+		CommentedCode:         analyzer.FindCommentedCode(sourceCode, objcCommentSyntax),
+	}, nil
+}
+
+// countLines counts different types of lines in the source
+func (objcAnalyzer *ObjCAnalyzer) countLines(sourceCode string) (total, code, comment, blank int) {
+	lines := strings.Split(sourceCode, "\n")
+	total = len(lines)
+
+	inBlockComment := false
+
+	for _, line := range lines {
+		trimmedLine := strings.TrimSpace(line)
+
+		if !inBlockComment && strings.HasPrefix(trimmedLine, "/*") {
+			inBlockComment = true
+		}
+
+		if inBlockComment {
+			comment++
+			if strings.Contains(trimmedLine, "*/") {
+				inBlockComment = false
+			}
+		} else if trimmedLine == "" {
+			blank++
+		} else if strings.HasPrefix(trimmedLine, "//") {
+			comment++
+		} else {
+			code++
+		}
+	}
+
+	return
+}
+
+// countImports counts #import, #include and @import directives
+func (objcAnalyzer *ObjCAnalyzer) countImports(sourceCode string) int {
+	lines := strings.Split(sourceCode, "\n")
+
+	count := 0
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#import") ||
+			strings.HasPrefix(trimmed, "#include") ||
+			strings.HasPrefix(trimmed, "@import ") {
+			count++
+		}
+	}
+	return count
+}
+
+// extractMethods finds every -/+ method definition at the top level of the file, followed
+// by the block literals inside it. Declarations ending in ';' (headers, @interface blocks)
+// are skipped.
+func (objcAnalyzer *ObjCAnalyzer) extractMethods(sourceCode, strippedSource string) []models.FunctionAnalysis {
+	var functions []models.FunctionAnalysis
+	namer := anonymous.NewNamer()
+
+	currentClass := ""
+	braceDepth := 0
+	atLineStart := true
+
+	for offset := 0; offset < len(strippedSource); offset++ {
+		char := strippedSource[offset]
+
+		switch {
+		case char == '\n':
+			atLineStart = true
+			continue
+		case char == ' ' || char == '\t' || char == '\r':
+			continue
+		case char == '{':
+			braceDepth++
+		case char == '}':
+			if braceDepth > 0 {
+				braceDepth--
+			}
+		case atLineStart && braceDepth == 0 && char == '@':
+			if match := typeDeclarationRegex.FindStringSubmatch(strippedSource[offset:lineEnd(strippedSource, offset)]); match != nil && match[1] == "implementation" {
+				currentClass = match[2]
+			} else if strings.HasPrefix(strippedSource[offset:], "@end") {
+				currentClass = ""
+			}
+		case atLineStart && braceDepth == 0 && (char == '-' || char == '+') && isMethodStart(strippedSource, offset):
+			method, endOffset := objcAnalyzer.analyzeMethod(sourceCode, strippedSource, offset, currentClass)
+			if method != nil {
+				functions = append(functions, *method)
+				functions = append(functions, objcAnalyzer.extractBlocks(sourceCode, strippedSource, offset, endOffset, method.Name, namer)...)
+				offset = endOffset
+			}
+		}
+
+		atLineStart = false
+	}
+
+	return functions
+}
+
+// analyzeMethod builds the analysis for the method whose signature starts at offset.
+// It returns nil when the signature is only a declaration.
+func (objcAnalyzer *ObjCAnalyzer) analyzeMethod(sourceCode, strippedSource string, offset int, className string) (*models.FunctionAnalysis, int) {
+	bodyStart := -1
+	for index := offset; index < len(strippedSource); index++ {
+		if strippedSource[index] == ';' {
+			return nil, offset
+		}
+		if strippedSource[index] == '{' {
+			bodyStart = index
+			break
+		}
+	}
+	if bodyStart < 0 {
+		return nil, offset
+	}
+
+	bodyEnd := matchingBrace(strippedSource, bodyStart)
+	if bodyEnd < 0 {
+		bodyEnd = len(strippedSource) - 1
+	}
+
+	signature := strippedSource[offset:bodyStart]
+	selector, parameterCount := parseSelector(signature[1:])
+	if selector == "" {
+		return nil, offset
+	}
+
+	methodName := fmt.Sprintf("%c%s", signature[0], selector)
+	if className != "" {
+		methodName = fmt.Sprintf("%c[%s %s]", signature[0], className, selector)
+	}
+
+	startLine := strings.Count(sourceCode[:offset], "\n") + 1
+	endLine := startLine + strings.Count(sourceCode[offset:bodyEnd+1], "\n")
+
+	method := NewObjCMethod(methodName, startLine, endLine, parameterCount, strippedSource[bodyStart:bodyEnd+1])
+	analysis := analyzeBody(method, startLine, endLine)
+	return &analysis, bodyEnd
+}
+
+// extractBlocks finds the block literals between a method's start and end offsets, including
+// blocks nested in other blocks, and analyzes each one as an anonymous function named after
+// the method
+func (objcAnalyzer *ObjCAnalyzer) extractBlocks(sourceCode, strippedSource string, startOffset, endOffset int, methodName string, namer *anonymous.Namer) []models.FunctionAnalysis {
+	var blocks []models.FunctionAnalysis
+
+	for offset := startOffset; offset < endOffset; offset++ {
+		if strippedSource[offset] != '^' {
+			continue
+		}
+		match := blockLiteralRegex.FindStringSubmatchIndex(strippedSource[offset:endOffset])
+		if match == nil {
+			continue
+		}
+
+		bodyStart := offset + match[1] - 1
+		bodyEnd := matchingBrace(strippedSource, bodyStart)
+		if bodyEnd < 0 || bodyEnd > endOffset {
+			continue
+		}
+
+		parameterCount := 0
+		if match[2] >= 0 {
+			parameterCount = countBlockParameters(strippedSource[offset+match[2] : offset+match[3]])
+		}
+
+		startLine := strings.Count(sourceCode[:offset], "\n") + 1
+		endLine := startLine + strings.Count(sourceCode[offset:bodyEnd+1], "\n")
+
+		block := NewObjCMethod(namer.Name(methodName, "block"), startLine, endLine, parameterCount, strippedSource[bodyStart:bodyEnd+1])
+		analysis := analyzeBody(block, startLine, endLine)
+		analysis.IsAnonymous = true
+		blocks = append(blocks, analysis)
+	}
+
+	return blocks
+}
+
+// countBlockParameters counts the comma-separated parameters of a block literal; "void" and an
+// empty list both declare none
+func countBlockParameters(parameterList string) int {
+	parameterList = strings.TrimSpace(parameterList)
+	if parameterList == "" || parameterList == "void" {
+		return 0
+	}
+	return strings.Count(parameterList, ",") + 1
+}
+
+// analyzeBody builds the analysis of a method or block from its lexical metrics
+func analyzeBody(method *ObjCMethod, startLine, endLine int) models.FunctionAnalysis {
+	cyclomaticComplexity := method.CalculateCyclomaticComplexity()
+	halsteadVol, halsteadDiff, halsteadEffort, halsteadTime := method.CalculateHalstead()
+
+	return models.FunctionAnalysis{
+		Name:                 method.Name(),
+		StartLine:            startLine,
+		EndLine:              endLine,
+		Length:               method.LineCount(),
+		LogicalLines:         method.LogicalLineCount(),
+		ParameterCount:       method.ParameterCount(),
+		ReturnCount:          method.ReturnCount(),
+		CyclomaticComplexity: cyclomaticComplexity,
+		CognitiveComplexity:  method.CalculateCognitiveComplexity(),
+		NestingDepth:         method.MaxNestingDepth(),
+		HalsteadVolume:       halsteadVol,
+		HalsteadDifficulty:   halsteadDiff,
+		HalsteadEffort:       halsteadEffort,
+		HalsteadTime:         halsteadTime,
+		MaintainabilityIndex: analyzer.MaintainabilityIndex(analyzer.MIVariantClassic, halsteadVol, cyclomaticComplexity, method.LineCount(), 0),
+	}
+}
+
+// extractTypes reports @interface and @protocol declarations, attributing
+// implemented methods to their class
+func (objcAnalyzer *ObjCAnalyzer) extractTypes(strippedSource string, functions []models.FunctionAnalysis) []models.TypeAnalysis {
+	var types []models.TypeAnalysis
+
+	for _, match := range typeDeclarationRegex.FindAllStringSubmatch(strippedSource, -1) {
+		keyword, name, openParen, rest := match[1], match[2], match[3], strings.TrimSpace(match[4])
+
+		kind := "class"
+		switch {
+		case keyword == "implementation":
+			continue
+		case keyword == "protocol":
+			// Skip forward declarations such as "@protocol Foo;"
+			if strings.HasPrefix(rest, ";") || strings.HasPrefix(rest, ",") {
+				continue
+			}
+			kind = "protocol"
+		case openParen != "":
+			kind = "category"
+		}
+
+		typeAnalysis := models.TypeAnalysis{Name: name, Kind: kind}
+		if kind == "class" {
+			methodPrefix := "[" + name + " "
+			for _, function := range functions {
+				if !function.IsAnonymous && strings.Contains(function.Name, methodPrefix) {
+					typeAnalysis.Functions = append(typeAnalysis.Functions, function)
+					typeAnalysis.MethodCount++
+					typeAnalysis.WeightedMethodsPerClass += function.CyclomaticComplexity
+				}
+			}
+		}
+
+		types = append(types, typeAnalysis)
+	}
+
+	return types
+}
+
+// isMethodStart reports whether the -/+ at offset begins a method signature, i.e. is followed by a return type
+func isMethodStart(strippedSource string, offset int) bool {
+	for index := offset + 1; index < len(strippedSource); index++ {
+		switch strippedSource[index] {
+		case ' ', '\t':
+			continue
+		case '(':
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// parseSelector extracts the selector name and parameter count from a method signature
+// such as "(void)setName:(NSString *)name age:(int)age"
+func parseSelector(signature string) (string, int) {
+	// Drop parenthesised types so only selector keywords and parameter names remain
+	var withoutTypes strings.Builder
+	parenDepth := 0
+	for _, char := range signature {
+		switch {
+		case char == '(':
+			parenDepth++
+		case char == ')':
+			if parenDepth > 0 {
+				parenDepth--
+			}
+			withoutTypes.WriteRune(' ')
+		case parenDepth == 0:
+			withoutTypes.WriteRune(char)
+		}
+	}
+
+	// Attributes such as NS_REQUIRES_SUPER or __attribute__ trail the selector; ignore them
+	fields := strings.Fields(strings.ReplaceAll(withoutTypes.String(), ":", ": "))
+	if len(fields) == 0 {
+		return "", 0
+	}
+
+	if !strings.HasSuffix(fields[0], ":") {
+		return fields[0], 0
+	}
+
+	var selector strings.Builder
+	parameterCount := 0
+	expectKeyword := true
+	for _, field := range fields {
+		if strings.HasSuffix(field, ":") {
+			if !expectKeyword && field != ":" {
+				break
+			}
+			selector.WriteString(field)
+			parameterCount++
+			expectKeyword = false
+		} else if !expectKeyword {
+			// Parameter name following a keyword
+			expectKeyword = true
+		} else {
+			break
+		}
+	}
+
+	return selector.String(), parameterCount
+}
+
+// stripCommentsAndStrings replaces comments, string and character literals with
+// spaces while preserving newlines, so offsets and line numbers stay valid
+func stripCommentsAndStrings(sourceCode string) string {
+	stripped := []byte(sourceCode)
+
+	blank := func(from, to int) {
+		for index := from; index < to && index < len(stripped); index++ {
+			if stripped[index] != '\n' {
+				stripped[index] = ' '
+			}
+		}
+	}
+
+	for offset := 0; offset < len(sourceCode); offset++ {
+		char := sourceCode[offset]
+		rest := sourceCode[offset:]
+
+		switch {
+		case strings.HasPrefix(rest, "//"):
+			end := lineEnd(sourceCode, offset)
+			blank(offset, end)
+			offset = end - 1
+		case strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest[2:], "*/")
+			if end < 0 {
+				blank(offset, len(sourceCode))
+				return string(stripped)
+			}
+			blank(offset, offset+end+4)
+			offset += end + 3
+		case char == '"' || char == '\'':
+			end := offset + 1
+			for end < len(sourceCode) && sourceCode[end] != char && sourceCode[end] != '\n' {
+				if sourceCode[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			blank(offset+1, end)
+			offset = end
+		}
+	}
+
+	return string(stripped)
+}
+
+// lineEnd returns the offset of the newline ending the line containing offset
+func lineEnd(source string, offset int) int {
+	end := strings.IndexByte(source[offset:], '\n')
+	if end < 0 {
+		return len(source)
+	}
+	return offset + end
+}
+
+// matchingBrace returns the offset of the brace closing the one at openOffset, or -1
+func matchingBrace(strippedSource string, openOffset int) int {
+	depth := 0
+	for index := openOffset; index < len(strippedSource); index++ {
+		switch strippedSource[index] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return index
+			}
+		}
+	}
+	return -1
+}
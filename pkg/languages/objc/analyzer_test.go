@@ -0,0 +1,205 @@
+package objc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+const sampleObjCSource = `#import <Foundation/Foundation.h>
+@import UIKit;
+
+// A simple person model
+@interface Person : NSObject
+- (void)setName:(NSString *)name age:(int)age;
+@end
+
+@protocol Greeter;
+
+@protocol Describable <NSObject>
+- (NSString *)describe;
+@end
+
+@implementation Person
+
+- (void)setName:(NSString *)name age:(int)age {
+    if (name == nil || age < 0) {
+        return;
+    }
+    _name = name;
+}
+
++ (instancetype)personWithItems:(NSArray *)items {
+    for (id item in items) {
+        if ([item isKindOfClass:[NSString class]] && [item length] > 0) {
+            while (true) {
+                break;
+            }
+        }
+    }
+    @try {
+        [self validate];
+    } @catch (NSException *exception) {
+        NSLog(@"if { failed");
+    }
+    switch ([items count]) {
+        case 0:
+            return nil;
+        case 1:
+            return [items firstObject];
+        default:
+            break;
+    }
+    return [items count] > 2 ? [items lastObject] : nil;
+}
+
+- (void)viewDidLoad {
+    [super viewDidLoad];
+}
+
+@end
+`
+
+func analyzeSample(testingT *testing.T, source string) *models.FileAnalysis {
+	testFile := filepath.Join(testingT.TempDir(), "Person.m")
+	require.NoError(testingT, os.WriteFile(testFile, []byte(source), 0644))
+
+	result, err := NewObjCAnalyzer().AnalyzeFile(testFile)
+	require.NoError(testingT, err)
+	return result
+}
+
+func TestCanAnalyze(t *testing.T) {
+	analyzer := NewObjCAnalyzer()
+
+	assert.Equal(t, "Objective-C", analyzer.Name())
+	assert.False(t, analyzer.IsStub())
+	assert.True(t, analyzer.CanAnalyze("Person.m"))
+	assert.True(t, analyzer.CanAnalyze("Bridge.mm"))
+	assert.False(t, analyzer.CanAnalyze("Person.h"))
+	assert.False(t, analyzer.CanAnalyze("Person.swift"))
+}
+
+func TestAnalyzeFileMethods(t *testing.T) {
+	result := analyzeSample(t, sampleObjCSource)
+
+	assert.Equal(t, "Objective-C", result.Language)
+	assert.Equal(t, 2, result.ImportCount)
+	require.Len(t, result.Functions, 3)
+
+	setter := result.Functions[0]
+	assert.Equal(t, "-[Person setName:age:]", setter.Name)
+	assert.Equal(t, 2, setter.ParameterCount)
+	assert.Equal(t, 17, setter.StartLine)
+	assert.Equal(t, 22, setter.EndLine)
+	assert.Equal(t, 3, setter.CyclomaticComplexity) // if + ||
+	assert.Equal(t, 1, setter.NestingDepth)
+	assert.Greater(t, setter.MaintainabilityIndex, 0.0)
+
+	factory := result.Functions[1]
+	assert.Equal(t, "+[Person personWithItems:]", factory.Name)
+	assert.Equal(t, 1, factory.ParameterCount)
+	// for, if, &&, while, @catch, 2 cases, ?: (the "if" inside the string literal is ignored)
+	assert.Equal(t, 9, factory.CyclomaticComplexity)
+	assert.Equal(t, 3, factory.NestingDepth)
+	assert.Equal(t, 3, factory.ReturnCount)
+
+	viewDidLoad := result.Functions[2]
+	assert.Equal(t, "-[Person viewDidLoad]", viewDidLoad.Name)
+	assert.Equal(t, 0, viewDidLoad.ParameterCount)
+	assert.Equal(t, 1, viewDidLoad.CyclomaticComplexity)
+}
+
+func TestAnalyzeFileTypes(t *testing.T) {
+	result := analyzeSample(t, sampleObjCSource)
+
+	require.Len(t, result.Types, 2)
+	assert.Equal(t, "Person", result.Types[0].Name)
+	assert.Equal(t, "class", result.Types[0].Kind)
+	assert.Equal(t, 3, result.Types[0].MethodCount)
+	assert.Equal(t, "Describable", result.Types[1].Name)
+	assert.Equal(t, "protocol", result.Types[1].Kind)
+}
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		signature      string
+		selector       string
+		parameterCount int
+	}{
+		{"(void)viewDidLoad", "viewDidLoad", 0},
+		{"(void)setName:(NSString *)name", "setName:", 1},
+		{"(void)setName:(NSString *)name age:(int)age", "setName:age:", 2},
+		{"(void)move:(int)x :(int)y", "move::", 2},
+		{"(void)log:(NSString *)format, ...", "log:", 1},
+		{"(void)handler:(void (^)(BOOL success))completion", "handler:", 1},
+	}
+
+	for _, tt := range tests {
+		selector, parameterCount := parseSelector(tt.signature)
+		assert.Equal(t, tt.selector, selector, "selector for %s", tt.signature)
+		assert.Equal(t, tt.parameterCount, parameterCount, "parameters for %s", tt.signature)
+	}
+}
+
+func TestCognitiveComplexityNesting(t *testing.T) {
+	body := `{
+    if (a) {
+        for (int i = 0; i < n; i++) {
+            if (b && c && d) {
+            }
+        }
+    } else if (e) {
+    } else {
+    }
+    do {
+    } while (f);
+}`
+	method := NewObjCMethod("sample", 1, 12, 0, body)
+
+	// if(1) + for(2) + if(3) + && sequence(1) + else if(1) + else(1) + do(1)
+	assert.Equal(t, 10, method.CalculateCognitiveComplexity())
+	assert.Equal(t, 3, method.MaxNestingDepth())
+}
+
+func TestAnalyzeFileBlocks(t *testing.T) {
+	source := `@implementation Loader
+- (void)loadWithCompletion:(void (^)(BOOL success))completion {
+    int mask = flags ^ 0x1;
+    dispatch_async(queue, ^{
+        [items enumerateObjectsUsingBlock:^(id item, NSUInteger index, BOOL *stop) {
+            if (item) { *stop = YES; }
+        }];
+    });
+    BOOL (^check)(id) = ^BOOL (id value) { return value != nil; };
+}
+@end
+`
+	result := analyzeSample(t, source)
+
+	require.Len(t, result.Functions, 4)
+	assert.Equal(t, "-[Loader loadWithCompletion:]", result.Functions[0].Name)
+	assert.False(t, result.Functions[0].IsAnonymous)
+
+	outer := result.Functions[1]
+	assert.Equal(t, "-[Loader loadWithCompletion:].block1", outer.Name)
+	assert.True(t, outer.IsAnonymous)
+	assert.Equal(t, 4, outer.StartLine)
+	assert.Equal(t, 8, outer.EndLine)
+	assert.Equal(t, 0, outer.ParameterCount)
+
+	inner := result.Functions[2]
+	assert.Equal(t, "-[Loader loadWithCompletion:].block2", inner.Name)
+	assert.Equal(t, 3, inner.ParameterCount)
+	assert.Equal(t, 2, inner.CyclomaticComplexity)
+
+	typed := result.Functions[3]
+	assert.Equal(t, "-[Loader loadWithCompletion:].block3", typed.Name)
+	assert.Equal(t, 1, typed.ParameterCount)
+	assert.Equal(t, 9, typed.StartLine)
+}
@@ -0,0 +1,295 @@
+package objc
+
+import (
+	"math"
+	"strings"
+)
+
+// ObjCMethod holds the source of a single Objective-C method for metric calculations
+type ObjCMethod struct {
+	name           string
+	startLine      int
+	endLine        int
+	parameterCount int
+	strippedBody   string // Body with comments and literals blanked out
+}
+
+// NewObjCMethod creates a new ObjCMethod
+func NewObjCMethod(name string, startLine, endLine, parameterCount int, strippedBody string) *ObjCMethod {
+	return &ObjCMethod{
+		name:           name,
+		startLine:      startLine,
+		endLine:        endLine,
+		parameterCount: parameterCount,
+		strippedBody:   strippedBody,
+	}
+}
+
+// Name returns the method name
+func (objcMethod *ObjCMethod) Name() string {
+	return objcMethod.name
+}
+
+// LineCount returns the total lines (including blank/comments)
+func (objcMethod *ObjCMethod) LineCount() int {
+	return objcMethod.endLine - objcMethod.startLine + 1
+}
+
+// LogicalLineCount returns the number of lines containing code
+func (objcMethod *ObjCMethod) LogicalLineCount() int {
+	count := 0
+	for _, line := range strings.Split(objcMethod.strippedBody, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && trimmed != "{" && trimmed != "}" {
+			count++
+		}
+	}
+	return count
+}
+
+// ParameterCount returns the number of selector arguments
+func (objcMethod *ObjCMethod) ParameterCount() int {
+	return objcMethod.parameterCount
+}
+
+// ReturnCount returns the number of return statements
+func (objcMethod *ObjCMethod) ReturnCount() int {
+	count := 0
+	for _, token := range tokenize(objcMethod.strippedBody) {
+		if token == "return" {
+			count++
+		}
+	}
+	return count
+}
+
+// CalculateCyclomaticComplexity calculates McCabe's cyclomatic complexity.
+// Each if/for/while/case/@catch, boolean operator and ternary adds a path.
+func (objcMethod *ObjCMethod) CalculateCyclomaticComplexity() int {
+	complexity := 1
+
+	for _, token := range tokenize(objcMethod.strippedBody) {
+		switch token {
+		case "if", "for", "while", "case", "@catch", "&&", "||", "?":
+			complexity++
+		}
+	}
+
+	return complexity
+}
+
+// CalculateCognitiveComplexity calculates cognitive complexity, penalising
+// control structures by how deeply they are nested
+func (objcMethod *ObjCMethod) CalculateCognitiveComplexity() int {
+	complexity := 0
+	objcMethod.walkControlFlow(func(token string, nesting int, afterElse bool) {
+		switch token {
+		case "if":
+			if afterElse {
+				complexity++
+			} else {
+				complexity += 1 + nesting
+			}
+		case "else", "&&", "||":
+			complexity++
+		default:
+			complexity += 1 + nesting
+		}
+	})
+	return complexity
+}
+
+// MaxNestingDepth returns the deepest nesting of control structures
+func (objcMethod *ObjCMethod) MaxNestingDepth() int {
+	maxDepth := 0
+	objcMethod.walkControlFlow(func(token string, nesting int, afterElse bool) {
+		if token == "&&" || token == "||" || token == "?" || token == "else" || afterElse {
+			return
+		}
+		if nesting+1 > maxDepth {
+			maxDepth = nesting + 1
+		}
+	})
+	return maxDepth
+}
+
+// walkControlFlow visits each control structure in the body along with the number
+// of enclosing control blocks. "else if" is reported as a single "if" with afterElse
+// set, and sequences of the same boolean operator are reported once.
+func (objcMethod *ObjCMethod) walkControlFlow(visit func(token string, nesting int, afterElse bool)) {
+	type braceFrame struct {
+		isControl bool
+		isDo      bool
+	}
+
+	var braceStack []braceFrame
+	nesting := 0
+	pendingBlock := false
+	pendingDo := false
+	closedDoBlock := false
+	parenDepth := 0
+	lastLogicalOperator := ""
+
+	tokens := tokenize(objcMethod.strippedBody)
+	// Skip the method's own braces
+	if len(tokens) >= 2 && tokens[0] == "{" {
+		tokens = tokens[1 : len(tokens)-1]
+	}
+
+	for index, token := range tokens {
+		previousToken := ""
+		if index > 0 {
+			previousToken = tokens[index-1]
+		}
+
+		switch token {
+		case "if":
+			visit(token, nesting, previousToken == "else")
+			pendingBlock = true
+		case "for", "switch", "@catch":
+			visit(token, nesting, false)
+			pendingBlock = true
+		case "do":
+			visit(token, nesting, false)
+			pendingBlock = true
+			pendingDo = true
+		case "while":
+			// The trailing while of a do/while loop was already counted at "do"
+			if !closedDoBlock {
+				visit(token, nesting, false)
+				pendingBlock = true
+			}
+		case "else":
+			if index+1 >= len(tokens) || tokens[index+1] != "if" {
+				visit(token, nesting, false)
+			}
+			pendingBlock = true
+		case "?":
+			visit(token, nesting, false)
+		case "&&", "||":
+			if token != lastLogicalOperator {
+				visit(token, nesting, false)
+			}
+			lastLogicalOperator = token
+		case "(":
+			parenDepth++
+		case ")":
+			if parenDepth > 0 {
+				parenDepth--
+			}
+		case ";":
+			lastLogicalOperator = ""
+			if parenDepth == 0 {
+				pendingBlock = false
+			}
+		case "{":
+			lastLogicalOperator = ""
+			braceStack = append(braceStack, braceFrame{isControl: pendingBlock, isDo: pendingDo})
+			if pendingBlock {
+				nesting++
+			}
+			pendingBlock = false
+			pendingDo = false
+		}
+
+		closedDoBlock = false
+		if token == "}" {
+			lastLogicalOperator = ""
+			if len(braceStack) > 0 {
+				frame := braceStack[len(braceStack)-1]
+				braceStack = braceStack[:len(braceStack)-1]
+				if frame.isControl {
+					nesting--
+				}
+				closedDoBlock = frame.isDo
+			}
+		}
+	}
+}
+
+// CalculateHalstead calculates Halstead volume and difficulty for the method body
+func (objcMethod *ObjCMethod) CalculateHalstead() (volume, difficulty, effort, timeToUnderstand float64) {
+	operators := make(map[string]bool)
+	operands := make(map[string]bool)
+	totalOperators := 0
+	totalOperands := 0
+
+	for _, token := range tokenize(objcMethod.strippedBody) {
+		if isIdentifierStart(token[0]) || token[0] == '@' || isDigit(token[0]) {
+			operands[token] = true
+			totalOperands++
+		} else {
+			operators[token] = true
+			totalOperators++
+		}
+	}
+
+	distinctOperators := len(operators)
+	distinctOperands := len(operands)
+
+	if distinctOperators == 0 || distinctOperands == 0 {
+		return 0, 0, 0, 0
+	}
+
+	// Halstead Volume = (N1 + N2) * log2(n1 + n2)
+	vocab := float64(distinctOperators + distinctOperands)
+	length := float64(totalOperators + totalOperands)
+	volume = length * math.Log2(vocab)
+
+	// Halstead Difficulty = (n1/2) * (N2/n2)
+	difficulty = (float64(distinctOperators) / 2.0) * (float64(totalOperands) / float64(distinctOperands))
+
+	// Effort = Volume * Difficulty
+	effort = volume * difficulty
+
+	// Time to understand in seconds = Effort / 18
+	timeToUnderstand = effort / 18.0
+
+	return volume, difficulty, effort, timeToUnderstand
+}
+
+// tokenize splits stripped source into identifiers (including @keywords), numbers,
+// two-character boolean operators and single punctuation characters
+func tokenize(strippedSource string) []string {
+	var tokens []string
+
+	for offset := 0; offset < len(strippedSource); offset++ {
+		char := strippedSource[offset]
+
+		switch {
+		case char == ' ' || char == '\t' || char == '\n' || char == '\r':
+			continue
+		case isIdentifierStart(char) || (char == '@' && offset+1 < len(strippedSource) && isIdentifierStart(strippedSource[offset+1])):
+			end := offset + 1
+			for end < len(strippedSource) && (isIdentifierStart(strippedSource[end]) || isDigit(strippedSource[end])) {
+				end++
+			}
+			tokens = append(tokens, strippedSource[offset:end])
+			offset = end - 1
+		case isDigit(char):
+			end := offset + 1
+			for end < len(strippedSource) && (isIdentifierStart(strippedSource[end]) || isDigit(strippedSource[end]) || strippedSource[end] == '.') {
+				end++
+			}
+			tokens = append(tokens, strippedSource[offset:end])
+			offset = end - 1
+		case offset+1 < len(strippedSource) && (strippedSource[offset:offset+2] == "&&" || strippedSource[offset:offset+2] == "||"):
+			tokens = append(tokens, strippedSource[offset:offset+2])
+			offset++
+		default:
+			tokens = append(tokens, string(char))
+		}
+	}
+
+	return tokens
+}
+
+// isIdentifierStart checks if a character can begin an identifier
+func isIdentifierStart(char byte) bool {
+	return (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z') || char == '_'
+}
+
+// isDigit checks if a character is a decimal digit
+func isDigit(char byte) bool {
+	return char >= '0' && char <= '9'
+}
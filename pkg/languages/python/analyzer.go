@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/alexcollie/kaizen/pkg/analyzer"
+	"github.com/alexcollie/kaizen/pkg/languages/anonymous"
 	"github.com/alexcollie/kaizen/pkg/models"
 	"github.com/smacker/go-tree-sitter"
 	"github.com/smacker/go-tree-sitter/python"
@@ -54,12 +55,23 @@ func (pyAnalyzer *PythonAnalyzer) IsStub() bool {
 	return false // Python analyzer is fully implemented
 }
 
+// pythonCommentSyntax drives commented-out code detection
+var pythonCommentSyntax = analyzer.CommentSyntax{
+	LinePrefix: "#",
+	Keywords:   []string{"def", "class", "if", "elif", "else", "for", "while", "return", "import", "from", "try", "except", "with", "raise", "print"},
+}
+
 // AnalyzeFile performs full analysis on a single Python file
 func (pyAnalyzer *PythonAnalyzer) AnalyzeFile(filePath string) (*models.FileAnalysis, error) {
 	sourceBytes, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
+	return pyAnalyzer.AnalyzeSource(filePath, sourceBytes)
+}
+
+// AnalyzeSource performs full analysis on in-memory Python source reported under filePath
+func (pyAnalyzer *PythonAnalyzer) AnalyzeSource(filePath string, sourceBytes []byte) (*models.FileAnalysis, error) {
 	sourceCode := string(sourceBytes)
 
 	// Keep existing line counting (works well for docstrings/comments)
@@ -100,16 +112,20 @@ func (pyAnalyzer *PythonAnalyzer) AnalyzeFile(filePath string) (*models.FileAnal
 		ImportCount:           importCount,
 		Functions:             functions,
 		Types:                 types,
+		CommentedCode:         analyzer.FindCommentedCode(sourceCode, pythonCommentSyntax),
 	}, nil
 }
 
-// countLines counts different types of lines in Python source
+// countLines counts different types of lines in Python source. String state is tracked across
+// the whole file, so a # inside a string literal is code, and only triple-quoted strings that
+// form a statement of their own (docstrings) count as comments; a triple-quoted string assigned
+// or passed somewhere is code.
 func (pyAnalyzer *PythonAnalyzer) countLines(sourceCode string) (total, code, comment, blank int) {
 	lines := strings.Split(sourceCode, "\n")
 	total = len(lines)
 
-	inMultilineString := false
-	multilineDelimiter := ""
+	openDelimiter := "" // Triple-quote delimiter of a string still open at the end of the previous line
+	inDocstring := false
 
 	for _, line := range lines {
 		trimmedLine := strings.TrimSpace(line)
@@ -119,47 +135,121 @@ func (pyAnalyzer *PythonAnalyzer) countLines(sourceCode string) (total, code, co
 			continue
 		}
 
-		// Handle multiline strings (docstrings)
-		if inMultilineString {
-			comment++
-			if strings.Contains(line, multilineDelimiter) {
-				inMultilineString = false
-				multilineDelimiter = ""
+		// Offset where code resumes after a string running from the start of the line
+		codeStart := 0
+		startsInDocstring := false
+
+		if openDelimiter != "" {
+			closeEnd := skipPythonString(trimmedLine, 0, openDelimiter)
+			if closeEnd < 0 {
+				if inDocstring {
+					comment++
+				} else {
+					code++
+				}
+				continue
 			}
-			continue
-		}
-
-		// Check for start of multiline string
-		if strings.HasPrefix(trimmedLine, `"""`) || strings.HasPrefix(trimmedLine, `'''`) {
-			delimiter := trimmedLine[:3]
-			// Check if it ends on the same line
-			restOfLine := trimmedLine[3:]
-			if strings.Contains(restOfLine, delimiter) {
-				comment++
-			} else {
-				inMultilineString = true
-				multilineDelimiter = delimiter
+			codeStart = closeEnd
+			startsInDocstring = inDocstring
+		} else if delimiter, openEnd := docstringOpening(trimmedLine); delimiter != "" {
+			closeEnd := skipPythonString(trimmedLine, openEnd, delimiter)
+			if closeEnd < 0 {
+				openDelimiter = delimiter
+				inDocstring = true
 				comment++
+				continue
 			}
-			continue
+			codeStart = closeEnd
+			startsInDocstring = true
 		}
 
-		// Single-line comment
-		if strings.HasPrefix(trimmedLine, "#") {
+		var hasCode bool
+		openDelimiter, hasCode = scanPythonCode(trimmedLine, codeStart)
+		inDocstring = false
+
+		// A line without code is a # comment or the end of a docstring; the end of any other
+		// multiline string is still part of a statement
+		if hasCode || (codeStart > 0 && !startsInDocstring) {
+			code++
+		} else {
 			comment++
-			continue
 		}
-
-		code++
 	}
 
 	return
 }
 
+// docstringOpening returns the triple-quote delimiter and the offset just after it when line
+// starts with a triple-quoted string, optionally prefixed (r, u, b, f, or two of them)
+func docstringOpening(line string) (delimiter string, openEnd int) {
+	prefixLength := 0
+	for prefixLength < len(line) && prefixLength < 2 && strings.ContainsRune("rRuUbBfF", rune(line[prefixLength])) {
+		prefixLength++
+	}
+
+	rest := line[prefixLength:]
+	for _, tripleQuote := range []string{`"""`, `'''`} {
+		if strings.HasPrefix(rest, tripleQuote) {
+			return tripleQuote, prefixLength + len(tripleQuote)
+		}
+	}
+	return "", 0
+}
+
+// skipPythonString returns the offset just after the delimiter closing a string whose contents
+// start at start in line, or -1 when the string is still open at the end of the line.
+// Backslashes escape the next character.
+func skipPythonString(line string, start int, delimiter string) int {
+	for index := start; index < len(line); index++ {
+		if line[index] == '\\' {
+			index++
+			continue
+		}
+		if strings.HasPrefix(line[index:], delimiter) {
+			return index + len(delimiter)
+		}
+	}
+	return -1
+}
+
+// scanPythonCode scans line from start, outside any string, up to the end or a # comment. It
+// returns the delimiter of a triple-quoted string left open at the end of the line and whether
+// anything other than whitespace came before the comment.
+func scanPythonCode(line string, start int) (openDelimiter string, hasCode bool) {
+	for index := start; index < len(line); index++ {
+		character := line[index]
+		switch {
+		case character == '#':
+			return "", hasCode
+		case character == '"' || character == '\'':
+			hasCode = true
+			delimiter := string(character)
+			if strings.HasPrefix(line[index:], strings.Repeat(delimiter, 3)) {
+				delimiter = strings.Repeat(delimiter, 3)
+			}
+
+			closeEnd := skipPythonString(line, index+len(delimiter), delimiter)
+			if closeEnd < 0 {
+				if len(delimiter) == 3 {
+					return delimiter, true
+				}
+				// An unterminated single-quoted string ends with the line
+				return "", true
+			}
+			index = closeEnd - 1
+		case character != ' ' && character != '\t':
+			hasCode = true
+		}
+	}
+	return "", hasCode
+}
+
+// pythonImportPattern matches the start of an import or from-import statement
+var pythonImportPattern = regexp.MustCompile(`(?m)^(?:from\s+\S+\s+)?import\s+`)
+
 // countImports counts import statements
 func (pyAnalyzer *PythonAnalyzer) countImports(sourceCode string) int {
-	importPattern := regexp.MustCompile(`(?m)^(?:from\s+\S+\s+)?import\s+`)
-	matches := importPattern.FindAllString(sourceCode, -1)
+	matches := pythonImportPattern.FindAllString(sourceCode, -1)
 	return len(matches)
 }
 
@@ -170,46 +260,36 @@ func (pyAnalyzer *PythonAnalyzer) extractFunctions(rootNode *sitter.Node, source
 	cursor := sitter.NewTreeCursor(rootNode)
 	defer cursor.Close()
 
-	pyAnalyzer.walkFunctions(cursor, sourceBytes, &functions)
+	pyAnalyzer.walkFunctions(cursor, sourceBytes, anonymous.NewNamer(), &functions)
 	return functions
 }
 
-// walkFunctions recursively walks the AST to find all function definitions
-func (pyAnalyzer *PythonAnalyzer) walkFunctions(cursor *sitter.TreeCursor, sourceBytes []byte, functions *[]models.FunctionAnalysis) {
+// walkFunctions recursively walks the AST to find all function definitions. Lambdas are
+// reported as anonymous functions named after the function enclosing them.
+func (pyAnalyzer *PythonAnalyzer) walkFunctions(cursor *sitter.TreeCursor, sourceBytes []byte, namer *anonymous.Namer, functions *[]models.FunctionAnalysis) {
 	node := cursor.CurrentNode()
 	nodeType := node.Type()
 
 	// Handle both regular and async functions
 	if nodeType == "function_definition" || nodeType == "async_function_definition" {
 		funcAnalysis := pyAnalyzer.analyzeFunctionNode(node, sourceBytes)
+		funcAnalysis.IsExported = pyAnalyzer.isPublicDefinition(node, funcAnalysis.Name, sourceBytes)
 		*functions = append(*functions, funcAnalysis)
 	}
 
-	// Handle decorated functions (decorators wrap the function_definition node)
-	if nodeType == "decorated_definition" {
-		// Find the actual function definition within the decorator
-		decoratedCursor := sitter.NewTreeCursor(node)
-		if decoratedCursor.GoToFirstChild() {
-			for {
-				childNode := decoratedCursor.CurrentNode()
-				childType := childNode.Type()
-				if childType == "function_definition" || childType == "async_function_definition" {
-					funcAnalysis := pyAnalyzer.analyzeFunctionNode(childNode, sourceBytes)
-					*functions = append(*functions, funcAnalysis)
-					break
-				}
-				if !decoratedCursor.GoToNextSibling() {
-					break
-				}
-			}
-		}
-		decoratedCursor.Close()
+	// The lambda keyword inside the expression is an unnamed node of the same type
+	if nodeType == "lambda" && node.IsNamed() {
+		funcAnalysis := pyAnalyzer.analyzeFunctionNode(node, sourceBytes)
+		funcAnalysis.Name = namer.Name(enclosingFunctionName(node, sourceBytes), "lambda")
+		funcAnalysis.IsAnonymous = true
+		*functions = append(*functions, funcAnalysis)
 	}
 
-	// Recurse to children
+	// Recurse to children; decorated functions are reached through the decorated_definition
+	// node wrapping them
 	if cursor.GoToFirstChild() {
 		for {
-			pyAnalyzer.walkFunctions(cursor, sourceBytes, functions)
+			pyAnalyzer.walkFunctions(cursor, sourceBytes, namer, functions)
 			if !cursor.GoToNextSibling() {
 				break
 			}
@@ -218,19 +298,52 @@ func (pyAnalyzer *PythonAnalyzer) walkFunctions(cursor *sitter.TreeCursor, sourc
 	}
 }
 
+// enclosingFunctionName returns the name of the nearest function definition around node, or ""
+// at module or class level
+func enclosingFunctionName(node *sitter.Node, sourceBytes []byte) string {
+	for parent := node.Parent(); parent != nil; parent = parent.Parent() {
+		if parent.Type() == "function_definition" || parent.Type() == "async_function_definition" {
+			return NewPythonFunction(parent, sourceBytes).Name()
+		}
+	}
+	return ""
+}
+
+// isPublicDefinition reports whether a function or class named name is part of its module's API:
+// neither it nor a class around it has a name with a leading underscore, and it is not local to
+// a function
+func (pyAnalyzer *PythonAnalyzer) isPublicDefinition(node *sitter.Node, name string, sourceBytes []byte) bool {
+	if strings.HasPrefix(name, "_") {
+		return false
+	}
+	for parent := node.Parent(); parent != nil; parent = parent.Parent() {
+		switch parent.Type() {
+		case "function_definition", "async_function_definition", "lambda":
+			return false
+		case "class_definition":
+			if strings.HasPrefix(pyAnalyzer.extractClassName(parent, sourceBytes), "_") {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // analyzeFunctionNode analyzes a single function node
 func (pyAnalyzer *PythonAnalyzer) analyzeFunctionNode(node *sitter.Node, sourceBytes []byte) models.FunctionAnalysis {
 	pythonFunc := NewPythonFunction(node, sourceBytes)
 
 	// Calculate Halstead metrics
 	funcCode := node.Content(sourceBytes)
-	halsteadVol, halsteadDiff := pyAnalyzer.calculateHalsteadMetrics(funcCode)
+	halsteadVol, halsteadDiff, halsteadEffort, halsteadTime := pyAnalyzer.calculateHalsteadMetrics(funcCode)
 
 	// Calculate maintainability index
-	maintainabilityIndex := pyAnalyzer.calculateMaintainabilityIndex(
+	maintainabilityIndex := analyzer.MaintainabilityIndex(
+		analyzer.MIVariantClassic,
 		halsteadVol,
 		pythonFunc.CalculateCyclomaticComplexity(),
 		pythonFunc.LineCount(),
+		0,
 	)
 
 	return models.FunctionAnalysis{
@@ -247,22 +360,21 @@ func (pyAnalyzer *PythonAnalyzer) analyzeFunctionNode(node *sitter.Node, sourceB
 		NestingDepth:         pythonFunc.MaxNestingDepth(),
 		HalsteadVolume:       halsteadVol,
 		HalsteadDifficulty:   halsteadDiff,
+		HalsteadEffort:       halsteadEffort,
+		HalsteadTime:         halsteadTime,
 		MaintainabilityIndex: maintainabilityIndex,
-		FanIn:                0,
+		HasDocComment:        pythonFunc.HasDocstring(),
+		FanIn:                0, // Set by the pipeline once all files are analyzed
 		FanOut:               pythonFunc.CountFunctionCalls(),
+		StdlibCalls:          pythonFunc.CountStdlibCalls(),
 	}
 }
 
 
-// calculateHalsteadMetrics calculates Halstead complexity metrics for Python
-func (pyAnalyzer *PythonAnalyzer) calculateHalsteadMetrics(funcCode string) (volume, difficulty float64) {
-	operators := make(map[string]bool)
-	operands := make(map[string]bool)
-	totalOperators := 0
-	totalOperands := 0
-
-	// Python operators
-	operatorPatterns := []string{
+// Halstead patterns are compiled once rather than for every function measured
+var (
+	// pythonOperatorPatterns match Python operators, keywords, and punctuation
+	pythonOperatorPatterns = compilePatterns(
 		`\+`, `-`, `\*`, `/`, `//`, `%`, `\*\*`,
 		`==`, `!=`, `<`, `>`, `<=`, `>=`,
 		`=`, `\+=`, `-=`, `\*=`, `/=`,
@@ -270,49 +382,67 @@ func (pyAnalyzer *PythonAnalyzer) calculateHalsteadMetrics(funcCode string) (vol
 		`\[`, `\]`, `\(`, `\)`, `\{`, `\}`,
 		`:`, `,`, `\.`, `->`,
 		`if`, `else`, `elif`, `for`, `while`, `try`, `except`, `return`, `def`, `class`,
+	)
+
+	// pythonIdentifierPattern matches identifiers, which count as operands unless they are keywords
+	pythonIdentifierPattern = regexp.MustCompile(`\b([a-zA-Z_][a-zA-Z0-9_]*)\b`)
+
+	// pythonLiteralPatterns match number and string literals
+	pythonLiteralPatterns = compilePatterns(
+		`\d+\.?\d*`,      // Numbers
+		`"[^"]*"`,        // Double-quoted strings
+		`'[^']*'`,        // Single-quoted strings
+		`"""[\s\S]*?"""`, // Triple double-quoted
+		`'''[\s\S]*?'''`, // Triple single-quoted
+	)
+)
+
+// pythonKeywords are identifiers that are not counted as Halstead operands
+var pythonKeywords = map[string]bool{
+	"if": true, "else": true, "elif": true, "for": true, "while": true,
+	"try": true, "except": true, "finally": true, "return": true,
+	"def": true, "class": true, "import": true, "from": true,
+	"and": true, "or": true, "not": true, "in": true, "is": true,
+	"True": true, "False": true, "None": true,
+	"pass": true, "break": true, "continue": true, "raise": true,
+	"with": true, "as": true, "global": true, "nonlocal": true,
+	"lambda": true, "yield": true, "assert": true, "del": true,
+}
+
+// compilePatterns compiles each pattern, panicking on an invalid one like regexp.MustCompile
+func compilePatterns(patterns ...string) []*regexp.Regexp {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		compiled = append(compiled, regexp.MustCompile(pattern))
 	}
+	return compiled
+}
+
+// calculateHalsteadMetrics calculates Halstead complexity metrics for Python
+func (pyAnalyzer *PythonAnalyzer) calculateHalsteadMetrics(funcCode string) (volume, difficulty, effort, timeToUnderstand float64) {
+	operators := make(map[string]bool)
+	operands := make(map[string]bool)
+	totalOperators := 0
+	totalOperands := 0
 
-	for _, op := range operatorPatterns {
-		re := regexp.MustCompile(op)
+	for _, re := range pythonOperatorPatterns {
 		matches := re.FindAllString(funcCode, -1)
 		if len(matches) > 0 {
-			operators[op] = true
+			operators[re.String()] = true
 			totalOperators += len(matches)
 		}
 	}
 
 	// Operands: identifiers and literals
-	identPattern := regexp.MustCompile(`\b([a-zA-Z_][a-zA-Z0-9_]*)\b`)
-	identMatches := identPattern.FindAllStringSubmatch(funcCode, -1)
-	keywords := map[string]bool{
-		"if": true, "else": true, "elif": true, "for": true, "while": true,
-		"try": true, "except": true, "finally": true, "return": true,
-		"def": true, "class": true, "import": true, "from": true,
-		"and": true, "or": true, "not": true, "in": true, "is": true,
-		"True": true, "False": true, "None": true,
-		"pass": true, "break": true, "continue": true, "raise": true,
-		"with": true, "as": true, "global": true, "nonlocal": true,
-		"lambda": true, "yield": true, "assert": true, "del": true,
-	}
-
+	identMatches := pythonIdentifierPattern.FindAllStringSubmatch(funcCode, -1)
 	for _, match := range identMatches {
-		if len(match) > 1 && !keywords[match[1]] {
+		if len(match) > 1 && !pythonKeywords[match[1]] {
 			operands[match[1]] = true
 			totalOperands++
 		}
 	}
 
-	// Literals
-	literalPatterns := []string{
-		`\d+\.?\d*`,       // Numbers
-		`"[^"]*"`,         // Double-quoted strings
-		`'[^']*'`,         // Single-quoted strings
-		`"""[\s\S]*?"""`,  // Triple double-quoted
-		`'''[\s\S]*?'''`,  // Triple single-quoted
-	}
-
-	for _, pattern := range literalPatterns {
-		re := regexp.MustCompile(pattern)
+	for _, re := range pythonLiteralPatterns {
 		matches := re.FindAllString(funcCode, -1)
 		for _, match := range matches {
 			operands[match] = true
@@ -324,7 +454,7 @@ func (pyAnalyzer *PythonAnalyzer) calculateHalsteadMetrics(funcCode string) (vol
 	distinctOperands := len(operands)
 
 	if distinctOperators == 0 || distinctOperands == 0 {
-		return 0, 0
+		return 0, 0, 0, 0
 	}
 
 	vocab := float64(distinctOperators + distinctOperands)
@@ -335,38 +465,16 @@ func (pyAnalyzer *PythonAnalyzer) calculateHalsteadMetrics(funcCode string) (vol
 		difficulty = (float64(distinctOperators) / 2.0) * (float64(totalOperands) / float64(distinctOperands))
 	}
 
-	return volume, difficulty
-}
-
-
-// calculateMaintainabilityIndex calculates the maintainability index
-func (pyAnalyzer *PythonAnalyzer) calculateMaintainabilityIndex(halsteadVolume float64, cyclomaticComplexity int, linesOfCode int) float64 {
-	if linesOfCode == 0 {
-		return 100
-	}
-
-	// MI = 171 - 5.2 * ln(HV) - 0.23 * CC - 16.2 * ln(LOC)
-	hvTerm := 0.0
-	if halsteadVolume > 0 {
-		hvTerm = 5.2 * math.Log(halsteadVolume)
-	}
-
-	ccTerm := 0.23 * float64(cyclomaticComplexity)
-	locTerm := 16.2 * math.Log(float64(linesOfCode))
+	// Effort = Volume * Difficulty
+	effort = volume * difficulty
 
-	maintainabilityIndex := 171 - hvTerm - ccTerm - locTerm
+	// Time to understand in seconds = Effort / 18
+	timeToUnderstand = effort / 18.0
 
-	// Normalize to 0-100
-	if maintainabilityIndex < 0 {
-		maintainabilityIndex = 0
-	}
-	if maintainabilityIndex > 100 {
-		maintainabilityIndex = 100
-	}
-
-	return maintainabilityIndex
+	return volume, difficulty, effort, timeToUnderstand
 }
 
+
 // extractTypes extracts class definitions using AST
 func (pyAnalyzer *PythonAnalyzer) extractTypes(rootNode *sitter.Node, sourceBytes []byte) []models.TypeAnalysis {
 	var types []models.TypeAnalysis
@@ -388,26 +496,8 @@ func (pyAnalyzer *PythonAnalyzer) walkTypes(cursor *sitter.TreeCursor, sourceByt
 		*types = append(*types, typeAnalysis)
 	}
 
-	// Handle decorated classes
-	if nodeType == "decorated_definition" {
-		decoratedCursor := sitter.NewTreeCursor(node)
-		if decoratedCursor.GoToFirstChild() {
-			for {
-				childNode := decoratedCursor.CurrentNode()
-				if childNode.Type() == "class_definition" {
-					typeAnalysis := pyAnalyzer.analyzeClassNode(childNode, sourceBytes)
-					*types = append(*types, typeAnalysis)
-					break
-				}
-				if !decoratedCursor.GoToNextSibling() {
-					break
-				}
-			}
-		}
-		decoratedCursor.Close()
-	}
-
-	// Recurse to children
+	// Recurse to children; decorated classes are reached through the decorated_definition node
+	// wrapping them
 	if cursor.GoToFirstChild() {
 		for {
 			pyAnalyzer.walkTypes(cursor, sourceBytes, types)
@@ -422,11 +512,12 @@ func (pyAnalyzer *PythonAnalyzer) walkTypes(cursor *sitter.TreeCursor, sourceByt
 // analyzeClassNode analyzes a single class node
 func (pyAnalyzer *PythonAnalyzer) analyzeClassNode(node *sitter.Node, sourceBytes []byte) models.TypeAnalysis {
 	className := pyAnalyzer.extractClassName(node, sourceBytes)
-	methodCount := pyAnalyzer.countMethods(node)
+	methodCount, weightedMethods, publicMethods := pyAnalyzer.countMethods(node, sourceBytes)
 
 	return models.TypeAnalysis{
 		Name:                    className,
 		Kind:                    "class",
+		IsExported:              pyAnalyzer.isPublicDefinition(node, className, sourceBytes),
 		AfferentCoupling:        0,
 		EfferentCoupling:        0,
 		Instability:             0,
@@ -434,8 +525,8 @@ func (pyAnalyzer *PythonAnalyzer) analyzeClassNode(node *sitter.Node, sourceByte
 		DepthOfInheritance:      0,
 		NumberOfChildren:        0,
 		MethodCount:             methodCount,
-		WeightedMethodsPerClass: 0,
-		PublicMethodCount:       0,
+		WeightedMethodsPerClass: weightedMethods,
+		PublicMethodCount:       publicMethods,
 	}
 }
 
@@ -459,24 +550,29 @@ func (pyAnalyzer *PythonAnalyzer) extractClassName(node *sitter.Node, sourceByte
 	return "unknown"
 }
 
-// countMethods counts methods in a class
-func (pyAnalyzer *PythonAnalyzer) countMethods(classNode *sitter.Node) int {
-	count := 0
+// countMethods counts methods in a class, sums their cyclomatic complexity, and counts those
+// without a leading underscore in their name
+func (pyAnalyzer *PythonAnalyzer) countMethods(classNode *sitter.Node, sourceBytes []byte) (count, weighted, public int) {
 	cursor := sitter.NewTreeCursor(classNode)
 	defer cursor.Close()
 
-	pyAnalyzer.countMethodsRecursive(cursor, &count)
-	return count
+	pyAnalyzer.countMethodsRecursive(cursor, sourceBytes, &count, &weighted, &public)
+	return count, weighted, public
 }
 
 // countMethodsRecursive recursively counts function definitions within a class
-func (pyAnalyzer *PythonAnalyzer) countMethodsRecursive(cursor *sitter.TreeCursor, count *int) {
+func (pyAnalyzer *PythonAnalyzer) countMethodsRecursive(cursor *sitter.TreeCursor, sourceBytes []byte, count *int, weighted *int, public *int) {
 	node := cursor.CurrentNode()
 	nodeType := node.Type()
 
 	// Count function definitions (methods)
 	if nodeType == "function_definition" || nodeType == "async_function_definition" {
+		pythonFunc := NewPythonFunction(node, sourceBytes)
 		*count++
+		*weighted += pythonFunc.CalculateCyclomaticComplexity()
+		if !strings.HasPrefix(pythonFunc.Name(), "_") {
+			*public++
+		}
 		// Don't recurse into nested functions within methods
 		return
 	}
@@ -484,7 +580,7 @@ func (pyAnalyzer *PythonAnalyzer) countMethodsRecursive(cursor *sitter.TreeCurso
 	// Recurse to children
 	if cursor.GoToFirstChild() {
 		for {
-			pyAnalyzer.countMethodsRecursive(cursor, count)
+			pyAnalyzer.countMethodsRecursive(cursor, sourceBytes, count, weighted, public)
 			if !cursor.GoToNextSibling() {
 				break
 			}
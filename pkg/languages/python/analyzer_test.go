@@ -8,6 +8,8 @@ import (
 
 	sitter "github.com/smacker/go-tree-sitter"
 	"github.com/smacker/go-tree-sitter/python"
+
+	"github.com/alexcollie/kaizen/pkg/analyzer"
 )
 
 // Test basic analyzer properties
@@ -113,6 +115,38 @@ func TestCountLines(t *testing.T) {
 			expectedComment: 4,
 			expectedBlank:   0,
 		},
+		{
+			name:            "hash inside string literal",
+			code:            "x = \"he said #hi\"\ny = 'a#b'  # trailing comment",
+			expectedTotal:   2,
+			expectedCode:    2,
+			expectedComment: 0,
+			expectedBlank:   0,
+		},
+		{
+			name:            "triple-quoted string assigned to a variable",
+			code:            "query = \"\"\"\n# not a comment\nSELECT 1\n\"\"\"\nrun(query)",
+			expectedTotal:   5,
+			expectedCode:    5,
+			expectedComment: 0,
+			expectedBlank:   0,
+		},
+		{
+			name:            "triple quotes inside string literals",
+			code:            "marker = '\"\"\"'\n# real comment\n\"\"\"a\"\"\".join(parts)\nfence = \"'''\"",
+			expectedTotal:   4,
+			expectedCode:    3,
+			expectedComment: 1,
+			expectedBlank:   0,
+		},
+		{
+			name:            "docstring containing quotes and hash",
+			code:            "def foo():\n    r\"\"\"It's \"quoted\" # here\n    see \\\"\"\" above\n    \"\"\"  # end\n    return '#'",
+			expectedTotal:   5,
+			expectedCode:    2,
+			expectedComment: 3,
+			expectedBlank:   0,
+		},
 	}
 
 	for _, testCase := range tests {
@@ -180,8 +214,6 @@ func TestCountImports(t *testing.T) {
 }
 
 func TestCalculateMaintainabilityIndex(t *testing.T) {
-	analyzer := &PythonAnalyzer{}
-
 	tests := []struct {
 		name       string
 		volume     float64
@@ -218,10 +250,10 @@ func TestCalculateMaintainabilityIndex(t *testing.T) {
 
 	for _, testCase := range tests {
 		t.Run(testCase.name, func(t *testing.T) {
-			result := analyzer.calculateMaintainabilityIndex(
-				testCase.volume, testCase.complexity, testCase.loc)
+			result := analyzer.MaintainabilityIndex(
+				analyzer.MIVariantClassic, testCase.volume, testCase.complexity, testCase.loc, 0)
 			if result < testCase.minScore || result > testCase.maxScore {
-				t.Errorf("calculateMaintainabilityIndex: got %.2f, expected between %.2f and %.2f",
+				t.Errorf("MaintainabilityIndex: got %.2f, expected between %.2f and %.2f",
 					result, testCase.minScore, testCase.maxScore)
 			}
 		})
@@ -286,6 +318,44 @@ class MyClass:
 	}
 }
 
+func TestExtractFunctionsDocstrings(t *testing.T) {
+	analyzer := &PythonAnalyzer{language: python.GetLanguage()}
+
+	code := `def documented():
+    """Explain what this does."""
+    return 1
+
+def undocumented():
+    value = "not a docstring"
+    return value
+
+def empty_string_later():
+    value = 1
+    "too late to be a docstring"
+    return value
+`
+
+	parser := sitter.NewParser()
+	parser.SetLanguage(analyzer.language)
+	tree, err := parser.ParseCtx(context.Background(), nil, []byte(code))
+	if err != nil || tree == nil {
+		t.Fatalf("Failed to parse code: %v", err)
+	}
+	defer tree.Close()
+
+	functions := analyzer.extractFunctions(tree.RootNode(), []byte(code))
+	if len(functions) != 3 {
+		t.Fatalf("Expected 3 functions, got %d", len(functions))
+	}
+
+	expected := map[string]bool{"documented": true, "undocumented": false, "empty_string_later": false}
+	for _, function := range functions {
+		if function.HasDocComment != expected[function.Name] {
+			t.Errorf("%s: expected HasDocComment %v, got %v", function.Name, expected[function.Name], function.HasDocComment)
+		}
+	}
+}
+
 func TestExtractTypes(t *testing.T) {
 	analyzer := &PythonAnalyzer{language: python.GetLanguage()}
 
@@ -324,6 +394,102 @@ class AnotherClass(BaseClass):
 	}
 }
 
+func TestExtractTypesWeightedMethods(t *testing.T) {
+	analyzer := &PythonAnalyzer{language: python.GetLanguage()}
+
+	code := `class Account:
+    def __init__(self, balance):
+        self.balance = balance
+
+    def withdraw(self, amount):
+        if amount > self.balance:
+            raise ValueError("insufficient funds")
+        for hook in self.hooks:
+            hook(amount)
+        self.balance -= amount
+
+    async def sync(self):
+        pass
+`
+
+	parser := sitter.NewParser()
+	parser.SetLanguage(analyzer.language)
+	tree, err := parser.ParseCtx(context.Background(), nil, []byte(code))
+	if err != nil || tree == nil {
+		t.Fatalf("Failed to parse code: %v", err)
+	}
+	defer tree.Close()
+
+	types := analyzer.extractTypes(tree.RootNode(), []byte(code))
+
+	if len(types) != 1 {
+		t.Fatalf("Expected 1 class, got %d", len(types))
+	}
+	if types[0].MethodCount != 3 {
+		t.Errorf("Expected 3 methods, got %d", types[0].MethodCount)
+	}
+	// __init__ 1 + withdraw 3 (if, for) + sync 1
+	if types[0].WeightedMethodsPerClass != 5 {
+		t.Errorf("Expected weighted methods of 5, got %d", types[0].WeightedMethodsPerClass)
+	}
+	// withdraw and sync; __init__ has a leading underscore
+	if types[0].PublicMethodCount != 2 {
+		t.Errorf("Expected 2 public methods, got %d", types[0].PublicMethodCount)
+	}
+}
+
+func TestPublicDefinitions(t *testing.T) {
+	code := `class Client:
+    def fetch(self):
+        def parse(body):
+            return body
+        return parse("")
+
+    def _retry(self):
+        pass
+
+class _Cache:
+    def get(self, key):
+        pass
+
+def connect():
+    pass
+
+def _dial():
+    pass
+`
+
+	result, err := NewPythonAnalyzer().AnalyzeSource("client.py", []byte(code))
+	if err != nil {
+		t.Fatalf("AnalyzeSource failed: %v", err)
+	}
+
+	exported := make(map[string]bool)
+	for _, function := range result.Functions {
+		exported[function.Name] = function.IsExported
+	}
+	expected := map[string]bool{
+		"fetch":   true,
+		"parse":   false, // Local to fetch
+		"_retry":  false,
+		"get":     false, // Method of a private class
+		"connect": true,
+		"_dial":   false,
+	}
+	for name, want := range expected {
+		if got, found := exported[name]; !found || got != want {
+			t.Errorf("Expected %s exported=%v, got %v (found %v)", name, want, got, found)
+		}
+	}
+
+	for _, typeAnalysis := range result.Types {
+		want := typeAnalysis.Name == "Client"
+		if typeAnalysis.IsExported != want {
+			t.Errorf("Expected class %s exported=%v, got %v", typeAnalysis.Name, want, typeAnalysis.IsExported)
+		}
+	}
+}
+
 func TestAnalyzeFile(t *testing.T) {
 	// Create a temporary Python file
 	tmpDir := t.TempDir()
@@ -499,10 +665,9 @@ def get_users():
 
 	functions := analyzer.extractFunctions(tree.RootNode(), []byte(code))
 
-	// Note: decorated_definition nodes are processed both in the handler and during recursion,
-	// which may result in duplicate entries. We check that at least the expected functions exist.
-	if len(functions) < 3 {
-		t.Errorf("Expected at least 3 functions, got %d", len(functions))
+	// Each decorated function is reported once
+	if len(functions) != 3 {
+		t.Errorf("Expected 3 functions, got %d", len(functions))
 	}
 
 	// Verify all expected function names are present
@@ -569,6 +734,45 @@ func TestComprehensionComplexity(t *testing.T) {
 	}
 }
 
+func TestNestingDepth(t *testing.T) {
+	analyzer := &PythonAnalyzer{language: python.GetLanguage()}
+
+	code := `def flat(value):
+    return value * 2
+
+def chained(value):
+    if value == 1:
+        return "one"
+    elif value == 2:
+        return "two"
+    else:
+        for item in range(value):
+            if item:
+                print(item)
+`
+
+	parser := sitter.NewParser()
+	parser.SetLanguage(analyzer.language)
+	tree, err := parser.ParseCtx(context.Background(), nil, []byte(code))
+	if err != nil || tree == nil {
+		t.Fatalf("Failed to parse code: %v", err)
+	}
+	defer tree.Close()
+
+	functions := analyzer.extractFunctions(tree.RootNode(), []byte(code))
+	if len(functions) != 2 {
+		t.Fatalf("Expected 2 functions, got %d", len(functions))
+	}
+
+	// The function definition itself does not count as a level
+	if functions[0].NestingDepth != 0 {
+		t.Errorf("Expected flat function depth 0, got %d", functions[0].NestingDepth)
+	}
+	if functions[1].NestingDepth != 3 {
+		t.Errorf("Expected if/else > for > if to reach depth 3, got %d", functions[1].NestingDepth)
+	}
+}
+
 func TestNestedFunctions(t *testing.T) {
 	analyzer := &PythonAnalyzer{language: python.GetLanguage()}
 
@@ -685,15 +889,26 @@ func TestLambdaExpressions(t *testing.T) {
 
 	functions := analyzer.extractFunctions(tree.RootNode(), []byte(code))
 
-	// Should only extract the main function, not lambdas
-	if len(functions) != 1 {
-		t.Errorf("Expected 1 function (lambdas should not be counted), got %d", len(functions))
+	// Lambdas are reported as anonymous functions, which the pipeline leaves out unless
+	// analysis.count_anonymous_functions is set
+	expectedNames := []string{"process_data", "process_data.lambda1", "process_data.lambda2", "process_data.lambda3", "process_data.lambda4"}
+	if len(functions) != len(expectedNames) {
+		t.Fatalf("Expected %d functions, got %d", len(expectedNames), len(functions))
+	}
+	for index, expectedName := range expectedNames {
+		if functions[index].Name != expectedName {
+			t.Errorf("Function %d should be '%s', got '%s'", index, expectedName, functions[index].Name)
+		}
+		if functions[index].IsAnonymous != (index > 0) {
+			t.Errorf("Function '%s' has IsAnonymous %v", functions[index].Name, functions[index].IsAnonymous)
+		}
+	}
+	if functions[4].ParameterCount != 2 || functions[4].StartLine != 12 || functions[4].EndLine != 14 {
+		t.Errorf("Expected the multiline lambda to take 2 parameters over lines 12-14, got %d over %d-%d",
+			functions[4].ParameterCount, functions[4].StartLine, functions[4].EndLine)
 	}
 
 	fn := functions[0]
-	if fn.Name != "process_data" {
-		t.Errorf("Function name should be 'process_data', got '%s'", fn.Name)
-	}
 
 	// The function should have reasonable complexity despite lambdas
 	if fn.CyclomaticComplexity < 1 {
@@ -729,9 +944,9 @@ class Database:
 
 	types := analyzer.extractTypes(tree.RootNode(), []byte(code))
 
-	// Note: decorated_definition nodes may cause duplicates similar to functions
-	if len(types) < 2 {
-		t.Errorf("Expected at least 2 classes, got %d", len(types))
+	// Each decorated class is reported once
+	if len(types) != 2 {
+		t.Errorf("Expected 2 classes, got %d", len(types))
 	}
 
 	// Verify all expected class names are present
@@ -800,3 +1015,89 @@ func TestExceptionHandlingComplexity(t *testing.T) {
 		t.Errorf("Expected complexity >= 4 due to exception handling, got %d", fn.CyclomaticComplexity)
 	}
 }
+
+// benchmarkSource is a small module with imports, a class, and several functions, so each
+// analysis runs the per-file and per-function metric passes
+const benchmarkSource = `import os
+from typing import Dict, List
+
+
+class Inventory:
+    """Tracks stock levels."""
+
+    def __init__(self, items: Dict[str, int]):
+        self.items = items
+
+    def restock(self, name: str, amount: int) -> None:
+        if amount <= 0:
+            raise ValueError("amount must be positive")
+        self.items[name] = self.items.get(name, 0) + amount
+
+
+def summarize(inventory: Inventory, threshold: int = 5) -> List[str]:
+    low = []
+    for name, count in inventory.items.items():
+        if count < threshold and not name.startswith("_"):
+            low.append(f"{name}: {count}")
+        elif count == 0:
+            low.append(name + " (out)")
+    return sorted(low)
+
+
+def load(path: str) -> Inventory:
+    with open(os.path.join(path, "stock.txt")) as handle:
+        pairs = [line.split(",") for line in handle if line.strip()]
+    return Inventory({name: int(count) for name, count in pairs})
+`
+
+func BenchmarkAnalyzeSource(b *testing.B) {
+	pyAnalyzer := NewPythonAnalyzer()
+	source := []byte(benchmarkSource)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := pyAnalyzer.AnalyzeSource("inventory.py", source); err != nil {
+			b.Fatalf("AnalyzeSource failed: %v", err)
+		}
+	}
+}
+
+func BenchmarkCalculateHalsteadMetrics(b *testing.B) {
+	pyAnalyzer := NewPythonAnalyzer().(*PythonAnalyzer)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		pyAnalyzer.calculateHalsteadMetrics(benchmarkSource)
+	}
+}
+
+func TestCountStdlibCalls(t *testing.T) {
+	code := `import json
+import os.path
+
+def save(report, directory):
+    path = os.path.join(directory, str(report.id) + ".json")
+    if not isinstance(report.items, list):
+        raise ValueError("items must be a list")
+    with open(path, "w") as handle:
+        handle.write(json.dumps(serialize(report)))
+    print(len(report.items))
+`
+	result, err := NewPythonAnalyzer().AnalyzeSource("save.py", []byte(code))
+	if err != nil {
+		t.Fatalf("AnalyzeSource failed: %v", err)
+	}
+	if len(result.Functions) != 1 {
+		t.Fatalf("Expected 1 function, got %d", len(result.Functions))
+	}
+
+	// os.path.join, str, isinstance, ValueError, open, json.dumps, print, and len; the
+	// handle.write method call and serialize are not standard library calls
+	function := result.Functions[0]
+	if function.FanOut != 10 {
+		t.Errorf("Expected FanOut 10, got %d", function.FanOut)
+	}
+	if function.StdlibCalls != 8 {
+		t.Errorf("Expected StdlibCalls 8, got %d", function.StdlibCalls)
+	}
+}
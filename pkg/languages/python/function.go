@@ -3,6 +3,7 @@ package python
 import (
 	"strings"
 
+	"github.com/alexcollie/kaizen/pkg/languages/nesting"
 	"github.com/smacker/go-tree-sitter"
 )
 
@@ -55,6 +56,20 @@ func (pythonFunc *PythonFunction) LineCount() int {
 	return pythonFunc.EndLine() - pythonFunc.StartLine() + 1
 }
 
+// HasDocstring reports whether the first statement of the function body is a string literal
+func (pythonFunc *PythonFunction) HasDocstring() bool {
+	body := pythonFunc.node.ChildByFieldName("body")
+	if body == nil || body.NamedChildCount() == 0 {
+		return false
+	}
+
+	firstStatement := body.NamedChild(0)
+	if firstStatement.Type() != "expression_statement" || firstStatement.NamedChildCount() == 0 {
+		return false
+	}
+	return firstStatement.NamedChild(0).Type() == "string"
+}
+
 // LogicalLineCount counts non-comment statement nodes in the function body
 func (pythonFunc *PythonFunction) LogicalLineCount() int {
 	count := 0
@@ -101,7 +116,8 @@ func (pythonFunc *PythonFunction) ParameterCount() int {
 	if cursor.GoToFirstChild() {
 		for {
 			node := cursor.CurrentNode()
-			if node.Type() == "parameters" {
+			// Lambdas list their parameters in lambda_parameters
+			if node.Type() == "parameters" || node.Type() == "lambda_parameters" {
 				return pythonFunc.countParametersInNode(node)
 			}
 			if !cursor.GoToNextSibling() {
@@ -195,52 +211,25 @@ func (pythonFunc *PythonFunction) countReturnStatements(cursor *sitter.TreeCurso
 	}
 }
 
-// MaxNestingDepth calculates the maximum nesting depth
-func (pythonFunc *PythonFunction) MaxNestingDepth() int {
-	maxDepth := 0
-	currentDepth := 0
-
-	cursor := sitter.NewTreeCursor(pythonFunc.node)
-	defer cursor.Close()
-
-	pythonFunc.findMaxNestingDepth(cursor, &maxDepth, &currentDepth)
-	return maxDepth
+// pythonNesting lists the Python constructs that open a nesting level. elif and else are
+// clauses of their if_statement, so an if/elif chain counts once.
+var pythonNesting = nesting.Language{
+	BlockTypes: map[string]bool{
+		"if_statement":              true,
+		"for_statement":             true,
+		"while_statement":           true,
+		"try_statement":             true,
+		"with_statement":            true,
+		"match_statement":           true,
+		"function_definition":       true,
+		"async_function_definition": true,
+		"class_definition":          true,
+	},
 }
 
-// findMaxNestingDepth recursively finds the maximum nesting depth
-func (pythonFunc *PythonFunction) findMaxNestingDepth(cursor *sitter.TreeCursor, maxDepth *int, currentDepth *int) {
-	node := cursor.CurrentNode()
-	nodeType := node.Type()
-
-	// Increase depth for control structures
-	shouldIncreaseDepth := false
-	switch nodeType {
-	case "if_statement", "for_statement", "while_statement",
-		"try_statement", "with_statement", "match_statement",
-		"function_definition", "async_function_definition",
-		"class_definition":
-		*currentDepth++
-		shouldIncreaseDepth = true
-		if *currentDepth > *maxDepth {
-			*maxDepth = *currentDepth
-		}
-	}
-
-	// Recurse to children
-	if cursor.GoToFirstChild() {
-		for {
-			pythonFunc.findMaxNestingDepth(cursor, maxDepth, currentDepth)
-			if !cursor.GoToNextSibling() {
-				break
-			}
-		}
-		cursor.GoToParent()
-	}
-
-	// Decrease depth when leaving structure
-	if shouldIncreaseDepth {
-		*currentDepth--
-	}
+// MaxNestingDepth calculates the maximum nesting depth below the function definition itself
+func (pythonFunc *PythonFunction) MaxNestingDepth() int {
+	return nesting.MaxDepth(pythonFunc.node, pythonNesting)
 }
 
 // CalculateCyclomaticComplexity calculates the cyclomatic complexity
@@ -475,3 +464,89 @@ func (pythonFunc *PythonFunction) countCalls(cursor *sitter.TreeCursor, count *i
 		cursor.GoToParent()
 	}
 }
+
+// pythonBuiltins are the functions, types, and common exceptions Python provides without an
+// import
+var pythonBuiltins = map[string]bool{
+	"AssertionError": true, "AttributeError": true, "Exception": true, "IndexError": true,
+	"KeyError": true, "NotImplementedError": true, "OSError": true, "RuntimeError": true,
+	"StopIteration": true, "TypeError": true, "ValueError": true,
+	"abs": true, "all": true, "any": true, "ascii": true, "bin": true, "bool": true,
+	"breakpoint": true, "bytearray": true, "bytes": true, "callable": true, "chr": true,
+	"classmethod": true, "compile": true, "complex": true, "delattr": true, "dict": true,
+	"dir": true, "divmod": true, "enumerate": true, "eval": true, "exec": true, "filter": true,
+	"float": true, "format": true, "frozenset": true, "getattr": true, "globals": true,
+	"hasattr": true, "hash": true, "help": true, "hex": true, "id": true, "input": true,
+	"int": true, "isinstance": true, "issubclass": true, "iter": true, "len": true,
+	"list": true, "locals": true, "map": true, "max": true, "memoryview": true, "min": true,
+	"next": true, "object": true, "oct": true, "open": true, "ord": true, "pow": true,
+	"print": true, "property": true, "range": true, "repr": true, "reversed": true,
+	"round": true, "set": true, "setattr": true, "slice": true, "sorted": true,
+	"staticmethod": true, "str": true, "sum": true, "super": true, "tuple": true, "type": true,
+	"vars": true, "zip": true,
+}
+
+// pythonStdlibModules are common standard library modules; a call through one of them, such
+// as os.path.join(...), is a standard library call
+var pythonStdlibModules = map[string]bool{
+	"abc": true, "argparse": true, "asyncio": true, "base64": true, "collections": true,
+	"contextlib": true, "copy": true, "csv": true, "dataclasses": true, "datetime": true,
+	"decimal": true, "enum": true, "functools": true, "glob": true, "hashlib": true,
+	"heapq": true, "io": true, "itertools": true, "json": true, "logging": true, "math": true,
+	"operator": true, "os": true, "pathlib": true, "pickle": true, "random": true, "re": true,
+	"shutil": true, "socket": true, "statistics": true, "string": true, "struct": true,
+	"subprocess": true, "sys": true, "tempfile": true, "textwrap": true, "threading": true,
+	"time": true, "typing": true, "unittest": true, "urllib": true, "uuid": true,
+	"warnings": true,
+}
+
+// CountStdlibCalls counts the calls included in CountFunctionCalls that go to Python
+// built-ins, such as len(...), or through a standard library module, such as json.dumps(...)
+func (pythonFunc *PythonFunction) CountStdlibCalls() int {
+	count := 0
+	cursor := sitter.NewTreeCursor(pythonFunc.node)
+	defer cursor.Close()
+
+	pythonFunc.countStdlibCalls(cursor, &count)
+	return count
+}
+
+// countStdlibCalls recursively counts call nodes whose callee is a built-in or is rooted at a
+// standard library module
+func (pythonFunc *PythonFunction) countStdlibCalls(cursor *sitter.TreeCursor, count *int) {
+	node := cursor.CurrentNode()
+	if node.Type() == "call" && pythonFunc.isStdlibCallee(node.ChildByFieldName("function")) {
+		*count++
+	}
+
+	if cursor.GoToFirstChild() {
+		for {
+			pythonFunc.countStdlibCalls(cursor, count)
+			if !cursor.GoToNextSibling() {
+				break
+			}
+		}
+		cursor.GoToParent()
+	}
+}
+
+// isStdlibCallee reports whether a call's function expression names a built-in or an attribute
+// of a standard library module
+func (pythonFunc *PythonFunction) isStdlibCallee(callee *sitter.Node) bool {
+	if callee == nil {
+		return false
+	}
+	if callee.Type() == "identifier" {
+		return pythonBuiltins[callee.Content(pythonFunc.sourceBytes)]
+	}
+
+	// Walk os.path.join down to its root name, os
+	root := callee
+	for root != nil && root.Type() == "attribute" {
+		root = root.ChildByFieldName("object")
+	}
+	if root == nil || root == callee || root.Type() != "identifier" {
+		return false
+	}
+	return pythonStdlibModules[root.Content(pythonFunc.sourceBytes)]
+}
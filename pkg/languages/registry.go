@@ -3,11 +3,16 @@ package languages
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/alexcollie/kaizen/pkg/analyzer"
+	"github.com/alexcollie/kaizen/pkg/languages/dart"
 	"github.com/alexcollie/kaizen/pkg/languages/golang"
 	"github.com/alexcollie/kaizen/pkg/languages/kotlin"
+	"github.com/alexcollie/kaizen/pkg/languages/lua"
+	"github.com/alexcollie/kaizen/pkg/languages/objc"
 	"github.com/alexcollie/kaizen/pkg/languages/python"
+	"github.com/alexcollie/kaizen/pkg/languages/sql"
 	"github.com/alexcollie/kaizen/pkg/languages/swift"
 )
 
@@ -20,9 +25,13 @@ type Registry struct {
 func NewRegistry() *Registry {
 	return &Registry{
 		analyzers: []analyzer.LanguageAnalyzer{
+			dart.NewDartAnalyzer(),
 			golang.NewGoAnalyzer(),
 			kotlin.NewKotlinAnalyzer(),
+			lua.NewLuaAnalyzer(),
+			objc.NewObjCAnalyzer(),
 			python.NewPythonAnalyzer(),
+			sql.NewSQLAnalyzer(),
 			swift.NewSwiftAnalyzer(),
 		},
 	}
@@ -40,10 +49,10 @@ func (registry *Registry) GetAnalyzerForFile(filePath string) (analyzer.Language
 	return nil, fmt.Errorf("no analyzer found for file extension: %s", ext)
 }
 
-// GetAnalyzerByName returns an analyzer by language name
+// GetAnalyzerByName returns an analyzer by language name, ignoring case
 func (registry *Registry) GetAnalyzerByName(name string) (analyzer.LanguageAnalyzer, error) {
 	for _, languageAnalyzer := range registry.analyzers {
-		if languageAnalyzer.Name() == name {
+		if strings.EqualFold(languageAnalyzer.Name(), name) {
 			return languageAnalyzer, nil
 		}
 	}
@@ -0,0 +1,549 @@
+package sql
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alexcollie/kaizen/pkg/analyzer"
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+// SQL dialects disagree on how a routine body is delimited, so this analyzer works on a
+// lexical pass like the Objective-C one: comments and string literals are blanked out, and
+// each CREATE FUNCTION / CREATE PROCEDURE is cut out of the token stream. The body is a
+// dollar-quoted block (PostgreSQL), a BEGIN ... END block (PL/SQL, MySQL, T-SQL), or for
+// T-SQL procedures without BEGIN, everything up to the next GO batch separator.
+
+// routineKeywords introduce a routine after CREATE [OR REPLACE | OR ALTER]
+var routineKeywords = map[string]bool{
+	"FUNCTION":  true,
+	"PROCEDURE": true,
+	"PROC":      true,
+}
+
+// nonRoutineObjects end the search for a routine keyword after CREATE
+var nonRoutineObjects = map[string]bool{
+	"TABLE":    true,
+	"VIEW":     true,
+	"INDEX":    true,
+	"TRIGGER":  true,
+	"TYPE":     true,
+	"SCHEMA":   true,
+	"SEQUENCE": true,
+	"PACKAGE":  true,
+	"DATABASE": true,
+	"ROLE":     true,
+	"USER":     true,
+	";":        true,
+}
+
+// headerEndKeywords end the unparenthesized T-SQL parameter list
+var headerEndKeywords = map[string]bool{
+	"AS":      true,
+	"RETURNS": true,
+	"WITH":    true,
+	"BEGIN":   true,
+	"IS":      true,
+	";":       true,
+}
+
+// tsqlStatementKeywords start statements; one right after AS marks a T-SQL body without BEGIN
+var tsqlStatementKeywords = map[string]bool{
+	"SET":      true,
+	"SELECT":   true,
+	"INSERT":   true,
+	"UPDATE":   true,
+	"DELETE":   true,
+	"MERGE":    true,
+	"IF":       true,
+	"WHILE":    true,
+	"EXEC":     true,
+	"EXECUTE":  true,
+	"PRINT":    true,
+	"RETURN":   true,
+	"DECLARE":  true,
+	"WITH":     true,
+	"THROW":    true,
+	"TRUNCATE": true,
+}
+
+// SQLAnalyzer implements the LanguageAnalyzer interface for SQL stored routines
+type SQLAnalyzer struct{}
+
+// NewSQLAnalyzer creates a new SQL analyzer
+func NewSQLAnalyzer() analyzer.LanguageAnalyzer {
+	return &SQLAnalyzer{}
+}
+
+// Name returns the language name
+func (sqlAnalyzer *SQLAnalyzer) Name() string {
+	return "SQL"
+}
+
+// FileExtensions returns the file extensions this analyzer handles
+func (sqlAnalyzer *SQLAnalyzer) FileExtensions() []string {
+	return []string{".sql"}
+}
+
+// CanAnalyze checks if this analyzer can handle the given file
+func (sqlAnalyzer *SQLAnalyzer) CanAnalyze(filePath string) bool {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	for _, supportedExt := range sqlAnalyzer.FileExtensions() {
+		if ext == supportedExt {
+			return true
+		}
+	}
+	return false
+}
+
+// IsStub indicates if this is a stub implementation
+func (sqlAnalyzer *SQLAnalyzer) IsStub() bool {
+	return false
+}
+
+// sqlCommentSyntax drives commented-out code detection
+var sqlCommentSyntax = analyzer.CommentSyntax{
+	LinePrefix: "--",
+	Keywords: []string{
+		"SELECT", "INSERT", "UPDATE", "DELETE", "IF", "RETURN", "CALL", "EXEC", "SET", "DECLARE",
+		"select", "insert", "update", "delete", "if", "return", "call", "exec", "set", "declare",
+	},
+}
+
+// AnalyzeFile performs full analysis on a single SQL file
+func (sqlAnalyzer *SQLAnalyzer) AnalyzeFile(filePath string) (*models.FileAnalysis, error) {
+	sourceBytes, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	return sqlAnalyzer.AnalyzeSource(filePath, sourceBytes)
+}
+
+// AnalyzeSource performs full analysis on in-memory SQL source reported under filePath
+func (sqlAnalyzer *SQLAnalyzer) AnalyzeSource(filePath string, sourceBytes []byte) (*models.FileAnalysis, error) {
+	sourceCode := string(sourceBytes)
+
+	// Count lines
+	totalLines, codeLines, commentLines, blankLines := sqlAnalyzer.countLines(sourceCode)
+
+	// Calculate comment density
+	commentDensity := 0.0
+	if totalLines > 0 {
+		commentDensity = float64(commentLines) / float64(totalLines) * 100
+	}
+
+	// Blank out comments and literals so keywords inside them are ignored
+	strippedSource := stripCommentsAndStrings(sourceCode)
+
+	return &models.FileAnalysis{
+		Path:                  filePath,
+		Language:              sqlAnalyzer.Name(),
+		TotalLines:            totalLines,
+		CodeLines:             codeLines,
+		CommentLines:          commentLines,
+		BlankLines:            blankLines,
+		CommentDensity:        commentDensity,
+		DuplicatedLines:       0, // TODO: Implement duplication detection
+		DuplicationPercentage: 0,
+		ImportCount:           0, // SQL scripts have no imports
+		Functions:             sqlAnalyzer.extractRoutines(sourceCode, strippedSource),
+		CommentedCode:         analyzer.FindCommentedCode(sourceCode, sqlCommentSyntax),
+	}, nil
+}
+
+// countLines counts different types of lines in the source
+func (sqlAnalyzer *SQLAnalyzer) countLines(sourceCode string) (total, code, comment, blank int) {
+	lines := strings.Split(sourceCode, "\n")
+	total = len(lines)
+
+	inBlockComment := false
+
+	for _, line := range lines {
+		trimmedLine := strings.TrimSpace(line)
+
+		if !inBlockComment && strings.HasPrefix(trimmedLine, "/*") {
+			inBlockComment = true
+		}
+
+		if inBlockComment {
+			comment++
+			if strings.Contains(trimmedLine, "*/") {
+				inBlockComment = false
+			}
+		} else if trimmedLine == "" {
+			blank++
+		} else if strings.HasPrefix(trimmedLine, "--") {
+			comment++
+		} else {
+			code++
+		}
+	}
+
+	return
+}
+
+// extractRoutines finds every CREATE FUNCTION and CREATE PROCEDURE in the file
+func (sqlAnalyzer *SQLAnalyzer) extractRoutines(sourceCode, strippedSource string) []models.FunctionAnalysis {
+	var functions []models.FunctionAnalysis
+
+	tokens := tokenize(strippedSource)
+	for index := 0; index < len(tokens); index++ {
+		if upper(tokens[index].text) != "CREATE" {
+			continue
+		}
+		routine, endIndex := sqlAnalyzer.analyzeRoutine(sourceCode, strippedSource, tokens, index)
+		if routine != nil {
+			functions = append(functions, *routine)
+			index = endIndex
+		}
+	}
+
+	return functions
+}
+
+// analyzeRoutine builds the analysis for the routine whose CREATE is tokens[createIndex]. It
+// returns nil when the statement creates something else, and otherwise the index of the
+// routine's last token.
+func (sqlAnalyzer *SQLAnalyzer) analyzeRoutine(sourceCode, strippedSource string, tokens []sqlToken, createIndex int) (*models.FunctionAnalysis, int) {
+	keywordIndex := findRoutineKeyword(tokens, createIndex)
+	if keywordIndex < 0 {
+		return nil, createIndex
+	}
+
+	name, nameEnd := parseRoutineName(tokens, keywordIndex+1)
+	if name == "" {
+		return nil, createIndex
+	}
+
+	parameterCount, headerEnd := countParameters(tokens, nameEnd)
+	bodyStart, bodyEnd, routineEnd := findRoutineBody(strippedSource, tokens, headerEnd)
+
+	startOffset := tokens[createIndex].offset
+	endOffset := tokens[routineEnd].offset + len(tokens[routineEnd].text)
+	startLine := strings.Count(sourceCode[:startOffset], "\n") + 1
+	endLine := startLine + strings.Count(sourceCode[startOffset:endOffset], "\n")
+
+	routine := NewSQLRoutine(name, startLine, endLine, parameterCount, tokenTexts(tokens[bodyStart:bodyEnd]), strippedSource[startOffset:endOffset])
+
+	cyclomaticComplexity := routine.CalculateCyclomaticComplexity()
+	halsteadVol, halsteadDiff, halsteadEffort, halsteadTime := routine.CalculateHalstead()
+
+	return &models.FunctionAnalysis{
+		Name:                 name,
+		StartLine:            startLine,
+		EndLine:              endLine,
+		Length:               routine.LineCount(),
+		LogicalLines:         routine.LogicalLineCount(),
+		ParameterCount:       routine.ParameterCount(),
+		ReturnCount:          routine.ReturnCount(),
+		CyclomaticComplexity: cyclomaticComplexity,
+		CognitiveComplexity:  routine.CalculateCognitiveComplexity(),
+		NestingDepth:         routine.MaxNestingDepth(),
+		HalsteadVolume:       halsteadVol,
+		HalsteadDifficulty:   halsteadDiff,
+		HalsteadEffort:       halsteadEffort,
+		HalsteadTime:         halsteadTime,
+		MaintainabilityIndex: analyzer.MaintainabilityIndex(analyzer.MIVariantClassic, halsteadVol, cyclomaticComplexity, routine.LineCount(), 0),
+	}, routineEnd
+}
+
+// findRoutineKeyword returns the index of FUNCTION/PROCEDURE after the CREATE at createIndex,
+// skipping modifiers such as OR REPLACE and DEFINER=user, or -1 when another object is created
+func findRoutineKeyword(tokens []sqlToken, createIndex int) int {
+	for index := createIndex + 1; index < len(tokens) && index <= createIndex+12; index++ {
+		keyword := upper(tokens[index].text)
+		if routineKeywords[keyword] {
+			return index
+		}
+		if nonRoutineObjects[keyword] {
+			return -1
+		}
+	}
+	return -1
+}
+
+// parseRoutineName reads a possibly schema-qualified, possibly quoted routine name starting at
+// tokens[start] and returns it unquoted with the index of the token after it
+func parseRoutineName(tokens []sqlToken, start int) (string, int) {
+	index := start
+	// MariaDB: CREATE FUNCTION IF NOT EXISTS name
+	if index+2 < len(tokens) && upper(tokens[index].text) == "IF" && upper(tokens[index+1].text) == "NOT" && upper(tokens[index+2].text) == "EXISTS" {
+		index += 3
+	}
+
+	var parts []string
+	for index < len(tokens) && isNameToken(tokens[index].text) {
+		parts = append(parts, unquoteIdentifier(tokens[index].text))
+		index++
+		if index+1 < len(tokens) && tokens[index].text == "." && isNameToken(tokens[index+1].text) {
+			index++
+			continue
+		}
+		break
+	}
+
+	return strings.Join(parts, "."), index
+}
+
+// countParameters counts the routine's parameters starting at tokens[start], either a
+// parenthesized list or T-SQL's bare "@name type, ..." list, and returns the index of the
+// token after the list
+func countParameters(tokens []sqlToken, start int) (int, int) {
+	if start < len(tokens) && tokens[start].text == "(" {
+		closeIndex := matchingParen(tokens, start)
+		if closeIndex < 0 {
+			return 0, len(tokens) - 1
+		}
+		if closeIndex == start+1 {
+			return 0, closeIndex + 1
+		}
+
+		count := 1
+		depth := 0
+		for index := start + 1; index < closeIndex; index++ {
+			switch tokens[index].text {
+			case "(":
+				depth++
+			case ")":
+				depth--
+			case ",":
+				if depth == 0 {
+					count++
+				}
+			}
+		}
+		return count, closeIndex + 1
+	}
+
+	count := 0
+	index := start
+	for ; index < len(tokens) && !headerEndKeywords[upper(tokens[index].text)]; index++ {
+		text := tokens[index].text
+		previous := tokens[index-1].text
+		if strings.HasPrefix(text, "@") && !strings.HasPrefix(text, "@@") && (index == start || previous == ",") {
+			count++
+		}
+	}
+	return count, index
+}
+
+// findRoutineBody locates the routine body after its header, returning the token range of the
+// body and the index of the routine's last token. After AS or IS, declarations (PL/SQL) lead
+// up to a BEGIN block, while a statement keyword starts a T-SQL body that runs to the end of
+// the batch.
+func findRoutineBody(strippedSource string, tokens []sqlToken, headerEnd int) (bodyStart, bodyEnd, routineEnd int) {
+	afterAs := false
+	batchBody := false
+	atStatementStart := false
+
+	for index := headerEnd; index < len(tokens); index++ {
+		text := tokens[index].text
+		keyword := upper(text)
+
+		if afterAs && atStatementStart {
+			batchBody = batchBody || tsqlStatementKeywords[keyword]
+			atStatementStart = false
+		}
+
+		switch {
+		case isDollarQuote(text) && !batchBody:
+			closeIndex := index + 1
+			for closeIndex < len(tokens) && tokens[closeIndex].text != text {
+				closeIndex++
+			}
+			if closeIndex >= len(tokens) {
+				return index + 1, len(tokens), len(tokens) - 1
+			}
+			return index + 1, closeIndex, statementEnd(tokens, closeIndex)
+		case keyword == "BEGIN" && !batchBody && opensBlock(tokens, index):
+			endIndex := matchingEnd(tokens, index)
+			return index, endIndex + 1, statementEnd(tokens, endIndex)
+		case (keyword == "AS" || keyword == "IS") && !afterAs:
+			// AS 'select ...' is a one-statement body in a string literal
+			afterAs = index+1 >= len(tokens) || tokens[index+1].text != "'"
+			atStatementStart = true
+		case isBatchSeparator(strippedSource, tokens[index]) || (keyword == "CREATE" && index > headerEnd):
+			return headerEnd, index, index - 1
+		case text == ";":
+			if !afterAs {
+				return headerEnd, index, index
+			}
+			atStatementStart = true
+		}
+	}
+	return headerEnd, len(tokens), len(tokens) - 1
+}
+
+// statementEnd returns the index of the ';' ending the statement whose body closes at
+// tokens[index], or index when the statement is not terminated right there
+func statementEnd(tokens []sqlToken, index int) int {
+	for next := index + 1; next < len(tokens) && next <= index+8; next++ {
+		switch {
+		case tokens[next].text == ";":
+			return next
+		case upper(tokens[next].text) == "CREATE" || isDollarQuote(tokens[next].text):
+			return index
+		}
+	}
+	return index
+}
+
+// opensBlock reports whether the BEGIN at tokens[index] starts a block rather than a transaction
+func opensBlock(tokens []sqlToken, index int) bool {
+	if index+1 >= len(tokens) {
+		return false
+	}
+	switch upper(tokens[index+1].text) {
+	case "TRAN", "TRANSACTION", "DISTRIBUTED", "WORK", ";":
+		return false
+	}
+	return true
+}
+
+// matchingEnd returns the index of the END closing the BEGIN at tokens[beginIndex]. CASE opens
+// a block closed by END too, while END IF, END LOOP and similar close constructs that never
+// opened one.
+func matchingEnd(tokens []sqlToken, beginIndex int) int {
+	depth := 0
+	for index := beginIndex; index < len(tokens); index++ {
+		switch upper(tokens[index].text) {
+		case "BEGIN":
+			if opensBlock(tokens, index) {
+				depth++
+			}
+		case "CASE":
+			if index == 0 || upper(tokens[index-1].text) != "END" {
+				depth++
+			}
+		case "END":
+			if index+1 < len(tokens) && closesUnopenedBlock(tokens[index+1].text) {
+				continue
+			}
+			depth--
+			if depth == 0 {
+				return index
+			}
+		}
+	}
+	return len(tokens) - 1
+}
+
+// closesUnopenedBlock reports whether END followed by next closes a construct that did not
+// push a BEGIN/CASE level
+func closesUnopenedBlock(next string) bool {
+	switch upper(next) {
+	case "IF", "LOOP", "WHILE", "REPEAT", "FOR":
+		return true
+	}
+	return false
+}
+
+// isBatchSeparator reports whether token is a T-SQL GO alone on its line
+func isBatchSeparator(strippedSource string, token sqlToken) bool {
+	if upper(token.text) != "GO" {
+		return false
+	}
+	lineStart := strings.LastIndexByte(strippedSource[:token.offset], '\n') + 1
+	return strings.TrimSpace(strippedSource[lineStart:lineEnd(strippedSource, token.offset)]) == token.text
+}
+
+// matchingParen returns the index of the ')' closing the '(' at tokens[openIndex], or -1
+func matchingParen(tokens []sqlToken, openIndex int) int {
+	depth := 0
+	for index := openIndex; index < len(tokens); index++ {
+		switch tokens[index].text {
+		case "(":
+			depth++
+		case ")":
+			depth--
+			if depth == 0 {
+				return index
+			}
+		}
+	}
+	return -1
+}
+
+// isNameToken reports whether text can be part of a routine name
+func isNameToken(text string) bool {
+	switch text[0] {
+	case '"', '`', '[':
+		return len(text) > 2
+	}
+	return isIdentifierStart(text[0])
+}
+
+// unquoteIdentifier removes double-quote, backtick or bracket quoting from an identifier
+func unquoteIdentifier(identifier string) string {
+	if len(identifier) >= 2 {
+		switch identifier[0] {
+		case '"', '`':
+			return identifier[1 : len(identifier)-1]
+		case '[':
+			return strings.TrimSuffix(identifier[1:], "]")
+		}
+	}
+	return identifier
+}
+
+// stripCommentsAndStrings replaces comments and single-quoted string literals with spaces
+// while preserving newlines, so offsets and line numbers stay valid. Dollar-quoted blocks
+// are kept, since in routine definitions they hold the body.
+func stripCommentsAndStrings(sourceCode string) string {
+	stripped := []byte(sourceCode)
+
+	blank := func(from, to int) {
+		for index := from; index < to && index < len(stripped); index++ {
+			if stripped[index] != '\n' {
+				stripped[index] = ' '
+			}
+		}
+	}
+
+	for offset := 0; offset < len(sourceCode); offset++ {
+		rest := sourceCode[offset:]
+
+		switch {
+		case strings.HasPrefix(rest, "--"):
+			end := lineEnd(sourceCode, offset)
+			blank(offset, end)
+			offset = end - 1
+		case strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest[2:], "*/")
+			if end < 0 {
+				blank(offset, len(sourceCode))
+				return string(stripped)
+			}
+			blank(offset, offset+end+4)
+			offset += end + 3
+		case sourceCode[offset] == '\'':
+			// Strings may span lines; a doubled quote is an escaped quote
+			end := offset + 1
+			for end < len(sourceCode) {
+				if sourceCode[end] == '\'' {
+					if end+1 < len(sourceCode) && sourceCode[end+1] == '\'' {
+						end += 2
+						continue
+					}
+					break
+				}
+				end++
+			}
+			blank(offset+1, end)
+			offset = end
+		}
+	}
+
+	return string(stripped)
+}
+
+// lineEnd returns the offset of the newline ending the line containing offset
+func lineEnd(source string, offset int) int {
+	end := strings.IndexByte(source[offset:], '\n')
+	if end < 0 {
+		return len(source)
+	}
+	return offset + end
+}
@@ -0,0 +1,196 @@
+package sql
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+const samplePostgresSource = `-- Billing routines
+CREATE TABLE invoices (id serial PRIMARY KEY, total numeric);
+
+CREATE OR REPLACE FUNCTION billing.apply_discount(p_invoice integer, p_code text, p_rate numeric DEFAULT 0.1)
+RETURNS numeric
+LANGUAGE plpgsql
+AS $$
+DECLARE
+    v_total numeric;
+BEGIN
+    SELECT total INTO v_total FROM invoices WHERE id = p_invoice;
+    IF v_total IS NULL OR v_total <= 0 THEN
+        RAISE EXCEPTION 'invoice % has no total; IF skipped', p_invoice;
+    ELSIF p_code = 'VIP' AND v_total > 100 THEN
+        v_total := v_total * (1 - p_rate * 2);
+    ELSE
+        FOR i IN 1..3 LOOP
+            IF i BETWEEN 2 AND 3 THEN
+                v_total := v_total - 1;
+            END IF;
+        END LOOP;
+    END IF;
+    RETURN CASE WHEN v_total < 0 THEN 0 ELSE v_total END;
+EXCEPTION
+    WHEN division_by_zero THEN
+        RETURN 0;
+END;
+$$;
+
+CREATE FUNCTION add_one(x integer) RETURNS integer LANGUAGE sql AS 'select x + 1';
+`
+
+const sampleTSQLSource = `CREATE PROCEDURE [dbo].[ArchiveOrders]
+    @CutoffDate DATE,
+    @BatchSize INT = 500
+AS
+BEGIN
+    SET NOCOUNT ON;
+    BEGIN TRANSACTION;
+    WHILE EXISTS (SELECT 1 FROM Orders WHERE OrderDate < @CutoffDate)
+    BEGIN
+        IF @BatchSize > 1000
+        BEGIN
+            SET @BatchSize = 1000;
+        END
+        ELSE IF @BatchSize < 1
+            SET @BatchSize = 1;
+        DELETE TOP (@BatchSize) FROM Orders WHERE OrderDate < @CutoffDate;
+    END
+    BEGIN TRY
+        COMMIT TRANSACTION;
+    END TRY
+    BEGIN CATCH
+        ROLLBACK TRANSACTION;
+    END CATCH
+END
+GO
+
+CREATE PROCEDURE dbo.PurgeLog AS
+    SET NOCOUNT ON;
+    DELETE FROM Log WHERE Level = 'debug';
+GO
+`
+
+const sampleMySQLSource = "DELIMITER $$\n" +
+	"CREATE DEFINER=`root`@`localhost` PROCEDURE `count_rows`(IN p_limit INT, OUT p_total INT)\n" +
+	"BEGIN\n" +
+	"    DECLARE done INT DEFAULT 0;\n" +
+	"    DECLARE CONTINUE HANDLER FOR NOT FOUND SET done = 1;\n" +
+	"    REPEAT\n" +
+	"        SET p_total = p_total + 1;\n" +
+	"    UNTIL done OR p_total >= p_limit END REPEAT;\n" +
+	"END$$\n" +
+	"DELIMITER ;\n"
+
+func analyzeSample(testingT *testing.T, fileName, source string) *models.FileAnalysis {
+	testFile := filepath.Join(testingT.TempDir(), fileName)
+	require.NoError(testingT, os.WriteFile(testFile, []byte(source), 0644))
+
+	result, err := NewSQLAnalyzer().AnalyzeFile(testFile)
+	require.NoError(testingT, err)
+	return result
+}
+
+func TestCanAnalyze(t *testing.T) {
+	analyzer := NewSQLAnalyzer()
+
+	assert.Equal(t, "SQL", analyzer.Name())
+	assert.False(t, analyzer.IsStub())
+	assert.True(t, analyzer.CanAnalyze("schema/billing.sql"))
+	assert.True(t, analyzer.CanAnalyze("LEGACY.SQL"))
+	assert.False(t, analyzer.CanAnalyze("billing.sqlite"))
+}
+
+func TestAnalyzePostgresFunctions(t *testing.T) {
+	result := analyzeSample(t, "billing.sql", samplePostgresSource)
+
+	assert.Equal(t, "SQL", result.Language)
+	require.Len(t, result.Functions, 2)
+
+	discount := result.Functions[0]
+	assert.Equal(t, "billing.apply_discount", discount.Name)
+	assert.Equal(t, 3, discount.ParameterCount)
+	assert.Equal(t, 4, discount.StartLine)
+	assert.Equal(t, 28, discount.EndLine)
+	// IF, OR, ELSIF, AND, LOOP, IF (BETWEEN's AND is not counted), CASE WHEN, EXCEPTION WHEN;
+	// the IF inside the string literal is ignored
+	assert.Equal(t, 9, discount.CyclomaticComplexity)
+	assert.Equal(t, 3, discount.NestingDepth) // IF > FOR LOOP > IF
+	assert.Equal(t, 2, discount.ReturnCount)
+	assert.Greater(t, discount.MaintainabilityIndex, 0.0)
+
+	addOne := result.Functions[1]
+	assert.Equal(t, "add_one", addOne.Name)
+	assert.Equal(t, 1, addOne.ParameterCount)
+	assert.Equal(t, 30, addOne.StartLine)
+	assert.Equal(t, 30, addOne.EndLine)
+	assert.Equal(t, 1, addOne.CyclomaticComplexity)
+}
+
+func TestAnalyzeTSQLProcedures(t *testing.T) {
+	result := analyzeSample(t, "archive.sql", sampleTSQLSource)
+
+	require.Len(t, result.Functions, 2)
+
+	archive := result.Functions[0]
+	assert.Equal(t, "dbo.ArchiveOrders", archive.Name)
+	assert.Equal(t, 2, archive.ParameterCount)
+	assert.Equal(t, 1, archive.StartLine)
+	assert.Equal(t, 24, archive.EndLine)
+	// WHILE, IF, ELSE IF, CATCH
+	assert.Equal(t, 5, archive.CyclomaticComplexity)
+	assert.Equal(t, 2, archive.NestingDepth) // WHILE > IF
+
+	purge := result.Functions[1]
+	assert.Equal(t, "dbo.PurgeLog", purge.Name)
+	assert.Equal(t, 0, purge.ParameterCount)
+	assert.Equal(t, 27, purge.StartLine)
+	assert.Equal(t, 29, purge.EndLine, "a T-SQL body without BEGIN runs to GO")
+}
+
+func TestAnalyzeMySQLProcedure(t *testing.T) {
+	result := analyzeSample(t, "count.sql", sampleMySQLSource)
+
+	require.Len(t, result.Functions, 1)
+
+	procedure := result.Functions[0]
+	assert.Equal(t, "count_rows", procedure.Name)
+	assert.Equal(t, 2, procedure.ParameterCount)
+	assert.Equal(t, 2, procedure.StartLine)
+	assert.Equal(t, 9, procedure.EndLine)
+	// HANDLER, REPEAT, OR
+	assert.Equal(t, 4, procedure.CyclomaticComplexity)
+	assert.Equal(t, 1, procedure.NestingDepth)
+}
+
+func TestAnalyzeScriptWithoutRoutines(t *testing.T) {
+	result := analyzeSample(t, "schema.sql", "-- Schema\nCREATE TABLE t (id int);\nDROP VIEW IF EXISTS v;\n")
+
+	assert.Empty(t, result.Functions)
+	assert.Equal(t, 1, result.CommentLines)
+}
+
+func TestCognitiveComplexityNesting(t *testing.T) {
+	tokens := tokenTexts(tokenize(`BEGIN
+    IF a THEN
+        WHILE b LOOP
+            IF c AND d AND e THEN
+                NULL;
+            END IF;
+        END LOOP;
+    ELSIF f THEN
+        NULL;
+    ELSE
+        NULL;
+    END IF;
+END`))
+	routine := NewSQLRoutine("sample", 1, 13, 0, tokens, "")
+
+	// IF(1) + WHILE(2) + IF(3) + AND sequence(1) + ELSIF(1) + ELSE(1)
+	assert.Equal(t, 9, routine.CalculateCognitiveComplexity())
+	assert.Equal(t, 3, routine.MaxNestingDepth())
+}
@@ -0,0 +1,466 @@
+package sql
+
+import (
+	"math"
+	"strings"
+)
+
+// SQLRoutine holds the tokens of a single stored function or procedure for metric calculations
+type SQLRoutine struct {
+	name           string
+	startLine      int
+	endLine        int
+	parameterCount int
+	bodyTokens     []string // Body tokens with comments and literals removed
+	strippedSource string   // Whole definition with comments and literals blanked out
+}
+
+// NewSQLRoutine creates a new SQLRoutine
+func NewSQLRoutine(name string, startLine, endLine, parameterCount int, bodyTokens []string, strippedSource string) *SQLRoutine {
+	return &SQLRoutine{
+		name:           name,
+		startLine:      startLine,
+		endLine:        endLine,
+		parameterCount: parameterCount,
+		bodyTokens:     bodyTokens,
+		strippedSource: strippedSource,
+	}
+}
+
+// Name returns the routine name
+func (sqlRoutine *SQLRoutine) Name() string {
+	return sqlRoutine.name
+}
+
+// LineCount returns the total lines (including blank/comments)
+func (sqlRoutine *SQLRoutine) LineCount() int {
+	return sqlRoutine.endLine - sqlRoutine.startLine + 1
+}
+
+// LogicalLineCount returns the number of lines containing code
+func (sqlRoutine *SQLRoutine) LogicalLineCount() int {
+	count := 0
+	for _, line := range strings.Split(sqlRoutine.strippedSource, "\n") {
+		if strings.TrimSpace(line) != "" {
+			count++
+		}
+	}
+	return count
+}
+
+// ParameterCount returns the number of declared parameters
+func (sqlRoutine *SQLRoutine) ParameterCount() int {
+	return sqlRoutine.parameterCount
+}
+
+// ReturnCount returns the number of RETURN statements
+func (sqlRoutine *SQLRoutine) ReturnCount() int {
+	count := 0
+	for _, token := range sqlRoutine.bodyTokens {
+		if upper(token) == "RETURN" {
+			count++
+		}
+	}
+	return count
+}
+
+// ddlObjects precede IF in "DROP TABLE IF EXISTS" and similar, where IF is not a branch
+var ddlObjects = map[string]bool{
+	"TABLE":     true,
+	"VIEW":      true,
+	"INDEX":     true,
+	"SEQUENCE":  true,
+	"FUNCTION":  true,
+	"PROCEDURE": true,
+	"TRIGGER":   true,
+	"SCHEMA":    true,
+	"TYPE":      true,
+	"EXTENSION": true,
+}
+
+// CalculateCyclomaticComplexity calculates McCabe's cyclomatic complexity. Each IF, ELSIF,
+// WHEN (CASE branches and EXCEPTION handlers), loop, CATCH block, condition handler and
+// AND/OR adds a path.
+func (sqlRoutine *SQLRoutine) CalculateCyclomaticComplexity() int {
+	complexity := 1
+	loopCounted := false
+	betweenPending := false
+
+	for index, token := range sqlRoutine.bodyTokens {
+		keyword := upper(token)
+		previous := sqlRoutine.keywordAt(index - 1)
+
+		switch keyword {
+		case "IF":
+			if previous != "END" && !ddlObjects[previous] {
+				complexity++
+			}
+		case "ELSIF", "ELSEIF", "WHEN", "HANDLER":
+			complexity++
+		case "WHILE":
+			if previous != "END" {
+				complexity++
+				loopCounted = true
+			}
+		case "LOOP":
+			// WHILE ... LOOP is one loop
+			if previous != "END" && !loopCounted {
+				complexity++
+			}
+			loopCounted = false
+		case "REPEAT":
+			if previous != "END" {
+				complexity++
+			}
+		case "CATCH":
+			if previous == "BEGIN" {
+				complexity++
+			}
+		case "BETWEEN":
+			betweenPending = true
+		case "AND":
+			// The AND of BETWEEN x AND y is not a condition
+			if betweenPending {
+				betweenPending = false
+			} else {
+				complexity++
+			}
+		case "OR":
+			complexity++
+		case ";":
+			loopCounted = false
+		}
+	}
+
+	return complexity
+}
+
+// CalculateCognitiveComplexity calculates cognitive complexity, penalising control
+// structures by how deeply they are nested
+func (sqlRoutine *SQLRoutine) CalculateCognitiveComplexity() int {
+	complexity := 0
+	sqlRoutine.walkControlFlow(func(keyword string, nesting int, afterElse bool) {
+		switch keyword {
+		case "IF":
+			if afterElse {
+				complexity++
+			} else {
+				complexity += 1 + nesting
+			}
+		case "ELSE", "AND", "OR":
+			complexity++
+		default:
+			complexity += 1 + nesting
+		}
+	})
+	return complexity
+}
+
+// MaxNestingDepth returns the deepest nesting of control structures
+func (sqlRoutine *SQLRoutine) MaxNestingDepth() int {
+	maxDepth := 0
+	sqlRoutine.walkControlFlow(func(keyword string, nesting int, afterElse bool) {
+		if keyword == "AND" || keyword == "OR" || keyword == "ELSE" || afterElse {
+			return
+		}
+		if nesting+1 > maxDepth {
+			maxDepth = nesting + 1
+		}
+	})
+	return maxDepth
+}
+
+// walkControlFlow visits each control structure in the body along with the number of
+// enclosing control blocks. Blocks open with THEN (IF), LOOP or DO (loops), REPEAT and CASE,
+// or with a BEGIN following IF/ELSE/WHILE (T-SQL), and close with END. ELSIF, ELSEIF and
+// ELSE IF are reported as an "IF" with afterElse set, and sequences of the same boolean
+// operator are reported once.
+func (sqlRoutine *SQLRoutine) walkControlFlow(visit func(keyword string, nesting int, afterElse bool)) {
+	type blockFrame struct {
+		isControl bool
+		isCase    bool
+	}
+
+	var blockStack []blockFrame
+	nesting := 0
+	pendingIf := false    // IF waiting for THEN
+	pendingBlock := false // IF/ELSE/WHILE waiting for a T-SQL BEGIN
+	pendingLoop := false  // WHILE waiting for LOOP or DO
+	betweenPending := false
+	parenDepth := 0
+	lastLogicalOperator := ""
+
+	openBlock := func(isControl, isCase bool) {
+		blockStack = append(blockStack, blockFrame{isControl: isControl, isCase: isCase})
+		if isControl {
+			nesting++
+		}
+		pendingIf = false
+		pendingBlock = false
+		pendingLoop = false
+	}
+
+	for index, token := range sqlRoutine.bodyTokens {
+		keyword := upper(token)
+		previous := sqlRoutine.keywordAt(index - 1)
+		next := sqlRoutine.keywordAt(index + 1)
+		insideCase := len(blockStack) > 0 && blockStack[len(blockStack)-1].isCase
+
+		switch keyword {
+		case "IF":
+			if previous == "END" || ddlObjects[previous] {
+				continue
+			}
+			visit(keyword, nesting, previous == "ELSE")
+			pendingIf = previous != "ELSE"
+			pendingBlock = true
+		case "ELSIF", "ELSEIF":
+			visit("IF", nesting, true)
+		case "ELSE":
+			if insideCase || next == "IF" {
+				continue
+			}
+			visit(keyword, nesting, false)
+			pendingBlock = true
+		case "THEN":
+			lastLogicalOperator = ""
+			if pendingIf {
+				openBlock(true, false)
+			}
+		case "CASE":
+			if previous == "END" {
+				continue
+			}
+			visit(keyword, nesting, false)
+			openBlock(true, true)
+		case "WHILE":
+			if previous == "END" {
+				continue
+			}
+			visit(keyword, nesting, false)
+			pendingBlock = true
+			pendingLoop = true
+		case "LOOP":
+			if previous == "END" {
+				continue
+			}
+			if !pendingLoop {
+				visit(keyword, nesting, false)
+			}
+			openBlock(true, false)
+		case "DO":
+			if pendingLoop {
+				openBlock(true, false)
+			}
+		case "REPEAT":
+			if previous == "END" {
+				continue
+			}
+			visit(keyword, nesting, false)
+			openBlock(true, false)
+		case "BEGIN":
+			if next == "TRAN" || next == "TRANSACTION" || next == "DISTRIBUTED" || next == "WORK" || next == ";" {
+				continue
+			}
+			if next == "CATCH" {
+				visit("CATCH", nesting, false)
+				openBlock(true, false)
+				continue
+			}
+			openBlock(pendingBlock, false)
+		case "EXCEPTION":
+			if next == "WHEN" {
+				visit(keyword, nesting, false)
+			}
+		case "END":
+			lastLogicalOperator = ""
+			if len(blockStack) > 0 {
+				frame := blockStack[len(blockStack)-1]
+				blockStack = blockStack[:len(blockStack)-1]
+				if frame.isControl {
+					nesting--
+				}
+			}
+		case "BETWEEN":
+			betweenPending = true
+		case "AND", "OR":
+			if keyword == "AND" && betweenPending {
+				betweenPending = false
+				continue
+			}
+			if keyword != lastLogicalOperator {
+				visit(keyword, nesting, false)
+			}
+			lastLogicalOperator = keyword
+		case "(":
+			parenDepth++
+		case ")":
+			if parenDepth > 0 {
+				parenDepth--
+			}
+		case ";":
+			lastLogicalOperator = ""
+			if parenDepth == 0 {
+				pendingIf = false
+				pendingBlock = false
+				pendingLoop = false
+			}
+		}
+	}
+}
+
+// keywordAt returns the upper-cased body token at index, or "" outside the body
+func (sqlRoutine *SQLRoutine) keywordAt(index int) string {
+	if index < 0 || index >= len(sqlRoutine.bodyTokens) {
+		return ""
+	}
+	return upper(sqlRoutine.bodyTokens[index])
+}
+
+// sqlKeywords are counted as Halstead operators rather than operands
+var sqlKeywords = map[string]bool{
+	"SELECT": true, "FROM": true, "WHERE": true, "INSERT": true, "INTO": true, "VALUES": true,
+	"UPDATE": true, "SET": true, "DELETE": true, "MERGE": true, "JOIN": true, "ON": true,
+	"GROUP": true, "BY": true, "ORDER": true, "HAVING": true, "UNION": true, "AS": true,
+	"IF": true, "THEN": true, "ELSE": true, "ELSIF": true, "ELSEIF": true, "END": true,
+	"CASE": true, "WHEN": true, "WHILE": true, "LOOP": true, "FOR": true, "DO": true,
+	"REPEAT": true, "UNTIL": true, "BEGIN": true, "DECLARE": true, "RETURN": true,
+	"EXCEPTION": true, "RAISE": true, "CALL": true, "EXEC": true, "EXECUTE": true,
+	"PERFORM": true, "AND": true, "OR": true, "NOT": true, "IN": true, "IS": true,
+	"NULL": true, "EXISTS": true, "BETWEEN": true, "LIKE": true,
+}
+
+// CalculateHalstead calculates Halstead volume and difficulty for the routine body
+func (sqlRoutine *SQLRoutine) CalculateHalstead() (volume, difficulty, effort, timeToUnderstand float64) {
+	operators := make(map[string]bool)
+	operands := make(map[string]bool)
+	totalOperators := 0
+	totalOperands := 0
+
+	for _, token := range sqlRoutine.bodyTokens {
+		keyword := upper(token)
+		if sqlKeywords[keyword] || !(isIdentifierStart(token[0]) || isDigit(token[0]) || strings.ContainsRune("@$\"`[", rune(token[0]))) {
+			operators[keyword] = true
+			totalOperators++
+		} else {
+			operands[token] = true
+			totalOperands++
+		}
+	}
+
+	distinctOperators := len(operators)
+	distinctOperands := len(operands)
+
+	if distinctOperators == 0 || distinctOperands == 0 {
+		return 0, 0, 0, 0
+	}
+
+	// Halstead Volume = (N1 + N2) * log2(n1 + n2)
+	vocab := float64(distinctOperators + distinctOperands)
+	length := float64(totalOperators + totalOperands)
+	volume = length * math.Log2(vocab)
+
+	// Halstead Difficulty = (n1/2) * (N2/n2)
+	difficulty = (float64(distinctOperators) / 2.0) * (float64(totalOperands) / float64(distinctOperands))
+
+	// Effort = Volume * Difficulty
+	effort = volume * difficulty
+
+	// Time to understand in seconds = Effort / 18
+	timeToUnderstand = effort / 18.0
+
+	return volume, difficulty, effort, timeToUnderstand
+}
+
+// sqlToken is a token of stripped SQL source with its byte offset
+type sqlToken struct {
+	text   string
+	offset int
+}
+
+// tokenize splits stripped SQL source into identifiers (including @variables, #temp tables
+// and $1 parameters), quoted identifiers, numbers, dollar-quote delimiters such as $$ or
+// $body$, two-character operators and single punctuation characters
+func tokenize(strippedSource string) []sqlToken {
+	var tokens []sqlToken
+
+	for offset := 0; offset < len(strippedSource); offset++ {
+		char := strippedSource[offset]
+		end := offset + 1
+
+		switch {
+		case char == ' ' || char == '\t' || char == '\n' || char == '\r':
+			continue
+		case isIdentifierStart(char) || ((char == '@' || char == '#') && end < len(strippedSource) && (isIdentifierStart(strippedSource[end]) || strippedSource[end] == '@')):
+			for end < len(strippedSource) && (isIdentifierStart(strippedSource[end]) || isDigit(strippedSource[end]) || strippedSource[end] == '@') {
+				end++
+			}
+		case char == '$':
+			// $tag$ delimits a dollar-quoted block; $1 is a positional parameter
+			for end < len(strippedSource) && (isIdentifierStart(strippedSource[end]) || isDigit(strippedSource[end])) {
+				end++
+			}
+			if end < len(strippedSource) && strippedSource[end] == '$' && !isDigit(strippedSource[offset+1]) {
+				end++
+			}
+		case char == '"' || char == '`' || char == '[':
+			closing := char
+			if char == '[' {
+				closing = ']'
+			}
+			closeOffset := strings.IndexByte(strippedSource[end:], closing)
+			if closeOffset >= 0 && !strings.ContainsRune(strippedSource[end:end+closeOffset], '\n') {
+				end += closeOffset + 1
+			}
+		case isDigit(char):
+			for end < len(strippedSource) && (isDigit(strippedSource[end]) || strippedSource[end] == '.') {
+				end++
+			}
+		case end < len(strippedSource) && isTwoCharOperator(strippedSource[offset:end+1]):
+			end++
+		}
+
+		tokens = append(tokens, sqlToken{text: strippedSource[offset:end], offset: offset})
+		offset = end - 1
+	}
+
+	return tokens
+}
+
+// tokenTexts returns the text of each token
+func tokenTexts(tokens []sqlToken) []string {
+	texts := make([]string, len(tokens))
+	for index, token := range tokens {
+		texts[index] = token.text
+	}
+	return texts
+}
+
+// isTwoCharOperator reports whether operator is a two-character SQL operator
+func isTwoCharOperator(operator string) bool {
+	switch operator {
+	case "<=", ">=", "<>", "!=", ":=", "||", "::", "=>":
+		return true
+	}
+	return false
+}
+
+// isDollarQuote reports whether a token is a dollar-quote delimiter such as $$ or $body$
+func isDollarQuote(text string) bool {
+	return len(text) >= 2 && text[0] == '$' && text[len(text)-1] == '$'
+}
+
+// upper returns a token upper-cased for keyword comparison
+func upper(text string) string {
+	return strings.ToUpper(text)
+}
+
+// isIdentifierStart checks if a character can begin an identifier
+func isIdentifierStart(char byte) bool {
+	return (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z') || char == '_'
+}
+
+// isDigit checks if a character is a decimal digit
+func isDigit(char byte) bool {
+	return char >= '0' && char <= '9'
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/alexcollie/kaizen/pkg/analyzer"
+	"github.com/alexcollie/kaizen/pkg/languages/anonymous"
 	"github.com/alexcollie/kaizen/pkg/models"
 	"github.com/smacker/go-tree-sitter"
 	"github.com/smacker/go-tree-sitter/swift"
@@ -51,13 +52,23 @@ func (swiftAnalyzer *SwiftAnalyzer) IsStub() bool {
 	return false
 }
 
+// swiftCommentSyntax drives commented-out code detection
+var swiftCommentSyntax = analyzer.CommentSyntax{
+	LinePrefix: "//",
+	Keywords:   []string{"func", "let", "var", "if", "guard", "else", "for", "while", "return", "class", "struct", "import", "switch", "case"},
+}
+
 // AnalyzeFile performs full analysis on a single Swift file
 func (swiftAnalyzer *SwiftAnalyzer) AnalyzeFile(filePath string) (*models.FileAnalysis, error) {
-	// Read source code
 	sourceBytes, err := os.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
+	return swiftAnalyzer.AnalyzeSource(filePath, sourceBytes)
+}
+
+// AnalyzeSource performs full analysis on in-memory Swift source reported under filePath
+func (swiftAnalyzer *SwiftAnalyzer) AnalyzeSource(filePath string, sourceBytes []byte) (*models.FileAnalysis, error) {
 	sourceCode := string(sourceBytes)
 
 	// Count lines
@@ -100,6 +111,7 @@ func (swiftAnalyzer *SwiftAnalyzer) AnalyzeFile(filePath string) (*models.FileAn
 		ImportCount:           importCount,
 		Functions:             functions,
 		Types:                 types,
+		CommentedCode:         analyzer.FindCommentedCode(sourceCode, swiftCommentSyntax),
 	}, nil
 }
 
@@ -156,27 +168,34 @@ func (swiftAnalyzer *SwiftAnalyzer) extractFunctions(node *sitter.Node, sourceBy
 	cursor := sitter.NewTreeCursor(node)
 	defer cursor.Close()
 
-	swiftAnalyzer.walkFunctions(cursor, &functions, sourceBytes)
+	swiftAnalyzer.walkFunctions(cursor, &functions, anonymous.NewNamer(), sourceBytes)
 
 	return functions
 }
 
-// walkFunctions recursively walks the AST to find function declarations
-func (swiftAnalyzer *SwiftAnalyzer) walkFunctions(cursor *sitter.TreeCursor, functions *[]models.FunctionAnalysis, sourceBytes []byte) {
+// walkFunctions recursively walks the AST to find function declarations. Closures are reported
+// as anonymous functions named after the function enclosing them.
+func (swiftAnalyzer *SwiftAnalyzer) walkFunctions(cursor *sitter.TreeCursor, functions *[]models.FunctionAnalysis, namer *anonymous.Namer, sourceBytes []byte) {
 	node := cursor.CurrentNode()
 
-	// Check if this is a function declaration
-	if node.Type() == "function_declaration" {
-		funcAnalysis := swiftAnalyzer.analyzeFunctionNode(node, sourceBytes)
+	switch node.Type() {
+	case "function_declaration":
+		funcAnalysis := swiftAnalyzer.analyzeFunctionNode(node, swiftAnalyzer.extractFunctionName(node, sourceBytes), sourceBytes)
 		if funcAnalysis != nil {
 			*functions = append(*functions, *funcAnalysis)
 		}
+	case "lambda_literal":
+		name := namer.Name(swiftAnalyzer.enclosingFunctionName(node, sourceBytes), "closure")
+		funcAnalysis := swiftAnalyzer.analyzeFunctionNode(node, name, sourceBytes)
+		funcAnalysis.IsAnonymous = true
+		funcAnalysis.ParameterCount = countClosureParameters(node)
+		*functions = append(*functions, *funcAnalysis)
 	}
 
 	// Recursively visit children
 	if cursor.GoToFirstChild() {
 		for {
-			swiftAnalyzer.walkFunctions(cursor, functions, sourceBytes)
+			swiftAnalyzer.walkFunctions(cursor, functions, namer, sourceBytes)
 			if !cursor.GoToNextSibling() {
 				break
 			}
@@ -185,9 +204,36 @@ func (swiftAnalyzer *SwiftAnalyzer) walkFunctions(cursor *sitter.TreeCursor, fun
 	}
 }
 
-// analyzeFunctionNode extracts details from a function declaration node
-func (swiftAnalyzer *SwiftAnalyzer) analyzeFunctionNode(node *sitter.Node, sourceBytes []byte) *models.FunctionAnalysis {
-	funcName := swiftAnalyzer.extractFunctionName(node, sourceBytes)
+// enclosingFunctionName returns the name of the nearest function declaration around node, or ""
+// outside any function
+func (swiftAnalyzer *SwiftAnalyzer) enclosingFunctionName(node *sitter.Node, sourceBytes []byte) string {
+	for parent := node.Parent(); parent != nil; parent = parent.Parent() {
+		if parent.Type() == "function_declaration" {
+			return swiftAnalyzer.extractFunctionName(parent, sourceBytes)
+		}
+	}
+	return ""
+}
+
+// countClosureParameters counts the parameters a closure names before in; a closure using
+// shorthand arguments such as $0 names none
+func countClosureParameters(node *sitter.Node) int {
+	closureType := node.ChildByFieldName("type")
+	if closureType == nil {
+		return 0
+	}
+	for childIdx := 0; childIdx < int(closureType.NamedChildCount()); childIdx++ {
+		child := closureType.NamedChild(childIdx)
+		if child.Type() == "lambda_function_type_parameters" {
+			return int(child.NamedChildCount())
+		}
+	}
+	return 0
+}
+
+// analyzeFunctionNode extracts details from a function declaration or closure node, reported
+// under funcName
+func (swiftAnalyzer *SwiftAnalyzer) analyzeFunctionNode(node *sitter.Node, funcName string, sourceBytes []byte) *models.FunctionAnalysis {
 	if funcName == "" {
 		return nil
 	}
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/alexcollie/kaizen/pkg/analyzer"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -198,3 +199,85 @@ func TestSwiftFunctionComplexity(t *testing.T) {
 	assert.NotNil(t, result)
 	assert.Greater(t, len(result.Functions), 0, "Should extract at least one function")
 }
+
+func TestSwiftNestingDepth(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "nesting.swift")
+
+	swiftCode := `func flat() {
+    print("flat")
+}
+
+func chained(value: Int) {
+    if value == 1 {
+        print("one")
+    } else if value == 2 {
+        print("two")
+    } else if value == 3 {
+        for item in [1, 2] {
+            print(item)
+        }
+    }
+}
+
+func closures(items: [Int]) {
+    items.forEach { item in
+        guard item > 0 else { return }
+    }
+}
+`
+
+	require.NoError(t, os.WriteFile(testFile, []byte(swiftCode), 0644))
+
+	result, err := NewSwiftAnalyzer().AnalyzeFile(testFile)
+	require.NoError(t, err)
+
+	depths := make(map[string]int)
+	for _, function := range result.Functions {
+		depths[function.Name] = function.NestingDepth
+	}
+	// The forEach closure is also reported on its own, as an anonymous function
+	assert.Equal(t, map[string]int{"flat": 0, "chained": 2, "closures": 2, "closures.closure1": 1}, depths)
+}
+
+const closureSource = `func load(urls: [String]) {
+    urls.forEach { url in
+        if url.isEmpty { return }
+    }
+    let sorted = urls.sorted { $0 < $1 }
+    DispatchQueue.main.async {
+        let counts = urls.map { (value: String) -> Int in value.count }
+    }
+}
+`
+
+func TestClosuresAreAnonymousFunctions(t *testing.T) {
+	result, err := analyzer.AnalyzeSource(NewSwiftAnalyzer(), "load.swift", []byte(closureSource), analyzer.AnalysisOptions{CountAnonymousFunctions: true})
+	require.NoError(t, err)
+
+	var names []string
+	for _, function := range result.Functions {
+		names = append(names, function.Name)
+		assert.Equal(t, function.Name != "load", function.IsAnonymous, function.Name)
+	}
+	// Closures are numbered in order, nested ones included
+	assert.Equal(t, []string{"load", "load.closure1", "load.closure2", "load.closure3", "load.closure4"}, names)
+
+	forEach := result.Functions[1]
+	assert.Equal(t, 2, forEach.StartLine)
+	assert.Equal(t, 4, forEach.EndLine)
+	assert.Equal(t, 1, forEach.ParameterCount)
+	assert.Equal(t, 2, forEach.CyclomaticComplexity)
+
+	// Shorthand arguments like $0 are not declared parameters
+	assert.Equal(t, 0, result.Functions[2].ParameterCount)
+}
+
+func TestClosuresDroppedWithoutCountAnonymousFunctions(t *testing.T) {
+	result, err := analyzer.AnalyzeSource(NewSwiftAnalyzer(), "load.swift", []byte(closureSource), analyzer.AnalysisOptions{})
+	require.NoError(t, err)
+
+	require.Len(t, result.Functions, 1)
+	assert.Equal(t, "load", result.Functions[0].Name)
+	assert.False(t, result.Functions[0].IsAnonymous)
+}
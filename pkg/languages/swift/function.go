@@ -1,6 +1,7 @@
 package swift
 
 import (
+	"github.com/alexcollie/kaizen/pkg/languages/nesting"
 	"github.com/smacker/go-tree-sitter"
 )
 
@@ -110,50 +111,23 @@ func (swiftFunc *SwiftFunction) countCognitiveComplexity(cursor *sitter.TreeCurs
 	}
 }
 
-// CalculateNestingDepth calculates the maximum nesting depth
-func (swiftFunc *SwiftFunction) CalculateNestingDepth() int {
-	maxDepth := 0
-	currentDepth := 0
-
-	cursor := sitter.NewTreeCursor(swiftFunc.node)
-	defer cursor.Close()
-
-	swiftFunc.findMaxNestingDepth(cursor, &maxDepth, &currentDepth)
-
-	return maxDepth
+// swiftNesting lists the Swift constructs that open a nesting level, closures included. An
+// if that follows else continues its chain rather than nesting.
+var swiftNesting = nesting.Language{
+	BlockTypes: map[string]bool{
+		"if_statement":           true,
+		"guard_statement":        true,
+		"switch_statement":       true,
+		"for_statement":          true,
+		"while_statement":        true,
+		"repeat_while_statement": true,
+		"do_statement":           true,
+		"lambda_literal":         true,
+	},
+	IfTypes: map[string]bool{"if_statement": true},
 }
 
-// findMaxNestingDepth recursively finds the maximum nesting depth
-func (swiftFunc *SwiftFunction) findMaxNestingDepth(cursor *sitter.TreeCursor, maxDepth *int, currentDepth *int) {
-	node := cursor.CurrentNode()
-	nodeType := node.Type()
-
-	// Increase depth for control structures
-	shouldIncreaseDepth := false
-	switch nodeType {
-	case "if_statement", "guard_statement", "switch_statement",
-		"for_statement", "while_statement", "repeat_while_statement",
-		"do_catch_statement", "block":
-		*currentDepth++
-		shouldIncreaseDepth = true
-		if *currentDepth > *maxDepth {
-			*maxDepth = *currentDepth
-		}
-	}
-
-	// Recursively visit children
-	if cursor.GoToFirstChild() {
-		for {
-			swiftFunc.findMaxNestingDepth(cursor, maxDepth, currentDepth)
-			if !cursor.GoToNextSibling() {
-				break
-			}
-		}
-		cursor.GoToParent()
-	}
-
-	// Decrease depth when leaving structure
-	if shouldIncreaseDepth {
-		*currentDepth--
-	}
+// CalculateNestingDepth calculates the maximum nesting depth
+func (swiftFunc *SwiftFunction) CalculateNestingDepth() int {
+	return nesting.MaxDepth(swiftFunc.node, swiftNesting)
 }
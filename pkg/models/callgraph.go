@@ -5,6 +5,16 @@ type CallGraph struct {
 	Nodes map[string]*CallNode `json:"nodes"` // Key is function full name
 	Edges []CallEdge           `json:"edges"`
 	Stats CallGraphStats       `json:"stats"`
+
+	edgeIndex    map[callEdgeKey]int // Position of each caller/callee pair in Edges, built by AddEdge
+	indexedEdges *CallEdge           // First element of the Edges slice edgeIndex describes
+	indexedCount int                 // Length of Edges when edgeIndex was last updated
+}
+
+// callEdgeKey identifies an edge by its endpoints
+type callEdgeKey struct {
+	from string
+	to   string
 }
 
 // CallNode represents a function in the call graph
@@ -69,16 +79,48 @@ func (graph *CallGraph) AddEdge(edge CallEdge) {
 	}
 
 	// Check if edge already exists and increment weight
-	for index := range graph.Edges {
-		if graph.Edges[index].From == edge.From && graph.Edges[index].To == edge.To {
-			graph.Edges[index].Weight++
-			return
-		}
+	if !graph.edgeIndexIsCurrent() {
+		graph.rebuildEdgeIndex()
+	}
+	key := callEdgeKey{from: edge.From, to: edge.To}
+	if index, exists := graph.edgeIndex[key]; exists {
+		graph.Edges[index].Weight++
+		return
 	}
 
 	// Add new edge
 	edge.Weight = 1
+	graph.edgeIndex[key] = len(graph.Edges)
 	graph.Edges = append(graph.Edges, edge)
+	graph.markEdgesIndexed()
+}
+
+// edgeIndexIsCurrent reports whether edgeIndex still describes Edges. Edges that were
+// reassigned or appended to directly, e.g. by a filter or when decoding JSON, have a different
+// length or backing array.
+func (graph *CallGraph) edgeIndexIsCurrent() bool {
+	if graph.edgeIndex == nil || len(graph.Edges) != graph.indexedCount {
+		return false
+	}
+	return len(graph.Edges) == 0 || &graph.Edges[0] == graph.indexedEdges
+}
+
+// rebuildEdgeIndex indexes every edge in Edges, keeping the first of any duplicate pair
+func (graph *CallGraph) rebuildEdgeIndex() {
+	graph.edgeIndex = make(map[callEdgeKey]int, len(graph.Edges))
+	for index := len(graph.Edges) - 1; index >= 0; index-- {
+		graph.edgeIndex[callEdgeKey{from: graph.Edges[index].From, to: graph.Edges[index].To}] = index
+	}
+	graph.markEdgesIndexed()
+}
+
+// markEdgesIndexed records which Edges slice edgeIndex describes
+func (graph *CallGraph) markEdgesIndexed() {
+	graph.indexedCount = len(graph.Edges)
+	graph.indexedEdges = nil
+	if len(graph.Edges) > 0 {
+		graph.indexedEdges = &graph.Edges[0]
+	}
 }
 
 // FilterByFunctionNames returns a new CallGraph containing only the named functions
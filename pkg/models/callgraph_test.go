@@ -0,0 +1,37 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddEdgeMergesRepeatedCalls(t *testing.T) {
+	graph := NewCallGraph()
+	graph.AddEdge(CallEdge{From: "main.run", To: "main.load"})
+	graph.AddEdge(CallEdge{From: "main.run", To: "main.save"})
+	graph.AddEdge(CallEdge{From: "main.run", To: "main.load"})
+
+	assert.Equal(t, []CallEdge{
+		{From: "main.run", To: "main.load", Weight: 2},
+		{From: "main.run", To: "main.save", Weight: 1},
+	}, graph.Edges)
+}
+
+func TestAddEdgeAfterEdgesReplacedWithSameLength(t *testing.T) {
+	graph := NewCallGraph()
+	graph.AddEdge(CallEdge{From: "main.run", To: "main.load"})
+	graph.AddEdge(CallEdge{From: "main.run", To: "main.save"})
+
+	// A different slice of the same length, as a filter or JSON decoding would leave
+	graph.Edges = []CallEdge{
+		{From: "main.run", To: "main.save", Weight: 1},
+		{From: "main.run", To: "main.load", Weight: 1},
+	}
+	graph.AddEdge(CallEdge{From: "main.run", To: "main.load"})
+
+	assert.Equal(t, []CallEdge{
+		{From: "main.run", To: "main.save", Weight: 1},
+		{From: "main.run", To: "main.load", Weight: 2},
+	}, graph.Edges)
+}
@@ -1,16 +1,51 @@
 package models
 
-import "time"
+import (
+	"path/filepath"
+	"time"
+)
+
+// SchemaVersion is the version of the AnalysisResult JSON format. It is bumped on every change
+// that could break a consumer: a field removed, renamed, or retyped, or its meaning changed.
+// Adding an optional field does not bump it.
+const SchemaVersion = 1
 
 // AnalysisResult represents the complete analysis of a codebase
 type AnalysisResult struct {
-	Repository  string                   `json:"repository"`
-	AnalyzedAt  time.Time                `json:"analyzed_at"`
-	TimeRange   TimeRange                `json:"time_range"`
-	Files       []FileAnalysis           `json:"files"`
-	FolderStats map[string]FolderMetrics `json:"folder_stats"`
-	Summary     SummaryMetrics           `json:"summary"`
-	ScoreReport *ScoreReport             `json:"score_report,omitempty"`
+	SchemaVersion               int                      `json:"schema_version"` // Format version, see SchemaVersion; 0 in results written before it existed
+	Repository                  string                   `json:"repository"`     // Repository root; relative file paths are relative to it
+	AnalyzedAt                  time.Time                `json:"analyzed_at"`
+	TimeRange                   TimeRange                `json:"time_range"`
+	ChurnMetric                 string                   `json:"churn_metric,omitempty"`                   // Churn count behind hotspots and churn concerns; empty means commits
+	MinFunctionLines            int                      `json:"min_function_lines,omitempty"`             // Shorter functions are trivial and skipped by concern detection
+	TrivialExcludedFromAverages bool                     `json:"trivial_excluded_from_averages,omitempty"` // Summary averages leave trivial functions out
+	AverageMethod               string                   `json:"average_method,omitempty"`                 // How summary and folder averages combine functions; empty means per_function
+	Files                       []FileAnalysis           `json:"files"`
+	FolderStats                 map[string]FolderMetrics `json:"folder_stats"`
+	ModuleStats                 map[string]FolderMetrics `json:"module_stats,omitempty"` // Keyed by module directory; only set for multi-module Go repos
+	APISurface                  map[string]APISurface    `json:"api_surface,omitempty"`  // Keyed by package directory; only packages with exported functions or types
+	Summary                     SummaryMetrics           `json:"summary"`
+	ScoreReport                 *ScoreReport             `json:"score_report,omitempty"`
+	SkippedFiles                []SkippedFile            `json:"skipped_files,omitempty"`           // Files left out of the analysis, e.g. on timeout
+	GeneratedFilesSkipped       int                      `json:"generated_files_skipped,omitempty"` // Files left out as generated (analysis.skip_generated)
+	TestFiles                   []FileAnalysis           `json:"test_files,omitempty"`              // Test files analyzed with --include-tests; not part of Files or any production aggregate
+	TestStats                   *TestMetrics             `json:"test_stats,omitempty"`              // Summary of TestFiles
+	TestsIncluded               bool                     `json:"tests_included,omitempty"`          // Test files were analyzed (--include-tests), so folders carry test ratios
+}
+
+// ResolvePath returns a file path from the result in a form that can be opened: relative paths
+// are joined onto Repository, and absolute paths are returned unchanged
+func (result *AnalysisResult) ResolvePath(path string) string {
+	if filepath.IsAbs(path) || result.Repository == "" {
+		return path
+	}
+	return filepath.Join(result.Repository, path)
+}
+
+// SkippedFile records a file that was discovered but not included in the analysis
+type SkippedFile struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
 }
 
 // TimeRange represents the time period analyzed for churn
@@ -39,9 +74,23 @@ type FileAnalysis struct {
 	// Churn metrics
 	Churn *ChurnMetric `json:"churn,omitempty"`
 
+	// Test coverage (0-100) from analysis.coverage_profile: covered statements for a Go cover
+	// profile, covered lines for LCOV; nil when the profile does not list the file
+	Coverage *float64 `json:"coverage,omitempty"`
+
 	// Function and type analysis
 	Functions []FunctionAnalysis `json:"functions"`
 	Types     []TypeAnalysis     `json:"types"`
+
+	// Runs of line comments that look like commented-out code
+	CommentedCode []CommentBlock `json:"commented_code,omitempty"`
+}
+
+// CommentBlock is a run of consecutive comment lines
+type CommentBlock struct {
+	StartLine int `json:"start_line"`
+	EndLine   int `json:"end_line"`
+	CodeLines int `json:"code_lines"` // Lines in the block that look like code
 }
 
 // FunctionAnalysis contains metrics for a single function
@@ -63,11 +112,24 @@ type FunctionAnalysis struct {
 	NestingDepth         int     `json:"nesting_depth"`
 	HalsteadVolume       float64 `json:"halstead_volume"`
 	HalsteadDifficulty   float64 `json:"halstead_difficulty"`
+	HalsteadEffort       float64 `json:"halstead_effort"` // Volume * Difficulty
+	HalsteadTime         float64 `json:"halstead_time"`   // Estimated seconds to understand (Effort / 18)
 	ABCScore             float64 `json:"abc_score"`
 
 	// Quality metrics
-	FanIn  int `json:"fan_in"`
-	FanOut int `json:"fan_out"`
+	FanIn         int  `json:"fan_in"`
+	FanOut        int  `json:"fan_out"`
+	StdlibCalls   int  `json:"stdlib_calls,omitempty"` // Calls in FanOut to the language's built-ins and standard library
+	HasDocComment bool `json:"has_doc_comment"`        // Only detected for Go (doc comment) and Python (docstring)
+	IsExported    bool `json:"is_exported,omitempty"`  // Part of its package's public API; only detected for Go, Kotlin, and Python
+
+	// Control-flow problems, by line (0 = none); only detected for Go
+	UnreachableLine   int `json:"unreachable_line,omitempty"`    // First statement after a return, panic, or other terminating statement
+	MissingReturnLine int `json:"missing_return_line,omitempty"` // Closing brace reached without returning a declared result
+
+	// Longest chain of calls on one expression, e.g. 3 for a.b().c().d(); only detected for Go and Kotlin
+	MethodChainLength int `json:"method_chain_length,omitempty"`
+	MethodChainLine   int `json:"method_chain_line,omitempty"` // Line where the longest chain starts
 
 	// Churn metrics
 	Churn *ChurnMetric `json:"churn,omitempty"`
@@ -75,6 +137,9 @@ type FunctionAnalysis struct {
 	// Composite scores
 	MaintainabilityIndex float64 `json:"maintainability_index"`
 	IsHotspot            bool    `json:"is_hotspot"`
+
+	// Closures, lambdas, and blocks; only kept when analysis.count_anonymous_functions is set
+	IsAnonymous bool `json:"is_anonymous,omitempty"`
 }
 
 // TypeAnalysis contains metrics for a class/struct/interface
@@ -82,6 +147,9 @@ type TypeAnalysis struct {
 	Name string `json:"name"`
 	Kind string `json:"kind"` // struct, interface, class
 
+	// Part of its package's public API; only detected for Go, Kotlin, and Python
+	IsExported bool `json:"is_exported,omitempty"`
+
 	// Coupling metrics
 	AfferentCoupling int     `json:"afferent_coupling"`
 	EfferentCoupling int     `json:"efferent_coupling"`
@@ -107,7 +175,7 @@ type ChurnMetric struct {
 	TotalCommits   int       `json:"total_commits"`
 	LinesAdded     int       `json:"lines_added"`
 	LinesDeleted   int       `json:"lines_deleted"`
-	TotalChanges   int       `json:"total_changes"`
+	TotalChanges   int       `json:"total_changes"` // Lines changed: LinesAdded + LinesDeleted
 	LastModified   time.Time `json:"last_modified"`
 	Contributors   []string  `json:"contributors"`
 	ChurnScore     float64   `json:"churn_score"`      // Normalized 0-100
@@ -115,6 +183,19 @@ type ChurnMetric struct {
 	AverageChurnBy float64   `json:"average_churn_by"` // Average days between changes
 }
 
+// FunctionCoupling records two functions that frequently change in the same commits
+type FunctionCoupling struct {
+	FirstFile       string  `json:"first_file"`
+	FirstFunction   string  `json:"first_function"`
+	SecondFile      string  `json:"second_file"`
+	SecondFunction  string  `json:"second_function"`
+	SharedCommits   int     `json:"shared_commits"`   // Commits touching both functions
+	FirstCommits    int     `json:"first_commits"`    // Commits touching the first function
+	SecondCommits   int     `json:"second_commits"`   // Commits touching the second function
+	CouplingDegree  float64 `json:"coupling_degree"`  // Shared / min(first, second), 0-1
+	CrossesBoundary bool    `json:"crosses_boundary"` // Functions live in different modules (directories)
+}
+
 // HalsteadMetrics represents Halstead complexity metrics
 type HalsteadMetrics struct {
 	DistinctOperators int     `json:"distinct_operators"` // n1
@@ -145,6 +226,7 @@ type FolderMetrics struct {
 	AverageLength         float64 `json:"average_length"`
 	AverageChurn          float64 `json:"average_churn"`
 	AverageMaintainability float64 `json:"average_maintainability"`
+	AverageHalsteadTime    float64 `json:"average_halstead_time"` // Seconds to understand a function
 
 	// Normalized scores for visualization (0-100)
 	ComplexityScore      float64 `json:"complexity_score"`
@@ -152,9 +234,24 @@ type FolderMetrics struct {
 	LengthScore          float64 `json:"length_score"`
 	MaintainabilityScore float64 `json:"maintainability_score"`
 	HotspotScore         float64 `json:"hotspot_score"` // Combined churn + complexity
+	RiskScore            float64 `json:"risk_score"`    // Weighted blend of churn, complexity, and missing coverage
+
+	// Line-weighted test coverage of files with coverage data (0-100); nil when none have it
+	TestCoverage *float64 `json:"test_coverage,omitempty"`
+
+	// Test code lines in the folder and their ratio to its production code lines; only set
+	// when test files were analyzed (--include-tests)
+	TestCodeLines int      `json:"test_code_lines,omitempty"`
+	TestRatio     *float64 `json:"test_ratio,omitempty"`
 
 	// Hotspot count
 	HotspotCount int `json:"hotspot_count"`
+
+	// Size-normalized metrics (per 1000 code lines) so small-but-dense folders stand out
+	ConcernCount        int     `json:"concern_count"`         // Functions over the complexity or function length warning threshold
+	HotspotDensity      float64 `json:"hotspot_density"`       // Hotspots per KLOC
+	ConcernDensity      float64 `json:"concern_density"`       // Concern functions per KLOC
+	HotspotDensityScore float64 `json:"hotspot_density_score"` // Normalized 0-100
 }
 
 // SummaryMetrics provides high-level statistics
@@ -173,6 +270,36 @@ type SummaryMetrics struct {
 	VeryHighComplexityCount   int     `json:"very_high_complexity_count"` // >20
 	LongFunctionCount         int     `json:"long_function_count"`        // >50 lines
 	VeryLongFunctionCount     int     `json:"very_long_function_count"`   // >100 lines
+	ConcernCount              int     `json:"concern_count"`              // Functions over the complexity or function length warning threshold
+	HotspotDensity            float64 `json:"hotspot_density"`            // Hotspots per KLOC
+	ConcernDensity            float64 `json:"concern_density"`            // Concern functions per KLOC
+	TrivialFunctionCount      int     `json:"trivial_function_count,omitempty"` // Functions shorter than analysis.min_function_lines
+	ExportedFunctionCount     int     `json:"exported_function_count,omitempty"` // Public API functions and methods, see APISurface
+	ExportedTypeCount         int     `json:"exported_type_count,omitempty"`     // Public API types, see APISurface
+}
+
+// APISurface counts the public API of one package (directory): the functions and types other
+// packages can use. Go counts capitalized names, with methods only on capitalized types; Kotlin
+// counts declarations without a private, protected, or internal modifier; Python counts names
+// without a leading underscore. Other languages do not record visibility and are not counted.
+type APISurface struct {
+	Package           string `json:"package"`
+	ExportedFunctions int    `json:"exported_functions"` // Including methods
+	ExportedTypes     int    `json:"exported_types"`
+}
+
+// TestMetrics summarizes test code separately from production code, so test complexity can be
+// tracked without affecting the grade
+type TestMetrics struct {
+	TotalFiles                  int     `json:"total_files"`
+	TotalFunctions              int     `json:"total_functions"`
+	TotalCodeLines              int     `json:"total_code_lines"`
+	AverageCyclomaticComplexity float64 `json:"average_cyclomatic_complexity"`
+	AverageCognitiveComplexity  float64 `json:"average_cognitive_complexity"`
+	AverageFunctionLength       float64 `json:"average_function_length"`
+	MaxCyclomaticComplexity     int     `json:"max_cyclomatic_complexity"`
+	HighComplexityCount         int     `json:"high_complexity_count"` // >10
+	LongFunctionCount           int     `json:"long_function_count"`   // >50 lines
 }
 
 // ScoreReport represents the overall health assessment of a codebase
@@ -181,7 +308,16 @@ type ScoreReport struct {
 	OverallScore    float64         `json:"overall_score"`    // 0-100
 	ComponentScores ComponentScores `json:"component_scores"`
 	Concerns        []Concern       `json:"concerns"`
+	ConcernSummary  ConcernSummary  `json:"concern_summary"` // Concerns counted by severity
 	HasChurnData    bool            `json:"has_churn_data"`
+	ChurnWeighted   bool            `json:"churn_weighted,omitempty"` // Component scores weighted by file churn
+}
+
+// ConcernSummary tallies a report's concerns by severity, for consumers that only need the counts
+type ConcernSummary struct {
+	Critical int `json:"critical"`
+	Warning  int `json:"warning"`
+	Info     int `json:"info"`
 }
 
 // ComponentScores breaks down health by category
@@ -203,10 +339,12 @@ type CategoryScore struct {
 // Concern represents an area needing attention
 type Concern struct {
 	Type          string         `json:"type"`
-	Severity      string         `json:"severity"` // "critical", "warning", "info"
+	Severity      string         `json:"severity"`                 // "critical", "warning", "info"
+	EscalatedFrom string         `json:"escalated_from,omitempty"` // Severity before trend-aware escalation, e.g. "warning"
 	Title         string         `json:"title"`
 	Description   string         `json:"description"`
 	AffectedItems []AffectedItem `json:"affected_items"`
+	OmittedItems  int            `json:"omitted_items,omitempty"` // Affected items left out by reports.max_items_per_concern
 }
 
 // AffectedItem references a specific file or function
@@ -214,5 +352,7 @@ type AffectedItem struct {
 	FilePath     string             `json:"file_path"`
 	FunctionName string             `json:"function_name,omitempty"`
 	Line         int                `json:"line,omitempty"`
+	EndLine      int                `json:"end_line,omitempty"` // Set when the item covers a line range
+	Overload     string             `json:"overload,omitempty"` // Set when other functions in the file share the name; see FileAnalysis.OverloadKeys
 	Metrics      map[string]float64 `json:"metrics"`
 }
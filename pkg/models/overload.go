@@ -0,0 +1,36 @@
+package models
+
+import "fmt"
+
+// OverloadKeys returns, for each of the file's functions in order, the key that tells it apart
+// from other functions of the same name in the file, such as Kotlin or Swift overloads. A
+// function whose name is unique in the file gets "". Overloads are keyed by parameter count,
+// e.g. "(2)", which stays stable as code around them moves; overloads that also share a
+// parameter count are numbered in declaration order, e.g. "(2)#2" for the second.
+func (file FileAnalysis) OverloadKeys() []string {
+	nameCounts := make(map[string]int)
+	for _, function := range file.Functions {
+		nameCounts[function.Name]++
+	}
+
+	keys := make([]string, len(file.Functions))
+	signatureTotals := make(map[string]int)
+	for index, function := range file.Functions {
+		if nameCounts[function.Name] < 2 {
+			continue
+		}
+		keys[index] = fmt.Sprintf("(%d)", function.ParameterCount)
+		signatureTotals[function.Name+keys[index]]++
+	}
+
+	signatureSeen := make(map[string]int)
+	for index, function := range file.Functions {
+		signature := function.Name + keys[index]
+		if keys[index] == "" || signatureTotals[signature] < 2 {
+			continue
+		}
+		signatureSeen[signature]++
+		keys[index] = fmt.Sprintf("%s#%d", keys[index], signatureSeen[signature])
+	}
+	return keys
+}
@@ -30,7 +30,8 @@ func (agg *Aggregator) AggregateByOwner(result *models.AnalysisResult) (map[stri
 
 	// Process each file
 	for _, fileAnalysis := range result.Files {
-		owners, pattern := agg.codeowners.GetOwnersWithPattern(fileAnalysis.Path)
+		// Match on the resolved path, since CODEOWNERS scopes are located on disk
+		owners, pattern := agg.codeowners.GetOwnersWithPattern(result.ResolvePath(fileAnalysis.Path))
 
 		// Track file ownership
 		fileOwnershipMap[fileAnalysis.Path] = owners
@@ -72,39 +73,81 @@ func (agg *Aggregator) AggregateByOwner(result *models.AnalysisResult) (map[stri
 		metrics.FileCount = len(ownerFiles[owner])
 		metrics.FunctionCount = len(functions)
 		metrics.TotalLines = ownerLines[owner]
+		applyFunctionMetrics(metrics, functions)
 
-		if len(functions) > 0 {
-			// Calculate averages
-			var sumComplexity, sumCognitive, sumMaintainability float64
-			hotspotCount := 0
-			highComplexityCount := 0
+		// Calculate health score (similar to overall score)
+		metrics.OverallHealthScore = calculateOwnerHealthScore(metrics)
+	}
 
-			for _, fn := range functions {
-				sumComplexity += float64(fn.CyclomaticComplexity)
-				sumCognitive += float64(fn.CognitiveComplexity)
-				sumMaintainability += fn.MaintainabilityIndex
+	return ownerMetrics, fileOwnershipMap
+}
 
-				if fn.IsHotspot {
-					hotspotCount++
-				}
+// applyFunctionMetrics sets the function averages and counts of metrics from functions
+func applyFunctionMetrics(metrics *OwnerMetrics, functions []models.FunctionAnalysis) {
+	if len(functions) == 0 {
+		return
+	}
 
-				if fn.CyclomaticComplexity > 10 {
-					highComplexityCount++
-				}
-			}
+	// Calculate averages
+	var sumComplexity, sumCognitive, sumMaintainability float64
+	hotspotCount := 0
+	highComplexityCount := 0
+
+	for _, fn := range functions {
+		sumComplexity += float64(fn.CyclomaticComplexity)
+		sumCognitive += float64(fn.CognitiveComplexity)
+		sumMaintainability += fn.MaintainabilityIndex
 
-			metrics.AvgCyclomaticComplexity = sumComplexity / float64(len(functions))
-			metrics.AvgCognitiveComplexity = sumCognitive / float64(len(functions))
-			metrics.AvgMaintainabilityIndex = sumMaintainability / float64(len(functions))
-			metrics.HotspotCount = hotspotCount
-			metrics.HighComplexityFunctionCount = highComplexityCount
+		if fn.IsHotspot {
+			hotspotCount++
 		}
 
-		// Calculate health score (similar to overall score)
-		metrics.OverallHealthScore = calculateOwnerHealthScore(metrics)
+		if fn.CyclomaticComplexity > 10 {
+			highComplexityCount++
+		}
 	}
 
-	return ownerMetrics, fileOwnershipMap
+	metrics.AvgCyclomaticComplexity = sumComplexity / float64(len(functions))
+	metrics.AvgCognitiveComplexity = sumCognitive / float64(len(functions))
+	metrics.AvgMaintainabilityIndex = sumMaintainability / float64(len(functions))
+	metrics.HotspotCount = hotspotCount
+	metrics.HighComplexityFunctionCount = highComplexityCount
+}
+
+// collectUnownedFiles returns the files of result that fileOwnership gives no owner, least
+// healthy first, so the riskiest code without an owner leads the list
+func collectUnownedFiles(result *models.AnalysisResult, fileOwnership map[string][]string) []UnownedFile {
+	var unowned []UnownedFile
+	for _, fileAnalysis := range result.Files {
+		if len(fileOwnership[fileAnalysis.Path]) > 0 {
+			continue
+		}
+
+		metrics := &OwnerMetrics{
+			FunctionCount: len(fileAnalysis.Functions),
+			TotalLines:    fileAnalysis.CodeLines,
+		}
+		applyFunctionMetrics(metrics, fileAnalysis.Functions)
+
+		unowned = append(unowned, UnownedFile{
+			FilePath:                    fileAnalysis.Path,
+			FunctionCount:               metrics.FunctionCount,
+			TotalLines:                  metrics.TotalLines,
+			AvgCyclomaticComplexity:     metrics.AvgCyclomaticComplexity,
+			AvgMaintainabilityIndex:     metrics.AvgMaintainabilityIndex,
+			HotspotCount:                metrics.HotspotCount,
+			HighComplexityFunctionCount: metrics.HighComplexityFunctionCount,
+			HealthScore:                 calculateOwnerHealthScore(metrics),
+		})
+	}
+
+	sort.SliceStable(unowned, func(i, j int) bool {
+		if unowned[i].HealthScore != unowned[j].HealthScore {
+			return unowned[i].HealthScore < unowned[j].HealthScore
+		}
+		return unowned[i].FilePath < unowned[j].FilePath
+	})
+	return unowned
 }
 
 // calculateOwnerHealthScore computes a health score (0-100) for an owner's code
@@ -171,5 +214,6 @@ func (agg *Aggregator) GetOwnerReport(result *models.AnalysisResult, snapshotID
 		TotalOwners:      len(metrics),
 		OwnerMetrics:     metrics,
 		FileOwnershipMap: fileOwnership,
+		UnownedFiles:     collectUnownedFiles(result, fileOwnership),
 	}
 }
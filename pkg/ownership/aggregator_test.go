@@ -377,3 +377,65 @@ func TestAggregateByOwnerFileLines(t *testing.T) {
 	assert.Equal(t, 2, metrics.FileCount)
 	assert.Equal(t, 300, metrics.TotalLines)
 }
+
+func TestGetOwnerReportListsUnownedFiles(t *testing.T) {
+	codeowners := &CodeOwners{
+		Rules: []OwnershipRule{
+			{Pattern: "/api/", Owners: []string{"@api-team"}},
+		},
+	}
+	agg := NewAggregator(codeowners)
+
+	result := &models.AnalysisResult{
+		Files: []models.FileAnalysis{
+			{
+				Path:      "api/handler.go",
+				CodeLines: 40,
+				Functions: []models.FunctionAnalysis{{Name: "Handle", CyclomaticComplexity: 3, MaintainabilityIndex: 80}},
+			},
+			{
+				Path:      "scripts/simple.go",
+				CodeLines: 10,
+				Functions: []models.FunctionAnalysis{{Name: "run", CyclomaticComplexity: 1, MaintainabilityIndex: 95}},
+			},
+			{
+				Path:      "billing/invoice.go",
+				CodeLines: 300,
+				Functions: []models.FunctionAnalysis{
+					{Name: "Total", CyclomaticComplexity: 25, MaintainabilityIndex: 30, IsHotspot: true},
+					{Name: "Tax", CyclomaticComplexity: 15, MaintainabilityIndex: 40},
+				},
+			},
+		},
+	}
+
+	report := agg.GetOwnerReport(result, 1, "2024-01-01")
+
+	assert.Len(t, report.OwnerMetrics, 1)
+	assert.Len(t, report.UnownedFiles, 2)
+
+	riskiest := report.UnownedFiles[0]
+	assert.Equal(t, "billing/invoice.go", riskiest.FilePath, "least healthy first")
+	assert.Equal(t, 300, riskiest.TotalLines)
+	assert.Equal(t, 2, riskiest.FunctionCount)
+	assert.Equal(t, 20.0, riskiest.AvgCyclomaticComplexity)
+	assert.Equal(t, 35.0, riskiest.AvgMaintainabilityIndex)
+	assert.Equal(t, 1, riskiest.HotspotCount)
+	assert.Equal(t, 2, riskiest.HighComplexityFunctionCount)
+	assert.Less(t, riskiest.HealthScore, report.UnownedFiles[1].HealthScore)
+
+	assert.Equal(t, "scripts/simple.go", report.UnownedFiles[1].FilePath)
+}
+
+func TestRenderOwnerReportASCIIUnownedFiles(t *testing.T) {
+	report := &OwnerReport{
+		UnownedFiles: []UnownedFile{{FilePath: "billing/invoice.go", TotalLines: 300, FunctionCount: 2, HealthScore: 42}},
+	}
+
+	output := RenderOwnerReportASCII(report, 1)
+
+	assert.Contains(t, output, "No ownership data available")
+	assert.Contains(t, output, "Unowned Files (1, least healthy first)")
+	assert.Contains(t, output, "billing/invoice.go")
+	assert.NotContains(t, RenderOwnerReportASCII(&OwnerReport{}, 1), "Unowned Files")
+}
@@ -0,0 +1,80 @@
+package ownership
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/alexcollie/kaizen/pkg/churn"
+)
+
+// BlameSource is the CodeOwners path recorded for ownership derived from git blame
+const BlameSource = "git blame"
+
+// AttributeByGitBlame assigns each file to the author owning the most surviving lines
+// according to git blame, independent of any CODEOWNERS file. When no file paths are
+// given, every file tracked by git under rootPath is attributed.
+//
+// The result is a CodeOwners with one exact-path rule per file, so it can be passed
+// straight to NewAggregator.
+func AttributeByGitBlame(rootPath string, filePaths ...string) (*CodeOwners, error) {
+	churnAnalyzer := churn.NewGitChurnAnalyzer(rootPath)
+	if !churnAnalyzer.IsGitRepository(rootPath) {
+		return nil, fmt.Errorf("not a git repository: %s", rootPath)
+	}
+
+	if len(filePaths) == 0 {
+		trackedFiles, err := churnAnalyzer.ListTrackedFiles()
+		if err != nil {
+			return nil, err
+		}
+		filePaths = trackedFiles
+	}
+
+	codeowners := &CodeOwners{
+		Path:  BlameSource,
+		Rules: []OwnershipRule{},
+	}
+
+	for _, filePath := range filePaths {
+		lineAuthors, err := churnAnalyzer.GetLineAuthors(filePath)
+		if err != nil {
+			// Untracked or deleted files have no blame; leave them unowned
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			continue
+		}
+
+		owner := topAuthor(lineAuthors)
+		if owner == "" {
+			continue
+		}
+
+		codeowners.Rules = append(codeowners.Rules, OwnershipRule{
+			Pattern: filePath,
+			Owners:  []string{owner},
+		})
+	}
+
+	// Patterns without a slash match files of that name at any depth and the last
+	// matching rule wins, so order shorter paths first to let each file's own rule win
+	sort.SliceStable(codeowners.Rules, func(i, j int) bool {
+		return len(codeowners.Rules[i].Pattern) < len(codeowners.Rules[j].Pattern)
+	})
+
+	return codeowners, nil
+}
+
+// topAuthor returns the author with the most lines, breaking ties alphabetically
+func topAuthor(lineAuthors map[string]int) string {
+	owner := ""
+	ownerLines := 0
+
+	for author, lines := range lineAuthors {
+		if lines > ownerLines || (lines == ownerLines && author < owner) {
+			owner = author
+			ownerLines = lines
+		}
+	}
+
+	return owner
+}
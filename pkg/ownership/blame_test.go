@@ -0,0 +1,64 @@
+package ownership
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTopAuthor(t *testing.T) {
+	assert.Equal(t, "", topAuthor(map[string]int{}))
+	assert.Equal(t, "bob@example.com", topAuthor(map[string]int{"alice@example.com": 1, "bob@example.com": 3}))
+	assert.Equal(t, "alice@example.com", topAuthor(map[string]int{"alice@example.com": 2, "bob@example.com": 2}))
+}
+
+func TestAttributeByGitBlame(t *testing.T) {
+	tempDir := t.TempDir()
+
+	commitAs := func(email string, files map[string]string) {
+		for name, content := range files {
+			require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(tempDir, name)), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644))
+		}
+		for _, args := range [][]string{{"add", "."}, {"commit", "-m", "change by " + email}} {
+			command := exec.Command("git", append([]string{"-c", "user.email=" + email, "-c", "user.name=" + email}, args...)...)
+			command.Dir = tempDir
+			output, err := command.CombinedOutput()
+			require.NoError(t, err, string(output))
+		}
+	}
+
+	initCommand := exec.Command("git", "init")
+	initCommand.Dir = tempDir
+	require.NoError(t, initCommand.Run())
+
+	commitAs("alice@example.com", map[string]string{
+		"main.go":     "package main\n\nfunc main() {}\n",
+		"cmd/main.go": "package main\n",
+	})
+	commitAs("bob@example.com", map[string]string{
+		"cmd/main.go": "package main\n\nfunc run() {}\n\nfunc stop() {}\n",
+	})
+
+	codeowners, err := AttributeByGitBlame(tempDir)
+	require.NoError(t, err)
+
+	assert.Equal(t, BlameSource, codeowners.Path)
+	assert.Equal(t, []string{"alice@example.com"}, codeowners.GetOwners("main.go"))
+	assert.Equal(t, []string{"bob@example.com"}, codeowners.GetOwners("cmd/main.go"))
+
+	// Restricting to specific files only attributes those
+	codeowners, err = AttributeByGitBlame(tempDir, "main.go")
+	require.NoError(t, err)
+	require.Len(t, codeowners.Rules, 1)
+}
+
+func TestAttributeByGitBlameNotGitRepo(t *testing.T) {
+	_, err := AttributeByGitBlame(t.TempDir())
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,119 @@
+package ownership
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ErrNoCodeOwners is returned when no CODEOWNERS file exists anywhere in a tree
+var ErrNoCodeOwners = errors.New("no CODEOWNERS file found")
+
+// codeOwnersLocations are the places a CODEOWNERS file is looked for within a directory, in
+// the order GitHub checks them
+var codeOwnersLocations = []string{
+	filepath.Join(".github", "CODEOWNERS"),
+	"CODEOWNERS",
+	filepath.Join(".gitlab", "CODEOWNERS"),
+	filepath.Join(".gitea", "CODEOWNERS"),
+}
+
+// skippedDiscoveryDirs are never searched for nested CODEOWNERS files
+var skippedDiscoveryDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"vendor":       true,
+}
+
+// FindCodeOwnersFiles returns every CODEOWNERS file under rootPath. The repository's own file,
+// taken from the first conventional location that exists, comes first; files in
+// subdirectories follow in path order.
+func FindCodeOwnersFiles(rootPath string) []string {
+	var files []string
+	for _, location := range codeOwnersLocations {
+		candidate := filepath.Join(rootPath, location)
+		if _, err := os.Stat(candidate); err == nil {
+			files = append(files, candidate)
+			break
+		}
+	}
+
+	var nestedFiles []string
+	_ = filepath.WalkDir(rootPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if entry.IsDir() {
+			if path != rootPath && skippedDiscoveryDirs[entry.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.Name() != "CODEOWNERS" {
+			return nil
+		}
+		// Other root-level locations are shadowed by the repository's own file
+		if codeOwnersScope(path) == filepath.Clean(rootPath) {
+			return nil
+		}
+		nestedFiles = append(nestedFiles, path)
+		return nil
+	})
+	sort.Strings(nestedFiles)
+
+	return append(files, nestedFiles...)
+}
+
+// ParseCodeOwnersTree parses every CODEOWNERS file under rootPath. The repository's own file
+// becomes the returned CodeOwners and each file in a subdirectory is attached as a nested
+// CodeOwners that applies only within that subdirectory. Returns ErrNoCodeOwners when the
+// tree has no CODEOWNERS file at all.
+func ParseCodeOwnersTree(rootPath string) (*CodeOwners, error) {
+	files := FindCodeOwnersFiles(rootPath)
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%w under %s", ErrNoCodeOwners, rootPath)
+	}
+
+	root := &CodeOwners{Rules: []OwnershipRule{}}
+	nestedFiles := files
+	if codeOwnersScope(files[0]) == filepath.Clean(rootPath) {
+		parsed, err := ParseCodeOwners(files[0])
+		if err != nil {
+			return nil, err
+		}
+		root = parsed
+		nestedFiles = files[1:]
+	}
+
+	for _, nestedFile := range nestedFiles {
+		nested, err := ParseCodeOwners(nestedFile)
+		if err != nil {
+			return nil, err
+		}
+		root.Nested = append(root.Nested, nested)
+	}
+
+	// Deepest scopes first so the nearest CODEOWNERS is consulted before its ancestors
+	sort.SliceStable(root.Nested, func(first, second int) bool {
+		firstDepth := strings.Count(filepath.ToSlash(codeOwnersScope(root.Nested[first].Path)), "/")
+		secondDepth := strings.Count(filepath.ToSlash(codeOwnersScope(root.Nested[second].Path)), "/")
+		return firstDepth > secondDepth
+	})
+
+	return root, nil
+}
+
+// codeOwnersScope returns the directory a CODEOWNERS file governs: the directory holding it,
+// or its parent when it sits in .github/, .gitlab/, .gitea/, or docs/
+func codeOwnersScope(codeownersPath string) string {
+	scope := filepath.Dir(codeownersPath)
+	switch filepath.Base(scope) {
+	case ".github", ".gitlab", ".gitea", "docs":
+		scope = filepath.Dir(scope)
+	}
+	return scope
+}
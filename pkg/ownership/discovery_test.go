@@ -0,0 +1,110 @@
+package ownership
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path string, content string) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+}
+
+// createMonorepo lays out a repository with a root CODEOWNERS and nested ones for two services
+func createMonorepo(t *testing.T) string {
+	repository := t.TempDir()
+	writeFile(t, filepath.Join(repository, ".github", "CODEOWNERS"), "* @platform\n*.md @docs\n")
+	writeFile(t, filepath.Join(repository, "services", "payments", "CODEOWNERS"), "* @payments\n/internal/ledger/ @ledger\n")
+	writeFile(t, filepath.Join(repository, "services", "payments", "api", ".github", "CODEOWNERS"), "*.proto @api-design\n")
+	writeFile(t, filepath.Join(repository, "node_modules", "left-pad", "CODEOWNERS"), "* @someone-else\n")
+	return repository
+}
+
+func TestFindCodeOwnersFiles(t *testing.T) {
+	repository := createMonorepo(t)
+	writeFile(t, filepath.Join(repository, "CODEOWNERS"), "* @shadowed\n")
+
+	files := FindCodeOwnersFiles(repository)
+
+	assert.Equal(t, []string{
+		filepath.Join(repository, ".github", "CODEOWNERS"),
+		filepath.Join(repository, "services", "payments", "CODEOWNERS"),
+		filepath.Join(repository, "services", "payments", "api", ".github", "CODEOWNERS"),
+	}, files)
+}
+
+func TestFindCodeOwnersFilesNone(t *testing.T) {
+	assert.Empty(t, FindCodeOwnersFiles(t.TempDir()))
+
+	_, err := ParseCodeOwnersTree(t.TempDir())
+	assert.ErrorIs(t, err, ErrNoCodeOwners)
+}
+
+func TestParseCodeOwnersTreeScopesNestedFiles(t *testing.T) {
+	repository := createMonorepo(t)
+
+	codeowners, err := ParseCodeOwnersTree(repository)
+	require.NoError(t, err)
+	require.Len(t, codeowners.Nested, 2)
+
+	tests := []struct {
+		path           string
+		expectedOwners []string
+	}{
+		// Outside every nested subtree the root file applies
+		{"cmd/main.go", []string{"@platform"}},
+		{"README.md", []string{"@docs"}},
+		// The nested file overrides the root within its subtree, with paths relative to it
+		{"services/payments/charge.go", []string{"@payments"}},
+		{"services/payments/README.md", []string{"@payments"}},
+		{"services/payments/internal/ledger/entry.go", []string{"@ledger"}},
+		// A deeper nested file wins where it has a matching rule...
+		{"services/payments/api/charge.proto", []string{"@api-design"}},
+		// ...and falls back to the next nearest file where it does not
+		{"services/payments/api/handler.go", []string{"@payments"}},
+		// Anchored patterns are relative to the nested file's directory, not the repository
+		{"internal/ledger/entry.go", []string{"@platform"}},
+	}
+
+	for _, tt := range tests {
+		owners := codeowners.GetOwners(filepath.Join(repository, tt.path))
+		assert.Equal(t, tt.expectedOwners, owners, "path=%q", tt.path)
+	}
+}
+
+func TestParseCodeOwnersTreeWithoutRootFile(t *testing.T) {
+	repository := t.TempDir()
+	writeFile(t, filepath.Join(repository, "services", "search", "CODEOWNERS"), "* @search\n")
+
+	codeowners, err := ParseCodeOwnersTree(repository)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"@search"}, codeowners.GetOwners(filepath.Join(repository, "services", "search", "index.go")))
+	assert.Empty(t, codeowners.GetOwners(filepath.Join(repository, "cmd", "main.go")))
+}
+
+func TestAggregateByOwnerUsesNearestCodeOwners(t *testing.T) {
+	repository := createMonorepo(t)
+	codeowners, err := ParseCodeOwnersTree(repository)
+	require.NoError(t, err)
+
+	result := &models.AnalysisResult{
+		Files: []models.FileAnalysis{
+			{Path: filepath.Join(repository, "cmd", "main.go"), CodeLines: 10},
+			{Path: filepath.Join(repository, "services", "payments", "charge.go"), CodeLines: 20},
+		},
+	}
+
+	ownerMetrics, fileOwnership := NewAggregator(codeowners).AggregateByOwner(result)
+
+	assert.Equal(t, []string{"@platform"}, fileOwnership[result.Files[0].Path])
+	assert.Equal(t, []string{"@payments"}, fileOwnership[result.Files[1].Path])
+	require.Contains(t, ownerMetrics, "@payments")
+	assert.Equal(t, 20, ownerMetrics["@payments"].TotalLines)
+	assert.Equal(t, 10, ownerMetrics["@platform"].TotalLines)
+}
@@ -5,12 +5,14 @@ type OwnershipRule struct {
 	Pattern    string   `json:"pattern"`
 	Owners     []string `json:"owners"`
 	LineNumber int      `json:"line_number"`
+	Section    string   `json:"section,omitempty"` // GitLab section heading; empty for GitHub files
 }
 
 // CodeOwners represents the parsed CODEOWNERS file
 type CodeOwners struct {
-	Rules []OwnershipRule `json:"rules"`
-	Path  string          `json:"path"`
+	Rules  []OwnershipRule `json:"rules"`
+	Path   string          `json:"path"`
+	Nested []*CodeOwners   `json:"nested,omitempty"` // CODEOWNERS files in subdirectories, deepest first; each applies only within its own directory
 }
 
 // FileOwnership maps a file to its owners
@@ -34,6 +36,19 @@ type OwnerMetrics struct {
 	OverallHealthScore         float64 `json:"overall_health_score"`
 }
 
+// UnownedFile is a file matched by no CODEOWNERS pattern, with the metrics used to decide
+// which unowned code most needs an owner
+type UnownedFile struct {
+	FilePath                    string  `json:"file_path"`
+	FunctionCount               int     `json:"function_count"`
+	TotalLines                  int     `json:"total_lines"`
+	AvgCyclomaticComplexity     float64 `json:"avg_cyclomatic_complexity"`
+	AvgMaintainabilityIndex     float64 `json:"avg_maintainability_index"`
+	HotspotCount                int     `json:"hotspot_count"`
+	HighComplexityFunctionCount int     `json:"high_complexity_function_count"`
+	HealthScore                 float64 `json:"health_score"` // Scored like an owner's overall health
+}
+
 // OwnerReport represents ownership report for a snapshot
 type OwnerReport struct {
 	SnapshotID      int64           `json:"snapshot_id"`
@@ -41,4 +56,5 @@ type OwnerReport struct {
 	TotalOwners     int             `json:"total_owners"`
 	OwnerMetrics    []OwnerMetrics  `json:"owner_metrics"`
 	FileOwnershipMap map[string][]string `json:"file_ownership_map,omitempty"`
+	UnownedFiles    []UnownedFile   `json:"unowned_files,omitempty"` // Least healthy first
 }
@@ -4,10 +4,14 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"path"
+	"path/filepath"
 	"strings"
 )
 
-// ParseCodeOwners parses a CODEOWNERS file
+// ParseCodeOwners parses a CODEOWNERS file. Both GitHub syntax and GitLab syntax are
+// accepted: GitLab section headings ("[Section]", "^[Optional Section][2] @default-owner")
+// start a new section whose default owners apply to entries listing no owners of their own.
 func ParseCodeOwners(path string) (*CodeOwners, error) {
 	file, err := os.Open(path)
 	if err != nil {
@@ -22,6 +26,8 @@ func ParseCodeOwners(path string) (*CodeOwners, error) {
 
 	scanner := bufio.NewScanner(file)
 	lineNumber := 0
+	section := ""
+	var sectionOwners []string
 
 	for scanner.Scan() {
 		lineNumber++
@@ -33,6 +39,18 @@ func ParseCodeOwners(path string) (*CodeOwners, error) {
 			continue
 		}
 
+		// GitLab section heading
+		if name, owners, isSection := parseSectionHeading(line); isSection {
+			section = name
+			sectionOwners = owners
+			continue
+		}
+
+		// Entries without owners inherit the section's default owners
+		if len(strings.Fields(line)) == 1 && len(sectionOwners) > 0 {
+			line = line + " " + strings.Join(sectionOwners, " ")
+		}
+
 		// Parse rule
 		rule, err := parseRule(line, lineNumber)
 		if err != nil {
@@ -40,6 +58,7 @@ func ParseCodeOwners(path string) (*CodeOwners, error) {
 			fmt.Fprintf(os.Stderr, "Warning: failed to parse CODEOWNERS line %d: %v\n", lineNumber, err)
 			continue
 		}
+		rule.Section = section
 
 		codeowners.Rules = append(codeowners.Rules, rule)
 	}
@@ -51,6 +70,33 @@ func ParseCodeOwners(path string) (*CodeOwners, error) {
 	return codeowners, nil
 }
 
+// parseSectionHeading parses a GitLab section heading such as "^[Docs][2] @docs-team",
+// returning the section name and its default owners
+func parseSectionHeading(line string) (string, []string, bool) {
+	heading := strings.TrimPrefix(line, "^")
+	if !strings.HasPrefix(heading, "[") {
+		return "", nil, false
+	}
+
+	closing := strings.Index(heading, "]")
+	if closing < 0 {
+		return "", nil, false
+	}
+	name := heading[1:closing]
+	remainder := heading[closing+1:]
+
+	// Optional required-approvals count, e.g. [Section][2]
+	if strings.HasPrefix(remainder, "[") {
+		countEnd := strings.Index(remainder, "]")
+		if countEnd < 0 {
+			return "", nil, false
+		}
+		remainder = remainder[countEnd+1:]
+	}
+
+	return name, strings.Fields(remainder), true
+}
+
 // parseRule parses a single CODEOWNERS rule line
 func parseRule(line string, lineNumber int) (OwnershipRule, error) {
 	parts := strings.Fields(line)
@@ -83,120 +129,180 @@ func parseRule(line string, lineNumber int) (OwnershipRule, error) {
 // GetOwners returns the owners for a given file path
 // Last matching rule wins (GitHub semantics)
 func (co *CodeOwners) GetOwners(filePath string) []string {
-	var lastMatch []string
+	owners, _ := co.GetOwnersWithPattern(filePath)
+	return owners
+}
 
-	for _, rule := range co.Rules {
-		if matchesPattern(filePath, rule.Pattern) {
-			lastMatch = rule.Owners
+// GetOwnersWithPattern returns owners and the matching pattern. Within a section the last
+// matching rule wins, as on GitHub; GitLab files with several sections combine the owners
+// of each section's last match, and the pattern reported is the last match overall.
+//
+// Nested CODEOWNERS files are consulted first, nearest first; a file inside a nested file's
+// directory takes its owners from the nearest file with a matching rule.
+func (co *CodeOwners) GetOwnersWithPattern(filePath string) ([]string, string) {
+	for _, nested := range co.Nested {
+		relativePath, within := nested.relativeToScope(filePath)
+		if !within {
+			continue
+		}
+		if owners, pattern := nested.matchRules(relativePath); len(owners) > 0 {
+			return owners, pattern
 		}
 	}
 
-	return lastMatch
+	return co.matchRules(co.repositoryRelativePath(filePath))
 }
 
-// GetOwnersWithPattern returns owners and the matching pattern
-func (co *CodeOwners) GetOwnersWithPattern(filePath string) ([]string, string) {
-	var lastMatch []string
+// matchRules returns the owners and pattern of the rules matching a path relative to the
+// CODEOWNERS scope
+func (co *CodeOwners) matchRules(relativePath string) ([]string, string) {
+
+	var sections []string
+	lastMatchBySection := make(map[string]OwnershipRule)
 	var lastPattern string
 
 	for _, rule := range co.Rules {
-		if matchesPattern(filePath, rule.Pattern) {
-			lastMatch = rule.Owners
-			lastPattern = rule.Pattern
+		if !matchesPattern(relativePath, rule.Pattern) {
+			continue
 		}
+		if _, seen := lastMatchBySection[rule.Section]; !seen {
+			sections = append(sections, rule.Section)
+		}
+		lastMatchBySection[rule.Section] = rule
+		lastPattern = rule.Pattern
 	}
 
-	return lastMatch, lastPattern
-}
+	if len(sections) == 1 {
+		return lastMatchBySection[sections[0]].Owners, lastPattern
+	}
 
-// matchesPattern checks if a file path matches a CODEOWNERS pattern
-func matchesPattern(filePath, pattern string) bool {
-	// Normalize paths
-	filePath = strings.TrimPrefix(filePath, "./")
-	pattern = strings.TrimPrefix(pattern, "./")
+	var owners []string
+	seenOwners := make(map[string]bool)
+	for _, section := range sections {
+		for _, owner := range lastMatchBySection[section].Owners {
+			if !seenOwners[owner] {
+				seenOwners[owner] = true
+				owners = append(owners, owner)
+			}
+		}
+	}
 
-	// Exact match
-	if filePath == pattern {
-		return true
+	return owners, lastPattern
+}
+
+// repositoryRelativePath converts a file path to a path relative to the repository holding the
+// CODEOWNERS file, which may live at the root or in .github/, .gitlab/, .gitea/, or docs/.
+// Paths outside the repository, and paths checked against rules not read from a file, are
+// returned unchanged.
+func (co *CodeOwners) repositoryRelativePath(filePath string) string {
+	if co.Path == "" || co.Path == BlameSource {
+		return filePath
 	}
 
-	// Directory match (pattern ends with /)
-	if strings.HasSuffix(pattern, "/") {
-		dir := strings.TrimSuffix(pattern, "/")
-		return strings.HasPrefix(filePath, dir+"/")
+	relativePath, within := co.relativeToScope(filePath)
+	if !within {
+		return filePath
 	}
+	return relativePath
+}
 
-	// Wildcard patterns
-	if strings.Contains(pattern, "*") {
-		return globMatch(filePath, pattern)
+// relativeToScope converts a file path to a path relative to the directory the CODEOWNERS file
+// governs, reporting whether the file lies within that directory at all
+func (co *CodeOwners) relativeToScope(filePath string) (string, bool) {
+	absoluteScope, err := filepath.Abs(codeOwnersScope(co.Path))
+	if err != nil {
+		return "", false
+	}
+	absolutePath, err := filepath.Abs(filePath)
+	if err != nil {
+		return "", false
 	}
 
-	// Suffix match (pattern doesn't start with /)
-	if !strings.HasPrefix(pattern, "/") && strings.HasSuffix(filePath, pattern) {
-		return true
+	relativePath, err := filepath.Rel(absoluteScope, absolutePath)
+	relativePath = filepath.ToSlash(relativePath)
+	if err != nil || relativePath == ".." || strings.HasPrefix(relativePath, "../") {
+		return "", false
 	}
+	return relativePath, true
+}
 
-	// Prefix match (pattern is directory-like)
-	if strings.HasPrefix(filePath, pattern+"/") {
+// matchesPattern checks if a file path matches a CODEOWNERS pattern, following the gitignore
+// rules GitHub uses:
+//   - a pattern containing a slash (other than a trailing one) is anchored to the repository
+//     root; otherwise it matches at any depth
+//   - a trailing slash matches directories only, and a directory match covers everything in it
+//   - "*" and "?" match within one path segment and "**" matches any number of segments
+//   - a pattern whose last segment is a wildcard, such as "docs/*", matches direct children only
+func matchesPattern(filePath, pattern string) bool {
+	// Normalize paths
+	filePath = strings.TrimPrefix(filepath.ToSlash(filePath), "./")
+	pattern = strings.TrimPrefix(pattern, "./")
+
+	// Exact match
+	if filePath == pattern {
 		return true
 	}
 
-	return false
-}
+	directoryOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	filePath = strings.TrimPrefix(filePath, "/")
+	if pattern == "" || filePath == "" {
+		return false
+	}
 
-// globMatch implements basic glob pattern matching
-func globMatch(path, pattern string) bool {
-	// Handle ** (matches any number of directories)
-	if strings.Contains(pattern, "**") {
-		parts := strings.Split(pattern, "**")
-		if len(parts) != 2 {
-			return false
-		}
+	patternSegments := strings.Split(pattern, "/")
+	pathSegments := strings.Split(filePath, "/")
 
-		prefix := strings.TrimSuffix(parts[0], "/")
-		suffix := strings.TrimPrefix(parts[1], "/")
+	lastSegment := patternSegments[len(patternSegments)-1]
+	matchesDescendants := lastSegment == "**" || !strings.ContainsAny(lastSegment, "*?[")
 
-		if prefix != "" && !strings.HasPrefix(path, prefix) {
-			return false
+	for start := 0; start < len(pathSegments); start++ {
+		if anchored && start > 0 {
+			break
 		}
-
-		if suffix != "" && !strings.HasSuffix(path, suffix) {
-			return false
+		for _, matchedCount := range matchSegments(patternSegments, pathSegments[start:]) {
+			remaining := len(pathSegments) - start - matchedCount
+			if remaining == 0 && !directoryOnly {
+				return true
+			}
+			if remaining > 0 && matchesDescendants {
+				return true
+			}
 		}
-
-		return true
 	}
 
-	// Handle single * (matches within a directory)
-	if strings.Contains(pattern, "*") {
-		// Simple * matching
-		parts := strings.Split(pattern, "*")
-		if len(parts) != 2 {
-			return false
-		}
-
-		prefix := parts[0]
-		suffix := parts[1]
+	return false
+}
 
-		if !strings.HasPrefix(path, prefix) {
-			return false
-		}
+// matchSegments returns every count of leading path segments that the pattern segments match
+func matchSegments(patternSegments, pathSegments []string) []int {
+	if len(patternSegments) == 0 {
+		return []int{0}
+	}
 
-		if !strings.HasSuffix(path, suffix) {
-			return false
-		}
+	var counts []int
+	head := patternSegments[0]
 
-		// Make sure * doesn't match across directory boundaries
-		if suffix != "" && strings.Contains(suffix, "/") {
-			// The part after * must come after one directory level
-			remainder := strings.TrimPrefix(path, prefix)
-			if strings.Count(remainder[:len(remainder)-len(suffix)], "/") > 0 {
-				return false
+	if head == "**" {
+		// "**" consumes zero or more segments
+		for consumed := 0; consumed <= len(pathSegments); consumed++ {
+			for _, count := range matchSegments(patternSegments[1:], pathSegments[consumed:]) {
+				counts = append(counts, consumed+count)
 			}
 		}
-
-		return true
+		return counts
 	}
 
-	return false
+	if len(pathSegments) == 0 {
+		return nil
+	}
+	if matched, err := path.Match(head, pathSegments[0]); err != nil || !matched {
+		return nil
+	}
+	for _, count := range matchSegments(patternSegments[1:], pathSegments[1:]) {
+		counts = append(counts, 1+count)
+	}
+	return counts
 }
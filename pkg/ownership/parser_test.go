@@ -266,3 +266,128 @@ pkg/storage/sqlite.go @db-expert
 	assert.NotEmpty(t, owners)
 	assert.Contains(t, owners, "@db-expert")
 }
+
+func writeCodeOwners(t *testing.T, dir string, content string) *CodeOwners {
+	codeownersPath := filepath.Join(dir, "CODEOWNERS")
+	require.NoError(t, os.MkdirAll(dir, 0755))
+	require.NoError(t, os.WriteFile(codeownersPath, []byte(content), 0644))
+
+	codeowners, err := ParseCodeOwners(codeownersPath)
+	require.NoError(t, err)
+	return codeowners
+}
+
+// TestCodeOwnersOverlappingPatterns checks GitHub precedence: the last matching line wins,
+// even when an earlier line is more specific
+func TestCodeOwnersOverlappingPatterns(t *testing.T) {
+	codeowners := writeCodeOwners(t, t.TempDir(), `# Default owners
+*       @global
+
+*.js    @js-team
+/build/logs/ @ops
+docs/*  @docs-team
+apps/   @apps-team
+**/logs @log-team
+
+# Later, broader rule overrides the earlier, more specific one
+pkg/storage/sqlite.go @db-expert
+pkg/ @platform
+`)
+
+	tests := []struct {
+		path           string
+		expectedOwners []string
+	}{
+		{"README.md", []string{"@global"}},
+		{"web/app.js", []string{"@js-team"}},
+		{"docs/getting-started.md", []string{"@docs-team"}},
+		{"docs/build-app/troubleshooting.md", []string{"@global"}},
+		{"apps/api/main.go", []string{"@apps-team"}},
+		{"services/apps/worker.go", []string{"@apps-team"}},
+		{"build/logs/out.txt", []string{"@log-team"}},
+		{"deploy/logs/app/error.txt", []string{"@log-team"}},
+		{"pkg/storage/sqlite.go", []string{"@platform"}},
+		{"src/pkg/util.go", []string{"@platform"}},
+		{"internal/storage/sqlite.go", []string{"@global"}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expectedOwners, codeowners.GetOwners(tt.path), "path=%q", tt.path)
+	}
+}
+
+func TestMatchesPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		matches bool
+	}{
+		{"*", "a/b/c.go", true},
+		{"*.go", "a/b/c.go", true},
+		{"*.go", "a/b/c.gox", false},
+		{"main.go", "cmd/main.go", true},
+		{"main.go", "cmd/domain.go", false},
+		{"/main.go", "cmd/main.go", false},
+		{"/docs/", "docs/guide/intro.md", true},
+		{"/docs/", "src/docs/intro.md", false},
+		{"docs/", "src/docs/intro.md", true},
+		{"docs/", "docs", false},
+		{"pkg/api", "pkg/api/handler.go", true},
+		{"pkg/api", "internal/pkg/api/handler.go", false},
+		{"src/**/*.js", "src/a/b/c.js", true},
+		{"src/**/*.js", "src/c.js", true},
+		{"src/**/*.js", "lib/src/c.js", false},
+		{"docs/**", "docs/a/b.md", true},
+		{"lib/?.go", "lib/a.go", true},
+		{"lib/?.go", "lib/ab.go", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.matches, matchesPattern(tt.path, tt.pattern), "pattern=%q path=%q", tt.pattern, tt.path)
+	}
+}
+
+func TestCodeOwnersMatchesPathsRelativeToRepository(t *testing.T) {
+	repository := t.TempDir()
+	codeowners := writeCodeOwners(t, filepath.Join(repository, ".github"), `* @global
+/pkg/api/ @api-team
+`)
+
+	assert.Equal(t, []string{"@api-team"}, codeowners.GetOwners(filepath.Join(repository, "pkg", "api", "handler.go")))
+	assert.Equal(t, []string{"@global"}, codeowners.GetOwners(filepath.Join(repository, "vendor", "pkg", "api", "x.go")))
+}
+
+func TestParseCodeOwnersGitLabSections(t *testing.T) {
+	codeowners := writeCodeOwners(t, t.TempDir(), `* @global
+
+[Documentation] @docs-team
+*.md
+docs/internal/ @internal-writers
+
+^[Backend][2] @backend-team
+*.go
+*.md @backend-writers
+`)
+
+	require.Len(t, codeowners.Rules, 5)
+	assert.Equal(t, "", codeowners.Rules[0].Section)
+	assert.Equal(t, "Documentation", codeowners.Rules[1].Section)
+	assert.Equal(t, []string{"@docs-team"}, codeowners.Rules[1].Owners)
+	assert.Equal(t, "Backend", codeowners.Rules[3].Section)
+
+	// Owners of each section's last match are combined
+	assert.Equal(t, []string{"@global", "@docs-team", "@backend-writers"}, codeowners.GetOwners("README.md"))
+	assert.Equal(t, []string{"@global", "@internal-writers", "@backend-writers"}, codeowners.GetOwners("docs/internal/notes.md"))
+	assert.Equal(t, []string{"@global", "@backend-team"}, codeowners.GetOwners("main.go"))
+	assert.Equal(t, []string{"@global"}, codeowners.GetOwners("Makefile"))
+}
+
+func TestParseSectionHeading(t *testing.T) {
+	name, owners, isSection := parseSectionHeading("^[Optional Docs][2] @docs @writers")
+	assert.True(t, isSection)
+	assert.Equal(t, "Optional Docs", name)
+	assert.Equal(t, []string{"@docs", "@writers"}, owners)
+
+	_, _, isSection = parseSectionHeading("*.md @docs")
+	assert.False(t, isSection)
+}
@@ -7,8 +7,9 @@ import (
 	"time"
 )
 
-// RenderOwnerReportASCII renders ownership report as ASCII table
-func RenderOwnerReportASCII(report *OwnerReport) string {
+// RenderOwnerReportASCII renders ownership report as ASCII table, with health scores to
+// precision decimal places
+func RenderOwnerReportASCII(report *OwnerReport, precision int) string {
 	var output strings.Builder
 
 	output.WriteString("👥 Code Ownership Report\n")
@@ -16,6 +17,7 @@ func RenderOwnerReportASCII(report *OwnerReport) string {
 
 	if report.TotalOwners == 0 {
 		output.WriteString("No ownership data available\n")
+		writeUnownedFilesASCII(&output, report.UnownedFiles, precision)
 		return output.String()
 	}
 
@@ -37,10 +39,11 @@ func RenderOwnerReportASCII(report *OwnerReport) string {
 		}
 
 		output.WriteString(fmt.Sprintf(
-			"%-20s │ %-8d │ %-8d │ %7.1f%% │ %10.1f │ %10.1f │ %-8d\n",
+			"%-20s │ %-8d │ %-8d │ %7.*f%% │ %10.1f │ %10.1f │ %-8d\n",
 			owner,
 			metrics.FileCount,
 			metrics.FunctionCount,
+			precision,
 			metrics.OverallHealthScore,
 			metrics.AvgCyclomaticComplexity,
 			metrics.AvgMaintainabilityIndex,
@@ -62,9 +65,44 @@ func RenderOwnerReportASCII(report *OwnerReport) string {
 		))
 	}
 
+	writeUnownedFilesASCII(&output, report.UnownedFiles, precision)
+
 	return output.String()
 }
 
+// writeUnownedFilesASCII appends a table of files without an owner, when there are any
+func writeUnownedFilesASCII(output *strings.Builder, unowned []UnownedFile, precision int) {
+	if len(unowned) == 0 {
+		return
+	}
+
+	output.WriteString(fmt.Sprintf("\n🚫 Unowned Files (%d, least healthy first)\n", len(unowned)))
+	output.WriteString(fmt.Sprintf(
+		"%-40s │ %-8s │ %-8s │ %-8s │ %-10s │ %-10s │ %-8s\n",
+		"File", "Lines", "Funcs", "Health", "Avg Cmplx", "Avg Maint", "Hotspots",
+	))
+	output.WriteString("─────────────────────────────────────────┼──────────┼──────────┼──────────┼────────────┼────────────┼──────────\n")
+
+	for _, file := range unowned {
+		path := file.FilePath
+		if len(path) > 40 {
+			path = "..." + path[len(path)-37:]
+		}
+
+		output.WriteString(fmt.Sprintf(
+			"%-40s │ %-8d │ %-8d │ %7.*f%% │ %10.1f │ %10.1f │ %-8d\n",
+			path,
+			file.TotalLines,
+			file.FunctionCount,
+			precision,
+			file.HealthScore,
+			file.AvgCyclomaticComplexity,
+			file.AvgMaintainabilityIndex,
+			file.HotspotCount,
+		))
+	}
+}
+
 // RenderOwnerReportJSON renders report as JSON
 func RenderOwnerReportJSON(report *OwnerReport) (string, error) {
 	data, err := json.MarshalIndent(report, "", "  ")
@@ -74,8 +112,9 @@ func RenderOwnerReportJSON(report *OwnerReport) (string, error) {
 	return string(data), nil
 }
 
-// RenderOwnerReportHTML generates interactive HTML report
-func RenderOwnerReportHTML(report *OwnerReport) (string, error) {
+// RenderOwnerReportHTML generates interactive HTML report, with a plain white background and
+// black text when lightTheme is set and health scores to precision decimal places
+func RenderOwnerReportHTML(report *OwnerReport, lightTheme bool, precision int) (string, error) {
 	// Convert metrics to JSON
 	jsonData, err := json.Marshal(report)
 	if err != nil {
@@ -209,9 +248,79 @@ func RenderOwnerReportHTML(report *OwnerReport) (string, error) {
             color: #666;
             font-size: 12px;
         }
+
+        /* Light theme (--theme=light): plain white background and black text */
+        body.theme-light {
+            background: white;
+            color: black;
+        }
+        body.theme-light .container {
+            box-shadow: none;
+            border: 1px solid #DDD;
+        }
+        body.theme-light h1,
+        body.theme-light td,
+        body.theme-light th {
+            color: black;
+        }
+        body.theme-light .summary-card {
+            background: white;
+            color: black;
+            border: 1px solid #DDD;
+            box-shadow: none;
+        }
+        body.theme-light th,
+        body.theme-light .chart-container {
+            background: #F4F4F4;
+        }
+
+        /* Print and PDF export: light colors and nothing split across pages */
+        @media print {
+            @page {
+                margin: 1.5cm;
+            }
+            * {
+                -webkit-print-color-adjust: exact;
+                print-color-adjust: exact;
+            }
+            body {
+                background: white;
+                color: black;
+                padding: 0;
+            }
+            .container {
+                max-width: none;
+                box-shadow: none;
+                padding: 0;
+            }
+            h1, td, th {
+                color: black;
+            }
+            .summary-card {
+                background: white;
+                color: black;
+                border: 1px solid #DDD;
+                box-shadow: none;
+            }
+            th, .chart-container {
+                background: white;
+            }
+            .table-container {
+                overflow: visible;
+            }
+            tr, .summary-card, .chart-container {
+                break-inside: avoid;
+            }
+            .chart-row {
+                grid-template-columns: 1fr;
+            }
+            canvas {
+                max-width: 100%%;
+            }
+        }
     </style>
 </head>
-<body>
+<body%s>
     <div class="container">
         <h1>👥 Code Ownership Report</h1>
         <div class="subtitle">Generated at %s</div>
@@ -271,6 +380,7 @@ func RenderOwnerReportHTML(report *OwnerReport) (string, error) {
 
     <script>
         const report = %s;
+        const scorePrecision = %d;
 
         // Populate summary
         let totalFiles = 0, totalFunctions = 0, totalHealth = 0;
@@ -283,7 +393,7 @@ func RenderOwnerReportHTML(report *OwnerReport) (string, error) {
         document.getElementById('totalOwners').textContent = report.total_owners;
         document.getElementById('totalFiles').textContent = totalFiles;
         document.getElementById('totalFunctions').textContent = totalFunctions;
-        document.getElementById('avgHealth').textContent = (totalHealth / report.total_owners).toFixed(1);
+        document.getElementById('avgHealth').textContent = (totalHealth / report.total_owners).toFixed(scorePrecision);
 
         // Populate table
         const tbody = document.getElementById('ownerBody');
@@ -296,7 +406,7 @@ func RenderOwnerReportHTML(report *OwnerReport) (string, error) {
             row.innerHTML = '<td>' + m.owner + '</td>' +
                 '<td>' + m.file_count + '</td>' +
                 '<td>' + m.function_count + '</td>' +
-                '<td class="' + healthClass + '">' + m.overall_health_score.toFixed(1) + '</td>' +
+                '<td class="' + healthClass + '">' + m.overall_health_score.toFixed(scorePrecision) + '</td>' +
                 '<td>' + m.avg_cyclomatic_complexity.toFixed(1) + '</td>' +
                 '<td>' + m.avg_maintainability_index.toFixed(1) + '</td>' +
                 '<td>' + m.hotspot_count + '</td>';
@@ -350,11 +460,19 @@ func RenderOwnerReportHTML(report *OwnerReport) (string, error) {
     </script>
 </body>
 </html>
-`, report.AnalyzedAt, report.TotalOwners, time.Now().Format("2006-01-02 15:04:05"), string(jsonData))
+`, themeAttribute(lightTheme), report.AnalyzedAt, report.TotalOwners, time.Now().Format("2006-01-02 15:04:05"), string(jsonData), precision)
 
 	return html, nil
 }
 
+// themeAttribute returns the body attribute selecting the report theme
+func themeAttribute(lightTheme bool) string {
+	if lightTheme {
+		return ` class="theme-light"`
+	}
+	return ""
+}
+
 func getHealthStatus(score float64) string {
 	if score >= 80 {
 		return "✅"
@@ -0,0 +1,102 @@
+package reports
+
+import (
+	"sort"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+// Score components, as named in the score report JSON
+const (
+	ComponentComplexity      = "complexity"
+	ComponentMaintainability = "maintainability"
+	ComponentChurn           = "churn"
+	ComponentFunctionSize    = "function_size"
+	ComponentCodeStructure   = "code_structure"
+	ComponentOther           = "other"
+)
+
+// concernComponents maps each concern type to the score component it reflects
+var concernComponents = map[string]string{
+	"churn_complexity_hotspot": ComponentChurn,
+	"high_churn_long_function": ComponentChurn,
+	"god_function":             ComponentComplexity,
+	"outlier_function":         ComponentComplexity,
+	"high_wmc":                 ComponentComplexity,
+	"undocumented_complexity":  ComponentComplexity,
+	"low_maintainability":      ComponentMaintainability,
+	"low_file_maintainability": ComponentMaintainability,
+	"undocumented_code":        ComponentMaintainability,
+	"over_commented":           ComponentMaintainability,
+	"commented_out_code":       ComponentMaintainability,
+	"deep_nesting":             ComponentCodeStructure,
+	"too_many_parameters":      ComponentCodeStructure,
+	"high_fan_out":             ComponentCodeStructure,
+	"too_many_locals":          ComponentCodeStructure,
+	"long_method_chain":        ComponentCodeStructure,
+	"missing_return":           ComponentCodeStructure,
+	"unreachable_code":         ComponentCodeStructure,
+}
+
+// ComponentBreakdown is one score component with the concerns that explain it
+type ComponentBreakdown struct {
+	Component string               `json:"component"`
+	Score     models.CategoryScore `json:"score"`
+	Concerns  []models.Concern     `json:"concerns"`
+}
+
+// ConcernComponent returns the score component a concern type reflects, or ComponentOther for
+// concerns that do not map to one, such as custom rules
+func ConcernComponent(concernType string) string {
+	if component, ok := concernComponents[concernType]; ok {
+		return component
+	}
+	return ComponentOther
+}
+
+// BreakdownByComponent groups a score report's concerns under the components they reflect,
+// worst-scoring component first. Components with no weight (churn without churn data) are left
+// out, and concerns that map to no component are listed last under ComponentOther.
+func BreakdownByComponent(report *models.ScoreReport) []ComponentBreakdown {
+	scores := report.ComponentScores
+	breakdowns := []ComponentBreakdown{
+		{Component: ComponentComplexity, Score: scores.Complexity},
+		{Component: ComponentMaintainability, Score: scores.Maintainability},
+		{Component: ComponentChurn, Score: scores.Churn},
+		{Component: ComponentFunctionSize, Score: scores.FunctionSize},
+		{Component: ComponentCodeStructure, Score: scores.CodeStructure},
+	}
+
+	weighted := breakdowns[:0]
+	for _, breakdown := range breakdowns {
+		if breakdown.Score.Weight > 0 {
+			breakdown.Concerns = []models.Concern{}
+			weighted = append(weighted, breakdown)
+		}
+	}
+	breakdowns = weighted
+	sort.SliceStable(breakdowns, func(i, j int) bool {
+		return breakdowns[i].Score.Score < breakdowns[j].Score.Score
+	})
+
+	var other []models.Concern
+	for _, concern := range report.Concerns {
+		component := ConcernComponent(concern.Type)
+		placed := false
+		for index := range breakdowns {
+			if breakdowns[index].Component == component {
+				breakdowns[index].Concerns = append(breakdowns[index].Concerns, concern)
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			other = append(other, concern)
+		}
+	}
+
+	if len(other) > 0 {
+		breakdowns = append(breakdowns, ComponentBreakdown{Component: ComponentOther, Concerns: other})
+	}
+	return breakdowns
+}
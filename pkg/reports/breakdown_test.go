@@ -0,0 +1,60 @@
+package reports
+
+import (
+	"testing"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+func TestBreakdownByComponent(t *testing.T) {
+	report := &models.ScoreReport{
+		ComponentScores: models.ComponentScores{
+			Complexity:      models.CategoryScore{Score: 80, Weight: 0.30},
+			Maintainability: models.CategoryScore{Score: 45, Weight: 0.30},
+			Churn:           models.CategoryScore{Score: 100, Weight: 0},
+			FunctionSize:    models.CategoryScore{Score: 90, Weight: 0.20},
+			CodeStructure:   models.CategoryScore{Score: 60, Weight: 0.20},
+		},
+		Concerns: []models.Concern{
+			{Type: "deep_nesting"},
+			{Type: "low_maintainability"},
+			{Type: "custom_rule"},
+			{Type: "god_function"},
+			{Type: "too_many_parameters"},
+		},
+	}
+
+	breakdowns := BreakdownByComponent(report)
+
+	expectedOrder := []string{ComponentMaintainability, ComponentCodeStructure, ComponentComplexity, ComponentFunctionSize, ComponentOther}
+	if len(breakdowns) != len(expectedOrder) {
+		t.Fatalf("Expected %d components (churn has no weight), got %d", len(expectedOrder), len(breakdowns))
+	}
+	for index, component := range expectedOrder {
+		if breakdowns[index].Component != component {
+			t.Errorf("Component %d: expected %s, got %s", index, component, breakdowns[index].Component)
+		}
+	}
+
+	expectedCounts := map[string]int{
+		ComponentMaintainability: 1,
+		ComponentCodeStructure:   2,
+		ComponentComplexity:      1,
+		ComponentFunctionSize:    0,
+		ComponentOther:           1,
+	}
+	for _, breakdown := range breakdowns {
+		if len(breakdown.Concerns) != expectedCounts[breakdown.Component] {
+			t.Errorf("%s: expected %d concerns, got %d", breakdown.Component, expectedCounts[breakdown.Component], len(breakdown.Concerns))
+		}
+	}
+}
+
+func TestConcernComponent(t *testing.T) {
+	if component := ConcernComponent("churn_complexity_hotspot"); component != ComponentChurn {
+		t.Errorf("Expected hotspots to reflect churn, got %s", component)
+	}
+	if component := ConcernComponent("custom_rule"); component != ComponentOther {
+		t.Errorf("Expected custom rules under other, got %s", component)
+	}
+}
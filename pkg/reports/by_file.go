@@ -0,0 +1,86 @@
+package reports
+
+import (
+	"sort"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+// FileConcern is one concern as it affects a single place in a file
+type FileConcern struct {
+	Type         string `json:"type"`
+	Severity     string `json:"severity"`
+	Title        string `json:"title"`
+	FunctionName string `json:"function_name,omitempty"`
+	Line         int    `json:"line,omitempty"`
+	EndLine      int    `json:"end_line,omitempty"`
+}
+
+// FileConcerns lists every concern affecting one file, with a tally by severity
+type FileConcerns struct {
+	FilePath string        `json:"file_path"`
+	Critical int           `json:"critical"`
+	Warning  int           `json:"warning"`
+	Info     int           `json:"info"`
+	Concerns []FileConcern `json:"concerns"`
+}
+
+// GroupConcernsByFile inverts concerns grouped by type into concerns grouped by the file they
+// affect. Files are ordered worst first: most critical concerns, then most warnings, then most
+// info concerns. Within a file, concerns are in line order. Only listed affected items are
+// grouped, so items left out by reports.max_items_per_concern do not appear.
+func GroupConcernsByFile(concerns []models.Concern) []FileConcerns {
+	byPath := map[string]*FileConcerns{}
+	var files []*FileConcerns
+
+	for _, concern := range concerns {
+		for _, item := range concern.AffectedItems {
+			file, ok := byPath[item.FilePath]
+			if !ok {
+				file = &FileConcerns{FilePath: item.FilePath}
+				byPath[item.FilePath] = file
+				files = append(files, file)
+			}
+
+			file.Concerns = append(file.Concerns, FileConcern{
+				Type:         concern.Type,
+				Severity:     concern.Severity,
+				Title:        concern.Title,
+				FunctionName: item.FunctionName,
+				Line:         item.Line,
+				EndLine:      item.EndLine,
+			})
+			switch concern.Severity {
+			case "critical":
+				file.Critical++
+			case "warning":
+				file.Warning++
+			default:
+				file.Info++
+			}
+		}
+	}
+
+	grouped := make([]FileConcerns, 0, len(files))
+	for _, file := range files {
+		sort.SliceStable(file.Concerns, func(i, j int) bool {
+			return file.Concerns[i].Line < file.Concerns[j].Line
+		})
+		grouped = append(grouped, *file)
+	}
+
+	sort.SliceStable(grouped, func(i, j int) bool {
+		left, right := grouped[i], grouped[j]
+		if left.Critical != right.Critical {
+			return left.Critical > right.Critical
+		}
+		if left.Warning != right.Warning {
+			return left.Warning > right.Warning
+		}
+		if left.Info != right.Info {
+			return left.Info > right.Info
+		}
+		return left.FilePath < right.FilePath
+	})
+	return grouped
+}
@@ -0,0 +1,63 @@
+package reports
+
+import (
+	"testing"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+func TestGroupConcernsByFile(t *testing.T) {
+	concerns := []models.Concern{
+		{
+			Type:     "deep_nesting",
+			Severity: "warning",
+			Title:    "Deep Nesting",
+			AffectedItems: []models.AffectedItem{
+				{FilePath: "api/handler.go", FunctionName: "serve", Line: 40},
+				{FilePath: "util/strings.go", FunctionName: "pad", Line: 12},
+			},
+		},
+		{
+			Type:     "low_maintainability",
+			Severity: "critical",
+			Title:    "Low Maintainability",
+			AffectedItems: []models.AffectedItem{
+				{FilePath: "db/query.go", FunctionName: "build", Line: 8},
+			},
+		},
+		{
+			Type:     "too_many_parameters",
+			Severity: "info",
+			Title:    "Many Parameters",
+			AffectedItems: []models.AffectedItem{
+				{FilePath: "api/handler.go", FunctionName: "route", Line: 10},
+			},
+		},
+	}
+
+	files := GroupConcernsByFile(concerns)
+
+	expectedOrder := []string{"db/query.go", "api/handler.go", "util/strings.go"}
+	if len(files) != len(expectedOrder) {
+		t.Fatalf("Expected %d files, got %d", len(expectedOrder), len(files))
+	}
+	for index, path := range expectedOrder {
+		if files[index].FilePath != path {
+			t.Errorf("File %d: expected %s, got %s", index, path, files[index].FilePath)
+		}
+	}
+
+	handler := files[1]
+	if handler.Warning != 1 || handler.Info != 1 || len(handler.Concerns) != 2 {
+		t.Fatalf("Expected one warning and one info concern in handler.go, got %+v", handler)
+	}
+	if handler.Concerns[0].FunctionName != "route" || handler.Concerns[1].FunctionName != "serve" {
+		t.Errorf("Expected concerns in line order, got %+v", handler.Concerns)
+	}
+}
+
+func TestGroupConcernsByFileEmpty(t *testing.T) {
+	if files := GroupConcernsByFile(nil); len(files) != 0 {
+		t.Errorf("Expected no files, got %+v", files)
+	}
+}
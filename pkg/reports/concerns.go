@@ -2,23 +2,24 @@ package reports
 
 import (
 	"fmt"
+	"math"
 	"sort"
 
 	"github.com/alexcollie/kaizen/internal/config"
 	"github.com/alexcollie/kaizen/pkg/models"
 )
 
-const (
-	MaxConcernItems = 5 // Max affected items to show per concern
-)
-
-// DetectConcerns analyzes the result and returns a list of concerns
-func DetectConcerns(result *models.AnalysisResult, hasChurnData bool, thresholds config.ThresholdConfig) []models.Concern {
+// DetectConcerns analyzes the result and returns a list of concerns. Each concern lists at most
+// reportsConfig.MaxItemsPerConcern affected items (0 = all) and counts the rest in OmittedItems.
+func DetectConcerns(result *models.AnalysisResult, hasChurnData bool, thresholds config.ThresholdConfig, reportsConfig config.ReportsConfig) []models.Concern {
 	var concerns []models.Concern
 
+	// Trivial functions (shorter than analysis.min_function_lines) are left out of every detector
+	files := withoutTrivialFunctions(result.Files, result.MinFunctionLines)
+
 	// Collect all functions for analysis
 	var allFunctions []functionWithFile
-	for _, file := range result.Files {
+	for _, file := range files {
 		for _, function := range file.Functions {
 			allFunctions = append(allFunctions, functionWithFile{
 				filePath: file.Path,
@@ -29,14 +30,33 @@ func DetectConcerns(result *models.AnalysisResult, hasChurnData bool, thresholds
 
 	// Detect different types of concerns
 	if hasChurnData {
-		concerns = append(concerns, detectChurnComplexityHotspots(allFunctions, thresholds)...)
-		concerns = append(concerns, detectHighChurnLongFunctions(allFunctions, thresholds)...)
+		concerns = append(concerns, detectChurnComplexityHotspots(allFunctions, thresholds, result.ChurnMetric)...)
+		concerns = append(concerns, detectHighChurnLongFunctions(allFunctions, thresholds, result.ChurnMetric)...)
 	}
 
 	concerns = append(concerns, detectLowMaintainability(allFunctions, thresholds)...)
+	concerns = append(concerns, detectLowFileMaintainability(files, thresholds)...)
 	concerns = append(concerns, detectDeepNesting(allFunctions, thresholds)...)
 	concerns = append(concerns, detectTooManyParameters(allFunctions, thresholds)...)
 	concerns = append(concerns, detectGodFunctions(allFunctions, thresholds)...)
+	concerns = append(concerns, detectHighFanOut(allFunctions, thresholds)...)
+	concerns = append(concerns, detectTooManyLocals(allFunctions, thresholds)...)
+	concerns = append(concerns, detectMethodChains(allFunctions, thresholds)...)
+	concerns = append(concerns, detectControlFlowProblems(allFunctions)...)
+	concerns = append(concerns, detectOutlierFunctions(files)...)
+	concerns = append(concerns, detectHighWMC(files, thresholds)...)
+	concerns = append(concerns, detectCommentDensity(files, thresholds)...)
+	concerns = append(concerns, detectLowTestRatio(result.FolderStats, thresholds)...)
+	concerns = append(concerns, detectCommentedOutCode(files)...)
+	concerns = append(concerns, detectUndocumentedComplexity(files, thresholds)...)
+	concerns = append(concerns, detectCustomRules(files, thresholds.CustomRules, result.ResolvePath)...)
+
+	annotateOverloads(concerns, result.Files)
+
+	// Descriptions summarize every item; only the listed items are capped
+	for index := range concerns {
+		limitConcernItems(&concerns[index], reportsConfig.MaxItemsPerConcern)
+	}
 
 	// Sort concerns by severity (critical first, then warning, then info)
 	sortConcernsBySeverity(concerns)
@@ -49,27 +69,132 @@ type functionWithFile struct {
 	function models.FunctionAnalysis
 }
 
-func detectChurnComplexityHotspots(functions []functionWithFile, thresholds config.ThresholdConfig) []models.Concern {
+// annotateOverloads sets the overload key on items naming a function that shares its name with
+// others in the file, so overloads are told apart as they are in function history
+func annotateOverloads(concerns []models.Concern, files []models.FileAnalysis) {
+	type functionAt struct {
+		filePath string
+		name     string
+		line     int
+	}
+	overloads := make(map[functionAt]string)
+	for _, file := range files {
+		for index, key := range file.OverloadKeys() {
+			if key != "" {
+				function := file.Functions[index]
+				overloads[functionAt{file.Path, function.Name, function.StartLine}] = key
+			}
+		}
+	}
+	if len(overloads) == 0 {
+		return
+	}
+
+	for concernIndex := range concerns {
+		items := concerns[concernIndex].AffectedItems
+		for index := range items {
+			items[index].Overload = overloads[functionAt{items[index].FilePath, items[index].FunctionName, items[index].Line}]
+		}
+	}
+}
+
+// IsTrivialFunction reports whether a function is shorter than analysis.min_function_lines;
+// a minimum of 0 marks nothing as trivial
+func IsTrivialFunction(function models.FunctionAnalysis, minFunctionLines int) bool {
+	return minFunctionLines > 0 && function.Length < minFunctionLines
+}
+
+// withoutTrivialFunctions returns files with their trivial functions removed, leaving the
+// input untouched. Files are returned as-is when no minimum length is set.
+func withoutTrivialFunctions(files []models.FileAnalysis, minFunctionLines int) []models.FileAnalysis {
+	if minFunctionLines <= 0 {
+		return files
+	}
+
+	filtered := make([]models.FileAnalysis, len(files))
+	for index, file := range files {
+		functions := make([]models.FunctionAnalysis, 0, len(file.Functions))
+		for _, function := range file.Functions {
+			if !IsTrivialFunction(function, minFunctionLines) {
+				functions = append(functions, function)
+			}
+		}
+		file.Functions = functions
+		filtered[index] = file
+	}
+	return filtered
+}
+
+// ExceedsChurn reports whether churn is above the minimum for the given analysis.churn_metric:
+// commits (the default when empty) compares TotalCommits with minCommits, lines compares the
+// lines changed (TotalChanges) with minLinesChanged, and both accepts either.
+func ExceedsChurn(churn *models.ChurnMetric, churnMetric string, minCommits, minLinesChanged int) bool {
+	if churn == nil {
+		return false
+	}
+
+	commitsExceeded := churn.TotalCommits > minCommits
+	linesExceeded := churn.TotalChanges > minLinesChanged
+
+	switch churnMetric {
+	case config.ChurnMetricLines:
+		return linesExceeded
+	case config.ChurnMetricBoth:
+		return commitsExceeded || linesExceeded
+	default:
+		return commitsExceeded
+	}
+}
+
+// IsHotspot reports whether a function is a complexity hotspot: cyclomatic complexity above
+// thresholds.MinComplexity combined with churn above the minimum for churnMetric, both required
+// when thresholds.Combine is and (the default) and either enough when it is or. A function
+// without churn data is never a hotspot, so complexity alone does not flag it outside git.
+func IsHotspot(function models.FunctionAnalysis, thresholds config.HotspotThresholds, churnMetric string) bool {
+	if function.Churn == nil {
+		return false
+	}
+
+	isComplex := function.CyclomaticComplexity > thresholds.MinComplexity
+	isChurning := ExceedsChurn(function.Churn, churnMetric, thresholds.MinChurn, thresholds.MinChurnLines)
+	if thresholds.Combine == config.HotspotCombineOr {
+		return isComplex || isChurning
+	}
+	return isComplex && isChurning
+}
+
+// churnSortKey returns the churn count an affected item is ranked by: lines changed for the
+// lines metric, commits otherwise
+func churnSortKey(item models.AffectedItem, churnMetric string) float64 {
+	if churnMetric == config.ChurnMetricLines {
+		return item.Metrics["lines_changed"]
+	}
+	return item.Metrics["churn"]
+}
+
+// churnMetrics returns the affected-item metrics describing a function's churn
+func churnMetrics(churn *models.ChurnMetric) map[string]float64 {
+	return map[string]float64{
+		"churn":         float64(churn.TotalCommits),
+		"lines_changed": float64(churn.TotalChanges),
+	}
+}
+
+func detectChurnComplexityHotspots(functions []functionWithFile, thresholds config.ThresholdConfig, churnMetric string) []models.Concern {
 	var affectedItems []models.AffectedItem
 
 	for _, funcFile := range functions {
 		function := funcFile.function
-		if function.Churn == nil {
-			continue
-		}
-
-		churnCount := function.Churn.TotalCommits
 		complexity := function.CyclomaticComplexity
 
-		if complexity > thresholds.Hotspot.MinComplexity && churnCount > thresholds.Hotspot.MinChurn {
+		if IsHotspot(function, thresholds.Hotspot, churnMetric) {
+			metrics := churnMetrics(function.Churn)
+			metrics["complexity"] = float64(complexity)
 			affectedItems = append(affectedItems, models.AffectedItem{
 				FilePath:     funcFile.filePath,
 				FunctionName: function.Name,
 				Line:         function.StartLine,
-				Metrics: map[string]float64{
-					"complexity": float64(complexity),
-					"churn":      float64(churnCount),
-				},
+				Metrics:      metrics,
 			})
 		}
 	}
@@ -80,7 +205,7 @@ func detectChurnComplexityHotspots(functions []functionWithFile, thresholds conf
 
 	// Sort by combined score (complexity * churn)
 	sortAffectedItemsByScore(affectedItems, func(item models.AffectedItem) float64 {
-		return item.Metrics["complexity"] * item.Metrics["churn"]
+		return item.Metrics["complexity"] * churnSortKey(item, churnMetric)
 	})
 
 	return []models.Concern{{
@@ -88,35 +213,31 @@ func detectChurnComplexityHotspots(functions []functionWithFile, thresholds conf
 		Severity:      "critical",
 		Title:         "Complexity Hotspots",
 		Description:   buildHotspotDescription(affectedItems),
-		AffectedItems: limitAffectedItems(affectedItems, MaxConcernItems),
+		AffectedItems: affectedItems,
 	}}
 }
 
-func detectHighChurnLongFunctions(functions []functionWithFile, thresholds config.ThresholdConfig) []models.Concern {
+func detectHighChurnLongFunctions(functions []functionWithFile, thresholds config.ThresholdConfig, churnMetric string) []models.Concern {
 	var warningItems []models.AffectedItem
 	var criticalItems []models.AffectedItem
 
 	for _, funcFile := range functions {
 		function := funcFile.function
-		if function.Churn == nil {
-			continue
-		}
-
-		churnCount := function.Churn.TotalCommits
 		length := function.Length
 
-		if length > thresholds.FunctionLength.Warning && churnCount > thresholds.Churn.Warning {
+		if length > thresholds.FunctionLength.Warning &&
+			ExceedsChurn(function.Churn, churnMetric, thresholds.Churn.Warning, thresholds.ChurnLines.Warning) {
+			metrics := churnMetrics(function.Churn)
+			metrics["length"] = float64(length)
 			item := models.AffectedItem{
 				FilePath:     funcFile.filePath,
 				FunctionName: function.Name,
 				Line:         function.StartLine,
-				Metrics: map[string]float64{
-					"length": float64(length),
-					"churn":  float64(churnCount),
-				},
+				Metrics:      metrics,
 			}
 
-			if length > thresholds.FunctionLength.Critical && churnCount > thresholds.Churn.Critical {
+			if length > thresholds.FunctionLength.Critical &&
+				ExceedsChurn(function.Churn, churnMetric, thresholds.Churn.Critical, thresholds.ChurnLines.Critical) {
 				criticalItems = append(criticalItems, item)
 			} else {
 				warningItems = append(warningItems, item)
@@ -128,27 +249,27 @@ func detectHighChurnLongFunctions(functions []functionWithFile, thresholds confi
 
 	if len(criticalItems) > 0 {
 		sortAffectedItemsByScore(criticalItems, func(item models.AffectedItem) float64 {
-			return item.Metrics["length"] * item.Metrics["churn"]
+			return item.Metrics["length"] * churnSortKey(item, churnMetric)
 		})
 		concerns = append(concerns, models.Concern{
 			Type:          "high_churn_long_function",
 			Severity:      "critical",
 			Title:         "Large Functions with High Churn",
 			Description:   buildChurnLengthDescription(criticalItems, "critical"),
-			AffectedItems: limitAffectedItems(criticalItems, MaxConcernItems),
+			AffectedItems: criticalItems,
 		})
 	}
 
 	if len(warningItems) > 0 {
 		sortAffectedItemsByScore(warningItems, func(item models.AffectedItem) float64 {
-			return item.Metrics["length"] * item.Metrics["churn"]
+			return item.Metrics["length"] * churnSortKey(item, churnMetric)
 		})
 		concerns = append(concerns, models.Concern{
 			Type:          "high_churn_long_function",
 			Severity:      "warning",
 			Title:         "Long Functions with Moderate Churn",
 			Description:   buildChurnLengthDescription(warningItems, "warning"),
-			AffectedItems: limitAffectedItems(warningItems, MaxConcernItems),
+			AffectedItems: warningItems,
 		})
 	}
 
@@ -174,7 +295,7 @@ func detectLowMaintainability(functions []functionWithFile, thresholds config.Th
 				Metrics: map[string]float64{
 					"maintainability_index": maintainability,
 					"cyclomatic_complexity": float64(function.CyclomaticComplexity),
-					"length":               float64(function.Length),
+					"length":                float64(function.Length),
 					"halstead_volume":       function.HalsteadVolume,
 				},
 			}
@@ -198,7 +319,7 @@ func detectLowMaintainability(functions []functionWithFile, thresholds config.Th
 			Severity:      "critical",
 			Title:         "Critical Maintainability Issues",
 			Description:   buildMaintainabilityDescription(criticalItems, miThresholds.Critical),
-			AffectedItems: limitAffectedItems(criticalItems, MaxConcernItems),
+			AffectedItems: criticalItems,
 		})
 	}
 
@@ -211,13 +332,103 @@ func detectLowMaintainability(functions []functionWithFile, thresholds config.Th
 			Severity:      "warning",
 			Title:         "Low Maintainability",
 			Description:   buildMaintainabilityDescription(warningItems, miThresholds.Warning),
-			AffectedItems: limitAffectedItems(warningItems, MaxConcernItems),
+			AffectedItems: warningItems,
+		})
+	}
+
+	return concerns
+}
+
+// detectLowFileMaintainability flags files whose functions are low on maintainability taken
+// together, which catches files of mediocre functions that each stay above the per-function
+// thresholds. Files with fewer than min_functions functions are left to the per-function concern.
+func detectLowFileMaintainability(files []models.FileAnalysis, thresholds config.ThresholdConfig) []models.Concern {
+	var warningItems []models.AffectedItem
+	var criticalItems []models.AffectedItem
+
+	fileThresholds := thresholds.FileMaintainability
+
+	for _, file := range files {
+		if len(file.Functions) == 0 || len(file.Functions) < fileThresholds.MinFunctions {
+			continue
+		}
+
+		maintainability, totalLength := fileMaintainability(file)
+		if maintainability >= float64(fileThresholds.Warning) {
+			continue
+		}
+
+		flaggedFunctions := 0
+		for _, function := range file.Functions {
+			if function.MaintainabilityIndex < float64(thresholds.MaintainabilityIndex.Warning) {
+				flaggedFunctions++
+			}
+		}
+
+		item := models.AffectedItem{
+			FilePath: file.Path,
+			Metrics: map[string]float64{
+				"maintainability_index": maintainability,
+				"function_count":        float64(len(file.Functions)),
+				"function_lines":        float64(totalLength),
+				"flagged_functions":     float64(flaggedFunctions),
+			},
+		}
+
+		if maintainability < float64(fileThresholds.Critical) {
+			criticalItems = append(criticalItems, item)
+		} else {
+			warningItems = append(warningItems, item)
+		}
+	}
+
+	var concerns []models.Concern
+
+	if len(criticalItems) > 0 {
+		sortAffectedItemsByScore(criticalItems, func(item models.AffectedItem) float64 {
+			return 100 - item.Metrics["maintainability_index"]
+		})
+		concerns = append(concerns, models.Concern{
+			Type:          "low_file_maintainability",
+			Severity:      "critical",
+			Title:         "Critical File Maintainability",
+			Description:   buildFileMaintainabilityDescription(criticalItems, fileThresholds.Critical, thresholds.MaintainabilityIndex.Warning),
+			AffectedItems: criticalItems,
+		})
+	}
+
+	if len(warningItems) > 0 {
+		sortAffectedItemsByScore(warningItems, func(item models.AffectedItem) float64 {
+			return 100 - item.Metrics["maintainability_index"]
+		})
+		concerns = append(concerns, models.Concern{
+			Type:          "low_file_maintainability",
+			Severity:      "warning",
+			Title:         "Low File Maintainability",
+			Description:   buildFileMaintainabilityDescription(warningItems, fileThresholds.Warning, thresholds.MaintainabilityIndex.Warning),
+			AffectedItems: warningItems,
 		})
 	}
 
 	return concerns
 }
 
+// fileMaintainability returns a file's maintainability index, the mean of its functions'
+// weighted by their length so long functions count for more, and the functions' total length
+func fileMaintainability(file models.FileAnalysis) (maintainability float64, totalLength int) {
+	var weightedSum float64
+	for _, function := range file.Functions {
+		// A one-line function still has some weight
+		length := max(function.Length, 1)
+		weightedSum += function.MaintainabilityIndex * float64(length)
+		totalLength += length
+	}
+	if totalLength == 0 {
+		return 0, 0
+	}
+	return weightedSum / float64(totalLength), totalLength
+}
+
 // buildMaintainabilityDescription analyzes the contributing factors and explains why scores are low
 func buildMaintainabilityDescription(items []models.AffectedItem, threshold int) string {
 	if len(items) == 0 {
@@ -320,7 +531,7 @@ func detectDeepNesting(functions []functionWithFile, thresholds config.Threshold
 			Severity:      "warning",
 			Title:         "Very Deep Nesting",
 			Description:   buildNestingDescription(warningItems, "warning"),
-			AffectedItems: limitAffectedItems(warningItems, MaxConcernItems),
+			AffectedItems: warningItems,
 		})
 	}
 
@@ -333,7 +544,7 @@ func detectDeepNesting(functions []functionWithFile, thresholds config.Threshold
 			Severity:      "info",
 			Title:         "Deep Nesting",
 			Description:   buildNestingDescription(infoItems, "info"),
-			AffectedItems: limitAffectedItems(infoItems, MaxConcernItems),
+			AffectedItems: infoItems,
 		})
 	}
 
@@ -379,7 +590,7 @@ func detectTooManyParameters(functions []functionWithFile, thresholds config.Thr
 			Severity:      "warning",
 			Title:         "Too Many Parameters",
 			Description:   buildParameterDescription(warningItems, "warning"),
-			AffectedItems: limitAffectedItems(warningItems, MaxConcernItems),
+			AffectedItems: warningItems,
 		})
 	}
 
@@ -392,7 +603,7 @@ func detectTooManyParameters(functions []functionWithFile, thresholds config.Thr
 			Severity:      "info",
 			Title:         "Many Parameters",
 			Description:   buildParameterDescription(infoItems, "info"),
-			AffectedItems: limitAffectedItems(infoItems, MaxConcernItems),
+			AffectedItems: infoItems,
 		})
 	}
 
@@ -435,131 +646,943 @@ func detectGodFunctions(functions []functionWithFile, thresholds config.Threshol
 		Severity:      "warning",
 		Title:         "God Functions",
 		Description:   buildGodFunctionDescription(affectedItems),
-		AffectedItems: limitAffectedItems(affectedItems, MaxConcernItems),
+		AffectedItems: affectedItems,
 	}}
 }
 
-func sortAffectedItemsByScore(items []models.AffectedItem, scoreFunc func(models.AffectedItem) float64) {
-	sort.Slice(items, func(i, j int) bool {
-		return scoreFunc(items[i]) > scoreFunc(items[j])
-	})
-}
+// detectHighFanOut flags functions that make many calls to other functions. Where god functions
+// are depended on by too much, these depend on too much: orchestration that knows every step.
+func detectHighFanOut(functions []functionWithFile, thresholds config.ThresholdConfig) []models.Concern {
+	var infoItems []models.AffectedItem
+	var warningItems []models.AffectedItem
 
-func limitAffectedItems(items []models.AffectedItem, maxItems int) []models.AffectedItem {
-	if len(items) <= maxItems {
-		return items
-	}
-	return items[:maxItems]
-}
+	fanOutThresholds := thresholds.FanOut
 
-func sortConcernsBySeverity(concerns []models.Concern) {
-	severityOrder := map[string]int{
-		"critical": 0,
-		"warning":  1,
-		"info":     2,
-	}
+	for _, funcFile := range functions {
+		function := funcFile.function
+		fanOut := function.FanOut
 
-	sort.Slice(concerns, func(i, j int) bool {
-		return severityOrder[concerns[i].Severity] < severityOrder[concerns[j].Severity]
-	})
-}
+		if fanOut > fanOutThresholds.Warning {
+			item := models.AffectedItem{
+				FilePath:     funcFile.filePath,
+				FunctionName: function.Name,
+				Line:         function.StartLine,
+				Metrics: map[string]float64{
+					"fan_out":    float64(fanOut),
+					"complexity": float64(function.CyclomaticComplexity),
+				},
+			}
 
-// buildHotspotDescription explains why functions are complexity hotspots
-func buildHotspotDescription(items []models.AffectedItem) string {
-	if len(items) == 0 {
-		return "High complexity functions that change frequently are risky to modify."
+			if fanOut > fanOutThresholds.Critical {
+				warningItems = append(warningItems, item)
+			} else {
+				infoItems = append(infoItems, item)
+			}
+		}
 	}
 
-	var totalComplexity, totalChurn float64
-	for _, item := range items {
-		totalComplexity += item.Metrics["complexity"]
-		totalChurn += item.Metrics["churn"]
+	var concerns []models.Concern
+
+	if len(warningItems) > 0 {
+		sortAffectedItemsByScore(warningItems, func(item models.AffectedItem) float64 {
+			return item.Metrics["fan_out"]
+		})
+		concerns = append(concerns, models.Concern{
+			Type:          "high_fan_out",
+			Severity:      "warning",
+			Title:         "Very High Fan-Out",
+			Description:   buildFanOutDescription(warningItems, "warning"),
+			AffectedItems: warningItems,
+		})
 	}
 
-	avgComplexity := totalComplexity / float64(len(items))
-	avgChurn := totalChurn / float64(len(items))
+	if len(infoItems) > 0 {
+		sortAffectedItemsByScore(infoItems, func(item models.AffectedItem) float64 {
+			return item.Metrics["fan_out"]
+		})
+		concerns = append(concerns, models.Concern{
+			Type:          "high_fan_out",
+			Severity:      "info",
+			Title:         "High Fan-Out",
+			Description:   buildFanOutDescription(infoItems, "info"),
+			AffectedItems: infoItems,
+		})
+	}
 
-	return fmt.Sprintf(
-		"These functions average CC:%.0f with %.0f commits each. High complexity makes changes error-prone, and frequent changes multiply that risk. Consider refactoring to reduce complexity before the next change.",
-		avgComplexity, avgChurn,
-	)
+	return concerns
 }
 
-// buildChurnLengthDescription explains why long functions with high churn are problematic
-func buildChurnLengthDescription(items []models.AffectedItem, severity string) string {
-	if len(items) == 0 {
-		return "Long functions that change frequently are hard to maintain."
-	}
-
-	var totalLength, totalChurn float64
-	for _, item := range items {
-		totalLength += item.Metrics["length"]
-		totalChurn += item.Metrics["churn"]
-	}
+// detectTooManyLocals flags functions that declare many local variables. Each one is state the
+// reader has to track, and a long list usually means several computations share one function.
+func detectTooManyLocals(functions []functionWithFile, thresholds config.ThresholdConfig) []models.Concern {
+	var infoItems []models.AffectedItem
+	var warningItems []models.AffectedItem
 
-	avgLength := totalLength / float64(len(items))
-	avgChurn := totalChurn / float64(len(items))
+	localThresholds := thresholds.LocalVariables
 
-	if severity == "critical" {
-		return fmt.Sprintf(
-			"Averaging %.0f lines and %.0f commits. Large functions are hard to understand and test. Each change risks unintended side effects. Split into smaller, single-purpose functions.",
-			avgLength, avgChurn,
-		)
-	}
+	for _, funcFile := range functions {
+		function := funcFile.function
+		locals := function.LocalVariableCount
 
-	return fmt.Sprintf(
-		"These functions average %.0f lines with %.0f changes. Consider extracting logical sections into separate functions to improve readability and reduce change risk.",
-		avgLength, avgChurn,
-	)
-}
+		if locals > localThresholds.Warning {
+			item := models.AffectedItem{
+				FilePath:     funcFile.filePath,
+				FunctionName: function.Name,
+				Line:         function.StartLine,
+				Metrics: map[string]float64{
+					"local_variable_count": float64(locals),
+					"length":               float64(function.Length),
+				},
+			}
 
-// buildParameterDescription explains why too many parameters is a concern
-func buildParameterDescription(items []models.AffectedItem, severity string) string {
-	if len(items) == 0 {
-		return "Functions with many parameters are harder to call correctly."
+			if locals > localThresholds.Critical {
+				warningItems = append(warningItems, item)
+			} else {
+				infoItems = append(infoItems, item)
+			}
+		}
 	}
 
-	var totalParams float64
-	for _, item := range items {
-		totalParams += item.Metrics["parameter_count"]
+	var concerns []models.Concern
+
+	if len(warningItems) > 0 {
+		sortAffectedItemsByScore(warningItems, func(item models.AffectedItem) float64 {
+			return item.Metrics["local_variable_count"]
+		})
+		concerns = append(concerns, models.Concern{
+			Type:          "too_many_locals",
+			Severity:      "warning",
+			Title:         "Too Many Local Variables",
+			Description:   buildLocalsDescription(warningItems, "warning"),
+			AffectedItems: warningItems,
+		})
 	}
-	avgParams := totalParams / float64(len(items))
 
-	if severity == "warning" {
-		return fmt.Sprintf(
-			"These functions average %.0f parameters. Many parameters increase cognitive load, make testing harder, and often indicate the function is doing too much. Group related parameters into structs or split the function.",
-			avgParams,
-		)
+	if len(infoItems) > 0 {
+		sortAffectedItemsByScore(infoItems, func(item models.AffectedItem) float64 {
+			return item.Metrics["local_variable_count"]
+		})
+		concerns = append(concerns, models.Concern{
+			Type:          "too_many_locals",
+			Severity:      "info",
+			Title:         "Many Local Variables",
+			Description:   buildLocalsDescription(infoItems, "info"),
+			AffectedItems: infoItems,
+		})
 	}
 
-	return fmt.Sprintf(
-		"Averaging %.0f parameters per function. Consider grouping related parameters into a config struct or options pattern to improve readability.",
-		avgParams,
-	)
+	return concerns
 }
 
-// buildGodFunctionDescription explains why god functions are problematic
-func buildGodFunctionDescription(items []models.AffectedItem) string {
-	if len(items) == 0 {
-		return "Functions with many parameters and high fan-in may be doing too much."
-	}
-
-	var totalParams, totalFanIn float64
-	for _, item := range items {
-		totalParams += item.Metrics["parameter_count"]
-		totalFanIn += item.Metrics["fan_in"]
-	}
+// detectMethodChains flags functions that chain many calls on one expression, e.g.
+// a.b().c().d().e(). Each link reaches through another object, so the caller depends on the
+// internals of every type along the way. Items point at the line where the longest chain
+// starts. Only the Go and Kotlin analyzers record chains.
+func detectMethodChains(functions []functionWithFile, thresholds config.ThresholdConfig) []models.Concern {
+	var infoItems []models.AffectedItem
+	var warningItems []models.AffectedItem
 
-	avgParams := totalParams / float64(len(items))
-	avgFanIn := totalFanIn / float64(len(items))
+	chainThresholds := thresholds.MethodChain
 
-	return fmt.Sprintf(
-		"These functions average %.0f parameters and are called from %.0f places. High fan-in with many parameters suggests these are 'god functions' trying to do everything. Break them into focused, single-responsibility functions.",
-		avgParams, avgFanIn,
-	)
-}
+	for _, funcFile := range functions {
+		function := funcFile.function
+		length := function.MethodChainLength
 
-// buildNestingDescription explains why deep nesting is problematic
+		if length > chainThresholds.Warning {
+			item := models.AffectedItem{
+				FilePath:     funcFile.filePath,
+				FunctionName: function.Name,
+				Line:         function.MethodChainLine,
+				Metrics: map[string]float64{
+					"method_chain_length": float64(length),
+					"function_line":       float64(function.StartLine),
+				},
+			}
+
+			if length > chainThresholds.Critical {
+				warningItems = append(warningItems, item)
+			} else {
+				infoItems = append(infoItems, item)
+			}
+		}
+	}
+
+	byLength := func(item models.AffectedItem) float64 {
+		return item.Metrics["method_chain_length"]
+	}
+
+	var concerns []models.Concern
+
+	if len(warningItems) > 0 {
+		sortAffectedItemsByScore(warningItems, byLength)
+		concerns = append(concerns, models.Concern{
+			Type:          "long_method_chain",
+			Severity:      "warning",
+			Title:         "Very Long Method Chains",
+			Description:   buildMethodChainDescription(warningItems, "warning"),
+			AffectedItems: warningItems,
+		})
+	}
+
+	if len(infoItems) > 0 {
+		sortAffectedItemsByScore(infoItems, byLength)
+		concerns = append(concerns, models.Concern{
+			Type:          "long_method_chain",
+			Severity:      "info",
+			Title:         "Long Method Chains",
+			Description:   buildMethodChainDescription(infoItems, "info"),
+			AffectedItems: infoItems,
+		})
+	}
+
+	return concerns
+}
+
+// detectControlFlowProblems flags functions with a path that ends without returning a declared
+// result, and functions with statements that can never run. Items point at the problem line
+// rather than the function start. Only the Go analyzer records these lines.
+func detectControlFlowProblems(functions []functionWithFile) []models.Concern {
+	var missingReturnItems []models.AffectedItem
+	var unreachableItems []models.AffectedItem
+
+	for _, funcFile := range functions {
+		function := funcFile.function
+		metrics := map[string]float64{
+			"function_line": float64(function.StartLine),
+			"complexity":    float64(function.CyclomaticComplexity),
+		}
+
+		if function.MissingReturnLine > 0 {
+			missingReturnItems = append(missingReturnItems, models.AffectedItem{
+				FilePath:     funcFile.filePath,
+				FunctionName: function.Name,
+				Line:         function.MissingReturnLine,
+				Metrics:      metrics,
+			})
+		}
+		if function.UnreachableLine > 0 {
+			unreachableItems = append(unreachableItems, models.AffectedItem{
+				FilePath:     funcFile.filePath,
+				FunctionName: function.Name,
+				Line:         function.UnreachableLine,
+				Metrics:      metrics,
+			})
+		}
+	}
+
+	byComplexity := func(item models.AffectedItem) float64 {
+		return item.Metrics["complexity"]
+	}
+
+	var concerns []models.Concern
+
+	if len(missingReturnItems) > 0 {
+		sortAffectedItemsByScore(missingReturnItems, byComplexity)
+		concerns = append(concerns, models.Concern{
+			Type:          "missing_return",
+			Severity:      "warning",
+			Title:         "Missing Return Path",
+			Description:   buildMissingReturnDescription(missingReturnItems),
+			AffectedItems: missingReturnItems,
+		})
+	}
+
+	if len(unreachableItems) > 0 {
+		sortAffectedItemsByScore(unreachableItems, byComplexity)
+		concerns = append(concerns, models.Concern{
+			Type:          "unreachable_code",
+			Severity:      "info",
+			Title:         "Unreachable Code",
+			Description:   buildUnreachableCodeDescription(unreachableItems),
+			AffectedItems: unreachableItems,
+		})
+	}
+
+	return concerns
+}
+
+const (
+	outlierStdDevs       = 2.0 // Standard deviations above the file mean that make a function an outlier
+	outlierMinFunctions  = 8   // Smaller files are too small a sample; with n functions none can sit above sqrt(n-1) std devs
+	outlierMinLength     = 20  // Shorter functions are never length outliers
+	outlierMinComplexity = 5   // Simpler functions are never complexity outliers
+)
+
+// detectOutlierFunctions flags functions whose length or cyclomatic complexity sits far above
+// the rest of their file, even when they stay under the absolute thresholds. One 300-line
+// function among twenty small ones is a refactor target the fixed limits can miss.
+func detectOutlierFunctions(files []models.FileAnalysis) []models.Concern {
+	var affectedItems []models.AffectedItem
+
+	for _, file := range files {
+		if len(file.Functions) < outlierMinFunctions {
+			continue
+		}
+
+		lengths := make([]float64, len(file.Functions))
+		complexities := make([]float64, len(file.Functions))
+		for index, function := range file.Functions {
+			lengths[index] = float64(function.Length)
+			complexities[index] = float64(function.CyclomaticComplexity)
+		}
+		lengthMean, lengthStdDev := meanAndStdDev(lengths)
+		complexityMean, complexityStdDev := meanAndStdDev(complexities)
+
+		for _, function := range file.Functions {
+			lengthDeviation := standardDeviations(float64(function.Length), lengthMean, lengthStdDev)
+			complexityDeviation := standardDeviations(float64(function.CyclomaticComplexity), complexityMean, complexityStdDev)
+
+			metrics := map[string]float64{}
+			if lengthDeviation > outlierStdDevs && function.Length >= outlierMinLength {
+				metrics["length"] = float64(function.Length)
+				metrics["length_std_devs"] = lengthDeviation
+			}
+			if complexityDeviation > outlierStdDevs && function.CyclomaticComplexity >= outlierMinComplexity {
+				metrics["complexity"] = float64(function.CyclomaticComplexity)
+				metrics["complexity_std_devs"] = complexityDeviation
+			}
+			if len(metrics) == 0 {
+				continue
+			}
+			metrics["std_devs"] = math.Max(metrics["length_std_devs"], metrics["complexity_std_devs"])
+
+			affectedItems = append(affectedItems, models.AffectedItem{
+				FilePath:     file.Path,
+				FunctionName: function.Name,
+				Line:         function.StartLine,
+				Metrics:      metrics,
+			})
+		}
+	}
+
+	if len(affectedItems) == 0 {
+		return nil
+	}
+
+	sortAffectedItemsByScore(affectedItems, func(item models.AffectedItem) float64 {
+		return item.Metrics["std_devs"]
+	})
+
+	return []models.Concern{{
+		Type:          "outlier_function",
+		Severity:      "info",
+		Title:         "Outlier Functions",
+		Description:   buildOutlierDescription(affectedItems),
+		AffectedItems: affectedItems,
+	}}
+}
+
+// meanAndStdDev returns the mean and population standard deviation of values
+func meanAndStdDev(values []float64) (mean, stdDev float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+
+	var sum float64
+	for _, value := range values {
+		sum += value
+	}
+	mean = sum / float64(len(values))
+
+	var squaredDiffs float64
+	for _, value := range values {
+		squaredDiffs += (value - mean) * (value - mean)
+	}
+	return mean, math.Sqrt(squaredDiffs / float64(len(values)))
+}
+
+// standardDeviations returns how many standard deviations value sits above mean (0 when the
+// values do not vary)
+func standardDeviations(value, mean, stdDev float64) float64 {
+	if stdDev == 0 {
+		return 0
+	}
+	return (value - mean) / stdDev
+}
+
+// detectHighWMC flags types whose weighted methods per class (the summed cyclomatic complexity
+// of their methods) is high. Affected items name the type in FunctionName.
+func detectHighWMC(files []models.FileAnalysis, thresholds config.ThresholdConfig) []models.Concern {
+	var infoItems []models.AffectedItem
+	var warningItems []models.AffectedItem
+
+	wmcThresholds := thresholds.WeightedMethods
+
+	for _, file := range files {
+		for _, typeAnalysis := range file.Types {
+			weightedMethods := typeAnalysis.WeightedMethodsPerClass
+			if weightedMethods <= wmcThresholds.Warning {
+				continue
+			}
+
+			item := models.AffectedItem{
+				FilePath:     file.Path,
+				FunctionName: typeAnalysis.Name,
+				Metrics: map[string]float64{
+					"weighted_methods": float64(weightedMethods),
+					"method_count":     float64(typeAnalysis.MethodCount),
+				},
+			}
+
+			if weightedMethods > wmcThresholds.Critical {
+				warningItems = append(warningItems, item)
+			} else {
+				infoItems = append(infoItems, item)
+			}
+		}
+	}
+
+	var concerns []models.Concern
+
+	if len(warningItems) > 0 {
+		sortAffectedItemsByScore(warningItems, func(item models.AffectedItem) float64 {
+			return item.Metrics["weighted_methods"]
+		})
+		concerns = append(concerns, models.Concern{
+			Type:          "high_wmc",
+			Severity:      "warning",
+			Title:         "Very Complex Classes",
+			Description:   buildWMCDescription(warningItems, "warning"),
+			AffectedItems: warningItems,
+		})
+	}
+
+	if len(infoItems) > 0 {
+		sortAffectedItemsByScore(infoItems, func(item models.AffectedItem) float64 {
+			return item.Metrics["weighted_methods"]
+		})
+		concerns = append(concerns, models.Concern{
+			Type:          "high_wmc",
+			Severity:      "info",
+			Title:         "Complex Classes",
+			Description:   buildWMCDescription(infoItems, "info"),
+			AffectedItems: infoItems,
+		})
+	}
+
+	return concerns
+}
+
+// docCommentLanguages lists the languages whose analyzers record FunctionAnalysis.HasDocComment
+var docCommentLanguages = map[string]bool{
+	"Go":     true,
+	"Python": true,
+	"Lua":    true,
+}
+
+func detectUndocumentedComplexity(files []models.FileAnalysis, thresholds config.ThresholdConfig) []models.Concern {
+	var infoItems []models.AffectedItem
+	var warningItems []models.AffectedItem
+
+	complexityThresholds := thresholds.Complexity
+
+	for _, file := range files {
+		if !docCommentLanguages[file.Language] {
+			continue
+		}
+
+		for _, function := range file.Functions {
+			complexity := function.CyclomaticComplexity
+			if function.HasDocComment || complexity <= complexityThresholds.Warning {
+				continue
+			}
+
+			item := models.AffectedItem{
+				FilePath:     file.Path,
+				FunctionName: function.Name,
+				Line:         function.StartLine,
+				Metrics: map[string]float64{
+					"complexity": float64(complexity),
+				},
+			}
+
+			if complexity > complexityThresholds.Critical {
+				warningItems = append(warningItems, item)
+			} else {
+				infoItems = append(infoItems, item)
+			}
+		}
+	}
+
+	var concerns []models.Concern
+
+	if len(warningItems) > 0 {
+		sortAffectedItemsByScore(warningItems, func(item models.AffectedItem) float64 {
+			return item.Metrics["complexity"]
+		})
+		concerns = append(concerns, models.Concern{
+			Type:          "undocumented_complexity",
+			Severity:      "warning",
+			Title:         "Very Complex Functions Without Documentation",
+			Description:   buildUndocumentedComplexityDescription(warningItems, "warning"),
+			AffectedItems: warningItems,
+		})
+	}
+
+	if len(infoItems) > 0 {
+		sortAffectedItemsByScore(infoItems, func(item models.AffectedItem) float64 {
+			return item.Metrics["complexity"]
+		})
+		concerns = append(concerns, models.Concern{
+			Type:          "undocumented_complexity",
+			Severity:      "info",
+			Title:         "Complex Functions Without Documentation",
+			Description:   buildUndocumentedComplexityDescription(infoItems, "info"),
+			AffectedItems: infoItems,
+		})
+	}
+
+	return concerns
+}
+
+func detectCommentDensity(files []models.FileAnalysis, thresholds config.ThresholdConfig) []models.Concern {
+	var sparseItems []models.AffectedItem
+	var heavyItems []models.AffectedItem
+
+	densityThresholds := thresholds.CommentDensity
+
+	for _, file := range files {
+		if file.CodeLines < densityThresholds.MinLines {
+			continue
+		}
+
+		item := models.AffectedItem{
+			FilePath: file.Path,
+			Metrics: map[string]float64{
+				"comment_density": file.CommentDensity,
+				"code_lines":      float64(file.CodeLines),
+			},
+		}
+
+		if file.CommentDensity < float64(densityThresholds.Min) {
+			sparseItems = append(sparseItems, item)
+		} else if densityThresholds.Max > 0 && file.CommentDensity > float64(densityThresholds.Max) {
+			heavyItems = append(heavyItems, item)
+		}
+	}
+
+	var concerns []models.Concern
+
+	if len(sparseItems) > 0 {
+		// Largest undocumented files first
+		sortAffectedItemsByScore(sparseItems, func(item models.AffectedItem) float64 {
+			return item.Metrics["code_lines"] * (float64(densityThresholds.Min) - item.Metrics["comment_density"])
+		})
+		concerns = append(concerns, models.Concern{
+			Type:          "undocumented_code",
+			Severity:      "info",
+			Title:         "Sparse Comments",
+			Description:   buildCommentDensityDescription(sparseItems, densityThresholds.Min, true),
+			AffectedItems: sparseItems,
+		})
+	}
+
+	if len(heavyItems) > 0 {
+		sortAffectedItemsByScore(heavyItems, func(item models.AffectedItem) float64 {
+			return item.Metrics["comment_density"]
+		})
+		concerns = append(concerns, models.Concern{
+			Type:          "over_commented",
+			Severity:      "info",
+			Title:         "Heavy Commenting",
+			Description:   buildCommentDensityDescription(heavyItems, densityThresholds.Max, false),
+			AffectedItems: heavyItems,
+		})
+	}
+
+	return concerns
+}
+
+// detectLowTestRatio flags folders whose test code is small next to their production code. Only
+// folders with a test ratio are checked, which the aggregator sets when tests were analyzed.
+func detectLowTestRatio(folders map[string]models.FolderMetrics, thresholds config.ThresholdConfig) []models.Concern {
+	var warningItems []models.AffectedItem
+	var infoItems []models.AffectedItem
+
+	ratioThresholds := thresholds.TestRatio
+
+	for _, folder := range folders {
+		if folder.TestRatio == nil || folder.TotalCodeLines < ratioThresholds.MinLines {
+			continue
+		}
+
+		ratio := *folder.TestRatio
+		item := models.AffectedItem{
+			FilePath: folder.Path,
+			Metrics: map[string]float64{
+				"test_ratio":      ratio,
+				"test_code_lines": float64(folder.TestCodeLines),
+				"code_lines":      float64(folder.TotalCodeLines),
+			},
+		}
+
+		if ratio < ratioThresholds.Warning {
+			warningItems = append(warningItems, item)
+		} else if ratio < ratioThresholds.Info {
+			infoItems = append(infoItems, item)
+		}
+	}
+
+	var concerns []models.Concern
+
+	// Folders with the most code and the least test code first
+	untestedScore := func(item models.AffectedItem) float64 {
+		return item.Metrics["code_lines"] * (1 - min(item.Metrics["test_ratio"], 1))
+	}
+
+	if len(warningItems) > 0 {
+		sortAffectedItemsByScore(warningItems, untestedScore)
+		concerns = append(concerns, models.Concern{
+			Type:          "low_test_ratio",
+			Severity:      "warning",
+			Title:         "Under-Tested Folders",
+			Description:   buildTestRatioDescription(warningItems, ratioThresholds.Warning),
+			AffectedItems: warningItems,
+		})
+	}
+
+	if len(infoItems) > 0 {
+		sortAffectedItemsByScore(infoItems, untestedScore)
+		concerns = append(concerns, models.Concern{
+			Type:          "low_test_ratio",
+			Severity:      "info",
+			Title:         "Low Test-to-Code Ratio",
+			Description:   buildTestRatioDescription(infoItems, ratioThresholds.Info),
+			AffectedItems: infoItems,
+		})
+	}
+
+	return concerns
+}
+
+const commentedCodeMinLines = 5 // Shorter commented-out snippets are usually deliberate examples
+
+// detectCommentedOutCode flags runs of comment lines that look like code, as recorded by the
+// language analyzers. Each affected item covers the block's line range.
+func detectCommentedOutCode(files []models.FileAnalysis) []models.Concern {
+	var affectedItems []models.AffectedItem
+
+	for _, file := range files {
+		for _, block := range file.CommentedCode {
+			blockLines := block.EndLine - block.StartLine + 1
+			if blockLines < commentedCodeMinLines {
+				continue
+			}
+
+			affectedItems = append(affectedItems, models.AffectedItem{
+				FilePath: file.Path,
+				Line:     block.StartLine,
+				EndLine:  block.EndLine,
+				Metrics: map[string]float64{
+					"lines":      float64(blockLines),
+					"code_lines": float64(block.CodeLines),
+				},
+			})
+		}
+	}
+
+	if len(affectedItems) == 0 {
+		return nil
+	}
+
+	sortAffectedItemsByScore(affectedItems, func(item models.AffectedItem) float64 {
+		return item.Metrics["lines"]
+	})
+
+	return []models.Concern{{
+		Type:          "commented_out_code",
+		Severity:      "info",
+		Title:         "Commented-Out Code",
+		Description:   buildCommentedOutCodeDescription(affectedItems),
+		AffectedItems: affectedItems,
+	}}
+}
+
+func sortAffectedItemsByScore(items []models.AffectedItem, scoreFunc func(models.AffectedItem) float64) {
+	sort.Slice(items, func(i, j int) bool {
+		return scoreFunc(items[i]) > scoreFunc(items[j])
+	})
+}
+
+// limitAffectedItems keeps the first maxItems items; a maxItems of 0 or less keeps them all
+func limitAffectedItems(items []models.AffectedItem, maxItems int) []models.AffectedItem {
+	if maxItems <= 0 || len(items) <= maxItems {
+		return items
+	}
+	return items[:maxItems]
+}
+
+// limitConcernItems caps a concern's affected items at maxItems, recording how many were left out
+func limitConcernItems(concern *models.Concern, maxItems int) {
+	limited := limitAffectedItems(concern.AffectedItems, maxItems)
+	concern.OmittedItems += len(concern.AffectedItems) - len(limited)
+	concern.AffectedItems = limited
+}
+
+// SummarizeConcerns counts concerns by severity. Each concern counts once, however many items
+// it lists. Call it again whenever concerns change after scoring, as escalation does.
+func SummarizeConcerns(concerns []models.Concern) models.ConcernSummary {
+	var summary models.ConcernSummary
+	for _, concern := range concerns {
+		switch concern.Severity {
+		case "critical":
+			summary.Critical++
+		case "warning":
+			summary.Warning++
+		case "info":
+			summary.Info++
+		}
+	}
+	return summary
+}
+
+func sortConcernsBySeverity(concerns []models.Concern) {
+	severityOrder := map[string]int{
+		"critical": 0,
+		"warning":  1,
+		"info":     2,
+	}
+
+	sort.Slice(concerns, func(i, j int) bool {
+		return severityOrder[concerns[i].Severity] < severityOrder[concerns[j].Severity]
+	})
+}
+
+// buildHotspotDescription explains why functions are complexity hotspots
+func buildHotspotDescription(items []models.AffectedItem) string {
+	if len(items) == 0 {
+		return "High complexity functions that change frequently are risky to modify."
+	}
+
+	var totalComplexity, totalChurn, totalLinesChanged float64
+	for _, item := range items {
+		totalComplexity += item.Metrics["complexity"]
+		totalChurn += item.Metrics["churn"]
+		totalLinesChanged += item.Metrics["lines_changed"]
+	}
+
+	avgComplexity := totalComplexity / float64(len(items))
+	avgChurn := totalChurn / float64(len(items))
+	avgLinesChanged := totalLinesChanged / float64(len(items))
+
+	return fmt.Sprintf(
+		"These functions average CC:%.0f with %.0f commits and %.0f lines changed each. High complexity makes changes error-prone, and frequent changes multiply that risk. Consider refactoring to reduce complexity before the next change.",
+		avgComplexity, avgChurn, avgLinesChanged,
+	)
+}
+
+// buildChurnLengthDescription explains why long functions with high churn are problematic
+func buildChurnLengthDescription(items []models.AffectedItem, severity string) string {
+	if len(items) == 0 {
+		return "Long functions that change frequently are hard to maintain."
+	}
+
+	var totalLength, totalChurn, totalLinesChanged float64
+	for _, item := range items {
+		totalLength += item.Metrics["length"]
+		totalChurn += item.Metrics["churn"]
+		totalLinesChanged += item.Metrics["lines_changed"]
+	}
+
+	avgLength := totalLength / float64(len(items))
+	avgChurn := totalChurn / float64(len(items))
+	avgLinesChanged := totalLinesChanged / float64(len(items))
+
+	if severity == "critical" {
+		return fmt.Sprintf(
+			"Averaging %.0f lines, %.0f commits, and %.0f lines changed. Large functions are hard to understand and test. Each change risks unintended side effects. Split into smaller, single-purpose functions.",
+			avgLength, avgChurn, avgLinesChanged,
+		)
+	}
+
+	return fmt.Sprintf(
+		"These functions average %.0f lines with %.0f changes touching %.0f lines. Consider extracting logical sections into separate functions to improve readability and reduce change risk.",
+		avgLength, avgChurn, avgLinesChanged,
+	)
+}
+
+// buildParameterDescription explains why too many parameters is a concern
+func buildParameterDescription(items []models.AffectedItem, severity string) string {
+	if len(items) == 0 {
+		return "Functions with many parameters are harder to call correctly."
+	}
+
+	var totalParams float64
+	for _, item := range items {
+		totalParams += item.Metrics["parameter_count"]
+	}
+	avgParams := totalParams / float64(len(items))
+
+	if severity == "warning" {
+		return fmt.Sprintf(
+			"These functions average %.0f parameters. Many parameters increase cognitive load, make testing harder, and often indicate the function is doing too much. Group related parameters into structs or split the function.",
+			avgParams,
+		)
+	}
+
+	return fmt.Sprintf(
+		"Averaging %.0f parameters per function. Consider grouping related parameters into a config struct or options pattern to improve readability.",
+		avgParams,
+	)
+}
+
+// buildGodFunctionDescription explains why god functions are problematic
+func buildGodFunctionDescription(items []models.AffectedItem) string {
+	if len(items) == 0 {
+		return "Functions with many parameters and high fan-in may be doing too much."
+	}
+
+	var totalParams, totalFanIn float64
+	for _, item := range items {
+		totalParams += item.Metrics["parameter_count"]
+		totalFanIn += item.Metrics["fan_in"]
+	}
+
+	avgParams := totalParams / float64(len(items))
+	avgFanIn := totalFanIn / float64(len(items))
+
+	return fmt.Sprintf(
+		"These functions average %.0f parameters and are called from %.0f places. High fan-in with many parameters suggests these are 'god functions' trying to do everything. Break them into focused, single-responsibility functions.",
+		avgParams, avgFanIn,
+	)
+}
+
+// buildFanOutDescription explains why functions that call many others are a concern
+func buildFanOutDescription(items []models.AffectedItem, severity string) string {
+	if len(items) == 0 {
+		return "Functions that call many others are doing too much orchestration."
+	}
+
+	var totalFanOut float64
+	for _, item := range items {
+		totalFanOut += item.Metrics["fan_out"]
+	}
+	avgFanOut := totalFanOut / float64(len(items))
+
+	if severity == "warning" {
+		return fmt.Sprintf(
+			"These functions average %.0f calls to other functions. A function coordinating this many steps breaks whenever any of them changes and is hard to test in isolation. Group related calls into helpers, each owning one stage of the work.",
+			avgFanOut,
+		)
+	}
+
+	return fmt.Sprintf(
+		"Averaging %.0f calls per function. Consider extracting groups of related calls into well-named helpers so each function reads as a short sequence of steps.",
+		avgFanOut,
+	)
+}
+
+// buildLocalsDescription explains why functions with many local variables are a concern
+func buildLocalsDescription(items []models.AffectedItem, severity string) string {
+	if len(items) == 0 {
+		return "Functions with many local variables carry too much state."
+	}
+
+	var totalLocals float64
+	for _, item := range items {
+		totalLocals += item.Metrics["local_variable_count"]
+	}
+	avgLocals := totalLocals / float64(len(items))
+
+	if severity == "warning" {
+		return fmt.Sprintf(
+			"These functions average %.0f local variables. Keeping track of that much state makes every line harder to reason about and often means several computations are tangled together. Split each computation into its own function or group related values into a struct.",
+			avgLocals,
+		)
+	}
+
+	return fmt.Sprintf(
+		"Averaging %.0f local variables per function. Consider extracting the parts that compute intermediate values so each function holds less state at once.",
+		avgLocals,
+	)
+}
+
+// buildMethodChainDescription explains why long chains of calls on one expression are a concern
+func buildMethodChainDescription(items []models.AffectedItem, severity string) string {
+	if len(items) == 0 {
+		return "Long method chains couple a function to the internals of many types."
+	}
+
+	longest := 0.0
+	for _, item := range items {
+		longest = max(longest, item.Metrics["method_chain_length"])
+	}
+
+	if severity == "warning" {
+		return fmt.Sprintf(
+			"These functions chain up to %.0f calls on a single expression. Every link reaches through another object, so a change to any type along the chain breaks the caller, and a failure in the middle is hard to pin down. Ask the nearest object for what you need (Law of Demeter), or break the chain into named steps.",
+			longest,
+		)
+	}
+
+	return fmt.Sprintf(
+		"Chains of up to %.0f calls on a single expression. Consider naming intermediate results or adding a method that hides the traversal; fluent builders and collection pipelines are usually fine.",
+		longest,
+	)
+}
+
+// buildMissingReturnDescription explains why a function that can end without returning is a concern
+func buildMissingReturnDescription(items []models.AffectedItem) string {
+	return fmt.Sprintf(
+		"%d function(s) declare results but have a path that reaches the closing brace without returning. Go rejects this at compile time, so the code is unfinished or was never built. Add the missing return, or end the path with panic if it cannot happen.",
+		len(items),
+	)
+}
+
+// buildUnreachableCodeDescription explains why statements after a terminating statement are a concern
+func buildUnreachableCodeDescription(items []models.AffectedItem) string {
+	return fmt.Sprintf(
+		"%d function(s) have statements after a return, panic, goto, or a branch that always returns, so they can never run. Unreachable code usually means a condition or early return is wrong, or leftovers from a refactor that readers will still try to understand. Check the logic, then delete the dead statements.",
+		len(items),
+	)
+}
+
+// buildOutlierDescription explains why functions far out of line with their file are a concern
+func buildOutlierDescription(items []models.AffectedItem) string {
+	if len(items) == 0 {
+		return "Functions much larger or more complex than the rest of their file are refactor targets."
+	}
+
+	var totalStdDevs float64
+	for _, item := range items {
+		totalStdDevs += item.Metrics["std_devs"]
+	}
+	avgStdDevs := totalStdDevs / float64(len(items))
+
+	return fmt.Sprintf(
+		"%d function(s) sit on average %.1f standard deviations above the length or complexity of the other functions in their file. Even under the absolute thresholds, one oversized function among small ones usually holds logic that belongs in helpers. Split it to match the rest of the file.",
+		len(items), avgStdDevs,
+	)
+}
+
+// buildWMCDescription explains why types with a high total method complexity are a concern
+func buildWMCDescription(items []models.AffectedItem, severity string) string {
+	if len(items) == 0 {
+		return "Types whose methods add up to a high complexity are hard to understand and change."
+	}
+
+	var totalWeighted, totalMethods float64
+	for _, item := range items {
+		totalWeighted += item.Metrics["weighted_methods"]
+		totalMethods += item.Metrics["method_count"]
+	}
+	avgWeighted := totalWeighted / float64(len(items))
+	avgMethods := totalMethods / float64(len(items))
+
+	if severity == "warning" {
+		return fmt.Sprintf(
+			"These types average a combined method complexity of %.0f across %.0f methods. A type carrying this much logic usually has several responsibilities; split it into smaller types that each own one.",
+			avgWeighted, avgMethods,
+		)
+	}
+
+	return fmt.Sprintf(
+		"These types average a combined method complexity of %.0f across %.0f methods. Consider moving related methods into collaborating types before the class grows further.",
+		avgWeighted, avgMethods,
+	)
+}
+
+// buildNestingDescription explains why deep nesting is problematic
 func buildNestingDescription(items []models.AffectedItem, severity string) string {
 	if len(items) == 0 {
 		return "Deeply nested code is hard to follow and test."
@@ -583,3 +1606,85 @@ func buildNestingDescription(items []models.AffectedItem, severity string) strin
 		avgNesting,
 	)
 }
+
+// buildUndocumentedComplexityDescription explains why complex code without documentation is a concern
+func buildUndocumentedComplexityDescription(items []models.AffectedItem, severity string) string {
+	var totalComplexity float64
+	for _, item := range items {
+		totalComplexity += item.Metrics["complexity"]
+	}
+	avgComplexity := totalComplexity / float64(len(items))
+
+	if severity == "warning" {
+		return fmt.Sprintf(
+			"%d function(s) average a cyclomatic complexity of %.0f with no doc comment or docstring. Complex, undocumented code is the hardest to maintain: readers must reverse-engineer intent from every branch. Document what the function does and why, then consider splitting it.",
+			len(items), avgComplexity,
+		)
+	}
+
+	return fmt.Sprintf(
+		"%d function(s) average a cyclomatic complexity of %.0f with no doc comment or docstring. A short comment explaining the purpose and edge cases makes complex logic much easier to change safely.",
+		len(items), avgComplexity,
+	)
+}
+
+// buildCommentDensityDescription explains why unusually low or high comment density is a concern
+// buildCommentedOutCodeDescription explains why commented-out code blocks are a concern
+func buildCommentedOutCodeDescription(items []models.AffectedItem) string {
+	var totalLines float64
+	for _, item := range items {
+		totalLines += item.Metrics["lines"]
+	}
+
+	return fmt.Sprintf(
+		"%d block(s) of commented-out code cover %.0f lines. Dead code in comments inflates comment density, goes stale as the code around it changes, and leaves readers guessing whether it matters. Delete it and let version control keep history.",
+		len(items), totalLines,
+	)
+}
+
+func buildFileMaintainabilityDescription(items []models.AffectedItem, threshold int, functionThreshold int) string {
+	var totalMaintainability, functionCount, flaggedFunctions float64
+	for _, item := range items {
+		totalMaintainability += item.Metrics["maintainability_index"]
+		functionCount += item.Metrics["function_count"]
+		flaggedFunctions += item.Metrics["flagged_functions"]
+	}
+
+	return fmt.Sprintf(
+		"%d file(s) average a maintainability index of %.1f (below %d), weighted by function length. %.0f of their %.0f functions fall below the per-function threshold of %d; the rest add up to files that are hard to work in. Split these files along their responsibilities and simplify the largest functions first.",
+		len(items), totalMaintainability/float64(len(items)), threshold, flaggedFunctions, functionCount, functionThreshold,
+	)
+}
+
+func buildCommentDensityDescription(items []models.AffectedItem, threshold int, sparse bool) string {
+	var totalDensity float64
+	for _, item := range items {
+		totalDensity += item.Metrics["comment_density"]
+	}
+	avgDensity := totalDensity / float64(len(items))
+
+	if sparse {
+		return fmt.Sprintf(
+			"%d file(s) average %.0f%% comment lines (below %d%%). Undocumented code slows onboarding and hides intent. Document public APIs and non-obvious decisions.",
+			len(items), avgDensity, threshold,
+		)
+	}
+
+	return fmt.Sprintf(
+		"%d file(s) average %.0f%% comment lines (above %d%%). Very high comment density often means commented-out code or comments restating the code. Delete dead code and let version control keep history.",
+		len(items), avgDensity, threshold,
+	)
+}
+
+func buildTestRatioDescription(items []models.AffectedItem, threshold float64) string {
+	var testLines, codeLines float64
+	for _, item := range items {
+		testLines += item.Metrics["test_code_lines"]
+		codeLines += item.Metrics["code_lines"]
+	}
+
+	return fmt.Sprintf(
+		"%d folder(s) have %.0f test lines for %.0f lines of code (a ratio of %.2f, below %.2f). Thinly tested code is risky to change. Add tests for these folders before refactoring them.",
+		len(items), testLines, codeLines, testLines/codeLines, threshold,
+	)
+}
@@ -1,6 +1,8 @@
 package reports
 
 import (
+	"fmt"
+	"math"
 	"strings"
 	"testing"
 
@@ -13,7 +15,7 @@ func TestDetectConcernsEmpty(t *testing.T) {
 		Files: []models.FileAnalysis{},
 	}
 
-	concerns := DetectConcerns(result, false, config.DefaultConfig().Thresholds)
+	concerns := DetectConcerns(result, false, config.DefaultConfig().Thresholds, config.DefaultConfig().Reports)
 	if len(concerns) != 0 {
 		t.Errorf("Empty result should have no concerns, got %d", len(concerns))
 	}
@@ -38,7 +40,7 @@ func TestDetectConcernsNoConcerns(t *testing.T) {
 		},
 	}
 
-	concerns := DetectConcerns(result, false, config.DefaultConfig().Thresholds)
+	concerns := DetectConcerns(result, false, config.DefaultConfig().Thresholds, config.DefaultConfig().Reports)
 	if len(concerns) != 0 {
 		t.Errorf("Clean code should have no concerns, got %d: %+v", len(concerns), concerns)
 	}
@@ -63,7 +65,7 @@ func TestDetectChurnComplexityHotspots(t *testing.T) {
 		},
 	}
 
-	concerns := DetectConcerns(result, true, config.DefaultConfig().Thresholds)
+	concerns := DetectConcerns(result, true, config.DefaultConfig().Thresholds, config.DefaultConfig().Reports)
 
 	foundHotspot := false
 	for _, concern := range concerns {
@@ -83,6 +85,133 @@ func TestDetectChurnComplexityHotspots(t *testing.T) {
 	}
 }
 
+func TestDetectChurnComplexityHotspotsByLinesChanged(t *testing.T) {
+	result := &models.AnalysisResult{
+		ChurnMetric: config.ChurnMetricLines,
+		Files: []models.FileAnalysis{
+			{
+				Path: "hotspot.go",
+				Functions: []models.FunctionAnalysis{
+					// Few commits, but each rewrote much of the function
+					{Name: "rewritten", CyclomaticComplexity: 15, Churn: &models.ChurnMetric{TotalCommits: 3, TotalChanges: 1500}},
+					// Many one-line commits
+					{Name: "tweaked", CyclomaticComplexity: 15, Churn: &models.ChurnMetric{TotalCommits: 10, TotalChanges: 10}},
+				},
+			},
+		},
+	}
+
+	concerns := DetectConcerns(result, true, config.DefaultConfig().Thresholds, config.DefaultConfig().Reports)
+
+	var hotspotNames []string
+	for _, concern := range concerns {
+		if concern.Type != "churn_complexity_hotspot" {
+			continue
+		}
+		for _, item := range concern.AffectedItems {
+			hotspotNames = append(hotspotNames, item.FunctionName)
+			if item.Metrics["lines_changed"] != 1500 || item.Metrics["churn"] != 3 {
+				t.Errorf("Expected commits and lines changed in metrics, got %+v", item.Metrics)
+			}
+		}
+	}
+
+	if len(hotspotNames) != 1 || hotspotNames[0] != "rewritten" {
+		t.Errorf("Expected only the heavily rewritten function as a hotspot, got %v", hotspotNames)
+	}
+}
+
+func TestExceedsChurn(t *testing.T) {
+	manyCommits := &models.ChurnMetric{TotalCommits: 12, TotalChanges: 40}
+	manyLines := &models.ChurnMetric{TotalCommits: 3, TotalChanges: 800}
+
+	testCases := []struct {
+		name        string
+		churn       *models.ChurnMetric
+		churnMetric string
+		expected    bool
+	}{
+		{"commits by default", manyCommits, "", true},
+		{"commits ignore lines", manyLines, config.ChurnMetricCommits, false},
+		{"lines ignore commits", manyCommits, config.ChurnMetricLines, false},
+		{"lines", manyLines, config.ChurnMetricLines, true},
+		{"both accepts commits", manyCommits, config.ChurnMetricBoth, true},
+		{"both accepts lines", manyLines, config.ChurnMetricBoth, true},
+		{"no churn", nil, config.ChurnMetricBoth, false},
+	}
+
+	for _, testCase := range testCases {
+		if got := ExceedsChurn(testCase.churn, testCase.churnMetric, 10, 250); got != testCase.expected {
+			t.Errorf("%s: expected %v, got %v", testCase.name, testCase.expected, got)
+		}
+	}
+}
+
+func TestIsHotspotCombine(t *testing.T) {
+	complexStable := models.FunctionAnalysis{CyclomaticComplexity: 40, Churn: &models.ChurnMetric{TotalCommits: 1}}
+	simpleChurning := models.FunctionAnalysis{CyclomaticComplexity: 2, Churn: &models.ChurnMetric{TotalCommits: 30}}
+	complexChurning := models.FunctionAnalysis{CyclomaticComplexity: 40, Churn: &models.ChurnMetric{TotalCommits: 30}}
+	complexNoHistory := models.FunctionAnalysis{CyclomaticComplexity: 40}
+
+	testCases := []struct {
+		name     string
+		function models.FunctionAnalysis
+		combine  string
+		expected bool
+	}{
+		{"and by default needs churn", complexStable, "", false},
+		{"and needs complexity", simpleChurning, config.HotspotCombineAnd, false},
+		{"and with both", complexChurning, config.HotspotCombineAnd, true},
+		{"or accepts complexity", complexStable, config.HotspotCombineOr, true},
+		{"or accepts churn", simpleChurning, config.HotspotCombineOr, true},
+		{"or needs churn data", complexNoHistory, config.HotspotCombineOr, false},
+	}
+
+	for _, testCase := range testCases {
+		thresholds := config.HotspotThresholds{MinComplexity: 10, MinChurn: 10, MinChurnLines: 250, Combine: testCase.combine}
+		if got := IsHotspot(testCase.function, thresholds, config.ChurnMetricCommits); got != testCase.expected {
+			t.Errorf("%s: expected %v, got %v", testCase.name, testCase.expected, got)
+		}
+	}
+}
+
+func TestDetectChurnComplexityHotspotsCombineOr(t *testing.T) {
+	result := &models.AnalysisResult{
+		Files: []models.FileAnalysis{
+			{
+				Path: "parser.go",
+				Functions: []models.FunctionAnalysis{
+					{Name: "parseGrammar", CyclomaticComplexity: 45, Churn: &models.ChurnMetric{TotalCommits: 1}},
+					{Name: "format", CyclomaticComplexity: 3, Churn: &models.ChurnMetric{TotalCommits: 2}},
+				},
+			},
+		},
+	}
+
+	hotspotNames := func(thresholds config.ThresholdConfig) []string {
+		var names []string
+		for _, concern := range DetectConcerns(result, true, thresholds, config.DefaultConfig().Reports) {
+			if concern.Type != "churn_complexity_hotspot" {
+				continue
+			}
+			for _, item := range concern.AffectedItems {
+				names = append(names, item.FunctionName)
+			}
+		}
+		return names
+	}
+
+	thresholds := config.DefaultConfig().Thresholds
+	if names := hotspotNames(thresholds); len(names) != 0 {
+		t.Fatalf("Expected no hotspot for a stable function by default, got %v", names)
+	}
+
+	thresholds.Hotspot.Combine = config.HotspotCombineOr
+	if names := hotspotNames(thresholds); len(names) != 1 || names[0] != "parseGrammar" {
+		t.Errorf("Expected the complex but stable function as the only hotspot with or, got %v", names)
+	}
+}
+
 func TestDetectHighChurnLongFunctions(t *testing.T) {
 	churnVeryHigh := &models.ChurnMetric{TotalCommits: 25}
 
@@ -102,7 +231,7 @@ func TestDetectHighChurnLongFunctions(t *testing.T) {
 		},
 	}
 
-	concerns := DetectConcerns(result, true, config.DefaultConfig().Thresholds)
+	concerns := DetectConcerns(result, true, config.DefaultConfig().Thresholds, config.DefaultConfig().Reports)
 
 	foundLongChurn := false
 	for _, concern := range concerns {
@@ -135,7 +264,7 @@ func TestDetectLowMaintainabilityCritical(t *testing.T) {
 		},
 	}
 
-	concerns := DetectConcerns(result, false, config.DefaultConfig().Thresholds)
+	concerns := DetectConcerns(result, false, config.DefaultConfig().Thresholds, config.DefaultConfig().Reports)
 
 	foundMaintain := false
 	for _, concern := range concerns {
@@ -172,7 +301,7 @@ func TestDetectLowMaintainabilityWarning(t *testing.T) {
 		},
 	}
 
-	concerns := DetectConcerns(result, false, config.DefaultConfig().Thresholds)
+	concerns := DetectConcerns(result, false, config.DefaultConfig().Thresholds, config.DefaultConfig().Reports)
 
 	foundMaintain := false
 	for _, concern := range concerns {
@@ -186,6 +315,86 @@ func TestDetectLowMaintainabilityWarning(t *testing.T) {
 	}
 }
 
+func TestDetectLowFileMaintainability(t *testing.T) {
+	result := &models.AnalysisResult{
+		Files: []models.FileAnalysis{
+			{
+				// Every function is mediocre, none low enough to be flagged on its own
+				Path: "mediocre.go",
+				Functions: []models.FunctionAnalysis{
+					{Name: "first", MaintainabilityIndex: 45, Length: 30},
+					{Name: "second", MaintainabilityIndex: 48, Length: 30},
+					{Name: "third", MaintainabilityIndex: 44, Length: 30},
+				},
+			},
+			{
+				// The plain mean is 63, but the long function dominates the weighted mean of 35
+				Path: "lopsided.go",
+				Functions: []models.FunctionAnalysis{
+					{Name: "long", MaintainabilityIndex: 30, Length: 90},
+					{Name: "shortA", MaintainabilityIndex: 80, Length: 5},
+					{Name: "shortB", MaintainabilityIndex: 80, Length: 5},
+				},
+			},
+			{
+				Path: "healthy.go",
+				Functions: []models.FunctionAnalysis{
+					{Name: "a", MaintainabilityIndex: 70, Length: 20},
+					{Name: "b", MaintainabilityIndex: 65, Length: 20},
+					{Name: "c", MaintainabilityIndex: 75, Length: 20},
+				},
+			},
+			{
+				// Fewer than min_functions functions
+				Path: "small.go",
+				Functions: []models.FunctionAnalysis{
+					{Name: "a", MaintainabilityIndex: 30, Length: 20},
+					{Name: "b", MaintainabilityIndex: 30, Length: 20},
+				},
+			},
+		},
+	}
+
+	concerns := detectLowFileMaintainability(result.Files, config.DefaultConfig().Thresholds)
+	if len(concerns) != 2 {
+		t.Fatalf("Expected a critical and a warning concern, got %+v", concerns)
+	}
+
+	critical := concerns[0]
+	if critical.Type != "low_file_maintainability" || critical.Severity != "critical" {
+		t.Fatalf("Expected a critical low_file_maintainability concern first, got %s %s", critical.Severity, critical.Type)
+	}
+	if len(critical.AffectedItems) != 1 || critical.AffectedItems[0].FilePath != "lopsided.go" {
+		t.Fatalf("Expected only lopsided.go to be critical, got %+v", critical.AffectedItems)
+	}
+	if got := critical.AffectedItems[0].Metrics["maintainability_index"]; math.Abs(got-35) > 0.001 {
+		t.Errorf("Expected a length-weighted MI of 35, got %.2f", got)
+	}
+	if critical.AffectedItems[0].Metrics["flagged_functions"] != 1 {
+		t.Errorf("Expected 1 function below the per-function threshold, got %.0f", critical.AffectedItems[0].Metrics["flagged_functions"])
+	}
+
+	warning := concerns[1]
+	if warning.Severity != "warning" || len(warning.AffectedItems) != 1 || warning.AffectedItems[0].FilePath != "mediocre.go" {
+		t.Fatalf("Expected only mediocre.go as a warning, got %+v", warning)
+	}
+	if warning.AffectedItems[0].FunctionName != "" {
+		t.Errorf("File-level items should name no function, got %s", warning.AffectedItems[0].FunctionName)
+	}
+	if !strings.Contains(warning.Description, "0 of their 3 functions") {
+		t.Errorf("Description should say no function is flagged on its own, got %q", warning.Description)
+	}
+
+	// Per-function detection sees nothing in mediocre.go
+	for _, concern := range DetectConcerns(result, false, config.DefaultConfig().Thresholds, config.DefaultConfig().Reports) {
+		for _, item := range concern.AffectedItems {
+			if concern.Type == "low_maintainability" && item.FilePath == "mediocre.go" {
+				t.Errorf("mediocre.go should only be flagged at the file level, got %s", item.FunctionName)
+			}
+		}
+	}
+}
+
 func TestDetectDeepNestingWarning(t *testing.T) {
 	result := &models.AnalysisResult{
 		Files: []models.FileAnalysis{
@@ -202,7 +411,7 @@ func TestDetectDeepNestingWarning(t *testing.T) {
 		},
 	}
 
-	concerns := DetectConcerns(result, false, config.DefaultConfig().Thresholds)
+	concerns := DetectConcerns(result, false, config.DefaultConfig().Thresholds, config.DefaultConfig().Reports)
 
 	foundNesting := false
 	for _, concern := range concerns {
@@ -232,7 +441,7 @@ func TestDetectDeepNestingInfo(t *testing.T) {
 		},
 	}
 
-	concerns := DetectConcerns(result, false, config.DefaultConfig().Thresholds)
+	concerns := DetectConcerns(result, false, config.DefaultConfig().Thresholds, config.DefaultConfig().Reports)
 
 	foundNesting := false
 	for _, concern := range concerns {
@@ -262,7 +471,7 @@ func TestDetectTooManyParametersWarning(t *testing.T) {
 		},
 	}
 
-	concerns := DetectConcerns(result, false, config.DefaultConfig().Thresholds)
+	concerns := DetectConcerns(result, false, config.DefaultConfig().Thresholds, config.DefaultConfig().Reports)
 
 	foundParams := false
 	for _, concern := range concerns {
@@ -276,6 +485,50 @@ func TestDetectTooManyParametersWarning(t *testing.T) {
 	}
 }
 
+func TestDetectConcernsSkipsTrivialFunctions(t *testing.T) {
+	result := &models.AnalysisResult{
+		MinFunctionLines: 3,
+		Files: []models.FileAnalysis{
+			{
+				Path: "params.go",
+				Functions: []models.FunctionAnalysis{
+					{Name: "newOptions", StartLine: 10, Length: 1, ParameterCount: 12, MaintainabilityIndex: 90},
+					{Name: "configure", StartLine: 20, Length: 10, ParameterCount: 12, MaintainabilityIndex: 90},
+				},
+			},
+		},
+	}
+
+	concerns := DetectConcerns(result, false, config.DefaultConfig().Thresholds, config.DefaultConfig().Reports)
+
+	for _, concern := range concerns {
+		if concern.Type != "too_many_parameters" {
+			continue
+		}
+		if len(concern.AffectedItems) != 1 || concern.AffectedItems[0].FunctionName != "configure" {
+			t.Errorf("Expected only configure to be flagged, got %+v", concern.AffectedItems)
+		}
+		if len(result.Files[0].Functions) != 2 {
+			t.Errorf("Expected the result's functions to be left untouched, got %d", len(result.Files[0].Functions))
+		}
+		return
+	}
+	t.Error("Should still detect too many parameters in non-trivial functions")
+}
+
+func TestIsTrivialFunction(t *testing.T) {
+	oneLiner := models.FunctionAnalysis{Length: 1}
+	if IsTrivialFunction(oneLiner, 0) {
+		t.Error("A minimum of 0 should mark nothing as trivial")
+	}
+	if !IsTrivialFunction(oneLiner, 2) {
+		t.Error("A 1-line function should be trivial with a minimum of 2")
+	}
+	if IsTrivialFunction(models.FunctionAnalysis{Length: 2}, 2) {
+		t.Error("A function at the minimum length should not be trivial")
+	}
+}
+
 func TestDetectTooManyParametersInfo(t *testing.T) {
 	result := &models.AnalysisResult{
 		Files: []models.FileAnalysis{
@@ -292,7 +545,7 @@ func TestDetectTooManyParametersInfo(t *testing.T) {
 		},
 	}
 
-	concerns := DetectConcerns(result, false, config.DefaultConfig().Thresholds)
+	concerns := DetectConcerns(result, false, config.DefaultConfig().Thresholds, config.DefaultConfig().Reports)
 
 	foundParams := false
 	for _, concern := range concerns {
@@ -323,7 +576,7 @@ func TestDetectGodFunctions(t *testing.T) {
 		},
 	}
 
-	concerns := DetectConcerns(result, false, config.DefaultConfig().Thresholds)
+	concerns := DetectConcerns(result, false, config.DefaultConfig().Thresholds, config.DefaultConfig().Reports)
 
 	foundGod := false
 	for _, concern := range concerns {
@@ -340,6 +593,207 @@ func TestDetectGodFunctions(t *testing.T) {
 	}
 }
 
+func TestDetectHighFanOut(t *testing.T) {
+	result := &models.AnalysisResult{
+		Files: []models.FileAnalysis{
+			{
+				Path: "orchestrator.go",
+				Functions: []models.FunctionAnalysis{
+					{Name: "runEverything", StartLine: 10, FanOut: 75},
+					{Name: "wireServices", StartLine: 120, FanOut: 40},
+					{Name: "helper", StartLine: 200, FanOut: 30},
+				},
+			},
+		},
+	}
+
+	var fanOutConcerns []models.Concern
+	for _, concern := range DetectConcerns(result, false, config.DefaultConfig().Thresholds, config.DefaultConfig().Reports) {
+		if concern.Type == "high_fan_out" {
+			fanOutConcerns = append(fanOutConcerns, concern)
+		}
+	}
+
+	if len(fanOutConcerns) != 2 {
+		t.Fatalf("Expected warning and info fan-out concerns, got %d: %+v", len(fanOutConcerns), fanOutConcerns)
+	}
+	if fanOutConcerns[0].Severity != "warning" || len(fanOutConcerns[0].AffectedItems) != 1 || fanOutConcerns[0].AffectedItems[0].FunctionName != "runEverything" {
+		t.Errorf("Expected runEverything as a warning, got %s %+v", fanOutConcerns[0].Severity, fanOutConcerns[0].AffectedItems)
+	}
+	if fanOutConcerns[1].Severity != "info" || len(fanOutConcerns[1].AffectedItems) != 1 || fanOutConcerns[1].AffectedItems[0].FunctionName != "wireServices" {
+		t.Errorf("Expected wireServices as info (helper is at the threshold), got %s %+v", fanOutConcerns[1].Severity, fanOutConcerns[1].AffectedItems)
+	}
+	if !strings.Contains(fanOutConcerns[0].Description, "75 calls") {
+		t.Errorf("Description should mention the fan-out, got: %s", fanOutConcerns[0].Description)
+	}
+}
+
+func TestDetectHighFanOutCustomThresholds(t *testing.T) {
+	thresholds := config.DefaultConfig().Thresholds
+	thresholds.FanOut.Warning = 5
+
+	functions := []functionWithFile{
+		{function: models.FunctionAnalysis{Name: "dispatch", FanOut: 8}, filePath: "dispatch.go"},
+	}
+
+	concerns := detectHighFanOut(functions, thresholds)
+
+	if len(concerns) != 1 || concerns[0].Severity != "info" {
+		t.Errorf("Custom warning threshold should flag dispatch, got %+v", concerns)
+	}
+}
+
+func TestDetectConcernsSetsOverloadKeys(t *testing.T) {
+	result := &models.AnalysisResult{
+		Files: []models.FileAnalysis{
+			{
+				Path: "Parser.kt",
+				Functions: []models.FunctionAnalysis{
+					{Name: "parse", StartLine: 3, ParameterCount: 1, Length: 10, NestingDepth: 6, MaintainabilityIndex: 80},
+					{Name: "parse", StartLine: 20, ParameterCount: 2, Length: 10, NestingDepth: 6, MaintainabilityIndex: 80},
+					{Name: "render", StartLine: 40, Length: 10, NestingDepth: 6, MaintainabilityIndex: 80},
+				},
+			},
+		},
+	}
+
+	overloads := map[int]string{}
+	for _, concern := range DetectConcerns(result, false, config.DefaultConfig().Thresholds, config.ReportsConfig{}) {
+		if concern.Type != "deep_nesting" {
+			continue
+		}
+		for _, item := range concern.AffectedItems {
+			overloads[item.Line] = item.Overload
+		}
+	}
+
+	expected := map[int]string{3: "(1)", 20: "(2)", 40: ""}
+	if len(overloads) != len(expected) {
+		t.Fatalf("Expected nesting items for all three functions, got %v", overloads)
+	}
+	for line, overload := range expected {
+		if overloads[line] != overload {
+			t.Errorf("Line %d: expected overload %q, got %q", line, overload, overloads[line])
+		}
+	}
+}
+
+func TestDetectTooManyLocals(t *testing.T) {
+	result := &models.AnalysisResult{
+		Files: []models.FileAnalysis{
+			{
+				Path: "report.go",
+				Functions: []models.FunctionAnalysis{
+					{Name: "buildReport", StartLine: 10, LocalVariableCount: 18},
+					{Name: "formatRows", StartLine: 80, LocalVariableCount: 12},
+					{Name: "render", StartLine: 140, LocalVariableCount: 10},
+				},
+			},
+		},
+	}
+
+	var localConcerns []models.Concern
+	for _, concern := range DetectConcerns(result, false, config.DefaultConfig().Thresholds, config.DefaultConfig().Reports) {
+		if concern.Type == "too_many_locals" {
+			localConcerns = append(localConcerns, concern)
+		}
+	}
+
+	if len(localConcerns) != 2 {
+		t.Fatalf("Expected warning and info local variable concerns, got %d: %+v", len(localConcerns), localConcerns)
+	}
+	if localConcerns[0].Severity != "warning" || len(localConcerns[0].AffectedItems) != 1 || localConcerns[0].AffectedItems[0].FunctionName != "buildReport" {
+		t.Errorf("Expected buildReport as a warning, got %s %+v", localConcerns[0].Severity, localConcerns[0].AffectedItems)
+	}
+	if localConcerns[1].Severity != "info" || len(localConcerns[1].AffectedItems) != 1 || localConcerns[1].AffectedItems[0].FunctionName != "formatRows" {
+		t.Errorf("Expected formatRows as info (render is at the threshold), got %s %+v", localConcerns[1].Severity, localConcerns[1].AffectedItems)
+	}
+	if !strings.Contains(localConcerns[0].Description, "18 local variables") {
+		t.Errorf("Description should mention the local variable count, got: %s", localConcerns[0].Description)
+	}
+}
+
+func TestDetectTooManyLocalsCustomThresholds(t *testing.T) {
+	thresholds := config.DefaultConfig().Thresholds
+	thresholds.LocalVariables.Warning = 3
+
+	functions := []functionWithFile{
+		{function: models.FunctionAnalysis{Name: "parse", LocalVariableCount: 5}, filePath: "parse.go"},
+	}
+
+	concerns := detectTooManyLocals(functions, thresholds)
+
+	if len(concerns) != 1 || concerns[0].Severity != "info" {
+		t.Errorf("Custom warning threshold should flag parse, got %+v", concerns)
+	}
+}
+
+func TestDetectMethodChains(t *testing.T) {
+	functions := []functionWithFile{
+		{function: models.FunctionAnalysis{Name: "shippingLabel", StartLine: 10, MethodChainLength: 7, MethodChainLine: 14}, filePath: "orders.go"},
+		{function: models.FunctionAnalysis{Name: "cityName", StartLine: 30, MethodChainLength: 4, MethodChainLine: 31}, filePath: "orders.go"},
+		{function: models.FunctionAnalysis{Name: "register", StartLine: 50, MethodChainLength: 3, MethodChainLine: 52}, filePath: "cmd.go"},
+	}
+
+	concerns := detectMethodChains(functions, config.DefaultConfig().Thresholds)
+
+	if len(concerns) != 2 {
+		t.Fatalf("Expected a warning and an info concern, got %d: %+v", len(concerns), concerns)
+	}
+	if concerns[0].Severity != "warning" || len(concerns[0].AffectedItems) != 1 || concerns[0].AffectedItems[0].FunctionName != "shippingLabel" {
+		t.Errorf("Expected shippingLabel as a warning, got %s %+v", concerns[0].Severity, concerns[0].AffectedItems)
+	}
+	if item := concerns[0].AffectedItems[0]; item.Line != 14 {
+		t.Errorf("Expected the item to point at the chain on line 14, got line %d", item.Line)
+	}
+	if concerns[1].Severity != "info" || len(concerns[1].AffectedItems) != 1 || concerns[1].AffectedItems[0].FunctionName != "cityName" {
+		t.Errorf("Expected cityName as info (register is at the threshold), got %s %+v", concerns[1].Severity, concerns[1].AffectedItems)
+	}
+	if !strings.Contains(concerns[0].Description, "7 calls") {
+		t.Errorf("Description should mention the chain length, got: %s", concerns[0].Description)
+	}
+}
+
+func TestDetectControlFlowProblems(t *testing.T) {
+	result := &models.AnalysisResult{
+		Files: []models.FileAnalysis{
+			{
+				Path: "flow.go",
+				Functions: []models.FunctionAnalysis{
+					{Name: "unfinished", StartLine: 10, MissingReturnLine: 18},
+					{Name: "deadCode", StartLine: 30, UnreachableLine: 34},
+					{Name: "clean", StartLine: 50},
+				},
+			},
+		},
+	}
+
+	concerns := DetectConcerns(result, false, config.DefaultConfig().Thresholds, config.DefaultConfig().Reports)
+
+	found := map[string]models.Concern{}
+	for _, concern := range concerns {
+		if concern.Type == "missing_return" || concern.Type == "unreachable_code" {
+			found[concern.Type] = concern
+		}
+	}
+
+	missingReturn, ok := found["missing_return"]
+	if !ok || missingReturn.Severity != "warning" || len(missingReturn.AffectedItems) != 1 {
+		t.Fatalf("Expected one missing return warning, got %+v", missingReturn)
+	}
+	if item := missingReturn.AffectedItems[0]; item.FunctionName != "unfinished" || item.Line != 18 {
+		t.Errorf("Expected the missing return at unfinished line 18, got %s line %d", item.FunctionName, item.Line)
+	}
+
+	unreachable, ok := found["unreachable_code"]
+	if !ok || unreachable.Severity != "info" || len(unreachable.AffectedItems) != 1 {
+		t.Fatalf("Expected one unreachable code info concern, got %+v", unreachable)
+	}
+	if item := unreachable.AffectedItems[0]; item.FunctionName != "deadCode" || item.Line != 34 {
+		t.Errorf("Expected the unreachable code at deadCode line 34, got %s line %d", item.FunctionName, item.Line)
+	}
+}
+
 func TestConcernsSortedBySeverity(t *testing.T) {
 	churnHigh := &models.ChurnMetric{TotalCommits: 15}
 
@@ -366,7 +820,7 @@ func TestConcernsSortedBySeverity(t *testing.T) {
 		},
 	}
 
-	concerns := DetectConcerns(result, true, config.DefaultConfig().Thresholds)
+	concerns := DetectConcerns(result, true, config.DefaultConfig().Thresholds, config.DefaultConfig().Reports)
 
 	if len(concerns) == 0 {
 		t.Skip("No concerns detected")
@@ -412,6 +866,43 @@ func TestLimitAffectedItems(t *testing.T) {
 	}
 }
 
+func TestDetectConcernsMaxItemsPerConcern(t *testing.T) {
+	var functions []models.FunctionAnalysis
+	for index := 0; index < 8; index++ {
+		functions = append(functions, models.FunctionAnalysis{
+			Name:           fmt.Sprintf("tooManyParams%d", index),
+			StartLine:      10 * (index + 1),
+			ParameterCount: 12,
+		})
+	}
+	result := &models.AnalysisResult{
+		Files: []models.FileAnalysis{{Path: "params.go", Functions: functions}},
+	}
+
+	findParamsConcern := func(concerns []models.Concern) models.Concern {
+		for _, concern := range concerns {
+			if concern.Type == "too_many_parameters" {
+				return concern
+			}
+		}
+		t.Fatal("Should detect too many parameters")
+		return models.Concern{}
+	}
+
+	limited := findParamsConcern(DetectConcerns(result, false, config.DefaultConfig().Thresholds, config.DefaultConfig().Reports))
+	if len(limited.AffectedItems) != config.DefaultMaxItemsPerConcern || limited.OmittedItems != 3 {
+		t.Errorf("Expected %d items and 3 omitted by default, got %d and %d", config.DefaultMaxItemsPerConcern, len(limited.AffectedItems), limited.OmittedItems)
+	}
+
+	unlimited := findParamsConcern(DetectConcerns(result, false, config.DefaultConfig().Thresholds, config.ReportsConfig{MaxItemsPerConcern: 0}))
+	if len(unlimited.AffectedItems) != 8 || unlimited.OmittedItems != 0 {
+		t.Errorf("Expected all 8 items with max_items_per_concern 0, got %d and %d omitted", len(unlimited.AffectedItems), unlimited.OmittedItems)
+	}
+	if unlimited.Description != limited.Description {
+		t.Errorf("Expected the description to cover every item whatever the limit, got %q and %q", limited.Description, unlimited.Description)
+	}
+}
+
 func TestBuildMaintainabilityDescription(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -518,7 +1009,7 @@ func TestNoChurnConcernsWithoutChurnData(t *testing.T) {
 		},
 	}
 
-	concerns := DetectConcerns(result, false, config.DefaultConfig().Thresholds)
+	concerns := DetectConcerns(result, false, config.DefaultConfig().Thresholds, config.DefaultConfig().Reports)
 
 	for _, concern := range concerns {
 		if concern.Type == "churn_complexity_hotspot" || concern.Type == "high_churn_long_function" {
@@ -550,7 +1041,7 @@ func TestDetectConcernsWithCustomThresholds(t *testing.T) {
 	}
 
 	// With default thresholds (min_complexity=10, min_churn=10), no hotspot
-	defaultConcerns := DetectConcerns(result, true, config.DefaultConfig().Thresholds)
+	defaultConcerns := DetectConcerns(result, true, config.DefaultConfig().Thresholds, config.DefaultConfig().Reports)
 	foundDefaultHotspot := false
 	for _, concern := range defaultConcerns {
 		if concern.Type == "churn_complexity_hotspot" {
@@ -562,7 +1053,7 @@ func TestDetectConcernsWithCustomThresholds(t *testing.T) {
 	}
 
 	// With custom lower thresholds, should detect hotspot
-	customConcerns := DetectConcerns(result, true, customThresholds)
+	customConcerns := DetectConcerns(result, true, customThresholds, config.DefaultConfig().Reports)
 	foundCustomHotspot := false
 	for _, concern := range customConcerns {
 		if concern.Type == "churn_complexity_hotspot" {
@@ -573,3 +1064,346 @@ func TestDetectConcernsWithCustomThresholds(t *testing.T) {
 		t.Error("Should detect hotspot with custom lower thresholds")
 	}
 }
+
+func TestDetectCommentDensitySparse(t *testing.T) {
+	result := &models.AnalysisResult{
+		Files: []models.FileAnalysis{
+			{Path: "undocumented.go", CodeLines: 200, CommentDensity: 1},
+			{Path: "healthy.go", CodeLines: 200, CommentDensity: 15},
+		},
+	}
+
+	concerns := DetectConcerns(result, false, config.DefaultConfig().Thresholds, config.DefaultConfig().Reports)
+
+	if len(concerns) != 1 {
+		t.Fatalf("Expected 1 concern, got %d", len(concerns))
+	}
+	if concerns[0].Type != "undocumented_code" || concerns[0].Severity != "info" {
+		t.Errorf("Expected info undocumented_code concern, got %s/%s", concerns[0].Severity, concerns[0].Type)
+	}
+	if len(concerns[0].AffectedItems) != 1 || concerns[0].AffectedItems[0].FilePath != "undocumented.go" {
+		t.Errorf("Expected undocumented.go to be affected, got %+v", concerns[0].AffectedItems)
+	}
+	if concerns[0].AffectedItems[0].FunctionName != "" {
+		t.Errorf("Comment density concerns should point to files, not functions")
+	}
+}
+
+func TestDetectCommentDensityHeavy(t *testing.T) {
+	result := &models.AnalysisResult{
+		Files: []models.FileAnalysis{
+			{Path: "commented_out.go", CodeLines: 50, CommentDensity: 70},
+		},
+	}
+
+	concerns := DetectConcerns(result, false, config.DefaultConfig().Thresholds, config.DefaultConfig().Reports)
+
+	if len(concerns) != 1 || concerns[0].Type != "over_commented" {
+		t.Fatalf("Expected over_commented concern, got %+v", concerns)
+	}
+	if !strings.Contains(concerns[0].Description, "70%") {
+		t.Errorf("Description should mention the density, got: %s", concerns[0].Description)
+	}
+}
+
+func TestDetectCommentDensitySkipsSmallFiles(t *testing.T) {
+	result := &models.AnalysisResult{
+		Files: []models.FileAnalysis{
+			{Path: "tiny.go", CodeLines: 5, CommentDensity: 0},
+		},
+	}
+
+	concerns := DetectConcerns(result, false, config.DefaultConfig().Thresholds, config.DefaultConfig().Reports)
+
+	if len(concerns) != 0 {
+		t.Errorf("Files below min_lines should not be checked, got %d concerns", len(concerns))
+	}
+}
+
+func TestDetectLowTestRatio(t *testing.T) {
+	ratio := func(value float64) *float64 { return &value }
+	result := &models.AnalysisResult{
+		FolderStats: map[string]models.FolderMetrics{
+			"untested": {Path: "untested", TotalCodeLines: 400, TestCodeLines: 0, TestRatio: ratio(0)},
+			"thin":     {Path: "thin", TotalCodeLines: 200, TestCodeLines: 60, TestRatio: ratio(0.3)},
+			"tested":   {Path: "tested", TotalCodeLines: 200, TestCodeLines: 240, TestRatio: ratio(1.2)},
+			"tiny":     {Path: "tiny", TotalCodeLines: 10, TestRatio: ratio(0)},
+			"no_tests": {Path: "no_tests", TotalCodeLines: 400},
+		},
+	}
+
+	concerns := DetectConcerns(result, false, config.DefaultConfig().Thresholds, config.DefaultConfig().Reports)
+
+	if len(concerns) != 2 {
+		t.Fatalf("Expected warning and info low_test_ratio concerns, got %+v", concerns)
+	}
+	warning, info := concerns[0], concerns[1]
+	if warning.Type != "low_test_ratio" || warning.Severity != "warning" || len(warning.AffectedItems) != 1 || warning.AffectedItems[0].FilePath != "untested" {
+		t.Errorf("Expected a warning for the untested folder, got %+v", warning)
+	}
+	if info.Type != "low_test_ratio" || info.Severity != "info" || len(info.AffectedItems) != 1 || info.AffectedItems[0].FilePath != "thin" {
+		t.Errorf("Expected an info concern for the thinly tested folder, got %+v", info)
+	}
+	if info.AffectedItems[0].Metrics["test_ratio"] != 0.3 {
+		t.Errorf("Expected the folder's ratio in the item metrics, got %v", info.AffectedItems[0].Metrics)
+	}
+	if !strings.Contains(info.Description, "60 test lines for 200 lines of code") {
+		t.Errorf("Description should total the lines, got: %s", info.Description)
+	}
+}
+
+func TestDetectCommentedOutCode(t *testing.T) {
+	files := []models.FileAnalysis{
+		{
+			Path: "legacy.go",
+			CommentedCode: []models.CommentBlock{
+				{StartLine: 10, EndLine: 14, CodeLines: 5},
+				{StartLine: 40, EndLine: 41, CodeLines: 2},
+			},
+		},
+		{
+			Path:          "handler.go",
+			CommentedCode: []models.CommentBlock{{StartLine: 100, EndLine: 129, CodeLines: 22}},
+		},
+	}
+
+	concerns := detectCommentedOutCode(files)
+
+	if len(concerns) != 1 {
+		t.Fatalf("Expected one concern, got %d: %+v", len(concerns), concerns)
+	}
+	concern := concerns[0]
+	if concern.Type != "commented_out_code" || concern.Severity != "info" {
+		t.Errorf("Expected info commented_out_code concern, got %s %s", concern.Severity, concern.Type)
+	}
+	if len(concern.AffectedItems) != 2 {
+		t.Fatalf("Expected the two-line block to be skipped, got %+v", concern.AffectedItems)
+	}
+
+	largest := concern.AffectedItems[0]
+	if largest.FilePath != "handler.go" || largest.Line != 100 || largest.EndLine != 129 {
+		t.Errorf("Expected handler.go:100-129 first, got %s:%d-%d", largest.FilePath, largest.Line, largest.EndLine)
+	}
+	if largest.Metrics["lines"] != 30 || largest.Metrics["code_lines"] != 22 {
+		t.Errorf("Unexpected metrics: %+v", largest.Metrics)
+	}
+	if !strings.Contains(concern.Description, "35 lines") {
+		t.Errorf("Description should mention the total lines, got: %s", concern.Description)
+	}
+}
+
+func TestDetectCommentedOutCodeNone(t *testing.T) {
+	files := []models.FileAnalysis{{Path: "clean.go"}}
+
+	if concerns := detectCommentedOutCode(files); concerns != nil {
+		t.Errorf("Expected no concerns, got %+v", concerns)
+	}
+}
+
+func TestDetectCommentDensityCustomRange(t *testing.T) {
+	thresholds := config.DefaultConfig().Thresholds
+	thresholds.CommentDensity.Min = 20
+
+	result := &models.AnalysisResult{
+		Files: []models.FileAnalysis{
+			{Path: "light.go", CodeLines: 100, CommentDensity: 10},
+		},
+	}
+
+	concerns := DetectConcerns(result, false, thresholds, config.DefaultConfig().Reports)
+
+	if len(concerns) != 1 || concerns[0].Type != "undocumented_code" {
+		t.Errorf("Custom min should flag 10%% density, got %+v", concerns)
+	}
+}
+
+func TestDetectCommentDensityZeroDisablesChecks(t *testing.T) {
+	thresholds := config.DefaultConfig().Thresholds
+	thresholds.CommentDensity.Min = 0
+	thresholds.CommentDensity.Max = 0
+
+	files := []models.FileAnalysis{
+		{Path: "bare.go", CodeLines: 100, CommentDensity: 0},
+		{Path: "chatty.go", CodeLines: 100, CommentDensity: 90},
+	}
+
+	if concerns := detectCommentDensity(files, thresholds); len(concerns) != 0 {
+		t.Errorf("Min and max of 0 should flag nothing, got %+v", concerns)
+	}
+}
+
+func TestDetectUndocumentedComplexity(t *testing.T) {
+	result := &models.AnalysisResult{
+		Files: []models.FileAnalysis{
+			{
+				Path:     "service.go",
+				Language: "Go",
+				Functions: []models.FunctionAnalysis{
+					{Name: "tangled", CyclomaticComplexity: 25, MaintainabilityIndex: 80},
+					{Name: "branchy", CyclomaticComplexity: 12, MaintainabilityIndex: 80},
+					{Name: "explained", CyclomaticComplexity: 25, MaintainabilityIndex: 80, HasDocComment: true},
+					{Name: "simple", CyclomaticComplexity: 3, MaintainabilityIndex: 80},
+				},
+			},
+		},
+	}
+
+	concerns := detectUndocumentedComplexity(result.Files, config.DefaultConfig().Thresholds)
+
+	if len(concerns) != 2 {
+		t.Fatalf("Expected warning and info concerns, got %d: %+v", len(concerns), concerns)
+	}
+	if concerns[0].Severity != "warning" || concerns[0].AffectedItems[0].FunctionName != "tangled" {
+		t.Errorf("Expected tangled as a warning, got %s %+v", concerns[0].Severity, concerns[0].AffectedItems)
+	}
+	if concerns[1].Severity != "info" || concerns[1].AffectedItems[0].FunctionName != "branchy" {
+		t.Errorf("Expected branchy as info, got %s %+v", concerns[1].Severity, concerns[1].AffectedItems)
+	}
+	for _, concern := range concerns {
+		if concern.Type != "undocumented_complexity" || len(concern.AffectedItems) != 1 {
+			t.Errorf("Expected one undocumented_complexity item per concern, got %+v", concern)
+		}
+	}
+}
+
+func TestDetectUndocumentedComplexitySkipsLanguagesWithoutDocDetection(t *testing.T) {
+	result := &models.AnalysisResult{
+		Files: []models.FileAnalysis{
+			{
+				Path:     "Service.kt",
+				Language: "Kotlin",
+				Functions: []models.FunctionAnalysis{
+					{Name: "tangled", CyclomaticComplexity: 25},
+				},
+			},
+		},
+	}
+
+	concerns := detectUndocumentedComplexity(result.Files, config.DefaultConfig().Thresholds)
+
+	if len(concerns) != 0 {
+		t.Errorf("Kotlin does not record doc comments and should not be flagged, got %+v", concerns)
+	}
+}
+
+// uniformFunctions returns count small functions of identical length and complexity
+func uniformFunctions(count int) []models.FunctionAnalysis {
+	functions := make([]models.FunctionAnalysis, count)
+	for index := range functions {
+		functions[index] = models.FunctionAnalysis{Name: "small", Length: 10, CyclomaticComplexity: 2, MaintainabilityIndex: 85}
+	}
+	return functions
+}
+
+func TestDetectOutlierFunctions(t *testing.T) {
+	functions := append(uniformFunctions(9), models.FunctionAnalysis{
+		Name: "oversized", StartLine: 40, Length: 60, CyclomaticComplexity: 8, MaintainabilityIndex: 85,
+	})
+	files := []models.FileAnalysis{{Path: "handlers.go", Functions: functions}}
+
+	concerns := detectOutlierFunctions(files)
+
+	if len(concerns) != 1 {
+		t.Fatalf("Expected 1 concern, got %d: %+v", len(concerns), concerns)
+	}
+	if concerns[0].Type != "outlier_function" || concerns[0].Severity != "info" {
+		t.Errorf("Expected info outlier_function concern, got %s/%s", concerns[0].Severity, concerns[0].Type)
+	}
+	if len(concerns[0].AffectedItems) != 1 {
+		t.Fatalf("Expected only the oversized function, got %+v", concerns[0].AffectedItems)
+	}
+
+	item := concerns[0].AffectedItems[0]
+	if item.FunctionName != "oversized" || item.Line != 40 {
+		t.Errorf("Expected oversized at line 40, got %s at %d", item.FunctionName, item.Line)
+	}
+	// One value among ten identical ones sits exactly 3 standard deviations out
+	for _, key := range []string{"length_std_devs", "complexity_std_devs", "std_devs"} {
+		if math.Abs(item.Metrics[key]-3) > 0.001 {
+			t.Errorf("Expected %s of 3, got %+v", key, item.Metrics)
+		}
+	}
+	if !strings.Contains(concerns[0].Description, "3.0 standard deviations") {
+		t.Errorf("Description should mention the deviation, got: %s", concerns[0].Description)
+	}
+}
+
+func TestDetectOutlierFunctionsIgnoresSmallOutliers(t *testing.T) {
+	// Three times the file mean, but too short to be worth splitting
+	functions := append(uniformFunctions(9), models.FunctionAnalysis{Name: "wordy", Length: 18, CyclomaticComplexity: 2})
+
+	concerns := detectOutlierFunctions([]models.FileAnalysis{{Path: "small.go", Functions: functions}})
+
+	if len(concerns) != 0 {
+		t.Errorf("Functions below the minimum length should not be outliers, got %+v", concerns)
+	}
+}
+
+func TestDetectOutlierFunctionsSkipsFilesWithFewFunctions(t *testing.T) {
+	functions := append(uniformFunctions(4), models.FunctionAnalysis{Name: "oversized", Length: 300, CyclomaticComplexity: 9})
+
+	concerns := detectOutlierFunctions([]models.FileAnalysis{{Path: "tiny.go", Functions: functions}})
+
+	if len(concerns) != 0 {
+		t.Errorf("Files with too few functions should not be checked, got %+v", concerns)
+	}
+}
+
+func TestMeanAndStdDev(t *testing.T) {
+	mean, stdDev := meanAndStdDev([]float64{2, 4, 4, 4, 5, 5, 7, 9})
+
+	if mean != 5 || stdDev != 2 {
+		t.Errorf("Expected mean 5 and std dev 2, got %v and %v", mean, stdDev)
+	}
+	if mean, stdDev := meanAndStdDev(nil); mean != 0 || stdDev != 0 {
+		t.Errorf("Expected zeros for no values, got %v and %v", mean, stdDev)
+	}
+}
+
+func TestDetectHighWMC(t *testing.T) {
+	files := []models.FileAnalysis{
+		{
+			Path: "service.go",
+			Types: []models.TypeAnalysis{
+				{Name: "OrderService", Kind: "struct", MethodCount: 40, WeightedMethodsPerClass: 140},
+				{Name: "Invoice", Kind: "struct", MethodCount: 12, WeightedMethodsPerClass: 60},
+				{Name: "Money", Kind: "struct", MethodCount: 5, WeightedMethodsPerClass: 8},
+			},
+		},
+	}
+
+	concerns := detectHighWMC(files, config.DefaultConfig().Thresholds)
+
+	if len(concerns) != 2 {
+		t.Fatalf("Expected warning and info concerns, got %d: %+v", len(concerns), concerns)
+	}
+	if concerns[0].Severity != "warning" || concerns[0].AffectedItems[0].FunctionName != "OrderService" {
+		t.Errorf("Expected OrderService as a warning, got %s %+v", concerns[0].Severity, concerns[0].AffectedItems)
+	}
+	if concerns[1].Severity != "info" || concerns[1].AffectedItems[0].FunctionName != "Invoice" {
+		t.Errorf("Expected Invoice as info, got %s %+v", concerns[1].Severity, concerns[1].AffectedItems)
+	}
+	for _, concern := range concerns {
+		if concern.Type != "high_wmc" || len(concern.AffectedItems) != 1 {
+			t.Errorf("Expected one high_wmc item per concern, got %+v", concern)
+		}
+	}
+	if !strings.Contains(concerns[0].Description, "140") {
+		t.Errorf("Description should mention the weighted methods, got: %s", concerns[0].Description)
+	}
+}
+
+func TestDetectHighWMCCustomThresholds(t *testing.T) {
+	thresholds := config.DefaultConfig().Thresholds
+	thresholds.WeightedMethods.Warning = 5
+
+	files := []models.FileAnalysis{
+		{Path: "money.go", Types: []models.TypeAnalysis{{Name: "Money", WeightedMethodsPerClass: 8}}},
+	}
+
+	concerns := detectHighWMC(files, thresholds)
+
+	if len(concerns) != 1 || concerns[0].Severity != "info" {
+		t.Errorf("Custom warning threshold should flag Money, got %+v", concerns)
+	}
+}
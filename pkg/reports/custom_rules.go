@@ -0,0 +1,129 @@
+package reports
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+// compiledRule pairs a custom rule with its compiled pattern
+type compiledRule struct {
+	rule    config.CustomRule
+	pattern *regexp.Regexp
+}
+
+// detectCustomRules re-reads each analyzed file, opened at the path resolvePath returns for it,
+// and reports every line matching a configured custom rule, producing one concern per rule that
+// matched
+func detectCustomRules(files []models.FileAnalysis, rules []config.CustomRule, resolvePath func(path string) string) []models.Concern {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	var compiledRules []compiledRule
+	for _, rule := range rules {
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			// Invalid patterns are reported by config validation
+			continue
+		}
+		compiledRules = append(compiledRules, compiledRule{rule: rule, pattern: pattern})
+	}
+
+	matchesByRule := make([][]models.AffectedItem, len(compiledRules))
+	for _, file := range files {
+		fileMatches := scanFileForRules(file, resolvePath(file.Path), compiledRules)
+		for ruleIndex, items := range fileMatches {
+			matchesByRule[ruleIndex] = append(matchesByRule[ruleIndex], items...)
+		}
+	}
+
+	var concerns []models.Concern
+	for ruleIndex, compiled := range compiledRules {
+		items := matchesByRule[ruleIndex]
+		if len(items) == 0 {
+			continue
+		}
+
+		severity := compiled.rule.Severity
+		if severity == "" {
+			severity = config.DefaultCustomRuleSeverity
+		}
+
+		concerns = append(concerns, models.Concern{
+			Type:          "custom_rule",
+			Severity:      severity,
+			Title:         compiled.rule.Name,
+			Description:   buildCustomRuleDescription(compiled.rule, items),
+			AffectedItems: items,
+		})
+	}
+
+	return concerns
+}
+
+// scanFileForRules returns the matching lines of a file read from sourcePath, keyed by rule index
+func scanFileForRules(file models.FileAnalysis, sourcePath string, rules []compiledRule) map[int][]models.AffectedItem {
+	sourceFile, err := os.Open(sourcePath)
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = sourceFile.Close() }()
+
+	matches := make(map[int][]models.AffectedItem)
+	scanner := bufio.NewScanner(sourceFile)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+
+		for ruleIndex, compiled := range rules {
+			if !compiled.pattern.MatchString(line) {
+				continue
+			}
+
+			matches[ruleIndex] = append(matches[ruleIndex], models.AffectedItem{
+				FilePath:     file.Path,
+				FunctionName: enclosingFunctionName(file.Functions, lineNumber),
+				Line:         lineNumber,
+				Metrics:      map[string]float64{},
+			})
+		}
+	}
+
+	return matches
+}
+
+// enclosingFunctionName returns the name of the function spanning the given line, if any
+func enclosingFunctionName(functions []models.FunctionAnalysis, line int) string {
+	for _, function := range functions {
+		if line >= function.StartLine && line <= function.EndLine {
+			return function.Name
+		}
+	}
+	return ""
+}
+
+// buildCustomRuleDescription summarizes a rule's matches along with its configured message
+func buildCustomRuleDescription(rule config.CustomRule, items []models.AffectedItem) string {
+	files := make(map[string]bool)
+	for _, item := range items {
+		files[item.FilePath] = true
+	}
+
+	var buffer strings.Builder
+	if rule.Message != "" {
+		buffer.WriteString(rule.Message)
+		buffer.WriteString(" ")
+	}
+	buffer.WriteString(fmt.Sprintf("Pattern `%s` matched %d line(s) in %d file(s).", rule.Pattern, len(items), len(files)))
+
+	return buffer.String()
+}
@@ -0,0 +1,73 @@
+package reports
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+func TestDetectCustomRules(t *testing.T) {
+	repository := t.TempDir()
+	sourcePath := filepath.Join(repository, "main.go")
+	source := "package main\n\nfunc run() {\n\t// TODO: handle errors\n\tpanic(\"boom\")\n}\n\nvar _ = fmt.Println\n"
+	if err := os.WriteFile(sourcePath, []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Paths are stored relative to the repository root and resolved against it for reading
+	result := &models.AnalysisResult{Repository: repository}
+	files := []models.FileAnalysis{{
+		Path:      "main.go",
+		Functions: []models.FunctionAnalysis{{Name: "run", StartLine: 3, EndLine: 6}},
+	}}
+	rules := []config.CustomRule{
+		{Name: "No panics", Pattern: `\bpanic\(`, Severity: "critical", Message: "Return errors instead."},
+		{Name: "Open TODOs", Pattern: `TODO`},
+		{Name: "Never matches", Pattern: `os\.Exit`},
+	}
+
+	concerns := detectCustomRules(files, rules, result.ResolvePath)
+
+	if len(concerns) != 2 {
+		t.Fatalf("Expected 2 concerns, got %d", len(concerns))
+	}
+
+	panicConcern := concerns[0]
+	if panicConcern.Type != "custom_rule" || panicConcern.Title != "No panics" || panicConcern.Severity != "critical" {
+		t.Errorf("Unexpected panic concern: %+v", panicConcern)
+	}
+	if len(panicConcern.AffectedItems) != 1 {
+		t.Fatalf("Expected 1 panic match, got %d", len(panicConcern.AffectedItems))
+	}
+	if item := panicConcern.AffectedItems[0]; item.Line != 5 || item.FunctionName != "run" || item.FilePath != "main.go" {
+		t.Errorf("Unexpected panic match: %+v", item)
+	}
+	if !strings.HasPrefix(panicConcern.Description, "Return errors instead.") {
+		t.Errorf("Description should start with the rule message, got %q", panicConcern.Description)
+	}
+
+	todoConcern := concerns[1]
+	if todoConcern.Severity != config.DefaultCustomRuleSeverity {
+		t.Errorf("Expected default severity %q, got %q", config.DefaultCustomRuleSeverity, todoConcern.Severity)
+	}
+	if todoConcern.AffectedItems[0].Line != 4 {
+		t.Errorf("Expected TODO on line 4, got %d", todoConcern.AffectedItems[0].Line)
+	}
+}
+
+func TestDetectCustomRulesSkipsInvalidPatternsAndMissingFiles(t *testing.T) {
+	files := []models.FileAnalysis{{Path: filepath.Join(t.TempDir(), "missing.go")}}
+	rules := []config.CustomRule{
+		{Name: "Broken", Pattern: `(`},
+		{Name: "Anything", Pattern: `.`},
+	}
+
+	result := &models.AnalysisResult{}
+	if concerns := detectCustomRules(files, rules, result.ResolvePath); len(concerns) != 0 {
+		t.Errorf("Expected no concerns, got %d", len(concerns))
+	}
+}
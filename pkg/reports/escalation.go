@@ -0,0 +1,150 @@
+package reports
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/alexcollie/kaizen/pkg/storage"
+)
+
+// DefaultTrendSnapshots is how many stored snapshots a warning must have worsened across
+// before trend-aware severity escalates it
+const DefaultTrendSnapshots = 3
+
+// trendMetric ties a concern type to the per-function metric stored in function history
+type trendMetric struct {
+	itemMetric   string // Key of the metric in AffectedItem.Metrics
+	value        func(record storage.FunctionHistoryRecord) float64
+	lowerIsWorse bool
+	describe     func(items []models.AffectedItem, thresholds config.ThresholdConfig) string
+}
+
+// trendMetrics lists the concern types whose warnings can be escalated. Other types have no
+// matching column in function history (e.g. nesting depth) or are never warnings.
+var trendMetrics = map[string]trendMetric{
+	"low_maintainability": {
+		itemMetric:   "maintainability_index",
+		value:        func(record storage.FunctionHistoryRecord) float64 { return record.MaintainabilityIndex },
+		lowerIsWorse: true,
+		describe: func(items []models.AffectedItem, thresholds config.ThresholdConfig) string {
+			return buildMaintainabilityDescription(items, thresholds.MaintainabilityIndex.Warning)
+		},
+	},
+	"high_churn_long_function": {
+		itemMetric: "length",
+		value:      func(record storage.FunctionHistoryRecord) float64 { return float64(record.Length) },
+		describe: func(items []models.AffectedItem, _ config.ThresholdConfig) string {
+			return buildChurnLengthDescription(items, "warning")
+		},
+	},
+	"undocumented_complexity": {
+		itemMetric: "complexity",
+		value:      func(record storage.FunctionHistoryRecord) float64 { return float64(record.CyclomaticComplexity) },
+		describe: func(items []models.AffectedItem, _ config.ThresholdConfig) string {
+			return buildUndocumentedComplexityDescription(items, "warning")
+		},
+	},
+}
+
+// EscalateWorseningConcerns raises warning-level functions to critical when their metric
+// regressed across the most recent stored snapshots (snapshots of them) up to the current
+// analysis: values may hold steady between snapshots but never improve, and the current value
+// is worse than the oldest one in the window. Escalated functions move out of their warning
+// concern into a critical concern of the same type with EscalatedFrom set; a warning left without
+// functions is dropped. records is the function history, oldest snapshot first, and must not yet
+// include the current analysis.
+func EscalateWorseningConcerns(concerns []models.Concern, records []storage.FunctionHistoryRecord, snapshots int, thresholds config.ThresholdConfig) []models.Concern {
+	if snapshots < 1 || len(records) == 0 {
+		return concerns
+	}
+
+	historyByFunction := groupFunctionHistory(records)
+	escalated := make([]models.Concern, 0, len(concerns))
+
+	for _, concern := range concerns {
+		metric, tracked := trendMetrics[concern.Type]
+		if concern.Severity != "warning" || !tracked {
+			escalated = append(escalated, concern)
+			continue
+		}
+
+		var worsening, remaining []models.AffectedItem
+		var trails []string
+		for _, item := range concern.AffectedItems {
+			key := recurringKey{filePath: item.FilePath, functionName: item.FunctionName, overload: item.Overload}
+			values, isWorsening := worseningValues(historyByFunction[key], item, metric, snapshots)
+			if !isWorsening {
+				remaining = append(remaining, item)
+				continue
+			}
+			worsening = append(worsening, item)
+			trails = append(trails, fmt.Sprintf("%s %s", item.FunctionName, formatTrendValues(values)))
+		}
+
+		if len(worsening) == 0 {
+			escalated = append(escalated, concern)
+			continue
+		}
+
+		escalated = append(escalated, models.Concern{
+			Type:          concern.Type,
+			Severity:      "critical",
+			EscalatedFrom: concern.Severity,
+			Title:         concern.Title + " (Worsening)",
+			Description: fmt.Sprintf(
+				"Escalated from warning because %s has been getting worse over the last %d snapshots: %s. A warning that keeps growing gets harder to fix the longer it is left, so treat it as critical now.",
+				strings.ReplaceAll(metric.itemMetric, "_", " "), snapshots, strings.Join(trails, "; "),
+			),
+			AffectedItems: worsening,
+		})
+
+		if len(remaining) > 0 {
+			concern.AffectedItems = remaining
+			concern.Description = metric.describe(remaining, thresholds)
+			escalated = append(escalated, concern)
+		}
+	}
+
+	sortConcernsBySeverity(escalated)
+	return escalated
+}
+
+// worseningValues returns item's metric in the most recent stored snapshots followed by its
+// current value, and whether that sequence never improves and ends worse than it started
+func worseningValues(history []storage.FunctionHistoryRecord, item models.AffectedItem, metric trendMetric, snapshots int) ([]float64, bool) {
+	current, hasCurrent := item.Metrics[metric.itemMetric]
+	if !hasCurrent || len(history) < snapshots {
+		return nil, false
+	}
+
+	values := make([]float64, 0, snapshots+1)
+	for _, record := range history[len(history)-snapshots:] {
+		values = append(values, metric.value(record))
+	}
+	values = append(values, current)
+
+	worse := func(later, earlier float64) bool {
+		if metric.lowerIsWorse {
+			return later < earlier
+		}
+		return later > earlier
+	}
+
+	for index := 1; index < len(values); index++ {
+		if worse(values[index-1], values[index]) {
+			return nil, false // Improved between two snapshots
+		}
+	}
+	return values, worse(current, values[0])
+}
+
+// formatTrendValues renders values oldest first, e.g. "12 → 14 → 17"
+func formatTrendValues(values []float64) string {
+	formatted := make([]string, len(values))
+	for index, value := range values {
+		formatted[index] = fmt.Sprintf("%.0f", value)
+	}
+	return strings.Join(formatted, " → ")
+}
@@ -0,0 +1,111 @@
+package reports
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/alexcollie/kaizen/pkg/storage"
+)
+
+func complexityItem(filePath, functionName string, complexity float64) models.AffectedItem {
+	return models.AffectedItem{
+		FilePath:     filePath,
+		FunctionName: functionName,
+		Metrics:      map[string]float64{"complexity": complexity},
+	}
+}
+
+func TestEscalateWorseningConcerns(t *testing.T) {
+	thresholds := config.DefaultConfig().Thresholds
+
+	var records []storage.FunctionHistoryRecord
+	records = append(records, complexityHistory("api/handler.go", "Handle", 20, 11, 12, 12)...)
+	records = append(records, complexityHistory("api/router.go", "Route", 11, 14, 12)...)
+	records = append(records, complexityHistory("api/flat.go", "Flat", 12, 12, 12)...)
+
+	concerns := []models.Concern{
+		{Type: "deep_nesting", Severity: "info", Title: "Deep Nesting"},
+		{
+			Type:     "undocumented_complexity",
+			Severity: "warning",
+			Title:    "Very Complex Functions Without Documentation",
+			AffectedItems: []models.AffectedItem{
+				complexityItem("api/handler.go", "Handle", 16), // 11 → 12 → 12 → 16
+				complexityItem("api/router.go", "Route", 16),   // Improved from 14 to 12
+				complexityItem("api/flat.go", "Flat", 12),      // Never got worse
+			},
+		},
+	}
+
+	escalated := EscalateWorseningConcerns(concerns, records, 3, thresholds)
+
+	if len(escalated) != 3 {
+		t.Fatalf("Expected 3 concerns, got %d: %+v", len(escalated), escalated)
+	}
+
+	critical := escalated[0]
+	if critical.Severity != "critical" || critical.EscalatedFrom != "warning" {
+		t.Errorf("Expected an escalated critical concern first, got %s from %q", critical.Severity, critical.EscalatedFrom)
+	}
+	if critical.Type != "undocumented_complexity" || !strings.HasSuffix(critical.Title, "(Worsening)") {
+		t.Errorf("Expected the escalated concern to keep its type, got %s %q", critical.Type, critical.Title)
+	}
+	if len(critical.AffectedItems) != 1 || critical.AffectedItems[0].FunctionName != "Handle" {
+		t.Errorf("Expected only Handle to be escalated, got %+v", critical.AffectedItems)
+	}
+	if !strings.Contains(critical.Description, "Handle 11 → 12 → 12 → 16") {
+		t.Errorf("Expected the description to show Handle's trend, got %q", critical.Description)
+	}
+
+	warning := escalated[1]
+	if warning.Severity != "warning" || len(warning.AffectedItems) != 2 {
+		t.Errorf("Expected Route and Flat to stay warnings, got %s with %+v", warning.Severity, warning.AffectedItems)
+	}
+	if !strings.HasPrefix(warning.Description, "2 function(s)") {
+		t.Errorf("Expected the warning description to be rebuilt for its remaining items, got %q", warning.Description)
+	}
+}
+
+func TestEscalateWorseningConcernsLowerIsWorse(t *testing.T) {
+	thresholds := config.DefaultConfig().Thresholds
+
+	records := complexityHistory("api/handler.go", "Handle", 10, 10)
+	for index, maintainability := range []float64{18, 15} {
+		records[index].MaintainabilityIndex = maintainability
+	}
+
+	concerns := []models.Concern{{
+		Type:     "low_maintainability",
+		Severity: "warning",
+		AffectedItems: []models.AffectedItem{{
+			FilePath:     "api/handler.go",
+			FunctionName: "Handle",
+			Metrics:      map[string]float64{"maintainability_index": 12},
+		}},
+	}}
+
+	escalated := EscalateWorseningConcerns(concerns, records, 2, thresholds)
+
+	if len(escalated) != 1 || escalated[0].Severity != "critical" {
+		t.Errorf("Expected the falling maintainability index to escalate the warning, got %+v", escalated)
+	}
+}
+
+func TestEscalateWorseningConcernsNeedsEnoughHistory(t *testing.T) {
+	thresholds := config.DefaultConfig().Thresholds
+	records := complexityHistory("api/handler.go", "Handle", 11, 12)
+
+	concerns := []models.Concern{{
+		Type:          "undocumented_complexity",
+		Severity:      "warning",
+		AffectedItems: []models.AffectedItem{complexityItem("api/handler.go", "Handle", 16)},
+	}}
+
+	escalated := EscalateWorseningConcerns(concerns, records, 3, thresholds)
+
+	if len(escalated) != 1 || escalated[0].Severity != "warning" {
+		t.Errorf("Expected no escalation with fewer snapshots than the window, got %+v", escalated)
+	}
+}
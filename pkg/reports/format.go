@@ -0,0 +1,18 @@
+package reports
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// FormatScore formats a 0-100 score with the given number of decimal places
+// (reports.precision), e.g. 78, 78.4 or 78.42
+func FormatScore(score float64, precision int) string {
+	return strconv.FormatFloat(score, 'f', precision, 64)
+}
+
+// FormatScoreChange formats a change in score with its sign and the given number of decimal
+// places, e.g. +2.3 or -0.5
+func FormatScoreChange(change float64, precision int) string {
+	return fmt.Sprintf("%+.*f", precision, change)
+}
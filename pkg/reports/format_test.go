@@ -0,0 +1,30 @@
+package reports
+
+import (
+	"testing"
+)
+
+func TestFormatScore(t *testing.T) {
+	tests := []struct {
+		precision      int
+		expectedScore  string
+		expectedChange string
+	}{
+		{0, "78", "+2"},
+		{1, "78.4", "+2.3"},
+		{2, "78.42", "+2.26"},
+	}
+
+	for _, test := range tests {
+		if got := FormatScore(78.4217, test.precision); got != test.expectedScore {
+			t.Errorf("precision %d: FormatScore = %q, want %q", test.precision, got, test.expectedScore)
+		}
+		if got := FormatScoreChange(2.26, test.precision); got != test.expectedChange {
+			t.Errorf("precision %d: FormatScoreChange = %q, want %q", test.precision, got, test.expectedChange)
+		}
+	}
+
+	if got := FormatScoreChange(-0.54, 1); got != "-0.5" {
+		t.Errorf("FormatScoreChange(-0.54, 1) = %q, want %q", got, "-0.5")
+	}
+}
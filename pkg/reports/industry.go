@@ -0,0 +1,148 @@
+package reports
+
+import (
+	"sort"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+// IndustryMinFunctions is the fewest functions a language needs before its averages are
+// compared; smaller samples swing too much to say anything about the codebase
+const IndustryMinFunctions = 20
+
+// Industry comparison verdicts
+const (
+	IndustryBetter  = "better"
+	IndustryTypical = "typical"
+	IndustryWorse   = "worse"
+)
+
+// IndustryRange is the typical band of a per-function average across open-source projects.
+// Every compared metric is lower-is-better, so values below Low are better than typical.
+type IndustryRange struct {
+	Low  float64
+	High float64
+}
+
+// IndustryComparison compares one language's average for one metric against its reference range
+type IndustryComparison struct {
+	Language      string
+	Metric        string // Display name, e.g. "Cyclomatic complexity"
+	FunctionCount int
+	Average       float64
+	Reference     IndustryRange
+	Verdict       string // IndustryBetter, IndustryTypical, or IndustryWorse
+}
+
+// industryMetric extracts a per-function value for comparison
+type industryMetric struct {
+	name  string
+	value func(function models.FunctionAnalysis) float64
+}
+
+// industryMetrics lists the compared metrics in display order
+var industryMetrics = []industryMetric{
+	{"Cyclomatic complexity", func(function models.FunctionAnalysis) float64 { return float64(function.CyclomaticComplexity) }},
+	{"Cognitive complexity", func(function models.FunctionAnalysis) float64 { return float64(function.CognitiveComplexity) }},
+	{"Function length", func(function models.FunctionAnalysis) float64 { return float64(function.Length) }},
+	{"Parameter count", func(function models.FunctionAnalysis) float64 { return float64(function.ParameterCount) }},
+}
+
+// industryReferences holds approximate typical ranges of per-project function averages, keyed
+// by language name and metric name. They are rough guides for context rather than a measured
+// benchmark, and ship with the binary so no network call is made.
+var industryReferences = map[string]map[string]IndustryRange{
+	"Go": {
+		"Cyclomatic complexity": {Low: 2.0, High: 4.0},
+		"Cognitive complexity":  {Low: 1.5, High: 4.5},
+		"Function length":       {Low: 8, High: 20},
+		"Parameter count":       {Low: 1.2, High: 2.2},
+	},
+	"Python": {
+		"Cyclomatic complexity": {Low: 2.0, High: 4.0},
+		"Cognitive complexity":  {Low: 2.0, High: 5.0},
+		"Function length":       {Low: 8, High: 20},
+		"Parameter count":       {Low: 1.5, High: 2.8},
+	},
+	"Kotlin": {
+		"Cyclomatic complexity": {Low: 1.5, High: 3.0},
+		"Cognitive complexity":  {Low: 1.0, High: 3.5},
+		"Function length":       {Low: 5, High: 15},
+		"Parameter count":       {Low: 1.0, High: 2.2},
+	},
+	"Swift": {
+		"Cyclomatic complexity": {Low: 1.5, High: 3.5},
+		"Cognitive complexity":  {Low: 1.0, High: 4.0},
+		"Function length":       {Low: 6, High: 18},
+		"Parameter count":       {Low: 0.8, High: 2.0},
+	},
+	"Objective-C": {
+		"Cyclomatic complexity": {Low: 2.0, High: 4.5},
+		"Cognitive complexity":  {Low: 2.0, High: 5.5},
+		"Function length":       {Low: 10, High: 25},
+		"Parameter count":       {Low: 0.8, High: 2.0},
+	},
+	"Lua": {
+		"Cyclomatic complexity": {Low: 2.0, High: 5.0},
+		"Cognitive complexity":  {Low: 2.0, High: 6.0},
+		"Function length":       {Low: 10, High: 25},
+		"Parameter count":       {Low: 1.0, High: 2.5},
+	},
+}
+
+// CompareToIndustry averages each metric per language and places it against the built-in
+// reference range. Languages without reference data or with fewer than IndustryMinFunctions
+// functions are left out. Results are sorted by language, then in industryMetrics order.
+func CompareToIndustry(result *models.AnalysisResult) []IndustryComparison {
+	functionsByLanguage := make(map[string][]models.FunctionAnalysis)
+	for _, file := range result.Files {
+		functionsByLanguage[file.Language] = append(functionsByLanguage[file.Language], file.Functions...)
+	}
+
+	languageNames := make([]string, 0, len(functionsByLanguage))
+	for language := range functionsByLanguage {
+		languageNames = append(languageNames, language)
+	}
+	sort.Strings(languageNames)
+
+	var comparisons []IndustryComparison
+	for _, language := range languageNames {
+		references, hasReferences := industryReferences[language]
+		functions := functionsByLanguage[language]
+		if !hasReferences || len(functions) < IndustryMinFunctions {
+			continue
+		}
+
+		for _, metric := range industryMetrics {
+			var total float64
+			for _, function := range functions {
+				total += metric.value(function)
+			}
+			average := total / float64(len(functions))
+			reference := references[metric.name]
+
+			comparisons = append(comparisons, IndustryComparison{
+				Language:      language,
+				Metric:        metric.name,
+				FunctionCount: len(functions),
+				Average:       average,
+				Reference:     reference,
+				Verdict:       industryVerdict(average, reference),
+			})
+		}
+	}
+
+	return comparisons
+}
+
+// industryVerdict places a lower-is-better average against its reference range
+func industryVerdict(average float64, reference IndustryRange) string {
+	switch {
+	case average < reference.Low:
+		return IndustryBetter
+	case average > reference.High:
+		return IndustryWorse
+	default:
+		return IndustryTypical
+	}
+}
@@ -0,0 +1,102 @@
+package reports
+
+import (
+	"testing"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+// functionsWithComplexity returns count functions that all have the given complexity and length
+func functionsWithComplexity(count, complexity, length int) []models.FunctionAnalysis {
+	functions := make([]models.FunctionAnalysis, count)
+	for index := range functions {
+		functions[index] = models.FunctionAnalysis{
+			CyclomaticComplexity: complexity,
+			CognitiveComplexity:  complexity,
+			Length:               length,
+			ParameterCount:       2,
+		}
+	}
+	return functions
+}
+
+func TestCompareToIndustry(t *testing.T) {
+	result := &models.AnalysisResult{
+		Files: []models.FileAnalysis{
+			{Path: "a.go", Language: "Go", Functions: functionsWithComplexity(15, 8, 60)},
+			{Path: "b.go", Language: "Go", Functions: functionsWithComplexity(15, 8, 60)},
+			{Path: "small.py", Language: "Python", Functions: functionsWithComplexity(5, 1, 5)},
+		},
+	}
+
+	comparisons := CompareToIndustry(result)
+
+	if len(comparisons) != len(industryMetrics) {
+		t.Fatalf("Expected one comparison per metric for Go only, got %d: %+v", len(comparisons), comparisons)
+	}
+
+	verdicts := make(map[string]string)
+	for _, comparison := range comparisons {
+		if comparison.Language != "Go" || comparison.FunctionCount != 30 {
+			t.Errorf("Expected Go with 30 functions, got %s with %d", comparison.Language, comparison.FunctionCount)
+		}
+		verdicts[comparison.Metric] = comparison.Verdict
+	}
+
+	if verdicts["Cyclomatic complexity"] != IndustryWorse {
+		t.Errorf("Average CC of 8 should be worse than typical for Go, got %s", verdicts["Cyclomatic complexity"])
+	}
+	if verdicts["Function length"] != IndustryWorse {
+		t.Errorf("Average length of 60 should be worse than typical for Go, got %s", verdicts["Function length"])
+	}
+	if verdicts["Parameter count"] != IndustryTypical {
+		t.Errorf("Two parameters should be typical for Go, got %s", verdicts["Parameter count"])
+	}
+}
+
+func TestCompareToIndustrySkipsLanguagesWithoutReferences(t *testing.T) {
+	result := &models.AnalysisResult{
+		Files: []models.FileAnalysis{
+			{Path: "main.rs", Language: "Rust", Functions: functionsWithComplexity(50, 3, 10)},
+		},
+	}
+
+	if comparisons := CompareToIndustry(result); len(comparisons) != 0 {
+		t.Errorf("Expected no comparisons without reference data, got %+v", comparisons)
+	}
+}
+
+func TestIndustryVerdict(t *testing.T) {
+	reference := IndustryRange{Low: 2, High: 4}
+
+	testCases := []struct {
+		average  float64
+		expected string
+	}{
+		{1.5, IndustryBetter},
+		{2, IndustryTypical},
+		{4, IndustryTypical},
+		{4.1, IndustryWorse},
+	}
+
+	for _, testCase := range testCases {
+		if got := industryVerdict(testCase.average, reference); got != testCase.expected {
+			t.Errorf("industryVerdict(%.1f) = %s, want %s", testCase.average, got, testCase.expected)
+		}
+	}
+}
+
+func TestIndustryReferencesCoverEveryMetric(t *testing.T) {
+	for language, references := range industryReferences {
+		for _, metric := range industryMetrics {
+			reference, exists := references[metric.name]
+			if !exists {
+				t.Errorf("%s has no reference range for %s", language, metric.name)
+				continue
+			}
+			if reference.Low <= 0 || reference.Low >= reference.High {
+				t.Errorf("%s %s range %.1f–%.1f should be positive and increasing", language, metric.name, reference.Low, reference.High)
+			}
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package reports
+
+import (
+	"sort"
+	"time"
+
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/alexcollie/kaizen/pkg/storage"
+)
+
+// RecurringMinFlaggedRuns is how many separate stretches of flagged snapshots make a concern
+// recurring: flagged, fixed, then flagged again
+const RecurringMinFlaggedRuns = 2
+
+// RecurringConcern is a function that crossed a concern threshold, dropped back below it, and
+// crossed it again across the stored snapshots
+type RecurringConcern struct {
+	FilePath     string                  `json:"file_path"`
+	FunctionName string                  `json:"function_name"`
+	Overload     string                  `json:"overload,omitempty"`
+	Metric       string                  `json:"metric"` // e.g. "cyclomatic_complexity"
+	Threshold    float64                 `json:"threshold"`
+	FlaggedRuns  int                     `json:"flagged_runs"` // Separate stretches of flagged snapshots
+	History      []RecurringConcernPoint `json:"history"`      // Oldest snapshot first
+}
+
+// RecurringConcernPoint is a function's value for one metric in one snapshot
+type RecurringConcernPoint struct {
+	SnapshotID int64     `json:"snapshot_id"`
+	AnalyzedAt time.Time `json:"analyzed_at"`
+	Value      float64   `json:"value"`
+	Flagged    bool      `json:"flagged"`
+}
+
+// recurringMetric is a per-function metric checked against its warning threshold
+type recurringMetric struct {
+	name      string
+	value     func(record storage.FunctionHistoryRecord) float64
+	threshold func(thresholds config.ThresholdConfig) float64
+	flagged   func(value, threshold float64) bool
+}
+
+func exceedsThreshold(value, threshold float64) bool { return value > threshold }
+func belowThreshold(value, threshold float64) bool   { return value < threshold }
+
+// recurringMetrics lists the checked metrics in display order
+var recurringMetrics = []recurringMetric{
+	{
+		name:      "cyclomatic_complexity",
+		value:     func(record storage.FunctionHistoryRecord) float64 { return float64(record.CyclomaticComplexity) },
+		threshold: func(thresholds config.ThresholdConfig) float64 { return float64(thresholds.Complexity.Warning) },
+		flagged:   exceedsThreshold,
+	},
+	{
+		name:  "cognitive_complexity",
+		value: func(record storage.FunctionHistoryRecord) float64 { return float64(record.CognitiveComplexity) },
+		threshold: func(thresholds config.ThresholdConfig) float64 {
+			return float64(thresholds.CognitiveComplexity.Warning)
+		},
+		flagged: exceedsThreshold,
+	},
+	{
+		name:      "function_length",
+		value:     func(record storage.FunctionHistoryRecord) float64 { return float64(record.Length) },
+		threshold: func(thresholds config.ThresholdConfig) float64 { return float64(thresholds.FunctionLength.Warning) },
+		flagged:   exceedsThreshold,
+	},
+	{
+		name:  "maintainability_index",
+		value: func(record storage.FunctionHistoryRecord) float64 { return record.MaintainabilityIndex },
+		threshold: func(thresholds config.ThresholdConfig) float64 {
+			return float64(thresholds.MaintainabilityIndex.Warning)
+		},
+		flagged: belowThreshold,
+	},
+}
+
+// recurringKey identifies a function across snapshots by file, name, and overload key
+type recurringKey struct {
+	filePath     string
+	functionName string
+	overload     string
+}
+
+// DetectRecurringConcerns walks each function's recorded history and reports the metrics that
+// went over their warning threshold, back under it, and over it again. Snapshots in which the
+// function is missing are skipped rather than counted as fixes, and overloads are tracked
+// separately by their overload key. Results are sorted by flagged
+// runs, most first, then by file, function, and metric.
+func DetectRecurringConcerns(records []storage.FunctionHistoryRecord, thresholds config.ThresholdConfig) []RecurringConcern {
+	var recurring []RecurringConcern
+	for key, history := range groupFunctionHistory(records) {
+		for _, metric := range recurringMetrics {
+			concern := buildRecurringConcern(key, history, metric, metric.threshold(thresholds))
+			if concern.FlaggedRuns >= RecurringMinFlaggedRuns {
+				recurring = append(recurring, concern)
+			}
+		}
+	}
+
+	sortRecurringConcerns(recurring)
+	return recurring
+}
+
+// groupFunctionHistory splits history records by function, keeping each function's snapshot
+// order. Functions that still share a key within a snapshot, which happens for overloads saved
+// before overload keys were recorded, are ambiguous and left out.
+func groupFunctionHistory(records []storage.FunctionHistoryRecord) map[recurringKey][]storage.FunctionHistoryRecord {
+	recordsByFunction := make(map[recurringKey][]storage.FunctionHistoryRecord)
+	ambiguous := make(map[recurringKey]bool)
+	seenInSnapshot := make(map[recurringKey]map[int64]bool)
+
+	for _, record := range records {
+		key := recurringKey{filePath: record.FilePath, functionName: record.FunctionName, overload: record.Overload}
+		if seenInSnapshot[key] == nil {
+			seenInSnapshot[key] = make(map[int64]bool)
+		}
+		if seenInSnapshot[key][record.SnapshotID] {
+			ambiguous[key] = true
+		}
+		seenInSnapshot[key][record.SnapshotID] = true
+		recordsByFunction[key] = append(recordsByFunction[key], record)
+	}
+
+	for key := range ambiguous {
+		delete(recordsByFunction, key)
+	}
+	return recordsByFunction
+}
+
+// buildRecurringConcern scores one function's history for one metric, counting each
+// unflagged-to-flagged transition (or a flagged first snapshot) as a new run
+func buildRecurringConcern(key recurringKey, history []storage.FunctionHistoryRecord, metric recurringMetric, threshold float64) RecurringConcern {
+	concern := RecurringConcern{
+		FilePath:     key.filePath,
+		FunctionName: key.functionName,
+		Overload:     key.overload,
+		Metric:       metric.name,
+		Threshold:    threshold,
+		History:      make([]RecurringConcernPoint, 0, len(history)),
+	}
+
+	previouslyFlagged := false
+	for _, record := range history {
+		value := metric.value(record)
+		flagged := metric.flagged(value, threshold)
+		if flagged && !previouslyFlagged {
+			concern.FlaggedRuns++
+		}
+		previouslyFlagged = flagged
+
+		concern.History = append(concern.History, RecurringConcernPoint{
+			SnapshotID: record.SnapshotID,
+			AnalyzedAt: record.AnalyzedAt,
+			Value:      value,
+			Flagged:    flagged,
+		})
+	}
+
+	return concern
+}
+
+// sortRecurringConcerns orders by flagged runs (most first), then file, function, and metric order
+func sortRecurringConcerns(recurring []RecurringConcern) {
+	metricOrder := make(map[string]int, len(recurringMetrics))
+	for index, metric := range recurringMetrics {
+		metricOrder[metric.name] = index
+	}
+
+	sort.Slice(recurring, func(first, second int) bool {
+		left, right := recurring[first], recurring[second]
+		if left.FlaggedRuns != right.FlaggedRuns {
+			return left.FlaggedRuns > right.FlaggedRuns
+		}
+		if left.FilePath != right.FilePath {
+			return left.FilePath < right.FilePath
+		}
+		if left.FunctionName != right.FunctionName {
+			return left.FunctionName < right.FunctionName
+		}
+		if left.Overload != right.Overload {
+			return left.Overload < right.Overload
+		}
+		return metricOrder[left.Metric] < metricOrder[right.Metric]
+	})
+}
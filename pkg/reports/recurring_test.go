@@ -0,0 +1,104 @@
+package reports
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/alexcollie/kaizen/pkg/storage"
+)
+
+// complexityHistory returns one record per complexity value, one snapshot apart
+func complexityHistory(filePath, functionName string, complexities ...int) []storage.FunctionHistoryRecord {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	records := make([]storage.FunctionHistoryRecord, len(complexities))
+	for index, complexity := range complexities {
+		records[index] = storage.FunctionHistoryRecord{
+			SnapshotID:           int64(index + 1),
+			AnalyzedAt:           start.AddDate(0, 0, index),
+			FilePath:             filePath,
+			FunctionName:         functionName,
+			Length:               10,
+			CyclomaticComplexity: complexity,
+			CognitiveComplexity:  1,
+			MaintainabilityIndex: 80,
+		}
+	}
+	return records
+}
+
+func TestDetectRecurringConcerns(t *testing.T) {
+	thresholds := config.DefaultConfig().Thresholds
+
+	var records []storage.FunctionHistoryRecord
+	records = append(records, complexityHistory("api/handler.go", "Handle", 12, 8, 14, 9, 15)...)
+	records = append(records, complexityHistory("api/router.go", "Route", 12, 8, 14)...)
+	records = append(records, complexityHistory("api/steady.go", "Steady", 12, 14, 16)...)
+	records = append(records, complexityHistory("api/fixed.go", "Fixed", 12, 8, 6)...)
+
+	recurring := DetectRecurringConcerns(records, thresholds)
+
+	if len(recurring) != 2 {
+		t.Fatalf("Expected 2 recurring concerns, got %d: %+v", len(recurring), recurring)
+	}
+
+	first := recurring[0]
+	if first.FunctionName != "Handle" || first.FlaggedRuns != 3 {
+		t.Errorf("Expected Handle with 3 flagged runs first, got %s with %d", first.FunctionName, first.FlaggedRuns)
+	}
+	if first.Metric != "cyclomatic_complexity" || first.Threshold != 10 {
+		t.Errorf("Expected cyclomatic_complexity over 10, got %s over %.0f", first.Metric, first.Threshold)
+	}
+	if len(first.History) != 5 || first.History[1].Flagged || !first.History[2].Flagged {
+		t.Errorf("Expected history flagged/unflagged per snapshot, got %+v", first.History)
+	}
+
+	if recurring[1].FunctionName != "Route" || recurring[1].FlaggedRuns != 2 {
+		t.Errorf("Expected Route with 2 flagged runs second, got %s with %d", recurring[1].FunctionName, recurring[1].FlaggedRuns)
+	}
+}
+
+func TestDetectRecurringConcernsLowMaintainability(t *testing.T) {
+	thresholds := config.DefaultConfig().Thresholds
+
+	records := complexityHistory("legacy.py", "parse", 1, 1, 1)
+	records[0].MaintainabilityIndex = 30
+	records[2].MaintainabilityIndex = 25
+
+	recurring := DetectRecurringConcerns(records, thresholds)
+
+	if len(recurring) != 1 || recurring[0].Metric != "maintainability_index" {
+		t.Fatalf("Expected a recurring maintainability_index concern, got %+v", recurring)
+	}
+}
+
+func TestDetectRecurringConcernsSkipsAmbiguousNames(t *testing.T) {
+	thresholds := config.DefaultConfig().Thresholds
+
+	records := complexityHistory("shapes.go", "Area", 12, 8, 14)
+	duplicate := records[1]
+	duplicate.CyclomaticComplexity = 3
+	records = append(records, duplicate)
+
+	if recurring := DetectRecurringConcerns(records, thresholds); len(recurring) != 0 {
+		t.Errorf("Expected names repeated within a snapshot to be skipped, got %+v", recurring)
+	}
+}
+
+func TestDetectRecurringConcernsTracksOverloadsSeparately(t *testing.T) {
+	thresholds := config.DefaultConfig().Thresholds
+
+	// Two Kotlin overloads of parse: only the two-parameter one keeps coming back
+	single := complexityHistory("Parser.kt", "parse", 4, 5, 4)
+	double := complexityHistory("Parser.kt", "parse", 12, 8, 14)
+	for index := range single {
+		single[index].Overload = "(1)"
+		double[index].Overload = "(2)"
+	}
+
+	recurring := DetectRecurringConcerns(append(single, double...), thresholds)
+
+	if len(recurring) != 1 || recurring[0].Overload != "(2)" || recurring[0].FlaggedRuns != 2 {
+		t.Fatalf("Expected only the (2) overload to recur, got %+v", recurring)
+	}
+}
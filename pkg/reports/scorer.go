@@ -36,8 +36,11 @@ func WeightsWithoutChurn() ScoreWeights {
 	}
 }
 
-// GenerateScoreReport calculates the overall score report for an analysis result
-func GenerateScoreReport(result *models.AnalysisResult, hasChurnData bool, thresholds config.ThresholdConfig) *models.ScoreReport {
+// GenerateScoreReport calculates the overall score report for an analysis result.
+// With scoring.ChurnWeighted and churn data available, functions in frequently changed
+// files count more towards the complexity, maintainability, size, and structure scores.
+// reportsConfig caps the affected items listed per concern.
+func GenerateScoreReport(result *models.AnalysisResult, hasChurnData bool, thresholds config.ThresholdConfig, scoring config.ScoringConfig, reportsConfig config.ReportsConfig) *models.ScoreReport {
 	// Handle empty codebase
 	if result.Summary.TotalFunctions == 0 {
 		return createEmptyCodebaseReport()
@@ -48,17 +51,20 @@ func GenerateScoreReport(result *models.AnalysisResult, hasChurnData bool, thres
 		weights = WeightsWithoutChurn()
 	}
 
-	componentScores := calculateComponentScores(result, hasChurnData, weights, thresholds)
+	churnWeighted := scoring.ChurnWeighted && hasChurnData
+	componentScores := calculateComponentScores(result, hasChurnData, churnWeighted, weights, thresholds)
 	overallScore := calculateOverallScore(componentScores, weights)
 	overallGrade := CalculateGrade(overallScore)
-	concerns := DetectConcerns(result, hasChurnData, thresholds)
+	concerns := DetectConcerns(result, hasChurnData, thresholds, reportsConfig)
 
 	return &models.ScoreReport{
 		OverallGrade:    overallGrade,
 		OverallScore:    overallScore,
 		ComponentScores: componentScores,
 		Concerns:        concerns,
+		ConcernSummary:  SummarizeConcerns(concerns),
 		HasChurnData:    hasChurnData,
+		ChurnWeighted:   churnWeighted,
 	}
 }
 
@@ -79,13 +85,15 @@ func createEmptyCodebaseReport() *models.ScoreReport {
 			Title:       "No Functions Found",
 			Description: "No functions found to analyze",
 		}},
-		HasChurnData: false,
+		ConcernSummary: models.ConcernSummary{Info: 1},
+		HasChurnData:   false,
 	}
 }
 
 func calculateComponentScores(
 	result *models.AnalysisResult,
 	hasChurnData bool,
+	churnWeighted bool,
 	weights ScoreWeights,
 	thresholds config.ThresholdConfig,
 ) models.ComponentScores {
@@ -95,6 +103,10 @@ func calculateComponentScores(
 	functionSizeScore := calculateFunctionSizeScore(result)
 	codeStructureScore := calculateCodeStructureScore(result, thresholds)
 
+	if churnWeighted {
+		complexityScore, maintainabilityScore, functionSizeScore, codeStructureScore = calculateChurnWeightedScores(result, thresholds)
+	}
+
 	return models.ComponentScores{
 		Complexity: models.CategoryScore{
 			Score:    complexityScore,
@@ -127,9 +139,12 @@ func calculateComponentScores(
 // calculateComplexityScore: 100 - clamp(avgCC * 5, 0, 100)
 // CC of 20 = score of 0
 func calculateComplexityScore(result *models.AnalysisResult) float64 {
-	avgComplexity := result.Summary.AverageCyclomaticComplexity
-	score := 100 - clamp(avgComplexity*5, 0, 100)
-	return score
+	return complexityScoreFromAverage(result.Summary.AverageCyclomaticComplexity)
+}
+
+// complexityScoreFromAverage maps an average cyclomatic complexity to a 0-100 score
+func complexityScoreFromAverage(avgComplexity float64) float64 {
+	return 100 - clamp(avgComplexity*5, 0, 100)
 }
 
 // calculateMaintainabilityScore: already 0-100, higher is better
@@ -175,6 +190,11 @@ func calculateFunctionSizeScore(result *models.AnalysisResult) float64 {
 	longFuncPct := float64(summary.LongFunctionCount) / float64(summary.TotalFunctions)
 	veryLongFuncPct := float64(summary.VeryLongFunctionCount) / float64(summary.TotalFunctions)
 
+	return functionSizeScoreFromShares(longFuncPct, veryLongFuncPct)
+}
+
+// functionSizeScoreFromShares maps the shares of long and very long functions to a 0-100 score
+func functionSizeScoreFromShares(longFuncPct, veryLongFuncPct float64) float64 {
 	score := 100 - (longFuncPct*50 + veryLongFuncPct*50)
 	return clamp(score, 0, 100)
 }
@@ -206,6 +226,11 @@ func calculateCodeStructureScore(result *models.AnalysisResult, thresholds confi
 	highParamPct := float64(highParamCount) / totalFunctions
 	veryHighCCPct := float64(summary.VeryHighComplexityCount) / totalFunctions
 
+	return codeStructureScoreFromShares(highNestingPct, highParamPct, veryHighCCPct)
+}
+
+// codeStructureScoreFromShares maps the shares of deeply nested, parameter-heavy, and very complex functions to a 0-100 score
+func codeStructureScoreFromShares(highNestingPct, highParamPct, veryHighCCPct float64) float64 {
 	score := 100 - (highNestingPct*40 + highParamPct*30 + veryHighCCPct*30)
 	return clamp(score, 0, 100)
 }
@@ -58,7 +58,7 @@ func TestGenerateScoreReportEmptyCodebase(t *testing.T) {
 		},
 	}
 
-	report := GenerateScoreReport(result, false, config.DefaultConfig().Thresholds)
+	report := GenerateScoreReport(result, false, config.DefaultConfig().Thresholds, config.ScoringConfig{}, config.DefaultConfig().Reports)
 
 	if report.OverallGrade != "A" {
 		t.Errorf("Empty codebase should get grade A, got %v", report.OverallGrade)
@@ -72,6 +72,54 @@ func TestGenerateScoreReportEmptyCodebase(t *testing.T) {
 	if report.Concerns[0].Severity != "info" {
 		t.Errorf("Empty codebase concern should be info severity, got %v", report.Concerns[0].Severity)
 	}
+	if report.ConcernSummary.Info != 1 {
+		t.Errorf("Empty codebase concern summary should count one info concern, got %+v", report.ConcernSummary)
+	}
+}
+
+func TestGenerateScoreReportConcernSummary(t *testing.T) {
+	result := &models.AnalysisResult{
+		Summary: models.SummaryMetrics{TotalFunctions: 1},
+		Files: []models.FileAnalysis{
+			{
+				Path: "test.go",
+				Functions: []models.FunctionAnalysis{
+					{Name: "bad", StartLine: 1, Length: 200, CyclomaticComplexity: 40, NestingDepth: 10, ParameterCount: 15},
+				},
+			},
+		},
+	}
+
+	report := GenerateScoreReport(result, false, config.DefaultConfig().Thresholds, config.ScoringConfig{}, config.DefaultConfig().Reports)
+
+	if len(report.Concerns) == 0 {
+		t.Fatal("Expected concerns for a very complex function")
+	}
+	if report.ConcernSummary != SummarizeConcerns(report.Concerns) {
+		t.Errorf("Concern summary %+v does not match concerns", report.ConcernSummary)
+	}
+	total := report.ConcernSummary.Critical + report.ConcernSummary.Warning + report.ConcernSummary.Info
+	if total != len(report.Concerns) {
+		t.Errorf("Expected concern summary to count all %d concerns, got %d", len(report.Concerns), total)
+	}
+}
+
+func TestSummarizeConcerns(t *testing.T) {
+	concerns := []models.Concern{
+		{Type: "a", Severity: "critical"},
+		{Type: "b", Severity: "warning"},
+		{Type: "c", Severity: "warning"},
+		{Type: "d", Severity: "info"},
+	}
+
+	summary := SummarizeConcerns(concerns)
+	expected := models.ConcernSummary{Critical: 1, Warning: 2, Info: 1}
+	if summary != expected {
+		t.Errorf("Expected %+v, got %+v", expected, summary)
+	}
+	if SummarizeConcerns(nil) != (models.ConcernSummary{}) {
+		t.Error("Expected an empty summary without concerns")
+	}
 }
 
 func TestGenerateScoreReportExcellentCode(t *testing.T) {
@@ -93,7 +141,7 @@ func TestGenerateScoreReportExcellentCode(t *testing.T) {
 		},
 	}
 
-	report := GenerateScoreReport(result, false, config.DefaultConfig().Thresholds)
+	report := GenerateScoreReport(result, false, config.DefaultConfig().Thresholds, config.ScoringConfig{}, config.DefaultConfig().Reports)
 
 	if report.OverallGrade != "A" {
 		t.Errorf("Excellent code should get grade A, got %v", report.OverallGrade)
@@ -123,7 +171,7 @@ func TestGenerateScoreReportPoorCode(t *testing.T) {
 		},
 	}
 
-	report := GenerateScoreReport(result, false, config.DefaultConfig().Thresholds)
+	report := GenerateScoreReport(result, false, config.DefaultConfig().Thresholds, config.ScoringConfig{}, config.DefaultConfig().Reports)
 
 	if report.OverallGrade == "A" {
 		t.Error("Poor code should not get grade A")
@@ -154,7 +202,7 @@ func TestGenerateScoreReportWithChurnData(t *testing.T) {
 		},
 	}
 
-	report := GenerateScoreReport(result, true, config.DefaultConfig().Thresholds)
+	report := GenerateScoreReport(result, true, config.DefaultConfig().Thresholds, config.ScoringConfig{}, config.DefaultConfig().Reports)
 
 	if !report.HasChurnData {
 		t.Error("Report should indicate churn data is present")
@@ -183,7 +231,7 @@ func TestGenerateScoreReportWithoutChurnData(t *testing.T) {
 		},
 	}
 
-	report := GenerateScoreReport(result, false, config.DefaultConfig().Thresholds)
+	report := GenerateScoreReport(result, false, config.DefaultConfig().Thresholds, config.ScoringConfig{}, config.DefaultConfig().Reports)
 
 	if report.HasChurnData {
 		t.Error("Report should indicate churn data is not present")
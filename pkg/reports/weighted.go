@@ -0,0 +1,105 @@
+package reports
+
+import (
+	"math"
+
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+// fileChurnWeight is the importance of a file's functions in churn-weighted scoring:
+// 1 + ln(1 + commits), so untouched files count once and hot files count a few times more
+// without a single file dominating the score
+func fileChurnWeight(file models.FileAnalysis) float64 {
+	if file.Churn == nil || file.Churn.TotalCommits <= 0 {
+		return 1
+	}
+	return 1 + math.Log(1+float64(file.Churn.TotalCommits))
+}
+
+// calculateChurnWeightedScores computes the complexity, maintainability, function size, and
+// code structure scores with each function weighted by its file's churn. The formulas match
+// the unweighted scores, with weighted averages and shares in place of plain ones: the
+// averages skip trivial functions and combine files the way the summary's do, and the shares
+// count every function.
+func calculateChurnWeightedScores(result *models.AnalysisResult, thresholds config.ThresholdConfig) (complexity, maintainability, functionSize, codeStructure float64) {
+	var totalWeight float64
+	var averageWeight, weightedComplexity, weightedMaintainability float64
+	var longWeight, veryLongWeight float64
+	var highNestingWeight, highParamWeight, veryHighCCWeight float64
+
+	for _, file := range result.Files {
+		weight := fileChurnWeight(file)
+		var fileComplexity, fileMaintainability float64
+		fileFunctions := 0
+
+		for _, function := range file.Functions {
+			totalWeight += weight
+
+			if function.Length > 50 {
+				longWeight += weight
+			}
+			if function.Length > 100 {
+				veryLongWeight += weight
+			}
+			if function.NestingDepth > thresholds.NestingDepth.Warning {
+				highNestingWeight += weight
+			}
+			if function.ParameterCount > thresholds.ParameterCount.Warning {
+				highParamWeight += weight
+			}
+			if function.CyclomaticComplexity > 20 {
+				veryHighCCWeight += weight
+			}
+
+			if result.TrivialExcludedFromAverages && IsTrivialFunction(function, result.MinFunctionLines) {
+				continue
+			}
+			fileComplexity += float64(function.CyclomaticComplexity)
+			fileMaintainability += function.MaintainabilityIndex
+			fileFunctions++
+		}
+
+		if fileFunctions == 0 {
+			continue
+		}
+
+		// Per-file methods average each file's own mean, weighted by its churn (and its code
+		// lines for weighted_by_loc); per_function weights every function by its file's churn
+		switch result.AverageMethod {
+		case config.AverageMethodPerFile, config.AverageMethodWeightedByLOC:
+			fileWeight := weight
+			if result.AverageMethod == config.AverageMethodWeightedByLOC && file.CodeLines > 0 {
+				fileWeight *= float64(file.CodeLines)
+			}
+			weightedComplexity += fileComplexity / float64(fileFunctions) * fileWeight
+			weightedMaintainability += fileMaintainability / float64(fileFunctions) * fileWeight
+			averageWeight += fileWeight
+		default:
+			weightedComplexity += fileComplexity * weight
+			weightedMaintainability += fileMaintainability * weight
+			averageWeight += float64(fileFunctions) * weight
+		}
+	}
+
+	if totalWeight == 0 {
+		return 100, 100, 100, 100
+	}
+
+	var averageComplexity, averageMaintainability float64
+	if averageWeight > 0 {
+		averageComplexity = weightedComplexity / averageWeight
+		averageMaintainability = weightedMaintainability / averageWeight
+	}
+
+	complexity = complexityScoreFromAverage(averageComplexity)
+	maintainability = clamp(averageMaintainability, 0, 100)
+	functionSize = functionSizeScoreFromShares(longWeight/totalWeight, veryLongWeight/totalWeight)
+	codeStructure = codeStructureScoreFromShares(
+		highNestingWeight/totalWeight,
+		highParamWeight/totalWeight,
+		veryHighCCWeight/totalWeight,
+	)
+
+	return complexity, maintainability, functionSize, codeStructure
+}
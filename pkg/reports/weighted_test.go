@@ -0,0 +1,163 @@
+package reports
+
+import (
+	"math"
+	"testing"
+
+	"github.com/alexcollie/kaizen/internal/config"
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+// churnWeightedFixture has a frequently changed, complex core file and an untouched simple util file
+func churnWeightedFixture(coreCommits, utilCommits int) *models.AnalysisResult {
+	complexFunction := models.FunctionAnalysis{Name: "Dispatch", CyclomaticComplexity: 16, Length: 120, MaintainabilityIndex: 40}
+	simpleFunction := models.FunctionAnalysis{Name: "Trim", CyclomaticComplexity: 2, Length: 8, MaintainabilityIndex: 90}
+
+	core := models.FileAnalysis{Path: "core/dispatch.go", Functions: []models.FunctionAnalysis{complexFunction}}
+	util := models.FileAnalysis{Path: "util/strings.go", Functions: []models.FunctionAnalysis{simpleFunction, simpleFunction, simpleFunction}}
+	if coreCommits > 0 {
+		core.Churn = &models.ChurnMetric{TotalCommits: coreCommits}
+	}
+	if utilCommits > 0 {
+		util.Churn = &models.ChurnMetric{TotalCommits: utilCommits}
+	}
+
+	return &models.AnalysisResult{
+		Files: []models.FileAnalysis{core, util},
+		Summary: models.SummaryMetrics{
+			TotalFunctions:              4,
+			AverageCyclomaticComplexity: 5.5,  // (16 + 3*2) / 4
+			AverageMaintainabilityIndex: 77.5, // (40 + 3*90) / 4
+			LongFunctionCount:           1,
+			VeryLongFunctionCount:       1,
+		},
+	}
+}
+
+func TestChurnWeightedScoreFavorsHotFiles(t *testing.T) {
+	thresholds := config.DefaultConfig().Thresholds
+	result := churnWeightedFixture(40, 0)
+
+	unweighted := GenerateScoreReport(result, true, thresholds, config.ScoringConfig{}, config.DefaultConfig().Reports)
+	weighted := GenerateScoreReport(result, true, thresholds, config.ScoringConfig{ChurnWeighted: true}, config.DefaultConfig().Reports)
+
+	if unweighted.ChurnWeighted {
+		t.Error("Unweighted report should not be marked churn weighted")
+	}
+	if !weighted.ChurnWeighted {
+		t.Error("Weighted report should be marked churn weighted")
+	}
+
+	// The core file weighs 1 + ln(41) ≈ 4.71 against 1 per util function
+	coreWeight := 1 + math.Log(41)
+	expectedComplexity := 100 - (coreWeight*16+3*2)/(coreWeight+3)*5
+	if math.Abs(weighted.ComponentScores.Complexity.Score-expectedComplexity) > 0.001 {
+		t.Errorf("Expected weighted complexity score %.3f, got %.3f", expectedComplexity, weighted.ComponentScores.Complexity.Score)
+	}
+
+	if weighted.ComponentScores.Complexity.Score >= unweighted.ComponentScores.Complexity.Score {
+		t.Errorf("Hot complex file should lower the complexity score: weighted %.1f, unweighted %.1f",
+			weighted.ComponentScores.Complexity.Score, unweighted.ComponentScores.Complexity.Score)
+	}
+	if weighted.ComponentScores.Maintainability.Score >= unweighted.ComponentScores.Maintainability.Score {
+		t.Errorf("Hot unmaintainable file should lower the maintainability score: weighted %.1f, unweighted %.1f",
+			weighted.ComponentScores.Maintainability.Score, unweighted.ComponentScores.Maintainability.Score)
+	}
+	if weighted.OverallScore >= unweighted.OverallScore {
+		t.Errorf("Weighted overall score %.1f should be below unweighted %.1f", weighted.OverallScore, unweighted.OverallScore)
+	}
+
+	// Churn itself is scored the same way in both modes
+	if weighted.ComponentScores.Churn.Score != unweighted.ComponentScores.Churn.Score {
+		t.Errorf("Churn component should not change with weighting")
+	}
+}
+
+func TestChurnWeightedScoreForgivesColdFiles(t *testing.T) {
+	thresholds := config.DefaultConfig().Thresholds
+
+	// Now the simple util file is the hot one and the complex core file is untouched
+	result := churnWeightedFixture(0, 40)
+
+	unweighted := GenerateScoreReport(result, true, thresholds, config.ScoringConfig{}, config.DefaultConfig().Reports)
+	weighted := GenerateScoreReport(result, true, thresholds, config.ScoringConfig{ChurnWeighted: true}, config.DefaultConfig().Reports)
+
+	if weighted.OverallScore <= unweighted.OverallScore {
+		t.Errorf("Weighted overall score %.1f should be above unweighted %.1f", weighted.OverallScore, unweighted.OverallScore)
+	}
+}
+
+func TestChurnWeightedScoreFallsBackWithoutChurnData(t *testing.T) {
+	thresholds := config.DefaultConfig().Thresholds
+	result := churnWeightedFixture(40, 0)
+
+	unweighted := GenerateScoreReport(result, false, thresholds, config.ScoringConfig{}, config.DefaultConfig().Reports)
+	weighted := GenerateScoreReport(result, false, thresholds, config.ScoringConfig{ChurnWeighted: true}, config.DefaultConfig().Reports)
+
+	if weighted.ChurnWeighted {
+		t.Error("Report without churn data should not be marked churn weighted")
+	}
+	if weighted.OverallScore != unweighted.OverallScore {
+		t.Errorf("Without churn data the scores should match: weighted %.3f, unweighted %.3f", weighted.OverallScore, unweighted.OverallScore)
+	}
+}
+
+func TestChurnWeightedScoreUniformChurnMatchesUnweighted(t *testing.T) {
+	thresholds := config.DefaultConfig().Thresholds
+	result := churnWeightedFixture(7, 7)
+
+	unweighted := GenerateScoreReport(result, true, thresholds, config.ScoringConfig{}, config.DefaultConfig().Reports)
+	weighted := GenerateScoreReport(result, true, thresholds, config.ScoringConfig{ChurnWeighted: true}, config.DefaultConfig().Reports)
+
+	if math.Abs(weighted.OverallScore-unweighted.OverallScore) > 0.001 {
+		t.Errorf("Equal churn everywhere should not change the score: weighted %.3f, unweighted %.3f", weighted.OverallScore, unweighted.OverallScore)
+	}
+}
+
+func TestChurnWeightedScoreExcludesTrivialFunctions(t *testing.T) {
+	thresholds := config.DefaultConfig().Thresholds
+	result := churnWeightedFixture(40, 0)
+
+	// The util functions are 8 lines long, so a minimum of 10 marks them trivial
+	result.MinFunctionLines = 10
+	counted := GenerateScoreReport(result, true, thresholds, config.ScoringConfig{ChurnWeighted: true}, config.DefaultConfig().Reports)
+
+	result.TrivialExcludedFromAverages = true
+	excluded := GenerateScoreReport(result, true, thresholds, config.ScoringConfig{ChurnWeighted: true}, config.DefaultConfig().Reports)
+
+	// Only Dispatch is left in the averages: CC 16 and MI 40, whatever its weight
+	if math.Abs(excluded.ComponentScores.Complexity.Score-20) > 0.001 {
+		t.Errorf("Expected complexity score 20 without trivial functions, got %.3f", excluded.ComponentScores.Complexity.Score)
+	}
+	if math.Abs(excluded.ComponentScores.Maintainability.Score-40) > 0.001 {
+		t.Errorf("Expected maintainability score 40 without trivial functions, got %.3f", excluded.ComponentScores.Maintainability.Score)
+	}
+	if excluded.ComponentScores.Complexity.Score >= counted.ComponentScores.Complexity.Score {
+		t.Errorf("Excluding simple trivial functions should lower the complexity score: excluded %.1f, counted %.1f",
+			excluded.ComponentScores.Complexity.Score, counted.ComponentScores.Complexity.Score)
+	}
+
+	// Function size and structure shares count every function, as unweighted scoring does
+	if excluded.ComponentScores.FunctionSize.Score != counted.ComponentScores.FunctionSize.Score {
+		t.Errorf("Function size score should not depend on exclude_trivial_from_averages")
+	}
+}
+
+func TestChurnWeightedScoreFollowsAverageMethod(t *testing.T) {
+	thresholds := config.DefaultConfig().Thresholds
+	result := churnWeightedFixture(7, 7)
+	result.AverageMethod = config.AverageMethodPerFile
+	// Per-file means: core 16 and 40, util 2 and 90
+	result.Summary.AverageCyclomaticComplexity = 9
+	result.Summary.AverageMaintainabilityIndex = 65
+
+	unweighted := GenerateScoreReport(result, true, thresholds, config.ScoringConfig{}, config.DefaultConfig().Reports)
+	weighted := GenerateScoreReport(result, true, thresholds, config.ScoringConfig{ChurnWeighted: true}, config.DefaultConfig().Reports)
+
+	if math.Abs(weighted.ComponentScores.Complexity.Score-55) > 0.001 {
+		t.Errorf("Expected per-file complexity score 55, got %.3f", weighted.ComponentScores.Complexity.Score)
+	}
+	if math.Abs(weighted.OverallScore-unweighted.OverallScore) > 0.001 {
+		t.Errorf("Equal churn everywhere should not change the per-file score: weighted %.3f, unweighted %.3f", weighted.OverallScore, unweighted.OverallScore)
+	}
+}
@@ -0,0 +1,192 @@
+// Package schema generates JSON Schema documents from Go types by following their json
+// struct tags, so a published schema cannot drift from the structs that are marshaled.
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+)
+
+// Draft is the JSON Schema dialect of generated documents
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is one node of a JSON Schema document
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 any                `json:"type,omitempty"` // A type name, or a list of them when null is also allowed
+	Format               string             `json:"format,omitempty"`
+	Const                any                `json:"const,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// AnalysisResult returns the schema of the JSON written by kaizen analyze. Its schema_version
+// property is pinned to models.SchemaVersion, so a validator rejects results of another version.
+func AnalysisResult() *Schema {
+	document := Generate(reflect.TypeOf(models.AnalysisResult{}))
+	document.Title = "Kaizen analysis result"
+	document.Description = "Output of kaizen analyze. schema_version is bumped on every breaking change: " +
+		"a field removed, renamed, or retyped, or its meaning changed. New optional fields do not bump it."
+	document.Properties["schema_version"].Const = models.SchemaVersion
+	return document
+}
+
+// Generate returns a schema document for root, which must be a struct type. Other named
+// structs it reaches are placed under $defs and referenced by type name.
+func Generate(root reflect.Type) *Schema {
+	generator := &generator{
+		names: make(map[reflect.Type]string),
+		defs:  make(map[string]*Schema),
+	}
+	document := generator.structSchema(root)
+	document.Schema = Draft
+	if len(generator.defs) > 0 {
+		document.Defs = generator.defs
+	}
+	return document
+}
+
+// Marshal returns document as indented JSON ending in a newline
+func Marshal(document *Schema) ([]byte, error) {
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// generator collects the $defs of one document
+type generator struct {
+	names map[reflect.Type]string
+	defs  map[string]*Schema
+}
+
+// typeSchema returns the schema of a value of type typ. Slices, maps, and pointers marshal
+// as null when nil, so they allow null unless the field is omitted when empty.
+func (generator *generator) typeSchema(typ reflect.Type, nullable bool) *Schema {
+	switch typ.Kind() {
+	case reflect.Pointer:
+		return orNull(generator.typeSchema(typ.Elem(), false), nullable)
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return orNull(&Schema{Type: "array", Items: generator.typeSchema(typ.Elem(), false)}, nullable && typ.Kind() == reflect.Slice)
+	case reflect.Map:
+		return orNull(&Schema{Type: "object", AdditionalProperties: generator.typeSchema(typ.Elem(), false)}, nullable)
+	case reflect.Struct:
+		if typ == timeType {
+			return &Schema{Type: "string", Format: "date-time"}
+		}
+		return generator.structRef(typ)
+	default:
+		// Interfaces and anything else hold any JSON value
+		return &Schema{}
+	}
+}
+
+// structRef returns a reference to the $defs entry for a struct type, adding the entry the
+// first time the type is seen
+func (generator *generator) structRef(typ reflect.Type) *Schema {
+	if typ.Name() == "" {
+		return generator.structSchema(typ)
+	}
+
+	name, seen := generator.names[typ]
+	if !seen {
+		name = typ.Name()
+		if _, taken := generator.defs[name]; taken {
+			name = strings.ReplaceAll(typ.String(), ".", "_")
+		}
+		generator.names[typ] = name
+		// Registered before its fields are walked, so self-referencing types terminate
+		generator.defs[name] = &Schema{}
+		*generator.defs[name] = *generator.structSchema(typ)
+	}
+	return &Schema{Ref: "#/$defs/" + name}
+}
+
+// structSchema returns the object schema of a struct's exported fields. Fields without
+// omitempty are always written, so they are required.
+func (generator *generator) structSchema(typ reflect.Type) *Schema {
+	object := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	generator.addFields(object, typ)
+	return object
+}
+
+// addFields adds the properties of typ's fields to object, flattening embedded structs the
+// way encoding/json does
+func (generator *generator) addFields(object *Schema, typ reflect.Type) {
+	for index := 0; index < typ.NumField(); index++ {
+		field := typ.Field(index)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				generator.addFields(object, embedded)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		omitEmpty := hasOption(options, "omitempty")
+		object.Properties[name] = generator.typeSchema(field.Type, !omitEmpty)
+		if !omitEmpty {
+			object.Required = append(object.Required, name)
+		}
+	}
+}
+
+// hasOption reports whether a json tag's comma-separated options include option
+func hasOption(options string, option string) bool {
+	for _, candidate := range strings.Split(options, ",") {
+		if candidate == option {
+			return true
+		}
+	}
+	return false
+}
+
+// orNull widens schema to also allow null when nullable is set
+func orNull(schema *Schema, nullable bool) *Schema {
+	if !nullable {
+		return schema
+	}
+	if typeName, ok := schema.Type.(string); ok {
+		schema.Type = []string{typeName, "null"}
+		return schema
+	}
+	return &Schema{AnyOf: []*Schema{schema, {Type: "null"}}}
+}
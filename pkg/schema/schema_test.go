@@ -0,0 +1,81 @@
+package schema
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// publishedSchemaPath is the schema document committed to the repository
+const publishedSchemaPath = "../../schema/analysis-result.schema.json"
+
+type sampleBase struct {
+	ID string `json:"id"`
+}
+
+type sampleChild struct {
+	Parent *sampleChild `json:"parent,omitempty"`
+}
+
+type sample struct {
+	sampleBase
+	Name     string             `json:"name"`
+	Count    int                `json:"count,omitempty"`
+	Ratio    *float64           `json:"ratio"`
+	When     time.Time          `json:"when"`
+	Tags     []string           `json:"tags"`
+	Children []sampleChild      `json:"children,omitempty"`
+	ByName   map[string]float64 `json:"by_name"`
+	Ignored  string             `json:"-"`
+	Untagged bool
+	hidden   string
+}
+
+func TestGenerate(t *testing.T) {
+	document := Generate(reflect.TypeOf(sample{}))
+
+	assert.Equal(t, Draft, document.Schema)
+	assert.Equal(t, "object", document.Type)
+	assert.Equal(t, []string{"id", "name", "ratio", "when", "tags", "by_name", "Untagged"}, document.Required)
+	assert.NotContains(t, document.Properties, "-")
+	assert.NotContains(t, document.Properties, "Ignored")
+	assert.NotContains(t, document.Properties, "hidden")
+
+	assert.Equal(t, "string", document.Properties["id"].Type, "embedded struct fields are flattened")
+	assert.Equal(t, "integer", document.Properties["count"].Type)
+	assert.Equal(t, []string{"number", "null"}, document.Properties["ratio"].Type)
+	assert.Equal(t, &Schema{Type: "string", Format: "date-time"}, document.Properties["when"])
+	assert.Equal(t, []string{"array", "null"}, document.Properties["tags"].Type)
+	assert.Equal(t, "array", document.Properties["children"].Type, "omitempty slices are never null")
+	assert.Equal(t, "#/$defs/sampleChild", document.Properties["children"].Items.Ref)
+	assert.Equal(t, "number", document.Properties["by_name"].AdditionalProperties.Type)
+	assert.Equal(t, "boolean", document.Properties["Untagged"].Type)
+
+	require.Contains(t, document.Defs, "sampleChild")
+	assert.Equal(t, "#/$defs/sampleChild", document.Defs["sampleChild"].Properties["parent"].Ref)
+}
+
+func TestAnalysisResultPinsSchemaVersion(t *testing.T) {
+	document := AnalysisResult()
+
+	assert.Equal(t, models.SchemaVersion, document.Properties["schema_version"].Const)
+	assert.Contains(t, document.Required, "schema_version")
+	assert.Contains(t, document.Defs, "FileAnalysis")
+	assert.Contains(t, document.Defs, "ScoreReport")
+}
+
+func TestPublishedSchemaIsCurrent(t *testing.T) {
+	published, err := os.ReadFile(publishedSchemaPath)
+	require.NoError(t, err)
+
+	generated, err := Marshal(AnalysisResult())
+	require.NoError(t, err)
+
+	assert.Equal(t, string(generated), string(published),
+		"schema/analysis-result.schema.json is stale; regenerate it with: go run ./cmd/kaizen schema --output=schema/analysis-result.schema.json")
+}
@@ -0,0 +1,292 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/alexcollie/kaizen/pkg/languages/golang"
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/alexcollie/kaizen/pkg/ownership"
+	"github.com/alexcollie/kaizen/pkg/storage"
+	"github.com/alexcollie/kaizen/pkg/trending"
+	"github.com/alexcollie/kaizen/pkg/visualization"
+)
+
+// shutdownTimeout bounds how long in-flight requests may run after an interrupt
+const shutdownTimeout = 5 * time.Second
+
+// Options configures the dashboard server
+type Options struct {
+	RootPath        string   // Source tree used for call graph analysis
+	CodeOwnersPath  string   // CODEOWNERS file for the owners report ("" disables it)
+	ExcludePatterns []string // Paths left out of the call graph, as in analysis.exclude
+	Precision       int      // Decimal places scores are shown with, as in reports.precision
+}
+
+// Server renders Kaizen dashboards live from the snapshot database
+type Server struct {
+	backend storage.StorageBackend
+	options Options
+}
+
+// NewServer creates a new dashboard server backed by the given storage
+func NewServer(backend storage.StorageBackend, options Options) *Server {
+	return &Server{
+		backend: backend,
+		options: options,
+	}
+}
+
+// Handler returns the HTTP handler serving all dashboard routes
+func (server *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", server.handleIndex)
+	mux.HandleFunc("/heatmap", server.handleHeatmap)
+	mux.HandleFunc("/trends", server.handleTrends)
+	mux.HandleFunc("/callgraph", server.handleCallGraph)
+	mux.HandleFunc("/owners", server.handleOwners)
+	mux.HandleFunc("/api/snapshots", server.handleSnapshots)
+	return mux
+}
+
+// ListenAndServe serves the dashboard until the context is cancelled, then shuts down gracefully
+func (server *Server) ListenAndServe(ctx context.Context, address string) error {
+	httpServer := &http.Server{
+		Addr:              address,
+		Handler:           server.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return httpServer.Shutdown(shutdownCtx)
+	}
+}
+
+// handleIndex renders the dashboard shell with snapshot and view pickers
+func (server *Server) handleIndex(writer http.ResponseWriter, request *http.Request) {
+	if request.URL.Path != "/" {
+		http.NotFound(writer, request)
+		return
+	}
+
+	snapshots, err := server.backend.ListSnapshots(0)
+	if err != nil {
+		http.Error(writer, fmt.Sprintf("could not list snapshots: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	tmpl := template.Must(template.New("index").Parse(indexTemplate))
+	templateData := map[string]interface{}{
+		"Snapshots":    snapshots,
+		"HasOwners":    server.options.CodeOwnersPath != "",
+		"TrendMetrics": trendMetrics,
+	}
+
+	writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := tmpl.Execute(writer, templateData); err != nil {
+		http.Error(writer, fmt.Sprintf("failed to render dashboard: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// handleHeatmap renders the treemap heat map for a snapshot
+func (server *Server) handleHeatmap(writer http.ResponseWriter, request *http.Request) {
+	result, _, err := server.loadSnapshot(request)
+	if err != nil {
+		writeSnapshotError(writer, err)
+		return
+	}
+
+	html, err := visualization.NewHTMLVisualizer(visualization.SizeByLines, 0, 0, server.options.Precision).GenerateHTML(result, false)
+	if err != nil {
+		http.Error(writer, fmt.Sprintf("failed to generate heat map: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	writeHTML(writer, html)
+}
+
+// handleTrends renders a metric trend chart from the time-series table
+func (server *Server) handleTrends(writer http.ResponseWriter, request *http.Request) {
+	query := request.URL.Query()
+
+	metricName := query.Get("metric")
+	if metricName == "" {
+		metricName = "overall_score"
+	}
+
+	days := 90
+	if daysParam := query.Get("days"); daysParam != "" {
+		parsedDays, err := strconv.Atoi(daysParam)
+		if err != nil || parsedDays < 0 {
+			http.Error(writer, "invalid days parameter", http.StatusBadRequest)
+			return
+		}
+		days = parsedDays
+	}
+
+	endTime := time.Now()
+	startTime := time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
+	if days > 0 {
+		startTime = endTime.AddDate(0, 0, -days)
+	}
+
+	folder := query.Get("folder")
+	points, err := server.backend.GetTimeSeries(metricName, folder, startTime, endTime)
+	if err != nil {
+		http.Error(writer, fmt.Sprintf("could not retrieve metric data: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if len(points) == 0 {
+		http.Error(writer, fmt.Sprintf("no data available for metric '%s'", metricName), http.StatusNotFound)
+		return
+	}
+
+	html, err := trending.RenderHTMLChart([]trending.MetricSeries{{MetricName: metricName, Points: points}}, folder, false)
+	if err != nil {
+		http.Error(writer, fmt.Sprintf("failed to generate chart: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	writeHTML(writer, html)
+}
+
+// handleCallGraph analyzes the source tree and renders the call graph
+func (server *Server) handleCallGraph(writer http.ResponseWriter, request *http.Request) {
+	if server.options.RootPath == "" {
+		http.Error(writer, "call graph requires a source path", http.StatusNotFound)
+		return
+	}
+
+	graph, err := golang.NewCallGraphAnalyzer().AnalyzeDirectory(server.options.RootPath, server.options.ExcludePatterns)
+	if err != nil {
+		http.Error(writer, fmt.Sprintf("could not analyze call graph: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := visualization.RenderCallGraphHTML(graph, writer, ""); err != nil {
+		http.Error(writer, fmt.Sprintf("failed to generate call graph: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// handleOwners renders the code ownership report for a snapshot
+func (server *Server) handleOwners(writer http.ResponseWriter, request *http.Request) {
+	if server.options.CodeOwnersPath == "" {
+		http.Error(writer, "CODEOWNERS file not found", http.StatusNotFound)
+		return
+	}
+
+	result, snapshotID, err := server.loadSnapshot(request)
+	if err != nil {
+		writeSnapshotError(writer, err)
+		return
+	}
+
+	codeowners, err := ownership.ParseCodeOwners(server.options.CodeOwnersPath)
+	if err != nil {
+		http.Error(writer, fmt.Sprintf("could not parse CODEOWNERS: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	aggregator := ownership.NewAggregator(codeowners)
+	report := aggregator.GetOwnerReport(result, snapshotID, result.AnalyzedAt.Format("2006-01-02 15:04:05"))
+
+	html, err := ownership.RenderOwnerReportHTML(report, false, server.options.Precision)
+	if err != nil {
+		http.Error(writer, fmt.Sprintf("failed to generate report: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	writeHTML(writer, html)
+}
+
+// handleSnapshots lists stored snapshots as JSON
+func (server *Server) handleSnapshots(writer http.ResponseWriter, request *http.Request) {
+	limit := 0
+	if limitParam := request.URL.Query().Get("limit"); limitParam != "" {
+		parsedLimit, err := strconv.Atoi(limitParam)
+		if err != nil || parsedLimit < 0 {
+			http.Error(writer, "invalid limit parameter", http.StatusBadRequest)
+			return
+		}
+		limit = parsedLimit
+	}
+
+	snapshots, err := server.backend.ListSnapshots(limit)
+	if err != nil {
+		http.Error(writer, fmt.Sprintf("could not list snapshots: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if snapshots == nil {
+		snapshots = []storage.SnapshotSummary{}
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	_ = encoder.Encode(snapshots)
+}
+
+// errSnapshotNotFound marks lookups that should produce a 404
+var errSnapshotNotFound = errors.New("snapshot not found")
+
+// loadSnapshot resolves the ?snapshot= parameter (latest when absent) to a full result
+func (server *Server) loadSnapshot(request *http.Request) (*models.AnalysisResult, int64, error) {
+	var snapshotID int64
+	if idParam := request.URL.Query().Get("snapshot"); idParam != "" {
+		parsedID, err := strconv.ParseInt(idParam, 10, 64)
+		if err != nil || parsedID < 0 {
+			return nil, 0, fmt.Errorf("%w: invalid snapshot ID %q", errSnapshotNotFound, idParam)
+		}
+		snapshotID = parsedID
+	}
+
+	if snapshotID == 0 {
+		summary, err := server.backend.GetLatestSummary()
+		if err != nil {
+			return nil, 0, fmt.Errorf("%w: %v", errSnapshotNotFound, err)
+		}
+		snapshotID = summary.ID
+	}
+
+	result, err := server.backend.GetByID(snapshotID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("%w: %v", errSnapshotNotFound, err)
+	}
+
+	return result, snapshotID, nil
+}
+
+func writeSnapshotError(writer http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	if errors.Is(err, errSnapshotNotFound) {
+		status = http.StatusNotFound
+	}
+	http.Error(writer, err.Error(), status)
+}
+
+func writeHTML(writer http.ResponseWriter, html string) {
+	writer.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = writer.Write([]byte(html))
+}
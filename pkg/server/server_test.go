@@ -0,0 +1,159 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/alexcollie/kaizen/pkg/storage"
+)
+
+func newTestServer(testingT *testing.T, options Options) (*Server, int64) {
+	tempDir := testingT.TempDir()
+
+	backend, err := storage.NewSQLiteBackend(filepath.Join(tempDir, "test.db"))
+	require.NoError(testingT, err)
+	testingT.Cleanup(func() { _ = backend.Close() })
+
+	result := &models.AnalysisResult{
+		Repository: "test",
+		AnalyzedAt: time.Now(),
+		Files: []models.FileAnalysis{
+			{
+				Path:     "pkg/test.go",
+				Language: "Go",
+				Functions: []models.FunctionAnalysis{
+					{Name: "TestFunc", Length: 20, CyclomaticComplexity: 2, MaintainabilityIndex: 85},
+				},
+			},
+		},
+		FolderStats: map[string]models.FolderMetrics{
+			"pkg": {Path: "pkg", TotalFiles: 1, TotalFunctions: 1, TotalCodeLines: 20},
+		},
+		Summary: models.SummaryMetrics{TotalFiles: 1, TotalFunctions: 1},
+		ScoreReport: &models.ScoreReport{
+			OverallGrade: "A",
+			OverallScore: 92,
+		},
+	}
+
+	snapshotID, err := backend.Save(result, storage.SnapshotMetadata{})
+	require.NoError(testingT, err)
+
+	return NewServer(backend, options), snapshotID
+}
+
+func serveRequest(server *Server, target string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+	return recorder
+}
+
+func TestSnapshotsEndpoint(t *testing.T) {
+	server, snapshotID := newTestServer(t, Options{})
+
+	recorder := serveRequest(server, "/api/snapshots")
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+	var snapshots []storage.SnapshotSummary
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &snapshots))
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, snapshotID, snapshots[0].ID)
+	assert.Equal(t, "A", snapshots[0].OverallGrade)
+}
+
+func TestSnapshotsEndpointInvalidLimit(t *testing.T) {
+	server, _ := newTestServer(t, Options{})
+
+	recorder := serveRequest(server, "/api/snapshots?limit=abc")
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestIndexListsSnapshots(t *testing.T) {
+	server, _ := newTestServer(t, Options{})
+
+	recorder := serveRequest(server, "/")
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Kaizen Dashboard")
+	assert.Contains(t, recorder.Body.String(), `<option value="1">`)
+	assert.NotContains(t, recorder.Body.String(), `<option value="owners">`)
+}
+
+func TestUnknownRouteNotFound(t *testing.T) {
+	server, _ := newTestServer(t, Options{})
+
+	recorder := serveRequest(server, "/does-not-exist")
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
+
+func TestHeatmapLatestAndByID(t *testing.T) {
+	server, snapshotID := newTestServer(t, Options{})
+
+	latest := serveRequest(server, "/heatmap")
+	require.Equal(t, http.StatusOK, latest.Code)
+	assert.Contains(t, latest.Body.String(), "<!DOCTYPE html>")
+
+	byID := serveRequest(server, "/heatmap?snapshot="+strconv.FormatInt(snapshotID, 10))
+	assert.Equal(t, http.StatusOK, byID.Code)
+}
+
+func TestHeatmapMissingSnapshot(t *testing.T) {
+	server, _ := newTestServer(t, Options{})
+
+	assert.Equal(t, http.StatusNotFound, serveRequest(server, "/heatmap?snapshot=999").Code)
+	assert.Equal(t, http.StatusNotFound, serveRequest(server, "/heatmap?snapshot=abc").Code)
+}
+
+func TestTrendsEndpoint(t *testing.T) {
+	server, _ := newTestServer(t, Options{})
+
+	recorder := serveRequest(server, "/trends?metric=overall_score")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "overall_score")
+
+	assert.Equal(t, http.StatusNotFound, serveRequest(server, "/trends?metric=unknown_metric").Code)
+	assert.Equal(t, http.StatusBadRequest, serveRequest(server, "/trends?days=-1").Code)
+}
+
+func TestOwnersEndpoint(t *testing.T) {
+	codeownersPath := filepath.Join(t.TempDir(), "CODEOWNERS")
+	require.NoError(t, os.WriteFile(codeownersPath, []byte("pkg/ @team-core\n"), 0644))
+
+	server, _ := newTestServer(t, Options{CodeOwnersPath: codeownersPath})
+
+	recorder := serveRequest(server, "/owners")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Code Ownership Report")
+}
+
+func TestOwnersEndpointWithoutCodeOwners(t *testing.T) {
+	server, _ := newTestServer(t, Options{})
+
+	assert.Equal(t, http.StatusNotFound, serveRequest(server, "/owners").Code)
+}
+
+func TestCallGraphEndpoint(t *testing.T) {
+	sourceDir := t.TempDir()
+	source := "package sample\n\nfunc Caller() { Callee() }\n\nfunc Callee() {}\n"
+	require.NoError(t, os.WriteFile(filepath.Join(sourceDir, "sample.go"), []byte(source), 0644))
+
+	server, _ := newTestServer(t, Options{RootPath: sourceDir})
+
+	recorder := serveRequest(server, "/callgraph")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "sample.Callee")
+}
@@ -0,0 +1,133 @@
+package server
+
+// trendMetrics lists the repository-level metrics offered in the trend picker
+var trendMetrics = []string{
+	"overall_score",
+	"complexity_score",
+	"maintainability_score",
+	"churn_score",
+	"avg_cyclomatic_complexity",
+	"avg_cognitive_complexity",
+	"avg_maintainability_index",
+	"hotspot_count",
+	"hotspot_density",
+}
+
+const indexTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+    <meta charset="UTF-8">
+    <meta name="viewport" content="width=device-width, initial-scale=1.0">
+    <title>Kaizen Dashboard</title>
+    <style>
+        * {
+            margin: 0;
+            padding: 0;
+            box-sizing: border-box;
+        }
+        html, body {
+            height: 100%;
+        }
+        body {
+            font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, Oxygen, Ubuntu, Cantarell, sans-serif;
+            background: #F5F1E8;
+            display: flex;
+            flex-direction: column;
+        }
+        header {
+            display: flex;
+            align-items: center;
+            gap: 16px;
+            padding: 12px 24px;
+            background: white;
+            box-shadow: 0 2px 8px rgba(45, 45, 42, 0.12);
+        }
+        h1 {
+            color: #C97064;
+            font-size: 20px;
+            font-weight: 700;
+            margin-right: auto;
+        }
+        label {
+            color: #6B6B68;
+            font-size: 13px;
+        }
+        select {
+            margin-left: 6px;
+            padding: 6px 10px;
+            border: 1px solid #D8D2C4;
+            border-radius: 6px;
+            background: #FBF9F4;
+            color: #2D2D2A;
+            font-size: 13px;
+        }
+        .empty {
+            margin: 80px auto;
+            color: #6B6B68;
+            text-align: center;
+        }
+        iframe {
+            flex: 1;
+            width: 100%;
+            border: none;
+        }
+    </style>
+</head>
+<body>
+    <header>
+        <h1>Kaizen Dashboard</h1>
+        <label>View
+            <select id="view">
+                <option value="heatmap">Heat map</option>
+                <option value="trends">Trends</option>
+                <option value="callgraph">Call graph</option>
+                {{if .HasOwners}}<option value="owners">Owners</option>{{end}}
+            </select>
+        </label>
+        <label>Snapshot
+            <select id="snapshot">
+                {{range .Snapshots}}<option value="{{.ID}}">#{{.ID}} · {{.AnalyzedAt.Format "2006-01-02 15:04"}} · {{.OverallGrade}}</option>
+                {{end}}
+            </select>
+        </label>
+        <label>Metric
+            <select id="metric">
+                {{range .TrendMetrics}}<option value="{{.}}">{{.}}</option>
+                {{end}}
+            </select>
+        </label>
+    </header>
+    {{if .Snapshots}}
+    <iframe id="frame" title="Kaizen view"></iframe>
+    {{else}}
+    <p class="empty">No analysis snapshots found. Run <code>kaizen analyze</code> first.</p>
+    {{end}}
+    <script>
+        const view = document.getElementById('view');
+        const snapshot = document.getElementById('snapshot');
+        const metric = document.getElementById('metric');
+        const frame = document.getElementById('frame');
+
+        function refresh() {
+            if (!frame) {
+                return;
+            }
+            const params = new URLSearchParams();
+            if (view.value === 'trends') {
+                params.set('metric', metric.value);
+            } else if (view.value !== 'callgraph' && snapshot.value) {
+                params.set('snapshot', snapshot.value);
+            }
+            metric.parentElement.style.display = view.value === 'trends' ? '' : 'none';
+            snapshot.parentElement.style.display = view.value === 'heatmap' || view.value === 'owners' ? '' : 'none';
+            frame.src = '/' + view.value + '?' + params.toString();
+        }
+
+        view.addEventListener('change', refresh);
+        snapshot.addEventListener('change', refresh);
+        metric.addEventListener('change', refresh);
+        refresh();
+    </script>
+</body>
+</html>
+`
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // BackendConfig specifies storage backend configuration
@@ -11,6 +12,7 @@ type BackendConfig struct {
 	Type           string // "sqlite" or "json"
 	Path           string // Path to database or JSON file
 	KeepJSONBackup bool   // Also save JSON alongside database
+	Repository     string // Limit reads to this repository's snapshots (empty = every repository)
 }
 
 // NewBackend creates a storage backend based on configuration
@@ -19,7 +21,12 @@ type BackendConfig struct {
 func NewBackend(config BackendConfig) (StorageBackend, error) {
 	switch config.Type {
 	case "sqlite", "":
-		return NewSQLiteBackend(config.Path)
+		backend, err := NewSQLiteBackend(config.Path)
+		if err != nil {
+			return nil, err
+		}
+		backend.repository = config.Repository
+		return backend, nil
 	default:
 		return nil, fmt.Errorf("unsupported storage backend: %s", config.Type)
 	}
@@ -44,6 +51,28 @@ func DetectOrCreateDatabase(rootPath string) (string, error) {
 	return filepath.Join(kaizenDir, "kaizen.db"), nil
 }
 
+// PrepareDatabasePath makes an explicitly configured database path absolute, expanding a
+// leading ~ to the home directory and resolving relative paths against rootPath, and creates
+// its parent directory so a shared database can live outside any repository
+func PrepareDatabasePath(path, rootPath string) (string, error) {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to expand %s: %w", path, err)
+		}
+		path = filepath.Join(homeDir, strings.TrimPrefix(path, "~"))
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(rootPath, path)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create database directory: %w", err)
+	}
+
+	return filepath.Clean(path), nil
+}
+
 // DefaultBackendConfig returns the default storage configuration
 func DefaultBackendConfig(rootPath string) (BackendConfig, error) {
 	dbPath, err := DetectOrCreateDatabase(rootPath)
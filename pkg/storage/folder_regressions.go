@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// GetFolderRegressions compares the folder-scoped time series recorded for a snapshot against
+// the snapshot analyzed just before it. Every metric in metricNames is one where higher is
+// worse, such as complexity_score or hotspot_score; a folder is listed for each of them that
+// rose by more than minPercent of its previous value. Folders missing from either snapshot are
+// skipped. Regressions are ordered largest rise first.
+func (backend *SQLiteBackend) GetFolderRegressions(snapshotID int64, metricNames []string, minPercent float64) (int64, []FolderRegression, error) {
+	previousID, err := backend.previousSnapshotID(snapshotID)
+	if err != nil || previousID == 0 {
+		return 0, nil, err
+	}
+
+	previousValues, err := backend.folderMetricValues(previousID, metricNames)
+	if err != nil {
+		return 0, nil, err
+	}
+	currentValues, err := backend.folderMetricValues(snapshotID, metricNames)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	regressions := []FolderRegression{}
+	for key, value := range currentValues {
+		previousValue, exists := previousValues[key]
+		if !exists || value <= previousValue {
+			continue
+		}
+		percentChange := 100.0
+		if previousValue != 0 {
+			percentChange = (value - previousValue) / math.Abs(previousValue) * 100
+		}
+		if percentChange <= minPercent {
+			continue
+		}
+		regressions = append(regressions, FolderRegression{
+			FolderPath:    key.folderPath,
+			MetricName:    key.metricName,
+			PreviousValue: previousValue,
+			Value:         value,
+			PercentChange: percentChange,
+		})
+	}
+
+	sort.Slice(regressions, func(first, second int) bool {
+		if regressions[first].PercentChange != regressions[second].PercentChange {
+			return regressions[first].PercentChange > regressions[second].PercentChange
+		}
+		if regressions[first].FolderPath != regressions[second].FolderPath {
+			return regressions[first].FolderPath < regressions[second].FolderPath
+		}
+		return regressions[first].MetricName < regressions[second].MetricName
+	})
+	return previousID, regressions, nil
+}
+
+// folderMetricKey identifies one folder's metric within a snapshot
+type folderMetricKey struct {
+	folderPath string
+	metricName string
+}
+
+// folderMetricValues loads a snapshot's folder-scoped values for the given metrics
+func (backend *SQLiteBackend) folderMetricValues(snapshotID int64, metricNames []string) (map[folderMetricKey]float64, error) {
+	values := make(map[folderMetricKey]float64)
+	if len(metricNames) == 0 {
+		return values, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(metricNames)), ", ")
+	args := []interface{}{snapshotID, ScopeFolder}
+	for _, metricName := range metricNames {
+		args = append(args, metricName)
+	}
+
+	rows, err := backend.database.Query(`
+		SELECT scope_path, metric_name, value FROM metrics_timeseries
+		WHERE snapshot_id = ? AND scope = ? AND metric_name IN (`+placeholders+`)
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query folder metrics: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var key folderMetricKey
+		var value float64
+		if err := rows.Scan(&key.folderPath, &key.metricName, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan folder metric: %w", err)
+		}
+		values[key] = value
+	}
+	return values, rows.Err()
+}
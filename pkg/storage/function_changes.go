@@ -0,0 +1,104 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+)
+
+// compareFunctions fills comparison's function counts and changes from function_history.
+// Functions are matched by file, name, and overload key, so overloads are compared separately;
+// same-named functions saved before overload keys were recorded are ambiguous and left out.
+func (backend *SQLiteBackend) compareFunctions(comparison *ComparisonResult) error {
+	oldFunctions, err := backend.snapshotFunctionMetrics(comparison.Snapshot1.ID)
+	if err != nil {
+		return err
+	}
+	newFunctions, err := backend.snapshotFunctionMetrics(comparison.Snapshot2.ID)
+	if err != nil {
+		return err
+	}
+
+	for key := range oldFunctions {
+		if _, exists := newFunctions[key]; !exists {
+			comparison.RemovedFunctionCount++
+		}
+	}
+
+	comparison.ChangedFunctions = []FunctionChange{}
+	for key, newRecord := range newFunctions {
+		oldRecord, exists := oldFunctions[key]
+		if !exists {
+			comparison.NewFunctionCount++
+			continue
+		}
+		if oldRecord.CyclomaticComplexity == newRecord.CyclomaticComplexity &&
+			oldRecord.Length == newRecord.Length &&
+			oldRecord.MaintainabilityIndex == newRecord.MaintainabilityIndex {
+			continue
+		}
+		comparison.ChangedFunctions = append(comparison.ChangedFunctions, FunctionChange{
+			FilePath:           key.filePath,
+			FunctionName:       key.functionName,
+			Overload:           key.overload,
+			OldComplexity:      oldRecord.CyclomaticComplexity,
+			NewComplexity:      newRecord.CyclomaticComplexity,
+			OldLength:          oldRecord.Length,
+			NewLength:          newRecord.Length,
+			OldMaintainability: oldRecord.MaintainabilityIndex,
+			NewMaintainability: newRecord.MaintainabilityIndex,
+		})
+	}
+
+	sort.Slice(comparison.ChangedFunctions, func(first, second int) bool {
+		changes := comparison.ChangedFunctions
+		if changes[first].FilePath != changes[second].FilePath {
+			return changes[first].FilePath < changes[second].FilePath
+		}
+		if changes[first].FunctionName != changes[second].FunctionName {
+			return changes[first].FunctionName < changes[second].FunctionName
+		}
+		return changes[first].Overload < changes[second].Overload
+	})
+
+	return nil
+}
+
+// snapshotFunctionMetrics loads the metrics of every unambiguous function in a snapshot
+func (backend *SQLiteBackend) snapshotFunctionMetrics(snapshotID int64) (map[functionKey]FunctionHistoryRecord, error) {
+	rows, err := backend.database.Query(`
+		SELECT
+			file_path, function_name, overload,
+			COALESCE(length, 0),
+			COALESCE(cyclomatic_complexity, 0),
+			COALESCE(maintainability_index, 0)
+		FROM function_history
+		WHERE snapshot_id = ?
+	`, snapshotID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query function history: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	functions := make(map[functionKey]FunctionHistoryRecord)
+	ambiguous := make(map[functionKey]bool)
+
+	for rows.Next() {
+		record := FunctionHistoryRecord{SnapshotID: snapshotID}
+		if err := rows.Scan(&record.FilePath, &record.FunctionName, &record.Overload, &record.Length, &record.CyclomaticComplexity, &record.MaintainabilityIndex); err != nil {
+			return nil, fmt.Errorf("failed to scan function history: %w", err)
+		}
+		key := functionKey{filePath: record.FilePath, functionName: record.FunctionName, overload: record.Overload}
+		if _, exists := functions[key]; exists {
+			ambiguous[key] = true
+		}
+		functions[key] = record
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read function history: %w", err)
+	}
+
+	for key := range ambiguous {
+		delete(functions, key)
+	}
+	return functions, nil
+}
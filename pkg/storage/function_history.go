@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+)
+
+// GetFunctionHistory loads the per-function metrics of every snapshot analyzed at or after
+// since, ordered oldest snapshot first. A zero since returns the full history.
+func (backend *SQLiteBackend) GetFunctionHistory(since time.Time) ([]FunctionHistoryRecord, error) {
+	repositoryCondition, repositoryArgs := backend.repositoryFilter("snapshots.repository")
+	rows, err := backend.database.Query(`
+		SELECT
+			snapshots.id, snapshots.analyzed_at,
+			history.file_path, history.function_name, history.overload,
+			COALESCE(history.length, 0),
+			COALESCE(history.cyclomatic_complexity, 0),
+			COALESCE(history.cognitive_complexity, 0),
+			COALESCE(history.maintainability_index, 0)
+		FROM function_history history
+		JOIN analysis_snapshots snapshots ON snapshots.id = history.snapshot_id
+		WHERE snapshots.analyzed_at >= ? AND `+repositoryCondition+`
+		ORDER BY snapshots.analyzed_at ASC, snapshots.id ASC, history.id ASC
+	`, append([]interface{}{since}, repositoryArgs...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query function history: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var records []FunctionHistoryRecord
+	for rows.Next() {
+		var record FunctionHistoryRecord
+		err := rows.Scan(
+			&record.SnapshotID, &record.AnalyzedAt,
+			&record.FilePath, &record.FunctionName, &record.Overload,
+			&record.Length,
+			&record.CyclomaticComplexity,
+			&record.CognitiveComplexity,
+			&record.MaintainabilityIndex,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan function history: %w", err)
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read function history: %w", err)
+	}
+
+	return records, nil
+}
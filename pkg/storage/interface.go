@@ -31,7 +31,10 @@ type StorageBackend interface {
 	// scopePath: "" for repository level, path for folder/file level
 	GetTimeSeries(metricName, scopePath string, start, end time.Time) ([]TimeSeriesPoint, error)
 
-	// Compare diffs two snapshots
+	// GetScopedTimeSeries retrieves metric history for an explicit scope (ScopeRepository, ScopeFolder, ScopeModule)
+	GetScopedTimeSeries(metricName, scope, scopePath string, start, end time.Time) ([]TimeSeriesPoint, error)
+
+	// Compare diffs two snapshots, including the functions added, removed, and changed between them
 	Compare(id1, id2 int64) (*ComparisonResult, error)
 
 	// ListSnapshots lists all snapshots (most recent first)
@@ -43,6 +46,31 @@ type StorageBackend interface {
 	// DeleteSnapshot removes a specific snapshot
 	DeleteSnapshot(id int64) error
 
+	// TagSnapshot attaches a unique label to a snapshot; tagged snapshots survive Prune
+	TagSnapshot(id int64, tag string) error
+
+	// UntagSnapshot removes a label from whichever snapshot carries it
+	UntagSnapshot(tag string) error
+
+	// AnnotateSnapshot sets a snapshot's note, replacing any earlier one; an empty note clears it
+	AnnotateSnapshot(id int64, note string) error
+
+	// ResolveSnapshotRef resolves a snapshot ID or tag to a snapshot ID
+	ResolveSnapshotRef(ref string) (int64, error)
+
+	// GetSignatureChanges lists public functions whose parameter count changed since the snapshot
+	// before snapshotID, returning that earlier snapshot's ID (0 when there is none)
+	GetSignatureChanges(snapshotID int64) (int64, []SignatureChange, error)
+
+	// GetFolderRegressions lists the folder metrics among metricNames that rose by more than
+	// minPercent since the snapshot before snapshotID, returning that earlier snapshot's ID (0 when
+	// there is none)
+	GetFolderRegressions(snapshotID int64, metricNames []string, minPercent float64) (int64, []FolderRegression, error)
+
+	// GetFunctionHistory loads per-function metrics for snapshots analyzed since the given time,
+	// oldest snapshot first
+	GetFunctionHistory(since time.Time) ([]FunctionHistoryRecord, error)
+
 	// Close closes the storage backend
 	Close() error
 
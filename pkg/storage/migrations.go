@@ -139,10 +139,68 @@ func migrateV1(database *sql.DB) error {
 	return err
 }
 
+// migrateV2 adds snapshot tags, which label snapshots for later reference and exempt them from pruning
+func migrateV2(database *sql.DB) error {
+	schema := `
+	-- snapshot_tags: Human-readable labels such as "baseline" or "release-1.2"
+	CREATE TABLE IF NOT EXISTS snapshot_tags (
+		tag TEXT PRIMARY KEY,
+		snapshot_id INTEGER NOT NULL,
+		created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+		FOREIGN KEY (snapshot_id) REFERENCES analysis_snapshots(id) ON DELETE CASCADE
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_snapshot_tags_snapshot ON snapshot_tags(snapshot_id);
+	`
+
+	_, err := database.Exec(schema)
+	return err
+}
+
+// migrateV3 records each function's parameter count so signature changes can be detected
+// between snapshots. Rows saved before this migration have no count and are never compared.
+func migrateV3(database *sql.DB) error {
+	_, err := database.Exec(`ALTER TABLE function_history ADD COLUMN parameter_count INTEGER`)
+	return err
+}
+
+// migrateV4 adds a free-text note to snapshots, e.g. "after the auth refactor"
+func migrateV4(database *sql.DB) error {
+	_, err := database.Exec(`ALTER TABLE analysis_snapshots ADD COLUMN note TEXT`)
+	return err
+}
+
+// migrateV5 records which repository each snapshot belongs to, so one database can hold the
+// history of several repositories. Snapshots saved before this migration have no repository.
+func migrateV5(database *sql.DB) error {
+	schema := `
+	ALTER TABLE analysis_snapshots ADD COLUMN repository TEXT;
+
+	CREATE INDEX IF NOT EXISTS idx_snapshots_repository ON analysis_snapshots(repository, analyzed_at DESC);
+	`
+
+	_, err := database.Exec(schema)
+	return err
+}
+
+// migrateV6 records the overload key that tells same-named functions in a file apart (see
+// models.FileAnalysis.OverloadKeys), so overloads keep separate histories. Rows saved before
+// this migration have an empty key, and their overloads stay ambiguous.
+func migrateV6(database *sql.DB) error {
+	_, err := database.Exec(`ALTER TABLE function_history ADD COLUMN overload TEXT NOT NULL DEFAULT ''`)
+	return err
+}
+
 // runMigrations applies all pending migrations
 func runMigrations(database *sql.DB) error {
 	migrations := []migration{
 		{version: 1, up: migrateV1},
+		{version: 2, up: migrateV2},
+		{version: 3, up: migrateV3},
+		{version: 4, up: migrateV4},
+		{version: 5, up: migrateV5},
+		{version: 6, up: migrateV6},
 	}
 
 	// Get current schema version
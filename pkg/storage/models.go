@@ -8,26 +8,38 @@ type SnapshotMetadata struct {
 	GitBranch     string
 	KaizenVersion string
 	ConfigHash    string
+	Note          string // Free-text context for the snapshot; empty for none
+	Repository    string // Root of the analyzed repository, keying snapshots in a shared database
 }
 
 // SnapshotSummary provides quick access to snapshot info without loading full data
 type SnapshotSummary struct {
-	ID                     int64
-	AnalyzedAt             time.Time
-	GitCommitHash          string
-	GitBranch              string
-	TotalFiles             int
-	TotalFunctions         int
-	AvgCyclomaticComplexity float64
-	AvgMaintainabilityIndex float64
-	HotspotCount           int
-	OverallGrade           string
-	OverallScore           float64
-	ComplexityScore        float64
-	MaintainabilityScore   float64
-	ChurnScore             float64
+	ID                      int64     `json:"id"`
+	AnalyzedAt              time.Time `json:"analyzed_at"`
+	GitCommitHash           string    `json:"git_commit_hash"`
+	GitBranch               string    `json:"git_branch"`
+	TotalFiles              int       `json:"total_files"`
+	TotalFunctions          int       `json:"total_functions"`
+	AvgCyclomaticComplexity float64   `json:"avg_cyclomatic_complexity"`
+	AvgMaintainabilityIndex float64   `json:"avg_maintainability_index"`
+	HotspotCount            int       `json:"hotspot_count"`
+	OverallGrade            string    `json:"overall_grade"`
+	OverallScore            float64   `json:"overall_score"`
+	ComplexityScore         float64   `json:"complexity_score"`
+	MaintainabilityScore    float64   `json:"maintainability_score"`
+	ChurnScore              float64   `json:"churn_score"`
+	Tags                    []string  `json:"tags,omitempty"`
+	Note                    string    `json:"note,omitempty"`
+	Repository              string    `json:"repository,omitempty"`
 }
 
+// Time-series scopes recorded in metrics_timeseries
+const (
+	ScopeRepository = "repository"
+	ScopeFolder     = "folder"
+	ScopeModule     = "module"
+)
+
 // TimeSeriesPoint represents a single data point in a time series
 type TimeSeriesPoint struct {
 	Timestamp time.Time
@@ -36,24 +48,65 @@ type TimeSeriesPoint struct {
 
 // ComparisonResult represents differences between two snapshots
 type ComparisonResult struct {
-	Snapshot1 SnapshotSummary
-	Snapshot2 SnapshotSummary
-	MetricDeltas map[string]float64
-	NewFunctionCount int
+	Snapshot1            SnapshotSummary
+	Snapshot2            SnapshotSummary
+	MetricDeltas         map[string]float64
+	NewFunctionCount     int
 	RemovedFunctionCount int
-	ChangedFunctions []FunctionChange
+	ChangedFunctions     []FunctionChange
 }
 
 // FunctionChange represents changes to a specific function
 type FunctionChange struct {
-	FilePath string
-	FunctionName string
-	OldComplexity int
-	NewComplexity int
-	OldLength int
-	NewLength int
-	OldMaintainability float64
-	NewMaintainability float64
+	FilePath           string  `json:"file_path"`
+	FunctionName       string  `json:"function_name"`
+	Overload           string  `json:"overload,omitempty"`
+	OldComplexity      int     `json:"old_complexity"`
+	NewComplexity      int     `json:"new_complexity"`
+	OldLength          int     `json:"old_length"`
+	NewLength          int     `json:"new_length"`
+	OldMaintainability float64 `json:"old_maintainability"`
+	NewMaintainability float64 `json:"new_maintainability"`
+}
+
+// ComplexityDelta is the change in cyclomatic complexity; positive means the function got more complex
+func (change FunctionChange) ComplexityDelta() int {
+	return change.NewComplexity - change.OldComplexity
+}
+
+// MaintainabilityDelta is the change in maintainability index; negative means the function got harder to maintain
+func (change FunctionChange) MaintainabilityDelta() float64 {
+	return change.NewMaintainability - change.OldMaintainability
+}
+
+// SignatureChange records a public function whose parameter count differs from the previous snapshot
+type SignatureChange struct {
+	FilePath               string `json:"file_path"`
+	FunctionName           string `json:"function_name"`
+	PreviousParameterCount int    `json:"previous_parameter_count"`
+	ParameterCount         int    `json:"parameter_count"`
+}
+
+// FolderRegression records a folder metric that rose since the previous snapshot
+type FolderRegression struct {
+	FolderPath    string  `json:"folder_path"`
+	MetricName    string  `json:"metric"`
+	PreviousValue float64 `json:"previous_value"`
+	Value         float64 `json:"value"`
+	PercentChange float64 `json:"percent_change"` // Relative to PreviousValue; 100 when PreviousValue is 0
+}
+
+// FunctionHistoryRecord is one function's metrics as recorded in one snapshot
+type FunctionHistoryRecord struct {
+	SnapshotID           int64
+	AnalyzedAt           time.Time
+	FilePath             string
+	FunctionName         string
+	Overload             string // Tells same-named functions in a file apart; "" for unique names
+	Length               int
+	CyclomaticComplexity int
+	CognitiveComplexity  int
+	MaintainabilityIndex float64
 }
 
 // OwnerMetric represents aggregated metrics for a code owner
@@ -0,0 +1,151 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// previousSnapshotID returns the ID of the snapshot analyzed just before snapshotID, or 0 when
+// there is none. The previous snapshot is the same repository's, or one saved before
+// repositories were recorded.
+func (backend *SQLiteBackend) previousSnapshotID(snapshotID int64) (int64, error) {
+	var analyzedAt time.Time
+	var repository sql.NullString
+	err := backend.database.QueryRow(`
+		SELECT analyzed_at, repository FROM analysis_snapshots WHERE id = ?
+	`, snapshotID).Scan(&analyzedAt, &repository)
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("snapshot %d not found", snapshotID)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to query snapshot: %w", err)
+	}
+
+	var previousID int64
+	err = backend.database.QueryRow(`
+		SELECT id FROM analysis_snapshots
+		WHERE (analyzed_at < ? OR (analyzed_at = ? AND id < ?))
+		AND (repository IS ? OR repository IS NULL)
+		ORDER BY analyzed_at DESC, id DESC LIMIT 1
+	`, analyzedAt, analyzedAt, snapshotID, repository).Scan(&previousID)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to query previous snapshot: %w", err)
+	}
+	return previousID, nil
+}
+
+// functionKey identifies a function within a snapshot by file, name, and overload key
+type functionKey struct {
+	filePath     string
+	functionName string
+	overload     string
+}
+
+// GetSignatureChanges compares the parameter counts recorded in function_history for a snapshot
+// against the snapshot analyzed just before it. Functions are matched by file and name; names
+// that occur more than once in a file (overloads, same-named methods) are skipped, since their
+// overload key includes the parameter count, as are functions that are not public and rows saved
+// before parameter counts were recorded.
+func (backend *SQLiteBackend) GetSignatureChanges(snapshotID int64) (int64, []SignatureChange, error) {
+	previousID, err := backend.previousSnapshotID(snapshotID)
+	if err != nil || previousID == 0 {
+		return 0, nil, err
+	}
+
+	previousCounts, err := backend.publicParameterCounts(previousID)
+	if err != nil {
+		return 0, nil, err
+	}
+	currentCounts, err := backend.publicParameterCounts(snapshotID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	changes := []SignatureChange{}
+	for key, parameterCount := range currentCounts {
+		previousCount, exists := previousCounts[key]
+		if !exists || previousCount == parameterCount {
+			continue
+		}
+		changes = append(changes, SignatureChange{
+			FilePath:               key.filePath,
+			FunctionName:           key.functionName,
+			PreviousParameterCount: previousCount,
+			ParameterCount:         parameterCount,
+		})
+	}
+
+	sort.Slice(changes, func(first, second int) bool {
+		if changes[first].FilePath != changes[second].FilePath {
+			return changes[first].FilePath < changes[second].FilePath
+		}
+		return changes[first].FunctionName < changes[second].FunctionName
+	})
+
+	return previousID, changes, nil
+}
+
+// publicParameterCounts loads the parameter count of every unambiguous public function in a snapshot
+func (backend *SQLiteBackend) publicParameterCounts(snapshotID int64) (map[functionKey]int, error) {
+	rows, err := backend.database.Query(`
+		SELECT file_path, function_name, parameter_count FROM function_history
+		WHERE snapshot_id = ? AND parameter_count IS NOT NULL AND overload = ''
+	`, snapshotID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query function history: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	counts := make(map[functionKey]int)
+	ambiguous := make(map[functionKey]bool)
+
+	for rows.Next() {
+		var key functionKey
+		var parameterCount int
+		if err := rows.Scan(&key.filePath, &key.functionName, &parameterCount); err != nil {
+			return nil, fmt.Errorf("failed to scan function history: %w", err)
+		}
+		if !isPublicFunction(key.filePath, key.functionName) {
+			continue
+		}
+		if _, exists := counts[key]; exists {
+			ambiguous[key] = true
+		}
+		counts[key] = parameterCount
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read function history: %w", err)
+	}
+
+	for key := range ambiguous {
+		delete(counts, key)
+	}
+	return counts, nil
+}
+
+// isPublicFunction reports whether a function is part of its file's public API: exported
+// (capitalized) names in Go and names without a leading underscore in Python. Other languages
+// do not record visibility, so all of their functions count as public.
+func isPublicFunction(filePath, functionName string) bool {
+	name := functionName[strings.LastIndex(functionName, ".")+1:]
+	if name == "" {
+		return false
+	}
+
+	switch filepath.Ext(filePath) {
+	case ".go":
+		return unicode.IsUpper([]rune(name)[0])
+	case ".py":
+		return !strings.HasPrefix(name, "_")
+	default:
+		return true
+	}
+}
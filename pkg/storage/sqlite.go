@@ -13,8 +13,9 @@ import (
 
 // SQLiteBackend implements StorageBackend using SQLite
 type SQLiteBackend struct {
-	database *sql.DB
-	path     string
+	database   *sql.DB
+	path       string
+	repository string // Reads are limited to this repository's snapshots unless empty
 }
 
 // NewSQLiteBackend creates or opens a SQLite database at the given path
@@ -45,7 +46,8 @@ func NewSQLiteBackend(path string) (*SQLiteBackend, error) {
 	}, nil
 }
 
-// Save stores a new analysis result
+// Save stores a new analysis result. The snapshot and its metrics and function history are
+// written in one transaction, so a failure part way leaves nothing behind.
 func (backend *SQLiteBackend) Save(result *models.AnalysisResult, metadata SnapshotMetadata) (int64, error) {
 	// Serialize full result as JSON
 	jsonData, err := json.Marshal(result)
@@ -65,16 +67,23 @@ func (backend *SQLiteBackend) Save(result *models.AnalysisResult, metadata Snaps
 		hasChurnData = result.ScoreReport.HasChurnData
 	}
 
+	transaction, err := backend.database.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	// Rolling back after a successful commit does nothing
+	defer func() { _ = transaction.Rollback() }()
+
 	// Insert snapshot
-	execResult, err := backend.database.Exec(`
+	execResult, err := transaction.Exec(`
 		INSERT INTO analysis_snapshots (
 			analyzed_at, git_commit_hash, git_branch, kaizen_version, config_hash,
 			total_files, total_functions, total_lines, total_code_lines,
 			avg_cyclomatic_complexity, avg_cognitive_complexity, avg_function_length,
 			avg_maintainability_index, hotspot_count,
 			overall_grade, overall_score, complexity_score, maintainability_score,
-			churn_score, has_churn_data, full_data
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			churn_score, has_churn_data, full_data, note, repository
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		result.AnalyzedAt,
 		metadata.GitCommitHash,
 		metadata.GitBranch,
@@ -96,6 +105,8 @@ func (backend *SQLiteBackend) Save(result *models.AnalysisResult, metadata Snaps
 		churnScore,
 		hasChurnData,
 		jsonData,
+		nullableString(metadata.Note),
+		nullableString(metadata.Repository),
 	)
 
 	if err != nil {
@@ -108,29 +119,39 @@ func (backend *SQLiteBackend) Save(result *models.AnalysisResult, metadata Snaps
 	}
 
 	// Insert time-series metrics (repository level)
-	err = backend.insertRepositoryMetrics(snapshotID, result)
+	err = insertRepositoryMetrics(transaction, snapshotID, result)
 	if err != nil {
 		return 0, fmt.Errorf("failed to insert repository metrics: %w", err)
 	}
 
 	// Insert folder-level metrics
-	err = backend.insertFolderMetrics(snapshotID, result)
+	err = insertGroupMetrics(transaction, snapshotID, result.AnalyzedAt, ScopeFolder, result.FolderStats)
 	if err != nil {
 		return 0, fmt.Errorf("failed to insert folder metrics: %w", err)
 	}
 
+	// Insert module-level metrics (multi-module repositories only)
+	err = insertGroupMetrics(transaction, snapshotID, result.AnalyzedAt, ScopeModule, result.ModuleStats)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert module metrics: %w", err)
+	}
+
 	// Insert function history
-	err = backend.insertFunctionHistory(snapshotID, result)
+	err = insertFunctionHistory(transaction, snapshotID, result)
 	if err != nil {
 		return 0, fmt.Errorf("failed to insert function history: %w", err)
 	}
 
+	if err := transaction.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit snapshot: %w", err)
+	}
+
 	return snapshotID, nil
 }
 
 // insertRepositoryMetrics inserts repository-level time-series metrics
-func (backend *SQLiteBackend) insertRepositoryMetrics(snapshotID int64, result *models.AnalysisResult) error {
-	stmt, err := backend.database.Prepare(`
+func insertRepositoryMetrics(transaction *sql.Tx, snapshotID int64, result *models.AnalysisResult) error {
+	stmt, err := transaction.Prepare(`
 		INSERT INTO metrics_timeseries (snapshot_id, analyzed_at, metric_name, scope, scope_path, value)
 		VALUES (?, ?, ?, ?, ?, ?)
 	`)
@@ -146,6 +167,8 @@ func (backend *SQLiteBackend) insertRepositoryMetrics(snapshotID int64, result *
 		"avg_function_length":           result.Summary.AverageFunctionLength,
 		"avg_maintainability_index":     result.Summary.AverageMaintainabilityIndex,
 		"hotspot_count":                 float64(result.Summary.HotspotCount),
+		"hotspot_density":               result.Summary.HotspotDensity,
+		"concern_density":               result.Summary.ConcernDensity,
 	}
 
 	// Add score report metrics if available
@@ -157,7 +180,7 @@ func (backend *SQLiteBackend) insertRepositoryMetrics(snapshotID int64, result *
 	}
 
 	for metricName, value := range metrics {
-		_, err := stmt.Exec(snapshotID, result.AnalyzedAt, metricName, "repository", "", value)
+		_, err := stmt.Exec(snapshotID, result.AnalyzedAt, metricName, ScopeRepository, "", value)
 		if err != nil {
 			return err
 		}
@@ -166,9 +189,13 @@ func (backend *SQLiteBackend) insertRepositoryMetrics(snapshotID int64, result *
 	return nil
 }
 
-// insertFolderMetrics inserts folder-level time-series metrics
-func (backend *SQLiteBackend) insertFolderMetrics(snapshotID int64, result *models.AnalysisResult) error {
-	stmt, err := backend.database.Prepare(`
+// insertGroupMetrics inserts folder- or module-level time-series metrics under the given scope
+func insertGroupMetrics(transaction *sql.Tx, snapshotID int64, analyzedAt time.Time, scope string, groups map[string]models.FolderMetrics) error {
+	if len(groups) == 0 {
+		return nil
+	}
+
+	stmt, err := transaction.Prepare(`
 		INSERT INTO metrics_timeseries (snapshot_id, analyzed_at, metric_name, scope, scope_path, value)
 		VALUES (?, ?, ?, ?, ?, ?)
 	`)
@@ -178,18 +205,22 @@ func (backend *SQLiteBackend) insertFolderMetrics(snapshotID int64, result *mode
 	defer func() { _ = stmt.Close() }()
 
 	folderMetricNames := []string{
+		"avg_cyclomatic_complexity",
 		"complexity_score",
 		"churn_score",
 		"maintainability_score",
 		"hotspot_score",
 		"hotspot_count",
+		"hotspot_density",
 	}
 
-	for folderPath, folderMetrics := range result.FolderStats {
+	for folderPath, folderMetrics := range groups {
 		for _, metricName := range folderMetricNames {
 			var value float64
 
 			switch metricName {
+			case "avg_cyclomatic_complexity":
+				value = folderMetrics.AverageComplexity
 			case "complexity_score":
 				value = folderMetrics.ComplexityScore
 			case "churn_score":
@@ -200,9 +231,11 @@ func (backend *SQLiteBackend) insertFolderMetrics(snapshotID int64, result *mode
 				value = folderMetrics.HotspotScore
 			case "hotspot_count":
 				value = float64(folderMetrics.HotspotCount)
+			case "hotspot_density":
+				value = folderMetrics.HotspotDensity
 			}
 
-			_, err := stmt.Exec(snapshotID, result.AnalyzedAt, metricName, "folder", folderPath, value)
+			_, err := stmt.Exec(snapshotID, analyzedAt, metricName, scope, folderPath, value)
 			if err != nil {
 				return err
 			}
@@ -213,13 +246,13 @@ func (backend *SQLiteBackend) insertFolderMetrics(snapshotID int64, result *mode
 }
 
 // insertFunctionHistory inserts function-level historical data
-func (backend *SQLiteBackend) insertFunctionHistory(snapshotID int64, result *models.AnalysisResult) error {
-	stmt, err := backend.database.Prepare(`
+func insertFunctionHistory(transaction *sql.Tx, snapshotID int64, result *models.AnalysisResult) error {
+	stmt, err := transaction.Prepare(`
 		INSERT INTO function_history (
-			snapshot_id, file_path, function_name,
+			snapshot_id, file_path, function_name, overload,
 			length, cyclomatic_complexity, cognitive_complexity,
-			maintainability_index, total_commits, is_hotspot, analyzed_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			maintainability_index, total_commits, is_hotspot, parameter_count, analyzed_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return err
@@ -227,7 +260,8 @@ func (backend *SQLiteBackend) insertFunctionHistory(snapshotID int64, result *mo
 	defer func() { _ = stmt.Close() }()
 
 	for _, fileAnalysis := range result.Files {
-		for _, funcAnalysis := range fileAnalysis.Functions {
+		overloadKeys := fileAnalysis.OverloadKeys()
+		for index, funcAnalysis := range fileAnalysis.Functions {
 			totalCommits := 0
 			if funcAnalysis.Churn != nil {
 				totalCommits = funcAnalysis.Churn.TotalCommits
@@ -237,12 +271,14 @@ func (backend *SQLiteBackend) insertFunctionHistory(snapshotID int64, result *mo
 				snapshotID,
 				fileAnalysis.Path,
 				funcAnalysis.Name,
+				overloadKeys[index],
 				funcAnalysis.Length,
 				funcAnalysis.CyclomaticComplexity,
 				funcAnalysis.CognitiveComplexity,
 				funcAnalysis.MaintainabilityIndex,
 				totalCommits,
 				funcAnalysis.IsHotspot,
+				funcAnalysis.ParameterCount,
 				result.AnalyzedAt,
 			)
 			if err != nil {
@@ -256,11 +292,14 @@ func (backend *SQLiteBackend) insertFunctionHistory(snapshotID int64, result *mo
 
 // GetLatest retrieves the most recent analysis
 func (backend *SQLiteBackend) GetLatest() (*models.AnalysisResult, error) {
+	repositoryCondition, repositoryArgs := backend.repositoryFilter("repository")
+
 	var jsonData string
 	err := backend.database.QueryRow(`
 		SELECT full_data FROM analysis_snapshots
+		WHERE `+repositoryCondition+`
 		ORDER BY analyzed_at DESC LIMIT 1
-	`).Scan(&jsonData)
+	`, repositoryArgs...).Scan(&jsonData)
 
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("no analysis snapshots found")
@@ -314,7 +353,8 @@ func (backend *SQLiteBackend) GetByIDSummary(id int64) (*SnapshotSummary, error)
 			total_files, total_functions,
 			avg_cyclomatic_complexity, avg_maintainability_index,
 			hotspot_count, overall_grade, overall_score,
-			complexity_score, maintainability_score, churn_score
+			complexity_score, maintainability_score, churn_score,
+			COALESCE(note, ''), COALESCE(repository, '')
 		FROM analysis_snapshots
 	`
 
@@ -323,7 +363,9 @@ func (backend *SQLiteBackend) GetByIDSummary(id int64) (*SnapshotSummary, error)
 		query += " WHERE id = ?"
 		args = append(args, id)
 	} else {
-		query += " ORDER BY analyzed_at DESC LIMIT 1"
+		repositoryCondition, repositoryArgs := backend.repositoryFilter("repository")
+		query += " WHERE " + repositoryCondition + " ORDER BY analyzed_at DESC LIMIT 1"
+		args = append(args, repositoryArgs...)
 	}
 
 	summary := &SnapshotSummary{}
@@ -333,6 +375,7 @@ func (backend *SQLiteBackend) GetByIDSummary(id int64) (*SnapshotSummary, error)
 		&summary.AvgCyclomaticComplexity, &summary.AvgMaintainabilityIndex,
 		&summary.HotspotCount, &summary.OverallGrade, &summary.OverallScore,
 		&summary.ComplexityScore, &summary.MaintainabilityScore, &summary.ChurnScore,
+		&summary.Note, &summary.Repository,
 	)
 
 	if err == sql.ErrNoRows {
@@ -345,20 +388,27 @@ func (backend *SQLiteBackend) GetByIDSummary(id int64) (*SnapshotSummary, error)
 		return nil, fmt.Errorf("failed to query snapshot: %w", err)
 	}
 
+	summary.Tags, err = backend.getSnapshotTags(summary.ID)
+	if err != nil {
+		return nil, err
+	}
+
 	return summary, nil
 }
 
 // GetRange retrieves snapshots within a time range
 func (backend *SQLiteBackend) GetRange(start, end time.Time, limit int) ([]SnapshotSummary, error) {
+	repositoryCondition, repositoryArgs := backend.repositoryFilter("repository")
 	query := `
 		SELECT
 			id, analyzed_at, git_commit_hash, git_branch,
 			total_files, total_functions,
 			avg_cyclomatic_complexity, avg_maintainability_index,
 			hotspot_count, overall_grade, overall_score,
-			complexity_score, maintainability_score, churn_score
+			complexity_score, maintainability_score, churn_score,
+			COALESCE(note, ''), COALESCE(repository, '')
 		FROM analysis_snapshots
-		WHERE analyzed_at BETWEEN ? AND ?
+		WHERE analyzed_at BETWEEN ? AND ? AND ` + repositoryCondition + `
 		ORDER BY analyzed_at DESC
 	`
 
@@ -366,7 +416,7 @@ func (backend *SQLiteBackend) GetRange(start, end time.Time, limit int) ([]Snaps
 		query += fmt.Sprintf(" LIMIT %d", limit)
 	}
 
-	rows, err := backend.database.Query(query, start, end)
+	rows, err := backend.database.Query(query, append([]interface{}{start, end}, repositoryArgs...)...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query snapshots: %w", err)
 	}
@@ -381,6 +431,7 @@ func (backend *SQLiteBackend) GetRange(start, end time.Time, limit int) ([]Snaps
 			&summary.AvgCyclomaticComplexity, &summary.AvgMaintainabilityIndex,
 			&summary.HotspotCount, &summary.OverallGrade, &summary.OverallScore,
 			&summary.ComplexityScore, &summary.MaintainabilityScore, &summary.ChurnScore,
+			&summary.Note, &summary.Repository,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan snapshot: %w", err)
@@ -391,24 +442,41 @@ func (backend *SQLiteBackend) GetRange(start, end time.Time, limit int) ([]Snaps
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("error iterating snapshots: %w", err)
 	}
+	_ = rows.Close()
+
+	if err := backend.attachTags(summaries); err != nil {
+		return nil, err
+	}
 
 	return summaries, nil
 }
 
 // GetTimeSeries retrieves metric history for trending
 func (backend *SQLiteBackend) GetTimeSeries(metricName, scopePath string, start, end time.Time) ([]TimeSeriesPoint, error) {
+	if scopePath == "" {
+		return backend.GetScopedTimeSeries(metricName, ScopeRepository, "", start, end)
+	}
+	return backend.GetScopedTimeSeries(metricName, ScopeFolder, scopePath, start, end)
+}
+
+// GetScopedTimeSeries retrieves metric history for a repository, folder, or module scope
+func (backend *SQLiteBackend) GetScopedTimeSeries(metricName, scope, scopePath string, start, end time.Time) ([]TimeSeriesPoint, error) {
 	query := `
 		SELECT analyzed_at, value
 		FROM metrics_timeseries
-		WHERE metric_name = ? AND analyzed_at BETWEEN ? AND ?
+		WHERE metric_name = ? AND scope = ? AND analyzed_at BETWEEN ? AND ?
 	`
-	args := []interface{}{metricName, start, end}
+	args := []interface{}{metricName, scope, start, end}
 
-	if scopePath != "" {
+	if backend.repository != "" {
+		repositoryCondition, repositoryArgs := backend.repositoryFilter("repository")
+		query += " AND snapshot_id IN (SELECT id FROM analysis_snapshots WHERE " + repositoryCondition + ")"
+		args = append(args, repositoryArgs...)
+	}
+
+	if scope != ScopeRepository {
 		query += " AND scope_path = ?"
 		args = append(args, scopePath)
-	} else {
-		query += " AND scope = 'repository'"
 	}
 
 	query += " ORDER BY analyzed_at ASC"
@@ -436,7 +504,8 @@ func (backend *SQLiteBackend) GetTimeSeries(metricName, scopePath string, start,
 	return points, nil
 }
 
-// Compare diffs two snapshots
+// Compare diffs two snapshots: summary metric deltas, plus the functions added, removed, and
+// changed between them
 func (backend *SQLiteBackend) Compare(id1, id2 int64) (*ComparisonResult, error) {
 	snap1, err := backend.GetByIDSummary(id1)
 	if err != nil {
@@ -472,19 +541,26 @@ func (backend *SQLiteBackend) Compare(id1, id2 int64) (*ComparisonResult, error)
 	result.MetricDeltas["total_files"] = float64(snap2.TotalFiles - snap1.TotalFiles)
 	result.MetricDeltas["total_functions"] = float64(snap2.TotalFunctions - snap1.TotalFunctions)
 
+	if err := backend.compareFunctions(result); err != nil {
+		return nil, err
+	}
+
 	return result, nil
 }
 
 // ListSnapshots lists all snapshots most recent first
 func (backend *SQLiteBackend) ListSnapshots(limit int) ([]SnapshotSummary, error) {
+	repositoryCondition, repositoryArgs := backend.repositoryFilter("repository")
 	query := `
 		SELECT
 			id, analyzed_at, git_commit_hash, git_branch,
 			total_files, total_functions,
 			avg_cyclomatic_complexity, avg_maintainability_index,
 			hotspot_count, overall_grade, overall_score,
-			complexity_score, maintainability_score, churn_score
+			complexity_score, maintainability_score, churn_score,
+			COALESCE(note, ''), COALESCE(repository, '')
 		FROM analysis_snapshots
+		WHERE ` + repositoryCondition + `
 		ORDER BY analyzed_at DESC
 	`
 
@@ -492,7 +568,7 @@ func (backend *SQLiteBackend) ListSnapshots(limit int) ([]SnapshotSummary, error
 		query += fmt.Sprintf(" LIMIT %d", limit)
 	}
 
-	rows, err := backend.database.Query(query)
+	rows, err := backend.database.Query(query, repositoryArgs...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query snapshots: %w", err)
 	}
@@ -507,6 +583,7 @@ func (backend *SQLiteBackend) ListSnapshots(limit int) ([]SnapshotSummary, error
 			&summary.AvgCyclomaticComplexity, &summary.AvgMaintainabilityIndex,
 			&summary.HotspotCount, &summary.OverallGrade, &summary.OverallScore,
 			&summary.ComplexityScore, &summary.MaintainabilityScore, &summary.ChurnScore,
+			&summary.Note, &summary.Repository,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan snapshot: %w", err)
@@ -517,17 +594,25 @@ func (backend *SQLiteBackend) ListSnapshots(limit int) ([]SnapshotSummary, error
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("error iterating snapshots: %w", err)
 	}
+	_ = rows.Close()
+
+	if err := backend.attachTags(summaries); err != nil {
+		return nil, err
+	}
 
 	return summaries, nil
 }
 
-// Prune removes snapshots older than retentionDays
+// Prune removes snapshots older than retentionDays, keeping any snapshot that carries a tag
 func (backend *SQLiteBackend) Prune(retentionDays int) (int, error) {
 	cutoffDate := time.Now().AddDate(0, 0, -retentionDays)
+	repositoryCondition, repositoryArgs := backend.repositoryFilter("repository")
 
 	result, err := backend.database.Exec(`
-		DELETE FROM analysis_snapshots WHERE analyzed_at < ?
-	`, cutoffDate)
+		DELETE FROM analysis_snapshots
+		WHERE analyzed_at < ?
+		AND id NOT IN (SELECT snapshot_id FROM snapshot_tags)
+		AND `+repositoryCondition, append([]interface{}{cutoffDate}, repositoryArgs...)...)
 
 	if err != nil {
 		return 0, fmt.Errorf("failed to prune snapshots: %w", err)
@@ -541,6 +626,46 @@ func (backend *SQLiteBackend) Prune(retentionDays int) (int, error) {
 	return int(rowsAffected), nil
 }
 
+// AnnotateSnapshot sets the note of a snapshot; an empty note clears it
+func (backend *SQLiteBackend) AnnotateSnapshot(id int64, note string) error {
+	result, err := backend.database.Exec(`
+		UPDATE analysis_snapshots SET note = ? WHERE id = ?
+	`, nullableString(note), id)
+
+	if err != nil {
+		return fmt.Errorf("failed to annotate snapshot: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("snapshot %d not found", id)
+	}
+
+	return nil
+}
+
+// repositoryFilter returns a SQL condition on column limiting snapshots to the backend's
+// repository, with its arguments. Snapshots saved before repositories were recorded match
+// every repository; without a repository the condition matches everything.
+func (backend *SQLiteBackend) repositoryFilter(column string) (string, []interface{}) {
+	if backend.repository == "" {
+		return "1 = 1", nil
+	}
+	return "(" + column + " = ? OR " + column + " IS NULL)", []interface{}{backend.repository}
+}
+
+// nullableString stores empty strings as NULL
+func nullableString(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
 // DeleteSnapshot removes a specific snapshot
 func (backend *SQLiteBackend) DeleteSnapshot(id int64) error {
 	result, err := backend.database.Exec(`
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -141,7 +142,477 @@ func TestSQLiteBackendMultipleSnapshots(testingT *testing.T) {
 	assert.Equal(testingT, id2, comparison.Snapshot2.ID)
 }
 
+// TestSQLiteBackendSnapshotTags tests tagging, resolving, listing, and untagging snapshots
+func TestSQLiteBackendSnapshotTags(testingT *testing.T) {
+	backend, err := NewSQLiteBackend(testingT.TempDir() + "/test-tags.db")
+	require.NoError(testingT, err)
+	defer func() { _ = backend.Close() }()
+
+	id1, err := backend.Save(createTestResult("first", 1, 90.0), SnapshotMetadata{})
+	require.NoError(testingT, err)
+	time.Sleep(10 * time.Millisecond) // Ensure different timestamp
+	id2, err := backend.Save(createTestResult("second", 2, 92.0), SnapshotMetadata{})
+	require.NoError(testingT, err)
+
+	require.NoError(testingT, backend.TagSnapshot(id1, "baseline"))
+	require.NoError(testingT, backend.TagSnapshot(id1, "release-1.2"))
+	require.NoError(testingT, backend.TagSnapshot(id1, "baseline"), "re-tagging the same snapshot is a no-op")
+
+	assert.Error(testingT, backend.TagSnapshot(id2, "baseline"), "tags are unique across snapshots")
+	assert.Error(testingT, backend.TagSnapshot(id2, "42"), "numeric tags would shadow snapshot IDs")
+	assert.Error(testingT, backend.TagSnapshot(id2, ""))
+	assert.Error(testingT, backend.TagSnapshot(9999, "missing"))
+
+	resolvedID, err := backend.ResolveSnapshotRef("baseline")
+	require.NoError(testingT, err)
+	assert.Equal(testingT, id1, resolvedID)
+
+	resolvedID, err = backend.ResolveSnapshotRef("7")
+	require.NoError(testingT, err)
+	assert.Equal(testingT, int64(7), resolvedID)
+
+	_, err = backend.ResolveSnapshotRef("unknown")
+	assert.Error(testingT, err)
+
+	summary, err := backend.GetByIDSummary(id1)
+	require.NoError(testingT, err)
+	assert.Equal(testingT, []string{"baseline", "release-1.2"}, summary.Tags)
+
+	snapshots, err := backend.ListSnapshots(10)
+	require.NoError(testingT, err)
+	require.Len(testingT, snapshots, 2)
+	assert.Empty(testingT, snapshots[0].Tags)
+	assert.Equal(testingT, []string{"baseline", "release-1.2"}, snapshots[1].Tags)
+
+	require.NoError(testingT, backend.UntagSnapshot("baseline"))
+	assert.Error(testingT, backend.UntagSnapshot("baseline"))
+
+	_, err = backend.ResolveSnapshotRef("baseline")
+	assert.Error(testingT, err)
+}
+
+// TestSQLiteBackendSnapshotNotes tests saving, listing, replacing, and clearing snapshot notes
+func TestSQLiteBackendSnapshotNotes(testingT *testing.T) {
+	backend, err := NewSQLiteBackend(testingT.TempDir() + "/test-notes.db")
+	require.NoError(testingT, err)
+	defer func() { _ = backend.Close() }()
+
+	notedID, err := backend.Save(createTestResult("noted", 1, 90.0), SnapshotMetadata{Note: "after auth refactor"})
+	require.NoError(testingT, err)
+	time.Sleep(10 * time.Millisecond) // Ensure different timestamp
+	plainID, err := backend.Save(createTestResult("plain", 1, 91.0), SnapshotMetadata{})
+	require.NoError(testingT, err)
+
+	summary, err := backend.GetByIDSummary(notedID)
+	require.NoError(testingT, err)
+	assert.Equal(testingT, "after auth refactor", summary.Note)
+
+	latest, err := backend.GetLatestSummary()
+	require.NoError(testingT, err)
+	assert.Empty(testingT, latest.Note)
+
+	require.NoError(testingT, backend.AnnotateSnapshot(plainID, "dependency bump"))
+	require.NoError(testingT, backend.AnnotateSnapshot(notedID, "auth refactor, part 1"))
+	assert.Error(testingT, backend.AnnotateSnapshot(9999, "missing"))
+
+	snapshots, err := backend.ListSnapshots(10)
+	require.NoError(testingT, err)
+	require.Len(testingT, snapshots, 2)
+	assert.Equal(testingT, "dependency bump", snapshots[0].Note)
+	assert.Equal(testingT, "auth refactor, part 1", snapshots[1].Note)
+
+	rangeSnapshots, err := backend.GetRange(time.Now().AddDate(0, 0, -1), time.Now().AddDate(0, 0, 1), 10)
+	require.NoError(testingT, err)
+	require.Len(testingT, rangeSnapshots, 2)
+	assert.ElementsMatch(testingT, []string{"dependency bump", "auth refactor, part 1"}, []string{rangeSnapshots[0].Note, rangeSnapshots[1].Note})
+
+	require.NoError(testingT, backend.AnnotateSnapshot(plainID, ""))
+	summary, err = backend.GetByIDSummary(plainID)
+	require.NoError(testingT, err)
+	assert.Empty(testingT, summary.Note)
+}
+
+// TestSQLiteBackendPruneKeepsTaggedSnapshots tests that prune never removes tagged snapshots
+func TestSQLiteBackendPruneKeepsTaggedSnapshots(testingT *testing.T) {
+	backend, err := NewSQLiteBackend(testingT.TempDir() + "/test-prune.db")
+	require.NoError(testingT, err)
+	defer func() { _ = backend.Close() }()
+
+	oldTagged := createTestResult("old-tagged", 1, 80.0)
+	oldTagged.AnalyzedAt = time.Now().AddDate(0, 0, -200)
+	taggedID, err := backend.Save(oldTagged, SnapshotMetadata{})
+	require.NoError(testingT, err)
+
+	oldUntagged := createTestResult("old-untagged", 1, 85.0)
+	oldUntagged.AnalyzedAt = time.Now().AddDate(0, 0, -199)
+	_, err = backend.Save(oldUntagged, SnapshotMetadata{})
+	require.NoError(testingT, err)
+
+	require.NoError(testingT, backend.TagSnapshot(taggedID, "baseline"))
+
+	deleted, err := backend.Prune(90)
+	require.NoError(testingT, err)
+	assert.Equal(testingT, 1, deleted)
+
+	summary, err := backend.GetByIDSummary(taggedID)
+	require.NoError(testingT, err)
+	assert.Equal(testingT, []string{"baseline"}, summary.Tags)
+
+	// Once untagged, the snapshot is subject to retention again
+	require.NoError(testingT, backend.UntagSnapshot("baseline"))
+	deleted, err = backend.Prune(90)
+	require.NoError(testingT, err)
+	assert.Equal(testingT, 1, deleted)
+}
+
+// TestSQLiteBackendScopedTimeSeries tests that module metrics are kept apart from folder metrics
+func TestSQLiteBackendScopedTimeSeries(testingT *testing.T) {
+	backend, err := NewSQLiteBackend(testingT.TempDir() + "/test-scopes.db")
+	require.NoError(testingT, err)
+	defer func() { _ = backend.Close() }()
+
+	result := createTestResult("modules", 1, 90.0)
+	result.FolderStats = map[string]models.FolderMetrics{
+		"services/api": {Path: "services/api", HotspotCount: 1},
+	}
+	result.ModuleStats = map[string]models.FolderMetrics{
+		"services/api": {Path: "services/api", HotspotCount: 4},
+	}
+	_, err = backend.Save(result, SnapshotMetadata{})
+	require.NoError(testingT, err)
+
+	start := time.Now().AddDate(0, 0, -1)
+	end := time.Now().Add(time.Minute)
+
+	folderPoints, err := backend.GetTimeSeries("hotspot_count", "services/api", start, end)
+	require.NoError(testingT, err)
+	require.Len(testingT, folderPoints, 1)
+	assert.Equal(testingT, 1.0, folderPoints[0].Value)
+
+	modulePoints, err := backend.GetScopedTimeSeries("hotspot_count", ScopeModule, "services/api", start, end)
+	require.NoError(testingT, err)
+	require.Len(testingT, modulePoints, 1)
+	assert.Equal(testingT, 4.0, modulePoints[0].Value)
+}
+
 // createTestResult creates a test AnalysisResult with given parameters
+// TestSQLiteBackendSignatureChanges tests detecting parameter count changes between snapshots
+func TestSQLiteBackendSignatureChanges(testingT *testing.T) {
+	backend, err := NewSQLiteBackend(testingT.TempDir() + "/test-signatures.db")
+	require.NoError(testingT, err)
+	defer func() { _ = backend.Close() }()
+
+	snapshotWithFunctions := func(analyzedAt time.Time, parameterCounts map[string]int) int64 {
+		result := createTestResult("signatures", 0, 90.0)
+		result.AnalyzedAt = analyzedAt
+		for _, name := range []string{"Handle", "parse", "Serve", "String", "Removed"} {
+			parameterCount, exists := parameterCounts[name]
+			if !exists {
+				continue
+			}
+			result.Files[0].Functions = append(result.Files[0].Functions, models.FunctionAnalysis{Name: name, ParameterCount: parameterCount})
+		}
+		// Same-named methods on different types cannot be told apart by file and name
+		result.Files[0].Functions = append(result.Files[0].Functions, models.FunctionAnalysis{Name: "String", ParameterCount: 0})
+
+		id, err := backend.Save(result, SnapshotMetadata{KaizenVersion: "1.0.0"})
+		require.NoError(testingT, err)
+		return id
+	}
+
+	start := time.Now().Add(-time.Hour)
+	firstID := snapshotWithFunctions(start, map[string]int{"Handle": 2, "parse": 1, "Serve": 1, "String": 0, "Removed": 3})
+	secondID := snapshotWithFunctions(start.Add(time.Minute), map[string]int{"Handle": 3, "parse": 2, "Serve": 1, "String": 1})
+
+	previousID, changes, err := backend.GetSignatureChanges(firstID)
+	require.NoError(testingT, err)
+	assert.Equal(testingT, int64(0), previousID)
+	assert.Empty(testingT, changes)
+
+	previousID, changes, err = backend.GetSignatureChanges(secondID)
+	require.NoError(testingT, err)
+	assert.Equal(testingT, firstID, previousID)
+	assert.Equal(testingT, []SignatureChange{
+		{FilePath: "test.go", FunctionName: "Handle", PreviousParameterCount: 2, ParameterCount: 3},
+	}, changes)
+
+	_, _, err = backend.GetSignatureChanges(secondID + 100)
+	assert.Error(testingT, err)
+}
+
+func TestSQLiteBackendFolderRegressions(testingT *testing.T) {
+	backend, err := NewSQLiteBackend(testingT.TempDir() + "/test-folder-regressions.db")
+	require.NoError(testingT, err)
+	defer func() { _ = backend.Close() }()
+
+	snapshotWithFolders := func(analyzedAt time.Time, folders map[string]models.FolderMetrics) int64 {
+		result := createTestResult("folders", 0, 90.0)
+		result.AnalyzedAt = analyzedAt
+		result.FolderStats = folders
+		id, err := backend.Save(result, SnapshotMetadata{KaizenVersion: "1.0.0"})
+		require.NoError(testingT, err)
+		return id
+	}
+
+	start := time.Now().Add(-time.Hour)
+	firstID := snapshotWithFolders(start, map[string]models.FolderMetrics{
+		"api":     {AverageComplexity: 4, ComplexityScore: 50, HotspotScore: 40},
+		"billing": {AverageComplexity: 6, ComplexityScore: 80, HotspotScore: 0},
+		"util":    {AverageComplexity: 2, ComplexityScore: 20, HotspotScore: 10},
+	})
+	secondID := snapshotWithFolders(start.Add(time.Minute), map[string]models.FolderMetrics{
+		"api":     {AverageComplexity: 4.4, ComplexityScore: 75, HotspotScore: 40},
+		"billing": {AverageComplexity: 6, ComplexityScore: 80, HotspotScore: 30},
+		"util":    {AverageComplexity: 1, ComplexityScore: 10, HotspotScore: 5},
+		"new":     {AverageComplexity: 9, ComplexityScore: 100, HotspotScore: 100},
+	})
+
+	previousID, regressions, err := backend.GetFolderRegressions(firstID, []string{"complexity_score"}, 20)
+	require.NoError(testingT, err)
+	assert.Equal(testingT, int64(0), previousID)
+	assert.Empty(testingT, regressions)
+
+	// api's average complexity rose only 10%, util improved, and new has nothing to compare with
+	previousID, regressions, err = backend.GetFolderRegressions(secondID, []string{"avg_cyclomatic_complexity", "complexity_score", "hotspot_score"}, 20)
+	require.NoError(testingT, err)
+	assert.Equal(testingT, firstID, previousID)
+	assert.Equal(testingT, []FolderRegression{
+		{FolderPath: "billing", MetricName: "hotspot_score", PreviousValue: 0, Value: 30, PercentChange: 100},
+		{FolderPath: "api", MetricName: "complexity_score", PreviousValue: 50, Value: 75, PercentChange: 50},
+	}, regressions)
+
+	_, _, err = backend.GetFolderRegressions(secondID+100, []string{"complexity_score"}, 20)
+	assert.Error(testingT, err)
+}
+
+func TestSQLiteBackendFunctionHistory(testingT *testing.T) {
+	backend, err := NewSQLiteBackend(testingT.TempDir() + "/test-function-history.db")
+	require.NoError(testingT, err)
+	defer func() { _ = backend.Close() }()
+
+	start := time.Now().Add(-time.Hour)
+	var snapshotIDs []int64
+	for index, complexity := range []int{12, 8, 14} {
+		result := createTestResult("history", 1, 90.0)
+		result.AnalyzedAt = start.Add(time.Duration(index) * time.Minute)
+		result.Files[0].Functions[0].CyclomaticComplexity = complexity
+		id, err := backend.Save(result, SnapshotMetadata{KaizenVersion: "1.0.0"})
+		require.NoError(testingT, err)
+		snapshotIDs = append(snapshotIDs, id)
+	}
+
+	records, err := backend.GetFunctionHistory(time.Time{})
+	require.NoError(testingT, err)
+	require.Len(testingT, records, 3)
+	for index, record := range records {
+		assert.Equal(testingT, snapshotIDs[index], record.SnapshotID)
+		assert.Equal(testingT, "test.go", record.FilePath)
+		assert.Equal(testingT, "Func", record.FunctionName)
+		assert.Equal(testingT, 20, record.Length)
+		assert.InDelta(testingT, 85.0, record.MaintainabilityIndex, 0.001)
+	}
+	assert.Equal(testingT, []int{12, 8, 14}, []int{
+		records[0].CyclomaticComplexity, records[1].CyclomaticComplexity, records[2].CyclomaticComplexity,
+	})
+
+	recent, err := backend.GetFunctionHistory(start.Add(30 * time.Second))
+	require.NoError(testingT, err)
+	require.Len(testingT, recent, 2)
+	assert.Equal(testingT, snapshotIDs[1], recent[0].SnapshotID)
+}
+
+func TestSQLiteBackendSaveRollsBackOnFailure(testingT *testing.T) {
+	backend, err := NewSQLiteBackend(testingT.TempDir() + "/test-rollback.db")
+	require.NoError(testingT, err)
+	defer func() { _ = backend.Close() }()
+
+	// Fail the last step of Save, once the snapshot and its metrics are already inserted
+	_, err = backend.database.Exec(`
+		CREATE TRIGGER fail_function_history BEFORE INSERT ON function_history
+		BEGIN SELECT RAISE(ABORT, 'simulated failure'); END
+	`)
+	require.NoError(testingT, err)
+
+	result := createTestResult("rollback", 2, 90.0)
+	result.FolderStats = map[string]models.FolderMetrics{"pkg": {Path: "pkg", ComplexityScore: 80}}
+	_, err = backend.Save(result, SnapshotMetadata{KaizenVersion: "1.0.0"})
+	require.Error(testingT, err)
+	assert.Contains(testingT, err.Error(), "failed to insert function history")
+
+	for _, table := range []string{"analysis_snapshots", "metrics_timeseries", "function_history"} {
+		var rows int
+		require.NoError(testingT, backend.database.QueryRow("SELECT COUNT(*) FROM "+table).Scan(&rows))
+		assert.Zero(testingT, rows, "%s should have no rows after a failed save", table)
+	}
+
+	// Once the failure is gone, the same result saves completely
+	_, err = backend.database.Exec("DROP TRIGGER fail_function_history")
+	require.NoError(testingT, err)
+	snapshotID, err := backend.Save(result, SnapshotMetadata{KaizenVersion: "1.0.0"})
+	require.NoError(testingT, err)
+
+	records, err := backend.GetFunctionHistory(time.Time{})
+	require.NoError(testingT, err)
+	require.Len(testingT, records, 2)
+	assert.Equal(testingT, snapshotID, records[0].SnapshotID)
+}
+
+func TestSQLiteBackendFunctionHistoryOverloads(testingT *testing.T) {
+	backend, err := NewSQLiteBackend(testingT.TempDir() + "/test-overloads.db")
+	require.NoError(testingT, err)
+	defer func() { _ = backend.Close() }()
+
+	start := time.Now().Add(-time.Hour)
+	for index, offset := range []int{0, 3} {
+		result := createTestResult("overloads", 0, 90.0)
+		result.AnalyzedAt = start.Add(time.Duration(index) * time.Minute)
+		result.Files[0].Path = "src/Parser.kt"
+		// fun parse(text: String), fun parse(text: String, radix: Int), fun parse(value: Int),
+		// and fun render(); the second snapshot only moves them down the file
+		result.Files[0].Functions = []models.FunctionAnalysis{
+			{Name: "parse", StartLine: 3 + offset, ParameterCount: 1, CyclomaticComplexity: 2 + index},
+			{Name: "parse", StartLine: 10 + offset, ParameterCount: 2, CyclomaticComplexity: 9 + index},
+			{Name: "parse", StartLine: 20 + offset, ParameterCount: 1, CyclomaticComplexity: 4 + index},
+			{Name: "render", StartLine: 30 + offset, CyclomaticComplexity: 1},
+		}
+		_, err := backend.Save(result, SnapshotMetadata{KaizenVersion: "1.0.0"})
+		require.NoError(testingT, err)
+	}
+
+	records, err := backend.GetFunctionHistory(time.Time{})
+	require.NoError(testingT, err)
+	require.Len(testingT, records, 8)
+
+	complexityByFunction := make(map[string][]int)
+	for _, record := range records {
+		assert.Equal(testingT, "src/Parser.kt", record.FilePath)
+		identity := record.FunctionName + record.Overload
+		complexityByFunction[identity] = append(complexityByFunction[identity], record.CyclomaticComplexity)
+	}
+	assert.Equal(testingT, map[string][]int{
+		"parse(1)#1": {2, 3},
+		"parse(2)":   {9, 10},
+		"parse(1)#2": {4, 5},
+		"render":     {1, 1},
+	}, complexityByFunction, "each overload keeps its own history")
+}
+
+func TestSQLiteBackendCompareFunctions(testingT *testing.T) {
+	backend, err := NewSQLiteBackend(testingT.TempDir() + "/test-compare-functions.db")
+	require.NoError(testingT, err)
+	defer func() { _ = backend.Close() }()
+
+	snapshotWithFunctions := func(analyzedAt time.Time, functions []models.FunctionAnalysis) int64 {
+		result := createTestResult("compare", 0, 90.0)
+		result.AnalyzedAt = analyzedAt
+		result.Files[0].Functions = functions
+		id, err := backend.Save(result, SnapshotMetadata{KaizenVersion: "1.0.0"})
+		require.NoError(testingT, err)
+		return id
+	}
+
+	start := time.Now().Add(-time.Hour)
+	firstID := snapshotWithFunctions(start, []models.FunctionAnalysis{
+		{Name: "Handle", Length: 40, CyclomaticComplexity: 12, MaintainabilityIndex: 55},
+		{Name: "Parse", Length: 10, CyclomaticComplexity: 3, MaintainabilityIndex: 80},
+		{Name: "Unchanged", Length: 5, CyclomaticComplexity: 1, MaintainabilityIndex: 95},
+		{Name: "Removed", Length: 5, CyclomaticComplexity: 1, MaintainabilityIndex: 95},
+		{Name: "String", CyclomaticComplexity: 1},
+		{Name: "String", CyclomaticComplexity: 2},
+	})
+	secondID := snapshotWithFunctions(start.Add(time.Minute), []models.FunctionAnalysis{
+		{Name: "Handle", Length: 20, CyclomaticComplexity: 5, MaintainabilityIndex: 72},
+		{Name: "Parse", Length: 25, CyclomaticComplexity: 9, MaintainabilityIndex: 61},
+		{Name: "Unchanged", Length: 5, CyclomaticComplexity: 1, MaintainabilityIndex: 95},
+		{Name: "Added", Length: 5, CyclomaticComplexity: 1, MaintainabilityIndex: 95},
+		{Name: "String", CyclomaticComplexity: 4},
+		{Name: "String", CyclomaticComplexity: 6},
+	})
+
+	comparison, err := backend.Compare(firstID, secondID)
+	require.NoError(testingT, err)
+	assert.Equal(testingT, 1, comparison.NewFunctionCount)
+	assert.Equal(testingT, 1, comparison.RemovedFunctionCount)
+	assert.Equal(testingT, []FunctionChange{
+		{FilePath: "test.go", FunctionName: "Handle", OldComplexity: 12, NewComplexity: 5, OldLength: 40, NewLength: 20, OldMaintainability: 55, NewMaintainability: 72},
+		{FilePath: "test.go", FunctionName: "Parse", OldComplexity: 3, NewComplexity: 9, OldLength: 10, NewLength: 25, OldMaintainability: 80, NewMaintainability: 61},
+		{FilePath: "test.go", FunctionName: "String", Overload: "(0)#1", OldComplexity: 1, NewComplexity: 4},
+		{FilePath: "test.go", FunctionName: "String", Overload: "(0)#2", OldComplexity: 2, NewComplexity: 6},
+	}, comparison.ChangedFunctions, "same-named String methods are compared in declaration order")
+
+	assert.Equal(testingT, -7, comparison.ChangedFunctions[0].ComplexityDelta())
+	assert.InDelta(testingT, -19.0, comparison.ChangedFunctions[1].MaintainabilityDelta(), 0.001)
+}
+
+func TestSQLiteBackendRepositoryScope(testingT *testing.T) {
+	dbPath := testingT.TempDir() + "/shared.db"
+
+	shared, err := NewBackend(BackendConfig{Type: "sqlite", Path: dbPath})
+	require.NoError(testingT, err)
+	legacyID, err := shared.Save(createTestResult("legacy", 1, 80.0), SnapshotMetadata{})
+	require.NoError(testingT, err)
+	apiID, err := shared.Save(createTestResult("api", 1, 90.0), SnapshotMetadata{Repository: "/src/api"})
+	require.NoError(testingT, err)
+	webID, err := shared.Save(createTestResult("web", 1, 70.0), SnapshotMetadata{Repository: "/src/web"})
+	require.NoError(testingT, err)
+	require.NoError(testingT, shared.Close())
+
+	snapshotIDs := func(repository string) []int64 {
+		backend, err := NewBackend(BackendConfig{Type: "sqlite", Path: dbPath, Repository: repository})
+		require.NoError(testingT, err)
+		defer func() { _ = backend.Close() }()
+
+		snapshots, err := backend.ListSnapshots(0)
+		require.NoError(testingT, err)
+		var ids []int64
+		for _, snapshot := range snapshots {
+			ids = append(ids, snapshot.ID)
+		}
+		return ids
+	}
+
+	assert.ElementsMatch(testingT, []int64{apiID, legacyID}, snapshotIDs("/src/api"), "legacy snapshots belong to every repository")
+	assert.ElementsMatch(testingT, []int64{webID, legacyID}, snapshotIDs("/src/web"))
+	assert.ElementsMatch(testingT, []int64{apiID, webID, legacyID}, snapshotIDs(""))
+
+	api, err := NewBackend(BackendConfig{Type: "sqlite", Path: dbPath, Repository: "/src/api"})
+	require.NoError(testingT, err)
+	defer func() { _ = api.Close() }()
+
+	latest, err := api.GetLatest()
+	require.NoError(testingT, err)
+	assert.Equal(testingT, "api", latest.Repository, "the web snapshot saved later is not the api repository's latest")
+}
+
+func TestPrepareDatabasePath(testingT *testing.T) {
+	rootPath := testingT.TempDir()
+
+	dbPath, err := PrepareDatabasePath("data/kaizen.db", rootPath)
+	require.NoError(testingT, err)
+	assert.Equal(testingT, filepath.Join(rootPath, "data", "kaizen.db"), dbPath)
+	assert.DirExists(testingT, filepath.Join(rootPath, "data"))
+
+	absolutePath := filepath.Join(testingT.TempDir(), "shared", "kaizen.db")
+	dbPath, err = PrepareDatabasePath(absolutePath, rootPath)
+	require.NoError(testingT, err)
+	assert.Equal(testingT, absolutePath, dbPath)
+
+	testingT.Setenv("HOME", testingT.TempDir())
+	homeDir, err := os.UserHomeDir()
+	require.NoError(testingT, err)
+	dbPath, err = PrepareDatabasePath("~/.kaizen/shared.db", rootPath)
+	require.NoError(testingT, err)
+	assert.Equal(testingT, filepath.Join(homeDir, ".kaizen", "shared.db"), dbPath)
+}
+
+func TestIsPublicFunction(testingT *testing.T) {
+	assert.True(testingT, isPublicFunction("pkg/api/handler.go", "Handle"))
+	assert.False(testingT, isPublicFunction("pkg/api/handler.go", "handle"))
+	assert.True(testingT, isPublicFunction("app/views.py", "render"))
+	assert.False(testingT, isPublicFunction("app/views.py", "_render"))
+	assert.True(testingT, isPublicFunction("app/Main.kt", "render"))
+}
+
 func createTestResult(name string, functionCount int, score float64) *models.AnalysisResult {
 	functions := make([]models.FunctionAnalysis, functionCount)
 	for i := 0; i < functionCount; i++ {
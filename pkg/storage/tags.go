@@ -0,0 +1,138 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// validateTag rejects labels that would be ambiguous when used as snapshot references
+func validateTag(tag string) error {
+	if tag == "" {
+		return fmt.Errorf("tag must not be empty")
+	}
+	if strings.ContainsAny(tag, " \t\n") {
+		return fmt.Errorf("tag %q must not contain whitespace", tag)
+	}
+	if _, err := strconv.ParseInt(tag, 10, 64); err == nil {
+		return fmt.Errorf("tag %q must not be numeric, as it would be mistaken for a snapshot ID", tag)
+	}
+	return nil
+}
+
+// TagSnapshot attaches a label to a snapshot. Tags are unique across snapshots, so
+// a label already held by a different snapshot must be removed before it can move.
+func (backend *SQLiteBackend) TagSnapshot(id int64, tag string) error {
+	if err := validateTag(tag); err != nil {
+		return err
+	}
+
+	if _, err := backend.GetByIDSummary(id); err != nil {
+		return err
+	}
+
+	var existingID int64
+	err := backend.database.QueryRow(`
+		SELECT snapshot_id FROM snapshot_tags WHERE tag = ?
+	`, tag).Scan(&existingID)
+
+	if err == nil {
+		if existingID == id {
+			return nil
+		}
+		return fmt.Errorf("tag %q is already assigned to snapshot %d", tag, existingID)
+	}
+	if err != sql.ErrNoRows {
+		return fmt.Errorf("failed to query tag: %w", err)
+	}
+
+	_, err = backend.database.Exec(`
+		INSERT INTO snapshot_tags (tag, snapshot_id) VALUES (?, ?)
+	`, tag, id)
+	if err != nil {
+		return fmt.Errorf("failed to tag snapshot: %w", err)
+	}
+
+	return nil
+}
+
+// UntagSnapshot removes a label from whichever snapshot carries it
+func (backend *SQLiteBackend) UntagSnapshot(tag string) error {
+	result, err := backend.database.Exec(`
+		DELETE FROM snapshot_tags WHERE tag = ?
+	`, tag)
+	if err != nil {
+		return fmt.Errorf("failed to untag snapshot: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("tag %q not found", tag)
+	}
+
+	return nil
+}
+
+// ResolveSnapshotRef resolves a snapshot reference, which is either a numeric ID or a tag
+func (backend *SQLiteBackend) ResolveSnapshotRef(ref string) (int64, error) {
+	if id, err := strconv.ParseInt(ref, 10, 64); err == nil {
+		return id, nil
+	}
+
+	var id int64
+	err := backend.database.QueryRow(`
+		SELECT snapshot_id FROM snapshot_tags WHERE tag = ?
+	`, ref).Scan(&id)
+
+	if err == sql.ErrNoRows {
+		return 0, fmt.Errorf("no snapshot with ID or tag %q", ref)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to query tag: %w", err)
+	}
+
+	return id, nil
+}
+
+// getSnapshotTags returns the tags of a single snapshot in alphabetical order
+func (backend *SQLiteBackend) getSnapshotTags(id int64) ([]string, error) {
+	rows, err := backend.database.Query(`
+		SELECT tag FROM snapshot_tags WHERE snapshot_id = ? ORDER BY tag
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tags: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var tags []string
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, fmt.Errorf("failed to scan tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating tags: %w", err)
+	}
+
+	return tags, nil
+}
+
+// attachTags fills in the tags of each summary in place
+func (backend *SQLiteBackend) attachTags(summaries []SnapshotSummary) error {
+	for index := range summaries {
+		tags, err := backend.getSnapshotTags(summaries[index].ID)
+		if err != nil {
+			return err
+		}
+		summaries[index].Tags = tags
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/alexcollie/kaizen/pkg/reports"
 	"github.com/alexcollie/kaizen/pkg/storage"
 )
 
@@ -171,8 +172,9 @@ func formatStats(metricName string, points []storage.TimeSeriesPoint) string {
 	return stats
 }
 
-// RenderComparisonTable renders side-by-side comparison of metrics
-func RenderComparisonTable(snapshot1, snapshot2 *storage.SnapshotSummary) string {
+// RenderComparisonTable renders side-by-side comparison of metrics, with scores to precision
+// decimal places
+func RenderComparisonTable(snapshot1, snapshot2 *storage.SnapshotSummary, precision int) string {
 	var output strings.Builder
 
 	output.WriteString("Snapshot Comparison\n")
@@ -185,16 +187,16 @@ func RenderComparisonTable(snapshot1, snapshot2 *storage.SnapshotSummary) string
 		val2   interface{}
 	}{
 		{"Analyzed At", snapshot1.AnalyzedAt.Format("2006-01-02 15:04"), snapshot2.AnalyzedAt.Format("2006-01-02 15:04")},
-		{"Overall Score", formatScore(snapshot1.OverallScore), formatScore(snapshot2.OverallScore)},
+		{"Overall Score", formatScore(snapshot1.OverallScore, precision), formatScore(snapshot2.OverallScore, precision)},
 		{"Overall Grade", snapshot1.OverallGrade, snapshot2.OverallGrade},
 		{"Total Files", snapshot1.TotalFiles, snapshot2.TotalFiles},
 		{"Total Functions", snapshot1.TotalFunctions, snapshot2.TotalFunctions},
 		{"Avg Cyclomatic Complexity", fmt.Sprintf("%.1f", snapshot1.AvgCyclomaticComplexity), fmt.Sprintf("%.1f", snapshot2.AvgCyclomaticComplexity)},
 		{"Avg Maintainability Index", fmt.Sprintf("%.1f", snapshot1.AvgMaintainabilityIndex), fmt.Sprintf("%.1f", snapshot2.AvgMaintainabilityIndex)},
 		{"Hotspot Count", snapshot1.HotspotCount, snapshot2.HotspotCount},
-		{"Complexity Score", formatScore(snapshot1.ComplexityScore), formatScore(snapshot2.ComplexityScore)},
-		{"Maintainability Score", formatScore(snapshot1.MaintainabilityScore), formatScore(snapshot2.MaintainabilityScore)},
-		{"Churn Score", formatScore(snapshot1.ChurnScore), formatScore(snapshot2.ChurnScore)},
+		{"Complexity Score", formatScore(snapshot1.ComplexityScore, precision), formatScore(snapshot2.ComplexityScore, precision)},
+		{"Maintainability Score", formatScore(snapshot1.MaintainabilityScore, precision), formatScore(snapshot2.MaintainabilityScore, precision)},
+		{"Churn Score", formatScore(snapshot1.ChurnScore, precision), formatScore(snapshot2.ChurnScore, precision)},
 	}
 
 	// Render table
@@ -210,9 +212,9 @@ func RenderComparisonTable(snapshot1, snapshot2 *storage.SnapshotSummary) string
 	return output.String()
 }
 
-func formatScore(score float64) string {
+func formatScore(score float64, precision int) string {
 	if score == 0 {
 		return "N/A"
 	}
-	return fmt.Sprintf("%.1f/100", score)
+	return reports.FormatScore(score, precision) + "/100"
 }
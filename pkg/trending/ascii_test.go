@@ -123,7 +123,7 @@ func TestRenderComparisonTable(t *testing.T) {
 		ChurnScore:               92.0,
 	}
 
-	output := RenderComparisonTable(snapshot1, snapshot2)
+	output := RenderComparisonTable(snapshot1, snapshot2, 1)
 
 	assert.NotEmpty(t, output)
 	assert.Contains(t, output, "Snapshot Comparison")
@@ -238,7 +238,7 @@ func TestFormatScore(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		result := formatScore(tt.score)
+		result := formatScore(tt.score, 1)
 		assert.Equal(t, tt.expected, result)
 	}
 }
@@ -0,0 +1,303 @@
+package trending
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/alexcollie/kaizen/pkg/reports"
+	"github.com/alexcollie/kaizen/pkg/storage"
+)
+
+// DigestTopMetrics is how many regressed and improved metrics a digest lists
+const DigestTopMetrics = 5
+
+// digestListLimit is the most hotspots or resolved concerns a rendered digest lists before
+// summarizing the rest as a count
+const digestListLimit = 10
+
+// higherIsBetterMetrics are the dashboard metrics that improve as they rise; every other
+// metric improves as it falls
+var higherIsBetterMetrics = map[string]bool{
+	"overall_score":             true,
+	"complexity_score":          true,
+	"maintainability_score":     true,
+	"churn_score":               true,
+	"avg_maintainability_index": true,
+}
+
+// MetricChange is a metric's value at the start and end of a digest period
+type MetricChange struct {
+	MetricName    string  `json:"metric"`
+	Before        float64 `json:"before"`
+	After         float64 `json:"after"`
+	Delta         float64 `json:"delta"`
+	PercentChange float64 `json:"percent_change"` // Relative to Before; ±100 when Before is 0
+}
+
+// DigestFunction is a function named by a digest, with the concern that flagged it if any
+type DigestFunction struct {
+	FilePath     string `json:"file_path"`
+	FunctionName string `json:"function_name"`
+	Overload     string `json:"overload,omitempty"`
+	Concern      string `json:"concern,omitempty"`
+}
+
+// Digest summarizes how a repository changed over a period of snapshots
+type Digest struct {
+	From             storage.SnapshotSummary `json:"from"`
+	To               storage.SnapshotSummary `json:"to"`
+	SnapshotCount    int                     `json:"snapshot_count"`
+	Grades           []string                `json:"grades"` // Overall grade of each snapshot, oldest first, repeats collapsed
+	ScoreSparkline   string                  `json:"score_sparkline"`
+	Regressed        []MetricChange          `json:"regressed"`
+	Improved         []MetricChange          `json:"improved"`
+	NewHotspots      []DigestFunction        `json:"new_hotspots"`
+	ResolvedConcerns []DigestFunction        `json:"resolved_concerns"`
+}
+
+// BuildDigest summarizes snapshots (oldest first) taken over a period. series holds each
+// metric's points over the same period, and first and last are the full results of the oldest
+// and newest snapshots, used to find new hotspots and resolved concerns. The top metrics that
+// regressed and improved most are ranked by relative change, since their units differ.
+func BuildDigest(snapshots []storage.SnapshotSummary, series []MetricSeries, first, last *models.AnalysisResult, top int) Digest {
+	digest := Digest{
+		SnapshotCount:    len(snapshots),
+		Regressed:        []MetricChange{},
+		Improved:         []MetricChange{},
+		NewHotspots:      []DigestFunction{},
+		ResolvedConcerns: []DigestFunction{},
+	}
+	if len(snapshots) == 0 {
+		return digest
+	}
+	digest.From, digest.To = snapshots[0], snapshots[len(snapshots)-1]
+
+	scores := make([]float64, len(snapshots))
+	for index, snapshot := range snapshots {
+		scores[index] = snapshot.OverallScore
+		if len(digest.Grades) == 0 || digest.Grades[len(digest.Grades)-1] != snapshot.OverallGrade {
+			digest.Grades = append(digest.Grades, snapshot.OverallGrade)
+		}
+	}
+	digest.ScoreSparkline = RenderSparkline(scores, SparklineWidth)
+
+	digest.Regressed, digest.Improved = rankMetricChanges(series, top)
+	if first != nil && last != nil {
+		digest.NewHotspots = newHotspots(first, last)
+		digest.ResolvedConcerns = resolvedConcerns(first, last)
+	}
+	return digest
+}
+
+// rankMetricChanges splits each series' change from its first to last point into regressions
+// and improvements, largest relative change first, keeping top of each (0 = all)
+func rankMetricChanges(series []MetricSeries, top int) ([]MetricChange, []MetricChange) {
+	regressed := []MetricChange{}
+	improved := []MetricChange{}
+
+	for _, metric := range series {
+		if len(metric.Points) < 2 {
+			continue
+		}
+		before, after := metric.Points[0].Value, metric.Points[len(metric.Points)-1].Value
+		change := MetricChange{MetricName: metric.MetricName, Before: before, After: after, Delta: after - before}
+		if math.Abs(change.Delta) < 0.05 {
+			continue
+		}
+		if before != 0 {
+			change.PercentChange = change.Delta / math.Abs(before) * 100
+		} else {
+			change.PercentChange = math.Copysign(100, change.Delta)
+		}
+
+		if (change.Delta > 0) == higherIsBetterMetrics[metric.MetricName] {
+			improved = append(improved, change)
+		} else {
+			regressed = append(regressed, change)
+		}
+	}
+
+	for _, changes := range [][]MetricChange{regressed, improved} {
+		sort.SliceStable(changes, func(first, second int) bool {
+			return math.Abs(changes[first].PercentChange) > math.Abs(changes[second].PercentChange)
+		})
+	}
+	if top > 0 && len(regressed) > top {
+		regressed = regressed[:top]
+	}
+	if top > 0 && len(improved) > top {
+		improved = improved[:top]
+	}
+	return regressed, improved
+}
+
+// newHotspots lists the functions that are hotspots in last but were not in first
+func newHotspots(first, last *models.AnalysisResult) []DigestFunction {
+	existing := make(map[string]bool)
+	for _, file := range first.Files {
+		overloadKeys := file.OverloadKeys()
+		for index, function := range file.Functions {
+			if function.IsHotspot {
+				existing[file.Path+"\x00"+function.Name+"\x00"+overloadKeys[index]] = true
+			}
+		}
+	}
+
+	hotspots := []DigestFunction{}
+	for _, file := range last.Files {
+		overloadKeys := file.OverloadKeys()
+		for index, function := range file.Functions {
+			if function.IsHotspot && !existing[file.Path+"\x00"+function.Name+"\x00"+overloadKeys[index]] {
+				hotspots = append(hotspots, DigestFunction{FilePath: file.Path, FunctionName: function.Name, Overload: overloadKeys[index]})
+			}
+		}
+	}
+	return hotspots
+}
+
+// resolvedConcerns lists the concern items of first that no concern of the same type in last
+// still reports, matched by file and function
+func resolvedConcerns(first, last *models.AnalysisResult) []DigestFunction {
+	if first.ScoreReport == nil {
+		return []DigestFunction{}
+	}
+
+	remaining := make(map[string]bool)
+	if last.ScoreReport != nil {
+		for _, concern := range last.ScoreReport.Concerns {
+			for _, item := range concern.AffectedItems {
+				remaining[concernItemKey(concern.Type, item)] = true
+			}
+		}
+	}
+
+	resolved := []DigestFunction{}
+	seen := make(map[string]bool)
+	for _, concern := range first.ScoreReport.Concerns {
+		for _, item := range concern.AffectedItems {
+			key := concernItemKey(concern.Type, item)
+			if remaining[key] || seen[key] {
+				continue
+			}
+			seen[key] = true
+			resolved = append(resolved, DigestFunction{FilePath: item.FilePath, FunctionName: item.FunctionName, Overload: item.Overload, Concern: concern.Title})
+		}
+	}
+	return resolved
+}
+
+// concernItemKey identifies a concern item across snapshots by function and overload key,
+// ignoring its line, which moves as the file is edited
+func concernItemKey(concernType string, item models.AffectedItem) string {
+	return concernType + "\x00" + item.FilePath + "\x00" + item.FunctionName + "\x00" + item.Overload
+}
+
+// RenderDigestMarkdown renders a digest as Markdown for pasting into chat or a wiki, with
+// scores to precision decimal places
+func RenderDigestMarkdown(digest Digest, precision int) string {
+	var output strings.Builder
+
+	fmt.Fprintf(&output, "## Code health digest: %s → %s\n\n", digest.From.AnalyzedAt.Format("2006-01-02"), digest.To.AnalyzedAt.Format("2006-01-02"))
+	fmt.Fprintf(&output, "**Grade:** %s (score %s → %s, %s over %d snapshots) `%s`\n\n",
+		strings.Join(digest.Grades, " → "), reports.FormatScore(digest.From.OverallScore, precision), reports.FormatScore(digest.To.OverallScore, precision),
+		reports.FormatScoreChange(digest.To.OverallScore-digest.From.OverallScore, precision), digest.SnapshotCount, digest.ScoreSparkline)
+
+	writeMarkdownChanges(&output, "Regressed most", digest.Regressed)
+	writeMarkdownChanges(&output, "Improved most", digest.Improved)
+	writeMarkdownFunctions(&output, "New hotspots", digest.NewHotspots)
+	writeMarkdownFunctions(&output, "Resolved concerns", digest.ResolvedConcerns)
+	return output.String()
+}
+
+func writeMarkdownChanges(output *strings.Builder, title string, changes []MetricChange) {
+	fmt.Fprintf(output, "### %s\n\n", title)
+	if len(changes) == 0 {
+		output.WriteString("_None_\n\n")
+		return
+	}
+	for _, change := range changes {
+		fmt.Fprintf(output, "- `%s` %.1f → %.1f (%+.1f%%)\n", change.MetricName, change.Before, change.After, change.PercentChange)
+	}
+	output.WriteString("\n")
+}
+
+func writeMarkdownFunctions(output *strings.Builder, title string, functions []DigestFunction) {
+	fmt.Fprintf(output, "### %s (%d)\n\n", title, len(functions))
+	if len(functions) == 0 {
+		output.WriteString("_None_\n\n")
+		return
+	}
+	for index, function := range functions {
+		if index == digestListLimit {
+			fmt.Fprintf(output, "- …and %d more\n", len(functions)-digestListLimit)
+			break
+		}
+		if function.FunctionName == "" {
+			fmt.Fprintf(output, "- `%s`%s\n", function.FilePath, concernSuffix(function))
+			continue
+		}
+		fmt.Fprintf(output, "- `%s%s` in `%s`%s\n", function.FunctionName, function.Overload, function.FilePath, concernSuffix(function))
+	}
+	output.WriteString("\n")
+}
+
+// RenderDigestASCII renders a digest as plain text for the terminal, with scores to precision
+// decimal places
+func RenderDigestASCII(digest Digest, precision int) string {
+	var output strings.Builder
+
+	fmt.Fprintf(&output, "📋 Code Health Digest: %s → %s\n\n", digest.From.AnalyzedAt.Format("2006-01-02"), digest.To.AnalyzedAt.Format("2006-01-02"))
+	fmt.Fprintf(&output, "Grade:  %s\n", strings.Join(digest.Grades, " → "))
+	fmt.Fprintf(&output, "Score:  %s → %s (%s over %d snapshots)  %s\n\n",
+		reports.FormatScore(digest.From.OverallScore, precision), reports.FormatScore(digest.To.OverallScore, precision),
+		reports.FormatScoreChange(digest.To.OverallScore-digest.From.OverallScore, precision), digest.SnapshotCount, digest.ScoreSparkline)
+
+	writeASCIIChanges(&output, "⚠️  Regressed Most", digest.Regressed)
+	writeASCIIChanges(&output, "🏆 Improved Most", digest.Improved)
+	writeASCIIFunctions(&output, "🔥 New Hotspots", digest.NewHotspots)
+	writeASCIIFunctions(&output, "✅ Resolved Concerns", digest.ResolvedConcerns)
+	return output.String()
+}
+
+func writeASCIIChanges(output *strings.Builder, title string, changes []MetricChange) {
+	fmt.Fprintf(output, "%s:\n", title)
+	if len(changes) == 0 {
+		output.WriteString("  (none)\n\n")
+		return
+	}
+	for _, change := range changes {
+		fmt.Fprintf(output, "  %-26s %8.1f → %-8.1f (%+.1f%%)\n", change.MetricName, change.Before, change.After, change.PercentChange)
+	}
+	output.WriteString("\n")
+}
+
+func writeASCIIFunctions(output *strings.Builder, title string, functions []DigestFunction) {
+	fmt.Fprintf(output, "%s (%d):\n", title, len(functions))
+	if len(functions) == 0 {
+		output.WriteString("  (none)\n\n")
+		return
+	}
+	for index, function := range functions {
+		if index == digestListLimit {
+			fmt.Fprintf(output, "  ...and %d more\n", len(functions)-digestListLimit)
+			break
+		}
+		if function.FunctionName == "" {
+			fmt.Fprintf(output, "  %s%s\n", function.FilePath, concernSuffix(function))
+			continue
+		}
+		fmt.Fprintf(output, "  %s%s (%s)%s\n", function.FunctionName, function.Overload, function.FilePath, concernSuffix(function))
+	}
+	output.WriteString("\n")
+}
+
+// concernSuffix names the concern a resolved item had, or is empty for a hotspot
+func concernSuffix(function DigestFunction) string {
+	if function.Concern == "" {
+		return ""
+	}
+	return " — " + function.Concern
+}
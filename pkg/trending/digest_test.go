@@ -0,0 +1,110 @@
+package trending
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/alexcollie/kaizen/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func digestSeries(metricName string, values ...float64) MetricSeries {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	points := make([]storage.TimeSeriesPoint, len(values))
+	for index, value := range values {
+		points[index] = storage.TimeSeriesPoint{Timestamp: start.AddDate(0, 0, index), Value: value}
+	}
+	return MetricSeries{MetricName: metricName, Points: points}
+}
+
+func TestRankMetricChanges(t *testing.T) {
+	series := []MetricSeries{
+		digestSeries("overall_score", 80, 70),           // -12.5%, worse
+		digestSeries("avg_cyclomatic_complexity", 4, 6), // +50%, worse
+		digestSeries("hotspot_count", 4, 2),             // -50%, better
+		digestSeries("maintainability_score", 60, 66),   // +10%, better
+		digestSeries("concern_density", 0, 3),           // from zero, worse
+		digestSeries("avg_function_length", 20, 20.01),  // flat
+		digestSeries("churn_score", 70),                 // a single point has no change
+	}
+
+	regressed, improved := rankMetricChanges(series, 2)
+
+	require.Len(t, regressed, 2)
+	assert.Equal(t, "concern_density", regressed[0].MetricName)
+	assert.Equal(t, 100.0, regressed[0].PercentChange)
+	assert.Equal(t, "avg_cyclomatic_complexity", regressed[1].MetricName)
+
+	require.Len(t, improved, 2)
+	assert.Equal(t, "hotspot_count", improved[0].MetricName)
+	assert.Equal(t, "maintainability_score", improved[1].MetricName)
+	assert.InDelta(t, 10.0, improved[1].PercentChange, 0.001)
+}
+
+func TestBuildDigest(t *testing.T) {
+	snapshots := []storage.SnapshotSummary{
+		{ID: 1, OverallGrade: "B", OverallScore: 78},
+		{ID: 2, OverallGrade: "B", OverallScore: 79},
+		{ID: 3, OverallGrade: "A", OverallScore: 86},
+	}
+	first := &models.AnalysisResult{
+		Files: []models.FileAnalysis{{
+			Path: "api/handler.go",
+			Functions: []models.FunctionAnalysis{
+				{Name: "serve", IsHotspot: true},
+				{Name: "route"},
+			},
+		}},
+		ScoreReport: &models.ScoreReport{Concerns: []models.Concern{
+			{Type: "deep_nesting", Title: "Deep Nesting", AffectedItems: []models.AffectedItem{
+				{FilePath: "api/handler.go", FunctionName: "serve", Line: 10},
+				{FilePath: "api/handler.go", FunctionName: "route", Line: 40},
+			}},
+		}},
+	}
+	last := &models.AnalysisResult{
+		Files: []models.FileAnalysis{{
+			Path: "api/handler.go",
+			Functions: []models.FunctionAnalysis{
+				{Name: "serve", IsHotspot: true},
+				{Name: "route", IsHotspot: true},
+			},
+		}},
+		ScoreReport: &models.ScoreReport{Concerns: []models.Concern{
+			// The same item on a different line is still the same concern
+			{Type: "deep_nesting", Title: "Deep Nesting", AffectedItems: []models.AffectedItem{
+				{FilePath: "api/handler.go", FunctionName: "route", Line: 52},
+			}},
+		}},
+	}
+
+	digest := BuildDigest(snapshots, nil, first, last, DigestTopMetrics)
+
+	assert.Equal(t, 3, digest.SnapshotCount)
+	assert.Equal(t, []string{"B", "A"}, digest.Grades)
+	assert.Equal(t, int64(1), digest.From.ID)
+	assert.Equal(t, int64(3), digest.To.ID)
+	assert.Equal(t, []DigestFunction{{FilePath: "api/handler.go", FunctionName: "route"}}, digest.NewHotspots)
+	assert.Equal(t, []DigestFunction{{FilePath: "api/handler.go", FunctionName: "serve", Concern: "Deep Nesting"}}, digest.ResolvedConcerns)
+
+	markdown := RenderDigestMarkdown(digest, 1)
+	assert.Contains(t, markdown, "**Grade:** B → A (score 78.0 → 86.0, +8.0 over 3 snapshots)")
+	assert.Contains(t, markdown, "### New hotspots (1)\n\n- `route` in `api/handler.go`\n")
+	assert.Contains(t, markdown, "- `serve` in `api/handler.go` — Deep Nesting\n")
+
+	ascii := RenderDigestASCII(digest, 1)
+	assert.Contains(t, ascii, "Grade:  B → A\n")
+	assert.Contains(t, ascii, "🔥 New Hotspots (1):\n  route (api/handler.go)\n")
+}
+
+func TestRenderDigestLimitsLists(t *testing.T) {
+	digest := Digest{Grades: []string{"C"}}
+	for index := 0; index < digestListLimit+3; index++ {
+		digest.NewHotspots = append(digest.NewHotspots, DigestFunction{FilePath: "main.go", FunctionName: "f"})
+	}
+
+	assert.Contains(t, RenderDigestMarkdown(digest, 1), "- …and 3 more\n")
+	assert.Contains(t, RenderDigestASCII(digest, 1), "  ...and 3 more\n")
+}
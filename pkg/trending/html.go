@@ -3,44 +3,51 @@ package trending
 import (
 	"encoding/json"
 	"fmt"
+	"html/template"
+	"math"
 	"os"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/alexcollie/kaizen/pkg/storage"
 )
 
-// RenderHTMLChart generates an interactive HTML chart using Chart.js
-func RenderHTMLChart(metricName string, points []storage.TimeSeriesPoint, scopePath string) (string, error) {
-	if len(points) == 0 {
-		return "", fmt.Errorf("no data available for metric: %s", metricName)
-	}
+// overlayScaleMax is the top of the shared axis when several metrics are overlaid
+const overlayScaleMax = 100
 
-	// Prepare data for JSON
-	labels := make([]string, len(points))
-	data := make([]float64, len(points))
+// percentScaleMetrics are the metrics already on a 0-100 scale; overlaid charts plot them as
+// they are and scale every other metric to a percentage of its peak
+var percentScaleMetrics = map[string]bool{
+	"overall_score":             true,
+	"complexity_score":          true,
+	"maintainability_score":     true,
+	"churn_score":               true,
+	"avg_maintainability_index": true,
+}
 
-	for i, p := range points {
-		labels[i] = p.Timestamp.Format("2006-01-02 15:04")
-		data[i] = p.Value
-	}
+// seriesColors are the line colors of an overlaid chart, in series order
+var seriesColors = []string{"#C97064", "#5E81AC", "#A3BE8C", "#D08770", "#B48EAD", "#88C0D0", "#EBCB8B", "#4C566A"}
 
-	// Create JSON data
-	chartData := map[string]interface{}{
-		"labels": labels,
-		"data":   data,
-	}
+// chartSeries is one line of the HTML chart. Values and Plotted are aligned with the chart
+// labels and hold null where the metric has no point at that time.
+type chartSeries struct {
+	Name    string     `json:"name"`
+	Label   string     `json:"label"`
+	Color   string     `json:"color"`
+	Values  []*float64 `json:"values"`  // Values as recorded, shown in tooltips and stats
+	Plotted []*float64 `json:"plotted"` // Values as drawn; scaled to 0-100 when overlaid
+}
 
-	jsonData, err := json.Marshal(chartData)
+// RenderHTMLChart generates an interactive HTML chart using Chart.js, with a plain white
+// background and black text when lightTheme is set. A single series is drawn on its own axis;
+// several are overlaid on a shared 0-100 axis with a legend that toggles each one.
+func RenderHTMLChart(series []MetricSeries, scopePath string, lightTheme bool) (string, error) {
+	title, pointCount, jsonData, err := buildChartPage(series, scopePath)
 	if err != nil {
 		return "", err
 	}
 
-	// Create title
-	title := metricName
-	if scopePath != "" {
-		title = fmt.Sprintf("%s - %s", metricName, scopePath)
-	}
-
 	html := fmt.Sprintf(`<!DOCTYPE html>
 <html lang="en">
 <head>
@@ -117,6 +124,39 @@ func RenderHTMLChart(metricName string, points []storage.TimeSeriesPoint, scopeP
             font-size: 24px;
             font-weight: bold;
         }
+        .chart-note {
+            color: #6B6B68;
+            font-size: 13px;
+            margin-top: -15px;
+            margin-bottom: 30px;
+        }
+        .series-stats {
+            width: 100%%;
+            border-collapse: collapse;
+            margin-top: 30px;
+            font-size: 14px;
+        }
+        .series-stats th {
+            text-align: left;
+            color: #6B6B68;
+            font-size: 12px;
+            text-transform: uppercase;
+            letter-spacing: 0.05em;
+            padding: 8px 12px;
+            border-bottom: 2px solid #E8E4DA;
+        }
+        .series-stats td {
+            color: #2D2D2A;
+            padding: 10px 12px;
+            border-bottom: 1px solid #E8E4DA;
+        }
+        .series-swatch {
+            display: inline-block;
+            width: 10px;
+            height: 10px;
+            border-radius: 50%%;
+            margin-right: 8px;
+        }
         .footer {
             margin-top: 30px;
             padding-top: 20px;
@@ -124,9 +164,73 @@ func RenderHTMLChart(metricName string, points []storage.TimeSeriesPoint, scopeP
             color: #9A9A97;
             font-size: 12px;
         }
+
+        /* Light theme (--theme=light): plain white background and black text */
+        body.theme-light {
+            background: white;
+        }
+        body.theme-light .container {
+            box-shadow: none;
+            border: 1px solid #DDD;
+        }
+        body.theme-light h1,
+        body.theme-light .stat-value,
+        body.theme-light .series-stats td {
+            color: black;
+        }
+        body.theme-light .subtitle,
+        body.theme-light .stat-label,
+        body.theme-light .chart-note,
+        body.theme-light .series-stats th,
+        body.theme-light .footer {
+            color: #333;
+        }
+        body.theme-light .chart-container {
+            background: white;
+            border: 1px solid #DDD;
+        }
+        body.theme-light .stat-card {
+            background: #F4F4F4;
+            border-left-color: black;
+        }
+
+        /* Print and PDF export: light colors and nothing split across pages */
+        @media print {
+            @page {
+                margin: 1.5cm;
+            }
+            body {
+                background: white;
+                padding: 0;
+            }
+            .container {
+                max-width: none;
+                box-shadow: none;
+                padding: 0;
+            }
+            h1, .stat-value, .series-stats td {
+                color: black;
+            }
+            .subtitle, .stat-label, .chart-note, .series-stats th, .footer {
+                color: #333;
+            }
+            .chart-container {
+                background: white;
+                border: 1px solid #DDD;
+                break-inside: avoid;
+            }
+            .stat-card, .series-stats {
+                background: white;
+                border: 1px solid #DDD;
+                break-inside: avoid;
+            }
+            canvas {
+                max-width: 100%%;
+            }
+        }
     </style>
 </head>
-<body>
+<body%s>
     <div class="container">
         <h1>📈 %s</h1>
         <div class="subtitle">Generated at %s</div>
@@ -134,8 +238,8 @@ func RenderHTMLChart(metricName string, points []storage.TimeSeriesPoint, scopeP
         <div class="chart-container">
             <canvas id="trendChart"></canvas>
         </div>
-
-        <div class="stats">
+%s
+        <div class="stats" id="singleStats">
             <div class="stat-card">
                 <div class="stat-label">Current Value</div>
                 <div class="stat-value" id="currentValue">-</div>
@@ -162,6 +266,13 @@ func RenderHTMLChart(metricName string, points []storage.TimeSeriesPoint, scopeP
             </div>
         </div>
 
+        <table class="series-stats" id="seriesStats" style="display: none">
+            <thead>
+                <tr><th>Metric</th><th>Current</th><th>Min</th><th>Max</th><th>Average</th><th>Change</th></tr>
+            </thead>
+            <tbody></tbody>
+        </table>
+
         <div class="footer">
             Generated by Kaizen · %s
         </div>
@@ -169,34 +280,37 @@ func RenderHTMLChart(metricName string, points []storage.TimeSeriesPoint, scopeP
 
     <script>
         const chartData = %s;
+        const overlaid = chartData.series.length > 1;
 
         const ctx = document.getElementById('trendChart').getContext('2d');
         const chart = new Chart(ctx, {
             type: 'line',
             data: {
                 labels: chartData.labels,
-                datasets: [{
-                    label: '%s',
-                    data: chartData.data,
-                    borderColor: '#C97064',
-                    backgroundColor: 'rgba(201, 112, 100, 0.1)',
+                datasets: chartData.series.map(series => ({
+                    label: series.label,
+                    data: series.plotted,
+                    values: series.values,
+                    borderColor: series.color,
+                    backgroundColor: series.color + '1A',
                     borderWidth: 3,
-                    fill: true,
+                    fill: !overlaid,
+                    spanGaps: true,
                     tension: 0.4,
                     pointRadius: 5,
-                    pointBackgroundColor: '#C97064',
+                    pointBackgroundColor: series.color,
                     pointBorderColor: '#fff',
                     pointBorderWidth: 2,
                     pointHoverRadius: 7,
-                    pointHoverBackgroundColor: '#B85C50',
-                }]
+                }))
             },
             options: {
                 responsive: true,
                 maintainAspectRatio: false,
                 plugins: {
                     legend: {
-                        display: false,
+                        // Clicking a legend entry hides or shows its series
+                        display: overlaid,
                     },
                     tooltip: {
                         backgroundColor: 'rgba(0, 0, 0, 0.8)',
@@ -204,11 +318,16 @@ func RenderHTMLChart(metricName string, points []storage.TimeSeriesPoint, scopeP
                         titleFont: { size: 14 },
                         bodyFont: { size: 13 },
                         cornerRadius: 6,
+                        callbacks: {
+                            // Scaled series still report their recorded value
+                            label: context => context.dataset.label + ': ' + context.dataset.values[context.dataIndex].toFixed(1),
+                        }
                     }
                 },
                 scales: {
                     y: {
-                        beginAtZero: false,
+                        beginAtZero: overlaid,
+                        max: overlaid ? %d : undefined,
                         grid: {
                             color: '#f0f0f0',
                         },
@@ -231,26 +350,210 @@ func RenderHTMLChart(metricName string, points []storage.TimeSeriesPoint, scopeP
         });
 
         // Calculate and display statistics
-        const values = chartData.data;
-        const min = Math.min(...values);
-        const max = Math.max(...values);
-        const avg = values.reduce((a, b) => a + b) / values.length;
-        const current = values[values.length - 1];
-        const change = current - values[0];
-
-        document.getElementById('currentValue').textContent = current.toFixed(1);
-        document.getElementById('minValue').textContent = min.toFixed(1);
-        document.getElementById('maxValue').textContent = max.toFixed(1);
-        document.getElementById('avgValue').textContent = avg.toFixed(1);
-        document.getElementById('changeValue').textContent = (change >= 0 ? '+' : '') + change.toFixed(1);
+        const formatChange = change => (change >= 0 ? '+' : '') + change.toFixed(1);
+        const stats = chartData.series.map(series => {
+            const values = series.values.filter(value => value !== null);
+            const current = values[values.length - 1];
+            return {
+                series: series,
+                current: current,
+                min: Math.min(...values),
+                max: Math.max(...values),
+                avg: values.reduce((a, b) => a + b) / values.length,
+                change: current - values[0],
+            };
+        });
+
+        if (!overlaid) {
+            document.getElementById('currentValue').textContent = stats[0].current.toFixed(1);
+            document.getElementById('minValue').textContent = stats[0].min.toFixed(1);
+            document.getElementById('maxValue').textContent = stats[0].max.toFixed(1);
+            document.getElementById('avgValue').textContent = stats[0].avg.toFixed(1);
+            document.getElementById('changeValue').textContent = formatChange(stats[0].change);
+        } else {
+            document.getElementById('singleStats').style.display = 'none';
+            const table = document.getElementById('seriesStats');
+            table.style.display = '';
+            stats.forEach(stat => {
+                const row = table.tBodies[0].insertRow();
+                const nameCell = row.insertCell();
+                const swatch = document.createElement('span');
+                swatch.className = 'series-swatch';
+                swatch.style.background = stat.series.color;
+                nameCell.appendChild(swatch);
+                nameCell.appendChild(document.createTextNode(stat.series.name));
+                [stat.current.toFixed(1), stat.min.toFixed(1), stat.max.toFixed(1), stat.avg.toFixed(1), formatChange(stat.change)]
+                    .forEach(value => { row.insertCell().textContent = value; });
+            });
+        }
     </script>
 </body>
 </html>
-`, title, title, time.Now().Format("2006-01-02 15:04:05"), len(points), time.Now().Format("2006-01-02 15:04:05"), string(jsonData), metricName)
+`, title, themeAttribute(lightTheme), title, time.Now().Format("2006-01-02 15:04:05"), overlayNote(series), pointCount, time.Now().Format("2006-01-02 15:04:05"), string(jsonData), overlayScaleMax)
 
 	return html, nil
 }
 
+// ChartTemplateData is what a custom trend chart template is executed with
+type ChartTemplateData struct {
+	Title       string      // Metric names, and the folder when the chart is scoped to one
+	ChartData   template.JS // {"labels": [...], "series": [{"name", "label", "color", "values", "plotted"}]}
+	Overlaid    bool        // Several metrics share a 0-100 axis, with plotted values scaled
+	ScaleMax    int         // Top of the shared axis of an overlaid chart
+	PointCount  int         // Data points on the time axis
+	LightTheme  bool
+	GeneratedAt string
+}
+
+// RenderHTMLChartTemplate renders the trend chart with a user-supplied html/template instead of
+// the built-in page, for branded reports. The template is executed with ChartTemplateData.
+func RenderHTMLChartTemplate(series []MetricSeries, scopePath string, lightTheme bool, templateSource string) (string, error) {
+	title, pointCount, jsonData, err := buildChartPage(series, scopePath)
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := template.New("trend").Parse(templateSource)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	data := ChartTemplateData{
+		Title:       title,
+		ChartData:   template.JS(jsonData),
+		Overlaid:    len(series) > 1,
+		ScaleMax:    overlayScaleMax,
+		PointCount:  pointCount,
+		LightTheme:  lightTheme,
+		GeneratedAt: time.Now().Format("2006-01-02 15:04:05"),
+	}
+
+	var builder strings.Builder
+	if err := tmpl.Execute(&builder, data); err != nil {
+		return "", fmt.Errorf("failed to execute template: %w", err)
+	}
+	return builder.String(), nil
+}
+
+// buildChartPage checks that every series has points and returns the chart title, the number
+// of points on the time axis, and the chart data as JSON
+func buildChartPage(series []MetricSeries, scopePath string) (string, int, []byte, error) {
+	if len(series) == 0 {
+		return "", 0, nil, fmt.Errorf("no metrics to chart")
+	}
+	metricNames := make([]string, len(series))
+	for index, metric := range series {
+		if len(metric.Points) == 0 {
+			return "", 0, nil, fmt.Errorf("no data available for metric: %s", metric.MetricName)
+		}
+		metricNames[index] = metric.MetricName
+	}
+
+	labels, lines := buildChartSeries(series)
+
+	// Create JSON data
+	chartData := map[string]interface{}{
+		"labels": labels,
+		"series": lines,
+	}
+
+	jsonData, err := json.Marshal(chartData)
+	if err != nil {
+		return "", 0, nil, err
+	}
+
+	// Create title
+	title := strings.Join(metricNames, ", ")
+	if scopePath != "" {
+		title = fmt.Sprintf("%s - %s", title, scopePath)
+	}
+
+	return title, len(labels), jsonData, nil
+}
+
+// buildChartSeries aligns every series on the union of their timestamps, oldest first, and
+// scales the plotted values for overlaid charts
+func buildChartSeries(series []MetricSeries) ([]string, []chartSeries) {
+	var timestamps []time.Time
+	seen := make(map[time.Time]bool)
+	for _, metric := range series {
+		for _, point := range metric.Points {
+			if !seen[point.Timestamp] {
+				seen[point.Timestamp] = true
+				timestamps = append(timestamps, point.Timestamp)
+			}
+		}
+	}
+	sort.Slice(timestamps, func(first, second int) bool {
+		return timestamps[first].Before(timestamps[second])
+	})
+
+	labels := make([]string, len(timestamps))
+	positions := make(map[time.Time]int, len(timestamps))
+	for index, timestamp := range timestamps {
+		labels[index] = timestamp.Format("2006-01-02 15:04")
+		positions[timestamp] = index
+	}
+
+	overlaid := len(series) > 1
+	lines := make([]chartSeries, len(series))
+	for index, metric := range series {
+		scale, scaled := 1.0, false
+		if overlaid && !percentScaleMetrics[metric.MetricName] {
+			scale, scaled = peakScale(metric.Points), true
+		}
+
+		line := chartSeries{
+			Name:    metric.MetricName,
+			Label:   metric.MetricName,
+			Color:   seriesColors[index%len(seriesColors)],
+			Values:  make([]*float64, len(labels)),
+			Plotted: make([]*float64, len(labels)),
+		}
+		if scaled {
+			line.Label += " (% of peak)"
+		}
+		for _, point := range metric.Points {
+			value, plotted := point.Value, point.Value*scale
+			line.Values[positions[point.Timestamp]] = &value
+			line.Plotted[positions[point.Timestamp]] = &plotted
+		}
+		lines[index] = line
+	}
+	return labels, lines
+}
+
+// peakScale is the factor that maps a series' largest magnitude to overlayScaleMax
+func peakScale(points []storage.TimeSeriesPoint) float64 {
+	peak := 0.0
+	for _, point := range points {
+		peak = math.Max(peak, math.Abs(point.Value))
+	}
+	if peak == 0 {
+		return 1
+	}
+	return overlayScaleMax / peak
+}
+
+// overlayNote explains the legend toggles and scaling of an overlaid chart; single-metric charts
+// need no note
+func overlayNote(series []MetricSeries) string {
+	if len(series) < 2 {
+		return ""
+	}
+	return `
+        <div class="chart-note">Click a legend entry to hide or show its metric. Scores and maintainability index are plotted as recorded; other metrics as a percentage of their highest value in the range. Tooltips show recorded values.</div>
+`
+}
+
+// themeAttribute returns the body attribute selecting the chart theme
+func themeAttribute(lightTheme bool) string {
+	if lightTheme {
+		return ` class="theme-light"`
+	}
+	return ""
+}
+
 // WriteHTMLToFile writes HTML chart to file and returns path
 func WriteHTMLToFile(html, outputPath string) error {
 	return os.WriteFile(outputPath, []byte(html), 0644)
@@ -0,0 +1,98 @@
+package trending
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alexcollie/kaizen/pkg/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderHTMLChartTheme(t *testing.T) {
+	points := []storage.TimeSeriesPoint{
+		{Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Value: 72},
+		{Timestamp: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), Value: 75},
+	}
+
+	html, err := RenderHTMLChart([]MetricSeries{{MetricName: "overall_score", Points: points}}, "", false)
+	require.NoError(t, err)
+	assert.Contains(t, html, "@media print")
+	assert.Contains(t, html, "<body>")
+
+	html, err = RenderHTMLChart([]MetricSeries{{MetricName: "overall_score", Points: points}}, "", true)
+	require.NoError(t, err)
+	assert.Contains(t, html, `<body class="theme-light">`)
+	assert.NotContains(t, html, "%!")
+}
+
+func TestRenderHTMLChartNoData(t *testing.T) {
+	_, err := RenderHTMLChart([]MetricSeries{{MetricName: "overall_score"}}, "", false)
+	assert.Error(t, err)
+
+	_, err = RenderHTMLChart(nil, "", false)
+	assert.Error(t, err)
+}
+
+func TestRenderHTMLChartTemplate(t *testing.T) {
+	points := []storage.TimeSeriesPoint{
+		{Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Value: 72},
+		{Timestamp: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), Value: 75},
+	}
+	series := []MetricSeries{{MetricName: "overall_score", Points: points}}
+
+	html, err := RenderHTMLChartTemplate(series, "pkg/api", true, `<title>{{.Title}}</title>{{if .LightTheme}}light{{end}} {{.PointCount}}<script>const data = {{.ChartData}};</script>`)
+	require.NoError(t, err)
+	assert.Contains(t, html, "<title>overall_score - pkg/api</title>light 2")
+	assert.Contains(t, html, `const data = {"labels":`)
+
+	_, err = RenderHTMLChartTemplate(series, "", false, "{{.Title")
+	assert.Error(t, err)
+}
+
+func TestRenderHTMLChartOverlaidMetrics(t *testing.T) {
+	first := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	second := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	series := []MetricSeries{
+		{MetricName: "overall_score", Points: []storage.TimeSeriesPoint{{Timestamp: first, Value: 72}, {Timestamp: second, Value: 75}}},
+		{MetricName: "hotspot_count", Points: []storage.TimeSeriesPoint{{Timestamp: second, Value: 8}}},
+	}
+
+	html, err := RenderHTMLChart(series, "", false)
+	require.NoError(t, err)
+	assert.Contains(t, html, "Kaizen Trend: overall_score, hotspot_count")
+	assert.Contains(t, html, "Click a legend entry")
+	assert.NotContains(t, html, "%!")
+
+	single, err := RenderHTMLChart(series[:1], "", false)
+	require.NoError(t, err)
+	assert.NotContains(t, single, "Click a legend entry")
+}
+
+func TestBuildChartSeries(t *testing.T) {
+	first := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	second := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	series := []MetricSeries{
+		{MetricName: "complexity_score", Points: []storage.TimeSeriesPoint{{Timestamp: second, Value: 60}}},
+		{MetricName: "hotspot_count", Points: []storage.TimeSeriesPoint{{Timestamp: first, Value: 4}, {Timestamp: second, Value: 8}}},
+	}
+
+	labels, lines := buildChartSeries(series)
+	assert.Equal(t, []string{"2024-01-01 00:00", "2024-02-01 00:00"}, labels)
+	require.Len(t, lines, 2)
+	assert.NotEqual(t, lines[0].Color, lines[1].Color)
+
+	// 0-100 metrics are plotted as recorded; a missing point stays null
+	assert.Nil(t, lines[0].Plotted[0])
+	assert.InDelta(t, 60, *lines[0].Plotted[1], 0.001)
+	assert.Equal(t, "complexity_score", lines[0].Label)
+
+	// Other metrics are scaled to a percentage of their peak, keeping recorded values for tooltips
+	assert.InDelta(t, 50, *lines[1].Plotted[0], 0.001)
+	assert.InDelta(t, 100, *lines[1].Plotted[1], 0.001)
+	assert.InDelta(t, 4, *lines[1].Values[0], 0.001)
+	assert.Equal(t, "hotspot_count (% of peak)", lines[1].Label)
+
+	_, lines = buildChartSeries(series[1:])
+	assert.InDelta(t, 4, *lines[0].Plotted[0], 0.001, "a single series keeps its own axis")
+}
@@ -0,0 +1,119 @@
+package trending
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/alexcollie/kaizen/pkg/storage"
+)
+
+// SparklineWidth is the most characters a dashboard sparkline uses; longer histories are averaged down
+const SparklineWidth = 40
+
+// sparkBlocks are the sparkline levels, lowest first
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// DashboardMetrics lists the repository metrics shown by `trend --all`, in display order
+var DashboardMetrics = []string{
+	"overall_score",
+	"complexity_score",
+	"maintainability_score",
+	"churn_score",
+	"avg_cyclomatic_complexity",
+	"avg_cognitive_complexity",
+	"avg_function_length",
+	"avg_maintainability_index",
+	"hotspot_count",
+	"hotspot_density",
+	"concern_density",
+}
+
+// MetricSeries is one metric's time-series data
+type MetricSeries struct {
+	MetricName string
+	Points     []storage.TimeSeriesPoint
+}
+
+// RenderSparkline draws values as a one-line Unicode sparkline of at most width characters.
+// Flat data is drawn at the middle level.
+func RenderSparkline(values []float64, width int) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	values = scaleDownPoints(values, width)
+
+	minVal, maxVal := values[0], values[0]
+	for _, value := range values {
+		minVal = math.Min(minVal, value)
+		maxVal = math.Max(maxVal, value)
+	}
+
+	var sparkline strings.Builder
+	for _, value := range values {
+		level := len(sparkBlocks) / 2
+		if maxVal > minVal {
+			level = int(math.Round((value - minVal) / (maxVal - minVal) * float64(len(sparkBlocks)-1)))
+		}
+		sparkline.WriteRune(sparkBlocks[level])
+	}
+	return sparkline.String()
+}
+
+// RenderSparklineDashboard prints one line per series: metric name, sparkline, latest value,
+// and an arrow with the change since the first point. Series without points are left out.
+func RenderSparklineDashboard(series []MetricSeries, scopePath string) string {
+	var output strings.Builder
+
+	title := "📈 Metric Trends"
+	if scopePath != "" {
+		title = fmt.Sprintf("📈 Metric Trends - %s", scopePath)
+	}
+	output.WriteString(title + "\n\n")
+
+	nameWidth, sparklineWidth := 0, 0
+	sparklines := make([]string, len(series))
+	for index, metric := range series {
+		if len(metric.Points) == 0 {
+			continue
+		}
+
+		values := make([]float64, len(metric.Points))
+		for pointIndex, point := range metric.Points {
+			values[pointIndex] = point.Value
+		}
+		sparklines[index] = RenderSparkline(values, SparklineWidth)
+
+		nameWidth = max(nameWidth, len(metric.MetricName))
+		sparklineWidth = max(sparklineWidth, utf8.RuneCountInString(sparklines[index]))
+	}
+
+	for index, metric := range series {
+		if len(metric.Points) == 0 {
+			continue
+		}
+
+		// Pad by rune count, since fmt width counts the multi-byte block characters as bytes
+		sparkline := sparklines[index] + strings.Repeat(" ", sparklineWidth-utf8.RuneCountInString(sparklines[index]))
+		first, current := metric.Points[0].Value, metric.Points[len(metric.Points)-1].Value
+
+		fmt.Fprintf(&output, "  %-*s  %s  %8.1f  %s\n",
+			nameWidth, metric.MetricName, sparkline, current, trendArrow(current-first))
+	}
+
+	return output.String()
+}
+
+// trendArrow shows the direction and size of a change, treating anything under 0.05 as flat
+func trendArrow(delta float64) string {
+	switch {
+	case delta >= 0.05:
+		return fmt.Sprintf("↑ +%.1f", delta)
+	case delta <= -0.05:
+		return fmt.Sprintf("↓ %.1f", delta)
+	default:
+		return "→ 0.0"
+	}
+}
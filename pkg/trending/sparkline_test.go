@@ -0,0 +1,99 @@
+package trending
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/alexcollie/kaizen/pkg/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderSparklineEmpty(t *testing.T) {
+	assert.Equal(t, "", RenderSparkline(nil, SparklineWidth))
+}
+
+func TestRenderSparklineScalesToRange(t *testing.T) {
+	sparkline := RenderSparkline([]float64{0, 1, 2, 3, 4, 5, 6, 7}, SparklineWidth)
+
+	assert.Equal(t, "▁▂▃▄▅▆▇█", sparkline)
+}
+
+func TestRenderSparklineFlatData(t *testing.T) {
+	sparkline := RenderSparkline([]float64{3, 3, 3}, SparklineWidth)
+
+	assert.Equal(t, "▅▅▅", sparkline)
+}
+
+func TestRenderSparklineLimitsWidth(t *testing.T) {
+	values := make([]float64, 100)
+	for index := range values {
+		values[index] = float64(index)
+	}
+
+	sparkline := RenderSparkline(values, 10)
+
+	assert.Equal(t, 10, len([]rune(sparkline)))
+}
+
+func TestRenderSparklineDashboard(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	series := []MetricSeries{
+		{
+			MetricName: "overall_score",
+			Points: []storage.TimeSeriesPoint{
+				{Timestamp: start, Value: 70},
+				{Timestamp: start.AddDate(0, 0, 1), Value: 78.4},
+			},
+		},
+		{
+			MetricName: "hotspot_count",
+			Points: []storage.TimeSeriesPoint{
+				{Timestamp: start, Value: 6},
+				{Timestamp: start.AddDate(0, 0, 1), Value: 4},
+			},
+		},
+		{
+			MetricName: "churn_score",
+			Points: []storage.TimeSeriesPoint{
+				{Timestamp: start, Value: 50},
+				{Timestamp: start.AddDate(0, 0, 1), Value: 50},
+			},
+		},
+		{MetricName: "concern_density"},
+	}
+
+	output := RenderSparklineDashboard(series, "")
+
+	assert.Contains(t, output, "Metric Trends")
+	assert.Contains(t, output, "78.4  ↑ +8.4")
+	assert.Contains(t, output, "4.0  ↓ -2.0")
+	assert.Contains(t, output, "50.0  → 0.0")
+	assert.NotContains(t, output, "concern_density")
+	assert.Equal(t, 3, strings.Count(output, "\n  "))
+}
+
+func TestRenderSparklineDashboardFolderTitle(t *testing.T) {
+	output := RenderSparklineDashboard(nil, "pkg/api")
+
+	assert.Contains(t, output, "Metric Trends - pkg/api")
+}
+
+func TestRenderSparklineDashboardAlignsColumns(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	shortSeries := []storage.TimeSeriesPoint{{Timestamp: start, Value: 1}, {Timestamp: start.AddDate(0, 0, 1), Value: 2}}
+	longSeries := make([]storage.TimeSeriesPoint, 10)
+	for index := range longSeries {
+		longSeries[index] = storage.TimeSeriesPoint{Timestamp: start.AddDate(0, 0, index), Value: float64(index)}
+	}
+
+	output := RenderSparklineDashboard([]MetricSeries{
+		{MetricName: "hotspot_count", Points: shortSeries},
+		{MetricName: "overall_score", Points: longSeries},
+	}, "")
+
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	lastTwo := lines[len(lines)-2:]
+	assert.Equal(t, utf8.RuneCountInString(lastTwo[0]), utf8.RuneCountInString(lastTwo[1]))
+}
@@ -1,9 +1,11 @@
 package visualization
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"html/template"
+	"io"
 	"math"
 	"os"
 
@@ -104,6 +106,25 @@ const callGraphHTMLTemplate = `<!DOCTYPE html>
             width: 150px;
         }
 
+        input[type="search"] {
+            width: 220px;
+            padding: 6px 10px;
+            background: #3a3a3a;
+            border: 1px solid #4a4a4a;
+            color: white;
+            border-radius: 4px;
+            font-size: 14px;
+        }
+
+        input[type="search"]:focus {
+            outline: none;
+            border-color: #667eea;
+        }
+
+        input[type="search"].not-found {
+            border-color: #ef4444;
+        }
+
         button {
             padding: 8px 16px;
             background: #667eea;
@@ -119,6 +140,12 @@ const callGraphHTMLTemplate = `<!DOCTYPE html>
             background: #5568d3;
         }
 
+        button:disabled {
+            background: #3a3a3a;
+            color: #777;
+            cursor: default;
+        }
+
         #graph-container {
             position: relative;
             width: 100%;
@@ -151,6 +178,11 @@ const callGraphHTMLTemplate = `<!DOCTYPE html>
             stroke-width: 3px;
         }
 
+        .node.selected circle {
+            stroke: white;
+            stroke-width: 4px;
+        }
+
         .node text {
             font-size: 11px;
             fill: white;
@@ -267,6 +299,12 @@ const callGraphHTMLTemplate = `<!DOCTYPE html>
             <label for="show-external">Show External:</label>
             <input type="checkbox" id="show-external" checked>
         </div>
+        <div class="control-group">
+            <input type="search" id="node-search" list="node-names" placeholder="Find function…" aria-label="Find function">
+            <datalist id="node-names"></datalist>
+            <button id="find-node">Find</button>
+            <button id="isolate-node" disabled>Isolate</button>
+        </div>
         <button onclick="resetZoom()">Reset View</button>
     </div>
 
@@ -410,7 +448,7 @@ const callGraphHTMLTemplate = `<!DOCTYPE html>
                     '</div>');
 
             // Highlight connected nodes
-            link.classed('highlighted', l => l.source.id === d.id || l.target.id === d.id);
+            highlightLinks(d.id);
         })
         .on('mousemove', (event) => {
             tooltip.style('left', (event.pageX + 15) + 'px')
@@ -418,7 +456,10 @@ const callGraphHTMLTemplate = `<!DOCTYPE html>
         })
         .on('mouseout', () => {
             tooltip.style('display', 'none');
-            link.classed('highlighted', false);
+            highlightLinks(selectedNode ? selectedNode.id : null);
+        })
+        .on('click', (event, d) => {
+            selectNode(d);
         });
 
         // Update simulation
@@ -525,15 +566,102 @@ const callGraphHTMLTemplate = `<!DOCTYPE html>
             labels.style('display', this.checked ? 'block' : 'none');
         });
 
-        d3.select('#show-external').on('change', function() {
-            const showExternal = this.checked;
-            node.style('display', d => (showExternal || !d.is_external) ? 'block' : 'none');
-            link.style('display', l => {
-                if (!showExternal) {
-                    return (l.source.is_external || l.target.is_external) ? 'none' : 'block';
-                }
-                return 'block';
-            });
+        d3.select('#show-external').on('change', applyVisibility);
+
+        // Search and isolate
+        let selectedNode = null;
+        let isolatedIds = null;
+
+        const nodeNames = d3.select('#node-names');
+        Array.from(new Set(nodes.map(n => n.name))).sort().forEach(name => {
+            nodeNames.append('option').attr('value', name);
+        });
+
+        function highlightLinks(id) {
+            link.classed('highlighted', l => id !== null && (l.source.id === id || l.target.id === id));
+        }
+
+        // findNode prefers an exact full name, then an exact name, then a substring match;
+        // ties go to the most called function
+        function findNode(query) {
+            const needle = query.trim().toLowerCase();
+            if (!needle) return null;
+
+            const byCallCount = (a, b) => b.call_count - a.call_count;
+            const matchers = [
+                n => n.id.toLowerCase() === needle,
+                n => n.name.toLowerCase() === needle,
+                n => n.id.toLowerCase().includes(needle)
+            ];
+            for (const matches of matchers) {
+                const found = nodes.filter(matches).sort(byCallCount);
+                if (found.length > 0) return found[0];
+            }
+            return null;
+        }
+
+        function selectNode(d) {
+            selectedNode = d;
+            node.classed('selected', n => n === d);
+            highlightLinks(d ? d.id : null);
+
+            const isolateButton = document.getElementById('isolate-node');
+            isolateButton.disabled = !d;
+            if (isolatedIds !== null) {
+                isolate(d);
+            }
+        }
+
+        function centerOnNode(d) {
+            const scale = 1.5;
+            svg.transition().duration(750).call(
+                zoom.transform,
+                d3.zoomIdentity.translate(width / 2 - d.x * scale, height / 2 - d.y * scale).scale(scale)
+            );
+        }
+
+        function searchNode() {
+            const searchInput = document.getElementById('node-search');
+            const found = findNode(searchInput.value);
+            searchInput.classList.toggle('not-found', !found && searchInput.value.trim() !== '');
+            if (!found) return;
+
+            selectNode(found);
+            centerOnNode(found);
+        }
+
+        // isolate hides everything except d and its direct callers and callees; null shows all
+        function isolate(d) {
+            if (!d) {
+                isolatedIds = null;
+            } else {
+                isolatedIds = new Set([d.id]);
+                links.forEach(l => {
+                    if (l.source.id === d.id) isolatedIds.add(l.target.id);
+                    if (l.target.id === d.id) isolatedIds.add(l.source.id);
+                });
+            }
+            document.getElementById('isolate-node').textContent = isolatedIds === null ? 'Isolate' : 'Show All';
+            applyVisibility();
+        }
+
+        function isNodeVisible(d) {
+            const showExternal = document.getElementById('show-external').checked;
+            if (!showExternal && d.is_external) return false;
+            return isolatedIds === null || isolatedIds.has(d.id);
+        }
+
+        function applyVisibility() {
+            node.style('display', d => isNodeVisible(d) ? 'block' : 'none');
+            link.style('display', l => (isNodeVisible(l.source) && isNodeVisible(l.target)) ? 'block' : 'none');
+        }
+
+        d3.select('#find-node').on('click', searchNode);
+        d3.select('#node-search').on('keydown', event => {
+            if (event.key === 'Enter') searchNode();
+        });
+        d3.select('#isolate-node').on('click', () => {
+            isolate(isolatedIds === null ? selectedNode : null);
         });
 
         // Initial zoom
@@ -543,8 +671,30 @@ const callGraphHTMLTemplate = `<!DOCTYPE html>
 </html>
 `
 
-// GenerateCallGraphHTML generates an interactive HTML call graph visualization
-func GenerateCallGraphHTML(graph *models.CallGraph, outputPath string) error {
+// GenerateCallGraphHTML generates an interactive HTML call graph visualization, using
+// templateSource instead of the built-in page when it is not empty
+func GenerateCallGraphHTML(graph *models.CallGraph, outputPath string, templateSource string) error {
+	// Render before creating the file, so a failing template leaves no partial output behind
+	var page bytes.Buffer
+	if err := RenderCallGraphHTML(graph, &page, templateSource); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(outputPath, page.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	return nil
+}
+
+// CallGraphTemplateData is what the call graph page template, built-in or custom, is executed with
+type CallGraphTemplateData struct {
+	GraphDataJSON template.JS       // The graph as JSON: {"nodes": {full name: node}, "edges": [...], "stats": {...}}
+	Graph         *models.CallGraph // The same graph, for templates that render parts of it server-side
+}
+
+// RenderCallGraphHTML writes the interactive HTML call graph to a writer. A non-empty
+// templateSource replaces the built-in page and is executed with CallGraphTemplateData.
+func RenderCallGraphHTML(graph *models.CallGraph, writer io.Writer, templateSource string) error {
 	// Convert graph to JSON
 	graphJSON, err := json.Marshal(graph)
 	if err != nil {
@@ -552,26 +702,21 @@ func GenerateCallGraphHTML(graph *models.CallGraph, outputPath string) error {
 	}
 
 	// Create template
-	tmpl, err := template.New("callgraph").Parse(callGraphHTMLTemplate)
-	if err != nil {
-		return fmt.Errorf("failed to parse template: %w", err)
+	if templateSource == "" {
+		templateSource = callGraphHTMLTemplate
 	}
-
-	// Create output file
-	file, err := os.Create(outputPath)
+	tmpl, err := template.New("callgraph").Parse(templateSource)
 	if err != nil {
-		return fmt.Errorf("failed to create output file: %w", err)
+		return fmt.Errorf("failed to parse template: %w", err)
 	}
-	defer func() { _ = file.Close() }()
 
 	// Execute template
-	data := struct {
-		GraphDataJSON template.JS
-	}{
+	data := CallGraphTemplateData{
 		GraphDataJSON: template.JS(graphJSON),
+		Graph:         graph,
 	}
 
-	if err := tmpl.Execute(file, data); err != nil {
+	if err := tmpl.Execute(writer, data); err != nil {
 		return fmt.Errorf("failed to execute template: %w", err)
 	}
 
@@ -0,0 +1,54 @@
+package visualization
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderCallGraphHTMLSearchAndIsolate(t *testing.T) {
+	graph := &models.CallGraph{
+		Nodes: map[string]*models.CallNode{
+			"main.run":    {Name: "run", FullName: "main.run", CallCount: 1},
+			"main.handle": {Name: "handle", FullName: "main.handle"},
+		},
+		Edges: []models.CallEdge{{From: "main.run", To: "main.handle", Weight: 1}},
+	}
+
+	var builder strings.Builder
+	require.NoError(t, RenderCallGraphHTML(graph, &builder, ""))
+	html := builder.String()
+
+	assert.Contains(t, html, `id="node-search"`)
+	assert.Contains(t, html, `id="node-names"`)
+	assert.Contains(t, html, `id="isolate-node"`)
+	assert.Contains(t, html, "function isolate(d)")
+	assert.Contains(t, html, `"full_name":"main.handle"`)
+}
+
+func TestRenderCallGraphHTMLCustomTemplate(t *testing.T) {
+	graph := &models.CallGraph{
+		Nodes: map[string]*models.CallNode{"main.run": {Name: "run", FullName: "main.run"}},
+		Stats: models.CallGraphStats{TotalFunctions: 1},
+	}
+
+	var builder strings.Builder
+	require.NoError(t, RenderCallGraphHTML(graph, &builder, `{{.Graph.Stats.TotalFunctions}} function(s)`))
+	assert.Equal(t, "1 function(s)", builder.String())
+}
+
+func TestGenerateCallGraphHTMLBadTemplateWritesNoFile(t *testing.T) {
+	graph := &models.CallGraph{Nodes: map[string]*models.CallNode{}}
+	outputPath := filepath.Join(t.TempDir(), "callgraph.html")
+
+	err := GenerateCallGraphHTML(graph, outputPath, `{{.Missing`)
+	require.Error(t, err)
+
+	_, statErr := os.Stat(outputPath)
+	assert.True(t, os.IsNotExist(statErr), "a failed render must not leave an output file")
+}
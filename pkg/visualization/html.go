@@ -10,12 +10,52 @@ import (
 	"github.com/alexcollie/kaizen/pkg/models"
 )
 
+// Treemap sizing dimensions accepted by NewHTMLVisualizer
+const (
+	SizeByLines     = "lines"
+	SizeByFunctions = "functions"
+	SizeByHotspots  = "hotspots"
+)
+
+// sizeByLabels describes each sizing dimension in the heat map legend
+var sizeByLabels = map[string]string{
+	SizeByLines:     "lines of code",
+	SizeByFunctions: "function count",
+	SizeByHotspots:  "hotspot count",
+}
+
+// DefaultLabelThreshold is the narrowest treemap cell, in pixels, that shows its folder name
+const DefaultLabelThreshold = 50
+
 // HTMLVisualizer generates interactive HTML heat maps
-type HTMLVisualizer struct{}
+type HTMLVisualizer struct {
+	sizeBy         string
+	maxDepth       int
+	labelThreshold int
+	precision      int    // Decimal places of scores (reports.precision)
+	templateSource string // Custom html/template replacing the built-in page; empty for the built-in
+}
 
-// NewHTMLVisualizer creates a new HTML visualizer
-func NewHTMLVisualizer() *HTMLVisualizer {
-	return &HTMLVisualizer{}
+// NewHTMLVisualizer creates a new HTML visualizer whose treemap cells are sized by sizeBy
+// (lines, functions, or hotspots); an empty sizeBy sizes cells by lines of code. A positive
+// maxDepth folds folders nested deeper than maxDepth into their ancestor at that depth.
+// Cells narrower than labelThreshold pixels show no label and those under twice it a
+// shortened one; 0 uses DefaultLabelThreshold. Scores are shown with precision decimal places.
+func NewHTMLVisualizer(sizeBy string, maxDepth int, labelThreshold int, precision int) *HTMLVisualizer {
+	if sizeBy == "" {
+		sizeBy = SizeByLines
+	}
+	if labelThreshold <= 0 {
+		labelThreshold = DefaultLabelThreshold
+	}
+	return &HTMLVisualizer{sizeBy: sizeBy, maxDepth: maxDepth, labelThreshold: labelThreshold, precision: precision}
+}
+
+// SetTemplate replaces the built-in heat map page with a custom html/template, for branded
+// reports. It is executed with the same data as the built-in template; an empty source restores
+// the built-in.
+func (visualizer *HTMLVisualizer) SetTemplate(source string) {
+	visualizer.templateSource = source
 }
 
 // TreeNode represents a node in the treemap hierarchy
@@ -24,6 +64,12 @@ type TreeNode struct {
 	Value    int         `json:"value,omitempty"`
 	Children []TreeNode  `json:"children,omitempty"`
 	Metrics  TreeMetrics `json:"metrics,omitempty"`
+
+	// CollapsedFolders counts the nested folders summed into this node by the max depth
+	CollapsedFolders int `json:"collapsed_folders,omitempty"`
+
+	// codeLines weights scores when collapsed nodes are merged, whatever Value measures
+	codeLines int
 }
 
 // TreeMetrics contains all metric scores for a folder/file
@@ -31,15 +77,20 @@ type TreeMetrics struct {
 	ComplexityScore      float64 `json:"complexity_score"`
 	ChurnScore           float64 `json:"churn_score"`
 	HotspotScore         float64 `json:"hotspot_score"`
+	RiskScore            float64 `json:"risk_score"`
 	LengthScore          float64 `json:"length_score"`
 	MaintainabilityScore float64 `json:"maintainability_score"`
 	CognitiveScore       float64 `json:"cognitive_score"`
+	HotspotDensityScore  float64 `json:"hotspot_density_score"`
+	HotspotDensity       float64 `json:"hotspot_density"`       // Hotspots per KLOC
+	AverageHalsteadTime  float64 `json:"average_halstead_time"` // Seconds to understand a function
 	TotalFunctions       int     `json:"total_functions"`
 	HotspotCount         int     `json:"hotspot_count"`
 }
 
-// GenerateHTML creates an interactive HTML heat map with Nordic warm color scheme
-func (visualizer *HTMLVisualizer) GenerateHTML(result *models.AnalysisResult) (string, error) {
+// GenerateHTML creates an interactive HTML heat map with Nordic warm color scheme, or with a
+// plain white background and black text when lightTheme is set
+func (visualizer *HTMLVisualizer) GenerateHTML(result *models.AnalysisResult, lightTheme bool) (string, error) {
 	// Build tree data structure
 	treeData := visualizer.buildTreeData(result)
 
@@ -62,8 +113,15 @@ func (visualizer *HTMLVisualizer) GenerateHTML(result *models.AnalysisResult) (s
 		_ = json.Unmarshal(scoreReportJSON, &scoreReportMap)
 	}
 
-	// Render HTML template using Nordic theme
-	tmpl := template.Must(template.New("heatmap").Parse(htmlNordicTemplate))
+	// Render HTML template using Nordic theme, unless a custom template was set
+	templateSource := htmlNordicTemplate
+	if visualizer.templateSource != "" {
+		templateSource = visualizer.templateSource
+	}
+	tmpl, err := template.New("heatmap").Parse(templateSource)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
 
 	templateData := map[string]interface{}{
 		"TreeData":        template.JS(jsonData),
@@ -71,6 +129,10 @@ func (visualizer *HTMLVisualizer) GenerateHTML(result *models.AnalysisResult) (s
 		"HasScoreReport":  result.ScoreReport != nil,
 		"ScoreReportJSON": template.JS(scoreReportJSON),
 		"Repository":      result.Repository,
+		"LightTheme":      lightTheme,
+		"SizeByLabel":     sizeByLabels[visualizer.sizeBy],
+		"LabelThreshold":  visualizer.labelThreshold,
+		"ScorePrecision":  visualizer.precision,
 	}
 
 	// Add score report fields for template access
@@ -90,10 +152,25 @@ func (visualizer *HTMLVisualizer) GenerateHTML(result *models.AnalysisResult) (s
 	return builder.String(), nil
 }
 
+// GenerateTreeJSON returns the treemap hierarchy as indented JSON, without the HTML page around
+// it, for feeding into other visualization tools
+func (visualizer *HTMLVisualizer) GenerateTreeJSON(result *models.AnalysisResult) ([]byte, error) {
+	treeData := visualizer.buildTreeData(result)
+
+	jsonData, err := json.MarshalIndent(treeData, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal tree data: %w", err)
+	}
+
+	return jsonData, nil
+}
+
 // buildTreeData converts analysis results to a proper hierarchical tree structure
 func (visualizer *HTMLVisualizer) buildTreeData(result *models.AnalysisResult) TreeNode {
-	// Find leaf folders (folders that don't have children in the stats)
-	leafFolders := findLeafFolders(result.FolderStats)
+	// Fold folders below the max depth into their ancestors, then find leaf folders
+	// (folders that don't have children in the stats)
+	folderStats, collapsedCounts := collapseFoldersToDepth(result.FolderStats, visualizer.maxDepth)
+	leafFolders := findLeafFolders(folderStats)
 
 	// Build tree from leaf folders
 	root := TreeNode{
@@ -136,14 +213,20 @@ func (visualizer *HTMLVisualizer) buildTreeData(result *models.AnalysisResult) T
 
 				// If this is the leaf node, add metrics
 				if idx == len(parts)-1 {
-					newNode.Value = folder.TotalCodeLines
+					newNode.Value = visualizer.cellValue(folder)
+					newNode.codeLines = folder.TotalCodeLines
+					newNode.CollapsedFolders = collapsedCounts[path]
 					newNode.Metrics = TreeMetrics{
 						ComplexityScore:      folder.ComplexityScore,
 						ChurnScore:           folder.ChurnScore,
 						HotspotScore:         folder.HotspotScore,
+						RiskScore:            folder.RiskScore,
 						LengthScore:          folder.LengthScore,
 						MaintainabilityScore: folder.MaintainabilityScore,
 						CognitiveScore:       folder.ComplexityScore,
+						HotspotDensityScore:  folder.HotspotDensityScore,
+						HotspotDensity:       folder.HotspotDensity,
+						AverageHalsteadTime:  folder.AverageHalsteadTime,
 						TotalFunctions:       folder.TotalFunctions,
 						HotspotCount:         folder.HotspotCount,
 					}
@@ -167,6 +250,18 @@ func (visualizer *HTMLVisualizer) buildTreeData(result *models.AnalysisResult) T
 	return root
 }
 
+// cellValue returns the size of a folder's treemap cell in the configured dimension
+func (visualizer *HTMLVisualizer) cellValue(folder models.FolderMetrics) int {
+	switch visualizer.sizeBy {
+	case SizeByFunctions:
+		return folder.TotalFunctions
+	case SizeByHotspots:
+		return folder.HotspotCount
+	default:
+		return folder.TotalCodeLines
+	}
+}
+
 // findLeafFolders returns only the most specific folders (those without children)
 func findLeafFolders(folderStats map[string]models.FolderMetrics) map[string]models.FolderMetrics {
 	leafFolders := make(map[string]models.FolderMetrics)
@@ -187,6 +282,75 @@ func findLeafFolders(folderStats map[string]models.FolderMetrics) map[string]mod
 	return leafFolders
 }
 
+// collapseFoldersToDepth merges every folder nested deeper than maxDepth into its ancestor at
+// maxDepth, so those ancestors become leaves. It returns the merged stats and, per ancestor,
+// how many nested folders were merged into it. A maxDepth of 0 or less leaves the stats as is.
+func collapseFoldersToDepth(folderStats map[string]models.FolderMetrics, maxDepth int) (map[string]models.FolderMetrics, map[string]int) {
+	if maxDepth <= 0 {
+		return folderStats, nil
+	}
+
+	collapsedStats := make(map[string]models.FolderMetrics, len(folderStats))
+	collapsedCounts := make(map[string]int)
+
+	for path, folder := range folderStats {
+		parts := strings.Split(path, "/")
+		target := path
+		if len(parts) > maxDepth {
+			target = strings.Join(parts[:maxDepth], "/")
+			collapsedCounts[target]++
+		}
+
+		existing, found := collapsedStats[target]
+		if !found {
+			existing = models.FolderMetrics{Path: target}
+		}
+		collapsedStats[target] = mergeFolderMetrics(existing, folder)
+	}
+
+	return collapsedStats, collapsedCounts
+}
+
+// mergeFolderMetrics sums source into target for collapsed folders, keeping target's path.
+// Counts are summed and the treemap's scores are averaged weighted by code lines.
+func mergeFolderMetrics(target, source models.FolderMetrics) models.FolderMetrics {
+	totalWeight := float64(target.TotalCodeLines + source.TotalCodeLines)
+	if target.TotalFiles == 0 || totalWeight == 0 {
+		merged := source
+		merged.Path = target.Path
+		merged.TotalFiles += target.TotalFiles
+		merged.TotalFunctions += target.TotalFunctions
+		merged.HotspotCount += target.HotspotCount
+		merged.ConcernCount += target.ConcernCount
+		return merged
+	}
+
+	weightedScore := func(targetScore, sourceScore float64) float64 {
+		return (targetScore*float64(target.TotalCodeLines) + sourceScore*float64(source.TotalCodeLines)) / totalWeight
+	}
+
+	return models.FolderMetrics{
+		Path:                 target.Path,
+		TotalFiles:           target.TotalFiles + source.TotalFiles,
+		TotalFunctions:       target.TotalFunctions + source.TotalFunctions,
+		TotalLines:           target.TotalLines + source.TotalLines,
+		TotalCodeLines:       target.TotalCodeLines + source.TotalCodeLines,
+		TotalChurn:           target.TotalChurn + source.TotalChurn,
+		AverageHalsteadTime:  weightedScore(target.AverageHalsteadTime, source.AverageHalsteadTime),
+		ComplexityScore:      weightedScore(target.ComplexityScore, source.ComplexityScore),
+		ChurnScore:           weightedScore(target.ChurnScore, source.ChurnScore),
+		LengthScore:          weightedScore(target.LengthScore, source.LengthScore),
+		MaintainabilityScore: weightedScore(target.MaintainabilityScore, source.MaintainabilityScore),
+		HotspotScore:         weightedScore(target.HotspotScore, source.HotspotScore),
+		RiskScore:            weightedScore(target.RiskScore, source.RiskScore),
+		HotspotCount:         target.HotspotCount + source.HotspotCount,
+		ConcernCount:         target.ConcernCount + source.ConcernCount,
+		HotspotDensity:       weightedScore(target.HotspotDensity, source.HotspotDensity),
+		ConcernDensity:       weightedScore(target.ConcernDensity, source.ConcernDensity),
+		HotspotDensityScore:  weightedScore(target.HotspotDensityScore, source.HotspotDensityScore),
+	}
+}
+
 // sortPaths sorts paths so shorter paths come first (parents before children)
 func sortPaths(paths []string) {
 	sort.Slice(paths, func(i, j int) bool {
@@ -207,20 +371,55 @@ func collapseSingleChildren(node TreeNode) TreeNode {
 		node.Children[idx] = collapseSingleChildren(node.Children[idx])
 	}
 
-	// If this node has exactly one child and no value of its own, merge with child
-	if len(node.Children) == 1 && node.Value == 0 {
+	// If this node has exactly one child, merge with child, keeping any size
+	// and metrics the parent holds itself
+	if len(node.Children) == 1 {
 		child := node.Children[0]
 		return TreeNode{
-			Name:     node.Name + "/" + child.Name,
-			Value:    child.Value,
-			Children: child.Children,
-			Metrics:  child.Metrics,
+			Name:      node.Name + "/" + child.Name,
+			Value:     node.Value + child.Value,
+			Children:  child.Children,
+			Metrics:   mergeTreeMetrics(node.Metrics, node.codeLines, child.Metrics, child.codeLines),
+			codeLines: node.codeLines + child.codeLines,
+
+			CollapsedFolders: node.CollapsedFolders + child.CollapsedFolders,
 		}
 	}
 
 	return node
 }
 
+// mergeTreeMetrics combines the metrics of two collapsed nodes.
+// Scores are averaged weighted by code lines, counts are summed.
+func mergeTreeMetrics(parent TreeMetrics, parentWeight int, child TreeMetrics, childWeight int) TreeMetrics {
+	totalWeight := float64(parentWeight + childWeight)
+	if parentWeight == 0 || totalWeight == 0 {
+		merged := child
+		merged.TotalFunctions += parent.TotalFunctions
+		merged.HotspotCount += parent.HotspotCount
+		return merged
+	}
+
+	weightedScore := func(parentScore, childScore float64) float64 {
+		return (parentScore*float64(parentWeight) + childScore*float64(childWeight)) / totalWeight
+	}
+
+	return TreeMetrics{
+		ComplexityScore:      weightedScore(parent.ComplexityScore, child.ComplexityScore),
+		ChurnScore:           weightedScore(parent.ChurnScore, child.ChurnScore),
+		HotspotScore:         weightedScore(parent.HotspotScore, child.HotspotScore),
+		RiskScore:            weightedScore(parent.RiskScore, child.RiskScore),
+		LengthScore:          weightedScore(parent.LengthScore, child.LengthScore),
+		MaintainabilityScore: weightedScore(parent.MaintainabilityScore, child.MaintainabilityScore),
+		CognitiveScore:       weightedScore(parent.CognitiveScore, child.CognitiveScore),
+		HotspotDensityScore:  weightedScore(parent.HotspotDensityScore, child.HotspotDensityScore),
+		HotspotDensity:       weightedScore(parent.HotspotDensity, child.HotspotDensity),
+		AverageHalsteadTime:  weightedScore(parent.AverageHalsteadTime, child.AverageHalsteadTime),
+		TotalFunctions:       parent.TotalFunctions + child.TotalFunctions,
+		HotspotCount:         parent.HotspotCount + child.HotspotCount,
+	}
+}
+
 // getShortName extracts the last component of a path
 func getShortName(path string) string {
 	parts := strings.Split(path, "/")
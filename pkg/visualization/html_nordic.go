@@ -537,6 +537,12 @@ const htmlNordicTemplate = `<!DOCTYPE html>
             box-shadow: 0 2px 4px rgba(201, 112, 100, 0.15);
         }
 
+        .concern-more {
+            padding: 6px 12px;
+            color: var(--text-muted);
+            font-size: 0.85em;
+        }
+
         /* Responsive */
         @media (max-width: 768px) {
             body {
@@ -564,9 +570,85 @@ const htmlNordicTemplate = `<!DOCTYPE html>
                 height: 500px;
             }
         }
+
+        /* Light theme (--theme=light): plain white background and black text */
+        body.theme-light {
+            --bg-primary: #FFFFFF;
+            --bg-secondary: #F4F4F4;
+            --bg-surface: #FFFFFF;
+            --text-primary: #000000;
+            --text-secondary: #333333;
+            --text-muted: #555555;
+            --shadow-sm: none;
+            --shadow-md: none;
+            --shadow-lg: none;
+        }
+
+        body.theme-light .header,
+        body.theme-light .visualization-section,
+        body.theme-light .concerns-panel {
+            border: 1px solid #DDDDDD;
+        }
+
+        /* Print and PDF export: light colors, no controls, nothing split across pages */
+        @media print {
+            @page {
+                margin: 1.5cm;
+            }
+
+            :root {
+                --bg-primary: #FFFFFF;
+                --bg-secondary: #FFFFFF;
+                --bg-surface: #FFFFFF;
+                --text-primary: #000000;
+                --text-secondary: #333333;
+                --text-muted: #555555;
+                --shadow-sm: none;
+                --shadow-md: none;
+                --shadow-lg: none;
+            }
+
+            * {
+                -webkit-print-color-adjust: exact;
+                print-color-adjust: exact;
+            }
+
+            body {
+                padding: 0;
+            }
+
+            .container {
+                max-width: none;
+            }
+
+            .metric-selector,
+            .breadcrumb,
+            .tooltip {
+                display: none;
+            }
+
+            .header,
+            .visualization-section,
+            .concern-item,
+            .component-score {
+                break-inside: avoid;
+            }
+
+            /* The treemap scales with its viewBox, so it fits the page instead of being clipped */
+            #treemap {
+                height: auto;
+                overflow: visible;
+                box-shadow: none;
+            }
+
+            #treemap svg {
+                width: 100%;
+                height: auto;
+            }
+        }
     </style>
 </head>
-<body>
+<body{{if .LightTheme}} class="theme-light"{{end}}>
     <div class="container">
         <!-- Header -->
         <div class="header">
@@ -577,7 +659,7 @@ const htmlNordicTemplate = `<!DOCTYPE html>
                 {{if .HasScoreReport}}
                 <div class="grade-circle grade-{{.OverallGrade}}">
                     <div class="grade-letter">{{.OverallGrade}}</div>
-                    <div class="grade-score">{{printf "%.0f" .OverallScore}}/100</div>
+                    <div class="grade-score">{{printf "%.*f" .ScorePrecision .OverallScore}}/100</div>
                 </div>
                 {{end}}
 
@@ -616,6 +698,8 @@ const htmlNordicTemplate = `<!DOCTYPE html>
                     <button class="metric-btn" data-metric="maintainability">✨ Maintainability</button>
                     <button class="metric-btn" data-metric="length">📏 Function Size</button>
                     <button class="metric-btn" data-metric="churn">📊 Churn</button>
+                    <button class="metric-btn" data-metric="hotspot_density">🎯 Hotspot Density</button>
+                    <button class="metric-btn" data-metric="risk">🚨 Risk</button>
                 </div>
 
                 <div class="breadcrumb" id="breadcrumb">
@@ -643,6 +727,7 @@ const htmlNordicTemplate = `<!DOCTYPE html>
                         <span class="legend-label">Poor (0-39)</span>
                     </div>
                 </div>
+                <span class="legend-label">Cell size: {{.SizeByLabel}}</span>
             </div>
 
             <div id="treemap"></div>
@@ -664,6 +749,11 @@ const htmlNordicTemplate = `<!DOCTYPE html>
     <script>
         // Data
         const treeData = {{.TreeData}};
+        const repositoryRoot = {{.Repository}};
+        // Narrowest cell, in pixels, that shows its name (--label-threshold)
+        const labelThreshold = {{.LabelThreshold}};
+        // Decimal places of scores (reports.precision)
+        const scorePrecision = {{.ScorePrecision}};
         {{if .HasScoreReport}}
         const scoreReport = {{.ScoreReportJSON}};
         {{end}}
@@ -673,8 +763,9 @@ const htmlNordicTemplate = `<!DOCTYPE html>
         let fullRoot = treeData;
         let currentMetric = 'hotspot';
 
-        // Initialize
-        renderTreemap(currentRoot, currentMetric);
+        // Initialize, restoring the metric and folder from the URL hash of a shared link
+        applyHash();
+        window.addEventListener('hashchange', applyHash);
         {{if .HasScoreReport}}
         renderComponentScores();
         renderConcerns();
@@ -703,7 +794,7 @@ const htmlNordicTemplate = `<!DOCTYPE html>
                     '<div class="component-bar">' +
                     '<div class="component-bar-fill ' + rating + '" style="width: ' + comp.score + '%"></div>' +
                     '</div>' +
-                    '<div class="component-value">' + Math.round(comp.score) + '/100</div>' +
+                    '<div class="component-value">' + comp.score.toFixed(scorePrecision) + '/100</div>' +
                     '</div>';
             }).join('');
         }
@@ -711,13 +802,57 @@ const htmlNordicTemplate = `<!DOCTYPE html>
         // Metric selector
         document.querySelectorAll('.metric-btn').forEach(btn => {
             btn.addEventListener('click', () => {
-                document.querySelectorAll('.metric-btn').forEach(b => b.classList.remove('active'));
-                btn.classList.add('active');
-                currentMetric = btn.dataset.metric;
+                selectMetric(btn.dataset.metric);
                 renderTreemap(currentRoot, currentMetric);
+                writeHash();
             });
         });
 
+        function selectMetric(metric) {
+            currentMetric = metric;
+            document.querySelectorAll('.metric-btn').forEach(b => {
+                b.classList.toggle('active', b.dataset.metric === metric);
+            });
+        }
+
+        // Show node as the treemap root and record it in the URL hash
+        function zoomTo(node) {
+            currentRoot = node;
+            updateBreadcrumb(node);
+            renderTreemap(node, currentMetric);
+            writeHash();
+        }
+
+        // URL hash, e.g. #metric=complexity&path=pkg/analyzer; defaults are left out
+        function writeHash() {
+            const parts = [];
+            if (currentMetric !== 'hotspot') {
+                parts.push('metric=' + encodeURIComponent(currentMetric));
+            }
+            const path = nodePath(currentRoot);
+            if (path !== '') {
+                parts.push('path=' + encodeURIComponent(path).replace(/%2F/g, '/'));
+            }
+            const hash = parts.length > 0 ? '#' + parts.join('&') : '';
+            if (hash !== window.location.hash) {
+                history.replaceState(null, '', hash || window.location.pathname + window.location.search);
+            }
+        }
+
+        // Restore the metric and zoomed folder from the URL hash; unknown values fall back to
+        // the defaults
+        function applyHash() {
+            const params = new URLSearchParams(window.location.hash.replace(/^#/, ''));
+            const metric = params.get('metric') || 'hotspot';
+            const known = Array.from(document.querySelectorAll('.metric-btn')).some(b => b.dataset.metric === metric);
+            selectMetric(known ? metric : 'hotspot');
+
+            const path = params.get('path') || '';
+            currentRoot = path === '' ? fullRoot : (findNodeByPath(fullRoot, path) || fullRoot);
+            updateBreadcrumb(currentRoot);
+            renderTreemap(currentRoot, currentMetric);
+        }
+
         // Color scale - Nordic warm colors
         function getColor(value) {
             // Invert for maintainability (higher is better)
@@ -743,7 +878,8 @@ const htmlNordicTemplate = `<!DOCTYPE html>
             const svg = d3.select(container)
                 .append('svg')
                 .attr('width', width)
-                .attr('height', height);
+                .attr('height', height)
+                .attr('viewBox', '0 0 ' + width + ' ' + height);
 
             const treemap = d3.treemap()
                 .size([width, height])
@@ -762,11 +898,11 @@ const htmlNordicTemplate = `<!DOCTYPE html>
                 .append('g')
                 .attr('transform', d => 'translate(' + d.x0 + ',' + d.y0 + ')');
 
-            // Rectangles
+            // Rectangles, at least a pixel across so even the smallest cell shows a tooltip
             cells.append('rect')
                 .attr('class', 'cell')
-                .attr('width', d => d.x1 - d.x0)
-                .attr('height', d => d.y1 - d.y0)
+                .attr('width', d => Math.max(1, d.x1 - d.x0))
+                .attr('height', d => Math.max(1, d.y1 - d.y0))
                 .attr('fill', d => {
                     const metrics = d.data.metrics || {};
                     let value = metrics[metric + '_score'] || 0;
@@ -774,9 +910,7 @@ const htmlNordicTemplate = `<!DOCTYPE html>
                 })
                 .on('click', (event, d) => {
                     if (d.data.children && d.data.children.length > 0) {
-                        currentRoot = d.data;
-                        updateBreadcrumb(d.data);
-                        renderTreemap(d.data, currentMetric);
+                        zoomTo(d.data);
                     }
                 })
                 .on('mouseover', (event, d) => showTooltip(event, d))
@@ -790,8 +924,8 @@ const htmlNordicTemplate = `<!DOCTYPE html>
                 .text(d => {
                     const width = d.x1 - d.x0;
                     const name = d.data.name;
-                    if (width < 50) return '';
-                    if (width < 100) return name.substring(0, 8) + '...';
+                    if (width < labelThreshold) return '';
+                    if (width < labelThreshold * 2) return name.substring(0, 8) + '...';
                     return name;
                 })
                 .attr('font-size', d => {
@@ -810,11 +944,19 @@ const htmlNordicTemplate = `<!DOCTYPE html>
             const metrics = d.data.metrics || {};
 
             let html = '<div class="tooltip-title">' + d.data.name + '</div>';
+            if (d.data.collapsed_folders > 0) {
+                html += '<div class="tooltip-metric"><span class="tooltip-label">📦 Collapsed aggregate:</span><span class="tooltip-value">' + d.data.collapsed_folders + ' nested folder' + (d.data.collapsed_folders === 1 ? '' : 's') + '</span></div>';
+            }
             html += '<div class="tooltip-metric"><span class="tooltip-label">Functions:</span><span class="tooltip-value">' + (metrics.total_functions || 0) + '</span></div>';
-            html += '<div class="tooltip-metric"><span class="tooltip-label">Complexity:</span><span class="tooltip-value">' + (metrics.complexity_score || 0).toFixed(1) + '</span></div>';
-            html += '<div class="tooltip-metric"><span class="tooltip-label">Maintainability:</span><span class="tooltip-value">' + (metrics.maintainability_score || 0).toFixed(1) + '</span></div>';
+            html += '<div class="tooltip-metric"><span class="tooltip-label">Complexity:</span><span class="tooltip-value">' + (metrics.complexity_score || 0).toFixed(scorePrecision) + '</span></div>';
+            html += '<div class="tooltip-metric"><span class="tooltip-label">Maintainability:</span><span class="tooltip-value">' + (metrics.maintainability_score || 0).toFixed(scorePrecision) + '</span></div>';
+            html += '<div class="tooltip-metric"><span class="tooltip-label">Risk:</span><span class="tooltip-value">' + (metrics.risk_score || 0).toFixed(scorePrecision) + '</span></div>';
+            if (metrics.average_halstead_time > 0) {
+                html += '<div class="tooltip-metric"><span class="tooltip-label">⏱️ Time to understand:</span><span class="tooltip-value">' + formatDuration(metrics.average_halstead_time) + '</span></div>';
+            }
             if (metrics.hotspot_count > 0) {
                 html += '<div class="tooltip-metric"><span class="tooltip-label">🔥 Hotspots:</span><span class="tooltip-value">' + metrics.hotspot_count + '</span></div>';
+                html += '<div class="tooltip-metric"><span class="tooltip-label">🎯 Per KLOC:</span><span class="tooltip-value">' + (metrics.hotspot_density || 0).toFixed(2) + '</span></div>';
             }
 
             tooltip.innerHTML = html;
@@ -823,21 +965,36 @@ const htmlNordicTemplate = `<!DOCTYPE html>
             tooltip.style.top = (event.pageY + 10) + 'px';
         }
 
+        // Format seconds as a short human-readable duration
+        function formatDuration(seconds) {
+            if (seconds < 60) return Math.round(seconds) + 's';
+            if (seconds < 3600) return (seconds / 60).toFixed(1) + 'm';
+            return (seconds / 3600).toFixed(1) + 'h';
+        }
+
         function hideTooltip() {
             document.getElementById('tooltip').classList.remove('visible');
         }
 
-        // Breadcrumb
-        function updateBreadcrumb(node) {
-            // Build path
-            const path = [];
+        // Names from the root down to node
+        function nodeNames(node) {
+            const names = [];
             let current = node;
 
             while (current && current !== fullRoot) {
-                path.unshift(current.name);
-                // Find parent (simplified)
+                names.unshift(current.name);
                 current = findParent(fullRoot, current);
             }
+            return names;
+        }
+
+        function nodePath(node) {
+            return nodeNames(node).join('/');
+        }
+
+        // Breadcrumb
+        function updateBreadcrumb(node) {
+            const path = nodeNames(node);
 
             const breadcrumb = document.getElementById('breadcrumb');
             breadcrumb.innerHTML = '<span class="breadcrumb-item" data-path="">🏠 Root</span>';
@@ -851,13 +1008,7 @@ const htmlNordicTemplate = `<!DOCTYPE html>
             document.querySelectorAll('.breadcrumb-item').forEach(item => {
                 item.addEventListener('click', () => {
                     const path = item.dataset.path;
-                    if (path === '') {
-                        currentRoot = fullRoot;
-                    } else {
-                        currentRoot = findNodeByPath(fullRoot, path);
-                    }
-                    renderTreemap(currentRoot, currentMetric);
-                    updateBreadcrumb(currentRoot);
+                    zoomTo(path === '' ? fullRoot : (findNodeByPath(fullRoot, path) || fullRoot));
                 });
             });
         }
@@ -872,14 +1023,17 @@ const htmlNordicTemplate = `<!DOCTYPE html>
             return null;
         }
 
+        // Find the node at a slash-separated path of names, or null. Collapsed single-child
+        // folders have names containing slashes, so names are matched as path prefixes.
         function findNodeByPath(root, path) {
-            const parts = path.split('/');
             let current = root;
+            let rest = path;
 
-            for (const part of parts) {
-                if (!current.children) return current;
-                current = current.children.find(c => c.name === part);
-                if (!current) return root;
+            while (rest !== '') {
+                const child = (current.children || []).find(c => rest === c.name || rest.startsWith(c.name + '/'));
+                if (!child) return null;
+                current = child;
+                rest = rest.substring(child.name.length).replace(/^\//, '');
             }
 
             return current;
@@ -904,10 +1058,15 @@ const htmlNordicTemplate = `<!DOCTYPE html>
                         '<div class="concern-files">' + concern.affected_items.map(item => {
                             const displayName = item.function_name || item.file_path;
                             const location = item.line ? item.file_path + ':' + item.line : item.file_path;
-                            return '<a href="vscode://file/' + location + '" class="concern-file" title="' + JSON.stringify(item.metrics || {}) + '">' +
-                                '📄 ' + location + (item.function_name ? ' → ' + item.function_name : '') +
+                            const lineRange = item.end_line > item.line ? '-' + item.end_line : '';
+                            const absolutePath = item.file_path.startsWith('/') || !repositoryRoot ? item.file_path : repositoryRoot + '/' + item.file_path;
+                            const editorLocation = item.line ? absolutePath + ':' + item.line : absolutePath;
+                            return '<a href="vscode://file/' + editorLocation + '" class="concern-file" title="' + JSON.stringify(item.metrics || {}) + '">' +
+                                '📄 ' + location + lineRange + (item.function_name ? ' → ' + item.function_name : '') +
                                 '</a>';
-                        }).join('') + '</div>'
+                        }).join('') +
+                        (concern.omitted_items > 0 ? '<div class="concern-more">… and ' + concern.omitted_items + ' more</div>' : '') +
+                        '</div>'
                     : '') +
                 '</div>';
             }).join('');
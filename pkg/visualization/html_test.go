@@ -11,19 +11,19 @@ import (
 )
 
 func TestNewHTMLVisualizer(t *testing.T) {
-	visualizer := NewHTMLVisualizer()
+	visualizer := NewHTMLVisualizer(SizeByLines, 0, 0, 1)
 
 	assert.NotNil(t, visualizer)
 }
 
 func TestGenerateHTMLEmpty(t *testing.T) {
-	visualizer := NewHTMLVisualizer()
+	visualizer := NewHTMLVisualizer(SizeByLines, 0, 0, 1)
 
 	result := &models.AnalysisResult{
 		Files: []models.FileAnalysis{},
 	}
 
-	html, err := visualizer.GenerateHTML(result)
+	html, err := visualizer.GenerateHTML(result, false)
 
 	require.NoError(t, err)
 	assert.NotEmpty(t, html)
@@ -31,8 +31,38 @@ func TestGenerateHTMLEmpty(t *testing.T) {
 	assert.Contains(t, html, "d3")
 }
 
+func TestGenerateHTMLTheme(t *testing.T) {
+	visualizer := NewHTMLVisualizer(SizeByLines, 0, 0, 1)
+	result := &models.AnalysisResult{Files: []models.FileAnalysis{}}
+
+	html, err := visualizer.GenerateHTML(result, false)
+	require.NoError(t, err)
+	assert.Contains(t, html, "@media print")
+	assert.NotContains(t, html, `<body class="theme-light">`)
+
+	html, err = visualizer.GenerateHTML(result, true)
+	require.NoError(t, err)
+	assert.Contains(t, html, `<body class="theme-light">`)
+}
+
+func TestGenerateHTMLCustomTemplate(t *testing.T) {
+	visualizer := NewHTMLVisualizer(SizeByLines, 0, 0, 1)
+	visualizer.SetTemplate(`<h1>Acme {{.Summary.TotalFiles}} files</h1><script>const tree = {{.TreeData}};</script>`)
+	result := &models.AnalysisResult{Summary: models.SummaryMetrics{TotalFiles: 3}}
+
+	html, err := visualizer.GenerateHTML(result, false)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(html, "<h1>Acme 3 files</h1>"))
+	assert.Contains(t, html, `const tree = {"name":`)
+	assert.NotContains(t, html, "<!DOCTYPE html>")
+
+	visualizer.SetTemplate("{{.Summary")
+	_, err = visualizer.GenerateHTML(result, false)
+	assert.Error(t, err, "an unparseable template should be reported, not panic")
+}
+
 func TestGenerateHTMLWithData(t *testing.T) {
-	visualizer := NewHTMLVisualizer()
+	visualizer := NewHTMLVisualizer(SizeByLines, 0, 0, 1)
 
 	result := &models.AnalysisResult{
 		Summary: models.SummaryMetrics{
@@ -55,7 +85,7 @@ func TestGenerateHTMLWithData(t *testing.T) {
 		},
 	}
 
-	html, err := visualizer.GenerateHTML(result)
+	html, err := visualizer.GenerateHTML(result, false)
 
 	require.NoError(t, err)
 	assert.NotEmpty(t, html)
@@ -65,7 +95,7 @@ func TestGenerateHTMLWithData(t *testing.T) {
 }
 
 func TestGenerateHTMLWithScoreReport(t *testing.T) {
-	visualizer := NewHTMLVisualizer()
+	visualizer := NewHTMLVisualizer(SizeByLines, 0, 0, 1)
 
 	result := &models.AnalysisResult{
 		Summary: models.SummaryMetrics{
@@ -78,7 +108,7 @@ func TestGenerateHTMLWithScoreReport(t *testing.T) {
 		},
 	}
 
-	html, err := visualizer.GenerateHTML(result)
+	html, err := visualizer.GenerateHTML(result, false)
 
 	require.NoError(t, err)
 	assert.NotEmpty(t, html)
@@ -87,13 +117,13 @@ func TestGenerateHTMLWithScoreReport(t *testing.T) {
 }
 
 func TestGenerateHTMLContainsD3(t *testing.T) {
-	visualizer := NewHTMLVisualizer()
+	visualizer := NewHTMLVisualizer(SizeByLines, 0, 0, 1)
 
 	result := &models.AnalysisResult{
 		Files: []models.FileAnalysis{},
 	}
 
-	html, err := visualizer.GenerateHTML(result)
+	html, err := visualizer.GenerateHTML(result, false)
 
 	require.NoError(t, err)
 	// D3 library should be included
@@ -102,21 +132,53 @@ func TestGenerateHTMLContainsD3(t *testing.T) {
 }
 
 func TestGenerateHTMLContainsTreemap(t *testing.T) {
-	visualizer := NewHTMLVisualizer()
+	visualizer := NewHTMLVisualizer(SizeByLines, 0, 0, 1)
 
 	result := &models.AnalysisResult{
 		Files: []models.FileAnalysis{},
 	}
 
-	html, err := visualizer.GenerateHTML(result)
+	html, err := visualizer.GenerateHTML(result, false)
 
 	require.NoError(t, err)
 	// Should contain treemap-related content
 	assert.Contains(t, html, "treemap")
 }
 
+func TestGenerateHTMLDeepLinking(t *testing.T) {
+	html, err := NewHTMLVisualizer(SizeByLines, 0, 0, 1).GenerateHTML(&models.AnalysisResult{}, false)
+
+	require.NoError(t, err)
+	// The metric and zoomed folder are restored from and written to the URL hash
+	assert.Contains(t, html, "window.addEventListener('hashchange', applyHash)")
+	assert.Contains(t, html, "function zoomTo(node)")
+	assert.Contains(t, html, "history.replaceState")
+}
+
+func TestGenerateHTMLLabelThreshold(t *testing.T) {
+	html, err := NewHTMLVisualizer(SizeByLines, 0, 30, 1).GenerateHTML(&models.AnalysisResult{}, false)
+
+	require.NoError(t, err)
+	assert.Regexp(t, `const labelThreshold = +30 *;`, html)
+
+	html, err = NewHTMLVisualizer(SizeByLines, 0, 0, 1).GenerateHTML(&models.AnalysisResult{}, false)
+
+	require.NoError(t, err)
+	assert.Regexp(t, `const labelThreshold = +50 *;`, html, "0 falls back to the default threshold")
+}
+
+func TestGenerateHTMLScorePrecision(t *testing.T) {
+	result := &models.AnalysisResult{ScoreReport: &models.ScoreReport{OverallGrade: "B", OverallScore: 78.4217}}
+
+	html, err := NewHTMLVisualizer(SizeByLines, 0, 0, 2).GenerateHTML(result, false)
+
+	require.NoError(t, err)
+	assert.Contains(t, html, "78.42/100")
+	assert.Regexp(t, `const scorePrecision = +2 *;`, html)
+}
+
 func TestGenerateHTMLIsValidHTML(t *testing.T) {
-	visualizer := NewHTMLVisualizer()
+	visualizer := NewHTMLVisualizer(SizeByLines, 0, 0, 1)
 
 	result := &models.AnalysisResult{
 		Summary: models.SummaryMetrics{
@@ -131,7 +193,7 @@ func TestGenerateHTMLIsValidHTML(t *testing.T) {
 		},
 	}
 
-	html, err := visualizer.GenerateHTML(result)
+	html, err := visualizer.GenerateHTML(result, false)
 
 	require.NoError(t, err)
 
@@ -144,7 +206,7 @@ func TestGenerateHTMLIsValidHTML(t *testing.T) {
 }
 
 func TestGenerateHTMLMultipleFiles(t *testing.T) {
-	visualizer := NewHTMLVisualizer()
+	visualizer := NewHTMLVisualizer(SizeByLines, 0, 0, 1)
 
 	result := &models.AnalysisResult{
 		Summary: models.SummaryMetrics{
@@ -180,7 +242,7 @@ func TestGenerateHTMLMultipleFiles(t *testing.T) {
 		},
 	}
 
-	html, err := visualizer.GenerateHTML(result)
+	html, err := visualizer.GenerateHTML(result, false)
 
 	require.NoError(t, err)
 	assert.NotEmpty(t, html)
@@ -210,7 +272,7 @@ func TestTreeNodeJSON(t *testing.T) {
 }
 
 func TestGenerateHTMLWithNilScoreReport(t *testing.T) {
-	visualizer := NewHTMLVisualizer()
+	visualizer := NewHTMLVisualizer(SizeByLines, 0, 0, 1)
 
 	result := &models.AnalysisResult{
 		Summary: models.SummaryMetrics{
@@ -226,20 +288,20 @@ func TestGenerateHTMLWithNilScoreReport(t *testing.T) {
 		ScoreReport: nil,
 	}
 
-	html, err := visualizer.GenerateHTML(result)
+	html, err := visualizer.GenerateHTML(result, false)
 
 	require.NoError(t, err)
 	assert.NotEmpty(t, html)
 }
 
 func TestGenerateHTMLContainsNordicTheme(t *testing.T) {
-	visualizer := NewHTMLVisualizer()
+	visualizer := NewHTMLVisualizer(SizeByLines, 0, 0, 1)
 
 	result := &models.AnalysisResult{
 		Files: []models.FileAnalysis{},
 	}
 
-	html, err := visualizer.GenerateHTML(result)
+	html, err := visualizer.GenerateHTML(result, false)
 
 	require.NoError(t, err)
 	// Should reference Nordic theme elements (warm colors, etc.)
@@ -251,7 +313,7 @@ func TestGenerateHTMLContainsNordicTheme(t *testing.T) {
 }
 
 func TestGenerateHTMLMetricsPresent(t *testing.T) {
-	visualizer := NewHTMLVisualizer()
+	visualizer := NewHTMLVisualizer(SizeByLines, 0, 0, 1)
 
 	result := &models.AnalysisResult{
 		Summary: models.SummaryMetrics{
@@ -263,7 +325,7 @@ func TestGenerateHTMLMetricsPresent(t *testing.T) {
 		Files: []models.FileAnalysis{},
 	}
 
-	html, err := visualizer.GenerateHTML(result)
+	html, err := visualizer.GenerateHTML(result, false)
 
 	require.NoError(t, err)
 	// Should include metrics data
@@ -271,21 +333,21 @@ func TestGenerateHTMLMetricsPresent(t *testing.T) {
 }
 
 func TestGenerateHTMLRepositoryInfo(t *testing.T) {
-	visualizer := NewHTMLVisualizer()
+	visualizer := NewHTMLVisualizer(SizeByLines, 0, 0, 1)
 
 	result := &models.AnalysisResult{
 		Repository: "github.com/example/project",
 		Files:      []models.FileAnalysis{},
 	}
 
-	html, err := visualizer.GenerateHTML(result)
+	html, err := visualizer.GenerateHTML(result, false)
 
 	require.NoError(t, err)
 	assert.NotEmpty(t, html)
 }
 
 func TestHTMLVisualizerWithComplexStructure(t *testing.T) {
-	visualizer := NewHTMLVisualizer()
+	visualizer := NewHTMLVisualizer(SizeByLines, 0, 0, 1)
 
 	result := &models.AnalysisResult{
 		Summary: models.SummaryMetrics{
@@ -355,9 +417,203 @@ func TestHTMLVisualizerWithComplexStructure(t *testing.T) {
 		},
 	}
 
-	html, err := visualizer.GenerateHTML(result)
+	html, err := visualizer.GenerateHTML(result, false)
 
 	require.NoError(t, err)
 	assert.NotEmpty(t, html)
 	assert.Greater(t, len(html), 5000)
 }
+
+func TestCollapseSingleChildrenWithoutParentValue(t *testing.T) {
+	root := TreeNode{
+		Name: "pkg",
+		Children: []TreeNode{
+			{
+				Name:    "api",
+				Value:   100,
+				Metrics: TreeMetrics{ComplexityScore: 40, TotalFunctions: 4, HotspotCount: 1},
+			},
+		},
+	}
+
+	collapsed := collapseSingleChildren(root)
+
+	assert.Equal(t, "pkg/api", collapsed.Name)
+	assert.Equal(t, 100, collapsed.Value)
+	assert.Equal(t, 40.0, collapsed.Metrics.ComplexityScore)
+	assert.Equal(t, 4, collapsed.Metrics.TotalFunctions)
+	assert.Equal(t, 1, collapsed.Metrics.HotspotCount)
+}
+
+func TestCollapseSingleChildrenMixedValue(t *testing.T) {
+	root := TreeNode{
+		Name:      "pkg",
+		Value:     100,
+		codeLines: 100,
+		Metrics:   TreeMetrics{ComplexityScore: 20, MaintainabilityScore: 80, TotalFunctions: 5, HotspotCount: 1},
+		Children: []TreeNode{
+			{
+				Name:      "api",
+				Value:     300,
+				codeLines: 300,
+				Metrics:   TreeMetrics{ComplexityScore: 60, MaintainabilityScore: 40, TotalFunctions: 10, HotspotCount: 2},
+			},
+		},
+	}
+
+	collapsed := collapseSingleChildren(root)
+
+	assert.Equal(t, "pkg/api", collapsed.Name)
+	assert.Equal(t, 400, collapsed.Value)
+	assert.Empty(t, collapsed.Children)
+	assert.InDelta(t, 50.0, collapsed.Metrics.ComplexityScore, 0.001)
+	assert.InDelta(t, 50.0, collapsed.Metrics.MaintainabilityScore, 0.001)
+	assert.Equal(t, 15, collapsed.Metrics.TotalFunctions)
+	assert.Equal(t, 3, collapsed.Metrics.HotspotCount)
+}
+
+func TestCollapseSingleChildrenChain(t *testing.T) {
+	root := TreeNode{
+		Name:  "a",
+		Value: 50,
+		Children: []TreeNode{
+			{
+				Name: "b",
+				Children: []TreeNode{
+					{Name: "c", Value: 10},
+					{Name: "d", Value: 20},
+				},
+			},
+		},
+	}
+
+	collapsed := collapseSingleChildren(root)
+
+	assert.Equal(t, "a/b", collapsed.Name)
+	assert.Equal(t, 50, collapsed.Value)
+	assert.Len(t, collapsed.Children, 2)
+}
+
+func TestBuildTreeDataSizeBy(t *testing.T) {
+	result := &models.AnalysisResult{
+		Repository: "/repo",
+		FolderStats: map[string]models.FolderMetrics{
+			"pkg/api": {Path: "pkg/api", TotalCodeLines: 400, TotalFunctions: 12, HotspotCount: 3},
+			"pkg/db":  {Path: "pkg/db", TotalCodeLines: 900, TotalFunctions: 4, HotspotCount: 0},
+		},
+	}
+
+	cases := []struct {
+		sizeBy   string
+		expected map[string]int
+	}{
+		{sizeBy: SizeByLines, expected: map[string]int{"api": 400, "db": 900}},
+		{sizeBy: SizeByFunctions, expected: map[string]int{"api": 12, "db": 4}},
+		{sizeBy: SizeByHotspots, expected: map[string]int{"api": 3, "db": 0}},
+		{sizeBy: "", expected: map[string]int{"api": 400, "db": 900}},
+	}
+
+	for _, testCase := range cases {
+		tree := NewHTMLVisualizer(testCase.sizeBy, 0, 0, 1).buildTreeData(result)
+		require.Equal(t, "repo/pkg", tree.Name)
+
+		values := map[string]int{}
+		for _, child := range tree.Children {
+			values[child.Name] = child.Value
+		}
+		assert.Equal(t, testCase.expected, values, "size by %q", testCase.sizeBy)
+	}
+}
+
+func TestCollapseSingleChildrenWeightsByCodeLines(t *testing.T) {
+	root := TreeNode{
+		Name:      "pkg",
+		Value:     1,
+		codeLines: 300,
+		Metrics:   TreeMetrics{ComplexityScore: 20},
+		Children: []TreeNode{
+			{Name: "api", Value: 3, codeLines: 100, Metrics: TreeMetrics{ComplexityScore: 60}},
+		},
+	}
+
+	collapsed := collapseSingleChildren(root)
+
+	assert.Equal(t, 4, collapsed.Value)
+	assert.InDelta(t, 30.0, collapsed.Metrics.ComplexityScore, 0.001)
+}
+
+func TestGenerateHTMLSizeByLabel(t *testing.T) {
+	result := &models.AnalysisResult{FolderStats: map[string]models.FolderMetrics{}}
+
+	html, err := NewHTMLVisualizer(SizeByHotspots, 0, 0, 1).GenerateHTML(result, false)
+	require.NoError(t, err)
+	assert.Contains(t, html, "Cell size: hotspot count")
+}
+
+func TestGenerateTreeJSON(t *testing.T) {
+	result := &models.AnalysisResult{
+		Repository: "/repo",
+		FolderStats: map[string]models.FolderMetrics{
+			"pkg/api": {Path: "pkg/api", TotalCodeLines: 400, TotalFunctions: 12, HotspotCount: 3},
+			"pkg/db":  {Path: "pkg/db", TotalCodeLines: 900, TotalFunctions: 4, HotspotCount: 0},
+		},
+	}
+
+	jsonData, err := NewHTMLVisualizer(SizeByFunctions, 0, 0, 1).GenerateTreeJSON(result)
+	require.NoError(t, err)
+	assert.NotContains(t, string(jsonData), "<html")
+
+	var tree TreeNode
+	require.NoError(t, json.Unmarshal(jsonData, &tree))
+	assert.Equal(t, "repo/pkg", tree.Name)
+
+	values := map[string]int{}
+	for _, child := range tree.Children {
+		values[child.Name] = child.Value
+	}
+	assert.Equal(t, map[string]int{"api": 12, "db": 4}, values)
+}
+
+func TestBuildTreeDataMaxDepth(t *testing.T) {
+	result := &models.AnalysisResult{
+		Repository: "/repo",
+		FolderStats: map[string]models.FolderMetrics{
+			"pkg/api":             {Path: "pkg/api", TotalFiles: 2, TotalCodeLines: 100, TotalFunctions: 5, ComplexityScore: 10},
+			"pkg/api/handlers":    {Path: "pkg/api/handlers", TotalFiles: 3, TotalCodeLines: 300, TotalFunctions: 9, HotspotCount: 2, ComplexityScore: 50},
+			"pkg/api/handlers/v2": {Path: "pkg/api/handlers/v2", TotalFiles: 1, TotalCodeLines: 100, TotalFunctions: 1, ComplexityScore: 90},
+			"pkg/db":              {Path: "pkg/db", TotalFiles: 1, TotalCodeLines: 200, TotalFunctions: 4, ComplexityScore: 20},
+		},
+	}
+
+	tree := NewHTMLVisualizer(SizeByLines, 2, 0, 1).buildTreeData(result)
+	require.Equal(t, "repo/pkg", tree.Name)
+	require.Len(t, tree.Children, 2)
+
+	nodes := map[string]TreeNode{}
+	for _, child := range tree.Children {
+		nodes[child.Name] = child
+	}
+
+	api := nodes["api"]
+	assert.Empty(t, api.Children, "folders below the max depth should be folded into their ancestor")
+	assert.Equal(t, 500, api.Value)
+	assert.Equal(t, 15, api.Metrics.TotalFunctions)
+	assert.Equal(t, 2, api.Metrics.HotspotCount)
+	assert.InDelta(t, 50.0, api.Metrics.ComplexityScore, 0.001) // (10*100 + 50*300 + 90*100) / 500
+	assert.Equal(t, 2, api.CollapsedFolders)
+
+	db := nodes["db"]
+	assert.Equal(t, 200, db.Value)
+	assert.Zero(t, db.CollapsedFolders)
+
+	unlimited := NewHTMLVisualizer(SizeByLines, 0, 0, 1).buildTreeData(result)
+	assert.Equal(t, 0, countCollapsedFolders(unlimited))
+}
+
+func countCollapsedFolders(node TreeNode) int {
+	total := node.CollapsedFolders
+	for _, child := range node.Children {
+		total += countCollapsedFolders(child)
+	}
+	return total
+}
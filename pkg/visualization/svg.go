@@ -5,18 +5,21 @@ import (
 	"math"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/alexcollie/kaizen/pkg/reports"
 )
 
 // SVGVisualizer generates SVG heat maps
 type SVGVisualizer struct {
-	width  int
-	height int
+	width     int
+	height    int
+	precision int // Decimal places of scores (reports.precision)
 }
 
-// NewSVGVisualizer creates a new SVG visualizer
-func NewSVGVisualizer(width, height int) *SVGVisualizer {
+// NewSVGVisualizer creates a new SVG visualizer showing scores with precision decimal places
+func NewSVGVisualizer(width, height, precision int) *SVGVisualizer {
 	if width == 0 {
 		width = 1200
 	}
@@ -24,8 +27,9 @@ func NewSVGVisualizer(width, height int) *SVGVisualizer {
 		height = 800
 	}
 	return &SVGVisualizer{
-		width:  width,
-		height: height,
+		width:     width,
+		height:    height,
+		precision: precision,
 	}
 }
 
@@ -41,10 +45,17 @@ type Rectangle struct {
 	Color  string
 }
 
-// GenerateSVG creates an SVG treemap visualization
+// legendGradientID identifies the legend's color gradient within the SVG
+const legendGradientID = "score-gradient"
+
+// GenerateSVG creates an SVG treemap visualization. The header names the repository, metric, and
+// when the analysis ran and the SVG was generated, and the legend maps colors to scores, so the
+// file reads on its own when shared without the HTML report.
 func (visualizer *SVGVisualizer) GenerateSVG(result *models.AnalysisResult, metric string) (string, error) {
 	// Build rectangles from folder metrics
 	rectangles := visualizer.buildTreemap(result.FolderStats, metric)
+	repositoryName := getShortName(result.Repository)
+	generatedAt := time.Now().Format("2006-01-02 15:04")
 
 	// Generate SVG
 	var builder strings.Builder
@@ -55,10 +66,10 @@ func (visualizer *SVGVisualizer) GenerateSVG(result *models.AnalysisResult, metr
 `, visualizer.width, visualizer.height, visualizer.width, visualizer.height))
 
 	// Title and metadata
-	builder.WriteString(`  <title>Kaizen Code Heat Map</title>
-  <desc>Code quality heat map generated by Kaizen</desc>
+	builder.WriteString(fmt.Sprintf(`  <title>Kaizen Code Heat Map: %s - %s</title>
+  <desc>Code quality heat map of %s by %s, generated by Kaizen at %s</desc>
 
-`)
+`, escapeXML(repositoryName), escapeXML(metricTitle(metric)), escapeXML(repositoryName), escapeXML(metricTitle(metric)), generatedAt))
 
 	// Define styles
 	builder.WriteString(`  <defs>
@@ -87,9 +98,16 @@ func (visualizer *SVGVisualizer) GenerateSVG(result *models.AnalysisResult, metr
           font-size: 14px;
           fill: #999;
         }
+        .meta-text {
+          font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif;
+          font-size: 11px;
+          fill: #777;
+        }
       ]]>
     </style>
-  </defs>
+`)
+	builder.WriteString(visualizer.generateLegendGradient())
+	builder.WriteString(`  </defs>
 
 `)
 
@@ -102,13 +120,16 @@ func (visualizer *SVGVisualizer) GenerateSVG(result *models.AnalysisResult, metr
 	headerHeight := 80
 	builder.WriteString(fmt.Sprintf(`  <!-- Header -->
   <text x="%d" y="30" class="title-text" text-anchor="middle">Kaizen Code Heat Map</text>
-  <text x="%d" y="55" class="subtitle-text" text-anchor="middle">Metric: %s | Files: %d | Functions: %d</text>
+  <text x="%d" y="52" class="subtitle-text" text-anchor="middle">Repository: %s | Metric: %s | Files: %d | Functions: %d</text>
+  <text x="%d" y="70" class="meta-text" text-anchor="middle">Analyzed %s | Generated %s</text>
 
-`, visualizer.width/2, visualizer.width/2, metricTitle(metric), result.Summary.TotalFiles, result.Summary.TotalFunctions))
+`, visualizer.width/2,
+		visualizer.width/2, escapeXML(repositoryName), escapeXML(metricTitle(metric)), result.Summary.TotalFiles, result.Summary.TotalFunctions,
+		visualizer.width/2, result.AnalyzedAt.Format("2006-01-02 15:04"), generatedAt))
 
 	// Legend
 	legendY := visualizer.height - 50
-	builder.WriteString(visualizer.generateLegend(legendY))
+	builder.WriteString(visualizer.generateLegend(legendY, metric))
 
 	// Draw rectangles
 	treemapHeight := visualizer.height - headerHeight - 80
@@ -218,9 +239,9 @@ func (visualizer *SVGVisualizer) drawRectangle(rect Rectangle, maxHeight float64
 	builder.WriteString(fmt.Sprintf(`    <rect class="folder-rect" x="%.2f" y="%.2f" width="%.2f" height="%.2f" fill="%s">
       <title>%s
 Lines: %d
-Score: %.1f/100</title>
+Score: %s/100</title>
     </rect>
-`, rect.X, y, rect.Width, height, rect.Color, rect.Label, rect.Value, rect.Score))
+`, rect.X, y, rect.Width, height, rect.Color, rect.Label, rect.Value, reports.FormatScore(rect.Score, visualizer.precision)))
 
 	// Draw label if rectangle is large enough
 	if rect.Width > 60 && height > 25 {
@@ -241,38 +262,54 @@ Score: %.1f/100</title>
 	return builder.String()
 }
 
-// generateLegend generates the color scale legend
-func (visualizer *SVGVisualizer) generateLegend(yPosition int) string {
+// generateLegendGradient defines the legend's gradient, with a stop at each color of the score scale
+func (visualizer *SVGVisualizer) generateLegendGradient() string {
 	var builder strings.Builder
 
-	legendWidth := 300
-	legendHeight := 20
-	legendX := (visualizer.width - legendWidth) / 2
+	builder.WriteString(fmt.Sprintf(`    <linearGradient id="%s" x1="0%%" y1="0%%" x2="100%%" y2="0%%">
+`, legendGradientID))
+	for _, score := range []float64{0, 33, 67, 100} {
+		builder.WriteString(fmt.Sprintf(`      <stop offset="%.0f%%" stop-color="%s"/>
+`, score, visualizer.getColorForScore(score)))
+	}
+	builder.WriteString(`    </linearGradient>
+`)
 
-	builder.WriteString(fmt.Sprintf(`  <!-- Legend -->
-  <text x="%d" y="%d" class="subtitle-text" text-anchor="middle">Color Scale</text>
-`, visualizer.width/2, yPosition-10))
+	return builder.String()
+}
 
-	// Gradient legend
-	numSteps := 50
-	stepWidth := float64(legendWidth) / float64(numSteps)
+// generateLegend generates the color scale legend: the metric, a gradient bar with score ticks,
+// and what low and high scores mean
+func (visualizer *SVGVisualizer) generateLegend(yPosition int, metric string) string {
+	var builder strings.Builder
 
-	for step := 0; step < numSteps; step++ {
-		score := (float64(step) / float64(numSteps)) * 100
-		color := visualizer.getColorForScore(score)
-		x := float64(legendX) + float64(step)*stepWidth
+	legendWidth := 400
+	legendHeight := 14
+	legendX := (visualizer.width - legendWidth) / 2
+	tickY := yPosition + legendHeight + 12
+	labelY := yPosition + legendHeight + 26
 
-		builder.WriteString(fmt.Sprintf(`  <rect x="%.2f" y="%d" width="%.2f" height="%d" fill="%s" stroke="none"/>
-`, x, yPosition, stepWidth+1, legendHeight, color))
-	}
+	builder.WriteString(fmt.Sprintf(`  <!-- Legend -->
+  <text x="%d" y="%d" class="subtitle-text" text-anchor="middle">%s score (0-100)</text>
+  <rect x="%d" y="%d" width="%d" height="%d" fill="url(#%s)" stroke="none"/>
+`, visualizer.width/2, yPosition-10, escapeXML(metricTitle(metric)),
+		legendX, yPosition, legendWidth, legendHeight, legendGradientID))
+
+	// Score ticks under the bar
+	builder.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" class="meta-text" text-anchor="start">0</text>
+  <text x="%d" y="%d" class="meta-text" text-anchor="middle">50</text>
+  <text x="%d" y="%d" class="meta-text" text-anchor="end">100</text>
+`, legendX, tickY,
+		visualizer.width/2, tickY,
+		legendX+legendWidth, tickY))
 
 	// Legend labels
 	builder.WriteString(fmt.Sprintf(`  <text x="%d" y="%d" class="subtitle-text" text-anchor="start">Low (Good)</text>
   <text x="%d" y="%d" class="subtitle-text" text-anchor="middle">Medium</text>
   <text x="%d" y="%d" class="subtitle-text" text-anchor="end">High (Needs Attention)</text>
-`, legendX, yPosition+legendHeight+15,
-		visualizer.width/2, yPosition+legendHeight+15,
-		legendX+legendWidth, yPosition+legendHeight+15))
+`, legendX, labelY,
+		visualizer.width/2, labelY,
+		legendX+legendWidth, labelY))
 
 	return builder.String()
 }
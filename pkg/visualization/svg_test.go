@@ -0,0 +1,40 @@
+package visualization
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateSVGIsSelfDescribing(t *testing.T) {
+	result := &models.AnalysisResult{
+		Repository: "/home/dev/projects/shop & co",
+		AnalyzedAt: time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC),
+		FolderStats: map[string]models.FolderMetrics{
+			"pkg/api": {Path: "pkg/api", TotalCodeLines: 120, AverageComplexity: 6},
+		},
+		Summary: models.SummaryMetrics{TotalFiles: 3, TotalFunctions: 12},
+	}
+
+	svg, err := NewSVGVisualizer(0, 0, 1).GenerateSVG(result, "complexity")
+	require.NoError(t, err)
+
+	assert.NoError(t, xml.Unmarshal([]byte(svg), new(struct{})), "SVG must be well-formed XML")
+	assert.Contains(t, svg, "<title>Kaizen Code Heat Map: shop &amp; co - Cyclomatic Complexity</title>")
+	assert.Contains(t, svg, "Repository: shop &amp; co | Metric: Cyclomatic Complexity | Files: 3 | Functions: 12")
+	assert.Contains(t, svg, "Analyzed 2026-03-01 09:30 | Generated ")
+
+	// Legend: a gradient bar with score ticks and low/medium/high labels
+	assert.Contains(t, svg, `<linearGradient id="score-gradient"`)
+	assert.Equal(t, 4, strings.Count(svg, "<stop "))
+	assert.Contains(t, svg, `fill="url(#score-gradient)"`)
+	assert.Contains(t, svg, "Cyclomatic Complexity score (0-100)")
+	for _, label := range []string{">0<", ">50<", ">100<", "Low (Good)", "Medium", "High (Needs Attention)"} {
+		assert.Contains(t, svg, label)
+	}
+}
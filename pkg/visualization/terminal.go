@@ -9,22 +9,26 @@ import (
 	"golang.org/x/text/language"
 
 	"github.com/alexcollie/kaizen/pkg/models"
+	"github.com/alexcollie/kaizen/pkg/reports"
 	"github.com/fatih/color"
 )
 
 // TerminalVisualizer generates colored terminal output
 type TerminalVisualizer struct {
-	green  *color.Color
-	yellow *color.Color
-	red    *color.Color
+	green     *color.Color
+	yellow    *color.Color
+	red       *color.Color
+	precision int // Decimal places of scores (reports.precision)
 }
 
-// NewTerminalVisualizer creates a new terminal visualizer
-func NewTerminalVisualizer() *TerminalVisualizer {
+// NewTerminalVisualizer creates a new terminal visualizer showing scores with precision
+// decimal places
+func NewTerminalVisualizer(precision int) *TerminalVisualizer {
 	return &TerminalVisualizer{
-		green:  color.New(color.FgGreen),
-		yellow: color.New(color.FgYellow),
-		red:    color.New(color.FgRed),
+		green:     color.New(color.FgGreen),
+		yellow:    color.New(color.FgYellow),
+		red:       color.New(color.FgRed),
+		precision: precision,
 	}
 }
 
@@ -79,7 +83,7 @@ func (visualizer *TerminalVisualizer) renderFolderRow(builder *strings.Builder,
 	colorFunc := visualizer.getColorForScore(score)
 
 	// Format score
-	scoreStr := fmt.Sprintf("%.1f", score)
+	scoreStr := reports.FormatScore(score, visualizer.precision)
 
 	// Print colored line
 	_, _ = colorFunc.Fprintf(builder, "%s %s %s", paddedPath, bar, scoreStr)
@@ -222,6 +226,10 @@ func metricTitle(metric string) string {
 		return "Function Length"
 	case "maintainability":
 		return "Maintainability Index"
+	case "hotspot_density":
+		return "Hotspot Density (per KLOC)"
+	case "risk":
+		return "Risk (Churn + Complexity + Coverage)"
 	default:
 		return cases.Title(language.English).String(metric)
 	}
@@ -241,6 +249,10 @@ func getMetricScore(folder models.FolderMetrics, metric string) float64 {
 		return folder.LengthScore
 	case "maintainability":
 		return folder.MaintainabilityScore
+	case "hotspot_density":
+		return folder.HotspotDensityScore
+	case "risk":
+		return folder.RiskScore
 	default:
 		return folder.HotspotScore
 	}